@@ -37,6 +37,30 @@ const (
 	DefaultAzureBastionSubnetName = "AzureBastionSubnet"
 	// DefaultAzureBastionSubnetRole is the default Subnet role for AzureBastion.
 	DefaultAzureBastionSubnetRole = SubnetBastion
+	// DefaultAzureFirewallSubnetCIDR is the default Subnet CIDR for AzureFirewall.
+	DefaultAzureFirewallSubnetCIDR = "10.255.255.128/26"
+	// DefaultAzureFirewallSubnetName is the default Subnet Name for AzureFirewall.
+	// Azure requires the firewall subnet to have this name.
+	DefaultAzureFirewallSubnetName = "AzureFirewallSubnet"
+	// DefaultAzureFirewallSubnetRole is the default Subnet role for AzureFirewall.
+	DefaultAzureFirewallSubnetRole = SubnetFirewall
+	// DefaultVirtualNetworkGatewaySubnetCIDR is the default Subnet CIDR for VirtualNetworkGateway.
+	DefaultVirtualNetworkGatewaySubnetCIDR = "10.255.255.192/27"
+	// DefaultVirtualNetworkGatewaySubnetName is the default Subnet Name for VirtualNetworkGateway.
+	// Azure requires the gateway subnet to have this name.
+	DefaultVirtualNetworkGatewaySubnetName = "GatewaySubnet"
+	// DefaultVirtualNetworkGatewaySubnetRole is the default Subnet role for VirtualNetworkGateway.
+	DefaultVirtualNetworkGatewaySubnetRole = SubnetGateway
+	// DefaultApplicationGatewaySubnetCIDR is the default Subnet CIDR for ApplicationGateway.
+	DefaultApplicationGatewaySubnetCIDR = "10.255.254.0/24"
+	// DefaultApplicationGatewaySubnetName is the default Subnet Name for ApplicationGateway.
+	DefaultApplicationGatewaySubnetName = "ApplicationGatewaySubnet"
+	// DefaultApplicationGatewaySubnetRole is the default Subnet role for ApplicationGateway.
+	DefaultApplicationGatewaySubnetRole = SubnetApplicationGateway
+	// DefaultVpnGatewaySKU is the default SKU of a VPN VirtualNetworkGateway.
+	DefaultVpnGatewaySKU = "VpnGw1AZ"
+	// DefaultExpressRouteGatewaySKU is the default SKU of an ExpressRoute VirtualNetworkGateway.
+	DefaultExpressRouteGatewaySKU = "ErGw1AZ"
 	// DefaultInternalLBIPAddress is the default internal load balancer ip address.
 	DefaultInternalLBIPAddress = "10.0.0.100"
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
@@ -54,7 +78,11 @@ func (c *AzureCluster) setDefaults() {
 func (c *AzureCluster) setNetworkSpecDefaults() {
 	c.setVnetDefaults()
 	c.setBastionDefaults()
+	c.setAzureFirewallDefaults()
+	c.setVirtualNetworkGatewayDefaults()
+	c.setApplicationGatewayDefaults()
 	c.setSubnetDefaults()
+	c.setAPIServerPrivateLinkServiceDefaults()
 	c.setVnetPeeringDefaults()
 	c.setAPIServerLBDefaults()
 	c.SetNodeOutboundLBDefaults()
@@ -97,7 +125,7 @@ func (c *AzureCluster) setSubnetDefaults() {
 	cpSubnet.SubnetClassSpec.setDefaults(DefaultControlPlaneSubnetCIDR)
 
 	if cpSubnet.SecurityGroup.Name == "" {
-		cpSubnet.SecurityGroup.Name = generateControlPlaneSecurityGroupName(c.ObjectMeta.Name)
+		cpSubnet.SecurityGroup.Name = c.Spec.NamingConvention.ResourceName(SecurityGroupNamingResourceType, generateControlPlaneSecurityGroupName(c.ObjectMeta.Name))
 	}
 	cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults()
 
@@ -117,15 +145,15 @@ func (c *AzureCluster) setSubnetDefaults() {
 		subnet.SubnetClassSpec.setDefaults(fmt.Sprintf(DefaultNodeSubnetCIDRPattern, nodeSubnetCounter))
 
 		if subnet.SecurityGroup.Name == "" {
-			subnet.SecurityGroup.Name = generateNodeSecurityGroupName(c.ObjectMeta.Name)
+			subnet.SecurityGroup.Name = c.Spec.NamingConvention.ResourceName(SecurityGroupNamingResourceType, generateNodeSecurityGroupName(c.ObjectMeta.Name))
 		}
 		cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults()
 
 		if subnet.RouteTable.Name == "" {
-			subnet.RouteTable.Name = generateNodeRouteTableName(c.ObjectMeta.Name)
+			subnet.RouteTable.Name = c.Spec.NamingConvention.ResourceName(RouteTableNamingResourceType, generateNodeRouteTableName(c.ObjectMeta.Name))
 		}
 
-		if !subnet.IsIPv6Enabled() {
+		if !subnet.IsIPv6Enabled() && !c.Spec.NetworkSpec.IsUserDefinedRouting() {
 			// NAT gateway supports the use of IPv4 public IP addresses for outbound connectivity.
 			// So default use the NAT gateway for outbound traffic in IPv4 cluster instead of loadbalancer.
 			if subnet.NatGateway.Name == "" {
@@ -147,16 +175,18 @@ func (c *AzureCluster) setSubnetDefaults() {
 				Name:       generateNodeSubnetName(c.ObjectMeta.Name),
 			},
 			SecurityGroup: SecurityGroup{
-				Name: generateNodeSecurityGroupName(c.ObjectMeta.Name),
+				Name: c.Spec.NamingConvention.ResourceName(SecurityGroupNamingResourceType, generateNodeSecurityGroupName(c.ObjectMeta.Name)),
 			},
 			RouteTable: RouteTable{
-				Name: generateNodeRouteTableName(c.ObjectMeta.Name),
+				Name: c.Spec.NamingConvention.ResourceName(RouteTableNamingResourceType, generateNodeRouteTableName(c.ObjectMeta.Name)),
 			},
-			NatGateway: NatGateway{
+		}
+		if !c.Spec.NetworkSpec.IsUserDefinedRouting() {
+			nodeSubnet.NatGateway = NatGateway{
 				NatGatewayClassSpec: NatGatewayClassSpec{
 					Name: generateNatGatewayName(c.ObjectMeta.Name),
 				},
-			},
+			}
 		}
 		c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, nodeSubnet)
 	}
@@ -177,7 +207,7 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 
 	if lb.Type == Public {
 		if lb.Name == "" {
-			lb.Name = generatePublicLBName(c.ObjectMeta.Name)
+			lb.Name = c.Spec.NamingConvention.ResourceName(LoadBalancerNamingResourceType, generatePublicLBName(c.ObjectMeta.Name))
 		}
 		if len(lb.FrontendIPs) == 0 {
 			lb.FrontendIPs = []FrontendIP{
@@ -191,7 +221,7 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 		}
 	} else if lb.Type == Internal {
 		if lb.Name == "" {
-			lb.Name = generateInternalLBName(c.ObjectMeta.Name)
+			lb.Name = c.Spec.NamingConvention.ResourceName(LoadBalancerNamingResourceType, generateInternalLBName(c.ObjectMeta.Name))
 		}
 		if len(lb.FrontendIPs) == 0 {
 			lb.FrontendIPs = []FrontendIP{
@@ -210,7 +240,7 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 // SetNodeOutboundLBDefaults sets the default values for the NodeOutboundLB.
 func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	if c.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.NetworkSpec.IsUserDefinedRouting() {
 			return
 		}
 
@@ -233,8 +263,15 @@ func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	}
 
 	lb := c.Spec.NetworkSpec.NodeOutboundLB
+	// No load balancer is created for the nodes when they share the API server load balancer or have no outbound LB.
+	if !lb.IsDedicated() {
+		return
+	}
+
 	lb.LoadBalancerClassSpec.setNodeOutboundLBDefaults()
 
+	// The node outbound LB is named after the cluster so that the cloud provider uses it for the LoadBalancer
+	// services, so the naming convention doesn't apply to it.
 	if lb.Name == "" {
 		lb.Name = c.ObjectMeta.Name
 	}
@@ -257,7 +294,7 @@ func (c *AzureCluster) SetControlPlaneOutboundLBDefaults() {
 
 	lb.LoadBalancerClassSpec.setControlPlaneOutboundLBDefaults()
 	if lb.Name == "" {
-		lb.Name = generateControlPlaneOutboundLBName(c.ObjectMeta.Name)
+		lb.Name = c.Spec.NamingConvention.ResourceName(LoadBalancerNamingResourceType, generateControlPlaneOutboundLBName(c.ObjectMeta.Name))
 	}
 	if lb.FrontendIPsCount == nil {
 		lb.FrontendIPsCount = pointer.Int32(1)
@@ -284,7 +321,7 @@ func (c *AzureCluster) SetAPIServerLBBackendPoolNameDefault() {
 // SetNodeOutboundLBBackendPoolNameDefault defaults the name of the backend pool for node outbound LB.
 func (c *AzureCluster) SetNodeOutboundLBBackendPoolNameDefault() {
 	nodeOutboundLB := c.Spec.NetworkSpec.NodeOutboundLB
-	if nodeOutboundLB != nil && nodeOutboundLB.BackendPool.Name == "" {
+	if nodeOutboundLB != nil && nodeOutboundLB.IsDedicated() && nodeOutboundLB.BackendPool.Name == "" {
 		nodeOutboundLB.BackendPool.Name = generateOutboundBackendAddressPoolName(nodeOutboundLB.Name)
 	}
 }
@@ -347,6 +384,115 @@ func (c *AzureCluster) setBastionDefaults() {
 	}
 }
 
+func (c *AzureCluster) setAzureFirewallDefaults() {
+	firewall := c.Spec.NetworkSpec.AzureFirewall
+	if firewall == nil {
+		return
+	}
+	// Node egress goes through the firewall, so neither NAT gateways nor a node outbound load balancer should be defaulted.
+	if c.Spec.NetworkSpec.OutboundType == "" {
+		c.Spec.NetworkSpec.OutboundType = UserDefinedRoutingOutboundType
+	}
+	if firewall.Name == "" {
+		firewall.Name = generateAzureFirewallName(c.ObjectMeta.Name)
+	}
+	if firewall.SKUTier == "" {
+		firewall.SKUTier = StandardAzureFirewallSKUTier
+	}
+	// Ensure defaults for the Subnet settings.
+	if firewall.Subnet.Name == "" {
+		firewall.Subnet.Name = DefaultAzureFirewallSubnetName
+	}
+	if len(firewall.Subnet.CIDRBlocks) == 0 {
+		firewall.Subnet.CIDRBlocks = []string{DefaultAzureFirewallSubnetCIDR}
+	}
+	if firewall.Subnet.Role == "" {
+		firewall.Subnet.Role = DefaultAzureFirewallSubnetRole
+	}
+	// Ensure defaults for the PublicIP settings.
+	if firewall.PublicIP.Name == "" {
+		firewall.PublicIP.Name = generateAzureFirewallPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setVirtualNetworkGatewayDefaults() {
+	gateway := c.Spec.NetworkSpec.VirtualNetworkGateway
+	if gateway == nil {
+		return
+	}
+	if gateway.Name == "" {
+		gateway.Name = generateVirtualNetworkGatewayName(c.ObjectMeta.Name)
+	}
+	if gateway.GatewayType == "" {
+		gateway.GatewayType = VpnVirtualNetworkGatewayType
+	}
+	if gateway.SKU == "" {
+		gateway.SKU = DefaultVpnGatewaySKU
+		if gateway.GatewayType == ExpressRouteVirtualNetworkGatewayType {
+			gateway.SKU = DefaultExpressRouteGatewaySKU
+		}
+	}
+	// Ensure defaults for the Subnet settings.
+	if gateway.Subnet.Name == "" {
+		gateway.Subnet.Name = DefaultVirtualNetworkGatewaySubnetName
+	}
+	if len(gateway.Subnet.CIDRBlocks) == 0 {
+		gateway.Subnet.CIDRBlocks = []string{DefaultVirtualNetworkGatewaySubnetCIDR}
+	}
+	if gateway.Subnet.Role == "" {
+		gateway.Subnet.Role = DefaultVirtualNetworkGatewaySubnetRole
+	}
+	// Ensure defaults for the PublicIP settings.
+	if gateway.PublicIP.Name == "" {
+		gateway.PublicIP.Name = generateVirtualNetworkGatewayPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setApplicationGatewayDefaults() {
+	gateway := c.Spec.ApplicationGateway
+	if gateway == nil {
+		return
+	}
+	if gateway.Name == "" {
+		gateway.Name = generateApplicationGatewayName(c.ObjectMeta.Name)
+	}
+	if gateway.SKUTier == "" {
+		gateway.SKUTier = StandardV2ApplicationGatewaySKUTier
+	}
+	if gateway.SKUTier == WAFV2ApplicationGatewaySKUTier && gateway.FirewallMode == "" {
+		gateway.FirewallMode = PreventionApplicationGatewayFirewallMode
+	}
+	// Ensure defaults for the Subnet settings.
+	if gateway.Subnet.Name == "" {
+		gateway.Subnet.Name = DefaultApplicationGatewaySubnetName
+	}
+	if len(gateway.Subnet.CIDRBlocks) == 0 {
+		gateway.Subnet.CIDRBlocks = []string{DefaultApplicationGatewaySubnetCIDR}
+	}
+	if gateway.Subnet.Role == "" {
+		gateway.Subnet.Role = DefaultApplicationGatewaySubnetRole
+	}
+	// Ensure defaults for the PublicIP settings.
+	if gateway.PublicIP.Name == "" {
+		gateway.PublicIP.Name = generateApplicationGatewayPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setAPIServerPrivateLinkServiceDefaults() {
+	pls := c.Spec.NetworkSpec.APIServerPrivateLinkService
+	if pls == nil {
+		return
+	}
+	if pls.Name == "" {
+		pls.Name = generatePrivateLinkServiceName(c.ObjectMeta.Name)
+	}
+	if pls.SubnetName == "" {
+		if subnet, err := c.Spec.NetworkSpec.GetControlPlaneSubnet(); err == nil {
+			pls.SubnetName = subnet.Name
+		}
+	}
+}
+
 func (lb *LoadBalancerClassSpec) setAPIServerLBDefaults() {
 	if lb.Type == "" {
 		lb.Type = Public
@@ -419,6 +565,41 @@ func generateAzureBastionPublicIPName(clusterName string) string {
 	return fmt.Sprintf("%s-azure-bastion-pip", clusterName)
 }
 
+// generateAzureFirewallName generates an azure firewall name.
+func generateAzureFirewallName(clusterName string) string {
+	return fmt.Sprintf("%s-azure-firewall", clusterName)
+}
+
+// generateAzureFirewallPublicIPName generates an azure firewall public ip name.
+func generateAzureFirewallPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-azure-firewall-pip", clusterName)
+}
+
+// generateVirtualNetworkGatewayName generates a virtual network gateway name.
+func generateVirtualNetworkGatewayName(clusterName string) string {
+	return fmt.Sprintf("%s-vnet-gateway", clusterName)
+}
+
+// generateApplicationGatewayName generates an application gateway name.
+func generateApplicationGatewayName(clusterName string) string {
+	return fmt.Sprintf("%s-appgw", clusterName)
+}
+
+// generateApplicationGatewayPublicIPName generates an application gateway public ip name.
+func generateApplicationGatewayPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-appgw-pip", clusterName)
+}
+
+// generatePrivateLinkServiceName generates the name of the private link service of the API server.
+func generatePrivateLinkServiceName(clusterName string) string {
+	return fmt.Sprintf("%s-apiserver-pls", clusterName)
+}
+
+// generateVirtualNetworkGatewayPublicIPName generates a virtual network gateway public ip name.
+func generateVirtualNetworkGatewayPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-vnet-gateway-pip", clusterName)
+}
+
 // generateControlPlaneSecurityGroupName generates a control plane security group name, based on the cluster name.
 func generateControlPlaneSecurityGroupName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "controlplane-nsg")
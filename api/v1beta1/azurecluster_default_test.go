@@ -309,6 +309,101 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no subnets with user defined routing",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{OutboundType: UserDefinedRoutingOutboundType},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{OutboundType: UserDefinedRoutingOutboundType},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{DefaultNodeSubnetCIDR},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "node subnet with user defined routing",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{OutboundType: UserDefinedRoutingOutboundType},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetNode,
+									Name: "my-node-subnet",
+								},
+								RouteTable: RouteTable{Name: "my-firewall-routetable"},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{OutboundType: UserDefinedRoutingOutboundType},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{DefaultNodeSubnetCIDR},
+									Name:       "my-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "my-firewall-routetable"},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "subnets with custom attributes",
 			cluster: &AzureCluster{
@@ -910,6 +1005,56 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "lb with naming convention",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{},
+					NamingConvention: &NamingConvention{
+						Templates: map[NamingResourceType]string{
+							LoadBalancerNamingResourceType: "lb-{name}-westeurope",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							Name: "lb-cluster-test-public-lb-westeurope",
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "lb-cluster-test-public-lb-westeurope-frontEnd",
+									PublicIP: &PublicIPSpec{
+										Name:    "pip-cluster-test-apiserver",
+										DNSName: "",
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "lb-cluster-test-public-lb-westeurope-backendPool",
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+					NamingConvention: &NamingConvention{
+						Templates: map[NamingResourceType]string{
+							LoadBalancerNamingResourceType: "lb-{name}-westeurope",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "internal lb",
 			cluster: &AzureCluster{
@@ -1752,6 +1897,35 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no defaults for node outbound lb shared with the api server lb",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						NodeOutboundLB: &LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeShared},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						NodeOutboundLB: &LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeShared},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -2179,3 +2353,454 @@ func TestBastionDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureFirewallDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no firewall set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"azure firewall enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						AzureFirewall: &AzureFirewall{},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						AzureFirewall: &AzureFirewall{
+							Name: "foo-azure-firewall",
+							Subnet: SubnetSpec{
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{DefaultAzureFirewallSubnetCIDR},
+									Role:       DefaultAzureFirewallSubnetRole,
+									Name:       "AzureFirewallSubnet",
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "foo-azure-firewall-pip",
+							},
+							SKUTier: StandardAzureFirewallSKUTier,
+						},
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: UserDefinedRoutingOutboundType,
+						},
+					},
+				},
+			},
+		},
+		"azure firewall enabled with settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						AzureFirewall: &AzureFirewall{
+							Name: "my-firewall",
+							Subnet: SubnetSpec{
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/26"},
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "my-firewall-pip",
+							},
+							SKUTier: PremiumAzureFirewallSKUTier,
+						},
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: UserDefinedRoutingOutboundType,
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						AzureFirewall: &AzureFirewall{
+							Name: "my-firewall",
+							Subnet: SubnetSpec{
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/26"},
+									Role:       DefaultAzureFirewallSubnetRole,
+									Name:       "AzureFirewallSubnet",
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "my-firewall-pip",
+							},
+							SKUTier: PremiumAzureFirewallSKUTier,
+						},
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType: UserDefinedRoutingOutboundType,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setAzureFirewallDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkGatewayDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no gateway set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"gateway enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VirtualNetworkGateway: &VirtualNetworkGateway{},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VirtualNetworkGateway: &VirtualNetworkGateway{
+							Name: "foo-vnet-gateway",
+							Subnet: SubnetSpec{
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+									Role:       DefaultVirtualNetworkGatewaySubnetRole,
+									Name:       "GatewaySubnet",
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "foo-vnet-gateway-pip",
+							},
+							GatewayType: VpnVirtualNetworkGatewayType,
+							SKU:         "VpnGw1AZ",
+						},
+					},
+				},
+			},
+		},
+		"ExpressRoute gateway with settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VirtualNetworkGateway: &VirtualNetworkGateway{
+							Name: "my-gateway",
+							Subnet: SubnetSpec{
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/27"},
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "my-gateway-pip",
+							},
+							GatewayType: ExpressRouteVirtualNetworkGatewayType,
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VirtualNetworkGateway: &VirtualNetworkGateway{
+							Name: "my-gateway",
+							Subnet: SubnetSpec{
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/27"},
+									Role:       DefaultVirtualNetworkGatewaySubnetRole,
+									Name:       "GatewaySubnet",
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "my-gateway-pip",
+							},
+							GatewayType: ExpressRouteVirtualNetworkGatewayType,
+							SKU:         "ErGw1AZ",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setVirtualNetworkGatewayDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestAPIServerPrivateLinkServiceDefault(t *testing.T) {
+	subnets := Subnets{
+		{SubnetClassSpec: SubnetClassSpec{Name: "foo-node-subnet", Role: SubnetNode}},
+		{SubnetClassSpec: SubnetClassSpec{Name: "foo-controlplane-subnet", Role: SubnetControlPlane}},
+	}
+	cases := map[string]struct {
+		pls    *PrivateLinkService
+		output *PrivateLinkService
+	}{
+		"no private link service set": {
+			pls:    nil,
+			output: nil,
+		},
+		"private link service enabled with no settings": {
+			pls: &PrivateLinkService{},
+			output: &PrivateLinkService{
+				Name:       "foo-apiserver-pls",
+				SubnetName: "foo-controlplane-subnet",
+			},
+		},
+		"private link service with settings": {
+			pls: &PrivateLinkService{
+				Name:                 "my-pls",
+				SubnetName:           "foo-node-subnet",
+				AllowedSubscriptions: []string{"*"},
+			},
+			output: &PrivateLinkService{
+				Name:                 "my-pls",
+				SubnetName:           "foo-node-subnet",
+				AllowedSubscriptions: []string{"*"},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets:                     subnets,
+						APIServerPrivateLinkService: c.pls,
+					},
+				},
+			}
+			cluster.setAPIServerPrivateLinkServiceDefaults()
+			if !reflect.DeepEqual(cluster.Spec.NetworkSpec.APIServerPrivateLinkService, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(cluster.Spec.NetworkSpec.APIServerPrivateLinkService, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestApplicationGatewayDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no gateway set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"gateway enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ApplicationGateway: &ApplicationGatewaySpec{},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ApplicationGateway: &ApplicationGatewaySpec{
+						Name: "foo-appgw",
+						Subnet: SubnetSpec{
+							SubnetClassSpec: SubnetClassSpec{
+								CIDRBlocks: []string{DefaultApplicationGatewaySubnetCIDR},
+								Role:       DefaultApplicationGatewaySubnetRole,
+								Name:       "ApplicationGatewaySubnet",
+							},
+						},
+						PublicIP: PublicIPSpec{
+							Name: "foo-appgw-pip",
+						},
+						SKUTier: StandardV2ApplicationGatewaySKUTier,
+					},
+				},
+			},
+		},
+		"WAF gateway with settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ApplicationGateway: &ApplicationGatewaySpec{
+						Name: "my-appgw",
+						Subnet: SubnetSpec{
+							SubnetClassSpec: SubnetClassSpec{
+								Name:       "my-appgw-subnet",
+								CIDRBlocks: []string{"10.10.0.0/24"},
+							},
+						},
+						PublicIP: PublicIPSpec{
+							Name: "my-appgw-pip",
+						},
+						SKUTier: WAFV2ApplicationGatewaySKUTier,
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					ApplicationGateway: &ApplicationGatewaySpec{
+						Name: "my-appgw",
+						Subnet: SubnetSpec{
+							SubnetClassSpec: SubnetClassSpec{
+								Name:       "my-appgw-subnet",
+								CIDRBlocks: []string{"10.10.0.0/24"},
+								Role:       DefaultApplicationGatewaySubnetRole,
+							},
+						},
+						PublicIP: PublicIPSpec{
+							Name: "my-appgw-pip",
+						},
+						SKUTier:      WAFV2ApplicationGatewaySKUTier,
+						FirewallMode: PreventionApplicationGatewayFirewallMode,
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setApplicationGatewayDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestNamingConventionResourceName(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention *NamingConvention
+		expected   string
+	}{
+		{
+			name:     "nil naming convention",
+			expected: "cluster-test-node-nsg",
+		},
+		{
+			name: "prefix and suffix",
+			convention: &NamingConvention{
+				Prefix: "corp-",
+				Suffix: "-001",
+			},
+			expected: "corp-cluster-test-node-nsg-001",
+		},
+		{
+			name: "template takes precedence over prefix and suffix",
+			convention: &NamingConvention{
+				Prefix: "corp-",
+				Templates: map[NamingResourceType]string{
+					SecurityGroupNamingResourceType: "nsg-{name}-westeurope",
+				},
+			},
+			expected: "nsg-cluster-test-node-nsg-westeurope",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.convention.ResourceName(SecurityGroupNamingResourceType, "cluster-test-node-nsg"); got != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
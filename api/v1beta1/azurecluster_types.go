@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -28,6 +30,38 @@ const (
 
 	// ClusterLabelNamespace indicates the namespace of the cluster.
 	ClusterLabelNamespace = "azurecluster.infrastructure.cluster.x-k8s.io/cluster-namespace"
+
+	// ReconcilePolicyAnnotation is the AzureCluster and AzureMachine annotation used to set how their Azure resources
+	// are reconciled. When the annotation is absent, pre-existing resource groups and virtual networks are never
+	// managed by CAPZ, and the Azure resources managed by CAPZ are deleted with the object.
+	ReconcilePolicyAnnotation = "infrastructure.cluster.x-k8s.io/reconcile-policy"
+
+	// DeletePolicyAnnotation is the AzureCluster and AzureMachine annotation used to set what happens to their Azure
+	// resources when they are deleted. It is independent of the ReconcilePolicyAnnotation, so that adopted resources
+	// can also be left behind. When the annotation is absent, the Azure resources managed by CAPZ are deleted with the
+	// object.
+	DeletePolicyAnnotation = "infrastructure.cluster.x-k8s.io/delete-policy"
+)
+
+// ReconcilePolicy determines how pre-existing Azure resources of a cluster are reconciled.
+type ReconcilePolicy string
+
+const (
+	// ReconcilePolicyAdopt makes CAPZ take ownership of the pre-existing resource group and virtual network of the
+	// cluster when they aren't tagged for any cluster: they are tagged as owned by the cluster, and are deleted with it.
+	ReconcilePolicyAdopt ReconcilePolicy = "Adopt"
+	// ReconcilePolicySkip makes CAPZ leave the Azure resources unchanged: they are neither created, updated nor
+	// deleted, and the finalizer is removed without deleting them.
+	ReconcilePolicySkip ReconcilePolicy = "Skip"
+)
+
+// DeletePolicy determines what happens to the Azure resources of an object when it is deleted.
+type DeletePolicy string
+
+const (
+	// DeletePolicyAbandon makes CAPZ leave the Azure resources behind when the object is deleted, e.g. to move a
+	// cluster to another management cluster without destroying its infrastructure.
+	DeletePolicyAbandon DeletePolicy = "Abandon"
 )
 
 // AzureClusterSpec defines the desired state of AzureCluster.
@@ -45,10 +79,71 @@ type AzureClusterSpec struct {
 	// +optional
 	BastionSpec BastionSpec `json:"bastionSpec,omitempty"`
 
+	// ApplicationGateway is the configuration for an Azure Application Gateway, with its own subnet and public IP,
+	// to be used by the Application Gateway Ingress Controller (AGIC).
+	// +optional
+	ApplicationGateway *ApplicationGatewaySpec `json:"applicationGateway,omitempty"`
+
+	// ProximityPlacementGroups is a list of proximity placement groups to create in the cluster resource group.
+	// Machines reference them by name to be co-located with each other.
+	// +optional
+	ProximityPlacementGroups []ProximityPlacementGroup `json:"proximityPlacementGroups,omitempty"`
+
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane. It is not recommended to set
 	// this when creating an AzureCluster as CAPZ will set this for you. However, if it is set, CAPZ will not change it.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// NamingConvention customizes the default names of the network interfaces, network security groups, load balancers
+	// and route tables of the cluster, e.g. to satisfy the naming policies of an organization. Names set explicitly in
+	// the spec are used as they are. It is immutable.
+	// +optional
+	NamingConvention *NamingConvention `json:"namingConvention,omitempty"`
+}
+
+// NamingResourceType is a type of Azure resource whose default name can be customized by a NamingConvention.
+type NamingResourceType string
+
+const (
+	// NetworkInterfaceNamingResourceType are the network interfaces of the machines.
+	NetworkInterfaceNamingResourceType NamingResourceType = "NetworkInterface"
+	// SecurityGroupNamingResourceType are the network security groups of the subnets.
+	SecurityGroupNamingResourceType NamingResourceType = "SecurityGroup"
+	// LoadBalancerNamingResourceType are the API server and control plane outbound load balancers.
+	LoadBalancerNamingResourceType NamingResourceType = "LoadBalancer"
+	// RouteTableNamingResourceType are the route tables of the subnets.
+	RouteTableNamingResourceType NamingResourceType = "RouteTable"
+)
+
+// NamingConventionNamePlaceholder is replaced by the default name of a resource in the templates of a NamingConvention.
+const NamingConventionNamePlaceholder = "{name}"
+
+// NamingConvention defines how the default names of Azure resources are generated.
+type NamingConvention struct {
+	// Prefix is prepended to the default names of the resources without a template.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the default names of the resources without a template.
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// Templates are the templates of the names of the resources, by resource type. The `{name}` placeholder in a
+	// template is replaced by the default name of the resource, e.g. `nsg-{name}-westeurope`. The supported resource
+	// types are NetworkInterface, SecurityGroup, LoadBalancer and RouteTable.
+	// +optional
+	Templates map[NamingResourceType]string `json:"templates,omitempty"`
+}
+
+// ResourceName returns the name of a resource of the given type from its default name.
+func (n *NamingConvention) ResourceName(resourceType NamingResourceType, defaultName string) string {
+	if n == nil {
+		return defaultName
+	}
+	if template, ok := n.Templates[resourceType]; ok {
+		return strings.ReplaceAll(template, NamingConventionNamePlaceholder, defaultName)
+	}
+	return n.Prefix + defaultName + n.Suffix
 }
 
 // AzureClusterStatus defines the observed state of AzureCluster.
@@ -74,6 +169,12 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// APIServerEndpoints lists the endpoints at which the API server can be reached through the frontend IPs of the
+	// API server load balancer, starting with the control plane endpoint. Endpoints other than the control plane
+	// endpoint must be added to the API server certificate SANs to be usable.
+	// +optional
+	APIServerEndpoints []clusterv1.APIEndpoint `json:"apiServerEndpoints,omitempty"`
 }
 
 // +kubebuilder:object:root=true
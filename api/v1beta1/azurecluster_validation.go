@@ -21,8 +21,13 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	valid "github.com/asaskevich/govalidator"
+	"github.com/google/uuid"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -48,16 +53,42 @@ const (
 	MinLBIdleTimeoutInMinutes = 4
 	// MaxLBIdleTimeoutInMinutes is the maximum number of minutes for the LB idle timeout.
 	MaxLBIdleTimeoutInMinutes = 30
+	// MaxLBAllocatedOutboundPorts is the maximum number of SNAT ports allocated per instance by a LB outbound rule.
+	MaxLBAllocatedOutboundPorts = 64000
 	// Network security rules should be a number between 100 and 4096.
 	// https://docs.microsoft.com/en-us/azure/virtual-network/network-security-groups-overview#security-rules
 	minRulePriority = 100
 	maxRulePriority = 4096
+	// NAT gateway idle timeouts should be between 4 and 120 minutes.
+	// https://learn.microsoft.com/en-us/azure/nat-gateway/nat-gateway-resource#tcp-idle-timeout
+	minNatGatewayIdleTimeout = 4
+	maxNatGatewayIdleTimeout = 120
+	// The Azure Firewall subnet must be at least a /26.
+	// https://learn.microsoft.com/en-us/azure/firewall/firewall-faq#why-does-azure-firewall-need-a--26-subnet-size
+	maxAzureFirewallSubnetPrefixLength = 26
+	// The virtual network gateway subnet must be at least a /29.
+	// https://learn.microsoft.com/en-us/azure/vpn-gateway/vpn-gateway-about-vpn-gateway-settings#gwsub
+	maxVirtualNetworkGatewaySubnetPrefixLength = 29
+	// A port or a range of ports of a security rule, e.g. 22 or 8000-8080.
+	securityRulePortsRegexPattern = `^\d{1,5}(-\d{1,5})?$`
 	// Must start with 'Microsoft.', then an alpha character, then can include alnum.
 	serviceEndpointServiceRegexPattern = `^Microsoft\.[a-zA-Z]{1,42}[a-zA-Z0-9]{0,42}$`
 	// Must start with an alpha character and then can include alnum OR be only *.
 	serviceEndpointLocationRegexPattern = `^([a-z]{1,42}\d{0,5}|[*])$`
+	// Must be a resource provider namespace starting with 'Microsoft.', followed by '/' and a resource type.
+	subnetDelegationServiceRegexPattern = `^Microsoft\.[a-zA-Z][a-zA-Z0-9.]{0,83}/[a-zA-Z][a-zA-Z0-9]{0,83}$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	subnetDelegationNameRegex = `^[-\w\._]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	routeRegex = `^[-\w\._]+$`
 	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
 	privateEndpointRegex = `^[-\w\._]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	proximityPlacementGroupRegex     = `^[-\w\._]+$`
+	proximityPlacementGroupMaxLength = 80
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	applicationSecurityGroupRegex     = `^[-\w\._]+$`
+	applicationSecurityGroupMaxLength = 80
 	// resource ID Pattern.
 	resourceIDPattern = `(?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)`
 )
@@ -65,12 +96,31 @@ const (
 var (
 	serviceEndpointServiceRegex  = regexp.MustCompile(serviceEndpointServiceRegexPattern)
 	serviceEndpointLocationRegex = regexp.MustCompile(serviceEndpointLocationRegexPattern)
+	subnetDelegationServiceRegex = regexp.MustCompile(subnetDelegationServiceRegexPattern)
+	securityRulePortsRegex       = regexp.MustCompile(securityRulePortsRegexPattern)
+
+	vpnGatewaySKUs = []string{
+		"VpnGw1", "VpnGw2", "VpnGw3", "VpnGw4", "VpnGw5",
+		"VpnGw1AZ", "VpnGw2AZ", "VpnGw3AZ", "VpnGw4AZ", "VpnGw5AZ",
+	}
+	expressRouteGatewaySKUs = []string{
+		"Standard", "HighPerformance", "UltraPerformance",
+		"ErGw1AZ", "ErGw2AZ", "ErGw3AZ",
+	}
+	// cloudProviderCredentialKeys are the cloud provider config keys which can't be set in extraConfig.
+	cloudProviderCredentialKeys = []string{
+		"aadClientId", "aadClientSecret", "aadClientCertPath", "aadClientCertPassword",
+		"useManagedIdentityExtension", "userAssignedIdentityID", "aadMSIResourceID",
+	}
 )
 
 // validateCluster validates a cluster.
 func (c *AzureCluster) validateCluster(old *AzureCluster) error {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, c.validateClusterName()...)
+	allErrs = append(allErrs, validateReconcilePolicy(c.Annotations[ReconcilePolicyAnnotation],
+		[]ReconcilePolicy{ReconcilePolicyAdopt, ReconcilePolicySkip}, reconcilePolicyAnnotationPath())...)
+	allErrs = append(allErrs, validateDeletePolicy(c.Annotations[DeletePolicyAnnotation], deletePolicyAnnotationPath())...)
 	allErrs = append(allErrs, c.validateClusterSpec(old)...)
 	if len(allErrs) == 0 {
 		return nil
@@ -81,6 +131,40 @@ func (c *AzureCluster) validateCluster(old *AzureCluster) error {
 		c.Name, allErrs)
 }
 
+// validateReconcilePolicy validates that the reconcile policy set with the ReconcilePolicyAnnotation is one of the
+// supported policies.
+func validateReconcilePolicy(policy string, supported []ReconcilePolicy, fldPath *field.Path) field.ErrorList {
+	if policy == "" {
+		return nil
+	}
+	supportedValues := make([]string, 0, len(supported))
+	for _, supportedPolicy := range supported {
+		if ReconcilePolicy(policy) == supportedPolicy {
+			return nil
+		}
+		supportedValues = append(supportedValues, string(supportedPolicy))
+	}
+	return field.ErrorList{field.NotSupported(fldPath, policy, supportedValues)}
+}
+
+// reconcilePolicyAnnotationPath returns the field path of the ReconcilePolicyAnnotation.
+func reconcilePolicyAnnotationPath() *field.Path {
+	return field.NewPath("metadata", "annotations").Key(ReconcilePolicyAnnotation)
+}
+
+// validateDeletePolicy validates that the delete policy set with the DeletePolicyAnnotation is supported.
+func validateDeletePolicy(policy string, fldPath *field.Path) field.ErrorList {
+	if policy == "" || DeletePolicy(policy) == DeletePolicyAbandon {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(fldPath, policy, []string{string(DeletePolicyAbandon)})}
+}
+
+// deletePolicyAnnotationPath returns the field path of the DeletePolicyAnnotation.
+func deletePolicyAnnotationPath() *field.Path {
+	return field.NewPath("metadata", "annotations").Key(DeletePolicyAnnotation)
+}
+
 // validateClusterSpec validates a ClusterSpec.
 func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	var allErrs field.ErrorList
@@ -90,12 +174,15 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
 
-	var oldCloudProviderConfigOverrides *CloudProviderConfigOverrides
 	if old != nil {
-		oldCloudProviderConfigOverrides = old.Spec.CloudProviderConfigOverrides
+		allErrs = append(allErrs, validateCloudProviderConfigOverrides(old.Spec.CloudProviderConfigOverrides, c.Spec.CloudProviderConfigOverrides,
+			field.NewPath("spec").Child("cloudProviderConfigOverrides"))...)
+	}
+	if c.Spec.CloudProviderConfigOverrides != nil {
+		overridesPath := field.NewPath("spec").Child("cloudProviderConfigOverrides")
+		allErrs = append(allErrs, validateCloudProviderCredentials(c.Spec.CloudProviderConfigOverrides.Credentials, overridesPath.Child("credentials"))...)
+		allErrs = append(allErrs, validateCloudProviderExtraConfig(c.Spec.CloudProviderConfigOverrides.ExtraConfig, overridesPath.Child("extraConfig"))...)
 	}
-	allErrs = append(allErrs, validateCloudProviderConfigOverrides(c.Spec.CloudProviderConfigOverrides, oldCloudProviderConfigOverrides,
-		field.NewPath("spec").Child("cloudProviderConfigOverrides"))...)
 
 	// If ClusterSpec has non-nil ExtendedLocation field but not enable EdgeZone feature gate flag, ClusterSpec validation failed.
 	if !feature.Gates.Enabled(feature.EdgeZone) && c.Spec.ExtendedLocation != nil {
@@ -106,6 +193,120 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, validateApplicationGateway(c.Spec.ApplicationGateway, field.NewPath("spec").Child("applicationGateway"))...)
+
+	allErrs = append(allErrs, validateProximityPlacementGroups(c.Spec.ProximityPlacementGroups, field.NewPath("spec").Child("proximityPlacementGroups"))...)
+
+	allErrs = append(allErrs, validateNamingConvention(c.Spec.NamingConvention, field.NewPath("spec").Child("namingConvention"))...)
+
+	return allErrs
+}
+
+// validateNamingConvention validates that the templates of a naming convention are for supported resource types and
+// contain the name placeholder, so that resources of the same type don't get the same name.
+func validateNamingConvention(convention *NamingConvention, fldPath *field.Path) field.ErrorList {
+	if convention == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for resourceType, template := range convention.Templates {
+		templatePath := fldPath.Child("templates").Key(string(resourceType))
+		switch resourceType {
+		case NetworkInterfaceNamingResourceType, SecurityGroupNamingResourceType, LoadBalancerNamingResourceType, RouteTableNamingResourceType:
+		default:
+			allErrs = append(allErrs, field.NotSupported(templatePath, resourceType, []string{
+				string(NetworkInterfaceNamingResourceType),
+				string(SecurityGroupNamingResourceType),
+				string(LoadBalancerNamingResourceType),
+				string(RouteTableNamingResourceType),
+			}))
+			continue
+		}
+		if !strings.Contains(template, NamingConventionNamePlaceholder) {
+			allErrs = append(allErrs, field.Invalid(templatePath, template, fmt.Sprintf("template must contain the %s placeholder", NamingConventionNamePlaceholder)))
+		}
+	}
+	return allErrs
+}
+
+// validateApplicationGateway validates that the firewall mode of an application gateway matches its tier,
+// that its autoscaling bounds are consistent and that its subnet CIDRs are valid.
+func validateApplicationGateway(gateway *ApplicationGatewaySpec, fldPath *field.Path) field.ErrorList {
+	if gateway == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if gateway.FirewallMode != "" && gateway.SKUTier != WAFV2ApplicationGatewaySKUTier {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("firewallMode"),
+			fmt.Sprintf("firewallMode can only be set with the %s tier", WAFV2ApplicationGatewaySKUTier)))
+	}
+	if gateway.MinCapacity != nil && gateway.MaxCapacity != nil && *gateway.MinCapacity > *gateway.MaxCapacity {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minCapacity"), *gateway.MinCapacity,
+			"minCapacity must be lower than or equal to maxCapacity"))
+	}
+	if gateway.Subnet.Role != SubnetApplicationGateway {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "role"), gateway.Subnet.Role,
+			fmt.Sprintf("application gateway subnet must have the %s role", SubnetApplicationGateway)))
+	}
+	for i, cidr := range gateway.Subnet.CIDRBlocks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr, "invalid CIDR format"))
+		}
+	}
+	return allErrs
+}
+
+// validateProximityPlacementGroups validates the proximity placement groups of a cluster.
+func validateProximityPlacementGroups(ppgs []ProximityPlacementGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]struct{}, len(ppgs))
+	for i, ppg := range ppgs {
+		if err := ValidateProximityPlacementGroupName(ppg.Name, fldPath.Index(i).Child("name")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if _, ok := names[ppg.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), ppg.Name))
+		}
+		names[ppg.Name] = struct{}{}
+	}
+	return allErrs
+}
+
+// ValidateProximityPlacementGroupName validates the name of a proximity placement group.
+func ValidateProximityPlacementGroupName(name string, fldPath *field.Path) *field.Error {
+	if name == "" {
+		return field.Invalid(fldPath, name, "name of proximity placement group cannot be empty")
+	}
+	if len(name) > proximityPlacementGroupMaxLength {
+		return field.Invalid(fldPath, name,
+			fmt.Sprintf("name of proximity placement group cannot be longer than %d characters", proximityPlacementGroupMaxLength))
+	}
+	if success, _ := regexp.MatchString(proximityPlacementGroupRegex, name); !success {
+		return field.Invalid(fldPath, name,
+			fmt.Sprintf("name of proximity placement group doesn't match regex %s", proximityPlacementGroupRegex))
+	}
+	return nil
+}
+
+// validateApplicationSecurityGroups validates a list of application security group names.
+func validateApplicationSecurityGroups(names []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		matches, _ := regexp.MatchString(applicationSecurityGroupRegex, name)
+		switch {
+		case len(name) > applicationSecurityGroupMaxLength:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), name,
+				fmt.Sprintf("name of application security group cannot be longer than %d characters", applicationSecurityGroupMaxLength)))
+		case !matches:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), name,
+				fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex)))
+		case seen[strings.ToLower(name)]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+		}
+		seen[strings.ToLower(name)] = true
+	}
 	return allErrs
 }
 
@@ -129,10 +330,26 @@ func (c *AzureCluster) validateClusterName() field.ErrorList {
 
 // validateBastionSpec validates a BastionSpec.
 func validateBastionSpec(bastionSpec BastionSpec, fldPath *field.Path) *field.Error {
-	if bastionSpec.AzureBastion != nil && bastionSpec.AzureBastion.Sku != StandardBastionHostSku && bastionSpec.AzureBastion.EnableTunneling {
-		return field.Invalid(fldPath.Child("sku"), bastionSpec.AzureBastion.Sku,
+	bastion := bastionSpec.AzureBastion
+	if bastion == nil || bastion.Sku == StandardBastionHostSku {
+		return nil
+	}
+	if bastion.EnableTunneling {
+		return field.Invalid(fldPath.Child("sku"), bastion.Sku,
 			"sku must be Standard if tunneling is enabled")
 	}
+	if bastion.EnableIPConnect {
+		return field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if IP connect is enabled")
+	}
+	if bastion.EnableShareableLink {
+		return field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if shareable links are enabled")
+	}
+	if bastion.ScaleUnits != nil {
+		return field.Invalid(fldPath.Child("sku"), bastion.Sku,
+			"sku must be Standard if scale units are set")
+	}
 	return nil
 }
 
@@ -162,6 +379,16 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 	}
 
 	cidrBlocks = controlPlaneSubnet.CIDRBlocks
+	// The private frontend IPs of the API server LB may live in another subnet, whose range is unknown when it isn't
+	// one of the cluster subnets.
+	if subnetName := networkSpec.APIServerLB.SubnetName; subnetName != "" {
+		cidrBlocks = nil
+		for _, subnet := range networkSpec.Subnets {
+			if subnet.Name == subnetName {
+				cidrBlocks = subnet.CIDRBlocks
+			}
+		}
+	}
 
 	allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
 
@@ -172,14 +399,28 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 			break
 		}
 	}
-	if needOutboundLB {
+	if needOutboundLB && !networkSpec.IsUserDefinedRouting() {
 		allErrs = append(allErrs, validateNodeOutboundLB(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
 	}
 
+	if !networkSpec.IsUserDefinedRouting() {
+		allErrs = append(allErrs, validateNodeOutboundLBMode(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
+	}
+
+	allErrs = append(allErrs, validateOutboundType(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validateAzureFirewall(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validateVirtualNetworkGateway(networkSpec.VirtualNetworkGateway, fldPath.Child("virtualNetworkGateway"))...)
+
+	allErrs = append(allErrs, validateAPIServerPrivateLinkService(networkSpec, fldPath.Child("apiServerPrivateLinkService"))...)
+
 	allErrs = append(allErrs, validateControlPlaneOutboundLB(networkSpec.ControlPlaneOutboundLB, networkSpec.APIServerLB, fldPath.Child("controlPlaneOutboundLB"))...)
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validatePrivateDNSZone(networkSpec.NetworkClassSpec, networkSpec.APIServerLB.Type, fldPath)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -199,6 +440,7 @@ func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Err
 func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	subnetNames := make(map[string]bool, len(subnets))
+	routeTableSubnets := make(map[string]int, len(subnets))
 	requiredSubnetRoles := map[string]bool{
 		"control-plane": false,
 		"node":          false,
@@ -217,23 +459,38 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 				requiredSubnetRoles[role] = true
 			}
 		}
-		for _, rule := range subnet.SecurityGroup.SecurityRules {
-			if err := validateSecurityRule(
-				rule,
-				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(i),
-			); err != nil {
-				allErrs = append(allErrs, err)
-			}
-		}
+		allErrs = append(allErrs, validateSecurityRules(subnet.SecurityGroup.SecurityRules, fldPath.Index(i).Child("securityGroup").Child("securityRules"))...)
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
 
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, validateNatGatewayClassSpec(subnet.NatGateway.NatGatewayClassSpec, fldPath.Index(i).Child("natGateway"))...)
+		}
+
 		if len(subnet.ServiceEndpoints) > 0 {
 			allErrs = append(allErrs, validateServiceEndpoints(subnet.ServiceEndpoints, fldPath.Index(i).Child("serviceEndpoints"))...)
 		}
 
+		if len(subnet.Delegations) > 0 {
+			allErrs = append(allErrs, validateSubnetDelegations(subnet.Delegations, subnet.Role, fldPath.Index(i).Child("delegations"))...)
+		}
+
 		if len(subnet.PrivateEndpoints) > 0 {
 			allErrs = append(allErrs, validatePrivateEndpoints(subnet.PrivateEndpoints, subnet.CIDRBlocks, fldPath.Index(i).Child("privateEndpoints"))...)
 		}
+
+		if len(subnet.RouteTable.Routes) > 0 {
+			allErrs = append(allErrs, validateRouteTable(subnet.RouteTable, fldPath.Index(i).Child("routeTable"))...)
+		}
+		if subnet.RouteTable.Name != "" {
+			if j, ok := routeTableSubnets[subnet.RouteTable.Name]; ok {
+				if !reflect.DeepEqual(subnet.RouteTable.Routes, subnets[j].RouteTable.Routes) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("routeTable", "routes"), subnet.RouteTable.Routes,
+						fmt.Sprintf("subnets sharing route table %s must declare the same routes", subnet.RouteTable.Name)))
+				}
+			} else {
+				routeTableSubnets[subnet.RouteTable.Name] = i
+			}
+		}
 	}
 	for k, v := range requiredSubnetRoles {
 		if !v {
@@ -244,6 +501,192 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 	return allErrs
 }
 
+// validateOutboundType validates that node subnets using the userDefinedRouting outbound type
+// don't rely on a node outbound load balancer or NAT gateway and have a route table associated.
+func validateOutboundType(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	if !networkSpec.IsUserDefinedRouting() {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if networkSpec.NodeOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB"),
+			"Node outbound load balancer can't be set when outboundType is userDefinedRouting"))
+	}
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.Role != SubnetNode {
+			continue
+		}
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("natGateway"),
+				"NAT gateway can't be set on node subnets when outboundType is userDefinedRouting"))
+		}
+		if subnet.RouteTable.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("subnets").Index(i).Child("routeTable", "name"),
+				"node subnets require a route table with a default route when outboundType is userDefinedRouting"))
+		}
+	}
+	return allErrs
+}
+
+// validateAzureFirewall validates that an Azure Firewall is used with the userDefinedRouting outbound type,
+// that its subnet satisfies the Azure requirements and that node subnets don't declare their own default route.
+func validateAzureFirewall(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	firewall := networkSpec.AzureFirewall
+	if firewall == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if !networkSpec.IsUserDefinedRouting() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), networkSpec.OutboundType,
+			"outboundType must be userDefinedRouting when an Azure Firewall is configured"))
+	}
+	subnetsPath := fldPath.Child("subnets")
+	fldPath = fldPath.Child("azureFirewall")
+	if firewall.Subnet.Name != DefaultAzureFirewallSubnetName {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "name"), firewall.Subnet.Name,
+			fmt.Sprintf("Azure Firewall subnet must be named %s", DefaultAzureFirewallSubnetName)))
+	}
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.Role != SubnetNode {
+			continue
+		}
+		for j, route := range subnet.RouteTable.Routes {
+			if route.AddressPrefix == "0.0.0.0/0" {
+				allErrs = append(allErrs, field.Forbidden(subnetsPath.Index(i).Child("routeTable", "routes").Index(j).Child("addressPrefix"),
+					"node subnets can't declare a default route when an Azure Firewall is configured"))
+			}
+		}
+	}
+	for i, cidr := range firewall.Subnet.CIDRBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr, "invalid CIDR format"))
+			continue
+		}
+		if ones, _ := ipNet.Mask.Size(); ones > maxAzureFirewallSubnetPrefixLength {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr,
+				fmt.Sprintf("Azure Firewall subnet must be at least a /%d", maxAzureFirewallSubnetPrefixLength)))
+		}
+	}
+	return allErrs
+}
+
+// validateVirtualNetworkGateway validates that the SKU of a virtual network gateway matches its type
+// and that its subnet satisfies the Azure requirements.
+func validateVirtualNetworkGateway(gateway *VirtualNetworkGateway, fldPath *field.Path) field.ErrorList {
+	if gateway == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	supportedSKUs := vpnGatewaySKUs
+	if gateway.GatewayType == ExpressRouteVirtualNetworkGatewayType {
+		supportedSKUs = expressRouteGatewaySKUs
+	}
+	supported := false
+	for _, sku := range supportedSKUs {
+		if sku == gateway.SKU {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sku"), gateway.SKU, supportedSKUs))
+	}
+	if gateway.Subnet.Name != DefaultVirtualNetworkGatewaySubnetName {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "name"), gateway.Subnet.Name,
+			fmt.Sprintf("virtual network gateway subnet must be named %s", DefaultVirtualNetworkGatewaySubnetName)))
+	}
+	for i, cidr := range gateway.Subnet.CIDRBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr, "invalid CIDR format"))
+			continue
+		}
+		if ones, _ := ipNet.Mask.Size(); ones > maxVirtualNetworkGatewaySubnetPrefixLength {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr,
+				fmt.Sprintf("virtual network gateway subnet must be at least a /%d", maxVirtualNetworkGatewaySubnetPrefixLength)))
+		}
+	}
+	return allErrs
+}
+
+// validateAPIServerPrivateLinkService validates that the private link service of the API server is attached to an
+// internal load balancer, that its NAT IP address is allocated from a cluster subnet, and that its subscription lists are valid.
+func validateAPIServerPrivateLinkService(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	pls := networkSpec.APIServerPrivateLinkService
+	if pls == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if networkSpec.APIServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a private link service can only be attached to an internal API server load balancer"))
+	}
+
+	if pls.SubnetName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subnetName"), "subnetName is required when the cluster has no control plane subnet"))
+	} else {
+		found := false
+		for _, subnet := range networkSpec.Subnets {
+			if subnet.Name == pls.SubnetName {
+				found = true
+				if subnet.Role == SubnetBastion || subnet.Role == SubnetFirewall || subnet.Role == SubnetGateway {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("subnetName"), pls.SubnetName,
+						fmt.Sprintf("the NAT IP address of the private link service cannot be allocated from a subnet with role %s", subnet.Role)))
+				}
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnetName"), pls.SubnetName, "subnet not found in the cluster subnets"))
+		}
+	}
+
+	allowAll := false
+	allowed := make(map[string]bool, len(pls.AllowedSubscriptions))
+	for i, subscription := range pls.AllowedSubscriptions {
+		if subscription == "*" {
+			allowAll = true
+			continue
+		}
+		if _, err := uuid.Parse(subscription); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedSubscriptions").Index(i), subscription, "must be a subscription ID or *"))
+		}
+		allowed[strings.ToLower(subscription)] = true
+	}
+	for i, subscription := range pls.AutoApprovedSubscriptions {
+		if _, err := uuid.Parse(subscription); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoApprovedSubscriptions").Index(i), subscription, "must be a subscription ID"))
+			continue
+		}
+		if !allowAll && !allowed[strings.ToLower(subscription)] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoApprovedSubscriptions").Index(i), subscription, "auto-approved subscriptions must also be allowed"))
+		}
+	}
+	return allErrs
+}
+
+// validateNatGatewayClassSpec validates the zone, idle timeout and public IP prefixes of a NAT gateway.
+func validateNatGatewayClassSpec(natGateway NatGatewayClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if timeout := natGateway.IdleTimeoutInMinutes; timeout != nil && (*timeout < minNatGatewayIdleTimeout || *timeout > maxNatGatewayIdleTimeout) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *timeout,
+			fmt.Sprintf("NAT gateway idle timeout should be between %d and %d minutes", minNatGatewayIdleTimeout, maxNatGatewayIdleTimeout)))
+	}
+	if len(natGateway.Zones) > 1 {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("zones"), len(natGateway.Zones), 1))
+	}
+	for i, prefix := range natGateway.PublicIPPrefixes {
+		if err := validateResourceIDType(prefix, "Microsoft.Network/publicIPPrefixes", fldPath.Child("publicIPPrefixes").Index(i)); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
 // validateSubnetName validates the Name of a Subnet.
 func validateSubnetName(name string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.Match(subnetRegex, []byte(name)); !success {
@@ -304,6 +747,9 @@ func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.Erro
 
 	for _, peering := range peerings {
 		vnetIdentifier := peering.ResourceGroup + "/" + peering.RemoteVnetName
+		if peering.SubscriptionID != "" {
+			vnetIdentifier = peering.SubscriptionID + "/" + vnetIdentifier
+		}
 		if _, ok := vnetIdentifiers[vnetIdentifier]; ok {
 			allErrs = append(allErrs, field.Duplicate(fldPath, vnetIdentifier))
 		}
@@ -344,9 +790,112 @@ func validateSecurityRule(rule SecurityRule, fldPath *field.Path) *field.Error {
 		return field.Invalid(fldPath, rule.Priority, fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority))
 	}
 
+	if rule.Source != nil && len(rule.Sources) > 0 {
+		return field.Forbidden(fldPath.Child("sources"), "sources cannot be set together with source")
+	}
+
+	if rule.Destination != nil && len(rule.Destinations) > 0 {
+		return field.Forbidden(fldPath.Child("destinations"), "destinations cannot be set together with destination")
+	}
+
+	if len(rule.SourceApplicationSecurityGroups) > 0 && (rule.Source != nil || len(rule.Sources) > 0) {
+		return field.Forbidden(fldPath.Child("sourceApplicationSecurityGroups"), "sourceApplicationSecurityGroups cannot be set together with source or sources")
+	}
+
+	if len(rule.DestinationApplicationSecurityGroups) > 0 && (rule.Destination != nil || len(rule.Destinations) > 0) {
+		return field.Forbidden(fldPath.Child("destinationApplicationSecurityGroups"), "destinationApplicationSecurityGroups cannot be set together with destination or destinations")
+	}
+
+	switch rule.Protocol {
+	case "", SecurityGroupProtocolAll, SecurityGroupProtocolTCP, SecurityGroupProtocolUDP:
+	case SecurityGroupProtocolICMP:
+		// ICMP has no ports.
+		if rule.SourcePorts != nil && *rule.SourcePorts != "*" {
+			return field.Invalid(fldPath.Child("sourcePorts"), *rule.SourcePorts, "source ports must be * when the protocol is Icmp")
+		}
+		if rule.DestinationPorts != nil && *rule.DestinationPorts != "*" {
+			return field.Invalid(fldPath.Child("destinationPorts"), *rule.DestinationPorts, "destination ports must be * when the protocol is Icmp")
+		}
+	default:
+		return field.NotSupported(fldPath.Child("protocol"), rule.Protocol, []string{
+			string(SecurityGroupProtocolAll), string(SecurityGroupProtocolTCP), string(SecurityGroupProtocolUDP), string(SecurityGroupProtocolICMP),
+		})
+	}
+
+	switch rule.Direction {
+	case "", SecurityRuleDirectionInbound, SecurityRuleDirectionOutbound:
+	default:
+		return field.NotSupported(fldPath.Child("direction"), rule.Direction, []string{
+			string(SecurityRuleDirectionInbound), string(SecurityRuleDirectionOutbound),
+		})
+	}
+
+	if rule.SourcePorts != nil && !isValidPortRange(*rule.SourcePorts) {
+		return field.Invalid(fldPath.Child("sourcePorts"), *rule.SourcePorts, "ports must be *, a port or a range of ports between 0 and 65535, e.g. 8000-8080")
+	}
+
+	if rule.DestinationPorts != nil && !isValidPortRange(*rule.DestinationPorts) {
+		return field.Invalid(fldPath.Child("destinationPorts"), *rule.DestinationPorts, "ports must be *, a port or a range of ports between 0 and 65535, e.g. 8000-8080")
+	}
+
 	return nil
 }
 
+// validateSecurityRules validates the SecurityRules of a security group.
+// Azure requires rule names to be unique, and rule priorities to be unique per direction.
+func validateSecurityRules(rules SecurityRules, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(rules))
+	priorities := make(map[SecurityRuleDirection]map[int32]string, 2)
+	for i, rule := range rules {
+		if err := validateSecurityRule(rule, fldPath.Index(i)); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		allErrs = append(allErrs, validateApplicationSecurityGroups(rule.SourceApplicationSecurityGroups, fldPath.Index(i).Child("sourceApplicationSecurityGroups"))...)
+		allErrs = append(allErrs, validateApplicationSecurityGroups(rule.DestinationApplicationSecurityGroups, fldPath.Index(i).Child("destinationApplicationSecurityGroups"))...)
+
+		if names[strings.ToLower(rule.Name)] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), rule.Name))
+		}
+		names[strings.ToLower(rule.Name)] = true
+
+		// Rules without a direction default to Inbound.
+		direction := rule.Direction
+		if direction == "" {
+			direction = SecurityRuleDirectionInbound
+		}
+		if priorities[direction] == nil {
+			priorities[direction] = make(map[int32]string)
+		}
+		if other, ok := priorities[direction][rule.Priority]; ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("priority"), rule.Priority,
+				fmt.Sprintf("priority is already used by %s rule %s", strings.ToLower(string(direction)), other)))
+		} else {
+			priorities[direction][rule.Priority] = rule.Name
+		}
+	}
+	return allErrs
+}
+
+// isValidPortRange returns true if ports is *, a single port or a range of ports, e.g. 8000-8080.
+func isValidPortRange(ports string) bool {
+	if ports == "*" {
+		return true
+	}
+	if !securityRulePortsRegex.MatchString(ports) {
+		return false
+	}
+	var portNumbers []int
+	for _, bound := range strings.Split(ports, "-") {
+		port, err := strconv.Atoi(bound)
+		if err != nil || port > 65535 {
+			return false
+		}
+		portNumbers = append(portNumbers, port)
+	}
+	return len(portNumbers) == 1 || portNumbers[0] <= portNumbers[1]
+}
+
 func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -363,8 +912,9 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer name should not be modified after AzureCluster creation."))
 	}
 
-	// There should only be one IP config.
-	if len(lb.FrontendIPs) != 1 || pointer.Int32Deref(lb.FrontendIPsCount, 1) != 1 {
+	// There should only be one IP config, except for a public load balancer which may also expose a private IP.
+	mixed := lb.Type == Public && len(lb.FrontendIPs) == 2
+	if (len(lb.FrontendIPs) != 1 && !mixed) || pointer.Int32Deref(lb.FrontendIPsCount, 1) != 1 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPConfigs"), lb.FrontendIPs,
 			"API Server Load balancer should have 1 Frontend IP"))
 	} else {
@@ -375,11 +925,13 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 					"Internal Load Balancers cannot have a Public IP"))
 			}
 			if lb.FrontendIPs[0].PrivateIPAddress != "" {
-				if err := validateInternalLBIPAddress(lb.FrontendIPs[0].PrivateIPAddress, cidrs,
+				if err := validateAPIServerLBIPAddress(lb, lb.FrontendIPs[0].PrivateIPAddress, cidrs,
 					fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP")); err != nil {
 					allErrs = append(allErrs, err)
 				}
-				if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PrivateIPAddress != lb.FrontendIPs[0].PrivateIPAddress {
+				// The private IP may be filled in once when it is claimed from an IPAM pool.
+				claimed := lb.FrontendIPs[0].AddressFromPool != nil && len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PrivateIPAddress == ""
+				if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PrivateIPAddress != lb.FrontendIPs[0].PrivateIPAddress && !claimed {
 					allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer private IP should not be modified after AzureCluster creation."))
 				}
 			}
@@ -387,10 +939,73 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 
 		// if Public, IP config should not have a private IP.
 		if lb.Type == Public {
+			if lb.FrontendIPs[0].AddressFromPool != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("addressFromPool"),
+					"Public Load Balancers cannot claim a Private IP from an IPAM pool"))
+			}
 			if lb.FrontendIPs[0].PrivateIPAddress != "" {
 				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP"),
 					"Public Load Balancers cannot have a Private IP"))
 			}
+			if mixed {
+				allErrs = append(allErrs, validateAPIServerLBPrivateFrontendIP(lb, old, cidrs, fldPath)...)
+			}
+		}
+	}
+
+	// Frontend IPs can't be added or removed once the load balancer exists.
+	if old.Name != "" && len(old.FrontendIPs) != 0 && len(old.FrontendIPs) != len(lb.FrontendIPs) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs"),
+			"API Server load balancer frontend IPs should not be added or removed after AzureCluster creation."))
+	}
+
+	return allErrs
+}
+
+// validateAPIServerLBIPAddress validates a private frontend IP of the API server load balancer. It must be within the
+// cidrs of its subnet, unless the frontend lives in a subnet that isn't one of the cluster subnets.
+func validateAPIServerLBIPAddress(lb LoadBalancerSpec, address string, cidrs []string, fldPath *field.Path) *field.Error {
+	if lb.SubnetName != "" && len(cidrs) == 0 {
+		if net.ParseIP(address) == nil {
+			return field.Invalid(fldPath, address, "Internal LB IP address isn't a valid IPv4 or IPv6 address")
+		}
+		return nil
+	}
+	return validateInternalLBIPAddress(address, cidrs, fldPath)
+}
+
+// validateAPIServerLBPrivateFrontendIP validates the second frontend IP of a public API server load balancer,
+// which is only exposed through a private IP in the control plane subnet.
+func validateAPIServerLBPrivateFrontendIP(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	frontendIP := lb.FrontendIPs[1]
+	frontendIPPath := fldPath.Child("frontendIPConfigs").Index(1)
+	if lb.FrontendIPs[0].PublicIP == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("frontendIPConfigs").Index(0).Child("publicIP"),
+			"The first frontend IP of a Public Load Balancer with a private frontend IP must have a Public IP"))
+	}
+	if frontendIP.Name == lb.FrontendIPs[0].Name {
+		allErrs = append(allErrs, field.Duplicate(frontendIPPath.Child("name"), frontendIP.Name))
+	}
+	if frontendIP.PublicIP != nil {
+		allErrs = append(allErrs, field.Forbidden(frontendIPPath.Child("publicIP"),
+			"The second frontend IP of a Public Load Balancer must only have a Private IP"))
+	}
+	if frontendIP.AddressFromPool != nil {
+		allErrs = append(allErrs, field.Forbidden(frontendIPPath.Child("addressFromPool"),
+			"Public Load Balancers cannot claim a Private IP from an IPAM pool"))
+	}
+	if frontendIP.PrivateIPAddress == "" {
+		allErrs = append(allErrs, field.Required(frontendIPPath.Child("privateIP"),
+			"The second frontend IP of a Public Load Balancer must have a Private IP"))
+	} else {
+		if err := validateAPIServerLBIPAddress(lb, frontendIP.PrivateIPAddress, cidrs, frontendIPPath.Child("privateIP")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if len(old.FrontendIPs) == 2 && old.FrontendIPs[1].PrivateIPAddress != frontendIP.PrivateIPAddress {
+			allErrs = append(allErrs, field.Forbidden(frontendIPPath.Child("privateIP"),
+				"API Server load balancer private IP should not be modified after AzureCluster creation."))
 		}
 	}
 
@@ -411,7 +1026,7 @@ func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserv
 
 	allErrs = append(allErrs, validateClassSpecForNodeOutboundLB(lbClassSpec, oldClassSpec, apiserverLBClassSpec, fldPath)...)
 
-	if lb == nil {
+	if lb == nil || !lb.IsDedicated() {
 		return allErrs
 	}
 
@@ -487,6 +1102,35 @@ func validatePrivateDNSZoneName(privateDNSZoneName string, apiserverLBType LBTyp
 	return allErrs
 }
 
+// validatePrivateDNSZone validates the PrivateDNSZone mode and its consistency with the PrivateDNSZoneName.
+func validatePrivateDNSZone(networkClassSpec NetworkClassSpec, apiserverLBType LBType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	privateDNSZone := networkClassSpec.PrivateDNSZone
+	if privateDNSZone == "" {
+		return nil
+	}
+	if apiserverLBType != Internal {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("privateDNSZone"), apiserverLBType,
+			"PrivateDNSZone is available only if APIServerLB.Type is Internal"))
+	}
+	if !networkClassSpec.IsPrivateDNSZoneBYO() {
+		return allErrs
+	}
+
+	if err := validateResourceIDType(privateDNSZone, "Microsoft.Network/privateDnsZones", fldPath.Child("privateDNSZone")); err != nil {
+		return append(allErrs, field.Invalid(fldPath.Child("privateDNSZone"), privateDNSZone,
+			fmt.Sprintf("PrivateDNSZone must be %s, %s or the resource ID of an existing private DNS zone", PrivateDNSZoneModeSystem, PrivateDNSZoneModeNone)))
+	}
+	parsed, _ := arm.ParseResourceID(privateDNSZone)
+	if networkClassSpec.PrivateDNSZoneName != "" && !strings.EqualFold(networkClassSpec.PrivateDNSZoneName, parsed.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("privateDNSZoneName"), networkClassSpec.PrivateDNSZoneName,
+			"PrivateDNSZoneName must match the name of the private DNS zone referenced by PrivateDNSZone"))
+	}
+
+	return allErrs
+}
+
 // validateCloudProviderConfigOverrides validates CloudProviderConfigOverrides.
 func validateCloudProviderConfigOverrides(oldConfig, newConfig *CloudProviderConfigOverrides, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -496,6 +1140,44 @@ func validateCloudProviderConfigOverrides(oldConfig, newConfig *CloudProviderCon
 	return allErrs
 }
 
+// validateCloudProviderCredentials validates that the certificate path and user-assigned identity are only set with
+// the matching cloud provider credentials type.
+func validateCloudProviderCredentials(credentials *CloudProviderCredentials, fldPath *field.Path) field.ErrorList {
+	if credentials == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if credentials.AADClientCertPath != "" && credentials.Type != ClientCertificateCloudProviderCredentials {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("aadClientCertPath"),
+			fmt.Sprintf("aadClientCertPath can only be set with the %s type", ClientCertificateCloudProviderCredentials)))
+	}
+	if credentials.UserAssignedIdentityID != "" && credentials.Type != ManagedIdentityCloudProviderCredentials {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("userAssignedIdentityID"),
+			fmt.Sprintf("userAssignedIdentityID can only be set with the %s type", ManagedIdentityCloudProviderCredentials)))
+	}
+	return allErrs
+}
+
+// validateCloudProviderExtraConfig validates that the extra cloud provider config values don't set the credentials of
+// the cloud provider, which are configured with the cluster identity, the VM identity or the credentials override.
+func validateCloudProviderExtraConfig(extraConfig map[string]apiextensionsv1.JSON, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for key := range extraConfig {
+		if key == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, key, "keys cannot be empty"))
+			continue
+		}
+		for _, credentialKey := range cloudProviderCredentialKeys {
+			// the cloud provider config is unmarshaled case-insensitively
+			if strings.EqualFold(key, credentialKey) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Key(key), "cloud provider credentials cannot be set in extraConfig"))
+			}
+		}
+	}
+	return allErrs
+}
+
 func validateClassSpecForAPIServerLB(lb LoadBalancerClassSpec, old *LoadBalancerClassSpec, apiServerLBPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -510,6 +1192,21 @@ func validateClassSpecForAPIServerLB(lb LoadBalancerClassSpec, old *LoadBalancer
 			[]string{string(Public), string(Internal)}))
 	}
 
+	if lb.Mode != "" {
+		allErrs = append(allErrs, field.Forbidden(apiServerLBPath.Child("mode"), "mode can only be set on the node outbound load balancer"))
+	}
+
+	if lb.SubnetName != "" {
+		if err := validateSubnetName(lb.SubnetName, apiServerLBPath.Child("subnetName")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	// SubnetName should be immutable, the type of an existing load balancer is always set.
+	if old != nil && old.Type != "" && old.SubnetName != lb.SubnetName {
+		allErrs = append(allErrs, field.Forbidden(apiServerLBPath.Child("subnetName"), "API Server load balancer subnet name should not be modified after AzureCluster creation."))
+	}
+
 	// SKU should be immutable.
 	if old != nil && old.SKU != "" && old.SKU != lb.SKU {
 		allErrs = append(allErrs, field.Forbidden(apiServerLBPath.Child("sku"), "API Server load balancer SKU should not be modified after AzureCluster creation."))
@@ -530,6 +1227,8 @@ func validateClassSpecForAPIServerLB(lb LoadBalancerClassSpec, old *LoadBalancer
 			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
 	}
 
+	allErrs = append(allErrs, validateAllocatedOutboundPorts(lb, apiServerLBPath)...)
+
 	return allErrs
 }
 
@@ -546,6 +1245,15 @@ func validateClassSpecForNodeOutboundLB(lb *LoadBalancerClassSpec, old *LoadBala
 		return allErrs
 	}
 
+	if lb.SubnetName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnetName"), "subnetName can only be set on the API server load balancer"))
+	}
+
+	// The mode of the node outbound load balancer is validated by validateClassSpecForNodeOutboundLBMode.
+	if !lb.IsDedicated() {
+		return allErrs
+	}
+
 	if old != nil && old.SKU != lb.SKU {
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sku"), "Node outbound load balancer SKU should not be modified after AzureCluster creation."))
 	}
@@ -563,6 +1271,74 @@ func validateClassSpecForNodeOutboundLB(lb *LoadBalancerClassSpec, old *LoadBala
 			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
 	}
 
+	allErrs = append(allErrs, validateAllocatedOutboundPorts(*lb, fldPath)...)
+
+	return allErrs
+}
+
+// validateNodeOutboundLBMode validates the mode of the node outbound load balancer, and that no outbound IPs are
+// configured when it isn't a dedicated load balancer.
+func validateNodeOutboundLBMode(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserverLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil {
+		return allErrs
+	}
+
+	var oldClassSpec *LoadBalancerClassSpec
+	if old != nil {
+		oldClassSpec = &old.LoadBalancerClassSpec
+	}
+	allErrs = append(allErrs, validateClassSpecForNodeOutboundLBMode(&lb.LoadBalancerClassSpec, oldClassSpec, apiserverLB.LoadBalancerClassSpec, fldPath)...)
+
+	if lb.IsDedicated() {
+		return allErrs
+	}
+	if lb.FrontendIPsCount != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPsCount"),
+			fmt.Sprintf("Node outbound load balancer frontendIPsCount can't be set when mode is %s", lb.Mode)))
+	}
+	if len(lb.FrontendIPs) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs"),
+			fmt.Sprintf("Node outbound load balancer frontendIPs can't be set when mode is %s", lb.Mode)))
+	}
+
+	return allErrs
+}
+
+// validateClassSpecForNodeOutboundLBMode validates that a node outbound load balancer is only shared with a public API
+// server load balancer, that the outbound settings are only set on a dedicated load balancer and that the mode isn't
+// changed after creation.
+func validateClassSpecForNodeOutboundLBMode(lb *LoadBalancerClassSpec, old *LoadBalancerClassSpec, apiserverLB LoadBalancerClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb == nil {
+		return allErrs
+	}
+
+	if old != nil && !(old.IsDedicated() && lb.IsDedicated()) && old.Mode != lb.Mode {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"), "Node outbound load balancer mode cannot be modified after AzureCluster creation."))
+	}
+
+	if lb.IsSharedWithAPIServerLB() && apiserverLB.Type != Public {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"),
+			"Node outbound load balancer can only be shared with a public API server load balancer"))
+	}
+
+	if lb.IsDedicated() {
+		return allErrs
+	}
+	if lb.IdleTimeoutInMinutes != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("idleTimeoutInMinutes"),
+			fmt.Sprintf("Node outbound load balancer idleTimeoutInMinutes can't be set when mode is %s", lb.Mode)))
+	}
+	if lb.EnableTCPReset != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableTCPReset"),
+			fmt.Sprintf("Node outbound load balancer enableTCPReset can't be set when mode is %s", lb.Mode)))
+	}
+	if lb.AllocatedOutboundPorts != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("allocatedOutboundPorts"),
+			fmt.Sprintf("Node outbound load balancer allocatedOutboundPorts can't be set when mode is %s", lb.Mode)))
+	}
+
 	return allErrs
 }
 
@@ -580,10 +1356,39 @@ func validateClassSpecForControlPlaneOutboundLB(lb *LoadBalancerClassSpec, apise
 			return nil
 		}
 
+		if lb.Mode != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"), "mode can only be set on the node outbound load balancer"))
+		}
+		if lb.SubnetName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnetName"), "subnetName can only be set on the API server load balancer"))
+		}
+
 		if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
 				fmt.Sprintf("Control plane outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
 		}
+
+		allErrs = append(allErrs, validateAllocatedOutboundPorts(*lb, fldPath)...)
+	}
+
+	return allErrs
+}
+
+// validateAllocatedOutboundPorts validates the number of SNAT ports allocated per instance by the outbound rule of a
+// load balancer. Internal load balancers don't have outbound rules.
+func validateAllocatedOutboundPorts(lb LoadBalancerClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb.AllocatedOutboundPorts == nil {
+		return allErrs
+	}
+
+	if lb.Type == Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("allocatedOutboundPorts"),
+			"allocated outbound ports can only be set for public load balancers"))
+	}
+	if ports := *lb.AllocatedOutboundPorts; ports < 0 || ports > MaxLBAllocatedOutboundPorts || ports%8 != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("allocatedOutboundPorts"), ports,
+			fmt.Sprintf("allocated outbound ports should be a multiple of 8 between 0 and %d", MaxLBAllocatedOutboundPorts)))
 	}
 
 	return allErrs
@@ -625,6 +1430,101 @@ func validateServiceEndpoints(serviceEndpoints []ServiceEndpointSpec, fldPath *f
 	return allErrs
 }
 
+// validateSubnetDelegations validates the delegations of a subnet.
+// Azure does not allow the bastion, firewall and gateway subnets to be delegated.
+func validateSubnetDelegations(delegations []SubnetDelegation, role SubnetRole, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if role == SubnetBastion || role == SubnetFirewall || role == SubnetGateway {
+		return append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("subnets with role %s cannot be delegated", role)))
+	}
+
+	names := make(map[string]bool, len(delegations))
+	services := make(map[string]bool, len(delegations))
+	for i, d := range delegations {
+		if d.ServiceName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("serviceName"), "serviceName is required for all delegations"))
+		} else {
+			if !subnetDelegationServiceRegex.MatchString(d.ServiceName) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("serviceName"), d.ServiceName, fmt.Sprintf("service name of delegation doesn't match regex %s", subnetDelegationServiceRegexPattern)))
+			}
+			if services[d.ServiceName] {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("serviceName"), d.ServiceName))
+			}
+			services[d.ServiceName] = true
+		}
+
+		if d.Name != "" {
+			if success, _ := regexp.MatchString(subnetDelegationNameRegex, d.Name); !success {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), d.Name, fmt.Sprintf("name of delegation doesn't match regex %s", subnetDelegationNameRegex)))
+			}
+			if names[d.Name] {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), d.Name))
+			}
+			names[d.Name] = true
+		}
+	}
+
+	return allErrs
+}
+
+// validateRouteTable validates the user-defined routes of a route table.
+func validateRouteTable(routeTable RouteTable, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if routeTable.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "route table name is required when routes are declared"))
+	}
+
+	fldPath = fldPath.Child("routes")
+	names := make(map[string]bool, len(routeTable.Routes))
+	addressPrefixes := make(map[string]bool, len(routeTable.Routes))
+	for i, route := range routeTable.Routes {
+		if route.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name is required for all routes"))
+		} else {
+			if success, _ := regexp.MatchString(routeRegex, route.Name); !success {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), route.Name, fmt.Sprintf("name of route doesn't match regex %s", routeRegex)))
+			}
+			if names[route.Name] {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), route.Name))
+			}
+			names[route.Name] = true
+		}
+
+		if _, _, err := net.ParseCIDR(route.AddressPrefix); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("addressPrefix"), route.AddressPrefix, "invalid CIDR format"))
+		} else {
+			if addressPrefixes[route.AddressPrefix] {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("addressPrefix"), route.AddressPrefix))
+			}
+			addressPrefixes[route.AddressPrefix] = true
+		}
+
+		switch route.NextHopType {
+		case RouteNextHopTypeVirtualAppliance:
+			if route.NextHopIPAddress == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("nextHopIPAddress"),
+					fmt.Sprintf("nextHopIPAddress is required when nextHopType is %s", RouteNextHopTypeVirtualAppliance)))
+			} else if net.ParseIP(route.NextHopIPAddress) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("nextHopIPAddress"), route.NextHopIPAddress, "invalid IP address"))
+			}
+		case RouteNextHopTypeVirtualNetworkGateway, RouteNextHopTypeVnetLocal, RouteNextHopTypeInternet, RouteNextHopTypeNone:
+			if route.NextHopIPAddress != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("nextHopIPAddress"),
+					fmt.Sprintf("nextHopIPAddress can only be set when nextHopType is %s", RouteNextHopTypeVirtualAppliance)))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("nextHopType"), route.NextHopType, []string{
+				string(RouteNextHopTypeVirtualNetworkGateway), string(RouteNextHopTypeVnetLocal), string(RouteNextHopTypeInternet),
+				string(RouteNextHopTypeVirtualAppliance), string(RouteNextHopTypeNone),
+			}))
+		}
+	}
+
+	return allErrs
+}
+
 func validateServiceEndpointServiceName(serviceName string, fldPath *field.Path) *field.Error {
 	if success := serviceEndpointServiceRegex.MatchString(serviceName); !success {
 		return field.Invalid(fldPath, serviceName, fmt.Sprintf("service name of endpoint service doesn't match regex %s", serviceEndpointServiceRegexPattern))
@@ -666,6 +1566,12 @@ func validatePrivateEndpoints(privateEndpointSpecs []PrivateEndpointSpec, subnet
 				allErrs = append(allErrs, err)
 			}
 		}
+
+		for j, zoneID := range pe.PrivateDNSZoneIDs {
+			if err := validateResourceIDType(zoneID, "Microsoft.Network/privateDnsZones", fldPath.Index(i).Child("privateDNSZoneIDs").Index(j)); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
 	}
 
 	return allErrs
@@ -17,9 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -504,6 +507,32 @@ func TestSubnetNamesNotUnique(t *testing.T) {
 	})
 }
 
+func TestSubnetsSharingRouteTableWithDifferentRoutes(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - shared route table with different routes",
+		subnets: createValidSubnets(),
+	}
+
+	testCase.subnets[0].RouteTable.Name = "my-routetable"
+	testCase.subnets[0].RouteTable.Routes = []Route{{Name: "on-premises", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway}}
+	testCase.subnets[1].RouteTable.Name = "my-routetable"
+
+	t.Run(testCase.name, func(t *testing.T) {
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[1].routeTable.routes"))
+	})
+}
+
 func TestSubnetNameValid(t *testing.T) {
 	g := NewWithT(t)
 
@@ -593,6 +622,24 @@ func TestValidateSubnetCIDR(t *testing.T) {
 			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "11.1.0.0/16"},
 			wantErr:          false,
 		},
+		{
+			name:             "dual-stack subnet cidrs in dual-stack vnet",
+			vnetCidrBlocks:   []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+			subnetCidrBlocks: []string{"10.1.0.0/16", "2001:1234:5678:9a40::/64"},
+			wantErr:          false,
+		},
+		{
+			name:             "ipv6 subnet cidr in ipv4 only vnet",
+			vnetCidrBlocks:   []string{"10.0.0.0/8"},
+			subnetCidrBlocks: []string{"10.1.0.0/16", "2001:1234:5678:9a40::/64"},
+			wantErr:          true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets.cidrBlocks",
+				BadValue: "2001:1234:5678:9a40::/64",
+				Detail:   "subnet CIDR not in vnet address space: [10.0.0.0/8]",
+			},
+		},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -642,6 +689,138 @@ func TestValidateSecurityRule(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "security rule - valid dual-stack sources",
+			validRule: SecurityRule{
+				Name:         "allow_apiserver",
+				Description:  "Allow K8s API Server",
+				Priority:     101,
+				Sources:      []string{"10.0.0.0/16", "2001:1234:5678:9a00::/56"},
+				Destinations: []string{"10.1.0.0/16", "2001:1234:5678:9b00::/56"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - invalid source and sources",
+			validRule: SecurityRule{
+				Name:        "allow_apiserver",
+				Description: "Allow K8s API Server",
+				Priority:    101,
+				Source:      pointer.String("*"),
+				Sources:     []string{"10.0.0.0/16", "2001:1234:5678:9a00::/56"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - valid port range",
+			validRule: SecurityRule{
+				Name:             "allow_nodeports",
+				Priority:         101,
+				Protocol:         SecurityGroupProtocolTCP,
+				SourcePorts:      pointer.String("*"),
+				DestinationPorts: pointer.String("30000-32767"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - invalid port range",
+			validRule: SecurityRule{
+				Name:             "allow_nodeports",
+				Priority:         101,
+				Protocol:         SecurityGroupProtocolTCP,
+				DestinationPorts: pointer.String("32767-30000"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - port out of range",
+			validRule: SecurityRule{
+				Name:             "allow_nodeports",
+				Priority:         101,
+				Protocol:         SecurityGroupProtocolTCP,
+				DestinationPorts: pointer.String("65536"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid port list",
+			validRule: SecurityRule{
+				Name:        "allow_nodeports",
+				Priority:    101,
+				Protocol:    SecurityGroupProtocolTCP,
+				SourcePorts: pointer.String("80,443"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - icmp with ports",
+			validRule: SecurityRule{
+				Name:             "allow_ping",
+				Priority:         101,
+				Protocol:         SecurityGroupProtocolICMP,
+				DestinationPorts: pointer.String("22"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid protocol",
+			validRule: SecurityRule{
+				Name:     "allow_sctp",
+				Priority: 101,
+				Protocol: SecurityGroupProtocol("Sctp"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid direction",
+			validRule: SecurityRule{
+				Name:      "allow_apiserver",
+				Priority:  101,
+				Direction: SecurityRuleDirection("Both"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid destination and destinations",
+			validRule: SecurityRule{
+				Name:         "allow_apiserver",
+				Description:  "Allow K8s API Server",
+				Priority:     101,
+				Destination:  pointer.String("*"),
+				Destinations: []string{"10.0.0.0/16", "2001:1234:5678:9a00::/56"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - valid application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_backend",
+				Priority:                             101,
+				SourceApplicationSecurityGroups:      []string{"frontend"},
+				DestinationApplicationSecurityGroups: []string{"backend"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - invalid source and source application security groups",
+			validRule: SecurityRule{
+				Name:                            "allow_backend",
+				Priority:                        101,
+				Source:                          pointer.String("*"),
+				SourceApplicationSecurityGroups: []string{"frontend"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid destinations and destination application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_backend",
+				Priority:                             101,
+				Destinations:                         []string{"10.0.0.0/16"},
+				DestinationApplicationSecurityGroups: []string{"backend"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, testCase := range tests {
 		testCase := testCase
@@ -660,6 +839,101 @@ func TestValidateSecurityRule(t *testing.T) {
 	}
 }
 
+func TestValidateSecurityRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       SecurityRules
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "same priority in different directions",
+			rules: SecurityRules{
+				{Name: "allow_ssh", Priority: 2200, Direction: SecurityRuleDirectionInbound},
+				{Name: "allow_egress", Priority: 2200, Direction: SecurityRuleDirectionOutbound},
+			},
+			wantErr: false,
+		},
+		{
+			name: "same priority in the same direction",
+			rules: SecurityRules{
+				{Name: "allow_ssh", Priority: 2200, Direction: SecurityRuleDirectionInbound},
+				{Name: "allow_apiserver", Priority: 2200},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "securityRules[1].priority",
+				BadValue: int32(2200),
+				Detail:   "priority is already used by inbound rule allow_ssh",
+			},
+		},
+		{
+			name: "duplicate rule names",
+			rules: SecurityRules{
+				{Name: "allow_ssh", Priority: 2200},
+				{Name: "Allow_SSH", Priority: 2201},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "securityRules[1].name",
+				BadValue: "Allow_SSH",
+			},
+		},
+		{
+			name: "invalid rule",
+			rules: SecurityRules{
+				{Name: "allow_ssh", Priority: 2200, DestinationPorts: pointer.String("ssh")},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "securityRules[0].destinationPorts",
+				BadValue: "ssh",
+				Detail:   "ports must be *, a port or a range of ports between 0 and 65535, e.g. 8000-8080",
+			},
+		},
+		{
+			name: "invalid application security group name",
+			rules: SecurityRules{
+				{Name: "allow_backend", Priority: 2200, DestinationApplicationSecurityGroups: []string{"back end"}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "securityRules[0].destinationApplicationSecurityGroups[0]",
+				BadValue: "back end",
+				Detail:   "name of application security group doesn't match regex ^[-\\w\\._]+$",
+			},
+		},
+		{
+			name: "duplicate application security group names",
+			rules: SecurityRules{
+				{Name: "allow_backend", Priority: 2200, SourceApplicationSecurityGroups: []string{"frontend", "Frontend"}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "securityRules[0].sourceApplicationSecurityGroups[1]",
+				BadValue: "Frontend",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateSecurityRules(testCase.rules, field.NewPath("securityRules"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateAPIServerLB(t *testing.T) {
 	g := NewWithT(t)
 
@@ -859,81 +1133,375 @@ func TestValidateAPIServerLB(t *testing.T) {
 			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
 			wantErr: false,
 		},
-	}
-
-	for _, test := range testcases {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
-			err := validateAPIServerLB(test.lb, test.old, test.cpCIDRS, field.NewPath("apiServerLB"))
-			if test.wantErr {
-				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
-			} else {
-				g.Expect(err).To(BeEmpty())
-			}
-		})
-	}
-}
-func TestPrivateDNSZoneName(t *testing.T) {
-	g := NewWithT(t)
-
-	testcases := []struct {
-		name        string
-		network     NetworkSpec
-		wantErr     bool
-		expectedErr field.Error
-	}{
 		{
-			name: "testInvalidPrivateDNSZoneName",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "wrong@d_ns.io",
+			name: "internal LB private IP claimed from an IPAM pool",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:            "ip-1",
+						AddressFromPool: &corev1.TypedLocalObjectReference{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool"},
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.10",
+						},
+					},
 				},
-				APIServerLB: createValidAPIServerInternalLB(),
-			},
-			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "spec.networkSpec.privateDNSZoneName",
-				BadValue: "wrong@d_ns.io",
-				Detail:   "PrivateDNSZoneName can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character",
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				Name: "my-private-lb",
 			},
-			wantErr: true,
-		},
-		{
-			name: "testValidPrivateDNSZoneName",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "good.dns.io",
+			old: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:            "ip-1",
+						AddressFromPool: &corev1.TypedLocalObjectReference{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool"},
+					},
 				},
-				APIServerLB: createValidAPIServerInternalLB(),
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				Name: "my-private-lb",
 			},
+			cpCIDRS: []string{"10.0.0.0/24"},
 			wantErr: false,
 		},
 		{
-			name: "testValidPrivateDNSZoneNameWithUnderscore",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "_good.__dns.io",
+			name: "public LB with a private frontend IP",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "ip-1",
+						PublicIP: &PublicIPSpec{Name: "pip-1"},
+					},
+					{
+						Name: "ip-2",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.100",
+						},
+					},
 				},
-				APIServerLB: createValidAPIServerInternalLB(),
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
 			},
+			cpCIDRS: []string{"10.0.0.0/24"},
 			wantErr: false,
 		},
 		{
-			name: "testBadAPIServerLBType",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "good.dns.io",
-				},
-				APIServerLB: LoadBalancerSpec{
-					Name: "my-lb",
-					LoadBalancerClassSpec: LoadBalancerClassSpec{
-						Type: Public,
+			name: "public LB with two public frontend IPs",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "ip-1",
+						PublicIP: &PublicIPSpec{Name: "pip-1"},
 					},
+					{
+						Name:     "ip-2",
+						PublicIP: &PublicIPSpec{Name: "pip-2"},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
 				},
+				Name: "my-public-lb",
 			},
+			wantErr: true,
 			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.frontendIPConfigs[1].publicIP",
+				Detail: "The second frontend IP of a Public Load Balancer must only have a Private IP",
+			},
+		},
+		{
+			name: "public LB with an out of range private frontend IP",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "ip-1",
+						PublicIP: &PublicIPSpec{Name: "pip-1"},
+					},
+					{
+						Name: "ip-2",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "20.1.2.3",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			cpCIDRS: []string{"10.0.0.0/24"},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[1].privateIP",
+				BadValue: "20.1.2.3",
+				Detail:   "Internal LB IP address needs to be in control plane subnet range ([10.0.0.0/24])",
+			},
+		},
+		{
+			name: "public LB with a private frontend IP using the same name",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "ip-1",
+						PublicIP: &PublicIPSpec{Name: "pip-1"},
+					},
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.100",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			cpCIDRS: []string{"10.0.0.0/24"},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "apiServerLB.frontendIPConfigs[1].name",
+				BadValue: "ip-1",
+			},
+		},
+		{
+			name: "private frontend IP added after creation",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "ip-1",
+						PublicIP: &PublicIPSpec{Name: "pip-1"},
+					},
+					{
+						Name: "ip-2",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.100",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			old: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "ip-1",
+						PublicIP: &PublicIPSpec{Name: "pip-1"},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			cpCIDRS: []string{"10.0.0.0/24"},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.frontendIPConfigs",
+				Detail: "API Server load balancer frontend IPs should not be added or removed after AzureCluster creation.",
+			},
+		},
+		{
+			name: "public LB with IPAM pool",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:            "ip-1",
+						AddressFromPool: &corev1.TypedLocalObjectReference{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool"},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				Name: "my-public-lb",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.frontendIPConfigs[0].addressFromPool",
+				Detail: "Public Load Balancers cannot claim a Private IP from an IPAM pool",
+			},
+		},
+		{
+			name: "internal LB in a subnet that isn't one of the cluster subnets",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "172.16.0.10",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:       Internal,
+					SKU:        SKUStandard,
+					SubnetName: "ilb-subnet",
+				},
+				Name: "my-private-lb",
+			},
+			wantErr: false,
+		},
+		{
+			name: "internal LB in another subnet with an invalid IP",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "172.16.0",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:       Internal,
+					SKU:        SKUStandard,
+					SubnetName: "ilb-subnet",
+				},
+				Name: "my-private-lb",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].privateIP",
+				BadValue: "172.16.0",
+				Detail:   "Internal LB IP address isn't a valid IPv4 or IPv6 address",
+			},
+		},
+		{
+			name: "internal LB subnet updated after creation",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.100",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:       Internal,
+					SKU:        SKUStandard,
+					SubnetName: "ilb-subnet",
+				},
+				Name: "my-private-lb",
+			},
+			old: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.0.0.100",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				Name: "my-private-lb",
+			},
+			cpCIDRS: []string{"10.0.0.0/24"},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.subnetName",
+				Detail: "API Server load balancer subnet name should not be modified after AzureCluster creation.",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateAPIServerLB(test.lb, test.old, test.cpCIDRS, field.NewPath("apiServerLB"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+func TestPrivateDNSZoneName(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		network     NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "testInvalidPrivateDNSZoneName",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "wrong@d_ns.io",
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneName",
+				BadValue: "wrong@d_ns.io",
+				Detail:   "PrivateDNSZoneName can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character",
+			},
+			wantErr: true,
+		},
+		{
+			name: "testValidPrivateDNSZoneName",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "good.dns.io",
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "testValidPrivateDNSZoneNameWithUnderscore",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "_good.__dns.io",
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "testBadAPIServerLBType",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "good.dns.io",
+				},
+				APIServerLB: LoadBalancerSpec{
+					Name: "my-lb",
+					LoadBalancerClassSpec: LoadBalancerClassSpec{
+						Type: Public,
+					},
+				},
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
 				Field:    "spec.networkSpec.privateDNSZoneName",
 				BadValue: "Public",
 				Detail:   "PrivateDNSZoneName is available only if APIServerLB.Type is Internal",
@@ -942,15 +1510,797 @@ func TestPrivateDNSZoneName(t *testing.T) {
 		},
 	}
 
-	for _, test := range testcases {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
-			err := validatePrivateDNSZoneName(test.network.PrivateDNSZoneName, test.network.APIServerLB.Type, field.NewPath("spec", "networkSpec", "privateDNSZoneName"))
-			if test.wantErr {
-				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validatePrivateDNSZoneName(test.network.PrivateDNSZoneName, test.network.APIServerLB.Type, field.NewPath("spec", "networkSpec", "privateDNSZoneName"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateDNSZone(t *testing.T) {
+	const zoneID = "/subscriptions/123/resourceGroups/my-dns-rg/providers/Microsoft.Network/privateDnsZones/my.dns.io"
+	testcases := []struct {
+		name             string
+		networkClassSpec NetworkClassSpec
+		lbType           LBType
+		wantErr          bool
+	}{
+		{
+			name:             "empty is valid",
+			networkClassSpec: NetworkClassSpec{},
+			lbType:           Public,
+			wantErr:          false,
+		},
+		{
+			name:             "system mode",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: PrivateDNSZoneModeSystem},
+			lbType:           Internal,
+			wantErr:          false,
+		},
+		{
+			name:             "none mode",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: PrivateDNSZoneModeNone},
+			lbType:           Internal,
+			wantErr:          false,
+		},
+		{
+			name:             "existing zone",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: zoneID},
+			lbType:           Internal,
+			wantErr:          false,
+		},
+		{
+			name:             "existing zone with matching name",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: zoneID, PrivateDNSZoneName: "my.dns.io"},
+			lbType:           Internal,
+			wantErr:          false,
+		},
+		{
+			name:             "existing zone with different name",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: zoneID, PrivateDNSZoneName: "other.dns.io"},
+			lbType:           Internal,
+			wantErr:          true,
+		},
+		{
+			name:             "invalid mode",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: "Managed"},
+			lbType:           Internal,
+			wantErr:          true,
+		},
+		{
+			name:             "resource ID of another resource type",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: "/subscriptions/123/resourceGroups/my-dns-rg/providers/Microsoft.Network/dnsZones/my.dns.io"},
+			lbType:           Internal,
+			wantErr:          true,
+		},
+		{
+			name:             "public API server",
+			networkClassSpec: NetworkClassSpec{PrivateDNSZone: PrivateDNSZoneModeNone},
+			lbType:           Public,
+			wantErr:          true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validatePrivateDNSZone(tc.networkClassSpec, tc.lbType, field.NewPath("spec", "networkSpec"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateBastionSpec(t *testing.T) {
+	testcases := []struct {
+		name    string
+		bastion *AzureBastion
+		wantErr bool
+	}{
+		{
+			name:    "no azure bastion",
+			bastion: nil,
+			wantErr: false,
+		},
+		{
+			name:    "basic azure bastion",
+			bastion: &AzureBastion{Sku: BasicBastionHostSku},
+			wantErr: false,
+		},
+		{
+			name: "standard azure bastion with scale units and features",
+			bastion: &AzureBastion{
+				Sku:                 StandardBastionHostSku,
+				ScaleUnits:          pointer.Int32(10),
+				EnableTunneling:     true,
+				EnableIPConnect:     true,
+				EnableShareableLink: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "basic azure bastion with tunneling",
+			bastion: &AzureBastion{Sku: BasicBastionHostSku, EnableTunneling: true},
+			wantErr: true,
+		},
+		{
+			name:    "basic azure bastion with IP connect",
+			bastion: &AzureBastion{Sku: BasicBastionHostSku, EnableIPConnect: true},
+			wantErr: true,
+		},
+		{
+			name:    "basic azure bastion with shareable links",
+			bastion: &AzureBastion{Sku: BasicBastionHostSku, EnableShareableLink: true},
+			wantErr: true,
+		},
+		{
+			name:    "basic azure bastion with scale units",
+			bastion: &AzureBastion{Sku: BasicBastionHostSku, ScaleUnits: pointer.Int32(2)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateBastionSpec(BastionSpec{AzureBastion: tc.bastion}, field.NewPath("spec", "bastionSpec"))
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name    string
+		ppgs    []ProximityPlacementGroup
+		wantErr bool
+	}{
+		{
+			name:    "no proximity placement groups",
+			ppgs:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "valid proximity placement groups",
+			ppgs:    []ProximityPlacementGroup{{Name: "my-ppg"}, {Name: "my_other.ppg"}},
+			wantErr: false,
+		},
+		{
+			name:    "empty name",
+			ppgs:    []ProximityPlacementGroup{{Name: ""}},
+			wantErr: true,
+		},
+		{
+			name:    "name too long",
+			ppgs:    []ProximityPlacementGroup{{Name: strings.Repeat("a", 81)}},
+			wantErr: true,
+		},
+		{
+			name:    "name with invalid characters",
+			ppgs:    []ProximityPlacementGroup{{Name: "my@ppg"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate names",
+			ppgs:    []ProximityPlacementGroup{{Name: "my-ppg"}, {Name: "my-ppg"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateProximityPlacementGroups(tc.ppgs, field.NewPath("spec", "proximityPlacementGroups"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNatGatewayClassSpec(t *testing.T) {
+	testcases := []struct {
+		name       string
+		natGateway NatGatewayClassSpec
+		wantErr    bool
+	}{
+		{
+			name:       "name only",
+			natGateway: NatGatewayClassSpec{Name: "my-natgw"},
+			wantErr:    false,
+		},
+		{
+			name: "valid idle timeout, zone and public IP prefix",
+			natGateway: NatGatewayClassSpec{
+				Name:                 "my-natgw",
+				IdleTimeoutInMinutes: pointer.Int32(120),
+				Zones:                []string{"1"},
+				PublicIPPrefixes:     []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "idle timeout too low",
+			natGateway: NatGatewayClassSpec{
+				Name:                 "my-natgw",
+				IdleTimeoutInMinutes: pointer.Int32(3),
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle timeout too high",
+			natGateway: NatGatewayClassSpec{
+				Name:                 "my-natgw",
+				IdleTimeoutInMinutes: pointer.Int32(121),
+			},
+			wantErr: true,
+		},
+		{
+			name: "more than one zone",
+			natGateway: NatGatewayClassSpec{
+				Name:  "my-natgw",
+				Zones: []string{"1", "2"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "public IP prefix is not a resource ID",
+			natGateway: NatGatewayClassSpec{
+				Name:             "my-natgw",
+				PublicIPPrefixes: []string{"my-prefix"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "public IP prefix is the ID of another resource type",
+			natGateway: NatGatewayClassSpec{
+				Name:             "my-natgw",
+				PublicIPPrefixes: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateNatGatewayClassSpec(tc.natGateway, field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("natGateway"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateOutboundType(t *testing.T) {
+	testcases := []struct {
+		name        string
+		networkSpec func() NetworkSpec
+		wantErr     bool
+	}{
+		{
+			name:        "load balancer outbound type is not checked",
+			networkSpec: createValidNetworkSpec,
+			wantErr:     false,
+		},
+		{
+			name: "user defined routing with node route table",
+			networkSpec: func() NetworkSpec {
+				networkSpec := createValidNetworkSpec()
+				networkSpec.OutboundType = UserDefinedRoutingOutboundType
+				networkSpec.NodeOutboundLB = nil
+				networkSpec.Subnets[1].RouteTable.Name = "my-firewall-routetable"
+				return networkSpec
+			},
+			wantErr: false,
+		},
+		{
+			name: "user defined routing with node outbound load balancer",
+			networkSpec: func() NetworkSpec {
+				networkSpec := createValidNetworkSpec()
+				networkSpec.OutboundType = UserDefinedRoutingOutboundType
+				networkSpec.Subnets[1].RouteTable.Name = "my-firewall-routetable"
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "user defined routing with NAT gateway",
+			networkSpec: func() NetworkSpec {
+				networkSpec := createValidNetworkSpec()
+				networkSpec.OutboundType = UserDefinedRoutingOutboundType
+				networkSpec.NodeOutboundLB = nil
+				networkSpec.Subnets[1].RouteTable.Name = "my-firewall-routetable"
+				networkSpec.Subnets[1].NatGateway.Name = "my-natgw"
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "user defined routing without node route table",
+			networkSpec: func() NetworkSpec {
+				networkSpec := createValidNetworkSpec()
+				networkSpec.OutboundType = UserDefinedRoutingOutboundType
+				networkSpec.NodeOutboundLB = nil
+				return networkSpec
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateOutboundType(tc.networkSpec(), field.NewPath("spec", "networkSpec"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAzureFirewall(t *testing.T) {
+	validFirewall := func() NetworkSpec {
+		networkSpec := createValidNetworkSpec()
+		networkSpec.OutboundType = UserDefinedRoutingOutboundType
+		networkSpec.AzureFirewall = &AzureFirewall{
+			Name: "my-firewall",
+			Subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{
+					Name:       DefaultAzureFirewallSubnetName,
+					CIDRBlocks: []string{DefaultAzureFirewallSubnetCIDR},
+					Role:       SubnetFirewall,
+				},
+			},
+		}
+		return networkSpec
+	}
+	testcases := []struct {
+		name        string
+		networkSpec func() NetworkSpec
+		wantErr     bool
+	}{
+		{
+			name:        "no firewall",
+			networkSpec: createValidNetworkSpec,
+			wantErr:     false,
+		},
+		{
+			name:        "valid firewall",
+			networkSpec: validFirewall,
+			wantErr:     false,
+		},
+		{
+			name: "firewall with larger subnet",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validFirewall()
+				networkSpec.AzureFirewall.Subnet.CIDRBlocks = []string{"10.255.0.0/24"}
+				return networkSpec
+			},
+			wantErr: false,
+		},
+		{
+			name: "firewall with load balancer outbound type",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validFirewall()
+				networkSpec.OutboundType = LoadBalancerOutboundType
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "firewall with invalid subnet name",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validFirewall()
+				networkSpec.AzureFirewall.Subnet.Name = "my-firewall-subnet"
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "firewall with subnet smaller than /26",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validFirewall()
+				networkSpec.AzureFirewall.Subnet.CIDRBlocks = []string{"10.255.255.224/27"}
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "firewall with a declared default route on a node subnet",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validFirewall()
+				networkSpec.Subnets[1].RouteTable.Routes = []Route{
+					{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet},
+				}
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "firewall with invalid subnet CIDR",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validFirewall()
+				networkSpec.AzureFirewall.Subnet.CIDRBlocks = []string{"10.255.255.128"}
+				return networkSpec
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateAzureFirewall(tc.networkSpec(), field.NewPath("spec", "networkSpec"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVirtualNetworkGateway(t *testing.T) {
+	validGateway := func() *VirtualNetworkGateway {
+		return &VirtualNetworkGateway{
+			Name: "my-gateway",
+			Subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{
+					Name:       DefaultVirtualNetworkGatewaySubnetName,
+					CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+					Role:       SubnetGateway,
+				},
+			},
+			GatewayType: VpnVirtualNetworkGatewayType,
+			SKU:         DefaultVpnGatewaySKU,
+		}
+	}
+	testcases := []struct {
+		name    string
+		gateway func() *VirtualNetworkGateway
+		wantErr bool
+	}{
+		{
+			name:    "no gateway",
+			gateway: func() *VirtualNetworkGateway { return nil },
+			wantErr: false,
+		},
+		{
+			name:    "valid VPN gateway",
+			gateway: validGateway,
+			wantErr: false,
+		},
+		{
+			name: "valid ExpressRoute gateway",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.GatewayType = ExpressRouteVirtualNetworkGatewayType
+				gateway.SKU = "ErGw2AZ"
+				return gateway
+			},
+			wantErr: false,
+		},
+		{
+			name: "ExpressRoute SKU on VPN gateway",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.SKU = "ErGw1AZ"
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "VPN SKU on ExpressRoute gateway",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.GatewayType = ExpressRouteVirtualNetworkGatewayType
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "basic SKU",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.SKU = "Basic"
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway with invalid subnet name",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.Subnet.Name = "my-gateway-subnet"
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway with subnet smaller than /29",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.Subnet.CIDRBlocks = []string{"10.255.255.192/30"}
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway with invalid subnet CIDR",
+			gateway: func() *VirtualNetworkGateway {
+				gateway := validGateway()
+				gateway.Subnet.CIDRBlocks = []string{"10.255.255.192"}
+				return gateway
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateVirtualNetworkGateway(tc.gateway(), field.NewPath("spec", "networkSpec", "virtualNetworkGateway"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerPrivateLinkService(t *testing.T) {
+	validNetworkSpec := func() NetworkSpec {
+		return NetworkSpec{
+			APIServerLB: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Internal},
+			},
+			Subnets: Subnets{
+				{SubnetClassSpec: SubnetClassSpec{Name: "my-cp-subnet", Role: SubnetControlPlane}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "AzureBastionSubnet", Role: SubnetBastion}},
+			},
+			APIServerPrivateLinkService: &PrivateLinkService{
+				Name:                      "my-pls",
+				SubnetName:                "my-cp-subnet",
+				AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"},
+				AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		}
+	}
+	testcases := []struct {
+		name        string
+		networkSpec func() NetworkSpec
+		wantErr     bool
+	}{
+		{
+			name: "no private link service",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService = nil
+				return networkSpec
+			},
+			wantErr: false,
+		},
+		{
+			name:        "valid private link service",
+			networkSpec: validNetworkSpec,
+			wantErr:     false,
+		},
+		{
+			name: "all subscriptions allowed",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.AllowedSubscriptions = []string{"*"}
+				return networkSpec
+			},
+			wantErr: false,
+		},
+		{
+			name: "public API server load balancer",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerLB.Type = Public
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing subnet name",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.SubnetName = ""
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown subnet",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.SubnetName = "my-other-subnet"
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "bastion subnet",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.SubnetName = "AzureBastionSubnet"
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid allowed subscription",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.AllowedSubscriptions = []string{"my-subscription"}
+				networkSpec.APIServerPrivateLinkService.AutoApprovedSubscriptions = nil
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "wildcard auto-approved subscription",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.AllowedSubscriptions = []string{"*"}
+				networkSpec.APIServerPrivateLinkService.AutoApprovedSubscriptions = []string{"*"}
+				return networkSpec
+			},
+			wantErr: true,
+		},
+		{
+			name: "auto-approved subscription not allowed",
+			networkSpec: func() NetworkSpec {
+				networkSpec := validNetworkSpec()
+				networkSpec.APIServerPrivateLinkService.AutoApprovedSubscriptions = []string{"00000000-0000-0000-0000-000000000003"}
+				return networkSpec
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateAPIServerPrivateLinkService(tc.networkSpec(), field.NewPath("spec", "networkSpec", "apiServerPrivateLinkService"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateApplicationGateway(t *testing.T) {
+	validGateway := func() *ApplicationGatewaySpec {
+		return &ApplicationGatewaySpec{
+			Name: "my-appgw",
+			Subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{
+					Name:       DefaultApplicationGatewaySubnetName,
+					CIDRBlocks: []string{DefaultApplicationGatewaySubnetCIDR},
+					Role:       SubnetApplicationGateway,
+				},
+			},
+			SKUTier: StandardV2ApplicationGatewaySKUTier,
+		}
+	}
+	testcases := []struct {
+		name    string
+		gateway func() *ApplicationGatewaySpec
+		wantErr bool
+	}{
+		{
+			name:    "no gateway",
+			gateway: func() *ApplicationGatewaySpec { return nil },
+			wantErr: false,
+		},
+		{
+			name:    "valid Standard_v2 gateway",
+			gateway: validGateway,
+			wantErr: false,
+		},
+		{
+			name: "valid WAF_v2 gateway",
+			gateway: func() *ApplicationGatewaySpec {
+				gateway := validGateway()
+				gateway.SKUTier = WAFV2ApplicationGatewaySKUTier
+				gateway.FirewallMode = DetectionApplicationGatewayFirewallMode
+				return gateway
+			},
+			wantErr: false,
+		},
+		{
+			name: "firewall mode on Standard_v2 gateway",
+			gateway: func() *ApplicationGatewaySpec {
+				gateway := validGateway()
+				gateway.FirewallMode = PreventionApplicationGatewayFirewallMode
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid autoscaling bounds",
+			gateway: func() *ApplicationGatewaySpec {
+				gateway := validGateway()
+				gateway.MinCapacity = pointer.Int32(2)
+				gateway.MaxCapacity = pointer.Int32(10)
+				return gateway
+			},
+			wantErr: false,
+		},
+		{
+			name: "min capacity greater than max capacity",
+			gateway: func() *ApplicationGatewaySpec {
+				gateway := validGateway()
+				gateway.MinCapacity = pointer.Int32(10)
+				gateway.MaxCapacity = pointer.Int32(2)
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway with node subnet role",
+			gateway: func() *ApplicationGatewaySpec {
+				gateway := validGateway()
+				gateway.Subnet.Role = SubnetNode
+				return gateway
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway with invalid subnet CIDR",
+			gateway: func() *ApplicationGatewaySpec {
+				gateway := validGateway()
+				gateway.Subnet.CIDRBlocks = []string{"10.255.254.0"}
+				return gateway
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateApplicationGateway(tc.gateway(), field.NewPath("spec", "applicationGateway"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
 			} else {
-				g.Expect(err).To(BeEmpty())
+				g.Expect(errs).To(BeEmpty())
 			}
 		})
 	}
@@ -1195,6 +2545,161 @@ func TestValidateControlPlaneNodeOutboundLB(t *testing.T) {
 	}
 }
 
+func TestValidateAllocatedOutboundPorts(t *testing.T) {
+	testcases := []struct {
+		name    string
+		lb      LoadBalancerClassSpec
+		wantErr bool
+	}{
+		{
+			name:    "allocated outbound ports not set",
+			lb:      LoadBalancerClassSpec{Type: Public},
+			wantErr: false,
+		},
+		{
+			name:    "valid allocated outbound ports",
+			lb:      LoadBalancerClassSpec{Type: Public, AllocatedOutboundPorts: pointer.Int32(1024)},
+			wantErr: false,
+		},
+		{
+			name:    "zero allocated outbound ports",
+			lb:      LoadBalancerClassSpec{Type: Public, AllocatedOutboundPorts: pointer.Int32(0)},
+			wantErr: false,
+		},
+		{
+			name:    "allocated outbound ports not a multiple of 8",
+			lb:      LoadBalancerClassSpec{Type: Public, AllocatedOutboundPorts: pointer.Int32(1001)},
+			wantErr: true,
+		},
+		{
+			name:    "too many allocated outbound ports",
+			lb:      LoadBalancerClassSpec{Type: Public, AllocatedOutboundPorts: pointer.Int32(64008)},
+			wantErr: true,
+		},
+		{
+			name:    "negative allocated outbound ports",
+			lb:      LoadBalancerClassSpec{Type: Public, AllocatedOutboundPorts: pointer.Int32(-8)},
+			wantErr: true,
+		},
+		{
+			name:    "allocated outbound ports on internal load balancer",
+			lb:      LoadBalancerClassSpec{Type: Internal, AllocatedOutboundPorts: pointer.Int32(1024)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateAllocatedOutboundPorts(tc.lb, field.NewPath("nodeOutboundLB"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNodeOutboundLBMode(t *testing.T) {
+	publicAPIServerLB := LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}}
+	internalAPIServerLB := LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Internal}}
+
+	testcases := []struct {
+		name        string
+		lb          *LoadBalancerSpec
+		old         *LoadBalancerSpec
+		apiServerLB LoadBalancerSpec
+		wantErr     bool
+	}{
+		{
+			name:        "no node outbound lb",
+			lb:          nil,
+			apiServerLB: publicAPIServerLB,
+			wantErr:     false,
+		},
+		{
+			name: "dedicated node outbound lb",
+			lb: &LoadBalancerSpec{
+				FrontendIPsCount:      pointer.Int32(2),
+				LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeDedicated, IdleTimeoutInMinutes: pointer.Int32(10)},
+			},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     false,
+		},
+		{
+			name:        "node outbound lb shared with a public api server lb",
+			lb:          &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeShared}},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     false,
+		},
+		{
+			name:        "node outbound lb shared with an internal api server lb",
+			lb:          &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeShared}},
+			apiServerLB: internalAPIServerLB,
+			wantErr:     true,
+		},
+		{
+			name:        "disabled node outbound lb",
+			lb:          &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeDisabled}},
+			apiServerLB: internalAPIServerLB,
+			wantErr:     false,
+		},
+		{
+			name: "disabled node outbound lb with frontend IPs",
+			lb: &LoadBalancerSpec{
+				FrontendIPsCount:      pointer.Int32(1),
+				LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeDisabled},
+			},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     true,
+		},
+		{
+			name: "shared node outbound lb with outbound settings",
+			lb: &LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeShared, AllocatedOutboundPorts: pointer.Int32(1024)},
+			},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     true,
+		},
+		{
+			name:        "defaulted mode set to dedicated",
+			lb:          &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeDedicated}},
+			old:         &LoadBalancerSpec{},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     false,
+		},
+		{
+			name:        "mode updated from dedicated to disabled",
+			lb:          &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeDisabled}},
+			old:         &LoadBalancerSpec{},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     true,
+		},
+		{
+			name:        "mode updated from shared to disabled",
+			lb:          &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeDisabled}},
+			old:         &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Mode: NodeOutboundLBModeShared}},
+			apiServerLB: publicAPIServerLB,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateNodeOutboundLBMode(tc.lb, tc.old, tc.apiServerLB, field.NewPath("nodeOutboundLB"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateCloudProviderConfigOverrides(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1257,28 +2762,237 @@ func TestValidateCloudProviderConfigOverrides(t *testing.T) {
 			},
 		},
 		{
-			name: "old config is nil",
-			newConfig: &CloudProviderConfigOverrides{RateLimits: []RateLimitSpec{{
-				Name:   "foo",
-				Config: RateLimitConfig{CloudProviderRateLimitBucket: 10, CloudProviderRateLimit: true},
-			}}},
+			name: "old config is nil",
+			newConfig: &CloudProviderConfigOverrides{RateLimits: []RateLimitSpec{{
+				Name:   "foo",
+				Config: RateLimitConfig{CloudProviderRateLimitBucket: 10, CloudProviderRateLimit: true},
+			}}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:  "FieldValueInvalid",
+				Field: "spec.cloudProviderConfigOverrides",
+				BadValue: &CloudProviderConfigOverrides{RateLimits: []RateLimitSpec{{
+					Name:   "foo",
+					Config: RateLimitConfig{CloudProviderRateLimitBucket: 10, CloudProviderRateLimit: true},
+				}}},
+				Detail: "cannot change cloudProviderConfigOverrides cluster creation",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateCloudProviderConfigOverrides(testCase.oldConfig, testCase.newConfig, field.NewPath("spec.cloudProviderConfigOverrides"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateCloudProviderCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		credentials *CloudProviderCredentials
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "nil credentials",
+			wantErr: false,
+		},
+		{
+			name: "client certificate with a certificate path",
+			credentials: &CloudProviderCredentials{
+				Type:              ClientCertificateCloudProviderCredentials,
+				AADClientCertPath: "/etc/kubernetes/certs/sp.pfx",
+			},
+			wantErr: false,
+		},
+		{
+			name: "managed identity with a user-assigned identity",
+			credentials: &CloudProviderCredentials{
+				Type:                   ManagedIdentityCloudProviderCredentials,
+				UserAssignedIdentityID: "foo",
+			},
+			wantErr: false,
+		},
+		{
+			name: "client secret with a certificate path",
+			credentials: &CloudProviderCredentials{
+				Type:              ClientSecretCloudProviderCredentials,
+				AADClientCertPath: "/etc/kubernetes/certs/sp.pfx",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.cloudProviderConfigOverrides.credentials.aadClientCertPath",
+				Detail: "aadClientCertPath can only be set with the ClientCertificate type",
+			},
+		},
+		{
+			name: "client certificate with a user-assigned identity",
+			credentials: &CloudProviderCredentials{
+				Type:                   ClientCertificateCloudProviderCredentials,
+				UserAssignedIdentityID: "foo",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.cloudProviderConfigOverrides.credentials.userAssignedIdentityID",
+				Detail: "userAssignedIdentityID can only be set with the ManagedIdentity type",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateCloudProviderCredentials(testCase.credentials, field.NewPath("spec", "cloudProviderConfigOverrides", "credentials"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateCloudProviderExtraConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		extraConfig map[string]apiextensionsv1.JSON
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "nil extra config",
+			wantErr: false,
+		},
+		{
+			name: "valid extra config",
+			extraConfig: map[string]apiextensionsv1.JSON{
+				"excludeMasterFromStandardLB": {Raw: []byte("false")},
+				"primaryScaleSetName":         {Raw: []byte(`"foo"`)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty key",
+			extraConfig: map[string]apiextensionsv1.JSON{
+				"": {Raw: []byte("false")},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.cloudProviderConfigOverrides.extraConfig",
+				BadValue: "",
+				Detail:   "keys cannot be empty",
+			},
+		},
+		{
+			name: "credentials",
+			extraConfig: map[string]apiextensionsv1.JSON{
+				"AADClientSecret": {Raw: []byte(`"foo"`)},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.cloudProviderConfigOverrides.extraConfig[AADClientSecret]",
+				Detail: "cloud provider credentials cannot be set in extraConfig",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateCloudProviderExtraConfig(testCase.extraConfig, field.NewPath("spec", "cloudProviderConfigOverrides", "extraConfig"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateReconcilePolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name:    "no policy",
+			policy:  "",
+			wantErr: false,
+		},
+		{
+			name:    "adopt",
+			policy:  "Adopt",
+			wantErr: false,
+		},
+		{
+			name:    "skip",
+			policy:  "Skip",
+			wantErr: false,
+		},
+		{
+			name:    "delete policy",
+			policy:  "Abandon",
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy",
+			policy:  "Takeover",
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateReconcilePolicy(testCase.policy, []ReconcilePolicy{ReconcilePolicyAdopt, ReconcilePolicySkip}, reconcilePolicyAnnotationPath())
+			if testCase.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateDeletePolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name:    "no policy",
+			policy:  "",
+			wantErr: false,
+		},
+		{
+			name:    "abandon",
+			policy:  "Abandon",
+			wantErr: false,
+		},
+		{
+			name:    "reconcile policy",
+			policy:  "Adopt",
 			wantErr: true,
-			expectedErr: field.Error{
-				Type:  "FieldValueInvalid",
-				Field: "spec.cloudProviderConfigOverrides",
-				BadValue: &CloudProviderConfigOverrides{RateLimits: []RateLimitSpec{{
-					Name:   "foo",
-					Config: RateLimitConfig{CloudProviderRateLimitBucket: 10, CloudProviderRateLimit: true},
-				}}},
-				Detail: "cannot change cloudProviderConfigOverrides cluster creation",
-			},
 		},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			err := validateCloudProviderConfigOverrides(testCase.oldConfig, testCase.newConfig, field.NewPath("spec.cloudProviderConfigOverrides"))
+			err := validateDeletePolicy(testCase.policy, deletePolicyAnnotationPath())
 			if testCase.wantErr {
-				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+				g.Expect(err).NotTo(BeEmpty())
 			} else {
 				g.Expect(err).To(BeEmpty())
 			}
@@ -1297,6 +3011,97 @@ func createValidCluster() *AzureCluster {
 	}
 }
 
+func createValidClusterWithAzureFirewall() *AzureCluster {
+	cluster := createValidCluster()
+	cluster.Spec.NetworkSpec.OutboundType = UserDefinedRoutingOutboundType
+	cluster.Spec.NetworkSpec.NodeOutboundLB = nil
+	cluster.Spec.NetworkSpec.Subnets[1].RouteTable.Name = "my-node-routetable"
+	cluster.Spec.NetworkSpec.AzureFirewall = &AzureFirewall{
+		Name: "my-firewall",
+		Subnet: SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{
+				Name:       DefaultAzureFirewallSubnetName,
+				CIDRBlocks: []string{DefaultAzureFirewallSubnetCIDR},
+				Role:       SubnetFirewall,
+			},
+		},
+		PublicIP: PublicIPSpec{
+			Name: "my-firewall-pip",
+		},
+		SKUTier: StandardAzureFirewallSKUTier,
+	}
+	return cluster
+}
+
+func createValidClusterWithVirtualNetworkGateway() *AzureCluster {
+	cluster := createValidCluster()
+	cluster.Spec.NetworkSpec.VirtualNetworkGateway = &VirtualNetworkGateway{
+		Name: "my-gateway",
+		Subnet: SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{
+				Name:       DefaultVirtualNetworkGatewaySubnetName,
+				CIDRBlocks: []string{DefaultVirtualNetworkGatewaySubnetCIDR},
+				Role:       SubnetGateway,
+			},
+		},
+		PublicIP: PublicIPSpec{
+			Name: "my-gateway-pip",
+		},
+		GatewayType: VpnVirtualNetworkGatewayType,
+		SKU:         DefaultVpnGatewaySKU,
+	}
+	return cluster
+}
+
+func createValidClusterWithAzureBastion() *AzureCluster {
+	cluster := createValidCluster()
+	cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{
+		Name: "my-bastion",
+		Sku:  BasicBastionHostSku,
+	}
+	return cluster
+}
+
+func createValidClusterWithAPIServerPrivateLinkService() *AzureCluster {
+	cluster := createValidCluster()
+	cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{DefaultVnetCIDR}
+	cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{DefaultControlPlaneSubnetCIDR}
+	cluster.Spec.NetworkSpec.APIServerLB.Type = Internal
+	cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs = []FrontendIP{
+		{
+			Name: "ip-1",
+			FrontendIPClass: FrontendIPClass{
+				PrivateIPAddress: DefaultInternalLBIPAddress,
+			},
+		},
+	}
+	cluster.Spec.NetworkSpec.APIServerPrivateLinkService = &PrivateLinkService{
+		Name:                 "my-pls",
+		SubnetName:           "control-plane-subnet",
+		AllowedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+	}
+	return cluster
+}
+
+func createValidClusterWithApplicationGateway() *AzureCluster {
+	cluster := createValidCluster()
+	cluster.Spec.ApplicationGateway = &ApplicationGatewaySpec{
+		Name: "my-appgw",
+		Subnet: SubnetSpec{
+			SubnetClassSpec: SubnetClassSpec{
+				Name:       DefaultApplicationGatewaySubnetName,
+				CIDRBlocks: []string{DefaultApplicationGatewaySubnetCIDR},
+				Role:       SubnetApplicationGateway,
+			},
+		},
+		PublicIP: PublicIPSpec{
+			Name: "my-appgw-pip",
+		},
+		SKUTier: StandardV2ApplicationGatewaySKUTier,
+	}
+	return cluster
+}
+
 func createValidNetworkSpec() NetworkSpec {
 	return NetworkSpec{
 		Vnet: VnetSpec{
@@ -1506,6 +3311,317 @@ func TestServiceEndpointsLackRequiredFieldLocations(t *testing.T) {
 	})
 }
 
+func TestValidatePrivateEndpointDNSZones(t *testing.T) {
+	tests := []struct {
+		name              string
+		privateDNSZoneIDs []string
+		wantErr           bool
+	}{
+		{
+			name:              "no private DNS zones",
+			privateDNSZoneIDs: nil,
+			wantErr:           false,
+		},
+		{
+			name:              "valid private DNS zone",
+			privateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"},
+			wantErr:           false,
+		},
+		{
+			name:              "resource ID of another resource type",
+			privateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/dnsZones/example.com"},
+			wantErr:           true,
+		},
+		{
+			name:              "invalid resource ID",
+			privateDNSZoneIDs: []string{"privatelink.vaultcore.azure.net"},
+			wantErr:           true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			privateEndpoints := []PrivateEndpointSpec{{
+				Name: "my-private-endpoint",
+				PrivateLinkServiceConnections: []PrivateLinkServiceConnection{{
+					PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/my-vault",
+					GroupIDs:             []string{"vault"},
+				}},
+				PrivateDNSZoneIDs: testCase.privateDNSZoneIDs,
+			}}
+			errs := validatePrivateEndpoints(privateEndpoints, []string{"10.0.0.0/24"}, field.NewPath("subnets[0].privateEndpoints"))
+			if testCase.wantErr {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				g.Expect(errs[0].Field).To(Equal("subnets[0].privateEndpoints[0].privateDNSZoneIDs[0]"))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateSubnetDelegations(t *testing.T) {
+	tests := []struct {
+		name        string
+		delegations SubnetDelegations
+		role        SubnetRole
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid delegations",
+			delegations: []SubnetDelegation{
+				{ServiceName: "Microsoft.ContainerInstance/containerGroups"},
+				{Name: "postgres", ServiceName: "Microsoft.DBforPostgreSQL/flexibleServers"},
+			},
+			role:    SubnetNode,
+			wantErr: false,
+		},
+		{
+			name: "missing service name",
+			delegations: []SubnetDelegation{
+				{Name: "foo"},
+			},
+			role:    SubnetNode,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "subnets[0].delegations[0].serviceName",
+				BadValue: "",
+				Detail:   "serviceName is required for all delegations",
+			},
+		},
+		{
+			name: "invalid service name",
+			delegations: []SubnetDelegation{
+				{ServiceName: "Microsoft.ContainerInstance"},
+			},
+			role:    SubnetNode,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].delegations[0].serviceName",
+				BadValue: "Microsoft.ContainerInstance",
+				Detail:   "service name of delegation doesn't match regex " + subnetDelegationServiceRegexPattern,
+			},
+		},
+		{
+			name: "duplicate service name",
+			delegations: []SubnetDelegation{
+				{ServiceName: "Microsoft.Web/serverFarms"},
+				{Name: "web", ServiceName: "Microsoft.Web/serverFarms"},
+			},
+			role:    SubnetNode,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].delegations[1].serviceName",
+				BadValue: "Microsoft.Web/serverFarms",
+			},
+		},
+		{
+			name: "invalid name",
+			delegations: []SubnetDelegation{
+				{Name: "foo/bar", ServiceName: "Microsoft.Web/serverFarms"},
+			},
+			role:    SubnetNode,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].delegations[0].name",
+				BadValue: "foo/bar",
+				Detail:   "name of delegation doesn't match regex " + subnetDelegationNameRegex,
+			},
+		},
+		{
+			name: "delegated bastion subnet",
+			delegations: []SubnetDelegation{
+				{ServiceName: "Microsoft.Web/serverFarms"},
+			},
+			role:    SubnetBastion,
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].delegations",
+				Detail: "subnets with role bastion cannot be delegated",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateSubnetDelegations(testCase.delegations, testCase.role, field.NewPath("subnets[0].delegations"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateRouteTable(t *testing.T) {
+	tests := []struct {
+		name        string
+		routeTable  RouteTable
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid routes",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0.4"},
+					{Name: "on-premises", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing route table name",
+			routeTable: RouteTable{
+				Routes: []Route{
+					{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "subnets[0].routeTable.name",
+				BadValue: "",
+				Detail:   "route table name is required when routes are declared",
+			},
+		},
+		{
+			name: "invalid route name",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "foo/bar", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].name",
+				BadValue: "foo/bar",
+				Detail:   "name of route doesn't match regex " + routeRegex,
+			},
+		},
+		{
+			name: "duplicate route name",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "foo", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet},
+					{Name: "foo", AddressPrefix: "192.168.0.0/16", NextHopType: RouteNextHopTypeVirtualNetworkGateway},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].routeTable.routes[1].name",
+				BadValue: "foo",
+			},
+		},
+		{
+			name: "duplicate address prefix",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "foo", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet},
+					{Name: "bar", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeNone},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].routeTable.routes[1].addressPrefix",
+				BadValue: "0.0.0.0/0",
+			},
+		},
+		{
+			name: "invalid address prefix",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "foo", AddressPrefix: "10.0.0.1", NextHopType: RouteNextHopTypeInternet},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].addressPrefix",
+				BadValue: "10.0.0.1",
+				Detail:   "invalid CIDR format",
+			},
+		},
+		{
+			name: "virtual appliance without next hop IP address",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				BadValue: "",
+				Detail:   "nextHopIPAddress is required when nextHopType is VirtualAppliance",
+			},
+		},
+		{
+			name: "invalid next hop IP address",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0"},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				BadValue: "10.1.0",
+				Detail:   "invalid IP address",
+			},
+		},
+		{
+			name: "next hop IP address with another next hop type",
+			routeTable: RouteTable{
+				Name: "my-routetable",
+				Routes: []Route{
+					{Name: "default", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet, NextHopIPAddress: "10.1.0.4"},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				Detail: "nextHopIPAddress can only be set when nextHopType is VirtualAppliance",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateRouteTable(testCase.routeTable, field.NewPath("subnets[0].routeTable"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestClusterWithExtendedLocationInvalid(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1529,3 +3645,69 @@ func TestClusterWithExtendedLocationInvalid(t *testing.T) {
 		g.Expect(err).NotTo(BeNil())
 	})
 }
+
+func TestValidateNamingConvention(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		convention  *NamingConvention
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "nil naming convention",
+			wantErr: false,
+		},
+		{
+			name: "valid naming convention",
+			convention: &NamingConvention{
+				Prefix: "corp-",
+				Templates: map[NamingResourceType]string{
+					SecurityGroupNamingResourceType: "nsg-{name}-westeurope",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported resource type",
+			convention: &NamingConvention{
+				Templates: map[NamingResourceType]string{
+					"VirtualNetwork": "vnet-{name}",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "spec.namingConvention.templates[VirtualNetwork]",
+				BadValue: NamingResourceType("VirtualNetwork"),
+				Detail:   `supported values: "NetworkInterface", "SecurityGroup", "LoadBalancer", "RouteTable"`,
+			},
+		},
+		{
+			name: "template without placeholder",
+			convention: &NamingConvention{
+				Templates: map[NamingResourceType]string{
+					NetworkInterfaceNamingResourceType: "nic",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.namingConvention.templates[NetworkInterface]",
+				BadValue: "nic",
+				Detail:   "template must contain the {name} placeholder",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateNamingConvention(testCase.convention, field.NewPath("spec", "namingConvention"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
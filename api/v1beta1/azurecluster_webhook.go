@@ -114,14 +114,94 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
-	// Allow enabling azure bastion but avoid disabling it.
-	if old.Spec.BastionSpec.AzureBastion != nil && !reflect.DeepEqual(old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion) {
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "PrivateDNSZone"),
+		old.Spec.NetworkSpec.PrivateDNSZone,
+		c.Spec.NetworkSpec.PrivateDNSZone); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	// The naming convention can't be added afterwards either, as it would change the names of the NICs of existing machines.
+	if !reflect.DeepEqual(old.Spec.NamingConvention, c.Spec.NamingConvention) {
 		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "BastionSpec", "AzureBastion"),
-				c.Spec.BastionSpec.AzureBastion, "azure bastion cannot be removed from a cluster"),
+			field.Invalid(field.NewPath("spec", "namingConvention"),
+				c.Spec.NamingConvention, "field is immutable"),
 		)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "OutboundType"),
+		old.Spec.NetworkSpec.OutboundType,
+		c.Spec.NetworkSpec.OutboundType); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	// Allow enabling azure bastion but avoid disabling it. Only its SKU, scale units and features can be changed afterwards.
+	if old.Spec.BastionSpec.AzureBastion != nil {
+		allErrs = append(allErrs, validateAzureBastionUpdate(old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion)...)
+	}
+
+	// The azure firewall can only be changed by updating the allowed FQDNs.
+	if old.Spec.NetworkSpec.AzureFirewall != nil {
+		if c.Spec.NetworkSpec.AzureFirewall == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("Spec", "NetworkSpec", "AzureFirewall"),
+					c.Spec.NetworkSpec.AzureFirewall, "azure firewall cannot be removed from a cluster"),
+			)
+		} else {
+			oldFirewall, newFirewall := old.Spec.NetworkSpec.AzureFirewall.DeepCopy(), c.Spec.NetworkSpec.AzureFirewall.DeepCopy()
+			oldFirewall.AdditionalAllowedFQDNs, newFirewall.AdditionalAllowedFQDNs = nil, nil
+			oldFirewall.PrivateIPAddress, newFirewall.PrivateIPAddress = "", ""
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("Spec", "NetworkSpec", "AzureFirewall"),
+				oldFirewall,
+				newFirewall); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	// A virtual network gateway can be added to an existing cluster but not changed or removed.
+	if old.Spec.NetworkSpec.VirtualNetworkGateway != nil {
+		if err := webhookutils.ValidateImmutable(
+			field.NewPath("Spec", "NetworkSpec", "VirtualNetworkGateway"),
+			old.Spec.NetworkSpec.VirtualNetworkGateway,
+			c.Spec.NetworkSpec.VirtualNetworkGateway); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	// An application gateway can be added to an existing cluster but not changed or removed, since AGIC manages it afterwards.
+	if old.Spec.ApplicationGateway != nil {
+		if err := webhookutils.ValidateImmutable(
+			field.NewPath("Spec", "ApplicationGateway"),
+			old.Spec.ApplicationGateway,
+			c.Spec.ApplicationGateway); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	// The private link service of the API server can be added to an existing cluster, but only its subscription lists can be changed.
+	if old.Spec.NetworkSpec.APIServerPrivateLinkService != nil {
+		if c.Spec.NetworkSpec.APIServerPrivateLinkService == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("Spec", "NetworkSpec", "APIServerPrivateLinkService"),
+					c.Spec.NetworkSpec.APIServerPrivateLinkService, "private link service cannot be removed from a cluster"),
+			)
+		} else {
+			oldPLS, newPLS := old.Spec.NetworkSpec.APIServerPrivateLinkService.DeepCopy(), c.Spec.NetworkSpec.APIServerPrivateLinkService.DeepCopy()
+			oldPLS.AllowedSubscriptions, newPLS.AllowedSubscriptions = nil, nil
+			oldPLS.AutoApprovedSubscriptions, newPLS.AutoApprovedSubscriptions = nil, nil
+			oldPLS.Alias, newPLS.Alias = "", ""
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("Spec", "NetworkSpec", "APIServerPrivateLinkService"),
+				oldPLS,
+				newPLS); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "NetworkSpec", "ControlPlaneOutboundLB"),
 		old.Spec.NetworkSpec.ControlPlaneOutboundLB,
@@ -174,6 +254,12 @@ func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 						c.Spec.NetworkSpec.Subnets[i].NatGateway.Name, "field is immutable"),
 				)
 			}
+			if oldSubnet.NatGateway.Name != "" && !reflect.DeepEqual(subnet.NatGateway.Zones, oldSubnet.NatGateway.Zones) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("NatGateway").Child("Zones"),
+						c.Spec.NetworkSpec.Subnets[i].NatGateway.Zones, "field is immutable"),
+				)
+			}
 			if subnet.SecurityGroup.Name != oldSubnet.SecurityGroup.Name {
 				allErrs = append(allErrs,
 					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("SecurityGroup").Child("Name"),
@@ -186,6 +272,33 @@ func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 	return allErrs
 }
 
+// validateAzureBastionUpdate validates an update of an existing Azure Bastion. The bastion can't be removed, and its SKU
+// can only be upgraded from Basic to Standard, which Azure doesn't allow to revert.
+func validateAzureBastionUpdate(old, bastion *AzureBastion) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "BastionSpec", "AzureBastion")
+	if bastion == nil {
+		return append(allErrs, field.Invalid(fldPath, bastion, "azure bastion cannot be removed from a cluster"))
+	}
+
+	if old.Sku == StandardBastionHostSku && bastion.Sku != StandardBastionHostSku {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("Sku"), bastion.Sku, "sku cannot be downgraded from Standard"))
+	}
+
+	// The SKU, scale units and features of the bastion can be changed.
+	mutable := old.DeepCopy()
+	mutable.Sku = bastion.Sku
+	mutable.ScaleUnits = bastion.ScaleUnits
+	mutable.EnableTunneling = bastion.EnableTunneling
+	mutable.EnableIPConnect = bastion.EnableIPConnect
+	mutable.EnableShareableLink = bastion.EnableShareableLink
+	if !reflect.DeepEqual(mutable, bastion) {
+		allErrs = append(allErrs, field.Invalid(fldPath, bastion, "only the sku, scale units and features of azure bastion can be changed"))
+	}
+
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (c *AzureCluster) ValidateDelete() error {
 	return nil
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -342,6 +343,272 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "natGateway zones are immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.Name = "cluster-test-node-natgw"
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.Zones = []string{"1"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.Name = "cluster-test-node-natgw"
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.Zones = []string{"2"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "natGateway idle timeout and public IP prefixes can be updated",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.Name = "cluster-test-node-natgw"
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.Name = "cluster-test-node-natgw"
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.IdleTimeoutInMinutes = pointer.Int32(30)
+				cluster.Spec.NetworkSpec.Subnets[1].NatGateway.PublicIPPrefixes = []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "outboundType is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidCluster()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.OutboundType = UserDefinedRoutingOutboundType
+				cluster.Spec.NetworkSpec.NodeOutboundLB = nil
+				cluster.Spec.NetworkSpec.Subnets[1].RouteTable.Name = "my-firewall-routetable"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "privateDNSZone is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB.Type = Internal
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB.Type = Internal
+				cluster.Spec.NetworkSpec.PrivateDNSZone = PrivateDNSZoneModeNone
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure firewall allowed FQDNs can be updated",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAzureFirewall()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAzureFirewall()
+				cluster.Spec.NetworkSpec.AzureFirewall.AdditionalAllowedFQDNs = []string{"example.com"}
+				cluster.Spec.NetworkSpec.AzureFirewall.PrivateIPAddress = "10.255.255.132"
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azure firewall name is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAzureFirewall()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAzureFirewall()
+				cluster.Spec.NetworkSpec.AzureFirewall.Name = "my-other-firewall"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure firewall cannot be removed",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAzureFirewall()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAzureFirewall()
+				cluster.Spec.NetworkSpec.AzureFirewall = nil
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private link service subscriptions can be updated",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAPIServerPrivateLinkService()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAPIServerPrivateLinkService()
+				cluster.Spec.NetworkSpec.APIServerPrivateLinkService.AllowedSubscriptions = []string{"*"}
+				cluster.Spec.NetworkSpec.APIServerPrivateLinkService.AutoApprovedSubscriptions = []string{"00000000-0000-0000-0000-000000000002"}
+				cluster.Spec.NetworkSpec.APIServerPrivateLinkService.Alias = "my-pls.00000000-0000-0000-0000-000000000000.eastus.azure.privatelinkservice"
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "private link service name is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAPIServerPrivateLinkService()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAPIServerPrivateLinkService()
+				cluster.Spec.NetworkSpec.APIServerPrivateLinkService.Name = "my-other-pls"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private link service subnet is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAPIServerPrivateLinkService()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAPIServerPrivateLinkService()
+				cluster.Spec.NetworkSpec.APIServerPrivateLinkService.SubnetName = "node-subnet"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private link service cannot be removed",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAPIServerPrivateLinkService()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAPIServerPrivateLinkService()
+				cluster.Spec.NetworkSpec.APIServerPrivateLinkService = nil
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "virtual network gateway can be added",
+			oldCluster: func() *AzureCluster {
+				return createValidCluster()
+			}(),
+			cluster: func() *AzureCluster {
+				return createValidClusterWithVirtualNetworkGateway()
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "virtual network gateway SKU is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithVirtualNetworkGateway()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithVirtualNetworkGateway()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway.SKU = "VpnGw2AZ"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "virtual network gateway cannot be removed",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithVirtualNetworkGateway()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithVirtualNetworkGateway()
+				cluster.Spec.NetworkSpec.VirtualNetworkGateway = nil
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "application gateway can be added",
+			oldCluster: func() *AzureCluster {
+				return createValidCluster()
+			}(),
+			cluster: func() *AzureCluster {
+				return createValidClusterWithApplicationGateway()
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "application gateway tier is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithApplicationGateway()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithApplicationGateway()
+				cluster.Spec.ApplicationGateway.SKUTier = WAFV2ApplicationGatewaySKUTier
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "application gateway cannot be removed",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithApplicationGateway()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithApplicationGateway()
+				cluster.Spec.ApplicationGateway = nil
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion sku, scale units and features can be updated",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAzureBastion()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAzureBastion()
+				cluster.Spec.BastionSpec.AzureBastion.Sku = StandardBastionHostSku
+				cluster.Spec.BastionSpec.AzureBastion.ScaleUnits = pointer.Int32(4)
+				cluster.Spec.BastionSpec.AzureBastion.EnableTunneling = true
+				cluster.Spec.BastionSpec.AzureBastion.EnableIPConnect = true
+				cluster.Spec.BastionSpec.AzureBastion.EnableShareableLink = true
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azure bastion sku cannot be downgraded",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidClusterWithAzureBastion()
+				cluster.Spec.BastionSpec.AzureBastion.Sku = StandardBastionHostSku
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				return createValidClusterWithAzureBastion()
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion name is immutable",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAzureBastion()
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidClusterWithAzureBastion()
+				cluster.Spec.BastionSpec.AzureBastion.Name = "my-other-bastion"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion cannot be removed",
+			oldCluster: func() *AzureCluster {
+				return createValidClusterWithAzureBastion()
+			}(),
+			cluster: func() *AzureCluster {
+				return createValidCluster()
+			}(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
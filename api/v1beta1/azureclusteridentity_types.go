@@ -44,7 +44,7 @@ type AllowedNamespaces struct {
 // AzureClusterIdentitySpec defines the parameters that are used to create an AzureIdentity.
 type AzureClusterIdentitySpec struct {
 	// Type is the type of Azure Identity used.
-	// ServicePrincipal, ServicePrincipalCertificate, UserAssignedMSI or ManualServicePrincipal.
+	// ServicePrincipal, ServicePrincipalCertificate, UserAssignedMSI, ManualServicePrincipal or WorkloadIdentity.
 	Type IdentityType `json:"type"`
 	// ResourceID is the Azure resource ID for the User Assigned MSI resource.
 	// Only applicable when type is UserAssignedMSI.
@@ -54,10 +54,21 @@ type AzureClusterIdentitySpec struct {
 	// Both User Assigned MSI and SP can use this field.
 	ClientID string `json:"clientID"`
 	// ClientSecret is a secret reference which should contain either a Service Principal password or certificate secret.
+	// Not applicable when type is WorkloadIdentity.
 	// +optional
 	ClientSecret corev1.SecretReference `json:"clientSecret,omitempty"`
+	// CertificateKeyVaultRef references a Service Principal certificate stored in Azure Key Vault, which is fetched
+	// at runtime with the managed identity of the management cluster instead of being read from ClientSecret.
+	// Only applicable when type is ServicePrincipalCertificate.
+	// +optional
+	CertificateKeyVaultRef *KeyVaultCertificateReference `json:"certificateKeyVaultRef,omitempty"`
 	// TenantID is the service principal primary tenant id.
 	TenantID string `json:"tenantID"`
+	// AuxiliaryTenantIDs are the IDs of additional tenants a multi-tenant service principal can get tokens in,
+	// so that clusters whose resources span those tenants can be managed with an identity homed in TenantID.
+	// Only applicable when type is ManualServicePrincipal or WorkloadIdentity, or with CertificateKeyVaultRef.
+	// +optional
+	AuxiliaryTenantIDs []string `json:"auxiliaryTenantIDs,omitempty"`
 	// AllowedNamespaces is used to identify the namespaces the clusters are allowed to use the identity from.
 	// Namespaces can be selected either using an array of namespaces or with label selector.
 	// An empty allowedNamespaces object indicates that AzureClusters can use this identity from any namespace.
@@ -69,11 +80,28 @@ type AzureClusterIdentitySpec struct {
 	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces"`
 }
 
+// KeyVaultCertificateReference references a certificate stored in Azure Key Vault.
+type KeyVaultCertificateReference struct {
+	// VaultURI is the URI of the Key Vault, e.g. https://my-vault.vault.azure.net/.
+	VaultURI string `json:"vaultURI"`
+	// SecretName is the name of the Key Vault secret holding the certificate and its private key, in PEM or PKCS12
+	// format. For a certificate managed by Key Vault, this is the name of the certificate.
+	SecretName string `json:"secretName"`
+	// ManagedIdentityClientID is the client ID of the user-assigned managed identity of the management cluster used to
+	// read the secret. Defaults to the system-assigned managed identity.
+	// +optional
+	ManagedIdentityClientID string `json:"managedIdentityClientID,omitempty"`
+}
+
 // AzureClusterIdentityStatus defines the observed state of AzureClusterIdentity.
 type AzureClusterIdentityStatus struct {
 	// Conditions defines current service state of the AzureClusterIdentity.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+	// ReferencedByClusters lists the AzureClusters and AzureManagedControlPlanes using the identity, as
+	// namespace/name. The identity can't be deleted while it is referenced by clusters in other namespaces.
+	// +optional
+	ReferencedByClusters []string `json:"referencedByClusters,omitempty"`
 }
 
 // +kubebuilder:object:root=true
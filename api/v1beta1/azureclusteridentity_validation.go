@@ -17,8 +17,19 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (c *AzureClusterIdentity) validateClusterIdentity() error {
@@ -28,8 +39,124 @@ func (c *AzureClusterIdentity) validateClusterIdentity() error {
 	} else if c.Spec.Type != UserAssignedMSI && c.Spec.ResourceID != "" {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "resourceID"), c.Spec.ResourceID))
 	}
+	if c.Spec.Type == WorkloadIdentity && c.Spec.ClientSecret.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clientSecret"), "clientSecret cannot be set with the WorkloadIdentity type"))
+	}
+	allErrs = append(allErrs, c.validateCertificateKeyVaultRef()...)
+	allErrs = append(allErrs, c.validateAuxiliaryTenantIDs()...)
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return apierrors.NewInvalid(GroupVersion.WithKind("AzureClusterIdentity").GroupKind(), c.Name, allErrs)
 }
+
+// validateCertificateKeyVaultRef validates that a Key Vault certificate is only referenced by a service principal
+// certificate identity, in place of a client secret, and that the vault URI is a valid https URL.
+func (c *AzureClusterIdentity) validateCertificateKeyVaultRef() field.ErrorList {
+	ref := c.Spec.CertificateKeyVaultRef
+	if ref == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "certificateKeyVaultRef")
+	if c.Spec.Type != ServicePrincipalCertificate {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			fmt.Sprintf("certificateKeyVaultRef can only be set with the %s type", ServicePrincipalCertificate)))
+	}
+	if c.Spec.ClientSecret.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clientSecret"), "clientSecret cannot be set with certificateKeyVaultRef"))
+	}
+	if u, err := url.Parse(ref.VaultURI); err != nil || u.Scheme != "https" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vaultURI"), ref.VaultURI, "vaultURI must be a valid https URL"))
+	}
+	if ref.SecretName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("secretName"), "secretName is required"))
+	}
+	return allErrs
+}
+
+// validateAuxiliaryTenantIDs validates that auxiliary tenants are only set with identity types that can get tokens in
+// other tenants, and that they are neither duplicated nor the primary tenant.
+func (c *AzureClusterIdentity) validateAuxiliaryTenantIDs() field.ErrorList {
+	if len(c.Spec.AuxiliaryTenantIDs) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "auxiliaryTenantIDs")
+	if c.Spec.Type != ManualServicePrincipal && c.Spec.Type != WorkloadIdentity && c.Spec.CertificateKeyVaultRef == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			fmt.Sprintf("auxiliaryTenantIDs can only be set with the %s or %s types, or with certificateKeyVaultRef", ManualServicePrincipal, WorkloadIdentity)))
+	}
+	seen := make(map[string]bool, len(c.Spec.AuxiliaryTenantIDs))
+	for i, tenantID := range c.Spec.AuxiliaryTenantIDs {
+		switch {
+		case tenantID == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "tenant ID cannot be empty"))
+		case tenantID == c.Spec.TenantID:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), tenantID, "auxiliary tenant ID cannot be the same as tenantID"))
+		case seen[tenantID]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), tenantID))
+		}
+		seen[tenantID] = true
+	}
+	return allErrs
+}
+
+// validateClusterIdentityDelete prevents deleting an identity which is still referenced by clusters in other
+// namespaces. Those clusters aren't moved along with the identity by clusterctl move, and would be left without
+// credentials. The clusters are listed rather than read from the status of the identity, which may be stale.
+func (c *AzureClusterIdentity) validateClusterIdentityDelete(ctx context.Context, cli client.Reader) error {
+	var objects []client.Object
+	azureClusters := &AzureClusterList{}
+	if err := cli.List(ctx, azureClusters); err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to list AzureClusters"))
+	}
+	for i := range azureClusters.Items {
+		objects = append(objects, &azureClusters.Items[i])
+	}
+	if feature.Gates.Enabled(capifeature.MachinePool) {
+		controlPlanes := &AzureManagedControlPlaneList{}
+		if err := cli.List(ctx, controlPlanes); err != nil {
+			return apierrors.NewInternalError(errors.Wrap(err, "failed to list AzureManagedControlPlanes"))
+		}
+		for i := range controlPlanes.Items {
+			objects = append(objects, &controlPlanes.Items[i])
+		}
+	}
+
+	var clusters []string
+	for _, o := range objects {
+		if o.GetNamespace() == c.Namespace || !c.isReferencedBy(o) {
+			continue
+		}
+		clusters = append(clusters, fmt.Sprintf("%s/%s", o.GetNamespace(), o.GetName()))
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	sort.Strings(clusters)
+	return apierrors.NewForbidden(GroupVersion.WithResource("azureclusteridentities").GroupResource(), c.Name,
+		fmt.Errorf("AzureClusterIdentity is referenced by clusters in other namespaces: %s", strings.Join(clusters, ", ")))
+}
+
+// isReferencedBy returns true if an AzureCluster or an AzureManagedControlPlane references the identity. The namespace
+// of the cluster is used when its reference doesn't set one.
+func (c *AzureClusterIdentity) isReferencedBy(o client.Object) bool {
+	var ref *corev1.ObjectReference
+	switch cluster := o.(type) {
+	case *AzureCluster:
+		ref = cluster.Spec.IdentityRef
+	case *AzureManagedControlPlane:
+		ref = cluster.Spec.IdentityRef
+	}
+	if ref == nil || ref.Name != c.Name {
+		return false
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = o.GetNamespace()
+	}
+	return namespace == c.Namespace
+}
@@ -17,33 +17,58 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
-// SetupWebhookWithManager sets up and registers the webhook with the manager.
-func (c *AzureClusterIdentity) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupAzureClusterIdentityWebhookWithManager sets up and registers the webhook with the manager.
+func SetupAzureClusterIdentityWebhookWithManager(mgr ctrl.Manager) error {
+	// The clusters referencing an identity are listed with the API reader rather than the cached client, so that a
+	// cluster created right before the identity is deleted isn't missed.
+	cw := &azureClusterIdentityWebhook{Client: mgr.GetAPIReader()}
 	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
+		For(&AzureClusterIdentity{}).
+		WithValidator(cw).
 		Complete()
 }
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azureclusteridentity,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities,versions=v1beta1,name=validation.azureclusteridentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azureclusteridentity,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities,versions=v1beta1,name=validation.azureclusteridentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// azureClusterIdentityWebhook implements a validating webhook for AzureClusterIdentity.
+type azureClusterIdentityWebhook struct {
+	Client client.Reader
+}
 
-var _ webhook.Validator = &AzureClusterIdentity{}
+var _ webhook.CustomValidator = &azureClusterIdentityWebhook{}
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (c *AzureClusterIdentity) ValidateCreate() error {
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (cw *azureClusterIdentityWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	c, ok := obj.(*AzureClusterIdentity)
+	if !ok {
+		return apierrors.NewBadRequest("expected an AzureClusterIdentity")
+	}
 	return c.validateClusterIdentity()
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (c *AzureClusterIdentity) ValidateUpdate(oldRaw runtime.Object) error {
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (cw *azureClusterIdentityWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	c, ok := newObj.(*AzureClusterIdentity)
+	if !ok {
+		return apierrors.NewBadRequest("expected an AzureClusterIdentity")
+	}
 	return c.validateClusterIdentity()
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
-func (c *AzureClusterIdentity) ValidateDelete() error {
-	return nil
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (cw *azureClusterIdentityWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	c, ok := obj.(*AzureClusterIdentity)
+	if !ok {
+		return apierrors.NewBadRequest("expected an AzureClusterIdentity")
+	}
+	return c.validateClusterIdentityDelete(ctx, cw.Client)
 }
@@ -17,9 +17,18 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const fakeClientID = "fake-client-id"
@@ -80,11 +89,235 @@ func TestAzureClusterIdentity_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "azureclusteridentity with workload identity",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:     WorkloadIdentity,
+					ClientID: fakeClientID,
+					TenantID: fakeTenantID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azureclusteridentity with workload identity and client secret",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:         WorkloadIdentity,
+					ClientID:     fakeClientID,
+					TenantID:     fakeTenantID,
+					ClientSecret: corev1.SecretReference{Name: "fake-secret", Namespace: "default"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity with service principal certificate from key vault",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:     ServicePrincipalCertificate,
+					ClientID: fakeClientID,
+					TenantID: fakeTenantID,
+					CertificateKeyVaultRef: &KeyVaultCertificateReference{
+						VaultURI:   "https://fake-vault.vault.azure.net/",
+						SecretName: "fake-certificate",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azureclusteridentity with service principal certificate from key vault and client secret",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:         ServicePrincipalCertificate,
+					ClientID:     fakeClientID,
+					TenantID:     fakeTenantID,
+					ClientSecret: corev1.SecretReference{Name: "fake-secret", Namespace: "default"},
+					CertificateKeyVaultRef: &KeyVaultCertificateReference{
+						VaultURI:   "https://fake-vault.vault.azure.net/",
+						SecretName: "fake-certificate",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity with service principal certificate from key vault with invalid vault uri",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:     ServicePrincipalCertificate,
+					ClientID: fakeClientID,
+					TenantID: fakeTenantID,
+					CertificateKeyVaultRef: &KeyVaultCertificateReference{
+						VaultURI:   "fake-vault.vault.azure.net",
+						SecretName: "fake-certificate",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity with service principal and key vault certificate",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:     ServicePrincipal,
+					ClientID: fakeClientID,
+					TenantID: fakeTenantID,
+					CertificateKeyVaultRef: &KeyVaultCertificateReference{
+						VaultURI:   "https://fake-vault.vault.azure.net/",
+						SecretName: "fake-certificate",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity with manual service principal and auxiliary tenants",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:               ManualServicePrincipal,
+					ClientID:           fakeClientID,
+					TenantID:           fakeTenantID,
+					AuxiliaryTenantIDs: []string{"fake-auxiliary-tenant-id"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azureclusteridentity with user assigned msi and auxiliary tenants",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:               UserAssignedMSI,
+					ClientID:           fakeClientID,
+					TenantID:           fakeTenantID,
+					ResourceID:         fakeResourceID,
+					AuxiliaryTenantIDs: []string{"fake-auxiliary-tenant-id"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity with auxiliary tenant same as primary tenant",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:               WorkloadIdentity,
+					ClientID:           fakeClientID,
+					TenantID:           fakeTenantID,
+					AuxiliaryTenantIDs: []string{fakeTenantID},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity with duplicate auxiliary tenants",
+			clusterIdentity: &AzureClusterIdentity{
+				Spec: AzureClusterIdentitySpec{
+					Type:               WorkloadIdentity,
+					ClientID:           fakeClientID,
+					TenantID:           fakeTenantID,
+					AuxiliaryTenantIDs: []string{"fake-auxiliary-tenant-id", "fake-auxiliary-tenant-id"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cw := &azureClusterIdentityWebhook{}
+			err := cw.ValidateCreate(context.Background(), tc.clusterIdentity)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureClusterIdentity_ValidateDelete(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, capifeature.MachinePool, true)()
+
+	tests := []struct {
+		name     string
+		clusters []client.Object
+		wantErr  bool
+	}{
+		{
+			name:    "azureclusteridentity not referenced by clusters",
+			wantErr: false,
+		},
+		{
+			name: "azureclusteridentity referenced by clusters in its namespace",
+			clusters: []client.Object{
+				&AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-cluster"},
+					Spec: AzureClusterSpec{AzureClusterClassSpec: AzureClusterClassSpec{
+						IdentityRef: &corev1.ObjectReference{Name: "my-identity"},
+					}},
+				},
+				&AzureManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-other-cluster"},
+					Spec:       AzureManagedControlPlaneSpec{IdentityRef: &corev1.ObjectReference{Name: "my-identity", Namespace: "default"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azureclusteridentity with the same name referenced by clusters in other namespaces",
+			clusters: []client.Object{
+				&AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "my-cluster"},
+					Spec: AzureClusterSpec{AzureClusterClassSpec: AzureClusterClassSpec{
+						IdentityRef: &corev1.ObjectReference{Name: "my-identity"},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azureclusteridentity referenced by an AzureCluster in another namespace",
+			clusters: []client.Object{
+				&AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "my-cluster"},
+					Spec: AzureClusterSpec{AzureClusterClassSpec: AzureClusterClassSpec{
+						IdentityRef: &corev1.ObjectReference{Name: "my-identity", Namespace: "default"},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azureclusteridentity referenced by an AzureManagedControlPlane in another namespace",
+			clusters: []client.Object{
+				&AzureManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "my-cluster"},
+					Spec:       AzureManagedControlPlaneSpec{IdentityRef: &corev1.ObjectReference{Name: "my-identity", Namespace: "default"}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
+		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.clusterIdentity.ValidateCreate()
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.clusters...).Build()
+			clusterIdentity := &AzureClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-identity"},
+				Spec: AzureClusterIdentitySpec{
+					Type:     WorkloadIdentity,
+					ClientID: fakeClientID,
+					TenantID: fakeTenantID,
+				},
+			}
+			cw := &azureClusterIdentityWebhook{Client: fakeClient}
+			err := cw.ValidateDelete(context.Background(), clusterIdentity)
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -88,7 +88,7 @@ func (c *AzureClusterTemplate) setSubnetsTemplateDefaults() {
 
 func (c *AzureClusterTemplate) setNodeOutboundLBDefaults() {
 	if c.Spec.Template.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.Template.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.Template.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.Template.Spec.NetworkSpec.IsUserDefinedRouting() {
 			return
 		}
 
@@ -110,7 +110,9 @@ func (c *AzureClusterTemplate) setNodeOutboundLBDefaults() {
 		c.Spec.Template.Spec.NetworkSpec.NodeOutboundLB = &LoadBalancerClassSpec{}
 	}
 
-	c.Spec.Template.Spec.NetworkSpec.NodeOutboundLB.setNodeOutboundLBDefaults()
+	if lb := c.Spec.Template.Spec.NetworkSpec.NodeOutboundLB; lb.IsDedicated() {
+		lb.setNodeOutboundLBDefaults()
+	}
 }
 
 func (c *AzureClusterTemplate) setControlPlaneOutboundLBDefaults() {
@@ -63,10 +63,17 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 			break
 		}
 	}
-	if needOutboundLB {
+	if needOutboundLB && !networkSpec.IsUserDefinedRouting() {
 		allErrs = append(allErrs, c.validateNodeOutboundLB()...)
 	}
 
+	if !networkSpec.IsUserDefinedRouting() {
+		allErrs = append(allErrs, validateClassSpecForNodeOutboundLBMode(networkSpec.NodeOutboundLB, nil, networkSpec.APIServerLB,
+			field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("nodeOutboundLB"))...)
+	}
+
+	allErrs = append(allErrs, c.validateOutboundType()...)
+
 	allErrs = append(allErrs, c.validateControlPlaneOutboundLB()...)
 
 	allErrs = append(allErrs, c.validatePrivateDNSZoneName()...)
@@ -95,15 +102,11 @@ func validateSubnetTemplates(subnets SubnetTemplatesSpec, vnet VnetTemplateSpec,
 				requiredSubnetRoles[role] = true
 			}
 		}
-		for j, rule := range subnet.SecurityGroup.SecurityRules {
-			if err := validateSecurityRule(
-				rule,
-				fld.Index(i).Child("securityGroup").Child("securityGroup").Child("securityRules").Index(j),
-			); err != nil {
-				allErrs = append(allErrs, err)
-			}
-		}
+		allErrs = append(allErrs, validateSecurityRules(subnet.SecurityGroup.SecurityRules, fld.Index(i).Child("securityGroup").Child("securityRules"))...)
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fld.Index(i).Child("cidrBlocks"))...)
+		if subnet.NatGateway.Name != "" {
+			allErrs = append(allErrs, validateNatGatewayClassSpec(subnet.NatGateway, fld.Index(i).Child("natGateway"))...)
+		}
 	}
 	for k, v := range requiredSubnetRoles {
 		if !v {
@@ -133,6 +136,29 @@ func (c *AzureClusterTemplate) validateNodeOutboundLB() field.ErrorList {
 	return allErrs
 }
 
+func (c *AzureClusterTemplate) validateOutboundType() field.ErrorList {
+	var allErrs field.ErrorList
+
+	networkSpec := c.Spec.Template.Spec.NetworkSpec
+	if !networkSpec.IsUserDefinedRouting() {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec").Child("template").Child("spec").Child("networkSpec")
+	if networkSpec.NodeOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB"),
+			"Node outbound load balancer can't be set when outboundType is userDefinedRouting"))
+	}
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.Role == SubnetNode && subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("natGateway"),
+				"NAT gateway can't be set on node subnets when outboundType is userDefinedRouting"))
+		}
+	}
+
+	return allErrs
+}
+
 func (c *AzureClusterTemplate) validateControlPlaneOutboundLB() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -157,5 +183,11 @@ func (c *AzureClusterTemplate) validatePrivateDNSZoneName() field.ErrorList {
 		fldPath,
 	)...)
 
+	allErrs = append(allErrs, validatePrivateDNSZone(
+		networkSpec.NetworkClassSpec,
+		networkSpec.APIServerLB.Type,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec"),
+	)...)
+
 	return allErrs
 }
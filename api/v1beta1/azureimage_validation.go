@@ -17,9 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/blang/semver"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// latestImageVersion is the image version used to select the latest version of an image.
+const latestImageVersion = "latest"
+
 // ValidateImage validates an image.
 func ValidateImage(image *Image, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -96,6 +100,19 @@ func validateComputeGalleryImage(image *Image, fldPath *field.Path) field.ErrorL
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("SubscriptionID"), "", "SubscriptionID cannot be empty when ResourceGroup is specified"))
 	}
 
+	if image.ComputeGallery.DirectShared && (image.ComputeGallery.SubscriptionID != nil || image.ComputeGallery.ResourceGroup != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("DirectShared"), "SubscriptionID and ResourceGroup cannot be specified for a directly shared gallery image"))
+	}
+
+	version := image.ComputeGallery.Version
+	if version != latestImageVersion {
+		if _, err := semver.ParseRange(version); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("Version"), version, "Version must be 'latest', a Major.Minor.Build version or a semver range"))
+		} else if _, err := semver.Parse(version); err != nil && (image.ComputeGallery.SubscriptionID == nil || image.ComputeGallery.ResourceGroup == nil) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("Version"), version, "semver range versions are only supported for private compute gallery images"))
+		}
+	}
+
 	return allErrs
 }
 
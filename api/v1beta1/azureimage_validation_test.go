@@ -84,6 +84,54 @@ func TestComputeImageGalleryValid(t *testing.T) {
 			expectedErrors: 1,
 			image:          createTestComputeImage(pointer.String("SUB1234"), nil),
 		},
+		"AzureComputeGalleryImage - community image with latest version": {
+			expectedErrors: 0,
+			image:          createTestComputeImageWithVersion(nil, nil, "latest"),
+		},
+		"AzureComputeGalleryImage - community image with semver range version": {
+			expectedErrors: 1,
+			image:          createTestComputeImageWithVersion(nil, nil, ">=1.0.0 <2.0.0"),
+		},
+		"AzureComputeGalleryImage - private image with latest version": {
+			expectedErrors: 0,
+			image:          createTestComputeImageWithVersion(pointer.String("SUB1234"), pointer.String("RG1234"), "latest"),
+		},
+		"AzureComputeGalleryImage - private image with semver range version": {
+			expectedErrors: 0,
+			image:          createTestComputeImageWithVersion(pointer.String("SUB1234"), pointer.String("RG1234"), ">=1.0.0 <2.0.0"),
+		},
+		"AzureComputeGalleryImage - private image with wildcard version": {
+			expectedErrors: 0,
+			image:          createTestComputeImageWithVersion(pointer.String("SUB1234"), pointer.String("RG1234"), "1.x"),
+		},
+		"AzureComputeGalleryImage - directly shared image": {
+			expectedErrors: 0,
+			image: &Image{
+				ComputeGallery: &AzureComputeGalleryImage{
+					Name:         "IMAGENAME",
+					Gallery:      "GALLERY-UNIQUE-NAME",
+					Version:      "1.0.0",
+					DirectShared: true,
+				},
+			},
+		},
+		"AzureComputeGalleryImage - directly shared image with subscription and resource group": {
+			expectedErrors: 1,
+			image: &Image{
+				ComputeGallery: &AzureComputeGalleryImage{
+					Name:           "IMAGENAME",
+					Gallery:        "GALLERY-UNIQUE-NAME",
+					Version:        "1.0.0",
+					SubscriptionID: pointer.String("SUB1234"),
+					ResourceGroup:  pointer.String("RG1234"),
+					DirectShared:   true,
+				},
+			},
+		},
+		"AzureComputeGalleryImage - private image with invalid version": {
+			expectedErrors: 1,
+			image:          createTestComputeImageWithVersion(pointer.String("SUB1234"), pointer.String("RG1234"), "newest"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -186,11 +234,15 @@ func TestImageByIDValid(t *testing.T) {
 }
 
 func createTestComputeImage(subscriptionID, resourceGroup *string) *Image {
+	return createTestComputeImageWithVersion(subscriptionID, resourceGroup, "1.0.0")
+}
+
+func createTestComputeImageWithVersion(subscriptionID, resourceGroup *string, version string) *Image {
 	return &Image{
 		ComputeGallery: &AzureComputeGalleryImage{
 			Name:           "IMAGENAME",
 			Gallery:        "GALLERY9876",
-			Version:        "1.0.0",
+			Version:        version,
 			SubscriptionID: subscriptionID,
 			ResourceGroup:  resourceGroup,
 		},
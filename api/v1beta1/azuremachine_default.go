@@ -77,8 +77,7 @@ func (s *AzureMachineSpec) SetDataDisksDefaults() {
 			}
 		}
 		if disk.CachingType == "" {
-			if s.DataDisks[i].ManagedDisk != nil &&
-				s.DataDisks[i].ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
+			if s.DataDisks[i].ManagedDisk != nil && !supportsHostCaching(s.DataDisks[i].ManagedDisk.StorageAccountType) {
 				s.DataDisks[i].CachingType = string(compute.CachingTypesNone)
 			} else {
 				s.DataDisks[i].CachingType = string(compute.CachingTypesReadWrite)
@@ -166,8 +165,11 @@ func (s *AzureMachineSpec) SetNetworkInterfacesDefaults() {
 		s.AcceleratedNetworking = nil
 	}
 
-	// Ensure that PrivateIPConfigs defaults to 1 if not specified.
+	// Ensure that PrivateIPConfigs matches the number of IPConfigs, or defaults to 1 if not specified.
 	for i := 0; i < len(s.NetworkInterfaces); i++ {
+		if len(s.NetworkInterfaces[i].IPConfigs) > 0 {
+			s.NetworkInterfaces[i].PrivateIPConfigs = len(s.NetworkInterfaces[i].IPConfigs)
+		}
 		if s.NetworkInterfaces[i].PrivateIPConfigs == 0 {
 			s.NetworkInterfaces[i].PrivateIPConfigs = 1
 		}
@@ -258,6 +258,14 @@ func TestAzureMachineSpec_SetDataDisksDefaults(t *testing.T) {
 					},
 					Lun: pointer.Int32(3),
 				},
+				{
+					NameSuffix: "testdisk4",
+					DiskSizeGB: 30,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "PremiumV2_LRS",
+					},
+					Lun: pointer.Int32(4),
+				},
 			},
 			output: []DataDisk{
 				{
@@ -281,6 +289,15 @@ func TestAzureMachineSpec_SetDataDisksDefaults(t *testing.T) {
 					},
 					CachingType: "None",
 				},
+				{
+					NameSuffix: "testdisk4",
+					DiskSizeGB: 30,
+					Lun:        pointer.Int32(4),
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "PremiumV2_LRS",
+					},
+					CachingType: "None",
+				},
 			},
 		},
 	}
@@ -370,6 +387,31 @@ func TestAzureMachineSpec_SetNetworkInterfacesDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "defaulting webhook sets privateIPConfigs to the number of ipConfigs",
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{
+						{
+							SubnetName:       "test-subnet",
+							PrivateIPConfigs: 1,
+							IPConfigs:        []NetworkInterfaceIPConfig{{}, {PrivateIPAddress: "10.0.0.5"}},
+						},
+					},
+				},
+			},
+			want: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{
+						{
+							SubnetName:       "test-subnet",
+							PrivateIPConfigs: 2,
+							IPConfigs:        []NetworkInterfaceIPConfig{{}, {PrivateIPAddress: "10.0.0.5"}},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "defaulting webhook does nothing if both new and deprecated subnetName fields are set",
 			machine: &AzureMachine{
@@ -28,6 +28,35 @@ const (
 	// MachineFinalizer allows ReconcileAzureMachine to clean up Azure resources associated with AzureMachine before
 	// removing it from the apiserver.
 	MachineFinalizer = "azuremachine.infrastructure.cluster.x-k8s.io"
+
+	// PowerStateAnnotation is the AzureMachine annotation used to request a power state for the virtual machine.
+	// When the annotation is absent, the controller does not manage the power state of the virtual machine.
+	PowerStateAnnotation = "infrastructure.cluster.x-k8s.io/power-state"
+)
+
+// FailureDomainPolicy determines how a failure domain is chosen for a machine which doesn't set one.
+type FailureDomainPolicy string
+
+const (
+	// FailureDomainPolicySpread spreads the machines of a MachineDeployment evenly across failure domains.
+	FailureDomainPolicySpread FailureDomainPolicy = "Spread"
+	// FailureDomainPolicyPack places the machines of a MachineDeployment in as few failure domains as possible.
+	FailureDomainPolicyPack FailureDomainPolicy = "Pack"
+	// FailureDomainPolicyNone doesn't place the machine in a failure domain.
+	FailureDomainPolicyNone FailureDomainPolicy = "None"
+)
+
+// VMPowerState is a power state which can be requested for a virtual machine with the PowerStateAnnotation.
+type VMPowerState string
+
+const (
+	// VMPowerStateRunning requests that the virtual machine is started if it is not running.
+	VMPowerStateRunning VMPowerState = "running"
+	// VMPowerStateStopped requests that the virtual machine is stopped and deallocated.
+	VMPowerStateStopped VMPowerState = "stopped"
+	// VMPowerStateHibernated requests that the virtual machine is hibernated and deallocated.
+	// The virtual machine must have the hibernation capability enabled.
+	VMPowerStateHibernated VMPowerState = "hibernated"
 )
 
 // AzureMachineSpec defines the desired state of AzureMachine.
@@ -43,6 +72,26 @@ type AzureMachineSpec struct {
 	// +optional
 	FailureDomain *string `json:"failureDomain,omitempty"`
 
+	// FailureDomainPolicy determines how the controller chooses a failure domain for the machine when neither
+	// the Machine nor the AzureMachine sets one, e.g. for machines of a MachineDeployment without a failure domain.
+	// Spread chooses the failure domain with the fewest machines of the same MachineDeployment, Pack chooses
+	// the failure domain with the most machines of the same MachineDeployment, and None doesn't choose one.
+	// Only failure domains where the VM size is available are chosen. Defaults to None.
+	// +kubebuilder:validation:Enum=Spread;Pack;None
+	// +optional
+	FailureDomainPolicy FailureDomainPolicy `json:"failureDomainPolicy,omitempty"`
+
+	// AllowedFailureDomains restricts the failure domains which the FailureDomainPolicy chooses from.
+	// Defaults to all the failure domains of the cluster.
+	// +optional
+	AllowedFailureDomains []string `json:"allowedFailureDomains,omitempty"`
+
+	// AvailabilitySetDomainCounts are the fault and update domain counts of the availability set the machine joins
+	// in clusters without failure domains, overriding the defaults of the AzureCluster. They only take effect when
+	// the availability set is created, which is by the first machine that joins it.
+	// +optional
+	AvailabilitySetDomainCounts *AvailabilitySetDomainCounts `json:"availabilitySetDomainCounts,omitempty"`
+
 	// Image is used to provide details of an image to use during VM creation.
 	// If image details are omitted the image will default the Azure Marketplace "capi" offer,
 	// which is based on Ubuntu.
@@ -100,6 +149,15 @@ type AzureMachineSpec struct {
 	// +optional
 	AllocatePublicIP bool `json:"allocatePublicIP,omitempty"`
 
+	// PublicIPPrefixID is the resource ID of an existing public IP prefix from which the public IP of the machine
+	// is allocated when AllocatePublicIP is true, so that node public IPs come from a known CIDR.
+	// +optional
+	PublicIPPrefixID *string `json:"publicIPPrefixID,omitempty"`
+
+	// PublicIP specifies the DNS label and IP tags of the public IP of the machine when AllocatePublicIP is true.
+	// +optional
+	PublicIP *NodePublicIP `json:"publicIP,omitempty"`
+
 	// EnableIPForwarding enables IP Forwarding in Azure which is required for some CNI's to send traffic from a pods on one machine
 	// to another. This is required for IpV6 with Calico in combination with User Defined Routes (set by the Azure Cloud Controller
 	// manager). Default is false for disabled.
@@ -124,6 +182,21 @@ type AzureMachineSpec struct {
 	// +optional
 	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
 
+	// DedicatedHost specifies the Azure Dedicated Host or host group the virtual machine is placed on.
+	// +optional
+	DedicatedHost *DedicatedHost `json:"dedicatedHost,omitempty"`
+
+	// CapacityReservationGroupID is the resource ID of a capacity reservation group to allocate the virtual machine
+	// from, so that it consumes capacity reserved in that group.
+	// It can't be used with Spot VMs or dedicated hosts.
+	// +optional
+	CapacityReservationGroupID string `json:"capacityReservationGroupID,omitempty"`
+
+	// ProximityPlacementGroupName is the name of a proximity placement group in the cluster resource group to place
+	// the virtual machine in. It is typically one of the proximity placement groups of the AzureCluster.
+	// +optional
+	ProximityPlacementGroupName string `json:"proximityPlacementGroupName,omitempty"`
+
 	// Deprecated: SubnetName should be set in the networkInterfaces field.
 	// +optional
 	SubnetName string `json:"subnetName,omitempty"`
@@ -136,6 +209,11 @@ type AzureMachineSpec struct {
 	// +optional
 	VMExtensions []VMExtension `json:"vmExtensions,omitempty"`
 
+	// GPUDriver specifies whether to install the NVIDIA GPU driver extension on the virtual machine.
+	// The VM size must have NVIDIA GPUs, e.g. one of the NC, ND or NV series.
+	// +optional
+	GPUDriver *GPUDriver `json:"gpuDriver,omitempty"`
+
 	// NetworkInterfaces specifies a list of network interface configurations.
 	// If left unspecified, the VM will get a single network interface with a
 	// single IPConfig in the subnet specified in the cluster's node subnet field.
@@ -155,6 +233,19 @@ type SpotVMOptions struct {
 	EvictionPolicy *SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
 }
 
+// DedicatedHost defines the Azure Dedicated Host placement of a virtual machine.
+// Exactly one of HostID and HostGroupID must be set.
+type DedicatedHost struct {
+	// HostID is the resource ID of the dedicated host to place the virtual machine on.
+	// +optional
+	HostID string `json:"hostID,omitempty"`
+
+	// HostGroupID is the resource ID of a dedicated host group with automatic placement enabled.
+	// Azure selects the host within the group that the virtual machine is placed on.
+	// +optional
+	HostGroupID string `json:"hostGroupID,omitempty"`
+}
+
 // SystemAssignedIdentityRole defines the role and scope to assign to the system assigned identity.
 type SystemAssignedIdentityRole struct {
 	// Name is the name of the role assignment to create for a system assigned identity. It can be any valid UUID.
@@ -184,9 +275,17 @@ type AzureMachineStatus struct {
 	Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
 
 	// VMState is the provisioning state of the Azure virtual machine.
+	// When a power state is requested with the PowerStateAnnotation, it reflects the power state transition instead,
+	// e.g. Stopping and Stopped.
 	// +optional
 	VMState *ProvisioningState `json:"vmState,omitempty"`
 
+	// Image is the image used to create the virtual machine. It is only populated when the image
+	// version is resolved at reconcile time, such as an Azure Compute Gallery image with a 'latest'
+	// or semver range version.
+	// +optional
+	Image *Image `json:"image,omitempty"`
+
 	// ErrorReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -233,6 +332,31 @@ type AzureMachineStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationStatus reports the progress of the ongoing operation on the virtual machine, if any.
+	// +optional
+	OperationStatus *OperationStatus `json:"operationStatus,omitempty"`
+
+	// IPAddressClaims records the IPAddressClaims made for the network interfaces of the AzureMachine
+	// and the addresses allocated to them.
+	// +optional
+	IPAddressClaims []IPAddressClaim `json:"ipAddressClaims,omitempty"`
+}
+
+// IPAddressClaim records an IP address claimed from an IPAM pool for a network interface IP configuration.
+type IPAddressClaim struct {
+	// Name is the name of the IPAddressClaim.
+	Name string `json:"name"`
+
+	// NetworkInterface is the index of the network interface in spec.networkInterfaces.
+	NetworkInterface int `json:"networkInterface"`
+
+	// IPConfig is the index of the private IP configuration on the network interface.
+	IPConfig int `json:"ipConfig"`
+
+	// Address is the IP address allocated by the IPAM provider. It is empty until the claim is fulfilled.
+	// +optional
+	Address string `json:"address,omitempty"`
 }
 
 // AdditionalCapabilities enables or disables a capability on the virtual machine.
@@ -242,6 +366,11 @@ type AdditionalCapabilities struct {
 	// otherwise it doesn't set the capability on the VM.
 	// +optional
 	UltraSSDEnabled *bool `json:"ultraSSDEnabled,omitempty"`
+
+	// HibernationEnabled enables or disables the hibernation capability for the virtual machine.
+	// Hibernation requires a VM size and image which support it, and cannot be changed after the VM is created.
+	// +optional
+	HibernationEnabled *bool `json:"hibernationEnabled,omitempty"`
 }
 
 // +kubebuilder:object:root=true
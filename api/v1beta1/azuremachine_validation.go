@@ -19,11 +19,15 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"net"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 )
 
 // ValidateAzureMachineSpec check for validation errors of azuremachine.spec.
@@ -54,6 +58,32 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if spec.ProximityPlacementGroupName != "" {
+		if err := ValidateProximityPlacementGroupName(spec.ProximityPlacementGroupName, field.NewPath("proximityPlacementGroupName")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if errs := ValidateDedicatedHost(spec.DedicatedHost, spec.SpotVMOptions, field.NewPath("dedicatedHost")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if spec.CapacityReservationGroupID != "" {
+		fldPath := field.NewPath("capacityReservationGroupID")
+		if spec.DedicatedHost != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "virtual machines on dedicated hosts cannot consume capacity reservations"))
+		}
+		allErrs = append(allErrs, ValidateCapacityReservationGroupID(spec.CapacityReservationGroupID, spec.SpotVMOptions, fldPath)...)
+	}
+
+	if errs := ValidatePublicIPPrefixID(spec.PublicIPPrefixID, spec.AllocatePublicIP, field.NewPath("publicIPPrefixID")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateNodePublicIP(spec.PublicIP, spec.AllocatePublicIP, field.NewPath("publicIP")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := ValidateNetwork(spec.SubnetName, spec.AcceleratedNetworking, spec.NetworkInterfaces, field.NewPath("networkInterfaces")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -62,6 +92,123 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateSecurityProfile(spec.SecurityProfile, field.NewPath("securityProfile")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateConfidentialCompute(spec.OSDisk, spec.SecurityProfile, field.NewPath("securityProfile")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateVMExtensions(spec.VMExtensions, field.NewPath("vmExtensions")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateFailureDomainPolicy(spec.FailureDomainPolicy, spec.AllowedFailureDomains, field.NewPath("allowedFailureDomains")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
+}
+
+// ValidateVMExtensions validates a list of VM extensions.
+func ValidateVMExtensions(extensions []VMExtension, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := make(map[string]struct{})
+	for i, extension := range extensions {
+		if _, ok := names[extension.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), extension.Name))
+		} else {
+			names[extension.Name] = struct{}{}
+		}
+
+		if extension.ProtectedSettingsSecretRef != nil && extension.ProtectedSettingsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("protectedSettingsSecretRef", "name"), "the secret name cannot be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateSecurityProfile validates the SecurityProfile spec.
+func ValidateSecurityProfile(securityProfile *SecurityProfile, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if securityProfile == nil || securityProfile.UefiSettings == nil {
+		return allErrs
+	}
+
+	uefiSettings := securityProfile.UefiSettings
+	if securityProfile.SecurityType == "" {
+		if pointer.BoolDeref(uefiSettings.SecureBootEnabled, false) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "secureBootEnabled"), true,
+				fmt.Sprintf("secureBootEnabled can only be set when securityType is %s or %s", SecurityTypesTrustedLaunch, SecurityTypesConfidentialVM)))
+		}
+		if pointer.BoolDeref(uefiSettings.VTpmEnabled, false) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "vTpmEnabled"), true,
+				fmt.Sprintf("vTpmEnabled can only be set when securityType is %s or %s", SecurityTypesTrustedLaunch, SecurityTypesConfidentialVM)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateConfidentialCompute validates the configuration of a confidential VM, which spans its security profile
+// and the security profile of its OS disk.
+func ValidateConfidentialCompute(osDisk OSDisk, profile *SecurityProfile, fieldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var diskSecurityProfile *VMDiskSecurityProfile
+	if osDisk.ManagedDisk != nil {
+		diskSecurityProfile = osDisk.ManagedDisk.SecurityProfile
+	}
+	diskSecurityProfilePath := field.NewPath("osDisk", "managedDisk", "securityProfile")
+
+	isConfidentialVM := profile != nil && profile.SecurityType == SecurityTypesConfidentialVM
+	if !isConfidentialVM {
+		if diskSecurityProfile != nil && diskSecurityProfile.SecurityEncryptionType != "" {
+			allErrs = append(allErrs, field.Invalid(diskSecurityProfilePath.Child("securityEncryptionType"), diskSecurityProfile.SecurityEncryptionType,
+				fmt.Sprintf("securityEncryptionType can only be set when securityType is %s", SecurityTypesConfidentialVM)))
+		}
+		return allErrs
+	}
+
+	if diskSecurityProfile == nil || diskSecurityProfile.SecurityEncryptionType == "" {
+		allErrs = append(allErrs, field.Required(diskSecurityProfilePath.Child("securityEncryptionType"),
+			fmt.Sprintf("securityEncryptionType must be set when securityType is %s", SecurityTypesConfidentialVM)))
+		return allErrs
+	}
+
+	uefiSettings := profile.UefiSettings
+	if uefiSettings == nil {
+		uefiSettings = &UefiSettings{}
+	}
+	if !pointer.BoolDeref(uefiSettings.VTpmEnabled, false) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "vTpmEnabled"), uefiSettings.VTpmEnabled,
+			fmt.Sprintf("vTpmEnabled must be true when securityType is %s", SecurityTypesConfidentialVM)))
+	}
+
+	if diskSecurityProfile.SecurityEncryptionType == SecurityEncryptionTypeDiskWithVMGuestState {
+		if !pointer.BoolDeref(uefiSettings.SecureBootEnabled, false) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "secureBootEnabled"), uefiSettings.SecureBootEnabled,
+				fmt.Sprintf("secureBootEnabled must be true when securityEncryptionType is %s", SecurityEncryptionTypeDiskWithVMGuestState)))
+		}
+		if pointer.BoolDeref(profile.EncryptionAtHost, false) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("encryptionAtHost"), true,
+				fmt.Sprintf("encryptionAtHost cannot be enabled when securityEncryptionType is %s", SecurityEncryptionTypeDiskWithVMGuestState)))
+		}
+	}
+
+	// Confidential VMs don't support the regular server-side encryption settings of the OS disk nor ephemeral OS disks.
+	if osDisk.ManagedDisk.DiskEncryptionSet != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("osDisk", "managedDisk", "diskEncryptionSet"), osDisk.ManagedDisk.DiskEncryptionSet.ID,
+			fmt.Sprintf("diskEncryptionSet is not supported when securityType is %s, use securityProfile.diskEncryptionSet instead", SecurityTypesConfidentialVM)))
+	}
+	if osDisk.DiffDiskSettings != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("osDisk", "diffDiskSettings"), osDisk.DiffDiskSettings.Option,
+			fmt.Sprintf("ephemeral OS disks are not supported when securityType is %s", SecurityTypesConfidentialVM)))
+	}
+
 	return allErrs
 }
 
@@ -75,15 +222,203 @@ func ValidateNetwork(subnetName string, acceleratedNetworking *bool, networkInte
 		return field.ErrorList{field.Invalid(fldPath, networkInterfaces, "cannot set both networkInterfaces and machine acceleratedNetworking")}
 	}
 
-	for _, nic := range networkInterfaces {
+	for i, nic := range networkInterfaces {
 		if nic.PrivateIPConfigs < 1 {
 			return field.ErrorList{field.Invalid(fldPath, networkInterfaces, "number of privateIPConfigs per interface must be at least 1")}
 		}
+		if len(nic.AddressesFromPools) > nic.PrivateIPConfigs {
+			return field.ErrorList{field.Invalid(fldPath.Index(i).Child("addressesFromPools"), nic.AddressesFromPools, "number of addressesFromPools cannot exceed privateIPConfigs")}
+		}
+		for j, pool := range nic.AddressesFromPools {
+			if pool.APIGroup == nil || *pool.APIGroup == "" || pool.Kind == "" || pool.Name == "" {
+				return field.ErrorList{field.Invalid(fldPath.Index(i).Child("addressesFromPools").Index(j), pool, "apiGroup, kind and name must be set")}
+			}
+		}
+		if len(nic.IPConfigs) > 0 && len(nic.IPConfigs) != nic.PrivateIPConfigs {
+			return field.ErrorList{field.Invalid(fldPath.Index(i).Child("privateIPConfigs"), nic.PrivateIPConfigs, "privateIPConfigs must match the number of ipConfigs")}
+		}
+		if errs := validateApplicationSecurityGroups(nic.ApplicationSecurityGroups, fldPath.Index(i).Child("applicationSecurityGroups")); len(errs) > 0 {
+			return errs
+		}
+		privateIPAddresses := make(map[string]struct{})
+		if nic.PrivateIPAddress != "" {
+			if net.ParseIP(nic.PrivateIPAddress) == nil {
+				return field.ErrorList{field.Invalid(fldPath.Index(i).Child("privateIPAddress"), nic.PrivateIPAddress, "must be a valid IP address")}
+			}
+			if len(nic.IPConfigs) > 0 && nic.IPConfigs[0].PrivateIPAddress != "" {
+				return field.ErrorList{field.Forbidden(fldPath.Index(i).Child("privateIPAddress"), "cannot be set together with the private IP address of the primary ipConfig")}
+			}
+			if len(nic.AddressesFromPools) > 0 {
+				return field.ErrorList{field.Forbidden(fldPath.Index(i).Child("privateIPAddress"), "cannot set a private IP address for an interface which claims its primary address from a pool")}
+			}
+			privateIPAddresses[nic.PrivateIPAddress] = struct{}{}
+		}
+		for j, ipConfig := range nic.IPConfigs {
+			if ipConfig.PrivateIPAddress == "" {
+				continue
+			}
+			if net.ParseIP(ipConfig.PrivateIPAddress) == nil {
+				return field.ErrorList{field.Invalid(fldPath.Index(i).Child("ipConfigs").Index(j).Child("privateIPAddress"), ipConfig.PrivateIPAddress, "must be a valid IP address")}
+			}
+			if _, ok := privateIPAddresses[ipConfig.PrivateIPAddress]; ok {
+				return field.ErrorList{field.Duplicate(fldPath.Index(i).Child("ipConfigs").Index(j).Child("privateIPAddress"), ipConfig.PrivateIPAddress)}
+			}
+			privateIPAddresses[ipConfig.PrivateIPAddress] = struct{}{}
+			if j < len(nic.AddressesFromPools) {
+				return field.ErrorList{field.Forbidden(fldPath.Index(i).Child("ipConfigs").Index(j).Child("privateIPAddress"), "cannot set a private IP address for an IP configuration which claims its address from a pool")}
+			}
+		}
+	}
+
+	return field.ErrorList{}
+}
+
+// ValidateDedicatedHost validates the dedicated host placement of a virtual machine.
+func ValidateDedicatedHost(host *DedicatedHost, spotVMOptions *SpotVMOptions, fldPath *field.Path) field.ErrorList {
+	if host == nil {
+		return field.ErrorList{}
+	}
+
+	if (host.HostID == "") == (host.HostGroupID == "") {
+		return field.ErrorList{field.Invalid(fldPath, host, "exactly one of hostID and hostGroupID must be set")}
+	}
+
+	if spotVMOptions != nil {
+		return field.ErrorList{field.Forbidden(fldPath, "Spot VMs cannot be placed on dedicated hosts")}
+	}
+
+	if host.HostID != "" {
+		if err := validateResourceIDType(host.HostID, "Microsoft.Compute/hostGroups/hosts", fldPath.Child("hostID")); err != nil {
+			return field.ErrorList{err}
+		}
+	}
+
+	if host.HostGroupID != "" {
+		if err := validateResourceIDType(host.HostGroupID, "Microsoft.Compute/hostGroups", fldPath.Child("hostGroupID")); err != nil {
+			return field.ErrorList{err}
+		}
+	}
+
+	return field.ErrorList{}
+}
+
+// ValidatePublicIPPrefixID validates the public IP prefix from which the public IPs of a virtual machine or scale set are allocated.
+func ValidatePublicIPPrefixID(id *string, allocatePublicIP bool, fldPath *field.Path) field.ErrorList {
+	if id == nil {
+		return field.ErrorList{}
+	}
+
+	if !allocatePublicIP {
+		return field.ErrorList{field.Forbidden(fldPath, "publicIPPrefixID can only be set when allocatePublicIP is true")}
+	}
+
+	if err := validateResourceIDType(*id, "Microsoft.Network/publicIPPrefixes", fldPath); err != nil {
+		return field.ErrorList{err}
 	}
 
 	return field.ErrorList{}
 }
 
+// ValidateNodePublicIP validates the DNS label and IP tags of the public IPs of a virtual machine or scale set.
+func ValidateNodePublicIP(publicIP *NodePublicIP, allocatePublicIP bool, fldPath *field.Path) field.ErrorList {
+	if publicIP == nil {
+		return field.ErrorList{}
+	}
+
+	if !allocatePublicIP {
+		return field.ErrorList{field.Forbidden(fldPath, "publicIP can only be set when allocatePublicIP is true")}
+	}
+
+	allErrs := field.ErrorList{}
+	for i, ipTag := range publicIP.IPTags {
+		if ipTag.Type == "" || ipTag.Tag == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ipTags").Index(i), ipTag, "type and tag must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCapacityReservationGroupID validates the capacity reservation group of a virtual machine or scale set.
+func ValidateCapacityReservationGroupID(id string, spotVMOptions *SpotVMOptions, fldPath *field.Path) field.ErrorList {
+	if id == "" {
+		return field.ErrorList{}
+	}
+
+	if spotVMOptions != nil {
+		return field.ErrorList{field.Forbidden(fldPath, "Spot VMs cannot consume capacity reservations")}
+	}
+
+	if err := validateResourceIDType(id, "Microsoft.Compute/capacityReservationGroups", fldPath); err != nil {
+		return field.ErrorList{err}
+	}
+
+	return field.ErrorList{}
+}
+
+// ValidateFailureDomainPolicy validates the failure domains allowed for a failure domain policy.
+func ValidateFailureDomainPolicy(policy FailureDomainPolicy, allowedFailureDomains []string, fldPath *field.Path) field.ErrorList {
+	if len(allowedFailureDomains) == 0 {
+		return field.ErrorList{}
+	}
+
+	if policy != FailureDomainPolicySpread && policy != FailureDomainPolicyPack {
+		return field.ErrorList{field.Forbidden(fldPath, "allowed failure domains require a failureDomainPolicy of Spread or Pack")}
+	}
+
+	allErrs := field.ErrorList{}
+	seen := make(map[string]struct{}, len(allowedFailureDomains))
+	for i, fd := range allowedFailureDomains {
+		if fd == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "failure domain must not be empty"))
+			continue
+		}
+		if _, ok := seen[fd]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), fd))
+		}
+		seen[fd] = struct{}{}
+	}
+	return allErrs
+}
+
+// ValidatePowerState validates the power state requested for a virtual machine with the PowerStateAnnotation.
+func ValidatePowerState(powerState string, capabilities *AdditionalCapabilities, fldPath *field.Path) field.ErrorList {
+	switch VMPowerState(powerState) {
+	case "", VMPowerStateRunning, VMPowerStateStopped:
+		return field.ErrorList{}
+	case VMPowerStateHibernated:
+		if capabilities == nil || !pointer.BoolDeref(capabilities.HibernationEnabled, false) {
+			return field.ErrorList{field.Forbidden(fldPath, "hibernation requires spec.additionalCapabilities.hibernationEnabled to be true")}
+		}
+		return field.ErrorList{}
+	default:
+		return field.ErrorList{field.NotSupported(fldPath, powerState, []string{string(VMPowerStateRunning), string(VMPowerStateStopped), string(VMPowerStateHibernated)})}
+	}
+}
+
+// ValidateDiskEncryptionSet validates that a disk encryption set references a Microsoft.Compute/diskEncryptionSets resource.
+func ValidateDiskEncryptionSet(des *DiskEncryptionSetParameters, fldPath *field.Path) field.ErrorList {
+	if des == nil {
+		return field.ErrorList{}
+	}
+	if err := validateResourceIDType(des.ID, "Microsoft.Compute/diskEncryptionSets", fldPath.Child("id")); err != nil {
+		return field.ErrorList{err}
+	}
+	return field.ErrorList{}
+}
+
+// validateResourceIDType validates that id is an Azure resource ID of the given resource type.
+func validateResourceIDType(id, resourceType string, fldPath *field.Path) *field.Error {
+	parsed, err := arm.ParseResourceID(id)
+	if err != nil {
+		return field.Invalid(fldPath, id, fmt.Sprintf("must be a valid Azure resource ID: %v", err))
+	}
+	if !strings.EqualFold(parsed.ResourceType.String(), resourceType) {
+		return field.Invalid(fldPath, id, fmt.Sprintf("must be the resource ID of a %s resource", resourceType))
+	}
+	return nil
+}
+
 // ValidateSSHKey validates an SSHKey.
 func ValidateSSHKey(sshKey string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -217,6 +552,8 @@ func ValidateOSDisk(osDisk OSDisk, fieldPath *field.Path) field.ErrorList {
 		}
 	}
 
+	allErrs = append(allErrs, ValidateDiffDiskSettings(osDisk.DiffDiskSettings, fieldPath.Child("diffDiskSettings"))...)
+
 	if osDisk.DiffDiskSettings != nil && osDisk.ManagedDisk != nil && osDisk.ManagedDisk.DiskEncryptionSet != nil {
 		allErrs = append(allErrs, field.Invalid(
 			fieldPath.Child("managedDisks").Child("diskEncryptionSet"),
@@ -228,12 +565,40 @@ func ValidateOSDisk(osDisk OSDisk, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// ValidateDiffDiskSettings validates the ephemeral OS disk settings.
+func ValidateDiffDiskSettings(settings *DiffDiskSettings, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if settings == nil || settings.Placement == nil {
+		return allErrs
+	}
+
+	switch *settings.Placement {
+	case DiffDiskPlacementCacheDisk, DiffDiskPlacementResourceDisk:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("placement"), *settings.Placement,
+			[]string{string(DiffDiskPlacementCacheDisk), string(DiffDiskPlacementResourceDisk)}))
+	}
+
+	return allErrs
+}
+
 // validateManagedDisk validates updates to the ManagedDiskParameters field.
 func validateManagedDisk(m *ManagedDiskParameters, fieldPath *field.Path, isOSDisk bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if m != nil {
 		allErrs = append(allErrs, validateStorageAccountType(m.StorageAccountType, fieldPath.Child("StorageAccountType"), isOSDisk)...)
+		allErrs = append(allErrs, ValidateDiskEncryptionSet(m.DiskEncryptionSet, fieldPath.Child("diskEncryptionSet"))...)
+
+		if m.SecurityProfile != nil {
+			if !isOSDisk {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("securityProfile"), "securityProfile can only be set on the OS disk"))
+			} else if m.SecurityProfile.DiskEncryptionSet != nil && m.SecurityProfile.SecurityEncryptionType != SecurityEncryptionTypeDiskWithVMGuestState {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("securityProfile", "diskEncryptionSet"), m.SecurityProfile.DiskEncryptionSet.ID,
+					fmt.Sprintf("diskEncryptionSet can only be set when securityEncryptionType is %s", SecurityEncryptionTypeDiskWithVMGuestState)))
+			}
+		}
 	}
 
 	return allErrs
@@ -307,8 +672,8 @@ func validateManagedDisksUpdate(oldDiskParams, newDiskParams *ManagedDiskParamet
 func validateStorageAccountType(storageAccountType string, fieldPath *field.Path, isOSDisk bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if isOSDisk && storageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
-		allErrs = append(allErrs, field.Invalid(fieldPath.Child("managedDisks").Child("storageAccountType"), storageAccountType, "UltraSSD_LRS can only be used with data disks, it cannot be used with OS Disks"))
+	if isOSDisk && (storageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) || storageAccountType == StorageAccountTypePremiumV2LRS) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("managedDisks").Child("storageAccountType"), storageAccountType, fmt.Sprintf("%s can only be used with data disks, it cannot be used with OS Disks", storageAccountType)))
 	}
 
 	if storageAccountType == "" {
@@ -316,22 +681,33 @@ func validateStorageAccountType(storageAccountType string, fieldPath *field.Path
 		return allErrs
 	}
 
+	possibleStorageAccountTypes := make([]string, 0, len(compute.PossibleDiskStorageAccountTypesValues())+1)
 	for _, possibleStorageAccountType := range compute.PossibleDiskStorageAccountTypesValues() {
-		if string(possibleStorageAccountType) == storageAccountType {
+		possibleStorageAccountTypes = append(possibleStorageAccountTypes, string(possibleStorageAccountType))
+	}
+	possibleStorageAccountTypes = append(possibleStorageAccountTypes, StorageAccountTypePremiumV2LRS)
+
+	for _, possibleStorageAccountType := range possibleStorageAccountTypes {
+		if possibleStorageAccountType == storageAccountType {
 			return allErrs
 		}
 	}
-	allErrs = append(allErrs, field.Invalid(fieldPath, "", fmt.Sprintf("allowed values are %v", compute.PossibleDiskStorageAccountTypesValues())))
+	allErrs = append(allErrs, field.Invalid(fieldPath, "", fmt.Sprintf("allowed values are %v", possibleStorageAccountTypes)))
 	return allErrs
 }
 
+// supportsHostCaching returns false for the storage account types of disks that don't support host caching.
+func supportsHostCaching(storageAccountType string) bool {
+	return storageAccountType != string(compute.StorageAccountTypesUltraSSDLRS) && storageAccountType != StorageAccountTypePremiumV2LRS
+}
+
 func validateCachingType(cachingType string, fieldPath *field.Path, managedDisk *ManagedDiskParameters) field.ErrorList {
 	allErrs := field.ErrorList{}
 	cachingTypeChildPath := fieldPath.Child("CachingType")
 
-	if managedDisk != nil && managedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
+	if managedDisk != nil && !supportsHostCaching(managedDisk.StorageAccountType) {
 		if cachingType != string(compute.CachingTypesNone) {
-			allErrs = append(allErrs, field.Invalid(cachingTypeChildPath, cachingType, fmt.Sprintf("cachingType '%s' is not supported when storageAccountType is '%s'. Allowed values are: '%s'", cachingType, compute.StorageAccountTypesUltraSSDLRS, compute.CachingTypesNone)))
+			allErrs = append(allErrs, field.Invalid(cachingTypeChildPath, cachingType, fmt.Sprintf("cachingType '%s' is not supported when storageAccountType is '%s'. Allowed values are: '%s'", cachingType, managedDisk.StorageAccountType, compute.CachingTypesNone)))
 		}
 	}
 
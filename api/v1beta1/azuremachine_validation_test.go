@@ -27,6 +27,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 )
@@ -97,6 +98,50 @@ func TestAzureMachine_ValidateOSDisk(t *testing.T) {
 			wantErr: true,
 			osDisk:  createOSDiskWithCacheType("invalid_cache_type"),
 		},
+		{
+			name:    "premium v2 os disk",
+			wantErr: true,
+			osDisk: OSDisk{
+				DiskSizeGB:  pointer.Int32(30),
+				CachingType: "None",
+				OSType:      "blah",
+				ManagedDisk: &ManagedDiskParameters{
+					StorageAccountType: StorageAccountTypePremiumV2LRS,
+				},
+			},
+		},
+		{
+			name:    "valid ephemeral os disk placement",
+			wantErr: false,
+			osDisk: OSDisk{
+				DiskSizeGB:  pointer.Int32(30),
+				CachingType: "ReadOnly",
+				OSType:      "blah",
+				DiffDiskSettings: &DiffDiskSettings{
+					Option:    string(compute.DiffDiskOptionsLocal),
+					Placement: (*DiffDiskPlacement)(pointer.String(string(DiffDiskPlacementResourceDisk))),
+				},
+				ManagedDisk: &ManagedDiskParameters{
+					StorageAccountType: "Standard_LRS",
+				},
+			},
+		},
+		{
+			name:    "invalid ephemeral os disk placement",
+			wantErr: true,
+			osDisk: OSDisk{
+				DiskSizeGB:  pointer.Int32(30),
+				CachingType: "ReadOnly",
+				OSType:      "blah",
+				DiffDiskSettings: &DiffDiskSettings{
+					Option:    string(compute.DiffDiskOptionsLocal),
+					Placement: (*DiffDiskPlacement)(pointer.String("NvmeDisk")),
+				},
+				ManagedDisk: &ManagedDiskParameters{
+					StorageAccountType: "Standard_LRS",
+				},
+			},
+		},
 		{
 			name:    "valid ephemeral os disk spec",
 			wantErr: false,
@@ -112,6 +157,23 @@ func TestAzureMachine_ValidateOSDisk(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "confidential disk encryption set without disk with guest state encryption",
+			wantErr: true,
+			osDisk: OSDisk{
+				DiskSizeGB: pointer.Int32(30),
+				OSType:     "Linux",
+				ManagedDisk: &ManagedDiskParameters{
+					StorageAccountType: "Premium_LRS",
+					SecurityProfile: &VMDiskSecurityProfile{
+						SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+						DiskEncryptionSet: &DiskEncryptionSetParameters{
+							ID: "disk-encryption-set",
+						},
+					},
+				},
+			},
+		},
 		{
 			name:    "byoc encryption with ephemeral os disk spec",
 			wantErr: true,
@@ -262,6 +324,60 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid data disk with disk encryption set",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         pointer.Int32(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						DiskEncryptionSet: &DiskEncryptionSetParameters{
+							ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/diskEncryptionSets/my-des",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "data disk with invalid disk encryption set ID",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         pointer.Int32(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						DiskEncryptionSet: &DiskEncryptionSetParameters{
+							ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/my-vault",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "data disk with security profile",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         pointer.Int32(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &VMDiskSecurityProfile{
+							SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "duplicate names",
 			disks: []DataDisk{
@@ -415,6 +531,36 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid combination of managed disk storage account type PremiumV2_LRS and cachingType None",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 64,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: StorageAccountTypePremiumV2LRS,
+					},
+					Lun:         pointer.Int32(0),
+					CachingType: string(compute.CachingTypesNone),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid combination of managed disk storage account type PremiumV2_LRS and cachingType ReadOnly",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 64,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: StorageAccountTypePremiumV2LRS,
+					},
+					Lun:         pointer.Int32(0),
+					CachingType: string(compute.CachingTypesReadOnly),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid combination of managed disk storage account type UltraSSD_LRS and cachingType ReadOnly",
 			disks: []DataDisk{
@@ -822,6 +968,28 @@ func TestAzureMachine_ValidateNetwork(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:                  "valid config with application security groups",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:                "subnet1",
+				PrivateIPConfigs:          1,
+				ApplicationSecurityGroups: []string{"frontend", "backend"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:                  "invalid config with duplicate application security groups",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:                "subnet1",
+				PrivateIPConfigs:          1,
+				ApplicationSecurityGroups: []string{"backend", "backend"},
+			}},
+			wantErr: true,
+		},
 		{
 			name:                  "invalid config using both deprecated subnetName and networkInterfaces",
 			subnetName:            "subnet1",
@@ -855,6 +1023,164 @@ func TestAzureMachine_ValidateNetwork(t *testing.T) {
 			}},
 			wantErr: true,
 		},
+		{
+			name:                  "valid config with addressesFromPools",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 2,
+				AddressesFromPools: []corev1.TypedLocalObjectReference{
+					{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool"},
+				},
+			}},
+			wantErr: false,
+		},
+		{
+			name:                  "invalid config with more addressesFromPools than privateIPConfigs",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				AddressesFromPools: []corev1.TypedLocalObjectReference{
+					{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool1"},
+					{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool2"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with addressesFromPools missing apiGroup",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				AddressesFromPools: []corev1.TypedLocalObjectReference{
+					{Kind: "InClusterIPPool", Name: "pool"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "valid config with ipConfigs",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 3,
+				IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.4"}, {}, {PrivateIPAddress: "10.0.0.6"}},
+			}},
+			wantErr: false,
+		},
+		{
+			name:                  "invalid config with privateIPConfigs not matching ipConfigs",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				IPConfigs:        []NetworkInterfaceIPConfig{{}, {}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with invalid ipConfigs private IP address",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with duplicate ipConfigs private IP addresses",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 2,
+				IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.4"}, {PrivateIPAddress: "10.0.0.4"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with ipConfigs private IP address claimed from a pool",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.4"}},
+				AddressesFromPools: []corev1.TypedLocalObjectReference{
+					{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "valid config with static private IP address",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				PrivateIPAddress: "10.0.0.4",
+			}},
+			wantErr: false,
+		},
+		{
+			name:                  "invalid config with invalid static private IP address",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				PrivateIPAddress: "10.0.0.256",
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with static private IP address and primary ipConfig private IP address",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				PrivateIPAddress: "10.0.0.4",
+				IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.5"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with static private IP address duplicated in a secondary ipConfig",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 2,
+				PrivateIPAddress: "10.0.0.4",
+				IPConfigs:        []NetworkInterfaceIPConfig{{}, {PrivateIPAddress: "10.0.0.4"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "invalid config with static private IP address and addressesFromPools",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				PrivateIPAddress: "10.0.0.4",
+				AddressesFromPools: []corev1.TypedLocalObjectReference{
+					{APIGroup: pointer.String("ipam.cluster.x-k8s.io"), Kind: "InClusterIPPool", Name: "pool"},
+				},
+			}},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -868,3 +1194,571 @@ func TestAzureMachine_ValidateNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachine_ValidateDedicatedHost(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name          string
+		host          *DedicatedHost
+		spotVMOptions *SpotVMOptions
+		wantErr       bool
+	}{
+		{
+			name:    "no dedicated host",
+			host:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "valid host ID",
+			host:    &DedicatedHost{HostID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group/hosts/my-host"},
+			wantErr: false,
+		},
+		{
+			name:    "valid host group ID",
+			host:    &DedicatedHost{HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group"},
+			wantErr: false,
+		},
+		{
+			name: "both host and host group ID",
+			host: &DedicatedHost{
+				HostID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group/hosts/my-host",
+				HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "neither host nor host group ID",
+			host:    &DedicatedHost{},
+			wantErr: true,
+		},
+		{
+			name:    "host ID of the wrong resource type",
+			host:    &DedicatedHost{HostID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed host group ID",
+			host:    &DedicatedHost{HostGroupID: "my-group"},
+			wantErr: true,
+		},
+		{
+			name:          "spot VM on a dedicated host",
+			host:          &DedicatedHost{HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group"},
+			spotVMOptions: &SpotVMOptions{},
+			wantErr:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDedicatedHost(test.host, test.spotVMOptions, field.NewPath("dedicatedHost"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateCapacityReservationGroupID(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name          string
+		id            string
+		spotVMOptions *SpotVMOptions
+		wantErr       bool
+	}{
+		{
+			name:    "no capacity reservation group",
+			id:      "",
+			wantErr: false,
+		},
+		{
+			name:    "valid capacity reservation group ID",
+			id:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+			wantErr: false,
+		},
+		{
+			name:    "capacity reservation group ID of the wrong resource type",
+			id:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-group",
+			wantErr: true,
+		},
+		{
+			name:    "malformed capacity reservation group ID",
+			id:      "my-crg",
+			wantErr: true,
+		},
+		{
+			name:          "spot VM consuming a capacity reservation",
+			id:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+			spotVMOptions: &SpotVMOptions{},
+			wantErr:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCapacityReservationGroupID(test.id, test.spotVMOptions, field.NewPath("capacityReservationGroupID"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidatePublicIPPrefixID(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name             string
+		id               *string
+		allocatePublicIP bool
+		wantErr          bool
+	}{
+		{
+			name:             "no public IP prefix",
+			id:               nil,
+			allocatePublicIP: true,
+			wantErr:          false,
+		},
+		{
+			name:             "valid public IP prefix ID",
+			id:               pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			allocatePublicIP: true,
+			wantErr:          false,
+		},
+		{
+			name:             "public IP prefix ID of the wrong resource type",
+			id:               pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"),
+			allocatePublicIP: true,
+			wantErr:          true,
+		},
+		{
+			name:             "public IP prefix without allocatePublicIP",
+			id:               pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			allocatePublicIP: false,
+			wantErr:          true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidatePublicIPPrefixID(test.id, test.allocatePublicIP, field.NewPath("publicIPPrefixID"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateNodePublicIP(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name             string
+		publicIP         *NodePublicIP
+		allocatePublicIP bool
+		wantErr          bool
+	}{
+		{
+			name:             "no public IP settings",
+			publicIP:         nil,
+			allocatePublicIP: false,
+			wantErr:          false,
+		},
+		{
+			name:             "valid DNS label prefix and IP tags",
+			publicIP:         &NodePublicIP{DNSLabelPrefix: "nodes", IPTags: []IPTag{{Type: "RoutingPreference", Tag: "Internet"}}},
+			allocatePublicIP: true,
+			wantErr:          false,
+		},
+		{
+			name:             "IP tag without a tag",
+			publicIP:         &NodePublicIP{IPTags: []IPTag{{Type: "FirstPartyUsage"}}},
+			allocatePublicIP: true,
+			wantErr:          true,
+		},
+		{
+			name:             "public IP settings without allocatePublicIP",
+			publicIP:         &NodePublicIP{DNSLabelPrefix: "nodes"},
+			allocatePublicIP: false,
+			wantErr:          true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateNodePublicIP(test.publicIP, test.allocatePublicIP, field.NewPath("publicIP"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateFailureDomainPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name                  string
+		policy                FailureDomainPolicy
+		allowedFailureDomains []string
+		wantErr               bool
+	}{
+		{
+			name:    "no failure domain policy",
+			wantErr: false,
+		},
+		{
+			name:                  "spread policy with allowed failure domains",
+			policy:                FailureDomainPolicySpread,
+			allowedFailureDomains: []string{"1", "2"},
+			wantErr:               false,
+		},
+		{
+			name:                  "pack policy with allowed failure domains",
+			policy:                FailureDomainPolicyPack,
+			allowedFailureDomains: []string{"3"},
+			wantErr:               false,
+		},
+		{
+			name:                  "allowed failure domains without a failure domain policy",
+			allowedFailureDomains: []string{"1"},
+			wantErr:               true,
+		},
+		{
+			name:                  "allowed failure domains with the None policy",
+			policy:                FailureDomainPolicyNone,
+			allowedFailureDomains: []string{"1"},
+			wantErr:               true,
+		},
+		{
+			name:                  "duplicate allowed failure domains",
+			policy:                FailureDomainPolicySpread,
+			allowedFailureDomains: []string{"1", "1"},
+			wantErr:               true,
+		},
+		{
+			name:                  "empty allowed failure domain",
+			policy:                FailureDomainPolicySpread,
+			allowedFailureDomains: []string{""},
+			wantErr:               true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateFailureDomainPolicy(test.policy, test.allowedFailureDomains, field.NewPath("allowedFailureDomains"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidatePowerState(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name         string
+		powerState   string
+		capabilities *AdditionalCapabilities
+		wantErr      bool
+	}{
+		{
+			name:       "no power state",
+			powerState: "",
+			wantErr:    false,
+		},
+		{
+			name:       "running power state",
+			powerState: "running",
+			wantErr:    false,
+		},
+		{
+			name:       "stopped power state without hibernation enabled",
+			powerState: "stopped",
+			wantErr:    false,
+		},
+		{
+			name:         "hibernated power state with hibernation enabled",
+			powerState:   "hibernated",
+			capabilities: &AdditionalCapabilities{HibernationEnabled: pointer.Bool(true)},
+			wantErr:      false,
+		},
+		{
+			name:       "hibernated power state without additional capabilities",
+			powerState: "hibernated",
+			wantErr:    true,
+		},
+		{
+			name:         "hibernated power state with hibernation disabled",
+			powerState:   "hibernated",
+			capabilities: &AdditionalCapabilities{HibernationEnabled: pointer.Bool(false)},
+			wantErr:      true,
+		},
+		{
+			name:       "unsupported power state",
+			powerState: "sleeping",
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidatePowerState(test.powerState, test.capabilities, field.NewPath("metadata", "annotations").Key(PowerStateAnnotation))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateVMExtensions(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name       string
+		extensions []VMExtension
+		wantErr    bool
+	}{
+		{
+			name:       "no extensions",
+			extensions: nil,
+			wantErr:    false,
+		},
+		{
+			name: "extensions with unique names",
+			extensions: []VMExtension{
+				{Name: "custom-script", Type: "CustomScript", Publisher: "Microsoft.Azure.Extensions", Version: "2.1"},
+				{Name: "other-extension", Publisher: "Microsoft.Azure.Extensions", Version: "1.0", ProtectedSettingsSecretRef: &corev1.LocalObjectReference{Name: "my-secret"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "extensions with duplicate names",
+			extensions: []VMExtension{
+				{Name: "custom-script", Publisher: "Microsoft.Azure.Extensions", Version: "2.1"},
+				{Name: "custom-script", Publisher: "Microsoft.Azure.Extensions", Version: "2.0"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "extension with an empty protected settings secret name",
+			extensions: []VMExtension{
+				{Name: "custom-script", Publisher: "Microsoft.Azure.Extensions", Version: "2.1", ProtectedSettingsSecretRef: &corev1.LocalObjectReference{}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateVMExtensions(test.extensions, field.NewPath("vmExtensions"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateSecurityProfile(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name            string
+		securityProfile *SecurityProfile
+		wantErr         bool
+	}{
+		{
+			name:            "no security profile",
+			securityProfile: nil,
+			wantErr:         false,
+		},
+		{
+			name:            "encryption at host only",
+			securityProfile: &SecurityProfile{EncryptionAtHost: pointer.Bool(true)},
+			wantErr:         false,
+		},
+		{
+			name: "trusted launch with secure boot and vTPM",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(true),
+					VTpmEnabled:       pointer.Bool(true),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secure boot disabled without security type",
+			securityProfile: &SecurityProfile{
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(false),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secure boot without security type",
+			securityProfile: &SecurityProfile{
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "vTPM without security type",
+			securityProfile: &SecurityProfile{
+				UefiSettings: &UefiSettings{
+					VTpmEnabled: pointer.Bool(true),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSecurityProfile(test.securityProfile, field.NewPath("securityProfile"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
+	g := NewWithT(t)
+
+	confidentialOSDisk := func(encryptionType SecurityEncryptionType) OSDisk {
+		return OSDisk{
+			OSType: "Linux",
+			ManagedDisk: &ManagedDiskParameters{
+				StorageAccountType: "Premium_LRS",
+				SecurityProfile: &VMDiskSecurityProfile{
+					SecurityEncryptionType: encryptionType,
+				},
+			},
+		}
+	}
+	confidentialVM := func(secureBoot, vTPM bool) *SecurityProfile {
+		return &SecurityProfile{
+			SecurityType: SecurityTypesConfidentialVM,
+			UefiSettings: &UefiSettings{
+				SecureBootEnabled: pointer.Bool(secureBoot),
+				VTpmEnabled:       pointer.Bool(vTPM),
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		osDisk          OSDisk
+		securityProfile *SecurityProfile
+		wantErr         bool
+	}{
+		{
+			name:            "not a confidential VM",
+			osDisk:          OSDisk{OSType: "Linux"},
+			securityProfile: nil,
+			wantErr:         false,
+		},
+		{
+			name:            "guest state only encryption",
+			osDisk:          confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			securityProfile: confidentialVM(false, true),
+			wantErr:         false,
+		},
+		{
+			name:            "disk with guest state encryption",
+			osDisk:          confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			securityProfile: confidentialVM(true, true),
+			wantErr:         false,
+		},
+		{
+			name:            "security encryption type without confidential VM",
+			osDisk:          confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesTrustedLaunch},
+			wantErr:         true,
+		},
+		{
+			name:            "confidential VM without security encryption type",
+			osDisk:          OSDisk{OSType: "Linux", ManagedDisk: &ManagedDiskParameters{StorageAccountType: "Premium_LRS"}},
+			securityProfile: confidentialVM(true, true),
+			wantErr:         true,
+		},
+		{
+			name:            "confidential VM without vTPM",
+			osDisk:          confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			securityProfile: confidentialVM(false, false),
+			wantErr:         true,
+		},
+		{
+			name:            "disk with guest state encryption without secure boot",
+			osDisk:          confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			securityProfile: confidentialVM(false, true),
+			wantErr:         true,
+		},
+		{
+			name:   "disk with guest state encryption with encryption at host",
+			osDisk: confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			securityProfile: func() *SecurityProfile {
+				profile := confidentialVM(true, true)
+				profile.EncryptionAtHost = pointer.Bool(true)
+				return profile
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM with server-side encryption disk encryption set",
+			osDisk: func() OSDisk {
+				osDisk := confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState)
+				osDisk.ManagedDisk.DiskEncryptionSet = &DiskEncryptionSetParameters{ID: "my-des-id"}
+				return osDisk
+			}(),
+			securityProfile: confidentialVM(true, true),
+			wantErr:         true,
+		},
+		{
+			name: "confidential VM with ephemeral OS disk",
+			osDisk: func() OSDisk {
+				osDisk := confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly)
+				osDisk.DiffDiskSettings = &DiffDiskSettings{Option: "Local"}
+				return osDisk
+			}(),
+			securityProfile: confidentialVM(false, true),
+			wantErr:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateConfidentialCompute(test.osDisk, test.securityProfile, field.NewPath("securityProfile"))
+			if test.wantErr {
+				g.Expect(err).ToNot(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -65,6 +65,18 @@ func (mw *azureMachineWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidatePowerState(m.Annotations[PowerStateAnnotation], spec.AdditionalCapabilities, powerStateAnnotationPath()); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateReconcilePolicy(m.Annotations[ReconcilePolicyAnnotation], machineReconcilePolicies, reconcilePolicyAnnotationPath()); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateDeletePolicy(m.Annotations[DeletePolicyAnnotation], deletePolicyAnnotationPath()); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -147,6 +159,31 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "PublicIPPrefixID"),
+		old.Spec.PublicIPPrefixID,
+		m.Spec.PublicIPPrefixID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if !reflect.DeepEqual(m.Spec.PublicIP, old.Spec.PublicIP) {
+		// The DNS label of an existing public IP can be changed, but its IP tags can't.
+		var oldIPTags, newIPTags []IPTag
+		if old.Spec.PublicIP != nil {
+			oldIPTags = old.Spec.PublicIP.IPTags
+		}
+		if m.Spec.PublicIP != nil {
+			newIPTags = m.Spec.PublicIP.IPTags
+		}
+		if err := webhookutils.ValidateImmutable(
+			field.NewPath("Spec", "PublicIP", "IPTags"),
+			oldIPTags,
+			newIPTags); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		allErrs = append(allErrs, ValidateNodePublicIP(m.Spec.PublicIP, m.Spec.AllocatePublicIP, field.NewPath("spec", "publicIP"))...)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "EnableIPForwarding"),
 		old.Spec.EnableIPForwarding,
@@ -178,6 +215,67 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "GPUDriver"),
+		old.Spec.GPUDriver,
+		m.Spec.GPUDriver); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ProximityPlacementGroupName"),
+		old.Spec.ProximityPlacementGroupName,
+		m.Spec.ProximityPlacementGroupName); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "DedicatedHost"),
+		old.Spec.DedicatedHost,
+		m.Spec.DedicatedHost); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "CapacityReservationGroupID"),
+		old.Spec.CapacityReservationGroupID,
+		m.Spec.CapacityReservationGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "AvailabilitySetDomainCounts"),
+		old.Spec.AvailabilitySetDomainCounts,
+		m.Spec.AvailabilitySetDomainCounts); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	var oldHibernationEnabled, newHibernationEnabled *bool
+	if old.Spec.AdditionalCapabilities != nil {
+		oldHibernationEnabled = old.Spec.AdditionalCapabilities.HibernationEnabled
+	}
+	if m.Spec.AdditionalCapabilities != nil {
+		newHibernationEnabled = m.Spec.AdditionalCapabilities.HibernationEnabled
+	}
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "AdditionalCapabilities", "HibernationEnabled"),
+		oldHibernationEnabled,
+		newHibernationEnabled); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if errs := ValidatePowerState(m.Annotations[PowerStateAnnotation], m.Spec.AdditionalCapabilities, powerStateAnnotationPath()); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateReconcilePolicy(m.Annotations[ReconcilePolicyAnnotation], machineReconcilePolicies, reconcilePolicyAnnotationPath()); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateDeletePolicy(m.Annotations[DeletePolicyAnnotation], deletePolicyAnnotationPath()); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if old.Spec.Diagnostics != nil {
 		if err := webhookutils.ValidateImmutable(
 			field.NewPath("Spec", "Diagnostics"),
@@ -196,6 +294,25 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 			old.Spec.NetworkInterfaces[0].SubnetName = m.Spec.NetworkInterfaces[0].SubnetName
 		}
 
+		// The secondary IP configurations, application security groups and IP forwarding of existing interfaces can be changed.
+		if len(old.Spec.NetworkInterfaces) == len(m.Spec.NetworkInterfaces) {
+			for i := range m.Spec.NetworkInterfaces {
+				oldNIC, newNIC := &old.Spec.NetworkInterfaces[i], m.Spec.NetworkInterfaces[i]
+				oldNIC.PrivateIPConfigs = newNIC.PrivateIPConfigs
+				oldNIC.EnableIPForwarding = newNIC.EnableIPForwarding
+				oldNIC.ApplicationSecurityGroups = newNIC.ApplicationSecurityGroups
+				primary := NetworkInterfaceIPConfig{}
+				if len(oldNIC.IPConfigs) > 0 {
+					primary = oldNIC.IPConfigs[0]
+				}
+				oldNIC.IPConfigs = newNIC.IPConfigs
+				if len(newNIC.IPConfigs) > 0 {
+					oldNIC.IPConfigs = append([]NetworkInterfaceIPConfig{primary}, newNIC.IPConfigs[1:]...)
+				}
+			}
+			allErrs = append(allErrs, ValidateNetwork("", nil, m.Spec.NetworkInterfaces, field.NewPath("spec", "networkInterfaces"))...)
+		}
+
 		// Enforce immutability for all other changes to NetworkInterfaces.
 		if !reflect.DeepEqual(m.Spec.NetworkInterfaces, old.Spec.NetworkInterfaces) {
 			allErrs = append(allErrs,
@@ -211,6 +328,14 @@ func (mw *azureMachineWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 	return apierrors.NewInvalid(GroupVersion.WithKind("AzureMachine").GroupKind(), m.Name, allErrs)
 }
 
+// machineReconcilePolicies are the reconcile policies supported by AzureMachines.
+var machineReconcilePolicies = []ReconcilePolicy{ReconcilePolicySkip}
+
+// powerStateAnnotationPath returns the field path of the PowerStateAnnotation.
+func powerStateAnnotationPath() *field.Path {
+	return field.NewPath("metadata", "annotations").Key(PowerStateAnnotation)
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (mw *azureMachineWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
 	return nil
@@ -480,6 +480,54 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.PublicIPPrefixID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AllocatePublicIP: true,
+					PublicIPPrefixID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/prefix-1"),
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AllocatePublicIP: true,
+					PublicIPPrefixID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/prefix-2"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.PublicIP.DNSLabelPrefix is mutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AllocatePublicIP: true,
+					PublicIP:         &NodePublicIP{DNSLabelPrefix: "nodes", IPTags: []IPTag{{Type: "RoutingPreference", Tag: "Internet"}}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AllocatePublicIP: true,
+					PublicIP:         &NodePublicIP{DNSLabelPrefix: "workers", IPTags: []IPTag{{Type: "RoutingPreference", Tag: "Internet"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.PublicIP.IPTags is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AllocatePublicIP: true,
+					PublicIP:         &NodePublicIP{DNSLabelPrefix: "nodes"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AllocatePublicIP: true,
+					PublicIP:         &NodePublicIP{DNSLabelPrefix: "nodes", IPTags: []IPTag{{Type: "RoutingPreference", Tag: "Internet"}}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.EnableIPForwarding is immutable",
 			oldMachine: &AzureMachine{
@@ -628,6 +676,209 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.DedicatedHost is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DedicatedHost: &DedicatedHost{HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/group-1"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DedicatedHost: &DedicatedHost{HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/group-2"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.DedicatedHost is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DedicatedHost: &DedicatedHost{HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/group-1"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DedicatedHost: &DedicatedHost{HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/group-1"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.CapacityReservationGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.CapacityReservationGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.AvailabilitySetDomainCounts is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AvailabilitySetDomainCounts: &AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(2)},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AvailabilitySetDomainCounts: &AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(3)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.AvailabilitySetDomainCounts is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AvailabilitySetDomainCounts: &AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(2), UpdateDomainCount: pointer.Int32(10)},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AvailabilitySetDomainCounts: &AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(2), UpdateDomainCount: pointer.Int32(10)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.GPUDriver is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					GPUDriver: &GPUDriver{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.GPUDriver is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					GPUDriver: &GPUDriver{Version: "1.6"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					GPUDriver: &GPUDriver{Version: "1.6"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.AdditionalCapabilities.HibernationEnabled is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AdditionalCapabilities: &AdditionalCapabilities{HibernationEnabled: pointer.Bool(true)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.AdditionalCapabilities.UltraSSDEnabled can be set without HibernationEnabled",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AdditionalCapabilities: &AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "validTest: azuremachine can be hibernated when hibernation is enabled",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					AdditionalCapabilities: &AdditionalCapabilities{HibernationEnabled: pointer.Bool(true)},
+				},
+			},
+			newMachine: &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{PowerStateAnnotation: "hibernated"},
+				},
+				Spec: AzureMachineSpec{
+					AdditionalCapabilities: &AdditionalCapabilities{HibernationEnabled: pointer.Bool(true)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine cannot be hibernated when hibernation is not enabled",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{PowerStateAnnotation: "hibernated"},
+				},
+				Spec: AzureMachineSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine can abandon its Azure resources",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{DeletePolicyAnnotation: "Abandon"},
+				},
+				Spec: AzureMachineSpec{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine delete policy must be supported",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{DeletePolicyAnnotation: "Delete"},
+				},
+				Spec: AzureMachineSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine cannot adopt Azure resources",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ReconcilePolicyAnnotation: "Adopt"},
+				},
+				Spec: AzureMachineSpec{},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.Diagnostics is immutable",
 			oldMachine: &AzureMachine{
@@ -726,6 +977,61 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "validTest: azuremachine.spec.networkInterfaces secondary ipConfigs and enableIPForwarding are mutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "subnet", PrivateIPConfigs: 1}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{{
+						SubnetName:         "subnet",
+						PrivateIPConfigs:   2,
+						IPConfigs:          []NetworkInterfaceIPConfig{{}, {PrivateIPAddress: "10.0.0.5"}},
+						EnableIPForwarding: pointer.Bool(true),
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "validTest: azuremachine.spec.networkInterfaces applicationSecurityGroups are mutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "subnet", PrivateIPConfigs: 1, ApplicationSecurityGroups: []string{"frontend"}}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "subnet", PrivateIPConfigs: 1, ApplicationSecurityGroups: []string{"backend"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.networkInterfaces primary ipConfig is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{{
+						SubnetName:       "subnet",
+						PrivateIPConfigs: 1,
+						IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.4"}},
+					}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					NetworkInterfaces: []NetworkInterface{{
+						SubnetName:       "subnet",
+						PrivateIPConfigs: 2,
+						IPConfigs:        []NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.7"}, {}},
+					}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
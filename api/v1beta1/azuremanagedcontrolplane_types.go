@@ -27,10 +27,10 @@ const (
 	// removing it from the apiserver.
 	ManagedClusterFinalizer = "azuremanagedcontrolplane.infrastructure.cluster.x-k8s.io"
 
-	// PrivateDNSZoneModeSystem represents mode System for azuremanagedcontrolplane.
+	// PrivateDNSZoneModeSystem represents mode System for azuremanagedcontrolplane and azurecluster.
 	PrivateDNSZoneModeSystem string = "System"
 
-	// PrivateDNSZoneModeNone represents mode None for azuremanagedcontrolplane.
+	// PrivateDNSZoneModeNone represents mode None for azuremanagedcontrolplane and azurecluster.
 	PrivateDNSZoneModeNone string = "None"
 )
 
@@ -48,6 +48,16 @@ const (
 	ManagedControlPlaneOutboundTypeUserDefinedRouting ManagedControlPlaneOutboundType = "userDefinedRouting"
 )
 
+// ManagedControlPlaneIdentityType enumerates the values for the managed control plane identity type.
+type ManagedControlPlaneIdentityType string
+
+const (
+	// ManagedControlPlaneIdentityTypeSystemAssigned uses a system-assigned identity for the control plane.
+	ManagedControlPlaneIdentityTypeSystemAssigned ManagedControlPlaneIdentityType = "SystemAssigned"
+	// ManagedControlPlaneIdentityTypeUserAssigned uses a user-assigned identity for the control plane.
+	ManagedControlPlaneIdentityTypeUserAssigned ManagedControlPlaneIdentityType = "UserAssigned"
+)
+
 // AzureManagedControlPlaneSpec defines the desired state of AzureManagedControlPlane.
 type AzureManagedControlPlaneSpec struct {
 	// Version defines the desired Kubernetes version.
@@ -115,14 +125,30 @@ type AzureManagedControlPlaneSpec struct {
 	// +optional
 	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
 
+	// Identity is the managed identity of the control plane of the managed cluster, used to manage the Azure
+	// resources of the cluster. A user-assigned identity is required to use an existing private DNS zone.
+	// Defaults to a system-assigned identity. It can't be changed once the managed cluster is created.
+	// +optional
+	Identity *ManagedControlPlaneIdentity `json:"identity,omitempty"`
+
 	// AadProfile is Azure Active Directory configuration to integrate with AKS for aad authentication.
 	// +optional
 	AADProfile *AADProfile `json:"aadProfile,omitempty"`
 
+	// DisableLocalAccounts - Whether to disable the local accounts of the cluster, so that it can only be accessed
+	// with Azure Active Directory identities. Requires a managed AADProfile. The kubeconfig generated for the cluster
+	// then uses the kubelogin exec plugin.
+	// +optional
+	DisableLocalAccounts *bool `json:"disableLocalAccounts,omitempty"`
+
 	// AddonProfiles are the profiles of managed cluster add-on.
 	// +optional
 	AddonProfiles []AddonProfile `json:"addonProfiles,omitempty"`
 
+	// Addons are the settings of common managed cluster add-ons. An add-on set here can't also be set in AddonProfiles.
+	// +optional
+	Addons *ManagedClusterAddons `json:"addons,omitempty"`
+
 	// SKU is the SKU of the AKS to be provisioned.
 	// +optional
 	SKU *AKSSku `json:"sku,omitempty"`
@@ -131,6 +157,11 @@ type AzureManagedControlPlaneSpec struct {
 	// +optional
 	LoadBalancerProfile *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
 
+	// NATGatewayProfile is the profile of the NAT gateway AKS creates for the cluster. Allowed only when OutboundType
+	// is managedNATGateway.
+	// +optional
+	NATGatewayProfile *NATGatewayProfile `json:"natGatewayProfile,omitempty"`
+
 	// APIServerAccessProfile is the access profile for AKS API server.
 	// +optional
 	APIServerAccessProfile *APIServerAccessProfile `json:"apiServerAccessProfile,omitempty"`
@@ -139,6 +170,37 @@ type AzureManagedControlPlaneSpec struct {
 	// +optional
 	AutoScalerProfile *AutoScalerProfile `json:"autoscalerProfile,omitempty"`
 
+	// SecurityProfile is the security profile of the managed cluster.
+	// +optional
+	SecurityProfile *ManagedControlPlaneSecurityProfile `json:"securityProfile,omitempty"`
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the managed cluster. The OIDC issuer can't be disabled once it
+	// is enabled.
+	// +optional
+	OIDCIssuerProfile *OIDCIssuerProfile `json:"oidcIssuerProfile,omitempty"`
+
+	// AutoUpgradeProfile defines the auto-upgrade configuration of the managed cluster.
+	// +optional
+	AutoUpgradeProfile *ManagedClusterAutoUpgradeProfile `json:"autoUpgradeProfile,omitempty"`
+
+	// MaintenanceConfigurations are the planned maintenance windows of the managed cluster. AKS only runs
+	// maintenance operations such as upgrades during these windows.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	MaintenanceConfigurations []MaintenanceConfiguration `json:"maintenanceConfigurations,omitempty"`
+
+	// PowerState is the desired power state of the managed cluster. Setting it to Stopped stops the cluster and its
+	// node pools, setting it back to Running starts them again. The cluster is neither started nor stopped when it's
+	// not set.
+	// +optional
+	PowerState *PowerState `json:"powerState,omitempty"`
+
+	// HTTPProxyConfig is the HTTP proxy configuration of the managed cluster, for clusters whose nodes can only
+	// reach the internet through a proxy. Only the trusted CA can be changed once the managed cluster is created.
+	// +optional
+	HTTPProxyConfig *HTTPProxyConfig `json:"httpProxyConfig,omitempty"`
+
 	// AzureEnvironment is the name of the AzureCloud to be used.
 	// The default value that would be used by most users is "AzurePublicCloud", other values are:
 	// - ChinaCloud: "AzureChinaCloud"
@@ -148,6 +210,20 @@ type AzureManagedControlPlaneSpec struct {
 	AzureEnvironment string `json:"azureEnvironment,omitempty"`
 }
 
+// ManagedControlPlaneIdentity is the managed identity of the control plane of a managed cluster.
+type ManagedControlPlaneIdentity struct {
+	// Type is the type of the identity.
+	// +kubebuilder:validation:Enum=SystemAssigned;UserAssigned
+	// +kubebuilder:default:=SystemAssigned
+	// +optional
+	Type ManagedControlPlaneIdentityType `json:"type,omitempty"`
+
+	// UserAssignedIdentityResourceID is the resource ID of the user-assigned identity. It is required when Type is
+	// UserAssigned.
+	// +optional
+	UserAssignedIdentityResourceID string `json:"userAssignedIdentityResourceID,omitempty"`
+}
+
 // AADProfile - AAD integration managed by AKS.
 type AADProfile struct {
 	// Managed - Whether to enable managed AAD.
@@ -157,6 +233,10 @@ type AADProfile struct {
 	// AdminGroupObjectIDs - AAD group object IDs that will have admin role of the cluster.
 	// +kubebuilder:validation:Required
 	AdminGroupObjectIDs []string `json:"adminGroupObjectIDs"`
+
+	// EnableAzureRBAC - Whether to enable Azure RBAC for Kubernetes authorization. Defaults to true.
+	// +optional
+	EnableAzureRBAC *bool `json:"enableAzureRBAC,omitempty"`
 }
 
 // AddonProfile represents a managed cluster add-on.
@@ -172,6 +252,101 @@ type AddonProfile struct {
 	Enabled bool `json:"enabled"`
 }
 
+const (
+	// AzurePolicyAddonName is the name of the Azure Policy add-on.
+	AzurePolicyAddonName = "azurepolicy"
+
+	// MonitoringAddonName is the name of the Azure Monitor for containers add-on.
+	MonitoringAddonName = "omsagent"
+
+	// AzureKeyvaultSecretsProviderAddonName is the name of the Azure Key Vault provider for Secrets Store CSI Driver add-on.
+	AzureKeyvaultSecretsProviderAddonName = "azureKeyvaultSecretsProvider"
+
+	// OpenServiceMeshAddonName is the name of the Open Service Mesh add-on.
+	OpenServiceMeshAddonName = "openServiceMesh"
+)
+
+// ManagedClusterAddons are the settings of common managed cluster add-ons.
+type ManagedClusterAddons struct {
+	// AzurePolicy is the Azure Policy add-on.
+	// +optional
+	AzurePolicy *AzurePolicyAddon `json:"azurePolicy,omitempty"`
+
+	// Monitoring is the Azure Monitor for containers add-on.
+	// +optional
+	Monitoring *MonitoringAddon `json:"monitoring,omitempty"`
+
+	// AzureKeyvaultSecretsProvider is the Azure Key Vault provider for Secrets Store CSI Driver add-on.
+	// +optional
+	AzureKeyvaultSecretsProvider *AzureKeyvaultSecretsProviderAddon `json:"azureKeyvaultSecretsProvider,omitempty"`
+
+	// OpenServiceMesh is the Open Service Mesh add-on.
+	// +optional
+	OpenServiceMesh *OpenServiceMeshAddon `json:"openServiceMesh,omitempty"`
+}
+
+// AzurePolicyAddon is the Azure Policy add-on.
+type AzurePolicyAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+}
+
+// MonitoringAddon is the Azure Monitor for containers add-on.
+type MonitoringAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+
+	// LogAnalyticsWorkspaceResourceID - The resource ID of the Log Analytics workspace to send the monitoring data
+	// to. AKS creates a default workspace when it is not set.
+	// +optional
+	LogAnalyticsWorkspaceResourceID string `json:"logAnalyticsWorkspaceResourceID,omitempty"`
+}
+
+// AzureKeyvaultSecretsProviderAddon is the Azure Key Vault provider for Secrets Store CSI Driver add-on.
+type AzureKeyvaultSecretsProviderAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+
+	// EnableSecretRotation - Whether to periodically update the mounted secrets from Key Vault.
+	// +optional
+	EnableSecretRotation *bool `json:"enableSecretRotation,omitempty"`
+
+	// RotationPollInterval - How often the secrets are updated when the secret rotation is enabled, e.g. "2m".
+	// +optional
+	RotationPollInterval *string `json:"rotationPollInterval,omitempty"`
+}
+
+// OpenServiceMeshAddon is the Open Service Mesh add-on.
+type OpenServiceMeshAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+}
+
+// HTTPProxyTrustedCAKey is the key of the CA certificate in the secret referenced by the trusted CA of an
+// HTTPProxyConfig.
+const HTTPProxyTrustedCAKey = "ca.crt"
+
+// HTTPProxyConfig is the HTTP proxy configuration of a managed cluster.
+type HTTPProxyConfig struct {
+	// HTTPProxy is the HTTP proxy server endpoint to use, e.g. "http://proxy.example.com:3128/".
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the HTTPS proxy server endpoint to use, e.g. "https://proxy.example.com:3129/".
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the list of endpoints that should not go through the proxy.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// TrustedCASecretRef is a reference to a secret in the same namespace holding the PEM encoded certificate of an
+	// alternative CA to trust when connecting to the proxy servers, under the ca.crt key. Changes to the secret are
+	// applied to the managed cluster.
+	// +optional
+	TrustedCASecretRef *corev1.LocalObjectReference `json:"trustedCASecretRef,omitempty"`
+}
+
 // AzureManagedControlPlaneSkuTier - Tier of a managed cluster SKU.
 // +kubebuilder:validation:Enum=Free;Paid
 type AzureManagedControlPlaneSkuTier string
@@ -217,6 +392,17 @@ type LoadBalancerProfile struct {
 	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
 }
 
+// NATGatewayProfile - Profile of the managed NAT gateway of the cluster.
+type NATGatewayProfile struct {
+	// ManagedOutboundIPs - Desired number of outbound IPs created and managed by AKS for the NAT gateway. Allowed values must be in the range of 1 to 16 (inclusive). The default value is 1.
+	// +optional
+	ManagedOutboundIPs *int32 `json:"managedOutboundIPs,omitempty"`
+
+	// IdleTimeoutInMinutes - Desired outbound flow idle timeout in minutes. Allowed values must be in the range of 4 to 120 (inclusive). The default value is 4 minutes.
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+}
+
 // APIServerAccessProfile - access profile for AKS API server.
 type APIServerAccessProfile struct {
 	// AuthorizedIPRanges - Authorized IP Ranges to kubernetes API server.
@@ -225,13 +411,196 @@ type APIServerAccessProfile struct {
 	// EnablePrivateCluster - Whether to create the cluster as a private cluster or not.
 	// +optional
 	EnablePrivateCluster *bool `json:"enablePrivateCluster,omitempty"`
-	// PrivateDNSZone - Private dns zone mode for private cluster.
-	// +kubebuilder:validation:Enum=System;None
+	// PrivateDNSZone - Private dns zone mode for private cluster: System, None, or the resource ID of an existing
+	// private DNS zone named privatelink.<location>.azmk8s.io or <subzone>.privatelink.<location>.azmk8s.io. An
+	// existing private DNS zone requires a user-assigned control plane identity.
 	// +optional
 	PrivateDNSZone *string `json:"privateDNSZone,omitempty"`
+	// FQDNSubdomain - The subdomain of the FQDN of a private cluster using an existing private DNS zone. It is used
+	// instead of the name of the cluster as the prefix of the FQDN of its API server.
+	// +optional
+	FQDNSubdomain *string `json:"fqdnSubdomain,omitempty"`
 	// EnablePrivateClusterPublicFQDN - Whether to create additional public FQDN for private cluster or not.
 	// +optional
 	EnablePrivateClusterPublicFQDN *bool `json:"enablePrivateClusterPublicFQDN,omitempty"`
+	// IncludeManagementClusterEgressIPs - Whether to add the egress IP ranges of the management cluster, set with the
+	// --management-cluster-egress-ips flag of the controller manager, to AuthorizedIPRanges so that CAPZ can still
+	// reach the API server. Only applies when AuthorizedIPRanges is set.
+	// +optional
+	IncludeManagementClusterEgressIPs *bool `json:"includeManagementClusterEgressIPs,omitempty"`
+}
+
+// UpgradeChannel is the channel used to automatically upgrade a managed cluster.
+// +kubebuilder:validation:Enum=rapid;stable;patch;node-image;none
+type UpgradeChannel string
+
+const (
+	// UpgradeChannelRapid upgrades the cluster to the latest supported patch release of the latest supported minor version.
+	UpgradeChannelRapid UpgradeChannel = "rapid"
+	// UpgradeChannelStable upgrades the cluster to the latest supported patch release of minor version N-1, where N
+	// is the latest supported minor version.
+	UpgradeChannelStable UpgradeChannel = "stable"
+	// UpgradeChannelPatch upgrades the cluster to the latest supported patch version of its minor version.
+	UpgradeChannelPatch UpgradeChannel = "patch"
+	// UpgradeChannelNodeImage upgrades the node image of the cluster to the latest version available.
+	UpgradeChannelNodeImage UpgradeChannel = "node-image"
+	// UpgradeChannelNone disables auto-upgrades.
+	UpgradeChannelNone UpgradeChannel = "none"
+)
+
+// ManagedClusterAutoUpgradeProfile defines the auto-upgrade configuration of a managed cluster.
+type ManagedClusterAutoUpgradeProfile struct {
+	// UpgradeChannel - The channel used to automatically upgrade the cluster. When it upgrades the Kubernetes
+	// version (rapid, stable or patch), the version reported by AKS is authoritative and the version in the spec is
+	// only used to upgrade the cluster further. The default is none.
+	// +optional
+	UpgradeChannel *UpgradeChannel `json:"upgradeChannel,omitempty"`
+}
+
+// PowerState is the power state of a managed cluster.
+// +kubebuilder:validation:Enum=Running;Stopped
+type PowerState string
+
+const (
+	// PowerStateRunning means the managed cluster is running.
+	PowerStateRunning PowerState = "Running"
+	// PowerStateStopped means the managed cluster and its node pools are stopped.
+	PowerStateStopped PowerState = "Stopped"
+)
+
+// MaintenanceConfiguration is a planned maintenance configuration of a managed cluster.
+type MaintenanceConfiguration struct {
+	// Name is the name of the maintenance configuration. The AKS API version used by CAPZ only supports the
+	// default configuration.
+	// +kubebuilder:validation:Enum=default
+	Name string `json:"name"`
+
+	// TimeInWeek are the weekly windows during which maintenance is allowed. If two entries specify the same day,
+	// maintenance is allowed during the union of their hour slots.
+	// +optional
+	TimeInWeek []TimeInWeek `json:"timeInWeek,omitempty"`
+
+	// NotAllowedTime are the time spans during which maintenance is not allowed, even within a weekly window.
+	// +optional
+	NotAllowedTime []TimeSpan `json:"notAllowedTime,omitempty"`
+}
+
+// WeekDay is a day of the week.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type WeekDay string
+
+const (
+	// Sunday ...
+	Sunday WeekDay = "Sunday"
+	// Monday ...
+	Monday WeekDay = "Monday"
+	// Tuesday ...
+	Tuesday WeekDay = "Tuesday"
+	// Wednesday ...
+	Wednesday WeekDay = "Wednesday"
+	// Thursday ...
+	Thursday WeekDay = "Thursday"
+	// Friday ...
+	Friday WeekDay = "Friday"
+	// Saturday ...
+	Saturday WeekDay = "Saturday"
+)
+
+// TimeInWeek is a weekly maintenance window.
+type TimeInWeek struct {
+	// Day is the day of the week of the window.
+	Day WeekDay `json:"day"`
+
+	// HourSlots are the hours of the window, in UTC. Each hour slot starts at the beginning of the hour and ends at
+	// the beginning of the next one, so 0 is 00:00 - 01:00 UTC and [0, 1] is 00:00 - 02:00 UTC.
+	// +kubebuilder:validation:MinItems=1
+	HourSlots []int32 `json:"hourSlots"`
+}
+
+// TimeSpan is a span of time, for example between 2023-05-25T13:00:00Z and 2023-05-25T14:00:00Z.
+type TimeSpan struct {
+	// Start is the start of the time span.
+	Start metav1.Time `json:"start"`
+
+	// End is the end of the time span.
+	End metav1.Time `json:"end"`
+}
+
+// ManagedControlPlaneSecurityProfile is the security profile of a managed cluster.
+type ManagedControlPlaneSecurityProfile struct {
+	// AzureKeyVaultKms - Azure Key Vault key management service settings, used to encrypt the secrets stored in etcd.
+	// +optional
+	AzureKeyVaultKms *AzureKeyVaultKms `json:"azureKeyVaultKms,omitempty"`
+	// Defender - Microsoft Defender settings.
+	// +optional
+	Defender *DefenderProfile `json:"defender,omitempty"`
+	// WorkloadIdentity - Workload identity settings. Requires the OIDC issuer to be enabled.
+	// +optional
+	WorkloadIdentity *WorkloadIdentityProfile `json:"workloadIdentity,omitempty"`
+}
+
+// WorkloadIdentityProfile is the workload identity settings of a managed cluster.
+type WorkloadIdentityProfile struct {
+	// Enabled - Whether to enable workload identity, which lets pods use Azure AD workload identity federation.
+	Enabled bool `json:"enabled"`
+}
+
+// OIDCIssuerProfile is the OIDC issuer profile of a managed cluster.
+type OIDCIssuerProfile struct {
+	// Enabled - Whether the OIDC issuer is enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// OIDCIssuerProfileStatus is the OIDC issuer profile of a managed cluster as reported by AKS.
+type OIDCIssuerProfileStatus struct {
+	// IssuerURL is the OIDC issuer URL of the managed cluster, used to create federated identity credentials.
+	// +optional
+	IssuerURL *string `json:"issuerURL,omitempty"`
+}
+
+// DefenderProfile is the Microsoft Defender settings of a managed cluster.
+type DefenderProfile struct {
+	// LogAnalyticsWorkspaceResourceID - Resource ID of the Log Analytics workspace associated with Microsoft Defender.
+	// Required when SecurityMonitoring is enabled.
+	// +optional
+	LogAnalyticsWorkspaceResourceID string `json:"logAnalyticsWorkspaceResourceID,omitempty"`
+	// SecurityMonitoring - Microsoft Defender threat detection settings.
+	SecurityMonitoring DefenderSecurityMonitoring `json:"securityMonitoring"`
+}
+
+// DefenderSecurityMonitoring is the Microsoft Defender threat detection settings of a managed cluster.
+type DefenderSecurityMonitoring struct {
+	// Enabled - Whether to enable Microsoft Defender threat detection.
+	Enabled bool `json:"enabled"`
+}
+
+// KeyVaultNetworkAccessTypes is the network access of a key vault.
+// +kubebuilder:validation:Enum=Public;Private
+type KeyVaultNetworkAccessTypes string
+
+const (
+	// KeyVaultNetworkAccessTypesPublic means the key vault allows public access from all networks.
+	KeyVaultNetworkAccessTypesPublic KeyVaultNetworkAccessTypes = "Public"
+	// KeyVaultNetworkAccessTypesPrivate means the key vault disables public access and enables private link.
+	KeyVaultNetworkAccessTypesPrivate KeyVaultNetworkAccessTypes = "Private"
+)
+
+// AzureKeyVaultKms is the Azure Key Vault key management service settings of a managed cluster.
+type AzureKeyVaultKms struct {
+	// Enabled - Whether to enable Azure Key Vault key management service.
+	Enabled bool `json:"enabled"`
+	// KeyID - Identifier of the Azure Key Vault key, including its version. Changing it rotates the key used to
+	// encrypt the secrets. Required when Enabled is true.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+	// KeyVaultNetworkAccess - Network access of the key vault. The default is Public.
+	// +optional
+	KeyVaultNetworkAccess *KeyVaultNetworkAccessTypes `json:"keyVaultNetworkAccess,omitempty"`
+	// KeyVaultResourceID - Resource ID of the key vault. Required when KeyVaultNetworkAccess is Private, and must be
+	// empty when it is Public.
+	// +optional
+	KeyVaultResourceID *string `json:"keyVaultResourceID,omitempty"`
 }
 
 // ManagedControlPlaneVirtualNetwork describes a virtual network required to provision AKS clusters.
@@ -279,6 +648,24 @@ type AzureManagedControlPlaneStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationStatus reports the progress of the ongoing operation on the managed cluster, if any.
+	// +optional
+	OperationStatus *OperationStatus `json:"operationStatus,omitempty"`
+
+	// Version is the Kubernetes version of the managed cluster as reported by AKS. It can be higher than the version
+	// in the spec when the cluster was upgraded by its auto-upgrade channel.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// PowerState is the power state of the managed cluster as reported by AKS. The agent pools of a stopped managed
+	// cluster aren't reconciled until it is started again.
+	// +optional
+	PowerState PowerState `json:"powerState,omitempty"`
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the managed cluster as reported by AKS.
+	// +optional
+	OIDCIssuerProfile *OIDCIssuerProfileStatus `json:"oidcIssuerProfile,omitempty"`
 }
 
 // AutoScalerProfile parameters to be applied to the cluster-autoscaler.
@@ -21,12 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -46,6 +48,7 @@ var (
 	rScaleDownTime             = regexp.MustCompile(`^(\d+)m$`)
 	rScaleDownDelayAfterDelete = regexp.MustCompile(`^(\d+)s$`)
 	rScanInterval              = regexp.MustCompile(`^(\d+)s$`)
+	rFQDNSubdomain             = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9]{0,52}[a-zA-Z0-9])?$`)
 )
 
 // SetupAzureManagedControlPlaneWebhookWithManager sets up and registers the webhook with the manager.
@@ -243,6 +246,25 @@ func (mw *azureManagedControlPlaneWebhook) ValidateUpdate(ctx context.Context, o
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := m.validateSecurityProfileUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := m.validateOIDCIssuerProfileUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	// The identity can't be set on an existing cluster either, as it isn't part of the managed cluster updates.
+	if !reflect.DeepEqual(old.Spec.Identity, m.Spec.Identity) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "Identity"), m.Spec.Identity, "field is immutable"),
+		)
+	}
+
+	if errs := m.validateHTTPProxyConfigUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return m.Validate(mw.Client)
 	}
@@ -263,9 +285,16 @@ func (m *AzureManagedControlPlane) Validate(cli client.Client) error {
 		m.validateDNSServiceIP,
 		m.validateSSHKey,
 		m.validateLoadBalancerProfile,
+		m.validateNATGatewayProfile,
 		m.validateAPIServerAccessProfile,
+		m.validateIdentity,
+		m.validateDisableLocalAccounts,
+		m.validateAddons,
+		m.validateSecurityProfile,
 		m.validateManagedClusterNetwork,
 		m.validateAutoScalerProfile,
+		m.validateMaintenanceConfigurations,
+		m.validateHTTPProxyConfig,
 	}
 
 	var errs []error
@@ -359,6 +388,37 @@ func (m *AzureManagedControlPlane) validateLoadBalancerProfile(_ client.Client)
 	return nil
 }
 
+// validateNATGatewayProfile validates a NATGatewayProfile.
+func (m *AzureManagedControlPlane) validateNATGatewayProfile(_ client.Client) error {
+	if m.Spec.NATGatewayProfile == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "NATGatewayProfile")
+	if m.Spec.OutboundType == nil || *m.Spec.OutboundType != ManagedControlPlaneOutboundTypeManagedNATGateway {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("NATGatewayProfile is allowed only when OutboundType is %s", ManagedControlPlaneOutboundTypeManagedNATGateway)))
+	}
+
+	if m.Spec.NATGatewayProfile.ManagedOutboundIPs != nil {
+		if *m.Spec.NATGatewayProfile.ManagedOutboundIPs < 1 || *m.Spec.NATGatewayProfile.ManagedOutboundIPs > 16 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ManagedOutboundIPs"), *m.Spec.NATGatewayProfile.ManagedOutboundIPs, "value should be in between 1 and 16"))
+		}
+	}
+
+	if m.Spec.NATGatewayProfile.IdleTimeoutInMinutes != nil {
+		if *m.Spec.NATGatewayProfile.IdleTimeoutInMinutes < 4 || *m.Spec.NATGatewayProfile.IdleTimeoutInMinutes > 120 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("IdleTimeoutInMinutes"), *m.Spec.NATGatewayProfile.IdleTimeoutInMinutes, "value should be in between 4 and 120"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
 // validateAPIServerAccessProfile validates an APIServerAccessProfile.
 func (m *AzureManagedControlPlane) validateAPIServerAccessProfile(_ client.Client) error {
 	if m.Spec.APIServerAccessProfile != nil {
@@ -368,6 +428,7 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfile(_ client.Clien
 				allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "APIServerAccessProfile", "AuthorizedIPRanges"), ipRange, "invalid CIDR format"))
 			}
 		}
+		allErrs = append(allErrs, m.validatePrivateDNSZone()...)
 		if len(allErrs) > 0 {
 			return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
 		}
@@ -375,6 +436,221 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfile(_ client.Clien
 	return nil
 }
 
+// validatePrivateDNSZone validates the private DNS zone and the FQDN subdomain of a private cluster.
+func (m *AzureManagedControlPlane) validatePrivateDNSZone() field.ErrorList {
+	var allErrs field.ErrorList
+	profile := m.Spec.APIServerAccessProfile
+	fldPath := field.NewPath("Spec", "APIServerAccessProfile")
+	privateCluster := pointer.BoolDeref(profile.EnablePrivateCluster, false)
+
+	if privateDNSZone := pointer.StringDeref(profile.PrivateDNSZone, ""); privateDNSZone != "" {
+		if !privateCluster {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("PrivateDNSZone"), privateDNSZone, "PrivateDNSZone is only available for private clusters"))
+		}
+		if isPrivateDNSZoneBYO(profile.PrivateDNSZone) {
+			if err := validateResourceIDType(privateDNSZone, "Microsoft.Network/privateDnsZones", fldPath.Child("PrivateDNSZone")); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("PrivateDNSZone"), privateDNSZone,
+					fmt.Sprintf("PrivateDNSZone must be %s, %s or the resource ID of an existing private DNS zone", PrivateDNSZoneModeSystem, PrivateDNSZoneModeNone)))
+			} else {
+				parsed, _ := arm.ParseResourceID(privateDNSZone)
+				zoneName := regexp.MustCompile(`^([a-z0-9-]+\.)?privatelink\.` + regexp.QuoteMeta(strings.ToLower(m.Spec.Location)) + `\.azmk8s\.io$`)
+				if !zoneName.MatchString(strings.ToLower(parsed.Name)) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("PrivateDNSZone"), privateDNSZone,
+						fmt.Sprintf("the name of the private DNS zone must be privatelink.%[1]s.azmk8s.io or <subzone>.privatelink.%[1]s.azmk8s.io", m.Spec.Location)))
+				}
+			}
+			if m.Spec.Identity == nil || m.Spec.Identity.Type != ManagedControlPlaneIdentityTypeUserAssigned {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("PrivateDNSZone"), privateDNSZone, "an existing private DNS zone requires a UserAssigned Identity"))
+			}
+		}
+	}
+
+	if fqdnSubdomain := pointer.StringDeref(profile.FQDNSubdomain, ""); fqdnSubdomain != "" {
+		if !privateCluster || !isPrivateDNSZoneBYO(profile.PrivateDNSZone) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("FQDNSubdomain"), fqdnSubdomain, "FQDNSubdomain is only available for private clusters using an existing private DNS zone"))
+		}
+		if !rFQDNSubdomain.MatchString(fqdnSubdomain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("FQDNSubdomain"), fqdnSubdomain, "FQDNSubdomain must be 1 to 54 alphanumerics or hyphens, and start and end with an alphanumeric"))
+		}
+	}
+
+	return allErrs
+}
+
+// isPrivateDNSZoneBYO returns true if the private DNS zone is the resource ID of an existing private DNS zone rather
+// than one of the System or None modes.
+func isPrivateDNSZoneBYO(privateDNSZone *string) bool {
+	zone := pointer.StringDeref(privateDNSZone, "")
+	return zone != "" && !strings.EqualFold(zone, PrivateDNSZoneModeSystem) && !strings.EqualFold(zone, PrivateDNSZoneModeNone)
+}
+
+// validateIdentity validates the managed identity of the control plane.
+func (m *AzureManagedControlPlane) validateIdentity(_ client.Client) error {
+	identity := m.Spec.Identity
+	if identity == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("Spec", "Identity", "UserAssignedIdentityResourceID")
+	switch {
+	case identity.Type == ManagedControlPlaneIdentityTypeUserAssigned && identity.UserAssignedIdentityResourceID == "":
+		allErrs = append(allErrs, field.Required(fldPath, "UserAssignedIdentityResourceID is required when the identity is UserAssigned"))
+	case identity.Type == ManagedControlPlaneIdentityTypeUserAssigned:
+		if err := validateResourceIDType(identity.UserAssignedIdentityResourceID, "Microsoft.ManagedIdentity/userAssignedIdentities", fldPath); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	case identity.UserAssignedIdentityResourceID != "":
+		allErrs = append(allErrs, field.Invalid(fldPath, identity.UserAssignedIdentityResourceID, "UserAssignedIdentityResourceID must be empty unless the identity is UserAssigned"))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateDisableLocalAccounts validates that local accounts are only disabled with a managed AADProfile.
+func (m *AzureManagedControlPlane) validateDisableLocalAccounts(_ client.Client) error {
+	if pointer.BoolDeref(m.Spec.DisableLocalAccounts, false) && (m.Spec.AADProfile == nil || !m.Spec.AADProfile.Managed) {
+		return field.Invalid(field.NewPath("Spec", "DisableLocalAccounts"), m.Spec.DisableLocalAccounts, "local accounts can only be disabled with a managed AADProfile")
+	}
+	return nil
+}
+
+// validateAddons validates the typed add-ons, which can't also be set in AddonProfiles.
+func (m *AzureManagedControlPlane) validateAddons(_ client.Client) error {
+	addons := m.Spec.Addons
+	if addons == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	set := map[string]bool{
+		AzurePolicyAddonName:                  addons.AzurePolicy != nil,
+		MonitoringAddonName:                   addons.Monitoring != nil,
+		AzureKeyvaultSecretsProviderAddonName: addons.AzureKeyvaultSecretsProvider != nil,
+		OpenServiceMeshAddonName:              addons.OpenServiceMesh != nil,
+	}
+	for i, profile := range m.Spec.AddonProfiles {
+		if set[profile.Name] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "AddonProfiles").Index(i).Child("Name"), profile.Name, "add-on is already set in Spec.Addons"))
+		}
+	}
+
+	if provider := addons.AzureKeyvaultSecretsProvider; provider != nil && provider.RotationPollInterval != nil {
+		if _, err := time.ParseDuration(*provider.RotationPollInterval); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "Addons", "AzureKeyvaultSecretsProvider", "RotationPollInterval"), *provider.RotationPollInterval, "invalid duration"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateSecurityProfile validates the Azure Key Vault key management service, Microsoft Defender and workload
+// identity settings.
+func (m *AzureManagedControlPlane) validateSecurityProfile(_ client.Client) error {
+	if m.Spec.SecurityProfile == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if defender := m.Spec.SecurityProfile.Defender; defender != nil && defender.SecurityMonitoring.Enabled && defender.LogAnalyticsWorkspaceResourceID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("Spec", "SecurityProfile", "Defender", "LogAnalyticsWorkspaceResourceID"), "LogAnalyticsWorkspaceResourceID is required when Microsoft Defender security monitoring is enabled"))
+	}
+
+	oidcIssuerEnabled := m.Spec.OIDCIssuerProfile != nil && pointer.BoolDeref(m.Spec.OIDCIssuerProfile.Enabled, false)
+	if workloadIdentity := m.Spec.SecurityProfile.WorkloadIdentity; workloadIdentity != nil && workloadIdentity.Enabled && !oidcIssuerEnabled {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "SecurityProfile", "WorkloadIdentity", "Enabled"), "workload identity requires the OIDC issuer to be enabled in OIDCIssuerProfile"))
+	}
+
+	if kms := m.Spec.SecurityProfile.AzureKeyVaultKms; kms != nil {
+		kmsPath := field.NewPath("Spec", "SecurityProfile", "AzureKeyVaultKms")
+		if kms.Enabled && kms.KeyID == "" {
+			allErrs = append(allErrs, field.Required(kmsPath.Child("KeyID"), "KeyID is required when Azure Key Vault key management service is enabled"))
+		}
+		private := kms.KeyVaultNetworkAccess != nil && *kms.KeyVaultNetworkAccess == KeyVaultNetworkAccessTypesPrivate
+		resourceID := pointer.StringDeref(kms.KeyVaultResourceID, "")
+		if private && resourceID == "" {
+			allErrs = append(allErrs, field.Required(kmsPath.Child("KeyVaultResourceID"), "KeyVaultResourceID is required when KeyVaultNetworkAccess is Private"))
+		}
+		if !private && resourceID != "" {
+			allErrs = append(allErrs, field.Invalid(kmsPath.Child("KeyVaultResourceID"), resourceID, "KeyVaultResourceID must be empty when KeyVaultNetworkAccess is Public"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateMaintenanceConfigurations validates the hour slots and the time spans of the maintenance configurations.
+func (m *AzureManagedControlPlane) validateMaintenanceConfigurations(_ client.Client) error {
+	var allErrs field.ErrorList
+	for i, config := range m.Spec.MaintenanceConfigurations {
+		configPath := field.NewPath("Spec", "MaintenanceConfigurations").Index(i)
+		if len(config.TimeInWeek) == 0 && len(config.NotAllowedTime) == 0 {
+			allErrs = append(allErrs, field.Required(configPath, "at least one of TimeInWeek or NotAllowedTime is required"))
+		}
+		for j, timeInWeek := range config.TimeInWeek {
+			for k, hourSlot := range timeInWeek.HourSlots {
+				if hourSlot < 0 || hourSlot > 23 {
+					allErrs = append(allErrs, field.Invalid(configPath.Child("TimeInWeek").Index(j).Child("HourSlots").Index(k), hourSlot, "hour slots must be between 0 and 23"))
+				}
+			}
+		}
+		for j, timeSpan := range config.NotAllowedTime {
+			if !timeSpan.Start.Before(&timeSpan.End) {
+				allErrs = append(allErrs, field.Invalid(configPath.Child("NotAllowedTime").Index(j).Child("End"), timeSpan.End, "End must be after Start"))
+			}
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateHTTPProxyConfig validates the proxy endpoints and the trusted CA secret reference of the HTTP proxy
+// configuration.
+func (m *AzureManagedControlPlane) validateHTTPProxyConfig(_ client.Client) error {
+	proxyConfig := m.Spec.HTTPProxyConfig
+	if proxyConfig == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	proxyPath := field.NewPath("Spec", "HTTPProxyConfig")
+	endpoints := []struct {
+		name     string
+		endpoint *string
+	}{
+		{name: "HTTPProxy", endpoint: proxyConfig.HTTPProxy},
+		{name: "HTTPSProxy", endpoint: proxyConfig.HTTPSProxy},
+	}
+	for _, e := range endpoints {
+		if e.endpoint == nil {
+			continue
+		}
+		if u, err := url.Parse(*e.endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(proxyPath.Child(e.name), *e.endpoint, "must be a URL with a scheme and a host"))
+		}
+	}
+	if proxyConfig.TrustedCASecretRef != nil && proxyConfig.TrustedCASecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(proxyPath.Child("TrustedCASecretRef", "Name"), "the secret name cannot be empty"))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
 // validateManagedClusterNetwork validates the Cluster network values.
 func (m *AzureManagedControlPlane) validateManagedClusterNetwork(cli client.Client) error {
 	ctx := context.Background()
@@ -454,6 +730,7 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfileUpdate(old *Azu
 		newAPIServerAccessProfileNormalized = &APIServerAccessProfile{
 			EnablePrivateCluster:           m.Spec.APIServerAccessProfile.EnablePrivateCluster,
 			PrivateDNSZone:                 m.Spec.APIServerAccessProfile.PrivateDNSZone,
+			FQDNSubdomain:                  m.Spec.APIServerAccessProfile.FQDNSubdomain,
 			EnablePrivateClusterPublicFQDN: m.Spec.APIServerAccessProfile.EnablePrivateClusterPublicFQDN,
 		}
 	}
@@ -461,6 +738,7 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfileUpdate(old *Azu
 		oldAPIServerAccessProfileNormalized = &APIServerAccessProfile{
 			EnablePrivateCluster:           old.Spec.APIServerAccessProfile.EnablePrivateCluster,
 			PrivateDNSZone:                 old.Spec.APIServerAccessProfile.PrivateDNSZone,
+			FQDNSubdomain:                  old.Spec.APIServerAccessProfile.FQDNSubdomain,
 			EnablePrivateClusterPublicFQDN: old.Spec.APIServerAccessProfile.EnablePrivateClusterPublicFQDN,
 		}
 	}
@@ -475,6 +753,81 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfileUpdate(old *Azu
 	return allErrs
 }
 
+// validateSecurityProfileUpdate validates update to SecurityProfile. Only the key of the Azure Key Vault key
+// management service can be changed, to rotate it.
+func (m *AzureManagedControlPlane) validateSecurityProfileUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	normalize := func(profile *ManagedControlPlaneSecurityProfile) *AzureKeyVaultKms {
+		if profile == nil || profile.AzureKeyVaultKms == nil {
+			return &AzureKeyVaultKms{}
+		}
+		return &AzureKeyVaultKms{
+			Enabled:               profile.AzureKeyVaultKms.Enabled,
+			KeyVaultNetworkAccess: profile.AzureKeyVaultKms.KeyVaultNetworkAccess,
+			KeyVaultResourceID:    profile.AzureKeyVaultKms.KeyVaultResourceID,
+		}
+	}
+
+	if !reflect.DeepEqual(normalize(m.Spec.SecurityProfile), normalize(old.Spec.SecurityProfile)) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "SecurityProfile", "AzureKeyVaultKms"),
+				m.Spec.SecurityProfile, "fields (except for KeyID) are immutable"),
+		)
+	}
+
+	return allErrs
+}
+
+// validateOIDCIssuerProfileUpdate validates update to OIDCIssuerProfile. AKS doesn't allow disabling the OIDC issuer
+// once it is enabled.
+func (m *AzureManagedControlPlane) validateOIDCIssuerProfileUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	enabled := func(profile *OIDCIssuerProfile) bool {
+		return profile != nil && pointer.BoolDeref(profile.Enabled, false)
+	}
+	if enabled(old.Spec.OIDCIssuerProfile) && !enabled(m.Spec.OIDCIssuerProfile) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("Spec", "OIDCIssuerProfile", "Enabled"), "the OIDC issuer can't be disabled once it is enabled"),
+		)
+	}
+
+	return allErrs
+}
+
+// validateHTTPProxyConfigUpdate validates update to HTTPProxyConfig. AKS doesn't allow enabling or disabling the HTTP
+// proxy of an existing managed cluster, only the trusted CA can be changed.
+func (m *AzureManagedControlPlane) validateHTTPProxyConfigUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	normalize := func(proxyConfig *HTTPProxyConfig) *HTTPProxyConfig {
+		if proxyConfig == nil {
+			return nil
+		}
+		return &HTTPProxyConfig{
+			HTTPProxy:  proxyConfig.HTTPProxy,
+			HTTPSProxy: proxyConfig.HTTPSProxy,
+			NoProxy:    proxyConfig.NoProxy,
+		}
+	}
+
+	if !reflect.DeepEqual(normalize(m.Spec.HTTPProxyConfig), normalize(old.Spec.HTTPProxyConfig)) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "HTTPProxyConfig"),
+				m.Spec.HTTPProxyConfig, "fields (except for TrustedCASecretRef) are immutable"),
+		)
+	} else if old.Spec.HTTPProxyConfig != nil && old.Spec.HTTPProxyConfig.TrustedCASecretRef != nil &&
+		m.Spec.HTTPProxyConfig.TrustedCASecretRef == nil {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "HTTPProxyConfig", "TrustedCASecretRef"),
+				m.Spec.HTTPProxyConfig.TrustedCASecretRef, "cannot be removed once set"),
+		)
+	}
+
+	return allErrs
+}
+
 // validateVirtualNetworkUpdate validates update to VirtualNetwork.
 func (m *AzureManagedControlPlane) validateVirtualNetworkUpdate(old *AzureManagedControlPlane) field.ErrorList {
 	var allErrs field.ErrorList
@@ -19,8 +19,10 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
@@ -232,6 +234,59 @@ func TestValidatingWebhook(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "Valid NATGatewayProfile",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: (*ManagedControlPlaneOutboundType)(pointer.String(string(ManagedControlPlaneOutboundTypeManagedNATGateway))),
+					NATGatewayProfile: &NATGatewayProfile{
+						ManagedOutboundIPs:   pointer.Int32(2),
+						IdleTimeoutInMinutes: pointer.Int32(10),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "NATGatewayProfile requires the managedNATGateway OutboundType",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: (*ManagedControlPlaneOutboundType)(pointer.String(string(ManagedControlPlaneOutboundTypeUserAssignedNATGateway))),
+					NATGatewayProfile: &NATGatewayProfile{
+						ManagedOutboundIPs: pointer.Int32(2),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalid NATGatewayProfile.ManagedOutboundIPs",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: (*ManagedControlPlaneOutboundType)(pointer.String(string(ManagedControlPlaneOutboundTypeManagedNATGateway))),
+					NATGatewayProfile: &NATGatewayProfile{
+						ManagedOutboundIPs: pointer.Int32(17),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Invalid NATGatewayProfile.IdleTimeoutInMinutes",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: (*ManagedControlPlaneOutboundType)(pointer.String(string(ManagedControlPlaneOutboundTypeManagedNATGateway))),
+					NATGatewayProfile: &NATGatewayProfile{
+						IdleTimeoutInMinutes: pointer.Int32(2),
+					},
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "Invalid CIDR for AuthorizedIPRanges",
 			amcp: AzureManagedControlPlane{
@@ -548,6 +603,372 @@ func TestValidatingWebhook(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "Testing valid DisableLocalAccounts with managed AADProfile",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					AADProfile: &AADProfile{
+						Managed:             true,
+						AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"},
+						EnableAzureRBAC:     pointer.Bool(true),
+					},
+					DisableLocalAccounts: pointer.Bool(true),
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing invalid DisableLocalAccounts without AADProfile",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:              "v1.24.1",
+					DisableLocalAccounts: pointer.Bool(true),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid SecurityProfile.AzureKeyVaultKms",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled:               true,
+							KeyID:                 "https://my-vault.vault.azure.net/keys/my-key/1",
+							KeyVaultNetworkAccess: (*KeyVaultNetworkAccessTypes)(pointer.String(string(KeyVaultNetworkAccessTypesPrivate))),
+							KeyVaultResourceID:    pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"),
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing SecurityProfile.AzureKeyVaultKms enabled without KeyID",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing SecurityProfile.AzureKeyVaultKms private without KeyVaultResourceID",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled:               true,
+							KeyID:                 "https://my-vault.vault.azure.net/keys/my-key/1",
+							KeyVaultNetworkAccess: (*KeyVaultNetworkAccessTypes)(pointer.String(string(KeyVaultNetworkAccessTypesPrivate))),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing SecurityProfile.Defender enabled without LogAnalyticsWorkspaceResourceID",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						Defender: &DefenderProfile{
+							SecurityMonitoring: DefenderSecurityMonitoring{Enabled: true},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid SecurityProfile.WorkloadIdentity with the OIDC issuer enabled",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:           "v1.24.1",
+					OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)},
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						WorkloadIdentity: &WorkloadIdentityProfile{Enabled: true},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing SecurityProfile.WorkloadIdentity enabled without the OIDC issuer",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						WorkloadIdentity: &WorkloadIdentityProfile{Enabled: true},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid Addons",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					AddonProfiles: []AddonProfile{
+						{Name: "ingressApplicationGateway", Enabled: true},
+					},
+					Addons: &ManagedClusterAddons{
+						AzurePolicy: &AzurePolicyAddon{Enabled: true},
+						AzureKeyvaultSecretsProvider: &AzureKeyvaultSecretsProviderAddon{
+							Enabled:              true,
+							EnableSecretRotation: pointer.Bool(true),
+							RotationPollInterval: pointer.String("2m"),
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing Addons also set in AddonProfiles",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					AddonProfiles: []AddonProfile{
+						{Name: AzurePolicyAddonName, Enabled: false},
+					},
+					Addons: &ManagedClusterAddons{
+						AzurePolicy: &AzurePolicyAddon{Enabled: true},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing invalid Addons.AzureKeyvaultSecretsProvider.RotationPollInterval",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					Addons: &ManagedClusterAddons{
+						AzureKeyvaultSecretsProvider: &AzureKeyvaultSecretsProviderAddon{
+							Enabled:              true,
+							RotationPollInterval: pointer.String("2 minutes"),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid MaintenanceConfigurations",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							TimeInWeek: []TimeInWeek{
+								{Day: Saturday, HourSlots: []int32{0, 1, 2}},
+								{Day: Sunday, HourSlots: []int32{23}},
+							},
+							NotAllowedTime: []TimeSpan{
+								{
+									Start: metav1.Date(2023, time.December, 24, 0, 0, 0, 0, time.UTC),
+									End:   metav1.Date(2023, time.December, 27, 0, 0, 0, 0, time.UTC),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing MaintenanceConfigurations with an invalid hour slot",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							TimeInWeek: []TimeInWeek{
+								{Day: Saturday, HourSlots: []int32{24}},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing MaintenanceConfigurations with a not allowed time ending before it starts",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							NotAllowedTime: []TimeSpan{
+								{
+									Start: metav1.Date(2023, time.December, 27, 0, 0, 0, 0, time.UTC),
+									End:   metav1.Date(2023, time.December, 24, 0, 0, 0, 0, time.UTC),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing MaintenanceConfigurations without any window",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{Name: "default"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing APIServerAccessProfile with an existing private DNS zone and FQDNSubdomain",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.24.1",
+					Location: "westus2",
+					Identity: &ManagedControlPlaneIdentity{
+						Type:                           ManagedControlPlaneIdentityTypeUserAssigned,
+						UserAssignedIdentityResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity",
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						PrivateDNSZone:       pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/my-subzone.privatelink.westus2.azmk8s.io"),
+						FQDNSubdomain:        pointer.String("my-cluster"),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing APIServerAccessProfile with an existing private DNS zone without a UserAssigned Identity",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.24.1",
+					Location: "westus2",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						PrivateDNSZone:       pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.westus2.azmk8s.io"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing APIServerAccessProfile with an existing private DNS zone in another location",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.24.1",
+					Location: "westus2",
+					Identity: &ManagedControlPlaneIdentity{
+						Type:                           ManagedControlPlaneIdentityTypeUserAssigned,
+						UserAssignedIdentityResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity",
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						PrivateDNSZone:       pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.eastus.azmk8s.io"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing APIServerAccessProfile with a private DNS zone for a public cluster",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.24.1",
+					Location: "westus2",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(false),
+						PrivateDNSZone:       pointer.String("System"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing APIServerAccessProfile with FQDNSubdomain and a System private DNS zone",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.24.1",
+					Location: "westus2",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						PrivateDNSZone:       pointer.String("System"),
+						FQDNSubdomain:        pointer.String("my-cluster"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing UserAssigned Identity without UserAssignedIdentityResourceID",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					Identity: &ManagedControlPlaneIdentity{
+						Type: ManagedControlPlaneIdentityTypeUserAssigned,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid HTTPProxyConfig",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy:          pointer.String("http://proxy.example.com:3128/"),
+						HTTPSProxy:         pointer.String("https://proxy.example.com:3129/"),
+						NoProxy:            []string{"localhost", "127.0.0.1"},
+						TrustedCASecretRef: &corev1.LocalObjectReference{Name: "proxy-ca"},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing HTTPProxyConfig with an invalid HTTPS proxy endpoint",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPSProxy: pointer.String("proxy.example.com:3129"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing HTTPProxyConfig with an empty trusted CA secret name",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.24.1",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy:          pointer.String("http://proxy.example.com:3128/"),
+						TrustedCASecretRef: &corev1.LocalObjectReference{},
+					},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -754,6 +1175,232 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			amcp:    createAzureManagedControlPlane("192.168.0.0", "1.999.9", generateSSHPublicKey(true)),
 			wantErr: true,
 		},
+		{
+			name: "AzureManagedControlPlane SecurityProfile.AzureKeyVaultKms.KeyID is mutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled: true,
+							KeyID:   "https://my-vault.vault.azure.net/keys/my-key/1",
+						},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled: true,
+							KeyID:   "https://my-vault.vault.azure.net/keys/my-key/2",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane SecurityProfile.AzureKeyVaultKms.KeyVaultNetworkAccess is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled: true,
+							KeyID:   "https://my-vault.vault.azure.net/keys/my-key/1",
+						},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					SecurityProfile: &ManagedControlPlaneSecurityProfile{
+						AzureKeyVaultKms: &AzureKeyVaultKms{
+							Enabled:               true,
+							KeyID:                 "https://my-vault.vault.azure.net/keys/my-key/1",
+							KeyVaultNetworkAccess: (*KeyVaultNetworkAccessTypes)(pointer.String(string(KeyVaultNetworkAccessTypesPrivate))),
+							KeyVaultResourceID:    pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane OIDCIssuerProfile can be enabled",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP:      pointer.String("192.168.0.0"),
+					Version:           "v1.18.0",
+					OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane OIDCIssuerProfile can't be disabled",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP:      pointer.String("192.168.0.0"),
+					Version:           "v1.18.0",
+					OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane Identity is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					Identity: &ManagedControlPlaneIdentity{
+						Type:                           ManagedControlPlaneIdentityTypeUserAssigned,
+						UserAssignedIdentityResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane APIServerAccessProfile.FQDNSubdomain is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						FQDNSubdomain:        pointer.String("my-cluster"),
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						FQDNSubdomain:        pointer.String("my-other-cluster"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane HTTPProxyConfig.TrustedCASecretRef is mutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy:          pointer.String("http://proxy.example.com:3128/"),
+						TrustedCASecretRef: &corev1.LocalObjectReference{Name: "proxy-ca"},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy:          pointer.String("http://proxy.example.com:3128/"),
+						TrustedCASecretRef: &corev1.LocalObjectReference{Name: "proxy-ca-rotated"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane HTTPProxyConfig.TrustedCASecretRef can't be removed",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy:          pointer.String("http://proxy.example.com:3128/"),
+						TrustedCASecretRef: &corev1.LocalObjectReference{Name: "proxy-ca"},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy: pointer.String("http://proxy.example.com:3128/"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane HTTPProxyConfig.NoProxy is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy: pointer.String("http://proxy.example.com:3128/"),
+						NoProxy:   []string{"localhost"},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy: pointer.String("http://proxy.example.com:3128/"),
+						NoProxy:   []string{"localhost", "10.0.0.0/8"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane HTTPProxyConfig can't be added to an existing cluster",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.String("192.168.0.0"),
+					Version:      "v1.18.0",
+					HTTPProxyConfig: &HTTPProxyConfig{
+						HTTPProxy: pointer.String("http://proxy.example.com:3128/"),
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "AzureManagedControlPlane SubscriptionID is immutable",
 			oldAMCP: &AzureManagedControlPlane{
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -34,6 +35,9 @@ const (
 
 	// DefaultOSType represents the default operating system for azmachinepool.
 	DefaultOSType string = LinuxOS
+
+	// ScaleSetPrioritySpot represents the priority of a node pool made of Spot VMs.
+	ScaleSetPrioritySpot = "Spot"
 )
 
 // NodePoolMode enumerates the values for agent pool mode.
@@ -445,6 +449,18 @@ type AzureManagedMachinePoolSpec struct {
 	// +optional
 	ScaleSetPriority *string `json:"scaleSetPriority,omitempty"`
 
+	// ScaleSetEvictionPolicy specifies what happens to the nodes of a Spot node pool when they are evicted. Default to
+	// Delete. Possible values include: 'Delete', 'Deallocate'. Allowed only when ScaleSetPriority is Spot.
+	// +kubebuilder:validation:Enum=Delete;Deallocate
+	// +optional
+	ScaleSetEvictionPolicy *SpotEvictionPolicy `json:"scaleSetEvictionPolicy,omitempty"`
+
+	// SpotMaxPrice is the maximum price per hour the user is willing to pay for the nodes of a Spot node pool, in US
+	// dollars. Default to -1, which means nodes are only evicted for capacity reasons and are paid at most the
+	// on-demand price. Allowed only when ScaleSetPriority is Spot.
+	// +optional
+	SpotMaxPrice *resource.Quantity `json:"spotMaxPrice,omitempty"`
+
 	// KubeletConfig specifies the kubelet configurations for nodes.
 	// +optional
 	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
@@ -516,6 +532,11 @@ type AzureManagedMachinePoolStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationStatus reports the progress of the ongoing operation on the nodes of the agent pool, if any, e.g. a
+	// node image upgrade.
+	// +optional
+	OperationStatus *OperationStatus `json:"operationStatus,omitempty"`
 }
 
 // +kubebuilder:object:root=true
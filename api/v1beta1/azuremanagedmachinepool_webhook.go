@@ -26,6 +26,7 @@ import (
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -107,6 +108,7 @@ func (mw *azureManagedMachinePoolWebhook) ValidateCreate(ctx context.Context, ob
 		m.validateKubeletConfig,
 		m.validateLinuxOSConfig,
 		m.validateSubnetName,
+		m.validateSpot,
 	}
 
 	var errs []error
@@ -202,6 +204,13 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 		}
 	}
 
+	if m.Spec.Mode == string(NodePoolModeSystem) && old.Spec.Mode != string(NodePoolModeSystem) &&
+		pointer.StringDeref(m.Spec.ScaleSetPriority, "") == ScaleSetPrioritySpot {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("Spec", "Mode"),
+			"Cannot change the mode of a Spot node pool to System"))
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "MaxPods"),
 		old.Spec.MaxPods,
@@ -223,6 +232,20 @@ func (mw *azureManagedMachinePoolWebhook) ValidateUpdate(ctx context.Context, ol
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ScaleSetEvictionPolicy"),
+		old.Spec.ScaleSetEvictionPolicy,
+		m.Spec.ScaleSetEvictionPolicy); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "SpotMaxPrice"),
+		old.Spec.SpotMaxPrice,
+		m.Spec.SpotMaxPrice); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "EnableUltraSSD"),
 		old.Spec.EnableUltraSSD,
@@ -412,6 +435,41 @@ func (m *AzureManagedMachinePool) validateSubnetName() error {
 	return nil
 }
 
+// validateSpot validates the Spot settings of the node pool. System node pools can't be made of Spot VMs, and the
+// eviction policy and the maximum price are only allowed for Spot node pools.
+func (m *AzureManagedMachinePool) validateSpot() error {
+	var errs []error
+	if pointer.StringDeref(m.Spec.ScaleSetPriority, "") != ScaleSetPrioritySpot {
+		if m.Spec.ScaleSetEvictionPolicy != nil {
+			errs = append(errs, field.Forbidden(
+				field.NewPath("Spec", "ScaleSetEvictionPolicy"),
+				"ScaleSetEvictionPolicy is allowed only when ScaleSetPriority is Spot"))
+		}
+		if m.Spec.SpotMaxPrice != nil {
+			errs = append(errs, field.Forbidden(
+				field.NewPath("Spec", "SpotMaxPrice"),
+				"SpotMaxPrice is allowed only when ScaleSetPriority is Spot"))
+		}
+		return kerrors.NewAggregate(errs)
+	}
+
+	if m.Spec.Mode == string(NodePoolModeSystem) {
+		errs = append(errs, field.Forbidden(
+			field.NewPath("Spec", "ScaleSetPriority"),
+			"System node pools can't use Spot VMs, ScaleSetPriority Spot is allowed only for User node pools"))
+	}
+
+	// -1 caps the price at the on-demand price, any other value must be a positive price.
+	if m.Spec.SpotMaxPrice != nil && m.Spec.SpotMaxPrice.Sign() <= 0 && m.Spec.SpotMaxPrice.Cmp(resource.MustParse("-1")) != 0 {
+		errs = append(errs, field.Invalid(
+			field.NewPath("Spec", "SpotMaxPrice"),
+			m.Spec.SpotMaxPrice.String(),
+			"SpotMaxPrice must be -1 or greater than 0"))
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
 // validateKubeletConfig enforces the AKS API configuration for KubeletConfig.
 // See:  https://learn.microsoft.com/en-us/azure/aks/custom-node-configuration.
 func (m *AzureManagedMachinePool) validateKubeletConfig() error {
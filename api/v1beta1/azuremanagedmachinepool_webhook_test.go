@@ -20,8 +20,9 @@ import (
 	"context"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -289,7 +290,7 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 					SKU:          "StandardD2S_V3",
 					OSDiskSizeGB: pointer.Int32(512),
 					MaxPods:      pointer.Int32(24),
-					OsDiskType:   pointer.String(string(containerservice.OSDiskTypeEphemeral)),
+					OsDiskType:   pointer.String(string(containerservice.Ephemeral)),
 				},
 			},
 			old: &AzureManagedMachinePool{
@@ -298,7 +299,7 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 					SKU:          "StandardD2S_V3",
 					OSDiskSizeGB: pointer.Int32(512),
 					MaxPods:      pointer.Int32(24),
-					OsDiskType:   pointer.String(string(containerservice.OSDiskTypeManaged)),
+					OsDiskType:   pointer.String(string(containerservice.Managed)),
 				},
 			},
 			wantErr: true,
@@ -392,7 +393,7 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 					SKU:          "StandardD2S_V3",
 					OSDiskSizeGB: pointer.Int32(512),
 					MaxPods:      pointer.Int32(30),
-					OsDiskType:   pointer.String(string(containerservice.OSDiskTypeManaged)),
+					OsDiskType:   pointer.String(string(containerservice.Managed)),
 				},
 			},
 			old: &AzureManagedMachinePool{
@@ -401,7 +402,7 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 					SKU:          "StandardD2S_V3",
 					OSDiskSizeGB: pointer.Int32(512),
 					MaxPods:      pointer.Int32(30),
-					OsDiskType:   pointer.String(string(containerservice.OSDiskTypeManaged)),
+					OsDiskType:   pointer.String(string(containerservice.Managed)),
 				},
 			},
 			wantErr: false,
@@ -585,6 +586,58 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Can't change the mode of a Spot node pool to System",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "System",
+					ScaleSetPriority: pointer.String("Spot"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "User",
+					ScaleSetPriority: pointer.String("Spot"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Can't update SpotMaxPrice",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "User",
+					ScaleSetPriority: pointer.String("Spot"),
+					SpotMaxPrice:     resource.NewMilliQuantity(100, resource.DecimalSI),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "User",
+					ScaleSetPriority: pointer.String("Spot"),
+					SpotMaxPrice:     resource.NewMilliQuantity(50, resource.DecimalSI),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Can't update ScaleSetEvictionPolicy",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                   "User",
+					ScaleSetPriority:       pointer.String("Spot"),
+					ScaleSetEvictionPolicy: (*SpotEvictionPolicy)(pointer.String(string(SpotEvictionPolicyDeallocate))),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                   "User",
+					ScaleSetPriority:       pointer.String("Spot"),
+					ScaleSetEvictionPolicy: (*SpotEvictionPolicy)(pointer.String(string(SpotEvictionPolicyDelete))),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	var client client.Client
 	for _, tc := range tests {
@@ -624,7 +677,7 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 			ammp: &AzureManagedMachinePool{
 				Spec: AzureManagedMachinePoolSpec{
 					MaxPods:    pointer.Int32(249),
-					OsDiskType: pointer.String(string(containerservice.OSDiskTypeManaged)),
+					OsDiskType: pointer.String(string(containerservice.Managed)),
 				},
 			},
 			wantErr: false,
@@ -1140,6 +1193,65 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 			wantErr:  true,
 			errorLen: 1,
 		},
+		{
+			name: "valid Spot node pool",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                   "User",
+					ScaleSetPriority:       pointer.String("Spot"),
+					ScaleSetEvictionPolicy: (*SpotEvictionPolicy)(pointer.String(string(SpotEvictionPolicyDelete))),
+					SpotMaxPrice:           resource.NewMilliQuantity(50, resource.DecimalSI),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid Spot node pool capped at the on-demand price",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "User",
+					ScaleSetPriority: pointer.String("Spot"),
+					SpotMaxPrice:     resource.NewQuantity(-1, resource.DecimalSI),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Spot System node pool not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "System",
+					ScaleSetPriority: pointer.String("Spot"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "invalid SpotMaxPrice",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "User",
+					ScaleSetPriority: pointer.String("Spot"),
+					SpotMaxPrice:     resource.NewQuantity(0, resource.DecimalSI),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "ScaleSetEvictionPolicy and SpotMaxPrice not allowed for Regular node pools",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:                   "User",
+					ScaleSetPriority:       pointer.String("Regular"),
+					ScaleSetEvictionPolicy: (*SpotEvictionPolicy)(pointer.String(string(SpotEvictionPolicyDeallocate))),
+					SpotMaxPrice:           resource.NewQuantity(-1, resource.DecimalSI),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
 	}
 
 	var client client.Client
@@ -1274,7 +1386,7 @@ func getKnownValidAzureManagedMachinePool() *AzureManagedMachinePool {
 	return &AzureManagedMachinePool{
 		Spec: AzureManagedMachinePoolSpec{
 			MaxPods:    pointer.Int32(30),
-			OsDiskType: pointer.String(string(containerservice.OSDiskTypeEphemeral)),
+			OsDiskType: pointer.String(string(containerservice.Ephemeral)),
 		},
 	}
 }
@@ -24,6 +24,9 @@ const (
 	NetworkInfrastructureReadyCondition clusterv1.ConditionType = "NetworkInfrastructureReady"
 	// NamespaceNotAllowedByIdentity used to indicate cluster in a namespace not allowed by identity.
 	NamespaceNotAllowedByIdentity = "NamespaceNotAllowedByIdentity"
+	// IdentityAllowedCondition reports whether the namespace of an AzureCluster or an AzureManagedControlPlane is
+	// allowed to use the AzureClusterIdentity it references.
+	IdentityAllowedCondition clusterv1.ConditionType = "IdentityAllowed"
 )
 
 // AzureMachine Conditions and Reasons.
@@ -52,6 +55,12 @@ const (
 	BootstrapInProgressReason = "BootstrapInProgress"
 	// BootstrapFailedReason is used to indicate the bootstrap process ran into an error.
 	BootstrapFailedReason = "BootstrapFailed"
+	// GPUDriverSucceededCondition reports the result of the installation of the NVIDIA GPU driver extension on the machine.
+	GPUDriverSucceededCondition clusterv1.ConditionType = "GPUDriverSucceeded"
+	// GPUDriverInProgressReason is used to indicate the NVIDIA GPU driver extension is still being installed.
+	GPUDriverInProgressReason = "GPUDriverInProgress"
+	// GPUDriverFailedReason is used to indicate the installation of the NVIDIA GPU driver extension failed.
+	GPUDriverFailedReason = "GPUDriverFailed"
 )
 
 // AzureMachinePool Conditions and Reasons.
@@ -66,6 +75,9 @@ const (
 	ScaleSetDeletingReason = "ScaleSetDeleting"
 	// ScaleSetProvisionFailedReason used for failures during scale set provisioning.
 	ScaleSetProvisionFailedReason = "ScaleSetProvisionFailed"
+	// EncryptionAtHostNotRegisteredReason used when encryption at host is enabled but the EncryptionAtHost feature
+	// is not registered for the subscription.
+	EncryptionAtHostNotRegisteredReason = "EncryptionAtHostNotRegistered"
 
 	// ScaleSetDesiredReplicasCondition reports on the scaling state of the machine pool.
 	ScaleSetDesiredReplicasCondition clusterv1.ConditionType = "ScaleSetDesiredReplicas"
@@ -80,12 +92,27 @@ const (
 	ScaleSetModelOutOfDateReason = "ScaleSetModelOutOfDate"
 )
 
+// AzureMachinePoolMachine Conditions and Reasons.
+const (
+	// VMHealthyCondition reports on the provisioning and power state of the scale set instance of an AzureMachinePoolMachine.
+	VMHealthyCondition clusterv1.ConditionType = "VMHealthy"
+	// VMNotRunningReason used when the scale set instance is provisioned but not running, e.g. when it is stopped or deallocated.
+	VMNotRunningReason = "VMNotRunning"
+
+	// LatestModelAppliedCondition reports whether the scale set instance runs the latest model of the scale set.
+	LatestModelAppliedCondition clusterv1.ConditionType = "LatestModelApplied"
+	// ModelOutOfDateReason used when the scale set instance doesn't run the latest model of the scale set.
+	ModelOutOfDateReason = "ModelOutOfDate"
+)
+
 // AzureManagedCluster Conditions and Reasons.
 const (
 	// ManagedClusterRunningCondition means the AKS cluster exists and is in a running state.
 	ManagedClusterRunningCondition clusterv1.ConditionType = "ManagedClusterRunning"
 	// AgentPoolsReadyCondition means the AKS agent pools exist and are ready to be used.
 	AgentPoolsReadyCondition clusterv1.ConditionType = "AgentPoolsReady"
+	// MaintenanceConfigurationsReadyCondition means the AKS maintenance configurations exist and are up to date.
+	MaintenanceConfigurationsReadyCondition clusterv1.ConditionType = "MaintenanceConfigurationsReady"
 	// AzureResourceAvailableCondition means the AKS cluster is healthy according to Azure's Resource Health API.
 	AzureResourceAvailableCondition clusterv1.ConditionType = "AzureResourceAvailable"
 )
@@ -118,10 +145,22 @@ const (
 	PrivateDNSRecordReadyCondition clusterv1.ConditionType = "PrivateDNSRecordReady"
 	// BastionHostReadyCondition means the bastion host exists and is ready to be used.
 	BastionHostReadyCondition clusterv1.ConditionType = "BastionHostReady"
+	// AzureFirewallReadyCondition means the Azure Firewall and the node default routes through it exist and are ready to be used.
+	AzureFirewallReadyCondition clusterv1.ConditionType = "AzureFirewallReady"
+	// VirtualNetworkGatewayReadyCondition means the virtual network gateway exists and is ready to be used.
+	VirtualNetworkGatewayReadyCondition clusterv1.ConditionType = "VirtualNetworkGatewayReady"
+	// ApplicationGatewayReadyCondition means the application gateway exists and is ready to be used.
+	ApplicationGatewayReadyCondition clusterv1.ConditionType = "ApplicationGatewayReady"
+	// PrivateLinkServiceReadyCondition means the private link service of the API server exists and is ready to be used.
+	PrivateLinkServiceReadyCondition clusterv1.ConditionType = "PrivateLinkServiceReady"
 	// InboundNATRulesReadyCondition means the inbound NAT rules exist and are ready to be used.
 	InboundNATRulesReadyCondition clusterv1.ConditionType = "InboundNATRulesReady"
 	// AvailabilitySetReadyCondition means the availability set exists and is ready to be used.
 	AvailabilitySetReadyCondition clusterv1.ConditionType = "AvailabilitySetReady"
+	// ProximityPlacementGroupsReadyCondition means the proximity placement groups exist and are ready to be used.
+	ProximityPlacementGroupsReadyCondition clusterv1.ConditionType = "ProximityPlacementGroupsReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
 	// RoleAssignmentReadyCondition means the role assignment exists and is ready to be used.
 	RoleAssignmentReadyCondition clusterv1.ConditionType = "RoleAssignmentReady"
 	// DisksReadyCondition means the disks exist and are ready to be used.
@@ -130,6 +169,10 @@ const (
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
 	// PrivateEndpointsReadyCondition means the private endpoints exist and are ready to be used.
 	PrivateEndpointsReadyCondition clusterv1.ConditionType = "PrivateEndpointsReady"
+	// IPAddressesClaimedCondition means the IP addresses requested from IPAM providers have been allocated.
+	IPAddressesClaimedCondition clusterv1.ConditionType = "IPAddressesClaimed"
+	// WaitingForIPAddressReason means an IPAddressClaim has not yet been fulfilled by its IPAM provider.
+	WaitingForIPAddressReason = "WaitingForIPAddress"
 
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
@@ -143,6 +186,8 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// DriftDetectedReason means the resource no longer matches its spec and was not updated to match it again.
+	DriftDetectedReason = "DriftDetected"
 )
 
 const (
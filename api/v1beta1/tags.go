@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // Tags defines a map of tags.
@@ -41,6 +42,17 @@ func (t Tags) HasOwned(cluster string) bool {
 	return ok && ResourceLifecycle(value) == ResourceLifecycleOwned
 }
 
+// HasAnyCluster returns true if the tags contains a tag that marks the resource as owned by or shared with any cluster
+// from the perspective of this management tooling.
+func (t Tags) HasAnyCluster() bool {
+	for key := range t {
+		if strings.HasPrefix(key, NameAzureProviderOwned) {
+			return true
+		}
+	}
+	return false
+}
+
 // HasAzureCloudProviderOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of the in-tree cloud provider.
 func (t Tags) HasAzureCloudProviderOwned(cluster string) bool {
 	value, ok := t[ClusterAzureCloudProviderTagKey(cluster)]
@@ -128,6 +140,10 @@ const (
 	// BastionRole describes the value for the bastion role.
 	BastionRole = Bastion
 
+	// ApplicationGatewayRole describes the value for the application gateway role, which AGIC can use to find the
+	// application gateway of a cluster.
+	ApplicationGatewayRole = ApplicationGateway
+
 	// CommonRole describes the value for the common role.
 	CommonRole = "common"
 
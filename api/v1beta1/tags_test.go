@@ -88,3 +88,40 @@ func TestTags_Merge(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_HasAnyCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		tags     Tags
+		expected bool
+	}{
+		{
+			name:     "nil tags",
+			tags:     nil,
+			expected: false,
+		},
+		{
+			name:     "no cluster tag",
+			tags:     Tags{"foo": "bar", NameAzureClusterAPIRole: CommonRole},
+			expected: false,
+		},
+		{
+			name:     "owned by a cluster",
+			tags:     Tags{ClusterTagKey("test-cluster"): string(ResourceLifecycleOwned)},
+			expected: true,
+		},
+		{
+			name:     "shared with a cluster",
+			tags:     Tags{ClusterTagKey("other-cluster"): string(ResourceLifecycleShared)},
+			expected: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g.Expect(tc.tags.HasAnyCluster()).To(Equal(tc.expected))
+		})
+	}
+}
@@ -18,7 +18,10 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/net"
 )
 
@@ -29,9 +32,18 @@ const (
 	Node string = "node"
 	// Bastion subnet label.
 	Bastion string = "bastion"
+	// Firewall subnet label.
+	Firewall string = "firewall"
+	// Gateway subnet label.
+	Gateway string = "gateway"
+	// ApplicationGateway subnet label.
+	ApplicationGateway string = "applicationgateway"
 )
 
-// Futures is a slice of Future.
+// Futures is a slice of Future, keyed by the service name and the name of the resource.
+// +listType=map
+// +listMapKey=serviceName
+// +listMapKey=name
 type Futures []Future
 
 const (
@@ -41,6 +53,8 @@ const (
 	PutFuture string = "PUT"
 	// DeleteFuture is a future that was derived from a DELETE request.
 	DeleteFuture string = "DELETE"
+	// PostFuture is a future that was derived from a POST request, such as starting or stopping a resource.
+	PostFuture string = "POST"
 )
 
 // Future contains the data needed for an Azure long-running operation to continue across reconcile loops.
@@ -62,6 +76,28 @@ type Future struct {
 
 	// Data is the base64 url encoded json Azure AutoRest Future.
 	Data string `json:"data"`
+
+	// StartTime is the time the long-running operation was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// OperationStatus describes the progress of an ongoing operation on an Azure resource.
+type OperationStatus struct {
+	// ProvisioningState is the state of the Azure resource during the operation, e.g. Creating, Updating or Deleting.
+	ProvisioningState ProvisioningState `json:"provisioningState"`
+
+	// Message describes the progress of the operation, e.g. "Updating instances, 3/5 up to date".
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PercentComplete is the progress of the operation, from 0 to 100, when it can be measured.
+	// +optional
+	PercentComplete *int32 `json:"percentComplete,omitempty"`
+
+	// StartTime is the time CAPZ first observed the operation.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
 }
 
 // NetworkSpec specifies what the Azure networking resources should look like.
@@ -87,6 +123,21 @@ type NetworkSpec struct {
 	// +optional
 	ControlPlaneOutboundLB *LoadBalancerSpec `json:"controlPlaneOutboundLB,omitempty"`
 
+	// AzureFirewall is the configuration for an Azure Firewall through which node egress traffic is routed.
+	// Setting it implies the userDefinedRouting outbound type.
+	// +optional
+	AzureFirewall *AzureFirewall `json:"azureFirewall,omitempty"`
+
+	// VirtualNetworkGateway is the configuration for a VPN or ExpressRoute gateway connecting the cluster virtual
+	// network to other networks.
+	// +optional
+	VirtualNetworkGateway *VirtualNetworkGateway `json:"virtualNetworkGateway,omitempty"`
+
+	// APIServerPrivateLinkService is the configuration for an Azure Private Link Service attached to the internal API server
+	// load balancer, through which clients in other virtual networks or tenants can reach the API server using private endpoints.
+	// +optional
+	APIServerPrivateLinkService *PrivateLinkService `json:"apiServerPrivateLinkService,omitempty"`
+
 	NetworkClassSpec `json:",inline"`
 }
 
@@ -126,6 +177,11 @@ type VnetPeeringClassSpec struct {
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
 
+	// SubscriptionID is the subscription of the remote virtual network.
+	// Defaults to the cluster's subscription. The cluster identity must be able to manage peerings in both subscriptions.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
 	// ForwardPeeringProperties specifies VnetPeeringProperties for peering from the cluster's virtual network to the
 	// remote virtual network.
 	// +optional
@@ -180,6 +236,11 @@ type Subnets []SubnetSpec
 // +listMapKey=service
 type ServiceEndpoints []ServiceEndpointSpec
 
+// SubnetDelegations is a slice of SubnetDelegation.
+// +listType=map
+// +listMapKey=serviceName
+type SubnetDelegations []SubnetDelegation
+
 // PrivateEndpoints is a slice of PrivateEndpointSpec.
 // +listType=map
 // +listMapKey=name
@@ -203,6 +264,43 @@ type RouteTable struct {
 	// +optional
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+	// Routes are the user-defined routes of the route table. When set, the routes of the route table are
+	// reconciled to match this list, and routes that are not listed are removed.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// RouteNextHopType is the type of Azure hop a route sends traffic to.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway sends traffic to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway RouteNextHopType = "VirtualNetworkGateway"
+	// RouteNextHopTypeVnetLocal keeps traffic within the virtual network.
+	RouteNextHopTypeVnetLocal RouteNextHopType = "VnetLocal"
+	// RouteNextHopTypeInternet sends traffic to the Internet.
+	RouteNextHopTypeInternet RouteNextHopType = "Internet"
+	// RouteNextHopTypeVirtualAppliance sends traffic to a network virtual appliance, e.g. a firewall.
+	RouteNextHopTypeVirtualAppliance RouteNextHopType = "VirtualAppliance"
+	// RouteNextHopTypeNone drops traffic.
+	RouteNextHopTypeNone RouteNextHopType = "None"
+)
+
+// Route defines a user-defined route of a route table.
+type Route struct {
+	// Name is the name of the route.
+	Name string `json:"name"`
+	// AddressPrefix is the destination CIDR the route applies to, e.g. 0.0.0.0/0.
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of Azure hop the traffic is sent to.
+	// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+	NextHopType RouteNextHopType `json:"nextHopType"`
+	// NextHopIPAddress is the IP address traffic is forwarded to. It is required when NextHopType is
+	// VirtualAppliance and forbidden otherwise.
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
 }
 
 // NatGateway defines an Azure NAT gateway.
@@ -221,6 +319,22 @@ type NatGateway struct {
 // NatGatewayClassSpec defines a NAT gateway class specification.
 type NatGatewayClassSpec struct {
 	Name string `json:"name"`
+	// IdleTimeoutInMinutes specifies the timeout for idle outbound connections, between 4 and 120 minutes.
+	// Azure uses 4 minutes when it is not set.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// Zones is the availability zone of the NAT gateway. A NAT gateway can be placed in at most one zone.
+	// When set, the public IP created for the NAT gateway is placed in the same zone.
+	// Zones cannot be changed after the NAT gateway is created.
+	// +kubebuilder:validation:MaxItems=1
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+	// PublicIPPrefixes is a list of resource IDs of existing public IP prefixes to use for outbound connectivity,
+	// in addition to the public IP created for the NAT gateway.
+	// +optional
+	PublicIPPrefixes []string `json:"publicIPPrefixes,omitempty"`
 }
 
 // SecurityGroupProtocol defines the protocol type for a security group rule.
@@ -272,9 +386,25 @@ type SecurityRule struct {
 	// Source specifies the CIDR or source IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used. If this is an ingress rule, specifies where network traffic originates from.
 	// +optional
 	Source *string `json:"source,omitempty"`
+	// Sources specifies a list of CIDRs or source IP ranges, for example one per IP family in a dual-stack cluster.
+	// Cannot be combined with Source.
+	// +optional
+	Sources []string `json:"sources,omitempty"`
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// Destinations specifies a list of destination CIDRs or IP ranges, for example one per IP family in a dual-stack cluster.
+	// Cannot be combined with Destination.
+	// +optional
+	Destinations []string `json:"destinations,omitempty"`
+	// SourceApplicationSecurityGroups specifies the names of the application security groups the rule applies to
+	// as source. CAPZ creates them in the cluster resource group. Cannot be combined with Source or Sources.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups specifies the names of the application security groups the rule applies to
+	// as destination. CAPZ creates them in the cluster resource group. Cannot be combined with Destination or Destinations.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
 }
 
 // SecurityRules is a slice of Azure security rules for security groups.
@@ -302,6 +432,30 @@ type LoadBalancerSpec struct {
 	LoadBalancerClassSpec `json:",inline"`
 }
 
+// PublicFrontendIPs returns the frontend IPs of the load balancer that are exposed through a public IP.
+func (lb *LoadBalancerSpec) PublicFrontendIPs() []FrontendIP {
+	var frontendIPs []FrontendIP
+	for _, frontendIP := range lb.FrontendIPs {
+		if frontendIP.PublicIP != nil {
+			frontendIPs = append(frontendIPs, frontendIP)
+		}
+	}
+	return frontendIPs
+}
+
+// PrivateFrontendIPs returns the frontend IPs of the load balancer that are only exposed through a private IP.
+// A public API server load balancer serves its private frontend IPs through a separate internal load balancer, as an
+// Azure load balancer can't have both public and private frontends.
+func (lb *LoadBalancerSpec) PrivateFrontendIPs() []FrontendIP {
+	var frontendIPs []FrontendIP
+	for _, frontendIP := range lb.FrontendIPs {
+		if frontendIP.PublicIP == nil {
+			frontendIPs = append(frontendIPs, frontendIP)
+		}
+	}
+	return frontendIPs
+}
+
 // SKU defines an Azure load balancer SKU.
 type SKU string
 
@@ -320,6 +474,28 @@ const (
 	Public = LBType("Public")
 )
 
+// OutboundType defines how outbound traffic egresses the cluster virtual network.
+type OutboundType string
+
+const (
+	// LoadBalancerOutboundType uses a node outbound load balancer or NAT gateways for outbound traffic.
+	LoadBalancerOutboundType = OutboundType("loadBalancer")
+	// UserDefinedRoutingOutboundType relies on user defined routes on the node subnets for outbound traffic.
+	UserDefinedRoutingOutboundType = OutboundType("userDefinedRouting")
+)
+
+// NodeOutboundLBMode defines how the node outbound load balancer provides outbound connectivity to the nodes.
+type NodeOutboundLBMode string
+
+const (
+	// NodeOutboundLBModeDedicated uses a load balancer dedicated to node outbound traffic.
+	NodeOutboundLBModeDedicated = NodeOutboundLBMode("Dedicated")
+	// NodeOutboundLBModeShared reuses the frontend IPs of the public API server load balancer for node outbound traffic.
+	NodeOutboundLBModeShared = NodeOutboundLBMode("Shared")
+	// NodeOutboundLBModeDisabled doesn't provide outbound connectivity to the nodes through a load balancer.
+	NodeOutboundLBModeDisabled = NodeOutboundLBMode("Disabled")
+)
+
 // FrontendIP defines a load balancer frontend IP configuration.
 type FrontendIP struct {
 	// +kubebuilder:validation:MinLength=1
@@ -327,6 +503,11 @@ type FrontendIP struct {
 	// +optional
 	PublicIP *PublicIPSpec `json:"publicIP,omitempty"`
 
+	// AddressFromPool specifies an IPAM pool from which to claim the private IP of an internal load balancer
+	// frontend. The claimed address is written to privateIP once the IPAM provider allocates it.
+	// +optional
+	AddressFromPool *corev1.TypedLocalObjectReference `json:"addressFromPool,omitempty"`
+
 	FrontendIPClass `json:",inline"`
 }
 
@@ -347,10 +528,41 @@ type IPTag struct {
 	Tag string `json:"tag"`
 }
 
+// NodePublicIP defines the DNS label and IP tags of the public IP of a machine.
+type NodePublicIP struct {
+	// DNSLabelPrefix gives the public IP the DNS label "<dnsLabelPrefix>-<name>", where name is the name of the machine,
+	// or of the scale set for machine pools. The label can be changed after an AzureMachine is created.
+	// +kubebuilder:validation:Pattern=`^[a-z][a-z0-9-]{0,30}$`
+	// +optional
+	DNSLabelPrefix string `json:"dnsLabelPrefix,omitempty"`
+
+	// IPTags are the IP tags of the public IP, e.g. a RoutingPreference or FirstPartyUsage tag.
+	// They can't be changed after the public IP is created.
+	// +optional
+	IPTags []IPTag `json:"ipTags,omitempty"`
+}
+
 // VMState describes the state of an Azure virtual machine.
 // Deprecated: use ProvisioningState.
 type VMState string
 
+// AvailabilitySetDomainCounts defines the number of fault and update domains of an availability set.
+type AvailabilitySetDomainCounts struct {
+	// FaultDomainCount is the number of fault domains of the availability set.
+	// It can't exceed the maximum number of fault domains of the location.
+	// Defaults to the maximum number of fault domains of the location.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FaultDomainCount *int32 `json:"faultDomainCount,omitempty"`
+
+	// UpdateDomainCount is the number of update domains of the availability set.
+	// Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	UpdateDomainCount *int32 `json:"updateDomainCount,omitempty"`
+}
+
 // ProvisioningState describes the provisioning state of an Azure resource.
 type ProvisioningState string
 
@@ -372,6 +584,18 @@ const (
 	// Deleted represents a deleted VM
 	// NOTE: This state is specific to capz, and does not have corresponding mapping in Azure API (https://docs.microsoft.com/en-us/azure/virtual-machines/states-billing#provisioning-states)
 	Deleted ProvisioningState = "Deleted"
+	// Starting represents a VM which is being started to reach the power state requested with the PowerStateAnnotation.
+	// NOTE: This state is specific to capz, and does not have corresponding mapping in Azure API.
+	Starting ProvisioningState = "Starting"
+	// Stopping represents a VM which is being deallocated to reach the power state requested with the PowerStateAnnotation.
+	// NOTE: This state is specific to capz, and does not have corresponding mapping in Azure API.
+	Stopping ProvisioningState = "Stopping"
+	// Stopped represents a VM which was deallocated because the PowerStateAnnotation requested it to be stopped.
+	// NOTE: This state is specific to capz, and does not have corresponding mapping in Azure API.
+	Stopped ProvisioningState = "Stopped"
+	// Hibernated represents a VM which was hibernated because the PowerStateAnnotation requested it to be hibernated.
+	// NOTE: This state is specific to capz, and does not have corresponding mapping in Azure API.
+	Hibernated ProvisioningState = "Hibernated"
 )
 
 // Image defines information about the image to use for VM creation.
@@ -404,11 +628,14 @@ type AzureComputeGalleryImage struct {
 	// Name is the name of the image
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
-	// Version specifies the version of the marketplace image. The allowed formats
-	// are Major.Minor.Build or 'latest'. Major, Minor, and Build are decimal numbers.
-	// Specify 'latest' to use the latest version of an image available at deploy time.
-	// Even if you use 'latest', the VM image will not automatically update after deploy
-	// time even if a new version becomes available.
+	// Version specifies the version of the compute gallery image. The allowed formats
+	// are Major.Minor.Build, 'latest' or a semver range such as '>=1.2.0 <2.0.0' or '1.x'.
+	// Major, Minor, and Build are decimal numbers.
+	// For images in a private compute gallery, 'latest' and semver ranges are resolved to the
+	// highest matching image version at reconcile time and the resolved image is recorded in
+	// the status. The resolved version is kept for as long as it matches the specified version,
+	// so the VM image will not automatically update when a new version becomes available.
+	// Semver ranges are not supported for community gallery images.
 	// +kubebuilder:validation:MinLength=1
 	Version string `json:"version"`
 	// SubscriptionID is the identifier of the subscription that contains the private compute gallery.
@@ -420,6 +647,12 @@ type AzureComputeGalleryImage struct {
 	// Plan contains plan information.
 	// +optional
 	Plan *ImagePlan `json:"plan,omitempty"`
+	// DirectShared indicates that the image is in a gallery directly shared with the subscription or tenant of the cluster.
+	// When set, Gallery is the unique name of the shared gallery and SubscriptionID and ResourceGroup must not be set.
+	// When neither DirectShared nor SubscriptionID and ResourceGroup are set, the image is a community gallery image and
+	// Gallery is the public name of the community gallery.
+	// +optional
+	DirectShared bool `json:"directShared,omitempty"`
 }
 
 // ImagePlan contains plan information for marketplace images.
@@ -535,7 +768,7 @@ const (
 )
 
 // IdentityType represents different types of identities.
-// +kubebuilder:validation:Enum=ServicePrincipal;UserAssignedMSI;ManualServicePrincipal;ServicePrincipalCertificate
+// +kubebuilder:validation:Enum=ServicePrincipal;UserAssignedMSI;ManualServicePrincipal;ServicePrincipalCertificate;WorkloadIdentity
 type IdentityType string
 
 const (
@@ -550,6 +783,10 @@ const (
 
 	// ServicePrincipalCertificate represents a service principal using a certificate as secret.
 	ServicePrincipalCertificate IdentityType = "ServicePrincipalCertificate"
+
+	// WorkloadIdentity represents a workload identity using the projected service account token of the
+	// controller pod as a federated credential.
+	WorkloadIdentity IdentityType = "WorkloadIdentity"
 )
 
 // OSDisk defines the operating system disk for a VM.
@@ -600,6 +837,10 @@ type VMExtension struct {
 	Name string `json:"name"`
 	// Publisher is the name of the extension handler publisher.
 	Publisher string `json:"publisher"`
+	// Type is the type of the extension, e.g. "CustomScript".
+	// If left unspecified, it defaults to the name of the extension.
+	// +optional
+	Type string `json:"type,omitempty"`
 	// Version specifies the version of the script handler.
 	Version string `json:"version"`
 	// Settings is a JSON formatted public settings for the extension.
@@ -608,14 +849,70 @@ type VMExtension struct {
 	// ProtectedSettings is a JSON formatted protected settings for the extension.
 	// +optional
 	ProtectedSettings Tags `json:"protectedSettings,omitempty"`
+	// ProtectedSettingsSecretRef is a reference to a secret in the same namespace whose data is added to the protected
+	// settings of the extension. Values from the secret take precedence over ProtectedSettings.
+	// +optional
+	ProtectedSettingsSecretRef *corev1.LocalObjectReference `json:"protectedSettingsSecretRef,omitempty"`
+	// ProvisionAfterBootstrap specifies whether the extension is provisioned only once the CAPZ bootstrapping
+	// extension succeeded, i.e. once the Kubernetes node is bootstrapped. By default, the extension is provisioned
+	// independently of the bootstrapping extension. It has no effect where the bootstrapping extension isn't available.
+	// +optional
+	ProvisionAfterBootstrap bool `json:"provisionAfterBootstrap,omitempty"`
+}
+
+// GPUDriver specifies the NVIDIA GPU driver VM extension to install on N-series VMs.
+type GPUDriver struct {
+	// Version is the version of the NVIDIA GPU driver extension handler.
+	// If left unspecified, it defaults to the latest known version for the OS type of the VM.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
+// StorageAccountTypePremiumV2LRS is the storage account type of Premium SSD v2 managed disks.
+// Premium SSD v2 disks can only be used as data disks of VMs placed in an availability zone.
+const StorageAccountTypePremiumV2LRS = "PremiumV2_LRS"
+
 // ManagedDiskParameters defines the parameters of a managed disk.
 type ManagedDiskParameters struct {
+	// StorageAccountType is the storage account type of the managed disk.
+	// UltraSSD_LRS and PremiumV2_LRS can only be used with data disks.
 	// +optional
 	StorageAccountType string `json:"storageAccountType,omitempty"`
 	// +optional
 	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityProfile specifies the security profile for the managed disk.
+	// It can only be set on the OS disk of a confidential VM.
+	// +optional
+	SecurityProfile *VMDiskSecurityProfile `json:"securityProfile,omitempty"`
+}
+
+// SecurityEncryptionType represents the Encryption Type when the virtual machine is a Confidential VM.
+type SecurityEncryptionType string
+
+const (
+	// SecurityEncryptionTypeVMGuestStateOnly disables OS disk confidential encryption and only encrypts the VMGuestState blob.
+	SecurityEncryptionTypeVMGuestStateOnly SecurityEncryptionType = "VMGuestStateOnly"
+	// SecurityEncryptionTypeDiskWithVMGuestState enables OS disk confidential encryption with a platform-managed key
+	// (PMK) or a customer-managed key (CMK), along with the VMGuestState blob.
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
+// VMDiskSecurityProfile specifies the security profile settings for the managed disk.
+// It can be set only for Confidential VMs.
+type VMDiskSecurityProfile struct {
+	// DiskEncryptionSet specifies the customer managed disk encryption set resource id for the
+	// managed disk that is used for Customer Managed Key encrypted ConfidentialVM OS Disk and VMGuest blob.
+	// +optional
+	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityEncryptionType specifies the encryption type of the managed disk.
+	// It is set to DiskWithVMGuestState to encrypt the managed disk along with the VMGuestState blob,
+	// and to VMGuestStateOnly to encrypt the VMGuestState blob only.
+	// When set to VMGuestStateOnly, VTpmEnabled should be set to true.
+	// When set to DiskWithVMGuestState, EncryptionAtHost should be disabled, and SecureBootEnabled and VTpmEnabled should be set to true.
+	// It can be set only for Confidential VMs.
+	// +kubebuilder:validation:Enum=VMGuestStateOnly;DiskWithVMGuestState
+	// +optional
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
 }
 
 // DiskEncryptionSetParameters defines disk encryption options.
@@ -625,12 +922,28 @@ type DiskEncryptionSetParameters struct {
 	ID string `json:"id,omitempty"`
 }
 
+// DiffDiskPlacement specifies the placement of an ephemeral OS disk.
+type DiffDiskPlacement string
+
+const (
+	// DiffDiskPlacementCacheDisk places the ephemeral OS disk on the cache disk of the VM.
+	DiffDiskPlacementCacheDisk DiffDiskPlacement = "CacheDisk"
+	// DiffDiskPlacementResourceDisk places the ephemeral OS disk on the resource (temp) disk of the VM.
+	DiffDiskPlacementResourceDisk DiffDiskPlacement = "ResourceDisk"
+)
+
 // DiffDiskSettings describe ephemeral disk settings for the os disk.
 type DiffDiskSettings struct {
 	// Option enables ephemeral OS when set to "Local"
 	// See https://docs.microsoft.com/en-us/azure/virtual-machines/ephemeral-os-disks for full details
 	// +kubebuilder:validation:Enum=Local
 	Option string `json:"option"`
+	// Placement specifies the disk of the VM size that hosts the ephemeral OS disk.
+	// When not set, Azure uses the cache disk if the VM size has one, otherwise the resource disk.
+	// The VM size must have a cache or resource disk large enough to hold the OS disk.
+	// +kubebuilder:validation:Enum=CacheDisk;ResourceDisk
+	// +optional
+	Placement *DiffDiskPlacement `json:"placement,omitempty"`
 }
 
 // SubnetRole defines the unique role of a subnet.
@@ -645,6 +958,15 @@ const (
 
 	// SubnetBastion defines a Bastion subnet role.
 	SubnetBastion = SubnetRole(Bastion)
+
+	// SubnetFirewall defines an Azure Firewall subnet role.
+	SubnetFirewall = SubnetRole(Firewall)
+
+	// SubnetGateway defines a virtual network gateway subnet role.
+	SubnetGateway = SubnetRole(Gateway)
+
+	// SubnetApplicationGateway defines an Azure Application Gateway subnet role.
+	SubnetApplicationGateway = SubnetRole(ApplicationGateway)
 )
 
 // SubnetSpec configures an Azure subnet.
@@ -676,6 +998,16 @@ type ServiceEndpointSpec struct {
 	Locations []string `json:"locations"`
 }
 
+// SubnetDelegation delegates a subnet to an Azure service.
+type SubnetDelegation struct {
+	// Name is the name of the delegation. Defaults to the service name with "/" replaced by ".".
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ServiceName is the name of the service the subnet is delegated to, e.g. Microsoft.ContainerInstance/containerGroups.
+	ServiceName string `json:"serviceName"`
+}
+
 // PrivateLinkServiceConnection defines the specification for a private link service connection associated with a private endpoint.
 type PrivateLinkServiceConnection struct {
 	// Name specifies the name of the private link service.
@@ -716,6 +1048,16 @@ type PrivateEndpointSpec struct {
 	// Defaults to false.
 	// +optional
 	ManualApproval bool `json:"manualApproval,omitempty"`
+	// PrivateDNSZoneIDs specifies the resource IDs of the private DNS zones in which DNS records for the private endpoint are registered.
+	// When set, a private DNS zone group named after the private endpoint is created.
+	// +optional
+	PrivateDNSZoneIDs []string `json:"privateDNSZoneIDs,omitempty"`
+}
+
+// ProximityPlacementGroup defines an Azure proximity placement group.
+type ProximityPlacementGroup struct {
+	// Name is the name of the proximity placement group.
+	Name string `json:"name"`
 }
 
 // NetworkInterface defines a network interface.
@@ -724,16 +1066,52 @@ type NetworkInterface struct {
 	SubnetName string `json:"subnetName,omitempty"`
 
 	// PrivateIPConfigs specifies the number of private IP addresses to attach to the interface.
-	// Defaults to 1 if not specified.
+	// Set to the number of IPConfigs if they are specified, defaults to 1 otherwise.
 	// +optional
 	PrivateIPConfigs int `json:"privateIPConfigs,omitempty"`
 
+	// PrivateIPAddress is the static private IP address of the primary IP configuration of the interface,
+	// e.g. to give control plane machines deterministic addresses for firewall rules.
+	// It must be within the address range of the subnet of the interface.
+	// +optional
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
+
+	// IPConfigs specifies the private IP configurations of the interface, e.g. for CNIs which pre-allocate
+	// secondary IP addresses to nodes. The first configuration is the primary one.
+	// IP configurations other than the primary one can be added or removed after the machine is created.
+	// +optional
+	IPConfigs []NetworkInterfaceIPConfig `json:"ipConfigs,omitempty"`
+
+	// EnableIPForwarding enables or disables IP forwarding on the interface.
+	// Defaults to the enableIPForwarding field of the AzureMachine spec.
+	// +optional
+	EnableIPForwarding *bool `json:"enableIPForwarding,omitempty"`
+
 	// AcceleratedNetworking enables or disables Azure accelerated networking. If omitted, it will be set based on
 	// whether the requested VMSize supports accelerated networking.
 	// If AcceleratedNetworking is set to true with a VMSize that does not support it, Azure will return an error.
 	// +kubebuilder:validation:nullable
 	// +optional
 	AcceleratedNetworking *bool `json:"acceleratedNetworking,omitempty"`
+
+	// AddressesFromPools specifies IPAM pools from which to claim the private IP addresses of the interface.
+	// The n-th pool is used for the n-th private IP configuration; configurations without a pool are allocated
+	// dynamically by Azure. Pools must be served by a Cluster API IPAM provider.
+	// +optional
+	AddressesFromPools []corev1.TypedLocalObjectReference `json:"addressesFromPools,omitempty"`
+
+	// ApplicationSecurityGroups specifies the names of the application security groups of the cluster resource group
+	// the IP configurations of the interface belong to, e.g. to be targeted by the security rules of the cluster.
+	// +optional
+	ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
+}
+
+// NetworkInterfaceIPConfig defines a private IP configuration of a network interface.
+type NetworkInterfaceIPConfig struct {
+	// PrivateIPAddress is the static private IP address of the configuration.
+	// If omitted, the address is allocated dynamically by Azure, or claimed from the IPAM pool of the configuration.
+	// +optional
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
 }
 
 // GetControlPlaneSubnet returns the cluster control plane subnet.
@@ -779,6 +1157,20 @@ func (s SubnetSpec) IsIPv6Enabled() bool {
 	return false
 }
 
+// SecurityTypes represents the SecurityType of the virtual machine.
+type SecurityTypes string
+
+const (
+	// SecurityTypesTrustedLaunch enables secure boot and vTPM on the virtual machine through its UefiSettings.
+	// It requires a VM size supporting generation 2 images.
+	SecurityTypesTrustedLaunch SecurityTypes = "TrustedLaunch"
+
+	// SecurityTypesConfidentialVM runs the virtual machine in a hardware-based trusted execution environment.
+	// It requires a confidential compute VM size, such as the DCasv5 or ECasv5 series, and a security profile
+	// on the OS disk.
+	SecurityTypesConfidentialVM SecurityTypes = "ConfidentialVM"
+)
+
 // SecurityProfile specifies the Security profile settings for a
 // virtual machine or virtual machine scale set.
 type SecurityProfile struct {
@@ -787,6 +1179,29 @@ type SecurityProfile struct {
 	// set. Default is disabled.
 	// +optional
 	EncryptionAtHost *bool `json:"encryptionAtHost,omitempty"`
+	// SecurityType specifies the SecurityType of the virtual machine. It has to be set to any specified value to
+	// enable UefiSettings. The default behavior is: UefiSettings will not be enabled unless this property is set.
+	// +kubebuilder:validation:Enum=TrustedLaunch;ConfidentialVM
+	// +optional
+	SecurityType SecurityTypes `json:"securityType,omitempty"`
+	// UefiSettings specifies the security settings like secure boot and vTPM used while creating the virtual machine.
+	// +optional
+	UefiSettings *UefiSettings `json:"uefiSettings,omitempty"`
+}
+
+// UefiSettings specifies the security settings like secure boot and vTPM used while creating the
+// virtual machine.
+type UefiSettings struct {
+	// SecureBootEnabled specifies whether secure boot should be enabled on the virtual machine.
+	// Secure Boot verifies the digital signature of all boot components and halts the boot process if
+	// signature verification fails.
+	// +optional
+	SecureBootEnabled *bool `json:"secureBootEnabled,omitempty"`
+	// VTpmEnabled specifies whether vTPM should be enabled on the virtual machine.
+	// When true it enables the virtualized trusted platform module measurements to create a known good boot integrity policy baseline.
+	// The integrity policy baseline is used for comparison with measurements from subsequent VM boots to determine if anything has changed.
+	// +optional
+	VTpmEnabled *bool `json:"vTpmEnabled,omitempty"`
 }
 
 // AddressRecord specifies a DNS record mapping a hostname to an IPV4 or IPv6 address.
@@ -801,6 +1216,61 @@ type CloudProviderConfigOverrides struct {
 	RateLimits []RateLimitSpec `json:"rateLimits,omitempty"`
 	// +optional
 	BackOffs BackOffConfig `json:"backOffs,omitempty"`
+	// Credentials configures how the cloud provider authenticates to Azure on nodes which don't use a VM identity.
+	// By default the client secret of the cluster identity is written to the cloud provider config.
+	// +optional
+	Credentials *CloudProviderCredentials `json:"credentials,omitempty"`
+	// ExtraConfig is a set of additional cloud provider config values, e.g. excludeMasterFromStandardLB or
+	// putVMSSVMBatchSize, which are merged into the generated cloud provider config. They take precedence over the
+	// values inferred by CAPZ, except for the credentials which can't be set here.
+	// +optional
+	ExtraConfig map[string]apiextensionsv1.JSON `json:"extraConfig,omitempty"`
+	// Mode is the cloud provider the config is generated for. Defaults to InTree.
+	// +optional
+	Mode CloudProviderConfigMode `json:"mode,omitempty"`
+}
+
+// CloudProviderConfigMode is the cloud provider the cloud provider config is generated for.
+// +kubebuilder:validation:Enum=InTree;External
+type CloudProviderConfigMode string
+
+const (
+	// InTreeCloudProviderConfigMode generates the config for the in-tree cloud provider of the kubelet and the
+	// kube-controller-manager.
+	InTreeCloudProviderConfigMode CloudProviderConfigMode = "InTree"
+	// ExternalCloudProviderConfigMode generates the config for the external cloud-provider-azure. The credentials are
+	// only written to the config of the cloud-controller-manager, and the v2 back-off mode is used.
+	ExternalCloudProviderConfigMode CloudProviderConfigMode = "External"
+)
+
+// CloudProviderCredentialsType is the type of credentials used by the cloud provider.
+// +kubebuilder:validation:Enum=ClientSecret;ClientCertificate;ManagedIdentity
+type CloudProviderCredentialsType string
+
+const (
+	// ClientSecretCloudProviderCredentials writes the client secret of the cluster identity to the cloud provider config.
+	ClientSecretCloudProviderCredentials CloudProviderCredentialsType = "ClientSecret"
+	// ClientCertificateCloudProviderCredentials makes the cloud provider read the certificate of the cluster identity
+	// service principal from a file on the nodes instead of a client secret.
+	ClientCertificateCloudProviderCredentials CloudProviderCredentialsType = "ClientCertificate"
+	// ManagedIdentityCloudProviderCredentials makes the cloud provider use a managed identity assigned to the nodes.
+	ManagedIdentityCloudProviderCredentials CloudProviderCredentialsType = "ManagedIdentity"
+)
+
+// CloudProviderCredentials defines the credentials used by the cloud provider, so that no plaintext client secret
+// has to be written to the nodes.
+type CloudProviderCredentials struct {
+	// Type is the type of credentials used by the cloud provider.
+	Type CloudProviderCredentialsType `json:"type"`
+	// AADClientCertPath is the path on the nodes of a PKCS12 certificate without password of the cluster identity
+	// service principal, e.g. mounted from Azure Key Vault with the Secrets Store CSI driver or akv2k8s.
+	// Only applicable when type is ClientCertificate. Defaults to /etc/kubernetes/certs/aad-client-cert.pfx.
+	// +optional
+	AADClientCertPath string `json:"aadClientCertPath,omitempty"`
+	// UserAssignedIdentityID is the client ID of a user-assigned identity assigned to the nodes.
+	// Only applicable when type is ManagedIdentity. The system-assigned identity of the nodes is used when it is empty.
+	// +optional
+	UserAssignedIdentityID string `json:"userAssignedIdentityID,omitempty"`
 }
 
 // BackOffConfig indicates the back-off config options.
@@ -911,6 +1381,163 @@ type AzureBastion struct {
 	// +kubebuilder:default=false
 	// +optional
 	EnableTunneling bool `json:"enableTunneling,omitempty"`
+	// ScaleUnits is the number of instances of the Azure Bastion Host, which determines how many concurrent sessions it
+	// supports. Can only be set with the Standard SKU, the Basic SKU always has 2 scale units.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	ScaleUnits *int32 `json:"scaleUnits,omitempty"`
+	// EnableIPConnect enables connecting to virtual machines through their private IP address.
+	// Requires the Standard SKU. Defaults to false.
+	// +optional
+	EnableIPConnect bool `json:"enableIPConnect,omitempty"`
+	// EnableShareableLink enables connecting to virtual machines through a shareable link, without access to the Azure Portal.
+	// Requires the Standard SKU. Defaults to false.
+	// +optional
+	EnableShareableLink bool `json:"enableShareableLink,omitempty"`
+}
+
+// VirtualNetworkGatewayType is the type of a virtual network gateway.
+type VirtualNetworkGatewayType string
+
+const (
+	// VpnVirtualNetworkGatewayType is a VPN gateway.
+	VpnVirtualNetworkGatewayType VirtualNetworkGatewayType = "Vpn"
+	// ExpressRouteVirtualNetworkGatewayType is an ExpressRoute gateway.
+	ExpressRouteVirtualNetworkGatewayType VirtualNetworkGatewayType = "ExpressRoute"
+)
+
+// VirtualNetworkGateway specifies how the VPN or ExpressRoute gateway of the cluster virtual network should be configured.
+type VirtualNetworkGateway struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Subnet is the subnet of the gateway. Azure requires it to be named GatewaySubnet and to be at least a /29.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+	// GatewayType configures the type of the gateway. Can be either Vpn or ExpressRoute. Defaults to Vpn.
+	// +kubebuilder:default=Vpn
+	// +kubebuilder:validation:Enum=Vpn;ExpressRoute
+	// +optional
+	GatewayType VirtualNetworkGatewayType `json:"gatewayType,omitempty"`
+	// SKU is the SKU of the gateway, e.g. VpnGw2AZ for a VPN gateway or ErGw2AZ for an ExpressRoute gateway.
+	// Defaults to VpnGw1AZ for VPN gateways and ErGw1AZ for ExpressRoute gateways.
+	// +optional
+	SKU string `json:"sku,omitempty"`
+}
+
+// ApplicationGatewaySKUTier is the tier of an Azure Application Gateway.
+type ApplicationGatewaySKUTier string
+
+const (
+	// StandardV2ApplicationGatewaySKUTier is the Standard_v2 tier of an Azure Application Gateway.
+	StandardV2ApplicationGatewaySKUTier ApplicationGatewaySKUTier = "Standard_v2"
+	// WAFV2ApplicationGatewaySKUTier is the WAF_v2 tier of an Azure Application Gateway, which adds a web application firewall.
+	WAFV2ApplicationGatewaySKUTier ApplicationGatewaySKUTier = "WAF_v2"
+)
+
+// ApplicationGatewayFirewallMode is the mode of the web application firewall of an Azure Application Gateway.
+type ApplicationGatewayFirewallMode string
+
+const (
+	// DetectionApplicationGatewayFirewallMode logs requests matching the firewall rules without blocking them.
+	DetectionApplicationGatewayFirewallMode ApplicationGatewayFirewallMode = "Detection"
+	// PreventionApplicationGatewayFirewallMode blocks requests matching the firewall rules.
+	PreventionApplicationGatewayFirewallMode ApplicationGatewayFirewallMode = "Prevention"
+)
+
+// ApplicationGatewaySpec specifies how the Azure Application Gateway used by the Application Gateway Ingress Controller (AGIC)
+// should be configured. CAPZ only creates the gateway with a placeholder configuration, its listeners and rules are
+// then managed by AGIC.
+type ApplicationGatewaySpec struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Subnet is the subnet of the gateway. Azure requires it to be dedicated to application gateways.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+	// SKUTier configures the tier of the gateway. Can be either Standard_v2 or WAF_v2. Defaults to Standard_v2.
+	// +kubebuilder:default=Standard_v2
+	// +kubebuilder:validation:Enum=Standard_v2;WAF_v2
+	// +optional
+	SKUTier ApplicationGatewaySKUTier `json:"skuTier,omitempty"`
+	// FirewallMode configures the mode of the web application firewall. Can be either Detection or Prevention.
+	// Can only be set with the WAF_v2 tier, for which it defaults to Prevention.
+	// +kubebuilder:validation:Enum=Detection;Prevention
+	// +optional
+	FirewallMode ApplicationGatewayFirewallMode `json:"firewallMode,omitempty"`
+	// MinCapacity is the minimum number of instances of the gateway. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=125
+	// +optional
+	MinCapacity *int32 `json:"minCapacity,omitempty"`
+	// MaxCapacity is the maximum number of instances of the gateway. Defaults to the Azure maximum of 125.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=125
+	// +optional
+	MaxCapacity *int32 `json:"maxCapacity,omitempty"`
+}
+
+// PrivateLinkService specifies how the Azure Private Link Service exposing the API server should be configured.
+type PrivateLinkService struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// SubnetName is the name of the subnet from which the NAT IP address of the private link service is allocated.
+	// Private link service network policies are disabled on this subnet. Defaults to the control plane subnet.
+	// +optional
+	SubnetName string `json:"subnetName,omitempty"`
+	// AllowedSubscriptions is a list of subscription IDs from which private endpoints can be connected to the private link service,
+	// or "*" to allow all subscriptions. Connections from these subscriptions must be approved manually unless they are auto-approved.
+	// +optional
+	AllowedSubscriptions []string `json:"allowedSubscriptions,omitempty"`
+	// AutoApprovedSubscriptions is a list of subscription IDs from which private endpoint connections to the private link service
+	// are approved automatically. The subscriptions must also be allowed.
+	// +optional
+	AutoApprovedSubscriptions []string `json:"autoApprovedSubscriptions,omitempty"`
+	// EnableProxyProtocol enables the TCP PROXY protocol on the private link service, so that the API server backends receive
+	// the connection information of the original client. The API server must be configured to accept it.
+	// +optional
+	EnableProxyProtocol *bool `json:"enableProxyProtocol,omitempty"`
+	// Alias is the globally unique alias of the private link service, used to create private endpoints connected to it.
+	// READ-ONLY
+	// +optional
+	Alias string `json:"alias,omitempty"`
+}
+
+// AzureFirewallSKUTier is the tier of an Azure Firewall.
+type AzureFirewallSKUTier string
+
+const (
+	// StandardAzureFirewallSKUTier is the Standard tier of Azure Firewall.
+	StandardAzureFirewallSKUTier AzureFirewallSKUTier = "Standard"
+	// PremiumAzureFirewallSKUTier is the Premium tier of Azure Firewall.
+	PremiumAzureFirewallSKUTier AzureFirewallSKUTier = "Premium"
+)
+
+// AzureFirewall specifies how the Azure Firewall used for node egress should be configured.
+type AzureFirewall struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Subnet is the subnet of the firewall. Azure requires it to be named AzureFirewallSubnet and to be at least a /26.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+	// SKUTier configures the tier of the Azure Firewall. Can be either Standard or Premium. Defaults to Standard.
+	// +kubebuilder:default=Standard
+	// +kubebuilder:validation:Enum=Standard;Premium
+	// +optional
+	SKUTier AzureFirewallSKUTier `json:"skuTier,omitempty"`
+	// AdditionalAllowedFQDNs is a list of FQDNs the nodes are allowed to reach on ports 80 and 443,
+	// in addition to the endpoints required to bootstrap and run Kubernetes on Azure.
+	// +optional
+	AdditionalAllowedFQDNs []string `json:"additionalAllowedFQDNs,omitempty"`
+	// PrivateIPAddress is the private IP address of the firewall, used as the next hop of the node default route.
+	// READ-ONLY
+	// +optional
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
 }
 
 // BackendPool describes the backend pool of the load balancer.
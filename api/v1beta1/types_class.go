@@ -56,6 +56,11 @@ type AzureClusterClassSpec struct {
 	// Note: All cloud provider config values can be customized by creating the secret beforehand. CloudProviderConfigOverrides is only used when the secret is managed by the Azure Provider.
 	// +optional
 	CloudProviderConfigOverrides *CloudProviderConfigOverrides `json:"cloudProviderConfigOverrides,omitempty"`
+
+	// AvailabilitySetDomainCounts are the default fault and update domain counts of the availability sets of machines
+	// in clusters without failure domains. Machines can override them in their spec.
+	// +optional
+	AvailabilitySetDomainCounts *AvailabilitySetDomainCounts `json:"availabilitySetDomainCounts,omitempty"`
 }
 
 // ExtendedLocationSpec defines the ExtendedLocation properties to enable CAPZ for Azure public MEC.
@@ -73,6 +78,36 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// PrivateDNSZone defines how the private DNS zone of a private cluster is managed.
+	// It can be System, to let CAPZ create and manage the zone, None, to not create any private DNS zone, virtual network
+	// link or record, or the resource ID of an existing private DNS zone, in which case CAPZ only manages the virtual
+	// network links and the API server record of the cluster in that zone. Defaults to System.
+	// +optional
+	PrivateDNSZone string `json:"privateDNSZone,omitempty"`
+
+	// OutboundType defines how outbound traffic from the node subnets egresses the virtual network.
+	// When set to userDefinedRouting, no node outbound load balancer or NAT gateway is created and the
+	// node subnets must be associated with a route table that has a default route, e.g. to an Azure Firewall.
+	// Defaults to loadBalancer.
+	// +kubebuilder:validation:Enum=loadBalancer;userDefinedRouting
+	// +optional
+	OutboundType OutboundType `json:"outboundType,omitempty"`
+}
+
+// IsPrivateDNSZoneDisabled returns true if no private DNS zone should be used for the cluster.
+func (n NetworkClassSpec) IsPrivateDNSZoneDisabled() bool {
+	return n.PrivateDNSZone == PrivateDNSZoneModeNone
+}
+
+// IsPrivateDNSZoneBYO returns true if the cluster uses an existing private DNS zone, referenced by its resource ID.
+func (n NetworkClassSpec) IsPrivateDNSZoneBYO() bool {
+	return n.PrivateDNSZone != "" && n.PrivateDNSZone != PrivateDNSZoneModeSystem && !n.IsPrivateDNSZoneDisabled()
+}
+
+// IsUserDefinedRouting returns true if node outbound traffic is routed by user defined routes.
+func (n NetworkClassSpec) IsUserDefinedRouting() bool {
+	return n.OutboundType == UserDefinedRoutingOutboundType
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
@@ -92,7 +127,7 @@ type SubnetClassSpec struct {
 	Name string `json:"name"`
 
 	// Role defines the subnet role (eg. Node, ControlPlane)
-	// +kubebuilder:validation:Enum=node;control-plane;bastion
+	// +kubebuilder:validation:Enum=node;control-plane;bastion;firewall;gateway;applicationgateway
 	Role SubnetRole `json:"role"`
 
 	// CIDRBlocks defines the subnet's address space, specified as one or more address prefixes in CIDR notation.
@@ -106,6 +141,10 @@ type SubnetClassSpec struct {
 	// PrivateEndpoints defines a list of private endpoints that should be attached to this subnet.
 	// +optional
 	PrivateEndpoints PrivateEndpoints `json:"privateEndpoints,omitempty"`
+
+	// Delegations is a slice of Azure services the subnet is delegated to.
+	// +optional
+	Delegations SubnetDelegations `json:"delegations,omitempty"`
 }
 
 // LoadBalancerClassSpec defines the LoadBalancerSpec properties that may be shared across several Azure clusters.
@@ -117,6 +156,38 @@ type LoadBalancerClassSpec struct {
 	// IdleTimeoutInMinutes specifies the timeout for the TCP idle connection.
 	// +optional
 	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// EnableTCPReset specifies whether the load balancer sends a bidirectional TCP reset when a flow of its load
+	// balancing and outbound rules times out or is terminated unexpectedly.
+	// +optional
+	EnableTCPReset *bool `json:"enableTCPReset,omitempty"`
+	// AllocatedOutboundPorts is the number of SNAT ports allocated to each instance of the backend pool by the
+	// outbound rule of a public load balancer. Must be a multiple of 8 between 0 and 64000. Leaving it unset or setting
+	// it to 0 lets Azure allocate ports based on the size of the backend pool.
+	// Each frontend IP provides 64000 ports, which must cover the allocated ports of all instances of the backend pool.
+	// +optional
+	AllocatedOutboundPorts *int32 `json:"allocatedOutboundPorts,omitempty"`
+	// Mode defines how outbound connectivity is provided to the nodes and only applies to the node outbound load
+	// balancer. Dedicated creates a separate load balancer, Shared reuses the public API server load balancer, and
+	// Disabled doesn't create any, e.g. when a NAT gateway or a firewall handles egress. Defaults to Dedicated.
+	// +kubebuilder:validation:Enum=Dedicated;Shared;Disabled
+	// +optional
+	Mode NodeOutboundLBMode `json:"mode,omitempty"`
+	// SubnetName is the name of the subnet of the cluster virtual network the private frontend IPs of the API server
+	// load balancer are allocated from, e.g. a dedicated subnet in a hub-and-spoke topology. It only applies to the API
+	// server load balancer. The subnet doesn't need to be one of the cluster subnets, in which case it must already exist.
+	// Defaults to the control plane subnet.
+	// +optional
+	SubnetName string `json:"subnetName,omitempty"`
+}
+
+// IsDedicated returns true if the node outbound load balancer is a separate load balancer.
+func (lb LoadBalancerClassSpec) IsDedicated() bool {
+	return lb.Mode == "" || lb.Mode == NodeOutboundLBModeDedicated
+}
+
+// IsSharedWithAPIServerLB returns true if node outbound traffic goes through the public API server load balancer.
+func (lb LoadBalancerClassSpec) IsSharedWithAPIServerLB() bool {
+	return lb.Mode == NodeOutboundLBModeShared
 }
 
 // SecurityGroupClass defines the SecurityGroup properties that may be shared across several Azure clusters.
@@ -23,6 +23,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -37,6 +38,11 @@ func (in *AADProfile) DeepCopyInto(out *AADProfile) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EnableAzureRBAC != nil {
+		in, out := &in.EnableAzureRBAC, &out.EnableAzureRBAC
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AADProfile.
@@ -82,11 +88,21 @@ func (in *APIServerAccessProfile) DeepCopyInto(out *APIServerAccessProfile) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FQDNSubdomain != nil {
+		in, out := &in.FQDNSubdomain, &out.FQDNSubdomain
+		*out = new(string)
+		**out = **in
+	}
 	if in.EnablePrivateClusterPublicFQDN != nil {
 		in, out := &in.EnablePrivateClusterPublicFQDN, &out.EnablePrivateClusterPublicFQDN
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IncludeManagementClusterEgressIPs != nil {
+		in, out := &in.IncludeManagementClusterEgressIPs, &out.IncludeManagementClusterEgressIPs
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerAccessProfile.
@@ -107,6 +123,11 @@ func (in *AdditionalCapabilities) DeepCopyInto(out *AdditionalCapabilities) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HibernationEnabled != nil {
+		in, out := &in.HibernationEnabled, &out.HibernationEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalCapabilities.
@@ -181,6 +202,33 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationGatewaySpec) DeepCopyInto(out *ApplicationGatewaySpec) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	if in.MinCapacity != nil {
+		in, out := &in.MinCapacity, &out.MinCapacity
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCapacity != nil {
+		in, out := &in.MaxCapacity, &out.MaxCapacity
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationGatewaySpec.
+func (in *ApplicationGatewaySpec) DeepCopy() *ApplicationGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalerProfile) DeepCopyInto(out *AutoScalerProfile) {
 	*out = *in
@@ -281,11 +329,41 @@ func (in *AutoScalerProfile) DeepCopy() *AutoScalerProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilitySetDomainCounts) DeepCopyInto(out *AvailabilitySetDomainCounts) {
+	*out = *in
+	if in.FaultDomainCount != nil {
+		in, out := &in.FaultDomainCount, &out.FaultDomainCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdateDomainCount != nil {
+		in, out := &in.UpdateDomainCount, &out.UpdateDomainCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilitySetDomainCounts.
+func (in *AvailabilitySetDomainCounts) DeepCopy() *AvailabilitySetDomainCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilitySetDomainCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureBastion) DeepCopyInto(out *AzureBastion) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
 	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	if in.ScaleUnits != nil {
+		in, out := &in.ScaleUnits, &out.ScaleUnits
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBastion.
@@ -366,6 +444,11 @@ func (in *AzureClusterClassSpec) DeepCopyInto(out *AzureClusterClassSpec) {
 		*out = new(CloudProviderConfigOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AvailabilitySetDomainCounts != nil {
+		in, out := &in.AvailabilitySetDomainCounts, &out.AvailabilitySetDomainCounts
+		*out = new(AvailabilitySetDomainCounts)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterClassSpec.
@@ -441,6 +524,16 @@ func (in *AzureClusterIdentityList) DeepCopyObject() runtime.Object {
 func (in *AzureClusterIdentitySpec) DeepCopyInto(out *AzureClusterIdentitySpec) {
 	*out = *in
 	out.ClientSecret = in.ClientSecret
+	if in.CertificateKeyVaultRef != nil {
+		in, out := &in.CertificateKeyVaultRef, &out.CertificateKeyVaultRef
+		*out = new(KeyVaultCertificateReference)
+		**out = **in
+	}
+	if in.AuxiliaryTenantIDs != nil {
+		in, out := &in.AuxiliaryTenantIDs, &out.AuxiliaryTenantIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AllowedNamespaces != nil {
 		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
 		*out = new(AllowedNamespaces)
@@ -468,6 +561,11 @@ func (in *AzureClusterIdentityStatus) DeepCopyInto(out *AzureClusterIdentityStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReferencedByClusters != nil {
+		in, out := &in.ReferencedByClusters, &out.ReferencedByClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterIdentityStatus.
@@ -518,7 +616,22 @@ func (in *AzureClusterSpec) DeepCopyInto(out *AzureClusterSpec) {
 	in.AzureClusterClassSpec.DeepCopyInto(&out.AzureClusterClassSpec)
 	in.NetworkSpec.DeepCopyInto(&out.NetworkSpec)
 	in.BastionSpec.DeepCopyInto(&out.BastionSpec)
+	if in.ApplicationGateway != nil {
+		in, out := &in.ApplicationGateway, &out.ApplicationGateway
+		*out = new(ApplicationGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProximityPlacementGroups != nil {
+		in, out := &in.ProximityPlacementGroups, &out.ProximityPlacementGroups
+		*out = make([]ProximityPlacementGroup, len(*in))
+		copy(*out, *in)
+	}
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.NamingConvention != nil {
+		in, out := &in.NamingConvention, &out.NamingConvention
+		*out = new(NamingConvention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterSpec.
@@ -551,6 +664,13 @@ func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 	if in.LongRunningOperationStates != nil {
 		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
 		*out = make(Futures, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.APIServerEndpoints != nil {
+		in, out := &in.APIServerEndpoints, &out.APIServerEndpoints
+		*out = make([]apiv1beta1.APIEndpoint, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -703,6 +823,78 @@ func (in *AzureComputeGalleryImage) DeepCopy() *AzureComputeGalleryImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureFirewall) DeepCopyInto(out *AzureFirewall) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	if in.AdditionalAllowedFQDNs != nil {
+		in, out := &in.AdditionalAllowedFQDNs, &out.AdditionalAllowedFQDNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureFirewall.
+func (in *AzureFirewall) DeepCopy() *AzureFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultKms) DeepCopyInto(out *AzureKeyVaultKms) {
+	*out = *in
+	if in.KeyVaultNetworkAccess != nil {
+		in, out := &in.KeyVaultNetworkAccess, &out.KeyVaultNetworkAccess
+		*out = new(KeyVaultNetworkAccessTypes)
+		**out = **in
+	}
+	if in.KeyVaultResourceID != nil {
+		in, out := &in.KeyVaultResourceID, &out.KeyVaultResourceID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultKms.
+func (in *AzureKeyVaultKms) DeepCopy() *AzureKeyVaultKms {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultKms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyvaultSecretsProviderAddon) DeepCopyInto(out *AzureKeyvaultSecretsProviderAddon) {
+	*out = *in
+	if in.EnableSecretRotation != nil {
+		in, out := &in.EnableSecretRotation, &out.EnableSecretRotation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RotationPollInterval != nil {
+		in, out := &in.RotationPollInterval, &out.RotationPollInterval
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyvaultSecretsProviderAddon.
+func (in *AzureKeyvaultSecretsProviderAddon) DeepCopy() *AzureKeyvaultSecretsProviderAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyvaultSecretsProviderAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachine) DeepCopyInto(out *AzureMachine) {
 	*out = *in
@@ -775,6 +967,16 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AllowedFailureDomains != nil {
+		in, out := &in.AllowedFailureDomains, &out.AllowedFailureDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilitySetDomainCounts != nil {
+		in, out := &in.AvailabilitySetDomainCounts, &out.AvailabilitySetDomainCounts
+		*out = new(AvailabilitySetDomainCounts)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(Image)
@@ -810,6 +1012,16 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 		*out = new(AdditionalCapabilities)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PublicIPPrefixID != nil {
+		in, out := &in.PublicIPPrefixID, &out.PublicIPPrefixID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(NodePublicIP)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AcceleratedNetworking != nil {
 		in, out := &in.AcceleratedNetworking, &out.AcceleratedNetworking
 		*out = new(bool)
@@ -830,6 +1042,11 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 		*out = new(SecurityProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DedicatedHost != nil {
+		in, out := &in.DedicatedHost, &out.DedicatedHost
+		*out = new(DedicatedHost)
+		**out = **in
+	}
 	if in.DNSServers != nil {
 		in, out := &in.DNSServers, &out.DNSServers
 		*out = make([]string, len(*in))
@@ -842,6 +1059,11 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GPUDriver != nil {
+		in, out := &in.GPUDriver, &out.GPUDriver
+		*out = new(GPUDriver)
+		**out = **in
+	}
 	if in.NetworkInterfaces != nil {
 		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
 		*out = make([]NetworkInterface, len(*in))
@@ -874,6 +1096,11 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 		*out = new(ProvisioningState)
 		**out = **in
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(Image)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -894,6 +1121,18 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 	if in.LongRunningOperationStates != nil {
 		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
 		*out = make(Futures, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationStatus != nil {
+		in, out := &in.OperationStatus, &out.OperationStatus
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAddressClaims != nil {
+		in, out := &in.IPAddressClaims, &out.IPAddressClaims
+		*out = make([]IPAddressClaim, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -1190,11 +1429,21 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(ManagedControlPlaneIdentity)
+		**out = **in
+	}
 	if in.AADProfile != nil {
 		in, out := &in.AADProfile, &out.AADProfile
 		*out = new(AADProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DisableLocalAccounts != nil {
+		in, out := &in.DisableLocalAccounts, &out.DisableLocalAccounts
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AddonProfiles != nil {
 		in, out := &in.AddonProfiles, &out.AddonProfiles
 		*out = make([]AddonProfile, len(*in))
@@ -1202,6 +1451,11 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(ManagedClusterAddons)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SKU != nil {
 		in, out := &in.SKU, &out.SKU
 		*out = new(AKSSku)
@@ -1212,6 +1466,11 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(LoadBalancerProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NATGatewayProfile != nil {
+		in, out := &in.NATGatewayProfile, &out.NATGatewayProfile
+		*out = new(NATGatewayProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.APIServerAccessProfile != nil {
 		in, out := &in.APIServerAccessProfile, &out.APIServerAccessProfile
 		*out = new(APIServerAccessProfile)
@@ -1222,6 +1481,38 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(AutoScalerProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(ManagedControlPlaneSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDCIssuerProfile != nil {
+		in, out := &in.OIDCIssuerProfile, &out.OIDCIssuerProfile
+		*out = new(OIDCIssuerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoUpgradeProfile != nil {
+		in, out := &in.AutoUpgradeProfile, &out.AutoUpgradeProfile
+		*out = new(ManagedClusterAutoUpgradeProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceConfigurations != nil {
+		in, out := &in.MaintenanceConfigurations, &out.MaintenanceConfigurations
+		*out = make([]MaintenanceConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PowerState != nil {
+		in, out := &in.PowerState, &out.PowerState
+		*out = new(PowerState)
+		**out = **in
+	}
+	if in.HTTPProxyConfig != nil {
+		in, out := &in.HTTPProxyConfig, &out.HTTPProxyConfig
+		*out = new(HTTPProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneSpec.
@@ -1247,7 +1538,19 @@ func (in *AzureManagedControlPlaneStatus) DeepCopyInto(out *AzureManagedControlP
 	if in.LongRunningOperationStates != nil {
 		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
 		*out = make(Futures, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationStatus != nil {
+		in, out := &in.OperationStatus, &out.OperationStatus
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDCIssuerProfile != nil {
+		in, out := &in.OIDCIssuerProfile, &out.OIDCIssuerProfile
+		*out = new(OIDCIssuerProfileStatus)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1402,6 +1705,16 @@ func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePool
 		*out = new(string)
 		**out = **in
 	}
+	if in.ScaleSetEvictionPolicy != nil {
+		in, out := &in.ScaleSetEvictionPolicy, &out.ScaleSetEvictionPolicy
+		*out = new(SpotEvictionPolicy)
+		**out = **in
+	}
+	if in.SpotMaxPrice != nil {
+		in, out := &in.SpotMaxPrice, &out.SpotMaxPrice
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.KubeletConfig != nil {
 		in, out := &in.KubeletConfig, &out.KubeletConfig
 		*out = new(KubeletConfig)
@@ -1457,7 +1770,14 @@ func (in *AzureManagedMachinePoolStatus) DeepCopyInto(out *AzureManagedMachinePo
 	if in.LongRunningOperationStates != nil {
 		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
 		*out = make(Futures, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationStatus != nil {
+		in, out := &in.OperationStatus, &out.OperationStatus
+		*out = new(OperationStatus)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1487,6 +1807,21 @@ func (in *AzureMarketplaceImage) DeepCopy() *AzureMarketplaceImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePolicyAddon) DeepCopyInto(out *AzurePolicyAddon) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePolicyAddon.
+func (in *AzurePolicyAddon) DeepCopy() *AzurePolicyAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePolicyAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureSharedGalleryImage) DeepCopyInto(out *AzureSharedGalleryImage) {
 	*out = *in
@@ -1660,6 +1995,18 @@ func (in *CloudProviderConfigOverrides) DeepCopyInto(out *CloudProviderConfigOve
 		}
 	}
 	in.BackOffs.DeepCopyInto(&out.BackOffs)
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(CloudProviderCredentials)
+		**out = **in
+	}
+	if in.ExtraConfig != nil {
+		in, out := &in.ExtraConfig, &out.ExtraConfig
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderConfigOverrides.
@@ -1672,6 +2019,21 @@ func (in *CloudProviderConfigOverrides) DeepCopy() *CloudProviderConfigOverrides
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderCredentials) DeepCopyInto(out *CloudProviderCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderCredentials.
+func (in *CloudProviderCredentials) DeepCopy() *CloudProviderCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 	*out = *in
@@ -1697,6 +2059,51 @@ func (in *DataDisk) DeepCopy() *DataDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedHost) DeepCopyInto(out *DedicatedHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedHost.
+func (in *DedicatedHost) DeepCopy() *DedicatedHost {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefenderProfile) DeepCopyInto(out *DefenderProfile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefenderProfile.
+func (in *DefenderProfile) DeepCopy() *DefenderProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DefenderProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefenderSecurityMonitoring) DeepCopyInto(out *DefenderSecurityMonitoring) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefenderSecurityMonitoring.
+func (in *DefenderSecurityMonitoring) DeepCopy() *DefenderSecurityMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(DefenderSecurityMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Diagnostics) DeepCopyInto(out *Diagnostics) {
 	*out = *in
@@ -1720,6 +2127,11 @@ func (in *Diagnostics) DeepCopy() *Diagnostics {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiffDiskSettings) DeepCopyInto(out *DiffDiskSettings) {
 	*out = *in
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(DiffDiskPlacement)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiffDiskSettings.
@@ -1770,7 +2182,12 @@ func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
 		*out = new(PublicIPSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.FrontendIPClass = in.FrontendIPClass
+	if in.AddressFromPool != nil {
+		in, out := &in.AddressFromPool, &out.AddressFromPool
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	out.FrontendIPClass = in.FrontendIPClass
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendIP.
@@ -1801,6 +2218,10 @@ func (in *FrontendIPClass) DeepCopy() *FrontendIPClass {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Future) DeepCopyInto(out *Future) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Future.
@@ -1818,7 +2239,9 @@ func (in Futures) DeepCopyInto(out *Futures) {
 	{
 		in := &in
 		*out = make(Futures, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1832,6 +2255,71 @@ func (in Futures) DeepCopy() Futures {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUDriver) DeepCopyInto(out *GPUDriver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDriver.
+func (in *GPUDriver) DeepCopy() *GPUDriver {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUDriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyConfig) DeepCopyInto(out *HTTPProxyConfig) {
+	*out = *in
+	if in.HTTPProxy != nil {
+		in, out := &in.HTTPProxy, &out.HTTPProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSProxy != nil {
+		in, out := &in.HTTPSProxy, &out.HTTPSProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrustedCASecretRef != nil {
+		in, out := &in.TrustedCASecretRef, &out.TrustedCASecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyConfig.
+func (in *HTTPProxyConfig) DeepCopy() *HTTPProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAddressClaim) DeepCopyInto(out *IPAddressClaim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAddressClaim.
+func (in *IPAddressClaim) DeepCopy() *IPAddressClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAddressClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPTag) DeepCopyInto(out *IPTag) {
 	*out = *in
@@ -1897,6 +2385,21 @@ func (in *ImagePlan) DeepCopy() *ImagePlan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultCertificateReference) DeepCopyInto(out *KeyVaultCertificateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultCertificateReference.
+func (in *KeyVaultCertificateReference) DeepCopy() *KeyVaultCertificateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultCertificateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
 	*out = *in
@@ -2010,6 +2513,16 @@ func (in *LoadBalancerClassSpec) DeepCopyInto(out *LoadBalancerClassSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.EnableTCPReset != nil {
+		in, out := &in.EnableTCPReset, &out.EnableTCPReset
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllocatedOutboundPorts != nil {
+		in, out := &in.AllocatedOutboundPorts, &out.AllocatedOutboundPorts
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerClassSpec.
@@ -2091,6 +2604,135 @@ func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceConfiguration) DeepCopyInto(out *MaintenanceConfiguration) {
+	*out = *in
+	if in.TimeInWeek != nil {
+		in, out := &in.TimeInWeek, &out.TimeInWeek
+		*out = make([]TimeInWeek, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotAllowedTime != nil {
+		in, out := &in.NotAllowedTime, &out.NotAllowedTime
+		*out = make([]TimeSpan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceConfiguration.
+func (in *MaintenanceConfiguration) DeepCopy() *MaintenanceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterAddons) DeepCopyInto(out *ManagedClusterAddons) {
+	*out = *in
+	if in.AzurePolicy != nil {
+		in, out := &in.AzurePolicy, &out.AzurePolicy
+		*out = new(AzurePolicyAddon)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringAddon)
+		**out = **in
+	}
+	if in.AzureKeyvaultSecretsProvider != nil {
+		in, out := &in.AzureKeyvaultSecretsProvider, &out.AzureKeyvaultSecretsProvider
+		*out = new(AzureKeyvaultSecretsProviderAddon)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenServiceMesh != nil {
+		in, out := &in.OpenServiceMesh, &out.OpenServiceMesh
+		*out = new(OpenServiceMeshAddon)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterAddons.
+func (in *ManagedClusterAddons) DeepCopy() *ManagedClusterAddons {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterAddons)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopyInto(out *ManagedClusterAutoUpgradeProfile) {
+	*out = *in
+	if in.UpgradeChannel != nil {
+		in, out := &in.UpgradeChannel, &out.UpgradeChannel
+		*out = new(UpgradeChannel)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterAutoUpgradeProfile.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopy() *ManagedClusterAutoUpgradeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterAutoUpgradeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneIdentity) DeepCopyInto(out *ManagedControlPlaneIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneIdentity.
+func (in *ManagedControlPlaneIdentity) DeepCopy() *ManagedControlPlaneIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneSecurityProfile) DeepCopyInto(out *ManagedControlPlaneSecurityProfile) {
+	*out = *in
+	if in.AzureKeyVaultKms != nil {
+		in, out := &in.AzureKeyVaultKms, &out.AzureKeyVaultKms
+		*out = new(AzureKeyVaultKms)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defender != nil {
+		in, out := &in.Defender, &out.Defender
+		*out = new(DefenderProfile)
+		**out = **in
+	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentityProfile)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneSecurityProfile.
+func (in *ManagedControlPlaneSecurityProfile) DeepCopy() *ManagedControlPlaneSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet) {
 	*out = *in
@@ -2144,6 +2786,11 @@ func (in *ManagedDiskParameters) DeepCopyInto(out *ManagedDiskParameters) {
 		*out = new(DiskEncryptionSetParameters)
 		**out = **in
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(VMDiskSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDiskParameters.
@@ -2181,11 +2828,73 @@ func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAddon) DeepCopyInto(out *MonitoringAddon) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAddon.
+func (in *MonitoringAddon) DeepCopy() *MonitoringAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayProfile) DeepCopyInto(out *NATGatewayProfile) {
+	*out = *in
+	if in.ManagedOutboundIPs != nil {
+		in, out := &in.ManagedOutboundIPs, &out.ManagedOutboundIPs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayProfile.
+func (in *NATGatewayProfile) DeepCopy() *NATGatewayProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamingConvention) DeepCopyInto(out *NamingConvention) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make(map[NamingResourceType]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingConvention.
+func (in *NamingConvention) DeepCopy() *NamingConvention {
+	if in == nil {
+		return nil
+	}
+	out := new(NamingConvention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NatGateway) DeepCopyInto(out *NatGateway) {
 	*out = *in
 	in.NatGatewayIP.DeepCopyInto(&out.NatGatewayIP)
-	out.NatGatewayClassSpec = in.NatGatewayClassSpec
+	in.NatGatewayClassSpec.DeepCopyInto(&out.NatGatewayClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGateway.
@@ -2201,6 +2910,21 @@ func (in *NatGateway) DeepCopy() *NatGateway {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NatGatewayClassSpec) DeepCopyInto(out *NatGatewayClassSpec) {
 	*out = *in
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicIPPrefixes != nil {
+		in, out := &in.PublicIPPrefixes, &out.PublicIPPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGatewayClassSpec.
@@ -2231,11 +2955,33 @@ func (in *NetworkClassSpec) DeepCopy() *NetworkClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
 	*out = *in
+	if in.IPConfigs != nil {
+		in, out := &in.IPConfigs, &out.IPConfigs
+		*out = make([]NetworkInterfaceIPConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableIPForwarding != nil {
+		in, out := &in.EnableIPForwarding, &out.EnableIPForwarding
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AcceleratedNetworking != nil {
 		in, out := &in.AcceleratedNetworking, &out.AcceleratedNetworking
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AddressesFromPools != nil {
+		in, out := &in.AddressesFromPools, &out.AddressesFromPools
+		*out = make([]corev1.TypedLocalObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
@@ -2248,6 +2994,21 @@ func (in *NetworkInterface) DeepCopy() *NetworkInterface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceIPConfig) DeepCopyInto(out *NetworkInterfaceIPConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceIPConfig.
+func (in *NetworkInterfaceIPConfig) DeepCopy() *NetworkInterfaceIPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceIPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -2270,6 +3031,21 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(LoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AzureFirewall != nil {
+		in, out := &in.AzureFirewall, &out.AzureFirewall
+		*out = new(AzureFirewall)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualNetworkGateway != nil {
+		in, out := &in.VirtualNetworkGateway, &out.VirtualNetworkGateway
+		*out = new(VirtualNetworkGateway)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerPrivateLinkService != nil {
+		in, out := &in.APIServerPrivateLinkService, &out.APIServerPrivateLinkService
+		*out = new(PrivateLinkService)
+		(*in).DeepCopyInto(*out)
+	}
 	out.NetworkClassSpec = in.NetworkClassSpec
 }
 
@@ -2318,6 +3094,66 @@ func (in *NetworkTemplateSpec) DeepCopy() *NetworkTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePublicIP) DeepCopyInto(out *NodePublicIP) {
+	*out = *in
+	if in.IPTags != nil {
+		in, out := &in.IPTags, &out.IPTags
+		*out = make([]IPTag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePublicIP.
+func (in *NodePublicIP) DeepCopy() *NodePublicIP {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePublicIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIssuerProfile) DeepCopyInto(out *OIDCIssuerProfile) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIssuerProfile.
+func (in *OIDCIssuerProfile) DeepCopy() *OIDCIssuerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIssuerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIssuerProfileStatus) DeepCopyInto(out *OIDCIssuerProfileStatus) {
+	*out = *in
+	if in.IssuerURL != nil {
+		in, out := &in.IssuerURL, &out.IssuerURL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIssuerProfileStatus.
+func (in *OIDCIssuerProfileStatus) DeepCopy() *OIDCIssuerProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIssuerProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OSDisk) DeepCopyInto(out *OSDisk) {
 	*out = *in
@@ -2334,7 +3170,7 @@ func (in *OSDisk) DeepCopyInto(out *OSDisk) {
 	if in.DiffDiskSettings != nil {
 		in, out := &in.DiffDiskSettings, &out.DiffDiskSettings
 		*out = new(DiffDiskSettings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -2348,6 +3184,45 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenServiceMeshAddon) DeepCopyInto(out *OpenServiceMeshAddon) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenServiceMeshAddon.
+func (in *OpenServiceMeshAddon) DeepCopy() *OpenServiceMeshAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenServiceMeshAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationStatus) DeepCopyInto(out *OperationStatus) {
+	*out = *in
+	if in.PercentComplete != nil {
+		in, out := &in.PercentComplete, &out.PercentComplete
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationStatus.
+func (in *OperationStatus) DeepCopy() *OperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 	*out = *in
@@ -2368,6 +3243,11 @@ func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateDNSZoneIDs != nil {
+		in, out := &in.PrivateDNSZoneIDs, &out.PrivateDNSZoneIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
@@ -2401,6 +3281,36 @@ func (in PrivateEndpoints) DeepCopy() PrivateEndpoints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkService) DeepCopyInto(out *PrivateLinkService) {
+	*out = *in
+	if in.AllowedSubscriptions != nil {
+		in, out := &in.AllowedSubscriptions, &out.AllowedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovedSubscriptions != nil {
+		in, out := &in.AutoApprovedSubscriptions, &out.AutoApprovedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableProxyProtocol != nil {
+		in, out := &in.EnableProxyProtocol, &out.EnableProxyProtocol
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkService.
+func (in *PrivateLinkService) DeepCopy() *PrivateLinkService {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateLinkServiceConnection) DeepCopyInto(out *PrivateLinkServiceConnection) {
 	*out = *in
@@ -2421,6 +3331,21 @@ func (in *PrivateLinkServiceConnection) DeepCopy() *PrivateLinkServiceConnection
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProximityPlacementGroup) DeepCopyInto(out *ProximityPlacementGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProximityPlacementGroup.
+func (in *ProximityPlacementGroup) DeepCopy() *ProximityPlacementGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProximityPlacementGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
@@ -2482,9 +3407,29 @@ func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTable.
@@ -2550,6 +3495,11 @@ func (in *SecurityProfile) DeepCopyInto(out *SecurityProfile) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UefiSettings != nil {
+		in, out := &in.UefiSettings, &out.UefiSettings
+		*out = new(UefiSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityProfile.
@@ -2580,11 +3530,31 @@ func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Destination != nil {
 		in, out := &in.Destination, &out.Destination
 		*out = new(string)
 		**out = **in
 	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
@@ -2706,6 +3676,11 @@ func (in *SubnetClassSpec) DeepCopyInto(out *SubnetClassSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Delegations != nil {
+		in, out := &in.Delegations, &out.Delegations
+		*out = make(SubnetDelegations, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetClassSpec.
@@ -2718,11 +3693,45 @@ func (in *SubnetClassSpec) DeepCopy() *SubnetClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetDelegation) DeepCopyInto(out *SubnetDelegation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDelegation.
+func (in *SubnetDelegation) DeepCopy() *SubnetDelegation {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetDelegation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in SubnetDelegations) DeepCopyInto(out *SubnetDelegations) {
+	{
+		in := &in
+		*out = make(SubnetDelegations, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDelegations.
+func (in SubnetDelegations) DeepCopy() SubnetDelegations {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetDelegations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
-	out.RouteTable = in.RouteTable
+	in.RouteTable.DeepCopyInto(&out.RouteTable)
 	in.NatGateway.DeepCopyInto(&out.NatGateway)
 	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
 }
@@ -2742,7 +3751,7 @@ func (in *SubnetTemplateSpec) DeepCopyInto(out *SubnetTemplateSpec) {
 	*out = *in
 	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
-	out.NatGateway = in.NatGateway
+	in.NatGateway.DeepCopyInto(&out.NatGateway)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetTemplateSpec.
@@ -3022,6 +4031,68 @@ func (in Taints) DeepCopy() Taints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeInWeek) DeepCopyInto(out *TimeInWeek) {
+	*out = *in
+	if in.HourSlots != nil {
+		in, out := &in.HourSlots, &out.HourSlots
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeInWeek.
+func (in *TimeInWeek) DeepCopy() *TimeInWeek {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeInWeek)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSpan) DeepCopyInto(out *TimeSpan) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSpan.
+func (in *TimeSpan) DeepCopy() *TimeSpan {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSpan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UefiSettings) DeepCopyInto(out *UefiSettings) {
+	*out = *in
+	if in.SecureBootEnabled != nil {
+		in, out := &in.SecureBootEnabled, &out.SecureBootEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VTpmEnabled != nil {
+		in, out := &in.VTpmEnabled, &out.VTpmEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UefiSettings.
+func (in *UefiSettings) DeepCopy() *UefiSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UefiSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserAssignedIdentity) DeepCopyInto(out *UserAssignedIdentity) {
 	*out = *in
@@ -3052,6 +4123,26 @@ func (in *UserManagedBootDiagnostics) DeepCopy() *UserManagedBootDiagnostics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMDiskSecurityProfile) DeepCopyInto(out *VMDiskSecurityProfile) {
+	*out = *in
+	if in.DiskEncryptionSet != nil {
+		in, out := &in.DiskEncryptionSet, &out.DiskEncryptionSet
+		*out = new(DiskEncryptionSetParameters)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDiskSecurityProfile.
+func (in *VMDiskSecurityProfile) DeepCopy() *VMDiskSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VMDiskSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMExtension) DeepCopyInto(out *VMExtension) {
 	*out = *in
@@ -3069,6 +4160,11 @@ func (in *VMExtension) DeepCopyInto(out *VMExtension) {
 			(*out)[key] = val
 		}
 	}
+	if in.ProtectedSettingsSecretRef != nil {
+		in, out := &in.ProtectedSettingsSecretRef, &out.ProtectedSettingsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMExtension.
@@ -3081,6 +4177,23 @@ func (in *VMExtension) DeepCopy() *VMExtension {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualNetworkGateway) DeepCopyInto(out *VirtualNetworkGateway) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualNetworkGateway.
+func (in *VirtualNetworkGateway) DeepCopy() *VirtualNetworkGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualNetworkGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetClassSpec) DeepCopyInto(out *VnetClassSpec) {
 	*out = *in
@@ -3263,3 +4376,18 @@ func (in *VnetTemplateSpec) DeepCopy() *VnetTemplateSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityProfile) DeepCopyInto(out *WorkloadIdentityProfile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityProfile.
+func (in *WorkloadIdentityProfile) DeepCopy() *WorkloadIdentityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
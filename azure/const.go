@@ -35,9 +35,37 @@ const (
 	// for annotation formatting rules.
 	ManagedClusterTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-managedcluster"
 
+	// ResourcesTagsLastAppliedAnnotation is the key for the AzureCluster and AzureMachine object annotation
+	// which tracks the AdditionalTags applied to their other Azure resources, keyed by resource ID.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	ResourcesTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-resources"
+
+	// RoleAssignmentsLastAppliedAnnotation is the key for the AzureMachine and AzureMachinePool object annotation
+	// which tracks the role assignments created for the system-assigned identity, keyed by name with their scope.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	RoleAssignmentsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-role-assignments"
+
+	// MaintenanceConfigurationsLastAppliedAnnotation is the key for the AzureManagedControlPlane object annotation
+	// which tracks the maintenance configurations created for the managed cluster, keyed by name with the name of the
+	// managed cluster.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	MaintenanceConfigurationsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-maintenance-configurations"
+
 	// CustomDataHashAnnotation is the key for the machine object annotation
 	// which tracks the hash of the custom data.
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	CustomDataHashAnnotation = "sigs.k8s.io/cluster-api-provider-azure-vmss-custom-data-hash"
+
+	// BlockMoveAnnotation is the key for the annotation that clusterctl move waits to be removed from an object
+	// before moving it. It is set while the object has long running Azure operations in flight.
+	BlockMoveAnnotation = "clusterctl.cluster.x-k8s.io/block-move"
+
+	// FuturesCheckpointAnnotation is the key for the annotation holding a copy of the long running Azure operations
+	// in flight of an object. Unlike the status, annotations are kept by clusterctl move, so the operations can be
+	// resumed on the target management cluster.
+	FuturesCheckpointAnnotation = "sigs.k8s.io/cluster-api-provider-azure-futures"
 )
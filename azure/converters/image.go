@@ -68,7 +68,7 @@ func computeImageToSDK(image *infrav1.Image) (*compute.ImageReference, error) {
 	}
 
 	// For private Azure Compute Gallery consumption both resource group and subscription ID must be provided.
-	// If they are not, we assume use of community gallery.
+	// If they are not, we assume use of a directly shared or community gallery.
 	if image.ComputeGallery.ResourceGroup != nil && image.ComputeGallery.SubscriptionID != nil {
 		return &compute.ImageReference{
 			ID: pointer.String(fmt.Sprintf(idTemplate,
@@ -81,6 +81,16 @@ func computeImageToSDK(image *infrav1.Image) (*compute.ImageReference, error) {
 		}, nil
 	}
 
+	// Directly shared galleries are referenced by their unique name.
+	if image.ComputeGallery.DirectShared {
+		return &compute.ImageReference{
+			SharedGalleryImageID: pointer.String(fmt.Sprintf("/SharedGalleries/%s/Images/%s/Versions/%s",
+				image.ComputeGallery.Gallery,
+				image.ComputeGallery.Name,
+				image.ComputeGallery.Version)),
+		}, nil
+	}
+
 	return &compute.ImageReference{
 		CommunityGalleryImageID: pointer.String(fmt.Sprintf("/CommunityGalleries/%s/Images/%s/Versions/%s",
 			image.ComputeGallery.Gallery,
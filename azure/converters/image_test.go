@@ -205,6 +205,23 @@ func Test_ComputeImageToSDK(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "Should return parsed directly shared gallery image id",
+			image: &infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{
+					Gallery:      "my-gallery-unique-name",
+					Name:         "my-image",
+					Version:      "my-version",
+					DirectShared: true,
+				},
+			},
+			expect: func(g *GomegaWithT, result *compute.ImageReference, err error) {
+				g.Expect(err).Should(BeNil())
+				g.Expect(result).To(Equal(&compute.ImageReference{
+					SharedGalleryImageID: pointer.String("/SharedGalleries/my-gallery-unique-name/Images/my-image/Versions/my-version"),
+				}))
+			},
+		},
 		{
 			name: "Should return error if SharedGallery and ComputeGallery are nil",
 			image: &infrav1.Image{
@@ -17,37 +17,39 @@ limitations under the License.
 package converters
 
 import (
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 )
 
 // AgentPoolToManagedClusterAgentPoolProfile converts a AgentPoolSpec to an Azure SDK ManagedClusterAgentPoolProfile used in managedcluster reconcile.
 func AgentPoolToManagedClusterAgentPoolProfile(pool containerservice.AgentPool) containerservice.ManagedClusterAgentPoolProfile {
 	properties := pool.ManagedClusterAgentPoolProfileProperties
 	agentPool := containerservice.ManagedClusterAgentPoolProfile{
-		Name:                 pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
-		VMSize:               properties.VMSize,
-		OsType:               properties.OsType,
-		OsDiskSizeGB:         properties.OsDiskSizeGB,
-		Count:                properties.Count,
-		Type:                 properties.Type,
-		OrchestratorVersion:  properties.OrchestratorVersion,
-		VnetSubnetID:         properties.VnetSubnetID,
-		Mode:                 properties.Mode,
-		EnableAutoScaling:    properties.EnableAutoScaling,
-		MaxCount:             properties.MaxCount,
-		MinCount:             properties.MinCount,
-		NodeTaints:           properties.NodeTaints,
-		AvailabilityZones:    properties.AvailabilityZones,
-		MaxPods:              properties.MaxPods,
-		OsDiskType:           properties.OsDiskType,
-		NodeLabels:           properties.NodeLabels,
-		EnableUltraSSD:       properties.EnableUltraSSD,
-		EnableNodePublicIP:   properties.EnableNodePublicIP,
-		NodePublicIPPrefixID: properties.NodePublicIPPrefixID,
-		ScaleSetPriority:     properties.ScaleSetPriority,
-		Tags:                 properties.Tags,
-		KubeletDiskType:      properties.KubeletDiskType,
-		LinuxOSConfig:        properties.LinuxOSConfig,
+		Name:                   pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
+		VMSize:                 properties.VMSize,
+		OsType:                 properties.OsType,
+		OsDiskSizeGB:           properties.OsDiskSizeGB,
+		Count:                  properties.Count,
+		Type:                   properties.Type,
+		OrchestratorVersion:    properties.OrchestratorVersion,
+		VnetSubnetID:           properties.VnetSubnetID,
+		Mode:                   properties.Mode,
+		EnableAutoScaling:      properties.EnableAutoScaling,
+		MaxCount:               properties.MaxCount,
+		MinCount:               properties.MinCount,
+		NodeTaints:             properties.NodeTaints,
+		AvailabilityZones:      properties.AvailabilityZones,
+		MaxPods:                properties.MaxPods,
+		OsDiskType:             properties.OsDiskType,
+		NodeLabels:             properties.NodeLabels,
+		EnableUltraSSD:         properties.EnableUltraSSD,
+		EnableNodePublicIP:     properties.EnableNodePublicIP,
+		NodePublicIPPrefixID:   properties.NodePublicIPPrefixID,
+		ScaleSetPriority:       properties.ScaleSetPriority,
+		ScaleSetEvictionPolicy: properties.ScaleSetEvictionPolicy,
+		SpotMaxPrice:           properties.SpotMaxPrice,
+		Tags:                   properties.Tags,
+		KubeletDiskType:        properties.KubeletDiskType,
+		LinuxOSConfig:          properties.LinuxOSConfig,
 	}
 	if properties.KubeletConfig != nil {
 		agentPool.KubeletConfig = properties.KubeletConfig
@@ -37,6 +37,13 @@ func SecurityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
 		},
 	}
 
+	if len(rule.Sources) > 0 {
+		secRule.SourceAddressPrefixes = &rule.Sources
+	}
+	if len(rule.Destinations) > 0 {
+		secRule.DestinationAddressPrefixes = &rule.Destinations
+	}
+
 	switch rule.Protocol {
 	case infrav1.SecurityGroupProtocolAll:
 		secRule.Protocol = network.SecurityRuleProtocolAsterisk
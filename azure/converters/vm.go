@@ -17,6 +17,8 @@ limitations under the License.
 package converters
 
 import (
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
@@ -24,6 +26,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
+// hibernatedStatusCode is the code of the instance view status of a VM which is hibernated.
+const hibernatedStatusCode = "HibernationState/Hibernated"
+
 // VM describes an Azure virtual machine.
 type VM struct {
 	ID               string `json:"id,omitempty"`
@@ -44,6 +49,12 @@ type VM struct {
 	Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
 
 	UserAssignedIdentities []infrav1.UserAssignedIdentity `json:"userAssignedIdentities,omitempty"`
+
+	// PowerState - The power state of the VM, e.g. "running" or "deallocated", which only appears in the instance view.
+	PowerState string `json:"powerState,omitempty"`
+
+	// Hibernated is true if the VM is deallocated after being hibernated, which only appears in the instance view.
+	Hibernated bool `json:"hibernated,omitempty"`
 }
 
 // SDKToVM converts an Azure SDK VirtualMachine to the CAPZ VM type.
@@ -58,6 +69,11 @@ func SDKToVM(v compute.VirtualMachine) *VM {
 		vm.VMSize = string(v.VirtualMachineProperties.HardwareProfile.VMSize)
 	}
 
+	if v.VirtualMachineProperties != nil && v.VirtualMachineProperties.InstanceView != nil {
+		vm.PowerState = sdkInstanceViewStatusesToPowerState(v.VirtualMachineProperties.InstanceView.Statuses)
+		vm.Hibernated = sdkInstanceViewStatusesHaveCode(v.VirtualMachineProperties.InstanceView.Statuses, hibernatedStatusCode)
+	}
+
 	if v.Zones != nil && len(*v.Zones) > 0 {
 		vm.AvailabilityZone = azure.StringSlice(v.Zones)[0]
 	}
@@ -78,3 +94,31 @@ func SDKToVM(v compute.VirtualMachine) *VM {
 
 	return vm
 }
+
+// sdkInstanceViewStatusesHaveCode returns true if one of the instance view statuses has the given code.
+func sdkInstanceViewStatusesHaveCode(statuses *[]compute.InstanceViewStatus, code string) bool {
+	if statuses == nil {
+		return false
+	}
+	for _, status := range *statuses {
+		if strings.EqualFold(pointer.StringDeref(status.Code, ""), code) {
+			return true
+		}
+	}
+	return false
+}
+
+// VMDiskSecurityProfileToSDK converts a CAPZ managed disk security profile to an Azure SDK VMDiskSecurityProfile.
+func VMDiskSecurityProfileToSDK(profile *infrav1.VMDiskSecurityProfile) *compute.VMDiskSecurityProfile {
+	if profile == nil {
+		return nil
+	}
+
+	securityProfile := &compute.VMDiskSecurityProfile{
+		SecurityEncryptionType: compute.SecurityEncryptionTypes(profile.SecurityEncryptionType),
+	}
+	if profile.DiskEncryptionSet != nil {
+		securityProfile.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(profile.DiskEncryptionSet.ID)}
+	}
+	return securityProfile
+}
@@ -66,6 +66,52 @@ func TestSDKToVM(t *testing.T) {
 				VMSize: "Standard_A1",
 			},
 		},
+		{
+			name: "Should convert and populate with power state from the instance view",
+			sdk: compute.VirtualMachine{
+				ID:   pointer.String("test-vm-id"),
+				Name: pointer.String("test-vm-name"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					ProvisioningState: pointer.String("Succeeded"),
+					InstanceView: &compute.VirtualMachineInstanceView{
+						Statuses: &[]compute.InstanceViewStatus{
+							{Code: pointer.String("ProvisioningState/succeeded")},
+							{Code: pointer.String("PowerState/deallocated")},
+						},
+					},
+				},
+			},
+			want: &VM{
+				ID:         "test-vm-id",
+				Name:       "test-vm-name",
+				State:      infrav1.ProvisioningState(compute.ProvisioningStateSucceeded),
+				PowerState: "deallocated",
+			},
+		},
+		{
+			name: "Should convert and populate with hibernation state from the instance view",
+			sdk: compute.VirtualMachine{
+				ID:   pointer.String("test-vm-id"),
+				Name: pointer.String("test-vm-name"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					ProvisioningState: pointer.String("Succeeded"),
+					InstanceView: &compute.VirtualMachineInstanceView{
+						Statuses: &[]compute.InstanceViewStatus{
+							{Code: pointer.String("ProvisioningState/succeeded")},
+							{Code: pointer.String("PowerState/deallocated")},
+							{Code: pointer.String("HibernationState/Hibernated")},
+						},
+					},
+				},
+			},
+			want: &VM{
+				ID:         "test-vm-id",
+				Name:       "test-vm-name",
+				State:      infrav1.ProvisioningState(compute.ProvisioningStateSucceeded),
+				PowerState: "deallocated",
+				Hibernated: true,
+			},
+		},
 		{
 			name: "Should convert and populate with availability zones",
 			sdk: compute.VirtualMachine{
@@ -135,3 +181,47 @@ func TestSDKToVM(t *testing.T) {
 		})
 	}
 }
+
+func TestVMDiskSecurityProfileToSDK(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *infrav1.VMDiskSecurityProfile
+		want    *compute.VMDiskSecurityProfile
+	}{
+		{
+			name:    "nil profile",
+			profile: nil,
+			want:    nil,
+		},
+		{
+			name: "guest state only",
+			profile: &infrav1.VMDiskSecurityProfile{
+				SecurityEncryptionType: infrav1.SecurityEncryptionTypeVMGuestStateOnly,
+			},
+			want: &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypesVMGuestStateOnly,
+			},
+		},
+		{
+			name: "disk with guest state and customer managed key",
+			profile: &infrav1.VMDiskSecurityProfile{
+				SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+				DiskEncryptionSet:      &infrav1.DiskEncryptionSetParameters{ID: "my-des-id"},
+			},
+			want: &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypesDiskWithVMGuestState,
+				DiskEncryptionSet:      &compute.DiskEncryptionSetParameters{ID: pointer.String("my-des-id")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := VMDiskSecurityProfileToSDK(tt.profile)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff between expected result and actual result:\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
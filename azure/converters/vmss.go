@@ -18,6 +18,7 @@ package converters
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"k8s.io/utils/pointer"
@@ -29,8 +30,12 @@ import (
 const (
 	// RegExpStrCommunityGalleryID is a regexp string used for matching community gallery IDs and capturing specific values.
 	RegExpStrCommunityGalleryID = `/CommunityGalleries/(?P<gallery>.*)/Images/(?P<name>.*)/Versions/(?P<version>.*)`
+	// RegExpStrSharedGalleryID is a regexp string used for matching directly shared gallery IDs and capturing specific values.
+	RegExpStrSharedGalleryID = `/SharedGalleries/(?P<gallery>.*)/Images/(?P<name>.*)/Versions/(?P<version>.*)`
 	// RegExpStrComputeGalleryID is a regexp string used for matching compute gallery IDs and capturing specific values.
 	RegExpStrComputeGalleryID = `/subscriptions/(?P<subID>.*)/resourceGroups/(?P<rg>.*)/providers/Microsoft.Compute/galleries/(?P<gallery>.*)/images/(?P<name>.*)/versions/(?P<version>.*)`
+
+	powerStatePrefix = "PowerState/"
 )
 
 // SDKToVMSS converts an Azure SDK VirtualMachineScaleSet to the AzureMachinePool type.
@@ -69,9 +74,48 @@ func SDKToVMSS(sdkvmss compute.VirtualMachineScaleSet, sdkinstances []compute.Vi
 		vmss.Image = SDKImageToImage(imageRef, sdkvmss.Plan != nil)
 	}
 
+	if sdkvmss.VirtualMachineProfile != nil && sdkvmss.VirtualMachineProfile.BillingProfile != nil {
+		// a max price of -1 caps spot instances at the on-demand price, which is the default when no max price is set.
+		if maxPrice := sdkvmss.VirtualMachineProfile.BillingProfile.MaxPrice; maxPrice != nil && *maxPrice != SpotMaxPriceOnDemand {
+			vmss.SpotMaxPrice = pointer.Float64(*maxPrice)
+		}
+	}
+
+	if sdkvmss.ScaleInPolicy != nil && sdkvmss.ScaleInPolicy.Rules != nil && len(*sdkvmss.ScaleInPolicy.Rules) > 0 {
+		vmss.ScaleInPolicy = string((*sdkvmss.ScaleInPolicy.Rules)[0])
+	}
+
+	if sdkvmss.VirtualMachineProfile != nil && sdkvmss.VirtualMachineProfile.StorageProfile != nil {
+		vmss.OSDiskEncryptionSetID, vmss.DataDiskEncryptionSetIDs = sdkStorageProfileToDiskEncryptionSets(sdkvmss.VirtualMachineProfile.StorageProfile)
+	}
+
 	return vmss
 }
 
+// sdkStorageProfileToDiskEncryptionSets returns the lowercased disk encryption set IDs of the OS disk and of each data disk,
+// keyed by LUN, of a scale set storage profile. Azure may return resource IDs with a different casing than the one in the spec.
+func sdkStorageProfileToDiskEncryptionSets(profile *compute.VirtualMachineScaleSetStorageProfile) (string, map[int32]string) {
+	var osDiskEncryptionSetID string
+	if profile.OsDisk != nil && profile.OsDisk.ManagedDisk != nil && profile.OsDisk.ManagedDisk.DiskEncryptionSet != nil {
+		osDiskEncryptionSetID = strings.ToLower(pointer.StringDeref(profile.OsDisk.ManagedDisk.DiskEncryptionSet.ID, ""))
+	}
+
+	var dataDiskEncryptionSetIDs map[int32]string
+	if profile.DataDisks != nil {
+		for _, disk := range *profile.DataDisks {
+			if disk.Lun == nil || disk.ManagedDisk == nil || disk.ManagedDisk.DiskEncryptionSet == nil {
+				continue
+			}
+			if dataDiskEncryptionSetIDs == nil {
+				dataDiskEncryptionSetIDs = make(map[int32]string)
+			}
+			dataDiskEncryptionSetIDs[*disk.Lun] = strings.ToLower(pointer.StringDeref(disk.ManagedDisk.DiskEncryptionSet.ID, ""))
+		}
+	}
+
+	return osDiskEncryptionSetID, dataDiskEncryptionSetIDs
+}
+
 // SDKVMToVMSSVM converts an Azure SDK VM to a VMSS VM.
 func SDKVMToVMSSVM(sdkInstance compute.VirtualMachine, mode infrav1.OrchestrationModeType) *azure.VMSSVM {
 	instance := azure.VMSSVM{
@@ -101,6 +145,10 @@ func SDKVMToVMSSVM(sdkInstance compute.VirtualMachine, mode infrav1.Orchestratio
 		instance.AvailabilityZone = azure.StringSlice(sdkInstance.Zones)[0]
 	}
 
+	if sdkInstance.InstanceView != nil {
+		instance.PowerState = sdkInstanceViewStatusesToPowerState(sdkInstance.InstanceView.Statuses)
+	}
+
 	instance.OrchestrationMode = mode
 
 	return &instance
@@ -135,10 +183,14 @@ func SDKToVMSSVM(sdkInstance compute.VirtualMachineScaleSetVM) *azure.VMSSVM {
 
 	if sdkInstance.Resources != nil {
 		for _, r := range *sdkInstance.Resources {
-			if r.ProvisioningState != nil && r.Name != nil &&
-				(*r.Name == azure.BootstrappingExtensionLinux || *r.Name == azure.BootstrappingExtensionWindows) {
+			if r.ProvisioningState == nil || r.Name == nil {
+				continue
+			}
+			switch *r.Name {
+			case azure.BootstrappingExtensionLinux, azure.BootstrappingExtensionWindows:
 				instance.BootstrappingState = infrav1.ProvisioningState(pointer.StringDeref(r.ProvisioningState, ""))
-				break
+			case azure.GPUDriverExtensionLinux, azure.GPUDriverExtensionWindows:
+				instance.GPUDriverState = infrav1.ProvisioningState(pointer.StringDeref(r.ProvisioningState, ""))
 			}
 		}
 	}
@@ -153,9 +205,29 @@ func SDKToVMSSVM(sdkInstance compute.VirtualMachineScaleSetVM) *azure.VMSSVM {
 		instance.AvailabilityZone = azure.StringSlice(sdkInstance.Zones)[0]
 	}
 
+	if sdkInstance.InstanceView != nil {
+		instance.PowerState = sdkInstanceViewStatusesToPowerState(sdkInstance.InstanceView.Statuses)
+	}
+
 	return &instance
 }
 
+// sdkInstanceViewStatusesToPowerState returns the power state of a VM from the statuses of its instance view,
+// e.g. "running" for the status code "PowerState/running", or "" if there is no power state status.
+func sdkInstanceViewStatusesToPowerState(statuses *[]compute.InstanceViewStatus) string {
+	if statuses == nil {
+		return ""
+	}
+
+	for _, status := range *statuses {
+		if code := pointer.StringDeref(status.Code, ""); strings.HasPrefix(code, powerStatePrefix) {
+			return strings.TrimPrefix(code, powerStatePrefix)
+		}
+	}
+
+	return ""
+}
+
 // SDKImageToImage converts a SDK image reference to infrav1.Image.
 func SDKImageToImage(sdkImageRef *compute.ImageReference, isThirdPartyImage bool) infrav1.Image {
 	if sdkImageRef.ID != nil {
@@ -233,6 +305,16 @@ func cgImageRefToImage(id string) infrav1.Image {
 
 // sgImageRefToImage converts a shared gallery ImageReference to an infrav1.Image.
 func sgImageRefToImage(id string) infrav1.Image {
+	if ok, params := getParams(RegExpStrSharedGalleryID, id); ok {
+		return infrav1.Image{
+			ComputeGallery: &infrav1.AzureComputeGalleryImage{
+				Gallery:      params["gallery"],
+				Name:         params["name"],
+				Version:      params["version"],
+				DirectShared: true,
+			},
+		}
+	}
 	if ok, params := getParams(RegExpStrComputeGalleryID, id); ok {
 		return infrav1.Image{
 			SharedGallery: &infrav1.AzureSharedGalleryImage{
@@ -110,6 +110,103 @@ func Test_SDKToVMSS(t *testing.T) {
 				g.Expect(actual).To(gomega.Equal(&expected))
 			},
 		},
+		{
+			Name: "ShouldPopulateDiskEncryptionSets",
+			SubjectFactory: func(g *gomega.GomegaWithT) (compute.VirtualMachineScaleSet, []compute.VirtualMachineScaleSetVM) {
+				return compute.VirtualMachineScaleSet{
+					ID:   pointer.String("vmssID"),
+					Name: pointer.String("vmssName"),
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+						VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+							StorageProfile: &compute.VirtualMachineScaleSetStorageProfile{
+								OsDisk: &compute.VirtualMachineScaleSetOSDisk{
+									ManagedDisk: &compute.VirtualMachineScaleSetManagedDiskParameters{
+										DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
+											ID: pointer.String("/subscriptions/123/resourceGroups/My-RG/providers/Microsoft.Compute/diskEncryptionSets/os-des"),
+										},
+									},
+								},
+								DataDisks: &[]compute.VirtualMachineScaleSetDataDisk{
+									{
+										Lun: pointer.Int32(0),
+										ManagedDisk: &compute.VirtualMachineScaleSetManagedDiskParameters{
+											DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
+												ID: pointer.String("/subscriptions/123/resourceGroups/My-RG/providers/Microsoft.Compute/diskEncryptionSets/data-des"),
+											},
+										},
+									},
+									{
+										Lun: pointer.Int32(1),
+										ManagedDisk: &compute.VirtualMachineScaleSetManagedDiskParameters{
+											StorageAccountType: compute.StorageAccountTypesPremiumLRS,
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			Expect: func(g *gomega.GomegaWithT, actual *azure.VMSS) {
+				g.Expect(actual.OSDiskEncryptionSetID).To(gomega.Equal("/subscriptions/123/resourcegroups/my-rg/providers/microsoft.compute/diskencryptionsets/os-des"))
+				g.Expect(actual.DataDiskEncryptionSetIDs).To(gomega.Equal(map[int32]string{
+					0: "/subscriptions/123/resourcegroups/my-rg/providers/microsoft.compute/diskencryptionsets/data-des",
+				}))
+			},
+		},
+		{
+			Name: "ShouldPopulateSpotMaxPrice",
+			SubjectFactory: func(g *gomega.GomegaWithT) (compute.VirtualMachineScaleSet, []compute.VirtualMachineScaleSetVM) {
+				return compute.VirtualMachineScaleSet{
+					ID:   pointer.String("vmssID"),
+					Name: pointer.String("vmssName"),
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+						VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+							Priority:       compute.VirtualMachinePriorityTypesSpot,
+							BillingProfile: &compute.BillingProfile{MaxPrice: pointer.Float64(0.5)},
+						},
+					},
+				}, nil
+			},
+			Expect: func(g *gomega.GomegaWithT, actual *azure.VMSS) {
+				g.Expect(actual.SpotMaxPrice).To(gomega.Equal(pointer.Float64(0.5)))
+			},
+		},
+		{
+			Name: "ShouldPopulateScaleInPolicy",
+			SubjectFactory: func(g *gomega.GomegaWithT) (compute.VirtualMachineScaleSet, []compute.VirtualMachineScaleSetVM) {
+				return compute.VirtualMachineScaleSet{
+					ID:   pointer.String("vmssID"),
+					Name: pointer.String("vmssName"),
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+						ScaleInPolicy: &compute.ScaleInPolicy{
+							Rules: &[]compute.VirtualMachineScaleSetScaleInRules{compute.VirtualMachineScaleSetScaleInRulesOldestVM},
+						},
+					},
+				}, nil
+			},
+			Expect: func(g *gomega.GomegaWithT, actual *azure.VMSS) {
+				g.Expect(actual.ScaleInPolicy).To(gomega.Equal("OldestVM"))
+			},
+		},
+		{
+			Name: "ShouldNotPopulateOnDemandSpotMaxPrice",
+			SubjectFactory: func(g *gomega.GomegaWithT) (compute.VirtualMachineScaleSet, []compute.VirtualMachineScaleSetVM) {
+				return compute.VirtualMachineScaleSet{
+					ID:   pointer.String("vmssID"),
+					Name: pointer.String("vmssName"),
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+						VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+							Priority:       compute.VirtualMachinePriorityTypesSpot,
+							BillingProfile: &compute.BillingProfile{MaxPrice: pointer.Float64(-1)},
+						},
+					},
+				}, nil
+			},
+			Expect: func(g *gomega.GomegaWithT, actual *azure.VMSS) {
+				g.Expect(actual.SpotMaxPrice).To(gomega.BeNil())
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -164,6 +261,55 @@ func Test_SDKToVMSSVM(t *testing.T) {
 				State: "Succeeded",
 			},
 		},
+		{
+			Name: "VM with power state",
+			SDKInstance: compute.VirtualMachineScaleSetVM{
+				ID: pointer.String("vm/1"),
+				VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+					ProvisioningState: pointer.String(string(compute.ProvisioningState1Succeeded)),
+					InstanceView: &compute.VirtualMachineScaleSetVMInstanceView{
+						Statuses: &[]compute.InstanceViewStatus{
+							{Code: pointer.String("ProvisioningState/succeeded")},
+							{Code: pointer.String("PowerState/deallocated")},
+						},
+					},
+				},
+			},
+			VMSSVM: &azure.VMSSVM{
+				ID:         "vm/1",
+				State:      "Succeeded",
+				PowerState: "deallocated",
+			},
+		},
+		{
+			Name: "VM with extensions",
+			SDKInstance: compute.VirtualMachineScaleSetVM{
+				ID: pointer.String("vm/1.5"),
+				VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+					ProvisioningState: pointer.String(string(compute.ProvisioningState1Succeeded)),
+				},
+				Resources: &[]compute.VirtualMachineExtension{
+					{
+						Name: pointer.String(azure.GPUDriverExtensionLinux),
+						VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+							ProvisioningState: pointer.String(string(compute.ProvisioningState1Creating)),
+						},
+					},
+					{
+						Name: pointer.String(azure.BootstrappingExtensionLinux),
+						VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+							ProvisioningState: pointer.String(string(compute.ProvisioningState1Succeeded)),
+						},
+					},
+				},
+			},
+			VMSSVM: &azure.VMSSVM{
+				ID:                 "vm/1.5",
+				State:              "Succeeded",
+				BootstrappingState: "Succeeded",
+				GPUDriverState:     "Creating",
+			},
+		},
 		{
 			Name: "VM with storage",
 			SDKInstance: compute.VirtualMachineScaleSetVM{
@@ -267,6 +413,20 @@ func Test_SDKImageToImage(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "directly shared gallery image",
+			SDKImageRef: &compute.ImageReference{
+				SharedGalleryImageID: pointer.String("/SharedGalleries/gallery/Images/image/Versions/version"),
+			},
+			Image: infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{
+					Gallery:      "gallery",
+					Name:         "image",
+					Version:      "version",
+					DirectShared: true,
+				},
+			},
+		},
 		{
 			Name: "community gallery image",
 			SDKImageRef: &compute.ImageReference{
@@ -356,6 +516,26 @@ func Test_SDKVMToVMSSVM(t *testing.T) {
 				AvailabilityZone: "zone0",
 			},
 		},
+		{
+			Name: "VM with power state",
+			Subject: compute.VirtualMachine{
+				ID: pointer.String("vmID5"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					ProvisioningState: pointer.String(string(compute.ProvisioningState1Succeeded)),
+					InstanceView: &compute.VirtualMachineInstanceView{
+						Statuses: &[]compute.InstanceViewStatus{
+							{Code: pointer.String("ProvisioningState/succeeded")},
+							{Code: pointer.String("PowerState/running")},
+						},
+					},
+				},
+			},
+			Expected: &azure.VMSSVM{
+				ID:         "vmID5",
+				State:      "Succeeded",
+				PowerState: "running",
+			},
+		},
 		{
 			Name: "VM with storage",
 			Subject: compute.VirtualMachine{
@@ -24,6 +24,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/go-autorest/autorest"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/throttle"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
@@ -66,6 +68,19 @@ const (
 	BootstrappingExtensionWindows = "CAPZ.Windows.Bootstrapping"
 )
 
+const (
+	// GPUDriverExtensionLinux is the name of the Linux NVIDIA GPU driver VM extension.
+	GPUDriverExtensionLinux = "NvidiaGpuDriverLinux"
+	// GPUDriverExtensionWindows is the name of the Windows NVIDIA GPU driver VM extension.
+	GPUDriverExtensionWindows = "NvidiaGpuDriverWindows"
+	// GPUDriverExtensionPublisher is the publisher of the NVIDIA GPU driver VM extensions.
+	GPUDriverExtensionPublisher = "Microsoft.HpcCompute"
+	// DefaultGPUDriverExtensionLinuxVersion is the default version of the Linux NVIDIA GPU driver VM extension.
+	DefaultGPUDriverExtensionLinuxVersion = "1.6"
+	// DefaultGPUDriverExtensionWindowsVersion is the default version of the Windows NVIDIA GPU driver VM extension.
+	DefaultGPUDriverExtensionWindowsVersion = "1.4"
+)
+
 const (
 	// DefaultWindowsOsAndVersion is the default Windows Server version to use when
 	// genearating default images for Windows nodes.
@@ -130,11 +145,23 @@ func GenerateOutboundBackendAddressPoolName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "outboundBackendPool")
 }
 
+// GenerateInternalAPIServerLBName generates the name of the internal load balancer serving the private frontend IPs
+// of a public API server load balancer.
+func GenerateInternalAPIServerLBName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "internal")
+}
+
 // GenerateFrontendIPConfigName generates a load balancer frontend IP config name.
 func GenerateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
 }
 
+// GenerateIPv6Name generates the name of the IPv6 counterpart of a dual-stack resource, such as a load balancer
+// frontend IP config, backend address pool or public IP.
+func GenerateIPv6Name(name string) string {
+	return fmt.Sprintf("%s-%s", name, "ipv6")
+}
+
 // GenerateNodeOutboundIPName generates a public IP name, based on the cluster name.
 func GenerateNodeOutboundIPName(clusterName string) string {
 	return fmt.Sprintf("pip-%s-node-outbound", clusterName)
@@ -145,6 +172,11 @@ func GenerateNodePublicIPName(machineName string) string {
 	return fmt.Sprintf("pip-%s", machineName)
 }
 
+// GenerateNodePublicIPDNSLabel generates the DNS label of a node public IP, based on a prefix and the machine or scale set name.
+func GenerateNodePublicIPDNSLabel(prefix, name string) string {
+	return fmt.Sprintf("%s-%s", prefix, name)
+}
+
 // GenerateControlPlaneOutboundLBName generates the name of the control plane outbound LB.
 func GenerateControlPlaneOutboundLBName(clusterName string) string {
 	return fmt.Sprintf("%s-outbound-lb", clusterName)
@@ -170,6 +202,11 @@ func GenerateVNetLinkName(vnetName string) string {
 	return fmt.Sprintf("%s-link", vnetName)
 }
 
+// GeneratePrivateDNSZoneGroupName generates the name of the private DNS zone group of a private endpoint.
+func GeneratePrivateDNSZoneGroupName(privateEndpointName string) string {
+	return fmt.Sprintf("%s-dns-zone-group", privateEndpointName)
+}
+
 // GenerateNICName generates the name of a network interface based on the name of a VM.
 func GenerateNICName(machineName string, multiNIC bool, index int) string {
 	if multiNIC {
@@ -246,6 +283,11 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, asgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, asgName)
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
@@ -256,6 +298,11 @@ func NetworkInterfaceID(subscriptionID, resourceGroup, nicName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkInterfaces/%s", subscriptionID, resourceGroup, nicName)
 }
 
+// LoadBalancerID returns the azure resource ID for a given load balancer.
+func LoadBalancerID(subscriptionID, resourceGroup, loadBalancerName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s", subscriptionID, resourceGroup, loadBalancerName)
+}
+
 // FrontendIPConfigID returns the azure resource ID for a given frontend IP config.
 func FrontendIPConfigID(subscriptionID, resourceGroup, loadBalancerName, configName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/frontendIPConfigurations/%s", subscriptionID, resourceGroup, loadBalancerName, configName)
@@ -276,11 +323,21 @@ func NATRuleID(subscriptionID, resourceGroup, loadBalancerName, natRuleName stri
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/inboundNatRules/%s", subscriptionID, resourceGroup, loadBalancerName, natRuleName)
 }
 
+// ApplicationGatewayID returns the azure resource ID for a given application gateway.
+func ApplicationGatewayID(subscriptionID, resourceGroup, gatewayName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationGateways/%s", subscriptionID, resourceGroup, gatewayName)
+}
+
 // AvailabilitySetID returns the azure resource ID for a given availability set.
 func AvailabilitySetID(subscriptionID, resourceGroup, availabilitySetName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/availabilitySets/%s", subscriptionID, resourceGroup, availabilitySetName)
 }
 
+// ProximityPlacementGroupID returns the azure resource ID for a given proximity placement group.
+func ProximityPlacementGroupID(subscriptionID, resourceGroup, proximityPlacementGroupName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/proximityPlacementGroups/%s", subscriptionID, resourceGroup, proximityPlacementGroupName)
+}
+
 // PrivateDNSZoneID returns the azure resource ID for a given private DNS zone.
 func PrivateDNSZoneID(subscriptionID, resourceGroup, privateDNSZoneName string) string {
 	return fmt.Sprintf("subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateDnsZones/%s", subscriptionID, resourceGroup, privateDNSZoneName)
@@ -331,6 +388,36 @@ func GetBootstrappingVMExtension(osType string, cloud string, vmName string) *Ex
 	return nil
 }
 
+// GetGPUDriverVMExtension returns the NVIDIA GPU driver VM extension for the given OS type, or nil if the GPU driver
+// isn't requested.
+func GetGPUDriverVMExtension(gpuDriver *infrav1.GPUDriver, osType string, vmName string) *ExtensionSpec {
+	if gpuDriver == nil {
+		return nil
+	}
+
+	spec := &ExtensionSpec{
+		VMName:    vmName,
+		Publisher: GPUDriverExtensionPublisher,
+		Version:   gpuDriver.Version,
+	}
+	switch osType {
+	case LinuxOS:
+		spec.Name = GPUDriverExtensionLinux
+		if spec.Version == "" {
+			spec.Version = DefaultGPUDriverExtensionLinuxVersion
+		}
+	case WindowsOS:
+		spec.Name = GPUDriverExtensionWindows
+		if spec.Version == "" {
+			spec.Version = DefaultGPUDriverExtensionWindowsVersion
+		}
+	default:
+		return nil
+	}
+
+	return spec
+}
+
 // UserAgent specifies a string to append to the agent identifier.
 func UserAgent() string {
 	return fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String())
@@ -355,6 +442,7 @@ func ARMClientOptions(azureEnvironment string) (*arm.ClientOptions, error) {
 	opts.PerCallPolicies = []policy.Policy{
 		correlationIDPolicy{},
 		userAgentPolicy{},
+		rateLimitPolicy{},
 	}
 	opts.Retry.MaxRetries = -1 // Less than zero means one try and no retries.
 
@@ -365,12 +453,15 @@ func ARMClientOptions(azureEnvironment string) (*arm.ClientOptions, error) {
 // It implements the policy.Policy interface.
 type correlationIDPolicy struct{}
 
-// Do adds the "x-ms-correlation-request-id" header if a request has a correlation ID in its context.
+// Do adds the "x-ms-correlation-request-id" header if a request has a correlation ID in its context, and records the
+// IDs Azure returned for the request on its span.
 func (p correlationIDPolicy) Do(req *policy.Request) (*http.Response, error) {
 	if corrID, ok := tele.CorrIDFromCtx(req.Raw().Context()); ok {
 		req.Raw().Header.Set(string(tele.CorrIDKeyVal), string(corrID))
 	}
-	return req.Next()
+	resp, err := req.Next()
+	tele.RecordAzureResponse(req.Raw().Context(), resp)
+	return resp, err
 }
 
 // userAgentPolicy extends the "User-Agent" header on requests.
@@ -383,13 +474,24 @@ func (p userAgentPolicy) Do(req *policy.Request) (*http.Response, error) {
 	return req.Next()
 }
 
+// rateLimitPolicy records the remaining requests of the subscription of responses.
+// It implements the policy.Policy interface.
+type rateLimitPolicy struct{}
+
+// Do records the remaining requests of the subscription of the response to a request.
+func (p rateLimitPolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	throttle.Observe(resp)
+	return resp, err
+}
+
 // SetAutoRestClientDefaults set authorizer and user agent for autorest client.
 func SetAutoRestClientDefaults(c *autorest.Client, auth autorest.Authorizer) {
 	c.Authorizer = auth
 	// Wrap the original Sender on the autorest.Client c.
 	// The wrapped Sender should set the x-ms-correlation-request-id on the given
 	// request, then pass the new request to the underlying Sender.
-	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator)
+	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator, rateLimitSendDecorator)
 	// The default number of retries is 3. This means the client will attempt to retry operation results like resource
 	// conflicts (HTTP 409). For a reconciling controller, this is undesirable behavior since if the controller runs
 	// into an error reconciling, the controller would be better off to end with an error and try again later.
@@ -405,6 +507,15 @@ func AutoRestClientAppendUserAgent(c *autorest.Client, extension string) {
 	_ = c.AddToUserAgent(extension) // intentionally ignore error as it doesn't matter
 }
 
+// rateLimitSendDecorator records the remaining requests of the subscription of responses.
+func rateLimitSendDecorator(snd autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		resp, err := snd.Do(r)
+		throttle.Observe(resp)
+		return resp, err
+	})
+}
+
 func msCorrelationIDSendDecorator(snd autorest.Sender) autorest.Sender {
 	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
 		// if the correlation ID was found in the request context, set
@@ -412,6 +523,8 @@ func msCorrelationIDSendDecorator(snd autorest.Sender) autorest.Sender {
 		if corrID, ok := tele.CorrIDFromCtx(r.Context()); ok {
 			r.Header.Set(string(tele.CorrIDKeyVal), string(corrID))
 		}
-		return snd.Do(r)
+		resp, err := snd.Do(r)
+		tele.RecordAzureResponse(r.Context(), resp)
+		return resp, err
 	})
 }
@@ -29,6 +29,10 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/throttle"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -77,7 +81,7 @@ func TestARMClientOptions(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(opts.Cloud).To(Equal(tc.expectedCloud))
 			g.Expect(opts.Retry.MaxRetries).To(BeNumerically("==", -1))
-			g.Expect(opts.PerCallPolicies).To(HaveLen(2))
+			g.Expect(opts.PerCallPolicies).To(HaveLen(3))
 		})
 	}
 }
@@ -95,7 +99,7 @@ func TestPerCallPolicies(t *testing.T) {
 
 	opts, err := ARMClientOptions("")
 	g.Expect(err).NotTo(HaveOccurred())
-	g.Expect(opts.PerCallPolicies).To(HaveLen(2))
+	g.Expect(opts.PerCallPolicies).To(HaveLen(3))
 	ctx := context.WithValue(context.Background(), tele.CorrIDKeyVal, tele.CorrID(corrID))
 	req, err := runtime.NewRequest(ctx, http.MethodGet, server.URL)
 	g.Expect(err).NotTo(HaveOccurred())
@@ -106,6 +110,29 @@ func TestPerCallPolicies(t *testing.T) {
 	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
 }
 
+func TestRateLimitPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	subscriptionID := "rate-limit-policy-test"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-ratelimit-remaining-subscription-reads", "1")
+		fmt.Fprintf(w, "Hello, %s", r.Proto)
+	}))
+	defer server.Close()
+
+	opts, err := ARMClientOptions("")
+	g.Expect(err).NotTo(HaveOccurred())
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, server.URL+"/subscriptions/"+subscriptionID+"/resourceGroups/my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	pipeline := defaultTestPipeline(opts.PerCallPolicies)
+	resp, err := pipeline.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+
+	_, throttled := throttle.RequeueAfter(subscriptionID, throttle.DefaultRemainingThreshold)
+	g.Expect(throttled).To(BeTrue())
+}
+
 func defaultTestPipeline(policies []policy.Policy) runtime.Pipeline {
 	return runtime.NewPipeline(
 		"testmodule",
@@ -203,3 +230,33 @@ func TestMSCorrelationIDSendDecorator(t *testing.T) {
 		receivedReq.Header.Get(string(tele.CorrIDKeyVal)),
 	).To(Equal(string(corrID)))
 }
+
+func TestMSCorrelationIDSendDecoratorRecordsResponse(t *testing.T) {
+	g := NewWithT(t)
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "test")
+
+	origSender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		resp.Header.Set(tele.RequestIDKeyVal, "request-id")
+		resp.Header.Set(string(tele.CorrIDKeyVal), "correlation-id")
+		return resp, nil
+	})
+	newSender := autorest.DecorateSender(origSender, msCorrelationIDSendDecorator)
+
+	req, err := http.NewRequest(http.MethodGet, "/abc", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = newSender.Do(req.WithContext(ctx))
+	g.Expect(err).NotTo(HaveOccurred())
+	span.End()
+
+	g.Expect(recorder.Ended()).To(HaveLen(1))
+	events := recorder.Ended()[0].Events()
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0].Attributes).To(ContainElements(
+		attribute.Int("http.status_code", http.StatusOK),
+		attribute.String(tele.RequestIDKeyVal, "request-id"),
+		attribute.String(string(tele.CorrIDKeyVal), "correlation-id"),
+	))
+}
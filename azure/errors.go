@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -48,6 +49,43 @@ func ResourceConflict(err error) bool {
 	return errors.As(err, &derr) && derr.StatusCode == 409
 }
 
+// ErrorCode returns the Azure Resource Manager error code of an error, e.g. "QuotaExceeded" or "SkuNotAvailable", or
+// an empty string if the error doesn't carry one.
+func ErrorCode(err error) string {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.ErrorCode
+	}
+	var reqErr *azureautorest.RequestError
+	if errors.As(err, &reqErr) && reqErr.ServiceError != nil {
+		return reqErr.ServiceError.Code
+	}
+	var serr *azureautorest.ServiceError
+	if errors.As(err, &serr) {
+		return serr.Code
+	}
+	return ""
+}
+
+// ErrorStatusCode returns the HTTP status code of the response to the Azure request which failed with an error, or 0
+// if the error doesn't carry one.
+func ErrorStatusCode(err error) int {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode
+	}
+	derr := autorest.DetailedError{}
+	if errors.As(err, &derr) {
+		if code, ok := derr.StatusCode.(int); ok {
+			return code
+		}
+		if derr.Response != nil {
+			return derr.Response.StatusCode
+		}
+	}
+	return 0
+}
+
 // VMDeletedError is returned when a virtual machine is deleted outside of capz.
 type VMDeletedError struct {
 	ProviderID string
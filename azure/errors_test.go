@@ -2,9 +2,13 @@ package azure
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 )
 
@@ -51,3 +55,81 @@ func TestIsContextDeadlineExceededOrCanceled(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "autorest request error",
+			err: autorest.NewErrorWithError(&azureautorest.RequestError{
+				ServiceError: &azureautorest.ServiceError{Code: "QuotaExceeded"},
+			}, "compute.VirtualMachinesClient", "CreateOrUpdate", &http.Response{StatusCode: http.StatusConflict}, "Failure sending request"),
+			want: "QuotaExceeded",
+		},
+		{
+			name: "wrapped autorest service error",
+			err:  errors.Wrap(&azureautorest.ServiceError{Code: "SkuNotAvailable"}, "failed to create VM"),
+			want: "SkuNotAvailable",
+		},
+		{
+			name: "azcore response error",
+			err:  errors.Wrap(&azcore.ResponseError{ErrorCode: "InvalidResourceReference", StatusCode: http.StatusBadRequest}, "failed to create NAT gateway"),
+			want: "InvalidResourceReference",
+		},
+		{
+			name: "autorest error without service error",
+			err:  autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"),
+			want: "",
+		},
+		{
+			name: "non Azure error",
+			err:  errors.New("dummy error"),
+			want: "",
+		},
+		{
+			name: "nil error",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Errorf("ErrorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "autorest error",
+			err:  errors.Wrap(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusConflict}, "Conflict"), "failed to create VM"),
+			want: http.StatusConflict,
+		},
+		{
+			name: "azcore response error",
+			err:  errors.Wrap(&azcore.ResponseError{ErrorCode: "InvalidResourceReference", StatusCode: http.StatusBadRequest}, "failed to create NAT gateway"),
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "non Azure error",
+			err:  errors.New("dummy error"),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorStatusCode(tt.err); got != tt.want {
+				t.Errorf("ErrorStatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AzureCluster renders the resources of the AzureCluster identified by key to w in the given format.
+// The AzureCluster, its owner Cluster and the credentials of its identity are read with c, so what can be exported is
+// bound by the permissions of the caller.
+func AzureCluster(ctx context.Context, c client.Client, key types.NamespacedName, format Format, w io.Writer) error {
+	switch format {
+	case FormatBicep, FormatTerraform:
+	default:
+		return errors.Errorf("unsupported export format %q", format)
+	}
+
+	azureCluster := &infrav1.AzureCluster{}
+	if err := c.Get(ctx, key, azureCluster); err != nil {
+		return errors.Wrapf(err, "failed to get AzureCluster %s", key)
+	}
+	cluster, err := util.GetOwnerCluster(ctx, c, azureCluster.ObjectMeta)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the owner Cluster of AzureCluster %s", key)
+	}
+	if cluster == nil {
+		return errors.Errorf("AzureCluster %s has no owner Cluster yet", key)
+	}
+
+	// The scope is never closed, so the AzureCluster isn't patched by an export.
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:       c,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create scope")
+	}
+
+	resources, err := ClusterResources(ctx, clusterScope)
+	if err != nil {
+		return err
+	}
+	return Render(w, format, resources)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAzureCluster(t *testing.T) {
+	testcases := []struct {
+		name           string
+		key            types.NamespacedName
+		format         Format
+		expectNotFound bool
+		expectedError  string
+	}{
+		{
+			name:          "unsupported format",
+			key:           types.NamespacedName{Namespace: "default", Name: "my-cluster"},
+			format:        "arm",
+			expectedError: `unsupported export format "arm"`,
+		},
+		{
+			name:           "AzureCluster not found",
+			key:            types.NamespacedName{Namespace: "default", Name: "missing"},
+			format:         FormatBicep,
+			expectNotFound: true,
+		},
+		{
+			name:          "AzureCluster without owner Cluster",
+			key:           types.NamespacedName{Namespace: "default", Name: "my-cluster"},
+			format:        FormatTerraform,
+			expectedError: "AzureCluster default/my-cluster has no owner Cluster yet",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			azureCluster := &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-cluster"},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(azureCluster).Build()
+
+			var buf bytes.Buffer
+			err := AzureCluster(context.TODO(), c, tc.key, tc.format, &buf)
+			g.Expect(err).To(HaveOccurred())
+			if tc.expectNotFound {
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				return
+			}
+			g.Expect(err).To(MatchError(tc.expectedError))
+			g.Expect(buf.Len()).To(BeZero())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// bicepIdentifierRegex matches property names that don't need to be quoted in Bicep.
+var bicepIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+var bicepStringReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", `\${`)
+
+// renderBicep renders the resources as a Bicep template deployed at resource group scope.
+// Resource groups are the deployment scope of the template, so they are only listed in comments.
+func renderBicep(w io.Writer, resources []Resource) error {
+	var b strings.Builder
+	b.WriteString("// Generated by cluster-api-provider-azure for audit purposes.\n")
+	for _, r := range resources {
+		b.WriteString("\n")
+		if r.Type == resourceGroupType {
+			fmt.Fprintf(&b, "// Resource group %q is the deployment scope of this template.\n", r.Name)
+			continue
+		}
+		fmt.Fprintf(&b, "resource %s '%s@%s' = {\n", symbolicName(r), r.Type, r.APIVersion)
+		fmt.Fprintf(&b, "  name: %s\n", bicepString(r.Name))
+		for _, key := range sortedKeys(r.Body) {
+			fmt.Fprintf(&b, "  %s: %s\n", bicepKey(key), bicepValue(r.Body[key], 1))
+		}
+		b.WriteString("}\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func bicepValue(v interface{}, depth int) string {
+	indent := strings.Repeat("  ", depth)
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return "{}"
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range sortedKeys(value) {
+			fmt.Fprintf(&b, "%s  %s: %s\n", indent, bicepKey(key), bicepValue(value[key], depth+1))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	case []interface{}:
+		if len(value) == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, item := range value {
+			fmt.Fprintf(&b, "%s  %s\n", indent, bicepValue(item, depth+1))
+		}
+		b.WriteString(indent + "]")
+		return b.String()
+	case string:
+		return bicepString(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case nil:
+		return "null"
+	default:
+		return bicepString(fmt.Sprint(value))
+	}
+}
+
+func bicepKey(key string) string {
+	if bicepIdentifierRegex.MatchString(key) {
+		return key
+	}
+	return bicepString(key)
+}
+
+func bicepString(s string) string {
+	return "'" + bicepStringReplacer.Replace(s) + "'"
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
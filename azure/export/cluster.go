@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// ClusterSpecGetter returns the specs of the resources reconciled by the AzureCluster controller.
+// It is implemented by scope.ClusterScope.
+type ClusterSpecGetter interface {
+	GroupSpec() azure.ResourceSpecGetter
+	VNetSpec() azure.ResourceSpecGetter
+	ProximityPlacementGroupSpecs() []azure.ResourceSpecGetter
+	ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter
+	NSGSpecs() []azure.ResourceSpecGetter
+	RouteTableSpecs() []azure.ResourceSpecGetter
+	PublicIPSpecs() []azure.ResourceSpecGetter
+	NatGatewaySpecs() []azure.ResourceSpecGetter
+	SubnetSpecs() []azure.ResourceSpecGetter
+	AzureFirewallSpec() azure.ResourceSpecGetter
+	AzureFirewallRouteSpecs() []azure.ResourceSpecGetter
+	VirtualNetworkGatewaySpec() azure.ResourceSpecGetter
+	ApplicationGatewaySpec() azure.ResourceSpecGetter
+	VnetPeeringSpecs() []azure.ResourceSpecGetter
+	LBSpecs() []azure.ResourceSpecGetter
+	APIServerPrivateLinkServiceSpec() azure.ResourceSpecGetter
+	PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter)
+	AzureBastionSpec() azure.ResourceSpecGetter
+	PrivateEndpointSpecs() []azure.ResourceSpecGetter
+	IsVnetManaged() bool
+}
+
+// ClusterResources returns the resources of a cluster in the order the AzureCluster controller reconciles them.
+// Resources that belong to a pre-existing (unmanaged) virtual network are left out since CAPZ doesn't own them.
+func ClusterResources(ctx context.Context, s ClusterSpecGetter) ([]Resource, error) {
+	specs := []azure.ResourceSpecGetter{s.GroupSpec()}
+	vnetManaged := s.IsVnetManaged()
+	if vnetManaged {
+		specs = append(specs, s.VNetSpec())
+	}
+	specs = append(specs, s.ProximityPlacementGroupSpecs()...)
+	specs = append(specs, s.ApplicationSecurityGroupSpecs()...)
+	if vnetManaged {
+		specs = append(specs, s.NSGSpecs()...)
+		specs = append(specs, s.RouteTableSpecs()...)
+	}
+	specs = append(specs, s.PublicIPSpecs()...)
+	if vnetManaged {
+		specs = append(specs, s.NatGatewaySpecs()...)
+		specs = append(specs, s.SubnetSpecs()...)
+	}
+	specs = append(specs, s.AzureFirewallSpec())
+	specs = append(specs, s.AzureFirewallRouteSpecs()...)
+	specs = append(specs, s.VirtualNetworkGatewaySpec())
+	specs = append(specs, s.ApplicationGatewaySpec())
+	specs = append(specs, s.VnetPeeringSpecs()...)
+	specs = append(specs, s.LBSpecs()...)
+	specs = append(specs, s.APIServerPrivateLinkServiceSpec())
+	zone, links, records := s.PrivateDNSSpec()
+	specs = append(specs, zone)
+	specs = append(specs, links...)
+	specs = append(specs, records...)
+	specs = append(specs, s.AzureBastionSpec())
+	specs = append(specs, s.PrivateEndpointSpecs()...)
+
+	return FromSpecs(ctx, specs...)
+}
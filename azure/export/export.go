@@ -0,0 +1,226 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export renders the Azure resources managed by CAPZ as infrastructure-as-code documents
+// (Bicep or Terraform) for audit and disaster recovery documentation purposes.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	networkv20220501 "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// Format is the output format of an export.
+type Format string
+
+const (
+	// FormatBicep renders resources as a Bicep template.
+	FormatBicep Format = "bicep"
+	// FormatTerraform renders resources as Terraform HCL using the azapi provider.
+	FormatTerraform Format = "terraform"
+)
+
+// resourceGroupType is the ARM resource type of a resource group.
+const resourceGroupType = "Microsoft.Resources/resourceGroups"
+
+// resourceTypes maps the SDK types returned by the services' Parameters to their ARM resource type.
+var resourceTypes = map[reflect.Type]string{
+	reflect.TypeOf(resources.Group{}):                         resourceGroupType,
+	reflect.TypeOf(network.VirtualNetwork{}):                  "Microsoft.Network/virtualNetworks",
+	reflect.TypeOf(network.Subnet{}):                          "Microsoft.Network/virtualNetworks/subnets",
+	reflect.TypeOf(network.VirtualNetworkPeering{}):           "Microsoft.Network/virtualNetworks/virtualNetworkPeerings",
+	reflect.TypeOf(network.SecurityGroup{}):                   "Microsoft.Network/networkSecurityGroups",
+	reflect.TypeOf(network.RouteTable{}):                      "Microsoft.Network/routeTables",
+	reflect.TypeOf(network.Route{}):                           "Microsoft.Network/routeTables/routes",
+	reflect.TypeOf(network.ApplicationSecurityGroup{}):        "Microsoft.Network/applicationSecurityGroups",
+	reflect.TypeOf(network.PublicIPAddress{}):                 "Microsoft.Network/publicIPAddresses",
+	reflect.TypeOf(network.NatGateway{}):                      "Microsoft.Network/natGateways",
+	reflect.TypeOf(network.LoadBalancer{}):                    "Microsoft.Network/loadBalancers",
+	reflect.TypeOf(network.InboundNatRule{}):                  "Microsoft.Network/loadBalancers/inboundNatRules",
+	reflect.TypeOf(network.Interface{}):                       "Microsoft.Network/networkInterfaces",
+	reflect.TypeOf(network.BastionHost{}):                     "Microsoft.Network/bastionHosts",
+	reflect.TypeOf(network.AzureFirewall{}):                   "Microsoft.Network/azureFirewalls",
+	reflect.TypeOf(network.VirtualNetworkGateway{}):           "Microsoft.Network/virtualNetworkGateways",
+	reflect.TypeOf(network.ApplicationGateway{}):              "Microsoft.Network/applicationGateways",
+	reflect.TypeOf(network.PrivateLinkService{}):              "Microsoft.Network/privateLinkServices",
+	reflect.TypeOf(networkv20220501.PrivateEndpoint{}):        "Microsoft.Network/privateEndpoints",
+	reflect.TypeOf(privatedns.PrivateZone{}):                  "Microsoft.Network/privateDnsZones",
+	reflect.TypeOf(privatedns.VirtualNetworkLink{}):           "Microsoft.Network/privateDnsZones/virtualNetworkLinks",
+	reflect.TypeOf(compute.VirtualMachine{}):                  "Microsoft.Compute/virtualMachines",
+	reflect.TypeOf(compute.AvailabilitySet{}):                 "Microsoft.Compute/availabilitySets",
+	reflect.TypeOf(compute.ProximityPlacementGroup{}):         "Microsoft.Compute/proximityPlacementGroups",
+	reflect.TypeOf(compute.VirtualMachineExtension{}):         "Microsoft.Compute/virtualMachines/extensions",
+	reflect.TypeOf(compute.VirtualMachineScaleSet{}):          "Microsoft.Compute/virtualMachineScaleSets",
+	reflect.TypeOf(compute.VirtualMachineScaleSetExtension{}): "Microsoft.Compute/virtualMachineScaleSets/extensions",
+}
+
+// apiVersionRegex extracts the API version from the import path of an SDK package, e.g. ".../mgmt/2021-08-01/network".
+var apiVersionRegex = regexp.MustCompile(`/(\d{4}-\d{2}-\d{2}(-preview)?)/`)
+
+// readOnlyProperties are top level properties returned by Parameters that are not part of a resource declaration.
+var readOnlyProperties = []string{"id", "name", "type", "etag"}
+
+// Resource is an Azure resource rendered from a CAPZ resource spec.
+type Resource struct {
+	// Type is the ARM resource type, e.g. Microsoft.Network/virtualNetworks.
+	Type string
+	// APIVersion is the ARM API version the services use for the resource.
+	APIVersion string
+	// Name is the resource name. For subresources it is prefixed with the owner's name, e.g. "vnet/subnet".
+	Name string
+	// ResourceGroup is the resource group of the resource. It is empty for resource groups.
+	ResourceGroup string
+	// Body contains the remaining top level properties of the resource, e.g. location, tags, sku and properties.
+	Body map[string]interface{}
+}
+
+// FromSpec converts a resource spec into a Resource using the same parameters the service would send to Azure
+// when creating the resource. It returns nil if the spec produces no parameters.
+func FromSpec(ctx context.Context, spec azure.ResourceSpecGetter) (*Resource, error) {
+	params, err := spec.Parameters(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get parameters for %s", spec.ResourceName())
+	}
+	if params == nil {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf(params)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	resource := &Resource{
+		Name:          spec.ResourceName(),
+		ResourceGroup: spec.ResourceGroupName(),
+		APIVersion:    apiVersion(t),
+	}
+	if owner := spec.OwnerResourceName(); owner != "" {
+		resource.Name = owner + "/" + resource.Name
+	}
+
+	switch p := params.(type) {
+	case privatedns.RecordSet:
+		// The ARM type of a record set depends on the kind of record it holds.
+		recordType := "A"
+		if p.RecordSetProperties != nil && p.AaaaRecords != nil {
+			recordType = "AAAA"
+		}
+		resource.Type = "Microsoft.Network/privateDnsZones/" + recordType
+	default:
+		var ok bool
+		if resource.Type, ok = resourceTypes[t]; !ok {
+			return nil, errors.Errorf("unsupported resource type %s for %s", t, spec.ResourceName())
+		}
+	}
+	if resource.Type == resourceGroupType {
+		resource.ResourceGroup = ""
+	}
+
+	resource.Body, err = toBody(params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert parameters for %s", spec.ResourceName())
+	}
+
+	return resource, nil
+}
+
+// FromSpecs converts a list of resource specs into Resources, skipping nil specs and specs that produce no parameters.
+func FromSpecs(ctx context.Context, specs ...azure.ResourceSpecGetter) ([]Resource, error) {
+	var result []Resource
+	for _, spec := range specs {
+		if spec == nil || reflect.ValueOf(spec).Kind() == reflect.Ptr && reflect.ValueOf(spec).IsNil() {
+			continue
+		}
+		resource, err := FromSpec(ctx, spec)
+		if err != nil {
+			return nil, err
+		}
+		if resource != nil {
+			result = append(result, *resource)
+		}
+	}
+	return result, nil
+}
+
+// Render writes the resources to w in the given format.
+func Render(w io.Writer, format Format, resources []Resource) error {
+	switch format {
+	case FormatBicep:
+		return renderBicep(w, resources)
+	case FormatTerraform:
+		return renderTerraform(w, resources)
+	default:
+		return errors.Errorf("unsupported export format %q", format)
+	}
+}
+
+// toBody marshals the SDK parameters with their ARM serialization and strips read-only top level properties.
+func toBody(params interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	for _, key := range readOnlyProperties {
+		delete(body, key)
+	}
+	return body, nil
+}
+
+func apiVersion(t reflect.Type) string {
+	if match := apiVersionRegex.FindStringSubmatch(t.PkgPath() + "/"); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// symbolicName returns a name for the resource that is a valid identifier in both Bicep and Terraform.
+func symbolicName(r Resource) string {
+	typeName := r.Type[strings.LastIndex(r.Type, "/")+1:]
+	raw := fmt.Sprintf("%s_%s", typeName, r.Name)
+	var b strings.Builder
+	for i, c := range raw {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+			b.WriteRune(c)
+		case c >= '0' && c <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(c)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+)
+
+var (
+	fakeGroupSpec = &groups.GroupSpec{
+		Name:        "my-rg",
+		Location:    "westus",
+		ClusterName: "my-cluster",
+	}
+	fakeNatGatewaySpec = &natgateways.NatGatewaySpec{
+		Name:           "my-natgateway",
+		ResourceGroup:  "my-rg",
+		SubscriptionID: "123",
+		Location:       "westus",
+		NatGatewayIP:   infrav1.PublicIPSpec{Name: "pip-my-natgateway"},
+		ClusterName:    "my-cluster",
+	}
+	fakeSubnetSpec = &subnets.SubnetSpec{
+		Name:              "my-subnet",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		CIDRs:             []string{"10.0.0.0/16"},
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		IsVNetManaged:     true,
+		Role:              infrav1.SubnetNode,
+	}
+	fakeProximityPlacementGroupSpec = &proximityplacementgroups.ProximityPlacementGroupSpec{
+		Name:          "my-ppg",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeAzureFirewallSpec = &azurefirewalls.AzureFirewallSpec{
+		Name:          "my-firewall",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+		SKUTier:       infrav1.StandardAzureFirewallSKUTier,
+		APIServerPort: 6443,
+	}
+)
+
+func TestFromSpec(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          azure.ResourceSpecGetter
+		expectedType  string
+		expectedName  string
+		expectedGroup string
+		expectedError string
+	}{
+		{
+			name:          "resource group has no resource group",
+			spec:          fakeGroupSpec,
+			expectedType:  "Microsoft.Resources/resourceGroups",
+			expectedName:  "my-rg",
+			expectedGroup: "",
+		},
+		{
+			name:          "top level resource",
+			spec:          fakeNatGatewaySpec,
+			expectedType:  "Microsoft.Network/natGateways",
+			expectedName:  "my-natgateway",
+			expectedGroup: "my-rg",
+		},
+		{
+			name:          "subresource is prefixed with its owner",
+			spec:          fakeSubnetSpec,
+			expectedType:  "Microsoft.Network/virtualNetworks/subnets",
+			expectedName:  "my-vnet/my-subnet",
+			expectedGroup: "my-rg",
+		},
+		{
+			name:          "proximity placement group",
+			spec:          fakeProximityPlacementGroupSpec,
+			expectedType:  "Microsoft.Compute/proximityPlacementGroups",
+			expectedName:  "my-ppg",
+			expectedGroup: "my-rg",
+		},
+		{
+			name:          "azure firewall",
+			spec:          fakeAzureFirewallSpec,
+			expectedType:  "Microsoft.Network/azureFirewalls",
+			expectedName:  "my-firewall",
+			expectedGroup: "my-rg",
+		},
+		{
+			name:          "spec returning an error",
+			spec:          &subnets.SubnetSpec{Name: "custom-subnet"},
+			expectedError: "failed to get parameters for custom-subnet: custom vnet was provided but subnet custom-subnet is missing",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			resource, err := FromSpec(context.TODO(), tc.spec)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(resource.Type).To(Equal(tc.expectedType))
+			g.Expect(resource.Name).To(Equal(tc.expectedName))
+			g.Expect(resource.ResourceGroup).To(Equal(tc.expectedGroup))
+			g.Expect(resource.APIVersion).NotTo(BeEmpty())
+			g.Expect(resource.Body).NotTo(HaveKey("id"))
+			g.Expect(resource.Body).NotTo(HaveKey("name"))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+	resources, err := FromSpecs(context.TODO(), fakeGroupSpec, fakeNatGatewaySpec, fakeSubnetSpec, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resources).To(HaveLen(3))
+
+	testcases := []struct {
+		name          string
+		format        Format
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:   "bicep",
+			format: FormatBicep,
+			expected: []string{
+				"// Resource group \"my-rg\" is the deployment scope of this template.",
+				"resource natGateways_my_natgateway 'Microsoft.Network/natGateways@2021-08-01' = {",
+				"  name: 'my-natgateway'",
+				"  location: 'westus'",
+				"resource subnets_my_vnet_my_subnet 'Microsoft.Network/virtualNetworks/subnets@2021-08-01' = {",
+				"  name: 'my-vnet/my-subnet'",
+				"    addressPrefix: '10.0.0.0/16'",
+				"    'sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster': 'owned'",
+			},
+		},
+		{
+			name:   "terraform",
+			format: FormatTerraform,
+			expected: []string{
+				"variable \"subscription_id\" {",
+				"resource \"azapi_resource\" \"resourceGroups_my_rg\" {",
+				"  parent_id = \"/subscriptions/${var.subscription_id}\"",
+				"  type      = \"Microsoft.Network/virtualNetworks/subnets@2021-08-01\"",
+				"  name      = \"my-subnet\"",
+				"  parent_id = \"/subscriptions/${var.subscription_id}/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet\"",
+				"  location  = \"westus\"",
+				"  body = jsonencode({",
+				"      \"addressPrefix\" = \"10.0.0.0/16\"",
+			},
+		},
+		{
+			name:          "unsupported format",
+			format:        Format("arm"),
+			expectedError: "unsupported export format \"arm\"",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			var buf bytes.Buffer
+			err := Render(&buf, tc.format, resources)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			for _, expected := range tc.expected {
+				g.Expect(buf.String()).To(ContainSubstring(expected))
+			}
+		})
+	}
+}
+
+type fakeClusterSpecGetter struct {
+	vnetManaged bool
+}
+
+func (f fakeClusterSpecGetter) GroupSpec() azure.ResourceSpecGetter { return fakeGroupSpec }
+func (f fakeClusterSpecGetter) VNetSpec() azure.ResourceSpecGetter  { return nil }
+func (f fakeClusterSpecGetter) ProximityPlacementGroupSpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{fakeProximityPlacementGroupSpec}
+}
+func (f fakeClusterSpecGetter) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter { return nil }
+func (f fakeClusterSpecGetter) NSGSpecs() []azure.ResourceSpecGetter {
+	return nil
+}
+func (f fakeClusterSpecGetter) RouteTableSpecs() []azure.ResourceSpecGetter { return nil }
+func (f fakeClusterSpecGetter) PublicIPSpecs() []azure.ResourceSpecGetter   { return nil }
+func (f fakeClusterSpecGetter) NatGatewaySpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{fakeNatGatewaySpec}
+}
+func (f fakeClusterSpecGetter) SubnetSpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{fakeSubnetSpec}
+}
+func (f fakeClusterSpecGetter) AzureFirewallSpec() azure.ResourceSpecGetter {
+	return fakeAzureFirewallSpec
+}
+func (f fakeClusterSpecGetter) AzureFirewallRouteSpecs() []azure.ResourceSpecGetter       { return nil }
+func (f fakeClusterSpecGetter) VirtualNetworkGatewaySpec() azure.ResourceSpecGetter       { return nil }
+func (f fakeClusterSpecGetter) ApplicationGatewaySpec() azure.ResourceSpecGetter          { return nil }
+func (f fakeClusterSpecGetter) VnetPeeringSpecs() []azure.ResourceSpecGetter              { return nil }
+func (f fakeClusterSpecGetter) LBSpecs() []azure.ResourceSpecGetter                       { return nil }
+func (f fakeClusterSpecGetter) APIServerPrivateLinkServiceSpec() azure.ResourceSpecGetter { return nil }
+func (f fakeClusterSpecGetter) PrivateDNSSpec() (azure.ResourceSpecGetter, []azure.ResourceSpecGetter, []azure.ResourceSpecGetter) {
+	return nil, nil, nil
+}
+func (f fakeClusterSpecGetter) AzureBastionSpec() azure.ResourceSpecGetter       { return nil }
+func (f fakeClusterSpecGetter) PrivateEndpointSpecs() []azure.ResourceSpecGetter { return nil }
+func (f fakeClusterSpecGetter) IsVnetManaged() bool                              { return f.vnetManaged }
+
+func TestClusterResources(t *testing.T) {
+	testcases := []struct {
+		name          string
+		vnetManaged   bool
+		expectedNames []string
+	}{
+		{
+			name:          "managed vnet includes network resources",
+			vnetManaged:   true,
+			expectedNames: []string{"my-rg", "my-ppg", "my-natgateway", "my-vnet/my-subnet", "my-firewall"},
+		},
+		{
+			name:          "unmanaged vnet excludes network resources",
+			vnetManaged:   false,
+			expectedNames: []string{"my-rg", "my-ppg", "my-firewall"},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			resources, err := ClusterResources(context.TODO(), fakeClusterSpecGetter{vnetManaged: tc.vnetManaged})
+			g.Expect(err).NotTo(HaveOccurred())
+			names := make([]string, len(resources))
+			for i, r := range resources {
+				names[i] = r.Name
+			}
+			g.Expect(names).To(Equal(tc.expectedNames))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var hclStringReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+
+// terraformAttributes are top level properties rendered as azapi_resource attributes rather than as part of the body.
+var terraformAttributes = map[string]bool{"location": true, "tags": true}
+
+// renderTerraform renders the resources as azapi_resource blocks. The subscription is left as an input variable.
+func renderTerraform(w io.Writer, resources []Resource) error {
+	var b strings.Builder
+	b.WriteString("# Generated by cluster-api-provider-azure for audit purposes.\n\n")
+	b.WriteString("variable \"subscription_id\" {\n  type = string\n}\n")
+	for _, r := range resources {
+		parentID, name := terraformParent(r)
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "resource \"azapi_resource\" %s {\n", hclString(symbolicName(r)))
+		fmt.Fprintf(&b, "  type      = %s\n", hclString(r.Type+"@"+r.APIVersion))
+		fmt.Fprintf(&b, "  name      = %s\n", hclString(name))
+		fmt.Fprintf(&b, "  parent_id = %s\n", parentID)
+		if location, ok := r.Body["location"]; ok {
+			fmt.Fprintf(&b, "  location  = %s\n", hclValue(location, 1))
+		}
+		if tags, ok := r.Body["tags"]; ok {
+			fmt.Fprintf(&b, "  tags      = %s\n", hclValue(tags, 1))
+		}
+		body := map[string]interface{}{}
+		for key, value := range r.Body {
+			if !terraformAttributes[key] {
+				body[key] = value
+			}
+		}
+		if len(body) > 0 {
+			fmt.Fprintf(&b, "  body = jsonencode(%s)\n", hclValue(body, 1))
+		}
+		b.WriteString("}\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// terraformParent returns the parent_id expression and the leaf name of a resource.
+func terraformParent(r Resource) (parentID, name string) {
+	subscription := "/subscriptions/${var.subscription_id}"
+	if r.Type == resourceGroupType {
+		return strconv.Quote(subscription), r.Name
+	}
+
+	parent := fmt.Sprintf("%s/resourceGroups/%s", subscription, r.ResourceGroup)
+	typeSegments := strings.Split(r.Type, "/")
+	nameSegments := strings.Split(r.Name, "/")
+	// A subresource type such as Microsoft.Network/virtualNetworks/subnets has one name segment per type segment
+	// after the provider namespace. Every segment but the last one identifies the parent resource.
+	if len(typeSegments) == len(nameSegments)+1 && len(nameSegments) > 1 {
+		parent += "/providers/" + typeSegments[0]
+		for i := 0; i < len(nameSegments)-1; i++ {
+			parent += fmt.Sprintf("/%s/%s", typeSegments[i+1], nameSegments[i])
+		}
+		name = nameSegments[len(nameSegments)-1]
+	} else {
+		name = r.Name
+	}
+	// The subscription variable is the only interpolation in the parent ID, so the rest of it must be escaped.
+	escaped := hclString(strings.TrimPrefix(parent, subscription))
+	return `"` + subscription + escaped[1:], name
+}
+
+func hclValue(v interface{}, depth int) string {
+	indent := strings.Repeat("  ", depth)
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return "{}"
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range sortedKeys(value) {
+			fmt.Fprintf(&b, "%s  %s = %s\n", indent, hclString(key), hclValue(value[key], depth+1))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	case []interface{}:
+		if len(value) == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, item := range value {
+			fmt.Fprintf(&b, "%s  %s,\n", indent, hclValue(item, depth+1))
+		}
+		b.WriteString(indent + "]")
+		return b.String()
+	case string:
+		return hclString(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case nil:
+		return "null"
+	default:
+		return hclString(fmt.Sprint(value))
+	}
+}
+
+func hclString(s string) string {
+	return `"` + hclStringReplacer.Replace(s) + `"`
+}
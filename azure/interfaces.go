@@ -81,8 +81,10 @@ type ClusterDescriber interface {
 	ExtendedLocationType() string
 	AdditionalTags() infrav1.Tags
 	AvailabilitySetEnabled() bool
+	AvailabilitySetDomainCounts() *infrav1.AvailabilitySetDomainCounts
 	CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides
 	FailureDomains() []string
+	NamingConvention() *infrav1.NamingConvention
 }
 
 // AsyncStatusUpdater is an interface used to keep track of long running operations in Status that has Conditions and Futures.
@@ -593,6 +593,20 @@ func (mr *MockClusterDescriberMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockClusterDescriber)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockClusterDescriber) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockClusterDescriberMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockClusterDescriber)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockClusterDescriber) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -775,6 +789,20 @@ func (mr *MockClusterDescriberMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockClusterDescriber)(nil).Location))
 }
 
+// NamingConvention mocks base method.
+func (m *MockClusterDescriber) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockClusterDescriberMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockClusterDescriber)(nil).NamingConvention))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockClusterDescriber) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -1007,6 +1035,20 @@ func (mr *MockClusterScoperMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockClusterScoper)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockClusterScoper) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockClusterScoperMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockClusterScoper)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockClusterScoper) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -1273,6 +1315,20 @@ func (mr *MockClusterScoperMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockClusterScoper)(nil).Location))
 }
 
+// NamingConvention mocks base method.
+func (m *MockClusterScoper) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockClusterScoperMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockClusterScoper)(nil).NamingConvention))
+}
+
 // NodeSubnets mocks base method.
 func (m *MockClusterScoper) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -1462,6 +1518,20 @@ func (mr *MockManagedClusterScoperMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockManagedClusterScoper)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockManagedClusterScoper) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockManagedClusterScoperMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockManagedClusterScoper)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockManagedClusterScoper) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -1644,6 +1714,20 @@ func (mr *MockManagedClusterScoperMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockManagedClusterScoper)(nil).Location))
 }
 
+// NamingConvention mocks base method.
+func (m *MockManagedClusterScoper) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockManagedClusterScoperMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockManagedClusterScoper)(nil).NamingConvention))
+}
+
 // NodeResourceGroup mocks base method.
 func (m *MockManagedClusterScoper) NodeResourceGroup() string {
 	m.ctrl.T.Helper()
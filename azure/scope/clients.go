@@ -49,6 +49,14 @@ func (c *AzureClients) TenantID() string {
 	return c.Values[auth.TenantID]
 }
 
+// AuxiliaryTenantIDs returns the additional Azure tenant ids the client can get tokens in.
+func (c *AzureClients) AuxiliaryTenantIDs() []string {
+	if v := c.Values[auth.AuxiliaryTenantIDs]; v != "" {
+		return strings.Split(v, ";")
+	}
+	return nil
+}
+
 // ClientID returns the Azure client id from the controller environment.
 func (c *AzureClients) ClientID() string {
 	return c.Values[auth.ClientID]
@@ -126,6 +134,7 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 	c.Values[auth.SubscriptionID] = strings.TrimSuffix(subscriptionID, "\n")
 	c.Values[auth.TenantID] = strings.TrimSuffix(credentialsProvider.GetTenantID(), "\n")
 	c.Values[auth.ClientID] = strings.TrimSuffix(credentialsProvider.GetClientID(), "\n")
+	c.Values[auth.AuxiliaryTenantIDs] = strings.Join(credentialsProvider.GetAuxiliaryTenantIDs(), ";")
 
 	clientSecret, err := credentialsProvider.GetClientSecret(ctx)
 	if err != nil {
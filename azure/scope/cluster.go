@@ -24,25 +24,38 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/pkg/errors"
 	"k8s.io/utils/net"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinks"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -58,6 +71,8 @@ type ClusterScopeParams struct {
 	Cluster      *clusterv1.Cluster
 	AzureCluster *infrav1.AzureCluster
 	Cache        *ClusterCache
+	// ServiceOptions configure how the services reconcile the Azure resources of the cluster.
+	ServiceOptions ServiceOptions
 }
 
 // NewClusterScope creates a new Scope from the supplied parameters.
@@ -98,6 +113,10 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		return nil, errors.Errorf("failed to init patch helper: %v", err)
 	}
 
+	// Resume the long running operations which were in flight before the AzureCluster lost its status,
+	// e.g. when it was moved to another management cluster.
+	futures.Restore(params.AzureCluster)
+
 	return &ClusterScope{
 		Client:       params.Client,
 		AzureClients: params.AzureClients,
@@ -105,6 +124,9 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		AzureCluster: params.AzureCluster,
 		patchHelper:  helper,
 		cache:        params.Cache,
+		mu:           &sync.Mutex{},
+
+		serviceOptions: params.ServiceOptions,
 	}, nil
 }
 
@@ -114,9 +136,20 @@ type ClusterScope struct {
 	patchHelper *patch.Helper
 	cache       *ClusterCache
 
+	serviceOptions ServiceOptions
+
 	AzureClients
 	Cluster      *clusterv1.Cluster
 	AzureCluster *infrav1.AzureCluster
+
+	// driftedResources are the names of the resources which no longer match their spec and were not corrected, by service.
+	driftedResources map[string][]string
+
+	// mu serializes the updates of the AzureCluster status made by the services reconciled concurrently.
+	mu *sync.Mutex
+
+	// notifications are sent once the AzureCluster is patched.
+	notifications notify.Pending
 }
 
 // ClusterCache stores ClusterCache data locally so we don't have to hit the API multiple times within the same reconcile loop.
@@ -176,9 +209,10 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	publicIPSpecs = append(publicIPSpecs, controlPlaneOutboundIPSpecs...)
 
 	// Public IP specs for node outbound lb
-	if s.NodeOutboundLB() != nil {
+	var nodeOutboundIPSpecs []azure.ResourceSpecGetter
+	if s.NodeOutboundLB() != nil && s.NodeOutboundLB().IsDedicated() {
 		for _, ip := range s.NodeOutboundLB().FrontendIPs {
-			publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			nodeOutboundIPSpecs = append(nodeOutboundIPSpecs, &publicips.PublicIPSpec{
 				Name:             ip.PublicIP.Name,
 				ResourceGroup:    s.ResourceGroup(),
 				ClusterName:      s.ClusterName(),
@@ -191,11 +225,35 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 			})
 		}
 	}
+	publicIPSpecs = append(publicIPSpecs, nodeOutboundIPSpecs...)
+
+	// In dual-stack clusters, every load balancer frontend also gets an IPv6 public IP.
+	if s.IsIPv6Enabled() {
+		for _, spec := range append(controlPlaneOutboundIPSpecs, nodeOutboundIPSpecs...) {
+			ipv4Spec := spec.(*publicips.PublicIPSpec)
+			publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+				Name:             azure.GenerateIPv6Name(ipv4Spec.Name),
+				ResourceGroup:    ipv4Spec.ResourceGroup,
+				ClusterName:      ipv4Spec.ClusterName,
+				DNSName:          "", // The DNS name of the cluster points to the IPv4 address
+				IsIPv6:           true,
+				Location:         ipv4Spec.Location,
+				ExtendedLocation: ipv4Spec.ExtendedLocation,
+				FailureDomains:   ipv4Spec.FailureDomains,
+				AdditionalTags:   ipv4Spec.AdditionalTags,
+			})
+		}
+	}
 
 	// Public IP specs for node NAT gateways
 	var nodeNatGatewayIPSpecs []azure.ResourceSpecGetter
 	for _, subnet := range s.NodeSubnets() {
 		if subnet.IsNatGatewayEnabled() {
+			// The public IP of a zonal NAT gateway must be in the same zone.
+			failureDomains := s.FailureDomains()
+			if len(subnet.NatGateway.Zones) > 0 {
+				failureDomains = subnet.NatGateway.Zones
+			}
 			nodeNatGatewayIPSpecs = append(nodeNatGatewayIPSpecs, &publicips.PublicIPSpec{
 				Name:           subnet.NatGateway.NatGatewayIP.Name,
 				ResourceGroup:  s.ResourceGroup(),
@@ -203,7 +261,7 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 				IsIPv6:         false, // Public IP is IPv4 by default
 				ClusterName:    s.ClusterName(),
 				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
+				FailureDomains: failureDomains,
 				AdditionalTags: s.AdditionalTags(),
 				IPTags:         subnet.NatGateway.NatGatewayIP.IPTags,
 			})
@@ -227,73 +285,163 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 		publicIPSpecs = append(publicIPSpecs, azureBastionPublicIP)
 	}
 
+	if azureFirewall := s.AzureFirewall(); azureFirewall != nil {
+		// public IP for Azure Firewall.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           azureFirewall.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        azureFirewall.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.FailureDomains(),
+			AdditionalTags: s.AdditionalTags(),
+			IPTags:         azureFirewall.PublicIP.IPTags,
+		})
+	}
+
+	if gateway := s.VirtualNetworkGateway(); gateway != nil {
+		// public IP for the virtual network gateway.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           gateway.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        gateway.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.FailureDomains(),
+			AdditionalTags: s.AdditionalTags(),
+			IPTags:         gateway.PublicIP.IPTags,
+		})
+	}
+
+	if gateway := s.ApplicationGateway(); gateway != nil {
+		// public IP for the application gateway.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           gateway.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        gateway.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.FailureDomains(),
+			AdditionalTags: s.AdditionalTags(),
+			IPTags:         gateway.PublicIP.IPTags,
+		})
+	}
+
 	return publicIPSpecs
 }
 
 // LBSpecs returns the load balancer specs.
 func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
+	frontendIPs := s.APIServerLB().FrontendIPs
+	if !s.IsAPIServerPrivate() {
+		frontendIPs = s.APIServerLB().PublicFrontendIPs()
+	}
+	// Nodes sharing the API server LB get their own backend pool and outbound rule on it.
+	var nodeOutboundPoolName string
+	if s.isNodeOutboundLBShared() {
+		nodeOutboundPoolName = s.OutboundPoolName(s.APIServerLBName())
+	}
 	specs := []azure.ResourceSpecGetter{
 		&loadbalancers.LBSpec{
 			// API Server LB
-			Name:                 s.APIServerLB().Name,
-			ResourceGroup:        s.ResourceGroup(),
-			SubscriptionID:       s.SubscriptionID(),
-			ClusterName:          s.ClusterName(),
-			Location:             s.Location(),
-			ExtendedLocation:     s.ExtendedLocation(),
-			VNetName:             s.Vnet().Name,
-			VNetResourceGroup:    s.Vnet().ResourceGroup,
-			SubnetName:           s.ControlPlaneSubnet().Name,
-			FrontendIPConfigs:    s.APIServerLB().FrontendIPs,
-			APIServerPort:        s.APIServerPort(),
-			Type:                 s.APIServerLB().Type,
-			SKU:                  s.APIServerLB().SKU,
-			Role:                 infrav1.APIServerRole,
-			BackendPoolName:      s.APIServerLB().BackendPool.Name,
-			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
-			AdditionalTags:       s.AdditionalTags(),
+			Name:                        s.APIServerLB().Name,
+			ResourceGroup:               s.ResourceGroup(),
+			SubscriptionID:              s.SubscriptionID(),
+			ClusterName:                 s.ClusterName(),
+			Location:                    s.Location(),
+			ExtendedLocation:            s.ExtendedLocation(),
+			VNetName:                    s.Vnet().Name,
+			VNetResourceGroup:           s.Vnet().ResourceGroup,
+			SubnetName:                  s.APIServerLBSubnetName(),
+			FrontendIPConfigs:           frontendIPs,
+			APIServerPort:               s.APIServerPort(),
+			Type:                        s.APIServerLB().Type,
+			SKU:                         s.APIServerLB().SKU,
+			Role:                        infrav1.APIServerRole,
+			IPv6Enabled:                 s.IsIPv6Enabled(),
+			BackendPoolName:             s.APIServerLB().BackendPool.Name,
+			IdleTimeoutInMinutes:        s.APIServerLB().IdleTimeoutInMinutes,
+			EnableTCPReset:              s.APIServerLB().EnableTCPReset,
+			AllocatedOutboundPorts:      s.APIServerLB().AllocatedOutboundPorts,
+			NodeOutboundBackendPoolName: nodeOutboundPoolName,
+			AdditionalTags:              s.AdditionalTags(),
 		},
 	}
 
+	// Internal API Server LB serving the private frontend IPs of a public API Server LB
+	if internalLBName := s.APIServerInternalLBName(); internalLBName != "" {
+		specs = append(specs, &loadbalancers.LBSpec{
+			Name:                   internalLBName,
+			ResourceGroup:          s.ResourceGroup(),
+			SubscriptionID:         s.SubscriptionID(),
+			ClusterName:            s.ClusterName(),
+			Location:               s.Location(),
+			ExtendedLocation:       s.ExtendedLocation(),
+			VNetName:               s.Vnet().Name,
+			VNetResourceGroup:      s.Vnet().ResourceGroup,
+			SubnetName:             s.APIServerLBSubnetName(),
+			FrontendIPConfigs:      s.APIServerLB().PrivateFrontendIPs(),
+			APIServerPort:          s.APIServerPort(),
+			Type:                   infrav1.Internal,
+			SKU:                    s.APIServerLB().SKU,
+			Role:                   infrav1.APIServerRole,
+			IPv6Enabled:            s.IsIPv6Enabled(),
+			BackendPoolName:        s.APIServerLBPoolName(internalLBName),
+			IdleTimeoutInMinutes:   s.APIServerLB().IdleTimeoutInMinutes,
+			EnableTCPReset:         s.APIServerLB().EnableTCPReset,
+			AllocatedOutboundPorts: s.APIServerLB().AllocatedOutboundPorts,
+			AdditionalTags:         s.AdditionalTags(),
+		})
+	}
+
 	// Node outbound LB
-	if s.NodeOutboundLB() != nil {
+	if s.NodeOutboundLB() != nil && s.NodeOutboundLB().IsDedicated() {
 		specs = append(specs, &loadbalancers.LBSpec{
-			Name:                 s.NodeOutboundLB().Name,
-			ResourceGroup:        s.ResourceGroup(),
-			SubscriptionID:       s.SubscriptionID(),
-			ClusterName:          s.ClusterName(),
-			Location:             s.Location(),
-			ExtendedLocation:     s.ExtendedLocation(),
-			VNetName:             s.Vnet().Name,
-			VNetResourceGroup:    s.Vnet().ResourceGroup,
-			FrontendIPConfigs:    s.NodeOutboundLB().FrontendIPs,
-			Type:                 s.NodeOutboundLB().Type,
-			SKU:                  s.NodeOutboundLB().SKU,
-			BackendPoolName:      s.NodeOutboundLB().BackendPool.Name,
-			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
-			Role:                 infrav1.NodeOutboundRole,
-			AdditionalTags:       s.AdditionalTags(),
+			Name:                   s.NodeOutboundLB().Name,
+			ResourceGroup:          s.ResourceGroup(),
+			SubscriptionID:         s.SubscriptionID(),
+			ClusterName:            s.ClusterName(),
+			Location:               s.Location(),
+			ExtendedLocation:       s.ExtendedLocation(),
+			VNetName:               s.Vnet().Name,
+			VNetResourceGroup:      s.Vnet().ResourceGroup,
+			FrontendIPConfigs:      s.NodeOutboundLB().FrontendIPs,
+			Type:                   s.NodeOutboundLB().Type,
+			SKU:                    s.NodeOutboundLB().SKU,
+			BackendPoolName:        s.NodeOutboundLB().BackendPool.Name,
+			IdleTimeoutInMinutes:   s.NodeOutboundLB().IdleTimeoutInMinutes,
+			EnableTCPReset:         s.NodeOutboundLB().EnableTCPReset,
+			AllocatedOutboundPorts: s.NodeOutboundLB().AllocatedOutboundPorts,
+			Role:                   infrav1.NodeOutboundRole,
+			IPv6Enabled:            s.IsIPv6Enabled(),
+			AdditionalTags:         s.AdditionalTags(),
 		})
 	}
 
 	// Control Plane Outbound LB
 	if s.ControlPlaneOutboundLB() != nil {
 		specs = append(specs, &loadbalancers.LBSpec{
-			Name:                 s.ControlPlaneOutboundLB().Name,
-			ResourceGroup:        s.ResourceGroup(),
-			SubscriptionID:       s.SubscriptionID(),
-			ClusterName:          s.ClusterName(),
-			Location:             s.Location(),
-			ExtendedLocation:     s.ExtendedLocation(),
-			VNetName:             s.Vnet().Name,
-			VNetResourceGroup:    s.Vnet().ResourceGroup,
-			FrontendIPConfigs:    s.ControlPlaneOutboundLB().FrontendIPs,
-			Type:                 s.ControlPlaneOutboundLB().Type,
-			SKU:                  s.ControlPlaneOutboundLB().SKU,
-			BackendPoolName:      s.ControlPlaneOutboundLB().BackendPool.Name,
-			IdleTimeoutInMinutes: s.ControlPlaneOutboundLB().IdleTimeoutInMinutes,
-			Role:                 infrav1.ControlPlaneOutboundRole,
-			AdditionalTags:       s.AdditionalTags(),
+			Name:                   s.ControlPlaneOutboundLB().Name,
+			ResourceGroup:          s.ResourceGroup(),
+			SubscriptionID:         s.SubscriptionID(),
+			ClusterName:            s.ClusterName(),
+			Location:               s.Location(),
+			ExtendedLocation:       s.ExtendedLocation(),
+			VNetName:               s.Vnet().Name,
+			VNetResourceGroup:      s.Vnet().ResourceGroup,
+			FrontendIPConfigs:      s.ControlPlaneOutboundLB().FrontendIPs,
+			Type:                   s.ControlPlaneOutboundLB().Type,
+			SKU:                    s.ControlPlaneOutboundLB().SKU,
+			BackendPoolName:        s.ControlPlaneOutboundLB().BackendPool.Name,
+			IdleTimeoutInMinutes:   s.ControlPlaneOutboundLB().IdleTimeoutInMinutes,
+			EnableTCPReset:         s.ControlPlaneOutboundLB().EnableTCPReset,
+			AllocatedOutboundPorts: s.ControlPlaneOutboundLB().AllocatedOutboundPorts,
+			Role:                   infrav1.ControlPlaneOutboundRole,
+			IPv6Enabled:            s.IsIPv6Enabled(),
+			AdditionalTags:         s.AdditionalTags(),
 		})
 	}
 
@@ -303,14 +451,33 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 // RouteTableSpecs returns the subnet route tables.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
+	// Route tables of a custom vnet are expected to live alongside it.
+	resourceGroup := s.ResourceGroup()
+	if !s.IsVnetManaged() {
+		resourceGroup = s.Vnet().ResourceGroup
+	}
+	// The default route through a CAPZ-managed Azure Firewall is created by the azurefirewalls service.
+	requireDefaultRoute := s.AzureCluster.Spec.NetworkSpec.IsUserDefinedRouting() && !s.IsAzureFirewallEnabled()
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if subnet.RouteTable.Name != "" {
+			routes := subnet.RouteTable.Routes
+			// Keep the default route through the Azure Firewall when the declared routes replace the routes of the route table.
+			if len(routes) > 0 && subnet.Role == infrav1.SubnetNode && s.IsAzureFirewallEnabled() && s.AzureFirewall().PrivateIPAddress != "" {
+				routes = append(append([]infrav1.Route{}, routes...), infrav1.Route{
+					Name:             s.azureFirewallDefaultRouteName(),
+					AddressPrefix:    "0.0.0.0/0",
+					NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+					NextHopIPAddress: s.AzureFirewall().PrivateIPAddress,
+				})
+			}
 			specs = append(specs, &routetables.RouteTableSpec{
-				Name:           subnet.RouteTable.Name,
-				Location:       s.Location(),
-				ResourceGroup:  s.ResourceGroup(),
-				ClusterName:    s.ClusterName(),
-				AdditionalTags: s.AdditionalTags(),
+				Name:                subnet.RouteTable.Name,
+				Location:            s.Location(),
+				ResourceGroup:       resourceGroup,
+				ClusterName:         s.ClusterName(),
+				AdditionalTags:      s.AdditionalTags(),
+				RequireDefaultRoute: subnet.Role == infrav1.SubnetNode && requireDefaultRoute,
+				Routes:              routes,
 			})
 		}
 	}
@@ -318,6 +485,22 @@ func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	return specs
 }
 
+// ProximityPlacementGroupSpecs returns the proximity placement groups declared on the cluster.
+func (s *ClusterScope) ProximityPlacementGroupSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	for _, ppg := range s.AzureCluster.Spec.ProximityPlacementGroups {
+		specs = append(specs, &proximityplacementgroups.ProximityPlacementGroupSpec{
+			Name:           ppg.Name,
+			Location:       s.Location(),
+			ResourceGroup:  s.ResourceGroup(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+
+	return specs
+}
+
 // NatGatewaySpecs returns the node NAT gateway.
 func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 	natGatewaySet := make(map[string]struct{})
@@ -337,7 +520,10 @@ func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 					NatGatewayIP: infrav1.PublicIPSpec{
 						Name: subnet.NatGateway.NatGatewayIP.Name,
 					},
-					AdditionalTags: s.AdditionalTags(),
+					IdleTimeoutInMinutes: subnet.NatGateway.IdleTimeoutInMinutes,
+					Zones:                subnet.NatGateway.Zones,
+					PublicIPPrefixIDs:    subnet.NatGateway.PublicIPPrefixes,
+					AdditionalTags:       s.AdditionalTags(),
 				})
 			}
 		}
@@ -354,6 +540,7 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 			Name:           subnet.SecurityGroup.Name,
 			SecurityRules:  subnet.SecurityGroup.SecurityRules,
 			ResourceGroup:  s.ResourceGroup(),
+			SubscriptionID: s.SubscriptionID(),
 			Location:       s.Location(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
@@ -363,12 +550,46 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 	return nsgspecs
 }
 
+// ApplicationSecurityGroupSpecs returns the application security groups referenced by the security rules of the subnets.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	names := make(map[string]bool)
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		for _, rule := range subnet.SecurityGroup.SecurityRules {
+			for _, name := range append(append([]string{}, rule.SourceApplicationSecurityGroups...), rule.DestinationApplicationSecurityGroups...) {
+				if names[name] {
+					continue
+				}
+				names[name] = true
+				specs = append(specs, &applicationsecuritygroups.ApplicationSecurityGroupSpec{
+					Name:           name,
+					ResourceGroup:  s.ResourceGroup(),
+					Location:       s.Location(),
+					ClusterName:    s.ClusterName(),
+					AdditionalTags: s.AdditionalTags(),
+				})
+			}
+		}
+	}
+
+	return specs
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
 	if s.IsAzureBastionEnabled() {
 		numberOfSubnets++
 	}
+	if s.IsAzureFirewallEnabled() {
+		numberOfSubnets++
+	}
+	if s.IsVirtualNetworkGatewayEnabled() {
+		numberOfSubnets++
+	}
+	if s.IsApplicationGatewayEnabled() {
+		numberOfSubnets++
+	}
 
 	subnetSpecs := make([]azure.ResourceSpecGetter, 0, numberOfSubnets)
 
@@ -386,6 +607,10 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 			Role:              subnet.Role,
 			NatGatewayName:    subnet.NatGateway.Name,
 			ServiceEndpoints:  subnet.ServiceEndpoints,
+			Delegations:       subnet.Delegations,
+		}
+		if pls := s.APIServerPrivateLinkService(); pls != nil && pls.SubnetName == subnet.Name {
+			subnetSpec.DisablePrivateLinkServiceNetworkPolicies = true
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
 	}
@@ -407,6 +632,53 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if s.IsAzureFirewallEnabled() {
+		azureFirewallSubnet := s.AzureFirewall().Subnet
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              azureFirewallSubnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             azureFirewallSubnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			Role:              azureFirewallSubnet.Role,
+			ServiceEndpoints:  azureFirewallSubnet.ServiceEndpoints,
+		})
+	}
+
+	if s.IsVirtualNetworkGatewayEnabled() {
+		// Azure doesn't support network security groups on the gateway subnet.
+		gatewaySubnet := s.VirtualNetworkGateway().Subnet
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              gatewaySubnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             gatewaySubnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			Role:              gatewaySubnet.Role,
+			ServiceEndpoints:  gatewaySubnet.ServiceEndpoints,
+		})
+	}
+
+	if s.IsApplicationGatewayEnabled() {
+		// The application gateway subnet must only contain application gateways.
+		appGatewaySubnet := s.ApplicationGateway().Subnet
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              appGatewaySubnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             appGatewaySubnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			Role:              appGatewaySubnet.Role,
+			ServiceEndpoints:  appGatewaySubnet.ServiceEndpoints,
+		})
+	}
+
 	return subnetSpecs
 }
 
@@ -417,6 +689,7 @@ func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 		Location:       s.Location(),
 		ClusterName:    s.ClusterName(),
 		AdditionalTags: s.AdditionalTags(),
+		Adopt:          s.AdoptsExistingResources(),
 	}
 }
 
@@ -424,25 +697,31 @@ func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
 	for i, peering := range s.Vnet().Peerings {
+		remoteSubscriptionID := peering.SubscriptionID
+		if remoteSubscriptionID == "" {
+			remoteSubscriptionID = s.SubscriptionID()
+		}
 		forwardPeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:               azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
+			PeeringName:               namer.VnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
 			SourceVnetName:            s.Vnet().Name,
 			SourceResourceGroup:       s.Vnet().ResourceGroup,
 			RemoteVnetName:            peering.RemoteVnetName,
 			RemoteResourceGroup:       peering.ResourceGroup,
 			SubscriptionID:            s.SubscriptionID(),
+			RemoteSubscriptionID:      remoteSubscriptionID,
 			AllowForwardedTraffic:     peering.ForwardPeeringProperties.AllowForwardedTraffic,
 			AllowGatewayTransit:       peering.ForwardPeeringProperties.AllowGatewayTransit,
 			AllowVirtualNetworkAccess: peering.ForwardPeeringProperties.AllowVirtualNetworkAccess,
 			UseRemoteGateways:         peering.ForwardPeeringProperties.UseRemoteGateways,
 		}
 		reversePeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:               azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
+			PeeringName:               namer.VnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
 			SourceVnetName:            peering.RemoteVnetName,
 			SourceResourceGroup:       peering.ResourceGroup,
 			RemoteVnetName:            s.Vnet().Name,
 			RemoteResourceGroup:       s.Vnet().ResourceGroup,
-			SubscriptionID:            s.SubscriptionID(),
+			SubscriptionID:            remoteSubscriptionID,
+			RemoteSubscriptionID:      s.SubscriptionID(),
 			AllowForwardedTraffic:     peering.ReversePeeringProperties.AllowForwardedTraffic,
 			AllowGatewayTransit:       peering.ReversePeeringProperties.AllowGatewayTransit,
 			AllowVirtualNetworkAccess: peering.ReversePeeringProperties.AllowVirtualNetworkAccess,
@@ -465,38 +744,47 @@ func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 		Location:         s.Location(),
 		ClusterName:      s.ClusterName(),
 		AdditionalTags:   s.AdditionalTags(),
+		Adopt:            s.AdoptsExistingResources(),
 	}
 }
 
 // PrivateDNSSpec returns the private dns zone spec.
 func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter) {
-	if s.IsAPIServerPrivate() {
+	if s.IsAPIServerPrivate() && !s.AzureCluster.Spec.NetworkSpec.IsPrivateDNSZoneDisabled() {
+		// The virtual network links and records live alongside the zone, which may be an existing zone in another resource group.
+		resourceGroup := s.ResourceGroup()
+		if s.IsPrivateDNSZoneBYO() {
+			if parsed, err := arm.ParseResourceID(s.AzureCluster.Spec.NetworkSpec.PrivateDNSZone); err == nil {
+				resourceGroup = parsed.ResourceGroupName
+			}
+		}
+
 		zone := privatedns.ZoneSpec{
 			Name:           s.GetPrivateDNSZoneName(),
-			ResourceGroup:  s.ResourceGroup(),
+			ResourceGroup:  resourceGroup,
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
 		}
 
 		links := make([]azure.ResourceSpecGetter, 1+len(s.Vnet().Peerings))
 		links[0] = privatedns.LinkSpec{
-			Name:              azure.GenerateVNetLinkName(s.Vnet().Name),
+			Name:              namer.VNetLinkName(s.Vnet().Name),
 			ZoneName:          s.GetPrivateDNSZoneName(),
 			SubscriptionID:    s.SubscriptionID(),
 			VNetResourceGroup: s.Vnet().ResourceGroup,
 			VNetName:          s.Vnet().Name,
-			ResourceGroup:     s.ResourceGroup(),
+			ResourceGroup:     resourceGroup,
 			ClusterName:       s.ClusterName(),
 			AdditionalTags:    s.AdditionalTags(),
 		}
 		for i, peering := range s.Vnet().Peerings {
 			links[i+1] = privatedns.LinkSpec{
-				Name:              azure.GenerateVNetLinkName(peering.RemoteVnetName),
+				Name:              namer.VNetLinkName(peering.RemoteVnetName),
 				ZoneName:          s.GetPrivateDNSZoneName(),
 				SubscriptionID:    s.SubscriptionID(),
 				VNetResourceGroup: peering.ResourceGroup,
 				VNetName:          peering.RemoteVnetName,
-				ResourceGroup:     s.ResourceGroup(),
+				ResourceGroup:     resourceGroup,
 				ClusterName:       s.ClusterName(),
 				AdditionalTags:    s.AdditionalTags(),
 			}
@@ -509,7 +797,7 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 				IP:       s.APIServerPrivateIP(),
 			},
 			ZoneName:      s.GetPrivateDNSZoneName(),
-			ResourceGroup: s.ResourceGroup(),
+			ResourceGroup: resourceGroup,
 		}
 
 		return zone, links, records
@@ -518,6 +806,11 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 	return nil, nil, nil
 }
 
+// IsPrivateDNSZoneBYO returns true if the cluster uses an existing private DNS zone that isn't managed by CAPZ.
+func (s *ClusterScope) IsPrivateDNSZoneBYO() bool {
+	return s.AzureCluster.Spec.NetworkSpec.IsPrivateDNSZoneBYO()
+}
+
 // IsAzureBastionEnabled returns true if the azure bastion is enabled.
 func (s *ClusterScope) IsAzureBastionEnabled() bool {
 	return s.AzureCluster.Spec.BastionSpec.AzureBastion != nil
@@ -535,27 +828,213 @@ func (s *ClusterScope) AzureBastionSpec() azure.ResourceSpecGetter {
 		publicIPID := azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.AzureBastion().PublicIP.Name)
 
 		return &bastionhosts.AzureBastionSpec{
-			Name:            s.AzureBastion().Name,
-			ResourceGroup:   s.ResourceGroup(),
-			Location:        s.Location(),
-			ClusterName:     s.ClusterName(),
-			SubnetID:        subnetID,
-			PublicIPID:      publicIPID,
-			Sku:             s.AzureBastion().Sku,
-			EnableTunneling: s.AzureBastion().EnableTunneling,
+			Name:                s.AzureBastion().Name,
+			ResourceGroup:       s.ResourceGroup(),
+			Location:            s.Location(),
+			ClusterName:         s.ClusterName(),
+			SubnetID:            subnetID,
+			PublicIPID:          publicIPID,
+			Sku:                 s.AzureBastion().Sku,
+			ScaleUnits:          s.AzureBastion().ScaleUnits,
+			EnableTunneling:     s.AzureBastion().EnableTunneling,
+			EnableIPConnect:     s.AzureBastion().EnableIPConnect,
+			EnableShareableLink: s.AzureBastion().EnableShareableLink,
 		}
 	}
 
 	return nil
 }
 
+// IsAzureFirewallEnabled returns true if the azure firewall is enabled.
+func (s *ClusterScope) IsAzureFirewallEnabled() bool {
+	return s.AzureCluster.Spec.NetworkSpec.AzureFirewall != nil
+}
+
+// AzureFirewall returns the cluster AzureFirewall.
+func (s *ClusterScope) AzureFirewall() *infrav1.AzureFirewall {
+	return s.AzureCluster.Spec.NetworkSpec.AzureFirewall
+}
+
+// AzureFirewallSpec returns the azure firewall spec.
+func (s *ClusterScope) AzureFirewallSpec() azure.ResourceSpecGetter {
+	if !s.IsAzureFirewallEnabled() {
+		return nil
+	}
+
+	var sourceAddresses []string
+	for _, subnet := range s.NodeSubnets() {
+		sourceAddresses = append(sourceAddresses, subnet.CIDRBlocks...)
+	}
+
+	return &azurefirewalls.AzureFirewallSpec{
+		Name:                   s.AzureFirewall().Name,
+		ResourceGroup:          s.ResourceGroup(),
+		Location:               s.Location(),
+		ClusterName:            s.ClusterName(),
+		SubnetID:               azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, s.AzureFirewall().Subnet.Name),
+		PublicIPID:             azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.AzureFirewall().PublicIP.Name),
+		SKUTier:                s.AzureFirewall().SKUTier,
+		SourceAddresses:        sourceAddresses,
+		APIServerPort:          s.APIServerPort(),
+		AdditionalAllowedFQDNs: s.AzureFirewall().AdditionalAllowedFQDNs,
+		AdditionalTags:         s.AdditionalTags(),
+	}
+}
+
+// IsVirtualNetworkGatewayEnabled returns true if the virtual network gateway is enabled.
+func (s *ClusterScope) IsVirtualNetworkGatewayEnabled() bool {
+	return s.AzureCluster.Spec.NetworkSpec.VirtualNetworkGateway != nil
+}
+
+// VirtualNetworkGateway returns the cluster VirtualNetworkGateway.
+func (s *ClusterScope) VirtualNetworkGateway() *infrav1.VirtualNetworkGateway {
+	return s.AzureCluster.Spec.NetworkSpec.VirtualNetworkGateway
+}
+
+// VirtualNetworkGatewaySpec returns the virtual network gateway spec.
+func (s *ClusterScope) VirtualNetworkGatewaySpec() azure.ResourceSpecGetter {
+	if !s.IsVirtualNetworkGatewayEnabled() {
+		return nil
+	}
+
+	return &virtualnetworkgateways.VirtualNetworkGatewaySpec{
+		Name:           s.VirtualNetworkGateway().Name,
+		ResourceGroup:  s.ResourceGroup(),
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		SubnetID:       azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, s.VirtualNetworkGateway().Subnet.Name),
+		PublicIPID:     azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.VirtualNetworkGateway().PublicIP.Name),
+		GatewayType:    s.VirtualNetworkGateway().GatewayType,
+		SKU:            s.VirtualNetworkGateway().SKU,
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
+// APIServerPrivateLinkService returns the private link service of the API server load balancer.
+func (s *ClusterScope) APIServerPrivateLinkService() *infrav1.PrivateLinkService {
+	return s.AzureCluster.Spec.NetworkSpec.APIServerPrivateLinkService
+}
+
+// APIServerPrivateLinkServiceSpec returns the spec of the private link service attached to the API server load balancer.
+func (s *ClusterScope) APIServerPrivateLinkServiceSpec() azure.ResourceSpecGetter {
+	pls := s.APIServerPrivateLinkService()
+	if pls == nil {
+		return nil
+	}
+
+	frontendIPConfigIDs := make([]string, 0, len(s.APIServerLB().FrontendIPs))
+	for _, frontendIP := range s.APIServerLB().FrontendIPs {
+		frontendIPConfigIDs = append(frontendIPConfigIDs, azure.FrontendIPConfigID(s.SubscriptionID(), s.ResourceGroup(), s.APIServerLBName(), frontendIP.Name))
+	}
+
+	return &privatelinks.PrivateLinkServiceSpec{
+		Name:                      pls.Name,
+		ResourceGroup:             s.ResourceGroup(),
+		Location:                  s.Location(),
+		ClusterName:               s.ClusterName(),
+		SubnetID:                  azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, pls.SubnetName),
+		LBFrontendIPConfigIDs:     frontendIPConfigIDs,
+		AllowedSubscriptions:      pls.AllowedSubscriptions,
+		AutoApprovedSubscriptions: pls.AutoApprovedSubscriptions,
+		EnableProxyProtocol:       pls.EnableProxyProtocol,
+		AdditionalTags:            s.AdditionalTags(),
+	}
+}
+
+// SetAPIServerPrivateLinkServiceAlias stores the alias of the private link service of the API server load balancer.
+func (s *ClusterScope) SetAPIServerPrivateLinkServiceAlias(alias string) {
+	if pls := s.APIServerPrivateLinkService(); pls != nil {
+		pls.Alias = alias
+	}
+}
+
+// IsApplicationGatewayEnabled returns true if the application gateway is enabled.
+func (s *ClusterScope) IsApplicationGatewayEnabled() bool {
+	return s.AzureCluster.Spec.ApplicationGateway != nil
+}
+
+// ApplicationGateway returns the cluster ApplicationGateway.
+func (s *ClusterScope) ApplicationGateway() *infrav1.ApplicationGatewaySpec {
+	return s.AzureCluster.Spec.ApplicationGateway
+}
+
+// ApplicationGatewaySpec returns the application gateway spec.
+func (s *ClusterScope) ApplicationGatewaySpec() azure.ResourceSpecGetter {
+	if !s.IsApplicationGatewayEnabled() {
+		return nil
+	}
+
+	gateway := s.ApplicationGateway()
+	return &applicationgateways.ApplicationGatewaySpec{
+		Name:           gateway.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		SubscriptionID: s.SubscriptionID(),
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		SubnetID:       azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, gateway.Subnet.Name),
+		PublicIPID:     azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), gateway.PublicIP.Name),
+		SKUTier:        gateway.SKUTier,
+		FirewallMode:   gateway.FirewallMode,
+		MinCapacity:    gateway.MinCapacity,
+		MaxCapacity:    gateway.MaxCapacity,
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
+// AzureFirewallRouteSpecs returns the default routes sending the egress traffic of the node subnets to the azure firewall.
+func (s *ClusterScope) AzureFirewallRouteSpecs() []azure.ResourceSpecGetter {
+	if !s.IsAzureFirewallEnabled() {
+		return nil
+	}
+
+	// Route tables of a custom vnet are expected to live alongside it.
+	resourceGroup := s.ResourceGroup()
+	if !s.IsVnetManaged() {
+		resourceGroup = s.Vnet().ResourceGroup
+	}
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.NodeSubnets() {
+		if subnet.RouteTable.Name == "" {
+			continue
+		}
+		specs = append(specs, &azurefirewalls.RouteSpec{
+			Name:             s.azureFirewallDefaultRouteName(),
+			ResourceGroup:    resourceGroup,
+			RouteTableName:   subnet.RouteTable.Name,
+			AddressPrefix:    "0.0.0.0/0",
+			NextHopIPAddress: s.AzureFirewall().PrivateIPAddress,
+		})
+	}
+
+	return specs
+}
+
+// azureFirewallDefaultRouteName returns the name of the default route sending node egress traffic to the azure firewall.
+func (s *ClusterScope) azureFirewallDefaultRouteName() string {
+	return fmt.Sprintf("%s-default-route", s.AzureFirewall().Name)
+}
+
+// SetAzureFirewallPrivateIP sets the private IP address of the azure firewall.
+func (s *ClusterScope) SetAzureFirewallPrivateIP(ip string) {
+	if s.IsAzureFirewallEnabled() {
+		s.AzureFirewall().PrivateIPAddress = ip
+	}
+}
+
 // Vnet returns the cluster Vnet.
 func (s *ClusterScope) Vnet() *infrav1.VnetSpec {
 	return &s.AzureCluster.Spec.NetworkSpec.Vnet
 }
 
+// AdoptsExistingResources returns true if the pre-existing resource group and virtual network of the cluster should be
+// adopted when they aren't tagged for any cluster.
+func (s *ClusterScope) AdoptsExistingResources() bool {
+	return infrav1.ReconcilePolicy(s.AzureCluster.Annotations[infrav1.ReconcilePolicyAnnotation]) == infrav1.ReconcilePolicyAdopt
+}
+
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ClusterScope) IsVnetManaged() bool {
+	defer s.lock()()
 	if s.cache.isVnetManaged != nil {
 		return pointer.BoolDeref(s.cache.isVnetManaged, false)
 	}
@@ -653,6 +1132,14 @@ func (s *ClusterScope) APIServerLB() *infrav1.LoadBalancerSpec {
 	return &s.AzureCluster.Spec.NetworkSpec.APIServerLB
 }
 
+// APIServerLBSubnetName returns the name of the subnet the private frontend IPs of the API server LB are allocated from.
+func (s *ClusterScope) APIServerLBSubnetName() string {
+	if s.APIServerLB().SubnetName != "" {
+		return s.APIServerLB().SubnetName
+	}
+	return s.ControlPlaneSubnet().Name
+}
+
 // NodeOutboundLB returns the cluster node outbound load balancer.
 func (s *ClusterScope) NodeOutboundLB() *infrav1.LoadBalancerSpec {
 	return s.AzureCluster.Spec.NetworkSpec.NodeOutboundLB
@@ -668,6 +1155,15 @@ func (s *ClusterScope) APIServerLBName() string {
 	return s.APIServerLB().Name
 }
 
+// APIServerInternalLBName returns the name of the internal LB serving the private frontend IPs of a public API Server LB,
+// or an empty string if the API Server LB has no such frontend IPs.
+func (s *ClusterScope) APIServerInternalLBName() string {
+	if s.IsAPIServerPrivate() || len(s.APIServerLB().PrivateFrontendIPs()) == 0 {
+		return ""
+	}
+	return namer.InternalAPIServerLBName(s.APIServerLBName())
+}
+
 // IsAPIServerPrivate returns true if the API Server LB is of type Internal.
 func (s *ClusterScope) IsAPIServerPrivate() bool {
 	return s.APIServerLB().Type == infrav1.Internal
@@ -685,21 +1181,29 @@ func (s *ClusterScope) APIServerPrivateIP() string {
 
 // GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
 func (s *ClusterScope) GetPrivateDNSZoneName() string {
+	if s.IsPrivateDNSZoneBYO() {
+		if parsed, err := arm.ParseResourceID(s.AzureCluster.Spec.NetworkSpec.PrivateDNSZone); err == nil {
+			return parsed.Name
+		}
+	}
 	if len(s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneName) > 0 {
 		return s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneName
 	}
-	return azure.GeneratePrivateDNSZoneName(s.ClusterName())
+	return namer.PrivateDNSZoneName(s.ClusterName())
 }
 
 // APIServerLBPoolName returns the API Server LB backend pool name.
 func (s *ClusterScope) APIServerLBPoolName(loadBalancerName string) string {
-	return azure.GenerateBackendAddressPoolName(loadBalancerName)
+	return namer.BackendAddressPoolName(loadBalancerName)
 }
 
 // OutboundLBName returns the name of the outbound LB.
 func (s *ClusterScope) OutboundLBName(role string) string {
 	if role == infrav1.Node {
-		if s.NodeOutboundLB() == nil {
+		if s.isNodeOutboundLBShared() {
+			return s.APIServerLBName()
+		}
+		if s.NodeOutboundLB() == nil || !s.NodeOutboundLB().IsDedicated() {
 			return ""
 		}
 		return s.NodeOutboundLB().Name
@@ -713,12 +1217,17 @@ func (s *ClusterScope) OutboundLBName(role string) string {
 	return s.APIServerLBName()
 }
 
+// isNodeOutboundLBShared returns true if node outbound traffic goes through the public API server LB.
+func (s *ClusterScope) isNodeOutboundLBShared() bool {
+	return s.NodeOutboundLB() != nil && s.NodeOutboundLB().IsSharedWithAPIServerLB() && !s.IsAPIServerPrivate()
+}
+
 // OutboundPoolName returns the outbound LB backend pool name.
 func (s *ClusterScope) OutboundPoolName(loadBalancerName string) string {
 	if loadBalancerName == "" {
 		return ""
 	}
-	return azure.GenerateOutboundBackendAddressPoolName(loadBalancerName)
+	return namer.OutboundBackendAddressPoolName(loadBalancerName)
 }
 
 // ResourceGroup returns the cluster resource group.
@@ -746,6 +1255,16 @@ func (s *ClusterScope) AvailabilitySetEnabled() bool {
 	return len(s.AzureCluster.Status.FailureDomains) == 0
 }
 
+// AvailabilitySetDomainCounts returns the default fault and update domain counts of availability sets in the cluster.
+func (s *ClusterScope) AvailabilitySetDomainCounts() *infrav1.AvailabilitySetDomainCounts {
+	return s.AzureCluster.Spec.AvailabilitySetDomainCounts
+}
+
+// NamingConvention returns the naming convention of the default names of the Azure resources in the cluster.
+func (s *ClusterScope) NamingConvention() *infrav1.NamingConvention {
+	return s.AzureCluster.Spec.NamingConvention
+}
+
 // CloudProviderConfigOverrides returns the cloud provider config overrides for the cluster.
 func (s *ClusterScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides {
 	return s.AzureCluster.Spec.CloudProviderConfigOverrides
@@ -806,29 +1325,50 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ClusterScope.PatchObject")
 	defer done()
 
+	futures.SetBlockMoveAnnotation(s.AzureCluster)
+	if err := futures.Checkpoint(s.AzureCluster); err != nil {
+		return err
+	}
 	conditions.SetSummary(s.AzureCluster)
 
-	return s.patchHelper.Patch(
+	if err := s.patchHelper.Patch(
 		ctx,
 		s.AzureCluster,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.RouteTablesReadyCondition,
+			infrav1.ProximityPlacementGroupsReadyCondition,
 			infrav1.NetworkInfrastructureReadyCondition,
 			infrav1.VnetPeeringReadyCondition,
 			infrav1.DisksReadyCondition,
 			infrav1.NATGatewaysReadyCondition,
 			infrav1.LoadBalancersReadyCondition,
 			infrav1.BastionHostReadyCondition,
+			infrav1.AzureFirewallReadyCondition,
+			infrav1.VirtualNetworkGatewayReadyCondition,
+			infrav1.ApplicationGatewayReadyCondition,
+			infrav1.PrivateLinkServiceReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
+			infrav1.ApplicationSecurityGroupsReadyCondition,
+			infrav1.PublicIPsReadyCondition,
 			infrav1.PrivateDNSZoneReadyCondition,
 			infrav1.PrivateDNSLinkReadyCondition,
 			infrav1.PrivateDNSRecordReadyCondition,
 			infrav1.PrivateEndpointsReadyCondition,
-		}})
+			infrav1.IPAddressesClaimedCondition,
+		}}); err != nil {
+		return err
+	}
+	s.notifications.Send(ctx)
+	return nil
+}
+
+// Notify notifies that the AzureCluster reached a lifecycle milestone, once its status is persisted.
+func (s *ClusterScope) Notify(eventType notify.EventType, message string) {
+	s.notifications.Add("AzureCluster", s.AzureCluster, eventType, message)
 }
 
 // Close closes the current scope persisting the cluster configuration and status.
@@ -839,12 +1379,28 @@ func (s *ClusterScope) Close(ctx context.Context) error {
 // AdditionalTags returns AdditionalTags from the scope's AzureCluster.
 func (s *ClusterScope) AdditionalTags() infrav1.Tags {
 	tags := make(infrav1.Tags)
-	if s.AzureCluster.Spec.AdditionalTags != nil {
-		tags = s.AzureCluster.Spec.AdditionalTags.DeepCopy()
-	}
+	// Start with the default tags...
+	tags.Merge(s.serviceOptions.DefaultTags)
+	// ... and merge in the AzureCluster's
+	tags.Merge(s.AzureCluster.Spec.AdditionalTags)
 	return tags
 }
 
+// ServiceOptions returns the options the services reconcile the Azure resources of the AzureCluster with.
+func (s *ClusterScope) ServiceOptions() ServiceOptions {
+	return s.serviceOptions
+}
+
+// AzureServiceReconcileTimeout returns how long the named service may take to reconcile the Azure resources of the AzureCluster.
+func (s *ClusterScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	return s.serviceOptions.Timeouts.AzureServiceReconcileTimeout(serviceName)
+}
+
+// DriftPolicy returns how the Azure resources of the AzureCluster which no longer match their spec are handled.
+func (s *ClusterScope) DriftPolicy() async.DriftPolicy {
+	return s.serviceOptions.DriftPolicy
+}
+
 // APIServerPort returns the APIServerPort to use when creating the load balancer.
 func (s *ClusterScope) APIServerPort() int32 {
 	if s.Cluster.Spec.ClusterNetwork != nil && s.Cluster.Spec.ClusterNetwork.APIServerPort != nil {
@@ -861,6 +1417,17 @@ func (s *ClusterScope) APIServerHost() string {
 	return s.APIServerPublicIP().DNSName
 }
 
+// APIServerEndpoints returns the endpoints of the API server LB frontend IPs, starting with the control plane endpoint.
+func (s *ClusterScope) APIServerEndpoints() []clusterv1.APIEndpoint {
+	endpoints := []clusterv1.APIEndpoint{s.AzureCluster.Spec.ControlPlaneEndpoint}
+	if s.APIServerInternalLBName() != "" {
+		for _, frontendIP := range s.APIServerLB().PrivateFrontendIPs() {
+			endpoints = append(endpoints, clusterv1.APIEndpoint{Host: frontendIP.PrivateIPAddress, Port: s.APIServerPort()})
+		}
+	}
+	return endpoints
+}
+
 // SetFailureDomain will set the spec for a for a given key.
 func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainSpec) {
 	if s.AzureCluster.Status.FailureDomains == nil {
@@ -949,55 +1516,109 @@ func (s *ClusterScope) SetDNSName() {
 	}
 }
 
+// lock locks the scope until the returned function is called. Scopes which were not created with NewClusterScope
+// are not locked.
+func (s *ClusterScope) lock() func() {
+	if s.mu == nil {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// ReportEvent emits an event on the AzureCluster for a change of one of its Azure resources.
+func (s *ClusterScope) ReportEvent(eventType, reason, message string) {
+	record.Emit(s.AzureCluster, eventType, reason, message)
+}
+
 // SetLongRunningOperationState will set the future on the AzureCluster status to allow the resource to continue
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
+	defer s.lock()()
 	futures.Set(s.AzureCluster, future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureCluster status.
 func (s *ClusterScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	defer s.lock()()
 	return futures.Get(s.AzureCluster, name, service, futureType)
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureCluster status.
 func (s *ClusterScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	defer s.lock()()
 	futures.Delete(s.AzureCluster, name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureCluster status after a DELETE operation.
 func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	defer s.lock()()
 	switch {
 	case err == nil:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureCluster, condition, failedReason(err, infrav1.DeletionFailedReason), clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
 }
 
 // UpdatePutStatus updates a condition on the AzureCluster status after a PUT operation.
 func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	defer s.lock()()
 	switch {
+	case err == nil && len(s.driftedResources[service]) > 0:
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DriftDetectedReason, clusterv1.ConditionSeverityWarning, "%s does not match its spec: %s", service, strings.Join(s.driftedResources[service], ", "))
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureCluster, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+	}
+}
+
+// failedReason returns the reason of the condition of a service which failed with err: the Azure Resource Manager error
+// code of err when there is one, e.g. "QuotaExceeded", so that users can tell why a service failed at a glance, or
+// defaultReason otherwise.
+func failedReason(err error, defaultReason string) string {
+	code := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, azure.ErrorCode(err))
+	if code == "" {
+		return defaultReason
 	}
+	return code
+}
+
+// ReportDrift emits an event for a resource which no longer matches its spec, and keeps track of it until the
+// status of its service is updated if it was not corrected.
+func (s *ClusterScope) ReportDrift(serviceName, resourceName string, corrected bool) {
+	defer s.lock()()
+	if corrected {
+		record.Warnf(s.AzureCluster, infrav1.DriftDetectedReason, "%s %s did not match its spec and was updated", serviceName, resourceName)
+		return
+	}
+	record.Warnf(s.AzureCluster, infrav1.DriftDetectedReason, "%s %s does not match its spec", serviceName, resourceName)
+	if s.driftedResources == nil {
+		s.driftedResources = make(map[string][]string)
+	}
+	s.driftedResources[serviceName] = append(s.driftedResources[serviceName], resourceName)
 }
 
 // UpdatePatchStatus updates a condition on the AzureCluster status after a PATCH operation.
 func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	defer s.lock()()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
-		conditions.MarkFalse(s.AzureCluster, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureCluster, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
 
@@ -1038,13 +1659,36 @@ func (s *ClusterScope) SetAnnotation(key, value string) {
 
 // TagsSpecs returns the tag specs for the AzureCluster.
 func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
-	return []azure.TagsSpec{
+	tagsSpecs := []azure.TagsSpec{
 		{
 			Scope:      azure.ResourceGroupID(s.SubscriptionID(), s.ResourceGroup()),
 			Tags:       s.AdditionalTags(),
 			Annotation: azure.RGTagsLastAppliedAnnotation,
 		},
 	}
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(s.SubscriptionID(), []azure.ResourceSpecGetter{s.VNetSpec()}, azure.VNetID, s.AdditionalTags())...)
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(s.SubscriptionID(), s.NSGSpecs(), azure.SecurityGroupID, s.AdditionalTags())...)
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(s.SubscriptionID(), s.RouteTableSpecs(), azure.RouteTableID, s.AdditionalTags())...)
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(s.SubscriptionID(), s.NatGatewaySpecs(), azure.NatGatewayID, s.AdditionalTags())...)
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(s.SubscriptionID(), s.PublicIPSpecs(), azure.PublicIPID, s.AdditionalTags())...)
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(s.SubscriptionID(), s.LBSpecs(), azure.LoadBalancerID, s.AdditionalTags())...)
+	return tagsSpecs
+}
+
+// resourceTagsSpecs returns the tag specs for the resources of the given specs, whose last applied tags are tracked
+// by resource ID in the ResourcesTagsLastAppliedAnnotation.
+func resourceTagsSpecs(subscriptionID string, specs []azure.ResourceSpecGetter, resourceID func(subscriptionID, resourceGroup, name string) string, tags infrav1.Tags) []azure.TagsSpec {
+	tagsSpecs := make([]azure.TagsSpec, 0, len(specs))
+	for _, spec := range specs {
+		id := resourceID(subscriptionID, spec.ResourceGroupName(), spec.ResourceName())
+		tagsSpecs = append(tagsSpecs, azure.TagsSpec{
+			Scope:         id,
+			Tags:          tags,
+			Annotation:    azure.ResourcesTagsLastAppliedAnnotation,
+			AnnotationKey: id,
+		})
+	}
+	return tagsSpecs
 }
 
 // PrivateEndpointSpecs returns the private endpoint specs.
@@ -1083,6 +1727,7 @@ func (s *ClusterScope) getPrivateEndpoints(subnet infrav1.SubnetSpec) []azure.Re
 			ManualApproval:             privateEndpoint.ManualApproval,
 			ClusterName:                s.ClusterName(),
 			AdditionalTags:             s.AdditionalTags(),
+			PrivateDNSZoneIDs:          privateEndpoint.PrivateDNSZoneIDs,
 		}
 
 		for _, privateLinkServiceConnection := range privateEndpoint.PrivateLinkServiceConnections {
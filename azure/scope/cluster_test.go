@@ -18,29 +18,40 @@ package scope
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -642,6 +653,93 @@ func TestPublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Dual-stack Azure cluster with public type apiserver LB and public node outbound lb",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "cluster.x-k8s.io/v1beta1",
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							VnetClassSpec: infrav1.VnetClassSpec{
+								CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-my-cluster-node-outbound",
+									},
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:    "pip-my-cluster-apiserver",
+										DNSName: "fake-dns",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "pip-my-cluster-apiserver",
+					ResourceGroup:  "my-rg",
+					DNSName:        "fake-dns",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-my-cluster-node-outbound",
+					ResourceGroup:  "my-rg",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-my-cluster-apiserver-ipv6",
+					ResourceGroup:  "my-rg",
+					IsIPv6:         true,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-my-cluster-node-outbound-ipv6",
+					ResourceGroup:  "my-rg",
+					IsIPv6:         true,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+			},
+		},
 		{
 			name: "Azure cluster with public type apiserver LB and public node outbound lb, NAT gateways and bastions",
 			azureCluster: &infrav1.AzureCluster{
@@ -850,6 +948,166 @@ func TestRouteTableSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "requires a default route on node route tables of a custom vnet with user defined routing",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								OutboundType: infrav1.UserDefinedRoutingOutboundType,
+							},
+							Vnet: infrav1.VnetSpec{
+								ID:            "fake-vnet-id",
+								Name:          "my-vnet",
+								ResourceGroup: "my-vnet-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetControlPlane,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-cp-route-table",
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-node-route-table",
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "fake-cp-route-table",
+					ResourceGroup:  "my-vnet-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&routetables.RouteTableSpec{
+					Name:                "fake-node-route-table",
+					ResourceGroup:       "my-vnet-rg",
+					Location:            "centralIndia",
+					ClusterName:         "my-cluster",
+					AdditionalTags:      make(infrav1.Tags),
+					RequireDefaultRoute: true,
+				},
+			},
+		},
+		{
+			name: "keeps the azure firewall default route on node route tables with declared routes",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								OutboundType: infrav1.UserDefinedRoutingOutboundType,
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetControlPlane,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-cp-route-table",
+										Routes: []infrav1.Route{
+											{
+												Name:          "on-premises",
+												AddressPrefix: "192.168.0.0/16",
+												NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+											},
+										},
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-node-route-table",
+										Routes: []infrav1.Route{
+											{
+												Name:          "on-premises",
+												AddressPrefix: "192.168.0.0/16",
+												NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+											},
+										},
+									},
+								},
+							},
+							AzureFirewall: &infrav1.AzureFirewall{
+								Name:             "my-firewall",
+								PrivateIPAddress: "10.255.255.4",
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "fake-cp-route-table",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+					Routes: []infrav1.Route{
+						{
+							Name:          "on-premises",
+							AddressPrefix: "192.168.0.0/16",
+							NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+						},
+					},
+				},
+				&routetables.RouteTableSpec{
+					Name:           "fake-node-route-table",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+					Routes: []infrav1.Route{
+						{
+							Name:          "on-premises",
+							AddressPrefix: "192.168.0.0/16",
+							NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+						},
+						{
+							Name:             "my-firewall-default-route",
+							AddressPrefix:    "0.0.0.0/0",
+							NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+							NextHopIPAddress: "10.255.255.4",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -863,6 +1121,103 @@ func TestRouteTableSpecs(t *testing.T) {
 	}
 }
 
+func TestAzureFirewallRouteSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no firewall is specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: nil,
+		},
+		{
+			name: "returns a default route for each node route table",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							AzureFirewall: &infrav1.AzureFirewall{
+								Name:             "my-firewall",
+								PrivateIPAddress: "10.255.255.132",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetControlPlane,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-cp-route-table",
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-node-route-table-1",
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										Name: "fake-node-route-table-2",
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&azurefirewalls.RouteSpec{
+					Name:             "my-firewall-default-route",
+					ResourceGroup:    "my-rg",
+					RouteTableName:   "fake-node-route-table-1",
+					AddressPrefix:    "0.0.0.0/0",
+					NextHopIPAddress: "10.255.255.132",
+				},
+				&azurefirewalls.RouteSpec{
+					Name:             "my-firewall-default-route",
+					ResourceGroup:    "my-rg",
+					RouteTableName:   "fake-node-route-table-2",
+					AddressPrefix:    "0.0.0.0/0",
+					NextHopIPAddress: "10.255.255.132",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.AzureFirewallRouteSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AzureFirewallRouteSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
 func TestNatGatewaySpecs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1192,19 +1547,130 @@ func TestNSGSpecs(t *testing.T) {
 	}
 }
 
-func TestSubnetSpecs(t *testing.T) {
+func TestApplicationSecurityGroupSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
 		clusterScope ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
-			name: "returns empty if no subnets are specified",
+			name: "returns empty if no security rules reference application security groups",
 			clusterScope: ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
-							Subnets: infrav1.Subnets{},
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											SecurityRules: infrav1.SecurityRules{
+												{
+													Name: "fake-rule-1",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns the application security groups referenced by the security rules once",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-1",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											SecurityRules: infrav1.SecurityRules{
+												{
+													Name:                                 "fake-rule-1",
+													SourceApplicationSecurityGroups:      []string{"frontend"},
+													DestinationApplicationSecurityGroups: []string{"backend"},
+												},
+											},
+										},
+									},
+								},
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "fake-security-group-2",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											SecurityRules: infrav1.SecurityRules{
+												{
+													Name:                                 "fake-rule-2",
+													DestinationApplicationSecurityGroups: []string{"backend"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&applicationsecuritygroups.ApplicationSecurityGroupSpec{
+					Name:           "frontend",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&applicationsecuritygroups.ApplicationSecurityGroupSpec{
+					Name:           "backend",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.ApplicationSecurityGroupSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplicationSecurityGroupSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestSubnetSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns empty if no subnets are specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{},
 						},
 					},
 				},
@@ -1595,6 +2061,9 @@ func TestAzureBastionSpec(t *testing.T) {
 								PublicIP: infrav1.PublicIPSpec{
 									Name: "fake-public-ip-1",
 								},
+								Sku:             infrav1.StandardBastionHostSku,
+								ScaleUnits:      pointer.Int32(4),
+								EnableIPConnect: true,
 							},
 						},
 						ResourceGroup: "my-rg",
@@ -1649,6 +2118,9 @@ func TestAzureBastionSpec(t *testing.T) {
 					"virtualNetworks/%s/subnets/%s", "123", "my-rg", "fake-vnet-1", "fake-bastion-subnet-1"),
 				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
 					"publicIPAddresses/%s", "123", "my-rg", "fake-public-ip-1"),
+				Sku:             infrav1.StandardBastionHostSku,
+				ScaleUnits:      pointer.Int32(4),
+				EnableIPConnect: true,
 			},
 		},
 	}
@@ -1664,6 +2136,283 @@ func TestAzureBastionSpec(t *testing.T) {
 	}
 }
 
+func TestVirtualNetworkGatewaySpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no gateway is specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns gateway spec if enabled",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "fake-vnet-1",
+								ResourceGroup: "my-rg-vnet",
+							},
+							VirtualNetworkGateway: &infrav1.VirtualNetworkGateway{
+								Name: "my-gateway",
+								Subnet: infrav1.SubnetSpec{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetGateway,
+										CIDRBlocks: []string{infrav1.DefaultVirtualNetworkGatewaySubnetCIDR},
+										Name:       infrav1.DefaultVirtualNetworkGatewaySubnetName,
+									},
+								},
+								PublicIP: infrav1.PublicIPSpec{
+									Name: "my-gateway-pip",
+								},
+								GatewayType: infrav1.ExpressRouteVirtualNetworkGatewayType,
+								SKU:         "ErGw1AZ",
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: &virtualnetworkgateways.VirtualNetworkGatewaySpec{
+				Name:          "my-gateway",
+				ResourceGroup: "my-rg",
+				Location:      "centralIndia",
+				ClusterName:   "my-cluster",
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "GatewaySubnet"),
+				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"publicIPAddresses/%s", "123", "my-rg", "my-gateway-pip"),
+				GatewayType:    infrav1.ExpressRouteVirtualNetworkGatewayType,
+				SKU:            "ErGw1AZ",
+				AdditionalTags: infrav1.Tags{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.VirtualNetworkGatewaySpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VirtualNetworkGatewaySpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestAPIServerPrivateLinkServiceSpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no private link service is specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns private link service spec if enabled",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "fake-vnet-1",
+								ResourceGroup: "my-rg-vnet",
+							},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								Name: "my-cluster-internal-lb",
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										Name: "my-cluster-internal-lb-frontEnd",
+									},
+								},
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Internal,
+								},
+							},
+							APIServerPrivateLinkService: &infrav1.PrivateLinkService{
+								Name:                      "my-cluster-apiserver-pls",
+								SubnetName:                "my-cp-subnet",
+								AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001"},
+								AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+								EnableProxyProtocol:       pointer.Bool(true),
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: &privatelinks.PrivateLinkServiceSpec{
+				Name:          "my-cluster-apiserver-pls",
+				ResourceGroup: "my-rg",
+				Location:      "centralIndia",
+				ClusterName:   "my-cluster",
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "my-cp-subnet"),
+				LBFrontendIPConfigIDs: []string{
+					fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+						"loadBalancers/%s/frontendIPConfigurations/%s", "123", "my-rg", "my-cluster-internal-lb", "my-cluster-internal-lb-frontEnd"),
+				},
+				AllowedSubscriptions:      []string{"00000000-0000-0000-0000-000000000001"},
+				AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000001"},
+				EnableProxyProtocol:       pointer.Bool(true),
+				AdditionalTags:            infrav1.Tags{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.APIServerPrivateLinkServiceSpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("APIServerPrivateLinkServiceSpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestApplicationGatewaySpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no gateway is specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns gateway spec if enabled",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "fake-vnet-1",
+								ResourceGroup: "my-rg-vnet",
+							},
+						},
+						ApplicationGateway: &infrav1.ApplicationGatewaySpec{
+							Name: "my-appgw",
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       infrav1.SubnetApplicationGateway,
+									CIDRBlocks: []string{infrav1.DefaultApplicationGatewaySubnetCIDR},
+									Name:       infrav1.DefaultApplicationGatewaySubnetName,
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name: "my-appgw-pip",
+							},
+							SKUTier:      infrav1.WAFV2ApplicationGatewaySKUTier,
+							FirewallMode: infrav1.PreventionApplicationGatewayFirewallMode,
+							MaxCapacity:  pointer.Int32(10),
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: &applicationgateways.ApplicationGatewaySpec{
+				Name:           "my-appgw",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				Location:       "centralIndia",
+				ClusterName:    "my-cluster",
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "fake-vnet-1", "ApplicationGatewaySubnet"),
+				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"publicIPAddresses/%s", "123", "my-rg", "my-appgw-pip"),
+				SKUTier:        infrav1.WAFV2ApplicationGatewaySKUTier,
+				FirewallMode:   infrav1.PreventionApplicationGatewayFirewallMode,
+				MaxCapacity:    pointer.Int32(10),
+				AdditionalTags: infrav1.Tags{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.ApplicationGatewaySpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplicationGatewaySpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
 func TestSubnet(t *testing.T) {
 	tests := []struct {
 		clusterName             string
@@ -1877,6 +2626,15 @@ func TestGetPrivateDNSZoneName(t *testing.T) {
 			clusterName:              "my-cluster-2",
 			expectPrivateDNSZoneName: "my-cluster-2.capz.io",
 		},
+		{
+			clusterName: "my-cluster-3",
+			azureClusterNetworkSpec: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZone: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private",
+				},
+			},
+			expectPrivateDNSZoneName: "example.private",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.clusterName, func(t *testing.T) {
@@ -1927,6 +2685,136 @@ func TestGetPrivateDNSZoneName(t *testing.T) {
 	}
 }
 
+func TestPrivateDNSSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		privateDNS    string
+		lbType        infrav1.LBType
+		wantZone      azure.ResourceSpecGetter
+		wantLinks     []azure.ResourceSpecGetter
+		wantRecords   []azure.ResourceSpecGetter
+		wantZoneIsNil bool
+	}{
+		{
+			name:          "returns nil for a public cluster",
+			lbType:        infrav1.Public,
+			wantZoneIsNil: true,
+		},
+		{
+			name:          "returns nil when the private DNS zone is disabled",
+			privateDNS:    infrav1.PrivateDNSZoneModeNone,
+			lbType:        infrav1.Internal,
+			wantZoneIsNil: true,
+		},
+		{
+			name:   "returns a zone managed by capz",
+			lbType: infrav1.Internal,
+			wantZone: privatedns.ZoneSpec{
+				Name:           "my-cluster.capz.io",
+				ResourceGroup:  "my-rg",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{},
+			},
+			wantLinks: []azure.ResourceSpecGetter{
+				privatedns.LinkSpec{
+					Name:              "my-vnet-link",
+					ZoneName:          "my-cluster.capz.io",
+					VNetResourceGroup: "my-vnet-rg",
+					VNetName:          "my-vnet",
+					ResourceGroup:     "my-rg",
+					ClusterName:       "my-cluster",
+					AdditionalTags:    infrav1.Tags{},
+				},
+			},
+			wantRecords: []azure.ResourceSpecGetter{
+				privatedns.RecordSpec{
+					Record:        infrav1.AddressRecord{Hostname: azure.PrivateAPIServerHostname, IP: "10.0.0.100"},
+					ZoneName:      "my-cluster.capz.io",
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
+		{
+			name:       "returns an existing zone in its own resource group",
+			privateDNS: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.private",
+			lbType:     infrav1.Internal,
+			wantZone: privatedns.ZoneSpec{
+				Name:           "example.private",
+				ResourceGroup:  "dns-rg",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{},
+			},
+			wantLinks: []azure.ResourceSpecGetter{
+				privatedns.LinkSpec{
+					Name:              "my-vnet-link",
+					ZoneName:          "example.private",
+					VNetResourceGroup: "my-vnet-rg",
+					VNetName:          "my-vnet",
+					ResourceGroup:     "dns-rg",
+					ClusterName:       "my-cluster",
+					AdditionalTags:    infrav1.Tags{},
+				},
+			},
+			wantRecords: []azure.ResourceSpecGetter{
+				privatedns.RecordSpec{
+					Record:        infrav1.AddressRecord{Hostname: azure.PrivateAPIServerHostname, IP: "10.0.0.100"},
+					ZoneName:      "example.private",
+					ResourceGroup: "dns-rg",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								PrivateDNSZone: tc.privateDNS,
+							},
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-vnet-rg",
+							},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										FrontendIPClass: infrav1.FrontendIPClass{
+											PrivateIPAddress: "10.0.0.100",
+										},
+									},
+								},
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: tc.lbType,
+								},
+							},
+						},
+					},
+				},
+			}
+			zone, links, records := clusterScope.PrivateDNSSpec()
+			if tc.wantZoneIsNil {
+				g.Expect(zone).To(BeNil())
+				g.Expect(links).To(BeNil())
+				g.Expect(records).To(BeNil())
+				return
+			}
+			g.Expect(zone).To(Equal(tc.wantZone))
+			g.Expect(links).To(Equal(tc.wantLinks))
+			g.Expect(records).To(Equal(tc.wantRecords))
+		})
+	}
+}
+
 func TestAPIServerLBPoolName(t *testing.T) {
 	tests := []struct {
 		lbName           string
@@ -2014,6 +2902,26 @@ func TestOutboundLBName(t *testing.T) {
 			role:        "control-plane",
 			expected:    "my-cluster-public-lb",
 		},
+		{
+			clusterName: "my-cluster",
+			name:        "public cluster node outbound lb shared with the api server lb",
+			role:        "node",
+			nodeOutboundLB: &infrav1.LoadBalancerSpec{
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+					Mode: infrav1.NodeOutboundLBModeShared,
+				}},
+			expected: "my-cluster-public-lb",
+		},
+		{
+			clusterName: "my-cluster",
+			name:        "public cluster node outbound lb disabled",
+			role:        "node",
+			nodeOutboundLB: &infrav1.LoadBalancerSpec{
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+					Mode: infrav1.NodeOutboundLBModeDisabled,
+				}},
+			expected: "",
+		},
 		{
 			clusterName:    "my-cluster",
 			name:           "private cluster with node outbound lb",
@@ -2429,6 +3337,7 @@ func TestAdditionalTags(t *testing.T) {
 	tests := []struct {
 		name                       string
 		clusterName                string
+		defaultTags                infrav1.Tags
 		azureClusterAdditionalTags infrav1.Tags
 		expectTags                 infrav1.Tags
 	}{
@@ -2462,6 +3371,21 @@ func TestAdditionalTags(t *testing.T) {
 				"fake-id-3": "fake-value-3",
 			},
 		},
+		{
+			name:        "Default tags are merged beneath the tags of the azure cluster spec",
+			clusterName: "my-cluster",
+			defaultTags: infrav1.Tags{
+				"costCenter": "1234",
+				"owner":      "team-a",
+			},
+			azureClusterAdditionalTags: infrav1.Tags{
+				"owner": "team-b",
+			},
+			expectTags: infrav1.Tags{
+				"costCenter": "1234",
+				"owner":      "team-b",
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2501,9 +3425,10 @@ func TestAdditionalTags(t *testing.T) {
 				AzureClients: AzureClients{
 					Authorizer: autorest.NullAuthorizer{},
 				},
-				Cluster:      cluster,
-				AzureCluster: azureCluster,
-				Client:       fakeClient,
+				Cluster:        cluster,
+				AzureCluster:   azureCluster,
+				Client:         fakeClient,
+				ServiceOptions: ServiceOptions{DefaultTags: tc.defaultTags},
 			})
 			g.Expect(err).NotTo(HaveOccurred())
 			got := clusterScope.AdditionalTags()
@@ -2683,16 +3608,252 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 		want         []azure.ResourceSpecGetter
 	}{
 		{
-			name: "API Server LB, Control Plane Oubound LB, and Node Outbound LB",
+			name: "API Server LB, Control Plane Oubound LB, and Node Outbound LB",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						AdditionalTags: infrav1.Tags{
+							"foo": "bar",
+						},
+						SubscriptionID: "123",
+						Location:       "westus2",
+					},
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "node-subnet",
+									Role: infrav1.SubnetNode,
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "api-server-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "api-server-lb-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type:                 infrav1.Public,
+								IdleTimeoutInMinutes: pointer.Int32(30),
+								SKU:                  infrav1.SKUStandard,
+							},
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "api-server-lb-frontend-ip",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "api-server-lb-frontend-ip",
+									},
+								},
+							},
+						},
+						ControlPlaneOutboundLB: &infrav1.LoadBalancerSpec{
+							Name: "cp-outbound-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "cp-outbound-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type:                 infrav1.Public,
+								IdleTimeoutInMinutes: pointer.Int32(15),
+								SKU:                  infrav1.SKUStandard,
+							},
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "cp-outbound-lb-frontend-ip",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "cp-outbound-lb-frontend-ip",
+									},
+								},
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							Name: "node-outbound-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "node-outbound-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type:                 infrav1.Public,
+								IdleTimeoutInMinutes: pointer.Int32(50),
+								SKU:                  infrav1.SKUStandard,
+							},
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "node-outbound-lb-frontend-ip",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "node-outbound-lb-frontend-ip",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&loadbalancers.LBSpec{
+					Name:              "api-server-lb",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					ClusterName:       "my-cluster",
+					Location:          "westus2",
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "my-rg",
+					SubnetName:        "cp-subnet",
+					FrontendIPConfigs: []infrav1.FrontendIP{
+						{
+							Name: "api-server-lb-frontend-ip",
+							PublicIP: &infrav1.PublicIPSpec{
+								Name: "api-server-lb-frontend-ip",
+							},
+						},
+					},
+					APIServerPort:        6443,
+					Type:                 infrav1.Public,
+					SKU:                  infrav1.SKUStandard,
+					Role:                 infrav1.APIServerRole,
+					BackendPoolName:      "api-server-lb-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(30),
+					AdditionalTags: infrav1.Tags{
+						"foo": "bar",
+					},
+				},
+				&loadbalancers.LBSpec{
+					Name:              "node-outbound-lb",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					ClusterName:       "my-cluster",
+					Location:          "westus2",
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "my-rg",
+					FrontendIPConfigs: []infrav1.FrontendIP{
+						{
+							Name: "node-outbound-lb-frontend-ip",
+							PublicIP: &infrav1.PublicIPSpec{
+								Name: "node-outbound-lb-frontend-ip",
+							},
+						},
+					},
+					Type:                 infrav1.Public,
+					SKU:                  infrav1.SKUStandard,
+					Role:                 infrav1.NodeOutboundRole,
+					BackendPoolName:      "node-outbound-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(50),
+					AdditionalTags: infrav1.Tags{
+						"foo": "bar",
+					},
+				},
+				&loadbalancers.LBSpec{
+					Name:              "cp-outbound-lb",
+					ResourceGroup:     "my-rg",
+					SubscriptionID:    "123",
+					ClusterName:       "my-cluster",
+					Location:          "westus2",
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "my-rg",
+					FrontendIPConfigs: []infrav1.FrontendIP{
+						{
+							Name: "cp-outbound-lb-frontend-ip",
+							PublicIP: &infrav1.PublicIPSpec{
+								Name: "cp-outbound-lb-frontend-ip",
+							},
+						},
+					},
+					Type:                 infrav1.Public,
+					SKU:                  infrav1.SKUStandard,
+					BackendPoolName:      "cp-outbound-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(15),
+					Role:                 infrav1.ControlPlaneOutboundRole,
+					AdditionalTags: infrav1.Tags{
+						"foo": "bar",
+					},
+				},
+			},
+		},
+		{
+			name: "Private API Server LB",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus2",
+					},
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "node-subnet",
+									Role: infrav1.SubnetNode,
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "api-server-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "api-server-lb-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type:                 infrav1.Internal,
+								IdleTimeoutInMinutes: pointer.Int32(30),
+								SKU:                  infrav1.SKUStandard,
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&loadbalancers.LBSpec{
+					Name:                 "api-server-lb",
+					ResourceGroup:        "my-rg",
+					SubscriptionID:       "123",
+					ClusterName:          "my-cluster",
+					Location:             "westus2",
+					VNetName:             "my-vnet",
+					VNetResourceGroup:    "my-rg",
+					SubnetName:           "cp-subnet",
+					APIServerPort:        6443,
+					Type:                 infrav1.Internal,
+					SKU:                  infrav1.SKUStandard,
+					Role:                 infrav1.APIServerRole,
+					BackendPoolName:      "api-server-lb-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(30),
+					AdditionalTags:       infrav1.Tags{},
+				},
+			},
+		},
+		{
+			name: "Private API Server LB in a dedicated subnet",
 			azureCluster: &infrav1.AzureCluster{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "my-cluster",
 				},
 				Spec: infrav1.AzureClusterSpec{
 					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
-						AdditionalTags: infrav1.Tags{
-							"foo": "bar",
-						},
 						SubscriptionID: "123",
 						Location:       "westus2",
 					},
@@ -2722,53 +3883,80 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 								Name: "api-server-lb-backend-pool",
 							},
 							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
-								Type:                 infrav1.Public,
+								Type:                 infrav1.Internal,
 								IdleTimeoutInMinutes: pointer.Int32(30),
 								SKU:                  infrav1.SKUStandard,
+								SubnetName:           "ilb-subnet",
 							},
-							FrontendIPs: []infrav1.FrontendIP{
-								{
-									Name: "api-server-lb-frontend-ip",
-									PublicIP: &infrav1.PublicIPSpec{
-										Name: "api-server-lb-frontend-ip",
-									},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&loadbalancers.LBSpec{
+					Name:                 "api-server-lb",
+					ResourceGroup:        "my-rg",
+					SubscriptionID:       "123",
+					ClusterName:          "my-cluster",
+					Location:             "westus2",
+					VNetName:             "my-vnet",
+					VNetResourceGroup:    "my-rg",
+					SubnetName:           "ilb-subnet",
+					APIServerPort:        6443,
+					Type:                 infrav1.Internal,
+					SKU:                  infrav1.SKUStandard,
+					Role:                 infrav1.APIServerRole,
+					BackendPoolName:      "api-server-lb-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(30),
+					AdditionalTags:       infrav1.Tags{},
+				},
+			},
+		},
+		{
+			name: "Public API Server LB with a private frontend IP",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus2",
+					},
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
 								},
 							},
 						},
-						ControlPlaneOutboundLB: &infrav1.LoadBalancerSpec{
-							Name: "cp-outbound-lb",
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "api-server-lb",
 							BackendPool: infrav1.BackendPool{
-								Name: "cp-outbound-backend-pool",
+								Name: "api-server-lb-backend-pool",
 							},
 							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
-								Type:                 infrav1.Public,
-								IdleTimeoutInMinutes: pointer.Int32(15),
-								SKU:                  infrav1.SKUStandard,
+								Type: infrav1.Public,
+								SKU:  infrav1.SKUStandard,
 							},
 							FrontendIPs: []infrav1.FrontendIP{
 								{
-									Name: "cp-outbound-lb-frontend-ip",
+									Name: "api-server-lb-frontend-ip",
 									PublicIP: &infrav1.PublicIPSpec{
-										Name: "cp-outbound-lb-frontend-ip",
+										Name: "api-server-lb-frontend-ip",
 									},
 								},
-							},
-						},
-						NodeOutboundLB: &infrav1.LoadBalancerSpec{
-							Name: "node-outbound-lb",
-							BackendPool: infrav1.BackendPool{
-								Name: "node-outbound-backend-pool",
-							},
-							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
-								Type:                 infrav1.Public,
-								IdleTimeoutInMinutes: pointer.Int32(50),
-								SKU:                  infrav1.SKUStandard,
-							},
-							FrontendIPs: []infrav1.FrontendIP{
 								{
-									Name: "node-outbound-lb-frontend-ip",
-									PublicIP: &infrav1.PublicIPSpec{
-										Name: "node-outbound-lb-frontend-ip",
+									Name: "api-server-lb-private-frontend-ip",
+									FrontendIPClass: infrav1.FrontendIPClass{
+										PrivateIPAddress: "10.0.0.100",
 									},
 								},
 							},
@@ -2794,70 +3982,41 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 							},
 						},
 					},
-					APIServerPort:        6443,
-					Type:                 infrav1.Public,
-					SKU:                  infrav1.SKUStandard,
-					Role:                 infrav1.APIServerRole,
-					BackendPoolName:      "api-server-lb-backend-pool",
-					IdleTimeoutInMinutes: pointer.Int32(30),
-					AdditionalTags: infrav1.Tags{
-						"foo": "bar",
-					},
-				},
-				&loadbalancers.LBSpec{
-					Name:              "node-outbound-lb",
-					ResourceGroup:     "my-rg",
-					SubscriptionID:    "123",
-					ClusterName:       "my-cluster",
-					Location:          "westus2",
-					VNetName:          "my-vnet",
-					VNetResourceGroup: "my-rg",
-					FrontendIPConfigs: []infrav1.FrontendIP{
-						{
-							Name: "node-outbound-lb-frontend-ip",
-							PublicIP: &infrav1.PublicIPSpec{
-								Name: "node-outbound-lb-frontend-ip",
-							},
-						},
-					},
-					Type:                 infrav1.Public,
-					SKU:                  infrav1.SKUStandard,
-					Role:                 infrav1.NodeOutboundRole,
-					BackendPoolName:      "node-outbound-backend-pool",
-					IdleTimeoutInMinutes: pointer.Int32(50),
-					AdditionalTags: infrav1.Tags{
-						"foo": "bar",
-					},
+					APIServerPort:   6443,
+					Type:            infrav1.Public,
+					SKU:             infrav1.SKUStandard,
+					Role:            infrav1.APIServerRole,
+					BackendPoolName: "api-server-lb-backend-pool",
+					AdditionalTags:  infrav1.Tags{},
 				},
 				&loadbalancers.LBSpec{
-					Name:              "cp-outbound-lb",
+					Name:              "api-server-lb-internal",
 					ResourceGroup:     "my-rg",
 					SubscriptionID:    "123",
 					ClusterName:       "my-cluster",
 					Location:          "westus2",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "my-rg",
+					SubnetName:        "cp-subnet",
 					FrontendIPConfigs: []infrav1.FrontendIP{
 						{
-							Name: "cp-outbound-lb-frontend-ip",
-							PublicIP: &infrav1.PublicIPSpec{
-								Name: "cp-outbound-lb-frontend-ip",
+							Name: "api-server-lb-private-frontend-ip",
+							FrontendIPClass: infrav1.FrontendIPClass{
+								PrivateIPAddress: "10.0.0.100",
 							},
 						},
 					},
-					Type:                 infrav1.Public,
-					SKU:                  infrav1.SKUStandard,
-					BackendPoolName:      "cp-outbound-backend-pool",
-					IdleTimeoutInMinutes: pointer.Int32(15),
-					Role:                 infrav1.ControlPlaneOutboundRole,
-					AdditionalTags: infrav1.Tags{
-						"foo": "bar",
-					},
+					APIServerPort:   6443,
+					Type:            infrav1.Internal,
+					SKU:             infrav1.SKUStandard,
+					Role:            infrav1.APIServerRole,
+					BackendPoolName: "api-server-lb-internal-backendPool",
+					AdditionalTags:  infrav1.Tags{},
 				},
 			},
 		},
 		{
-			name: "Private API Server LB",
+			name: "Node outbound LB shared with the API Server LB",
 			azureCluster: &infrav1.AzureCluster{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "my-cluster",
@@ -2893,9 +4052,13 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 								Name: "api-server-lb-backend-pool",
 							},
 							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
-								Type:                 infrav1.Internal,
-								IdleTimeoutInMinutes: pointer.Int32(30),
-								SKU:                  infrav1.SKUStandard,
+								Type: infrav1.Public,
+								SKU:  infrav1.SKUStandard,
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Mode: infrav1.NodeOutboundLBModeShared,
 							},
 						},
 					},
@@ -2903,21 +4066,21 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{
 				&loadbalancers.LBSpec{
-					Name:                 "api-server-lb",
-					ResourceGroup:        "my-rg",
-					SubscriptionID:       "123",
-					ClusterName:          "my-cluster",
-					Location:             "westus2",
-					VNetName:             "my-vnet",
-					VNetResourceGroup:    "my-rg",
-					SubnetName:           "cp-subnet",
-					APIServerPort:        6443,
-					Type:                 infrav1.Internal,
-					SKU:                  infrav1.SKUStandard,
-					Role:                 infrav1.APIServerRole,
-					BackendPoolName:      "api-server-lb-backend-pool",
-					IdleTimeoutInMinutes: pointer.Int32(30),
-					AdditionalTags:       infrav1.Tags{},
+					Name:                        "api-server-lb",
+					ResourceGroup:               "my-rg",
+					SubscriptionID:              "123",
+					ClusterName:                 "my-cluster",
+					Location:                    "westus2",
+					VNetName:                    "my-vnet",
+					VNetResourceGroup:           "my-rg",
+					SubnetName:                  "cp-subnet",
+					APIServerPort:               6443,
+					Type:                        infrav1.Public,
+					SKU:                         infrav1.SKUStandard,
+					Role:                        infrav1.APIServerRole,
+					BackendPoolName:             "api-server-lb-backend-pool",
+					NodeOutboundBackendPoolName: "api-server-lb-outboundBackendPool",
+					AdditionalTags:              infrav1.Tags{},
 				},
 			},
 		},
@@ -2957,6 +4120,68 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 	}
 }
 
+func TestAPIServerEndpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontendIPs []infrav1.FrontendIP
+		want        []clusterv1.APIEndpoint
+	}{
+		{
+			name: "returns the control plane endpoint",
+			frontendIPs: []infrav1.FrontendIP{
+				{
+					Name:     "my-frontend",
+					PublicIP: &infrav1.PublicIPSpec{Name: "my-pip", DNSName: "my-cluster.westus2.cloudapp.azure.com"},
+				},
+			},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-cluster.westus2.cloudapp.azure.com", Port: 6443},
+			},
+		},
+		{
+			name: "returns the private frontend IP after the control plane endpoint",
+			frontendIPs: []infrav1.FrontendIP{
+				{
+					Name:     "my-frontend",
+					PublicIP: &infrav1.PublicIPSpec{Name: "my-pip", DNSName: "my-cluster.westus2.cloudapp.azure.com"},
+				},
+				{
+					Name:            "my-private-frontend",
+					FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"},
+				},
+			},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-cluster.westus2.cloudapp.azure.com", Port: 6443},
+				{Host: "10.0.0.100", Port: 6443},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "my-cluster.westus2.cloudapp.azure.com", Port: 6443},
+						NetworkSpec: infrav1.NetworkSpec{
+							APIServerLB: infrav1.LoadBalancerSpec{
+								Name:        "my-lb",
+								FrontendIPs: tc.frontendIPs,
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Public,
+								},
+							},
+						},
+					},
+				},
+			}
+			g.Expect(clusterScope.APIServerEndpoints()).To(Equal(tc.want))
+		})
+	}
+}
+
 func TestExtendedLocationName(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -3148,20 +4373,22 @@ func TestVNetPeerings(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet1-To-vnet2",
-					SourceResourceGroup: "rg1",
-					SourceVnetName:      "vnet1",
-					RemoteResourceGroup: "rg2",
-					RemoteVnetName:      "vnet2",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet1-To-vnet2",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "rg2",
+					RemoteVnetName:       "vnet2",
+					SubscriptionID:       fakeSubscriptionID,
+					RemoteSubscriptionID: fakeSubscriptionID,
 				},
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet2-To-vnet1",
-					SourceResourceGroup: "rg2",
-					SourceVnetName:      "vnet2",
-					RemoteResourceGroup: "rg1",
-					RemoteVnetName:      "vnet1",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet2-To-vnet1",
+					SourceResourceGroup:  "rg2",
+					SourceVnetName:       "vnet2",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       fakeSubscriptionID,
+					RemoteSubscriptionID: fakeSubscriptionID,
 				},
 			},
 		},
@@ -3198,6 +4425,7 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg2",
 					RemoteVnetName:        "vnet2",
 					SubscriptionID:        fakeSubscriptionID,
+					RemoteSubscriptionID:  fakeSubscriptionID,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(false),
 					UseRemoteGateways:     pointer.Bool(true),
@@ -3209,6 +4437,7 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg1",
 					RemoteVnetName:        "vnet1",
 					SubscriptionID:        fakeSubscriptionID,
+					RemoteSubscriptionID:  fakeSubscriptionID,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(true),
 					UseRemoteGateways:     pointer.Bool(false),
@@ -3254,6 +4483,7 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg2",
 					RemoteVnetName:        "vnet2",
 					SubscriptionID:        fakeSubscriptionID,
+					RemoteSubscriptionID:  fakeSubscriptionID,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(false),
 					UseRemoteGateways:     pointer.Bool(true),
@@ -3265,25 +4495,65 @@ func TestVNetPeerings(t *testing.T) {
 					RemoteResourceGroup:   "rg1",
 					RemoteVnetName:        "vnet1",
 					SubscriptionID:        fakeSubscriptionID,
+					RemoteSubscriptionID:  fakeSubscriptionID,
 					AllowForwardedTraffic: pointer.Bool(true),
 					AllowGatewayTransit:   pointer.Bool(true),
 					UseRemoteGateways:     pointer.Bool(false),
 				},
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet1-To-vnet3",
-					SourceResourceGroup: "rg1",
-					SourceVnetName:      "vnet1",
-					RemoteResourceGroup: "rg3",
-					RemoteVnetName:      "vnet3",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet1-To-vnet3",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "rg3",
+					RemoteVnetName:       "vnet3",
+					SubscriptionID:       fakeSubscriptionID,
+					RemoteSubscriptionID: fakeSubscriptionID,
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "vnet3-To-vnet1",
+					SourceResourceGroup:  "rg3",
+					SourceVnetName:       "vnet3",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       fakeSubscriptionID,
+					RemoteSubscriptionID: fakeSubscriptionID,
+				},
+			},
+		},
+		{
+			name:           "VNet peering with a remote VNet in another subscription is specified",
+			subscriptionID: fakeSubscriptionID,
+			azureClusterVNetSpec: infrav1.VnetSpec{
+				ResourceGroup: "rg1",
+				Name:          "vnet1",
+				Peerings: infrav1.VnetPeerings{
+					{
+						VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+							ResourceGroup:  "rg2",
+							RemoteVnetName: "vnet2",
+							SubscriptionID: "456",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "vnet1-To-vnet2",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "rg2",
+					RemoteVnetName:       "vnet2",
+					SubscriptionID:       fakeSubscriptionID,
+					RemoteSubscriptionID: "456",
 				},
 				&vnetpeerings.VnetPeeringSpec{
-					PeeringName:         "vnet3-To-vnet1",
-					SourceResourceGroup: "rg3",
-					SourceVnetName:      "vnet3",
-					RemoteResourceGroup: "rg1",
-					RemoteVnetName:      "vnet1",
-					SubscriptionID:      fakeSubscriptionID,
+					PeeringName:          "vnet2-To-vnet1",
+					SourceResourceGroup:  "rg2",
+					SourceVnetName:       "vnet2",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       "456",
+					RemoteSubscriptionID: fakeSubscriptionID,
 				},
 			},
 		},
@@ -3344,3 +4614,78 @@ func TestVNetPeerings(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterScope_ReportDrift(t *testing.T) {
+	tests := []struct {
+		name           string
+		corrected      bool
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "corrected drift doesn't affect the condition",
+			corrected:      true,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:           "uncorrected drift is reported in the condition",
+			corrected:      false,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: infrav1.DriftDetectedReason,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{},
+			}
+
+			clusterScope.ReportDrift("securitygroups", "my-nsg", tc.corrected)
+			clusterScope.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, "securitygroups", nil)
+
+			condition := conditions.Get(clusterScope.AzureCluster, infrav1.SecurityGroupsReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
+
+func TestClusterScope_UpdatePutStatusReason(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedReason string
+	}{
+		{
+			name: "Azure error code is the reason",
+			err: autorest.NewErrorWithError(&azureautorest.RequestError{
+				ServiceError: &azureautorest.ServiceError{Code: "QuotaExceeded"},
+			}, "network.PublicIPAddressesClient", "CreateOrUpdate", &http.Response{StatusCode: http.StatusBadRequest}, "Failure sending request"),
+			expectedReason: "QuotaExceeded",
+		},
+		{
+			name:           "error without Azure error code uses the generic reason",
+			err:            errors.New("failed to build the public IP spec"),
+			expectedReason: infrav1.FailedReason,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{},
+			}
+
+			clusterScope.UpdatePutStatus(infrav1.PublicIPsReadyCondition, "publicips", tc.err)
+
+			condition := conditions.Get(clusterScope.AzureCluster, infrav1.PublicIPsReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+)
+
+const (
+	// credentialCacheSize is the maximum number of credentials kept in the cache.
+	credentialCacheSize = 1024
+	// credentialCacheTTL is how long a credential is reused before it is created again, so that certificates rotated
+	// in Key Vault are eventually picked up.
+	credentialCacheTTL = 1 * time.Hour
+	// tokenRefreshAhead is how long before their expiration cached tokens are refreshed.
+	tokenRefreshAhead = 5 * time.Minute
+)
+
+var (
+	credentialCacheOnce sync.Once
+	credentialCache     ttllru.PeekingCacher
+)
+
+// credentialCacheKey identifies the credential of an identity for a given tenant and token scope. The generation of
+// the identity and the hash of its client secret are part of the key, so that a new credential is created when
+// either changes.
+type credentialCacheKey struct {
+	identity                types.NamespacedName
+	uid                     types.UID
+	generation              int64
+	identityType            infrav1.IdentityType
+	clientID                string
+	tenantID                string
+	auxiliaryTenantIDs      string
+	clientSecretHash        string
+	resourceManagerEndpoint string
+	activeDirectoryEndpoint string
+	scope                   string
+}
+
+// newCredentialCacheKey returns the cache key of the credential of an identity.
+func newCredentialCacheKey(identity *infrav1.AzureClusterIdentity, clientSecret, resourceManagerEndpoint, activeDirectoryEndpoint, scope string) credentialCacheKey {
+	key := credentialCacheKey{
+		identity:                types.NamespacedName{Namespace: identity.Namespace, Name: identity.Name},
+		uid:                     identity.UID,
+		generation:              identity.Generation,
+		identityType:            identity.Spec.Type,
+		clientID:                identity.Spec.ClientID,
+		tenantID:                identity.Spec.TenantID,
+		auxiliaryTenantIDs:      strings.Join(identity.Spec.AuxiliaryTenantIDs, ";"),
+		resourceManagerEndpoint: resourceManagerEndpoint,
+		activeDirectoryEndpoint: activeDirectoryEndpoint,
+		scope:                   scope,
+	}
+	if clientSecret != "" {
+		hash := sha256.Sum256([]byte(clientSecret))
+		key.clientSecretHash = base64.URLEncoding.EncodeToString(hash[:])
+	}
+	return key
+}
+
+// getCachedCredential returns the cached credential for the key, or creates it with newCredential and caches it.
+func getCachedCredential(key credentialCacheKey, newCredential func() (azcore.TokenCredential, error)) (azcore.TokenCredential, error) {
+	var err error
+	credentialCacheOnce.Do(func() {
+		credentialCache, err = ttllru.New(credentialCacheSize, credentialCacheTTL)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for credentials")
+	}
+
+	// Peek doesn't extend the lifetime of the entry, so credentials are created again every credentialCacheTTL.
+	if cred, _, ok := credentialCache.Peek(key); ok {
+		return cred.(azcore.TokenCredential), nil
+	}
+
+	cred, err := newCredential()
+	if err != nil {
+		return nil, err
+	}
+	cached := newCachedTokenCredential(cred)
+	_ = credentialCache.Add(key, cached)
+	return cached, nil
+}
+
+// tokenCacheKey identifies a token of a credential.
+type tokenCacheKey struct {
+	tenantID string
+	scopes   string
+}
+
+// cachedTokenCredential is an azcore.TokenCredential that caches the tokens of another credential and refreshes them
+// tokenRefreshAhead before they expire.
+type cachedTokenCredential struct {
+	cred   azcore.TokenCredential
+	mu     sync.Mutex
+	tokens map[tokenCacheKey]azcore.AccessToken
+}
+
+func newCachedTokenCredential(cred azcore.TokenCredential) *cachedTokenCredential {
+	return &cachedTokenCredential{
+		cred:   cred,
+		tokens: make(map[tokenCacheKey]azcore.AccessToken),
+	}
+}
+
+// GetToken returns the cached token for the requested tenant and scopes, refreshing it when it is about to expire.
+// Concurrent requests for a token wait for a single refresh instead of all hitting Azure Active Directory.
+func (c *cachedTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	key := tokenCacheKey{
+		tenantID: options.TenantID,
+		scopes:   strings.Join(options.Scopes, " "),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.tokens[key]
+	now := time.Now()
+	if ok && now.Add(tokenRefreshAhead).Before(cached.ExpiresOn) {
+		return cached, nil
+	}
+
+	token, err := c.cred.GetToken(ctx, options)
+	if err != nil {
+		// The cached token can still be used until it actually expires.
+		if ok && now.Before(cached.ExpiresOn) {
+			return cached, nil
+		}
+		return azcore.AccessToken{}, err
+	}
+	c.tokens[key] = token
+	return token, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// countingTokenCredential returns tokens expiring after expiresIn and counts how many were requested.
+type countingTokenCredential struct {
+	expiresIn time.Duration
+	err       error
+	calls     int
+}
+
+func (c *countingTokenCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.calls++
+	if c.err != nil {
+		return azcore.AccessToken{}, c.err
+	}
+	return azcore.AccessToken{Token: options.TenantID, ExpiresOn: time.Now().Add(c.expiresIn)}, nil
+}
+
+func TestCachedTokenCredential(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiresIn     time.Duration
+		refreshErr    error
+		expectedCalls int
+		expectedErr   bool
+	}{
+		{
+			name:          "valid token is reused",
+			expiresIn:     time.Hour,
+			expectedCalls: 1,
+		},
+		{
+			name:          "token about to expire is refreshed",
+			expiresIn:     time.Minute,
+			expectedCalls: 2,
+		},
+		{
+			name:          "token about to expire is reused when the refresh fails",
+			expiresIn:     time.Minute,
+			refreshErr:    errors.New("token request failed"),
+			expectedCalls: 2,
+		},
+		{
+			name:          "expired token is not reused when the refresh fails",
+			expiresIn:     -time.Minute,
+			refreshErr:    errors.New("token request failed"),
+			expectedCalls: 2,
+			expectedErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fake := &countingTokenCredential{expiresIn: tt.expiresIn}
+			cred := newCachedTokenCredential(fake)
+			options := policy.TokenRequestOptions{Scopes: []string{"https://management.azure.com//.default"}}
+
+			_, err := cred.GetToken(context.TODO(), options)
+			g.Expect(err).NotTo(HaveOccurred())
+			fake.err = tt.refreshErr
+			_, err = cred.GetToken(context.TODO(), options)
+			if tt.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(fake.calls).To(Equal(tt.expectedCalls))
+		})
+	}
+}
+
+func TestCachedTokenCredentialPerTenant(t *testing.T) {
+	g := NewWithT(t)
+	fake := &countingTokenCredential{expiresIn: time.Hour}
+	cred := newCachedTokenCredential(fake)
+	scopes := []string{"https://management.azure.com//.default"}
+
+	for _, tenantID := range []string{"", "tenant-b", "", "tenant-b"} {
+		token, err := cred.GetToken(context.TODO(), policy.TokenRequestOptions{Scopes: scopes, TenantID: tenantID})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(token.Token).To(Equal(tenantID))
+	}
+	g.Expect(fake.calls).To(Equal(2))
+}
+
+func TestGetCachedCredential(t *testing.T) {
+	g := NewWithT(t)
+	identity := &infrav1.AzureClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-cached-credential",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: infrav1.AzureClusterIdentitySpec{
+			Type:     infrav1.ManualServicePrincipal,
+			ClientID: "fake-client-id",
+			TenantID: "fake-tenant-id",
+		},
+	}
+	created := 0
+	newCredential := func() (azcore.TokenCredential, error) {
+		created++
+		return &countingTokenCredential{expiresIn: time.Hour}, nil
+	}
+	get := func(clientSecret string) azcore.TokenCredential {
+		key := newCredentialCacheKey(identity, clientSecret, "https://management.azure.com/", "https://login.microsoftonline.com/", "https://management.azure.com//.default")
+		cred, err := getCachedCredential(key, newCredential)
+		g.Expect(err).NotTo(HaveOccurred())
+		return cred
+	}
+
+	first := get("secret")
+	g.Expect(get("secret")).To(BeIdenticalTo(first))
+	g.Expect(created).To(Equal(1))
+
+	// a rotated client secret creates a new credential
+	g.Expect(get("rotated-secret")).NotTo(BeIdenticalTo(first))
+	g.Expect(created).To(Equal(2))
+
+	// a change to the identity creates a new credential
+	identity.Generation = 2
+	get("rotated-secret")
+	g.Expect(created).To(Equal(3))
+}
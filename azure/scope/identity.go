@@ -18,7 +18,13 @@ package scope
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"reflect"
 	"strings"
 
@@ -26,15 +32,20 @@ import (
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/jongio/azidext/go/azidext"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/identity"
 	"sigs.k8s.io/cluster-api-provider-azure/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -42,7 +53,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const azureSecretKey = "clientSecret"
+const (
+	azureSecretKey = "clientSecret"
+
+	// federatedTokenFileEnvVar is the environment variable set by the Azure Workload Identity webhook
+	// with the path of the projected service account token of the pod.
+	federatedTokenFileEnvVar = "AZURE_FEDERATED_TOKEN_FILE"
+	// defaultFederatedTokenFilePath is the path the projected service account token is mounted at in the
+	// controller manager deployment when the webhook isn't used.
+	defaultFederatedTokenFilePath = "/var/run/secrets/azure/tokens/azure-identity-token"
+
+	// auxiliaryAuthorizationHeader is the header Azure Resource Manager reads the tokens of auxiliary tenants from.
+	auxiliaryAuthorizationHeader = "x-ms-authorization-auxiliary"
+
+	// pkcs12ContentType is the content type of Key Vault secrets holding a base64 encoded PKCS12 certificate.
+	pkcs12ContentType = "application/x-pkcs12"
+)
 
 // CredentialsProvider defines the behavior for azure identity based credential providers.
 type CredentialsProvider interface {
@@ -50,6 +76,7 @@ type CredentialsProvider interface {
 	GetClientID() string
 	GetClientSecret(ctx context.Context) (string, error)
 	GetTenantID() string
+	GetAuxiliaryTenantIDs() []string
 }
 
 // AzureCredentialsProvider represents a credential provider with azure cluster identity.
@@ -138,41 +165,86 @@ func (p *ManagedControlPlaneCredentialsProvider) GetAuthorizer(ctx context.Conte
 }
 
 // GetAuthorizer returns an Azure authorizer based on the provided azure identity and cluster metadata.
+// The credential of the identity is shared by all the clusters using it, so that its tokens are reused across reconciles.
 func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience string, clusterMeta metav1.ObjectMeta) (autorest.Authorizer, error) {
+	// The aad-pod-identity bindings are specific to each cluster, so they are created even if the credential is cached.
+	if p.Identity.Spec.CertificateKeyVaultRef == nil {
+		switch p.Identity.Spec.Type {
+		case infrav1.ServicePrincipal, infrav1.ServicePrincipalCertificate, infrav1.UserAssignedMSI:
+			useBindings, err := p.usesAADPodIdentity()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to check if aad-pod-identity is installed")
+			}
+			if useBindings {
+				if err := createAzureIdentityWithBindings(ctx, p.Identity, resourceManagerEndpoint, activeDirectoryEndpoint, clusterMeta, p.Client); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	clientSecret, err := p.GetClientSecret(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get client secret")
+	}
+	// We must use TokenAudience for StackCloud, otherwise we get an
+	// AADSTS500011 error from the API
+	scope := tokenAudience
+	if !strings.HasSuffix(scope, "/.default") {
+		scope += "/.default"
+	}
+	key := newCredentialCacheKey(p.Identity, clientSecret, resourceManagerEndpoint, activeDirectoryEndpoint, scope)
+	cred, err := getCachedCredential(key, func() (azcore.TokenCredential, error) {
+		return p.newCredential(ctx, resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience, clientSecret)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if auxiliaryTenantIDs := p.GetAuxiliaryTenantIDs(); len(auxiliaryTenantIDs) > 0 {
+		return newMultiTenantAuthorizer(cred, []string{scope}, auxiliaryTenantIDs), nil
+	}
+	authorizer := azidext.NewTokenCredentialAdapter(cred, []string{scope})
+	return authorizer, nil
+}
+
+// newCredential returns a new token credential for the identity.
+func (p *AzureCredentialsProvider) newCredential(ctx context.Context, resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience, clientSecret string) (azcore.TokenCredential, error) {
 	var authErr error
 	var cred azcore.TokenCredential
 	switch p.Identity.Spec.Type {
 	case infrav1.ServicePrincipal, infrav1.ServicePrincipalCertificate, infrav1.UserAssignedMSI:
-		if err := createAzureIdentityWithBindings(ctx, p.Identity, resourceManagerEndpoint, activeDirectoryEndpoint, clusterMeta, p.Client); err != nil {
-			return nil, err
+		if p.Identity.Spec.CertificateKeyVaultRef != nil {
+			// The certificate is read from Key Vault, so no aad-pod-identity binding is needed.
+			cred, authErr = p.newKeyVaultCertificateCredential(ctx, resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience)
+			break
 		}
 
+		// The client ID selects which of the identities assigned to the management cluster nodes is used, so every
+		// cluster gets a token for the identity it references.
 		options := azidentity.ManagedIdentityCredentialOptions{
 			ID: azidentity.ClientID(p.Identity.Spec.ClientID),
 		}
 		cred, authErr = azidentity.NewManagedIdentityCredential(&options)
 
 	case infrav1.ManualServicePrincipal:
-		clientSecret, err := p.GetClientSecret(ctx)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get client secret")
-		}
-
 		options := azidentity.ClientSecretCredentialOptions{
-			ClientOptions: azcore.ClientOptions{
-				Cloud: cloud.Configuration{
-					ActiveDirectoryAuthorityHost: activeDirectoryEndpoint,
-					Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
-						cloud.ResourceManager: {
-							Audience: tokenAudience,
-							Endpoint: resourceManagerEndpoint,
-						},
-					},
-				},
-			},
+			ClientOptions:              cloudClientOptions(resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience),
+			AdditionallyAllowedTenants: p.GetAuxiliaryTenantIDs(),
 		}
 		cred, authErr = azidentity.NewClientSecretCredential(p.GetTenantID(), p.Identity.Spec.ClientID, clientSecret, &options)
 
+	case infrav1.WorkloadIdentity:
+		// The credential reads the token file again when its assertion expires, so rotated tokens are picked up.
+		options := azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions:              cloudClientOptions(resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience),
+			AdditionallyAllowedTenants: p.GetAuxiliaryTenantIDs(),
+			ClientID:                   p.Identity.Spec.ClientID,
+			TenantID:                   p.GetTenantID(),
+			TokenFilePath:              federatedTokenFilePath(),
+		}
+		cred, authErr = azidentity.NewWorkloadIdentityCredential(&options)
+
 	default:
 		return nil, errors.Errorf("identity type %s not supported", p.Identity.Spec.Type)
 	}
@@ -180,14 +252,158 @@ func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceMa
 	if authErr != nil {
 		return nil, errors.Errorf("failed to get token from service principal identity: %v", authErr)
 	}
-	// We must use TokenAudience for StackCloud, otherwise we get an
-	// AADSTS500011 error from the API
-	scope := tokenAudience
-	if !strings.HasSuffix(scope, "/.default") {
-		scope += "/.default"
+	return cred, nil
+}
+
+// multiTenantAuthorizer is an autorest.Authorizer that authenticates requests in the primary tenant of a credential and
+// adds its tokens for the auxiliary tenants, so that requests can reference resources in those tenants.
+type multiTenantAuthorizer struct {
+	cred               azcore.TokenCredential
+	scopes             []string
+	auxiliaryTenantIDs []string
+}
+
+func newMultiTenantAuthorizer(cred azcore.TokenCredential, scopes, auxiliaryTenantIDs []string) *multiTenantAuthorizer {
+	return &multiTenantAuthorizer{
+		cred:               cred,
+		scopes:             scopes,
+		auxiliaryTenantIDs: auxiliaryTenantIDs,
 	}
-	authorizer := azidext.NewTokenCredentialAdapter(cred, []string{scope})
-	return authorizer, nil
+}
+
+// WithAuthorization returns a PrepareDecorator that adds the Authorization header with the token of the primary tenant
+// and the x-ms-authorization-auxiliary header with the tokens of the auxiliary tenants.
+func (a *multiTenantAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			token, err := a.cred.GetToken(r.Context(), policy.TokenRequestOptions{Scopes: a.scopes})
+			if err != nil {
+				return r, errors.Wrap(err, "failed to get token")
+			}
+			auxiliaryTokens := make([]string, 0, len(a.auxiliaryTenantIDs))
+			for _, tenantID := range a.auxiliaryTenantIDs {
+				auxiliaryToken, err := a.cred.GetToken(r.Context(), policy.TokenRequestOptions{Scopes: a.scopes, TenantID: tenantID})
+				if err != nil {
+					return r, errors.Wrapf(err, "failed to get token for auxiliary tenant %s", tenantID)
+				}
+				auxiliaryTokens = append(auxiliaryTokens, "Bearer "+auxiliaryToken.Token)
+			}
+			return autorest.Prepare(r,
+				autorest.WithBearerAuthorization(token.Token),
+				autorest.WithHeader(auxiliaryAuthorizationHeader, strings.Join(auxiliaryTokens, ", ")),
+			)
+		})
+	}
+}
+
+// usesAADPodIdentity returns true if the token of the identity is obtained through aad-pod-identity.
+// User-assigned managed identities can instead be assigned directly to the management cluster nodes, in which case
+// the token is requested from IMDS and aad-pod-identity doesn't need to be installed.
+func (p *AzureCredentialsProvider) usesAADPodIdentity() (bool, error) {
+	if p.Identity.Spec.Type != infrav1.UserAssignedMSI {
+		return true, nil
+	}
+	_, err := p.Client.RESTMapper().RESTMapping(schema.GroupKind{Group: aadpodv1.GroupName, Kind: "AzureIdentity"}, "v1")
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// newKeyVaultCertificateCredential returns a credential for the service principal of the identity, using its certificate
+// read from Azure Key Vault with the managed identity of the management cluster.
+func (p *AzureCredentialsProvider) newKeyVaultCertificateCredential(ctx context.Context, resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience string) (azcore.TokenCredential, error) {
+	ref := p.Identity.Spec.CertificateKeyVaultRef
+	miOptions := azidentity.ManagedIdentityCredentialOptions{}
+	if ref.ManagedIdentityClientID != "" {
+		miOptions.ID = azidentity.ClientID(ref.ManagedIdentityClientID)
+	}
+	miCred, err := azidentity.NewManagedIdentityCredential(&miOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create managed identity credential")
+	}
+
+	vaultScope, err := keyVaultScope(ref.VaultURI)
+	if err != nil {
+		return nil, err
+	}
+	kvClient := keyvault.New()
+	azure.SetAutoRestClientDefaults(&kvClient.Client, azidext.NewTokenCredentialAdapter(miCred, []string{vaultScope}))
+	secret, err := kvClient.GetSecret(ctx, ref.VaultURI, ref.SecretName, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get certificate %s from key vault %s", ref.SecretName, ref.VaultURI)
+	}
+
+	certs, key, err := parseKeyVaultCertificate(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse certificate %s from key vault %s", ref.SecretName, ref.VaultURI)
+	}
+	options := azidentity.ClientCertificateCredentialOptions{
+		ClientOptions:              cloudClientOptions(resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience),
+		AdditionallyAllowedTenants: p.GetAuxiliaryTenantIDs(),
+	}
+	return azidentity.NewClientCertificateCredential(p.GetTenantID(), p.Identity.Spec.ClientID, certs, key, &options)
+}
+
+// keyVaultScope returns the token scope of the Key Vault service hosting the given vault, e.g.
+// https://vault.azure.net/.default for https://my-vault.vault.azure.net/.
+func keyVaultScope(vaultURI string) (string, error) {
+	u, err := url.Parse(vaultURI)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse key vault URI %s", vaultURI)
+	}
+	_, domain, found := strings.Cut(u.Hostname(), ".")
+	if !found {
+		return "", errors.Errorf("invalid key vault URI %s", vaultURI)
+	}
+	return fmt.Sprintf("https://%s/.default", domain), nil
+}
+
+// parseKeyVaultCertificate parses the certificates and private key of a Key Vault secret in PEM or PKCS12 format.
+func parseKeyVaultCertificate(secret keyvault.SecretBundle) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	if secret.Value == nil {
+		return nil, nil, errors.New("secret has no value")
+	}
+	data := []byte(*secret.Value)
+	if secret.ContentType != nil && *secret.ContentType == pkcs12ContentType {
+		decoded, err := base64.StdEncoding.DecodeString(*secret.Value)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to decode PKCS12 certificate")
+		}
+		data = decoded
+	}
+	// Certificates managed by Key Vault are exported without a password.
+	return azidentity.ParseCertificates(data, nil)
+}
+
+// cloudClientOptions returns the client options targeting the given Azure cloud endpoints.
+func cloudClientOptions(resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience string) azcore.ClientOptions {
+	return azcore.ClientOptions{
+		Cloud: cloud.Configuration{
+			ActiveDirectoryAuthorityHost: activeDirectoryEndpoint,
+			Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+				cloud.ResourceManager: {
+					Audience: tokenAudience,
+					Endpoint: resourceManagerEndpoint,
+				},
+			},
+		},
+	}
+}
+
+// federatedTokenFilePath returns the path of the projected service account token used by workload identities.
+func federatedTokenFilePath() string {
+	if path := os.Getenv(federatedTokenFileEnvVar); path != "" {
+		return path
+	}
+	return defaultFederatedTokenFilePath
 }
 
 // GetClientID returns the Client ID associated with the AzureCredentialsProvider's Identity.
@@ -220,6 +436,11 @@ func (p *AzureCredentialsProvider) GetTenantID() string {
 	return p.Identity.Spec.TenantID
 }
 
+// GetAuxiliaryTenantIDs returns the IDs of the additional tenants the AzureCredentialsProvider's Identity can get tokens in.
+func (p *AzureCredentialsProvider) GetAuxiliaryTenantIDs() []string {
+	return p.Identity.Spec.AuxiliaryTenantIDs
+}
+
 // hasClientSecret returns true if the identity has a Service Principal Client Secret.
 // This does not include service principals with certificates or managed identities.
 func (p *AzureCredentialsProvider) hasClientSecret() bool {
@@ -18,14 +18,28 @@ package scope
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
 	"testing"
+	"time"
 
 	aadpodid "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity"
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -341,6 +355,15 @@ func TestHasClientSecret(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "workload identity",
+			identity: &infrav1.AzureClusterIdentity{
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type: infrav1.WorkloadIdentity,
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -353,3 +376,256 @@ func TestHasClientSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestFederatedTokenFilePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		envVar string
+		want   string
+	}{
+		{
+			name:   "defaults to the token mounted in the controller manager",
+			envVar: "",
+			want:   "/var/run/secrets/azure/tokens/azure-identity-token",
+		},
+		{
+			name:   "token file set by the workload identity webhook",
+			envVar: "/var/run/secrets/custom/token",
+			want:   "/var/run/secrets/custom/token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Setenv(federatedTokenFileEnvVar, tt.envVar)
+			g.Expect(federatedTokenFilePath()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetAuthorizerWorkloadIdentity(t *testing.T) {
+	g := NewWithT(t)
+	p := &AzureCredentialsProvider{
+		Identity: &infrav1.AzureClusterIdentity{
+			Spec: infrav1.AzureClusterIdentitySpec{
+				Type:     infrav1.WorkloadIdentity,
+				ClientID: "fake-client-id",
+				TenantID: "fake-tenant-id",
+			},
+		},
+	}
+	authorizer, err := p.GetAuthorizer(context.TODO(), "https://management.azure.com/", "https://login.microsoftonline.com/", "https://management.azure.com/", metav1.ObjectMeta{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizer).NotTo(BeNil())
+}
+
+func TestUsesAADPodIdentity(t *testing.T) {
+	withAADPodIdentity := runtime.NewScheme()
+	_ = infrav1.AddToScheme(withAADPodIdentity)
+	_ = aadpodv1.AddToScheme(withAADPodIdentity)
+	withoutAADPodIdentity := runtime.NewScheme()
+	_ = infrav1.AddToScheme(withoutAADPodIdentity)
+
+	tests := []struct {
+		name         string
+		identityType infrav1.IdentityType
+		scheme       *runtime.Scheme
+		want         bool
+	}{
+		{
+			name:         "service principal with aad-pod-identity",
+			identityType: infrav1.ServicePrincipal,
+			scheme:       withAADPodIdentity,
+			want:         true,
+		},
+		{
+			name:         "service principal without aad-pod-identity",
+			identityType: infrav1.ServicePrincipal,
+			scheme:       withoutAADPodIdentity,
+			want:         true,
+		},
+		{
+			name:         "user-assigned managed identity with aad-pod-identity",
+			identityType: infrav1.UserAssignedMSI,
+			scheme:       withAADPodIdentity,
+			want:         true,
+		},
+		{
+			name:         "user-assigned managed identity assigned to the nodes",
+			identityType: infrav1.UserAssignedMSI,
+			scheme:       withoutAADPodIdentity,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			// The RESTMapper of the fake client maps the kinds of the scheme, like the API server serves the kinds of
+			// the installed CRDs.
+			mapper := meta.NewDefaultRESTMapper(tt.scheme.PreferredVersionAllGroups())
+			for gvk := range tt.scheme.AllKnownTypes() {
+				mapper.Add(gvk, meta.RESTScopeNamespace)
+			}
+			p := &AzureCredentialsProvider{
+				Client: fake.NewClientBuilder().WithScheme(tt.scheme).WithRESTMapper(mapper).Build(),
+				Identity: &infrav1.AzureClusterIdentity{
+					Spec: infrav1.AzureClusterIdentitySpec{
+						Type: tt.identityType,
+					},
+				},
+			}
+			got, err := p.usesAADPodIdentity()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetAuthorizerUserAssignedMSIWithoutAADPodIdentity(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	for _, clientID := range []string{"fake-client-id-1", "fake-client-id-2"} {
+		p := &AzureCredentialsProvider{
+			Client: fakeClient,
+			Identity: &infrav1.AzureClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clientID,
+				},
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type:       infrav1.UserAssignedMSI,
+					ClientID:   clientID,
+					ResourceID: "fake-resource-id",
+					TenantID:   "fake-tenant-id",
+				},
+			},
+		}
+		authorizer, err := p.GetAuthorizer(context.TODO(), "https://management.azure.com/", "https://login.microsoftonline.com/", "https://management.azure.com/", metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(authorizer).NotTo(BeNil())
+		g.Expect(p.GetClientID()).To(Equal(clientID))
+	}
+}
+
+// fakeTokenCredential returns tokens named after the tenant they are requested in.
+type fakeTokenCredential struct{}
+
+func (fakeTokenCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	tenantID := options.TenantID
+	if tenantID == "" {
+		tenantID = "primary"
+	}
+	return azcore.AccessToken{Token: "token-" + tenantID, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestMultiTenantAuthorizer(t *testing.T) {
+	g := NewWithT(t)
+	authorizer := newMultiTenantAuthorizer(fakeTokenCredential{}, []string{"https://management.azure.com//.default"}, []string{"tenant-b", "tenant-c"})
+
+	req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions/123", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+	req, err = autorest.Prepare(req, authorizer.WithAuthorization())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(req.Header.Get("Authorization")).To(Equal("Bearer token-primary"))
+	g.Expect(req.Header.Get(auxiliaryAuthorizationHeader)).To(Equal("Bearer token-tenant-b, Bearer token-tenant-c"))
+}
+
+func TestKeyVaultScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		vaultURI string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "public cloud vault",
+			vaultURI: "https://my-vault.vault.azure.net/",
+			want:     "https://vault.azure.net/.default",
+		},
+		{
+			name:     "china cloud vault",
+			vaultURI: "https://my-vault.vault.azure.cn",
+			want:     "https://vault.azure.cn/.default",
+		},
+		{
+			name:     "vault URI without domain",
+			vaultURI: "https://my-vault",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := keyVaultScope(tt.vaultURI)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestParseKeyVaultCertificate(t *testing.T) {
+	g := NewWithT(t)
+	// Azure only supports RSA keys for service principal certificates.
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake-service-principal"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	g.Expect(err).NotTo(HaveOccurred())
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})) +
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+
+	tests := []struct {
+		name    string
+		secret  keyvault.SecretBundle
+		wantErr bool
+	}{
+		{
+			name: "PEM certificate",
+			secret: keyvault.SecretBundle{
+				Value:       pointer.String(pemData),
+				ContentType: pointer.String("application/x-pem-file"),
+			},
+		},
+		{
+			name: "invalid PKCS12 certificate",
+			secret: keyvault.SecretBundle{
+				Value:       pointer.String("not base64"),
+				ContentType: pointer.String("application/x-pkcs12"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "secret without value",
+			secret:  keyvault.SecretBundle{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			certs, privateKey, err := parseKeyVaultCertificate(tt.secret)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(certs).To(HaveLen(1))
+			g.Expect(certs[0].Subject.CommonName).To(Equal("fake-service-principal"))
+			g.Expect(privateKey).NotTo(BeNil())
+		})
+	}
+}
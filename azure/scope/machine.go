@@ -20,7 +20,10 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
@@ -29,6 +32,7 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
@@ -39,7 +43,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
@@ -77,6 +84,10 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 
+	// Resume the long running operations which were in flight before the AzureMachine lost its status,
+	// e.g. when it was moved to another management cluster.
+	futures.Restore(params.AzureMachine)
+
 	return &MachineScope{
 		client:        params.Client,
 		Machine:       params.Machine,
@@ -84,6 +95,8 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		patchHelper:   helper,
 		ClusterScoper: params.ClusterScope,
 		cache:         params.Cache,
+
+		serviceOptions: serviceOptionsOf(params.ClusterScope),
 	}, nil
 }
 
@@ -96,6 +109,15 @@ type MachineScope struct {
 	Machine      *clusterv1.Machine
 	AzureMachine *infrav1.AzureMachine
 	cache        *MachineCache
+
+	// serviceOptions are those of the cluster scope.
+	serviceOptions ServiceOptions
+
+	// driftedResources are the names of the resources which no longer match their spec and were not corrected, by service.
+	driftedResources map[string][]string
+
+	// notifications are sent once the AzureMachine is patched.
+	notifications notify.Pending
 }
 
 // MachineCache stores common machine information so we don't have to hit the API multiple times within the same reconcile loop.
@@ -103,7 +125,13 @@ type MachineCache struct {
 	BootstrapData      string
 	VMImage            *infrav1.Image
 	VMSKU              resourceskus.SKU
-	availabilitySetSKU resourceskus.SKU
+	PremiumV2DiskZones []string
+	// VMSizeZones are the zones of the location where the VM size is available.
+	// It is only set when the machine has a failure domain policy which chooses a failure domain.
+	VMSizeZones []string
+	// VMExtensionsProtectedSettings are the protected settings from the secrets referenced by VM extensions, by extension name.
+	VMExtensionsProtectedSettings map[string]map[string]string
+	availabilitySetSKU            resourceskus.SKU
 }
 
 // InitMachineCache sets cached information about the machine to be used in the scope.
@@ -125,6 +153,11 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 			return err
 		}
 
+		m.cache.VMExtensionsProtectedSettings, err = getVMExtensionsProtectedSettings(ctx, m.client, m.Namespace(), m.AzureMachine.Spec.VMExtensions)
+		if err != nil {
+			return err
+		}
+
 		skuCache, err := resourceskus.GetCache(m, m.Location())
 		if err != nil {
 			return err
@@ -139,38 +172,161 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to get availability set SKU %s in compute api", string(compute.AvailabilitySetSkuTypesAligned))
 		}
+
+		if m.hasPremiumV2DataDisks() {
+			m.cache.PremiumV2DiskZones, err = skuCache.GetZonesWithDiskType(ctx, infrav1.StorageAccountTypePremiumV2LRS, m.Location())
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the zones of disk type %s in compute api", infrav1.StorageAccountTypePremiumV2LRS)
+			}
+		}
+
+		if m.choosesFailureDomain() {
+			m.cache.VMSizeZones, err = skuCache.GetZonesWithVMSize(ctx, m.AzureMachine.Spec.VMSize, m.Location())
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the zones of VM size %s in compute api", m.AzureMachine.Spec.VMSize)
+			}
+		}
 	}
 
 	return nil
 }
 
+// choosesFailureDomain returns true if the failure domain policy of the machine chooses a failure domain for it,
+// which is when neither the Machine nor the AzureMachine sets one and the VM hasn't been created yet.
+func (m *MachineScope) choosesFailureDomain() bool {
+	policy := m.AzureMachine.Spec.FailureDomainPolicy
+	if policy != infrav1.FailureDomainPolicySpread && policy != infrav1.FailureDomainPolicyPack {
+		return false
+	}
+	return m.AvailabilityZone() == "" && m.ProviderID() == "" && len(m.FailureDomains()) > 0
+}
+
+// AssignFailureDomain chooses a failure domain for the machine with its failure domain policy and sets it on the AzureMachine.
+// The failure domain is chosen from the allowed failure domains of the cluster where the VM size is available.
+func (m *MachineScope) AssignFailureDomain(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "azure.MachineScope.AssignFailureDomain")
+	defer done()
+
+	if !m.choosesFailureDomain() {
+		return nil
+	}
+	if m.cache == nil {
+		return errors.New("machine cache must be initialized to assign a failure domain")
+	}
+
+	allowed := m.AzureMachine.Spec.AllowedFailureDomains
+	var candidates []string
+	for _, fd := range m.FailureDomains() {
+		if (len(allowed) == 0 || slice.Contains(allowed, fd)) && slice.Contains(m.cache.VMSizeZones, fd) {
+			candidates = append(candidates, fd)
+		}
+	}
+	if len(candidates) == 0 {
+		return azure.WithTerminalError(errors.Errorf("none of the allowed failure domains of the cluster has VM size %s available in location %s", m.AzureMachine.Spec.VMSize, m.Location()))
+	}
+
+	counts, err := m.failureDomainMachineCounts(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to count machines by failure domain")
+	}
+
+	failureDomain := selectFailureDomain(m.AzureMachine.Spec.FailureDomainPolicy, candidates, counts)
+	log.V(2).Info("assigning failure domain to machine", "failureDomain", failureDomain, "policy", m.AzureMachine.Spec.FailureDomainPolicy)
+	m.AzureMachine.Spec.FailureDomain = pointer.String(failureDomain)
+	return nil
+}
+
+// failureDomainMachineCounts returns the number of other AzureMachines of the same MachineDeployment, or MachineSet
+// if the machine isn't part of a MachineDeployment, in each failure domain.
+func (m *MachineScope) failureDomainMachineCounts(ctx context.Context) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	labels := client.MatchingLabels{clusterv1.ClusterNameLabel: m.ClusterName()}
+	if mdName, ok := m.Machine.Labels[clusterv1.MachineDeploymentNameLabel]; ok {
+		labels[clusterv1.MachineDeploymentNameLabel] = mdName
+	} else if msName, ok := m.Machine.Labels[clusterv1.MachineSetNameLabel]; ok {
+		labels[clusterv1.MachineSetNameLabel] = msName
+	} else {
+		return counts, nil
+	}
+
+	azureMachines := &infrav1.AzureMachineList{}
+	if err := m.client.List(ctx, azureMachines, client.InNamespace(m.AzureMachine.Namespace), labels); err != nil {
+		return nil, err
+	}
+	for _, azureMachine := range azureMachines.Items {
+		if azureMachine.Name == m.AzureMachine.Name || !azureMachine.DeletionTimestamp.IsZero() || azureMachine.Spec.FailureDomain == nil {
+			continue
+		}
+		counts[*azureMachine.Spec.FailureDomain]++
+	}
+	return counts, nil
+}
+
+// selectFailureDomain returns the candidate failure domain with the fewest machines for the Spread policy,
+// or with the most machines for the Pack policy. Ties are broken by the order of the candidates.
+func selectFailureDomain(policy infrav1.FailureDomainPolicy, candidates []string, counts map[string]int) string {
+	selected := candidates[0]
+	for _, fd := range candidates[1:] {
+		switch policy {
+		case infrav1.FailureDomainPolicySpread:
+			if counts[fd] < counts[selected] {
+				selected = fd
+			}
+		case infrav1.FailureDomainPolicyPack:
+			if counts[fd] > counts[selected] {
+				selected = fd
+			}
+		}
+	}
+	return selected
+}
+
+// hasPremiumV2DataDisks returns true if any data disk of the machine is a Premium SSD v2 disk.
+func (m *MachineScope) hasPremiumV2DataDisks() bool {
+	for _, disk := range m.AzureMachine.Spec.DataDisks {
+		if disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == infrav1.StorageAccountTypePremiumV2LRS {
+			return true
+		}
+	}
+	return false
+}
+
 // VMSpec returns the VM spec.
 func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 	spec := &virtualmachines.VMSpec{
-		Name:                   m.Name(),
-		Location:               m.Location(),
-		ExtendedLocation:       m.ExtendedLocation(),
-		ResourceGroup:          m.ResourceGroup(),
-		ClusterName:            m.ClusterName(),
-		Role:                   m.Role(),
-		NICIDs:                 m.NICIDs(),
-		SSHKeyData:             m.AzureMachine.Spec.SSHPublicKey,
-		Size:                   m.AzureMachine.Spec.VMSize,
-		OSDisk:                 m.AzureMachine.Spec.OSDisk,
-		DataDisks:              m.AzureMachine.Spec.DataDisks,
-		AvailabilitySetID:      m.AvailabilitySetID(),
-		Zone:                   m.AvailabilityZone(),
-		Identity:               m.AzureMachine.Spec.Identity,
-		UserAssignedIdentities: m.AzureMachine.Spec.UserAssignedIdentities,
-		SpotVMOptions:          m.AzureMachine.Spec.SpotVMOptions,
-		SecurityProfile:        m.AzureMachine.Spec.SecurityProfile,
-		DiagnosticsProfile:     m.AzureMachine.Spec.Diagnostics,
-		AdditionalTags:         m.AdditionalTags(),
-		AdditionalCapabilities: m.AzureMachine.Spec.AdditionalCapabilities,
-		ProviderID:             m.ProviderID(),
+		Name:                       m.Name(),
+		Location:                   m.Location(),
+		ExtendedLocation:           m.ExtendedLocation(),
+		ResourceGroup:              m.ResourceGroup(),
+		ClusterName:                m.ClusterName(),
+		Role:                       m.Role(),
+		NICIDs:                     m.NICIDs(),
+		SSHKeyData:                 m.AzureMachine.Spec.SSHPublicKey,
+		Size:                       m.AzureMachine.Spec.VMSize,
+		OSDisk:                     m.AzureMachine.Spec.OSDisk,
+		DataDisks:                  m.AzureMachine.Spec.DataDisks,
+		OSDiskName:                 namer.OSDiskName(m.Name()),
+		DataDiskNames:              m.dataDiskNames(),
+		AvailabilitySetID:          m.AvailabilitySetID(),
+		Zone:                       m.AvailabilityZone(),
+		Identity:                   m.AzureMachine.Spec.Identity,
+		UserAssignedIdentities:     m.AzureMachine.Spec.UserAssignedIdentities,
+		SpotVMOptions:              m.AzureMachine.Spec.SpotVMOptions,
+		SecurityProfile:            m.AzureMachine.Spec.SecurityProfile,
+		DedicatedHost:              m.AzureMachine.Spec.DedicatedHost,
+		CapacityReservationGroupID: m.AzureMachine.Spec.CapacityReservationGroupID,
+		ProximityPlacementGroupID:  m.ProximityPlacementGroupID(),
+		DiagnosticsProfile:         m.AzureMachine.Spec.Diagnostics,
+		GPUDriver:                  m.AzureMachine.Spec.GPUDriver,
+		AdditionalTags:             m.AdditionalTags(),
+		AdditionalCapabilities:     m.AzureMachine.Spec.AdditionalCapabilities,
+		ProviderID:                 m.ProviderID(),
+		PowerState:                 infrav1.VMPowerState(m.AzureMachine.Annotations[infrav1.PowerStateAnnotation]),
 	}
 	if m.cache != nil {
 		spec.SKU = m.cache.VMSKU
+		spec.PremiumV2DiskZones = m.cache.PremiumV2DiskZones
 		spec.Image = m.cache.VMImage
 		spec.BootstrapData = m.cache.BootstrapData
 	}
@@ -179,21 +335,24 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 
 // TagsSpecs returns the tags for the AzureMachine.
 func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
-	return []azure.TagsSpec{
+	tagsSpecs := []azure.TagsSpec{
 		{
 			Scope:      azure.VMID(m.SubscriptionID(), m.ResourceGroup(), m.Name()),
 			Tags:       m.AdditionalTags(),
 			Annotation: azure.VMTagsLastAppliedAnnotation,
 		},
 	}
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(m.SubscriptionID(), m.NICSpecs(), azure.NetworkInterfaceID, m.AdditionalTags())...)
+	tagsSpecs = append(tagsSpecs, resourceTagsSpecs(m.SubscriptionID(), m.PublicIPSpecs(), azure.PublicIPID, m.AdditionalTags())...)
+	return tagsSpecs
 }
 
 // PublicIPSpecs returns the public IP specs.
 func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
 	if m.AzureMachine.Spec.AllocatePublicIP {
-		specs = append(specs, &publicips.PublicIPSpec{
-			Name:             azure.GenerateNodePublicIPName(m.Name()),
+		spec := &publicips.PublicIPSpec{
+			Name:             namer.NodePublicIPName(m.Name()),
 			ResourceGroup:    m.ResourceGroup(),
 			ClusterName:      m.ClusterName(),
 			DNSName:          "",    // Set to default value
@@ -202,7 +361,15 @@ func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 			ExtendedLocation: m.ExtendedLocation(),
 			FailureDomains:   m.FailureDomains(),
 			AdditionalTags:   m.ClusterScoper.AdditionalTags(),
-		})
+			PublicIPPrefixID: pointer.StringDeref(m.AzureMachine.Spec.PublicIPPrefixID, ""),
+		}
+		if publicIP := m.AzureMachine.Spec.PublicIP; publicIP != nil {
+			if publicIP.DNSLabelPrefix != "" {
+				spec.DNSName = azure.GenerateNodePublicIPDNSLabel(publicIP.DNSLabelPrefix, m.Name())
+			}
+			spec.IPTags = publicIP.IPTags
+		}
+		specs = append(specs, spec)
 	}
 	return specs
 }
@@ -238,12 +405,28 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 
 	for i := 0; i < len(m.AzureMachine.Spec.NetworkInterfaces); i++ {
 		isPrimary := i == 0
-		nicName := azure.GenerateNICName(m.Name(), isMultiNIC, i)
-		nicSpecs = append(nicSpecs, m.BuildNICSpec(nicName, m.AzureMachine.Spec.NetworkInterfaces[i], isPrimary))
+		nicName := m.NamingConvention().ResourceName(infrav1.NetworkInterfaceNamingResourceType, namer.NICName(m.Name(), isMultiNIC, i))
+		nicSpec := m.BuildNICSpec(nicName, m.AzureMachine.Spec.NetworkInterfaces[i], isPrimary)
+		m.applyClaimedIPAddresses(nicSpec, i)
+		nicSpecs = append(nicSpecs, nicSpec)
 	}
 	return nicSpecs
 }
 
+// applyClaimedIPAddresses sets the private IPs of a network interface to the addresses claimed from IPAM pools.
+func (m *MachineScope) applyClaimedIPAddresses(spec *networkinterfaces.NICSpec, nicIndex int) {
+	for _, claim := range m.AzureMachine.Status.IPAddressClaims {
+		if claim.NetworkInterface != nicIndex || claim.Address == "" {
+			continue
+		}
+		if claim.IPConfig == 0 {
+			spec.StaticIPAddress = claim.Address
+		} else if claim.IPConfig < len(spec.IPConfigs) {
+			spec.IPConfigs[claim.IPConfig].PrivateIP = pointer.String(claim.Address)
+		}
+	}
+}
+
 // BuildNICSpec takes a NetworkInterface from the AzureMachineSpec and returns a NICSpec for use by the networkinterfaces service.
 func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infrav1.NetworkInterface, primaryNetworkInterface bool) *networkinterfaces.NICSpec {
 	spec := &networkinterfaces.NICSpec{
@@ -268,8 +451,28 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 		spec.SKU = &m.cache.VMSKU
 	}
 
+	if infrav1NetworkInterface.PrivateIPAddress != "" {
+		spec.StaticIPAddress = infrav1NetworkInterface.PrivateIPAddress
+	}
+
+	if infrav1NetworkInterface.EnableIPForwarding != nil {
+		spec.EnableIPForwarding = *infrav1NetworkInterface.EnableIPForwarding
+	}
+
+	for _, asgName := range infrav1NetworkInterface.ApplicationSecurityGroups {
+		spec.ApplicationSecurityGroupIDs = append(spec.ApplicationSecurityGroupIDs, azure.ApplicationSecurityGroupID(m.SubscriptionID(), m.ResourceGroup(), asgName))
+	}
+
 	for i := 0; i < infrav1NetworkInterface.PrivateIPConfigs; i++ {
-		spec.IPConfigs = append(spec.IPConfigs, networkinterfaces.IPConfig{})
+		ipConfig := networkinterfaces.IPConfig{}
+		if i < len(infrav1NetworkInterface.IPConfigs) && infrav1NetworkInterface.IPConfigs[i].PrivateIPAddress != "" {
+			if i == 0 {
+				spec.StaticIPAddress = infrav1NetworkInterface.IPConfigs[i].PrivateIPAddress
+			} else {
+				ipConfig.PrivateIP = pointer.String(infrav1NetworkInterface.IPConfigs[i].PrivateIPAddress)
+			}
+		}
+		spec.IPConfigs = append(spec.IPConfigs, ipConfig)
 	}
 
 	if primaryNetworkInterface {
@@ -284,11 +487,16 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 			} else {
 				spec.PublicLBNATRuleName = m.Name()
 				spec.PublicLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+				// Private frontend IPs of a public API Server LB are served by a separate internal LB.
+				if len(m.APIServerLB().PrivateFrontendIPs()) > 0 {
+					spec.InternalLBName = namer.InternalAPIServerLBName(m.APIServerLBName())
+					spec.InternalLBAddressPoolName = m.APIServerLBPoolName(spec.InternalLBName)
+				}
 			}
 		}
 
 		if m.Role() == infrav1.Node && m.AzureMachine.Spec.AllocatePublicIP {
-			spec.PublicIPName = azure.GenerateNodePublicIPName(m.Name())
+			spec.PublicIPName = namer.NodePublicIPName(m.Name())
 		}
 		// If the NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
 		if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !m.AzureMachine.Spec.AllocatePublicIP {
@@ -320,19 +528,28 @@ func (m *MachineScope) NICIDs() []string {
 func (m *MachineScope) DiskSpecs() []azure.ResourceSpecGetter {
 	diskSpecs := make([]azure.ResourceSpecGetter, 1+len(m.AzureMachine.Spec.DataDisks))
 	diskSpecs[0] = &disks.DiskSpec{
-		Name:          azure.GenerateOSDiskName(m.Name()),
+		Name:          namer.OSDiskName(m.Name()),
 		ResourceGroup: m.ResourceGroup(),
 	}
 
-	for i, dd := range m.AzureMachine.Spec.DataDisks {
+	for i, name := range m.dataDiskNames() {
 		diskSpecs[i+1] = &disks.DiskSpec{
-			Name:          azure.GenerateDataDiskName(m.Name(), dd.NameSuffix),
+			Name:          name,
 			ResourceGroup: m.ResourceGroup(),
 		}
 	}
 	return diskSpecs
 }
 
+// dataDiskNames returns the names of the data disks of the machine, in the same order as its data disks.
+func (m *MachineScope) dataDiskNames() []string {
+	names := make([]string, len(m.AzureMachine.Spec.DataDisks))
+	for i, dd := range m.AzureMachine.Spec.DataDisks {
+		names[i] = namer.DataDiskName(m.Name(), dd.NameSuffix)
+	}
+	return names
+}
+
 // RoleAssignmentSpecs returns the role assignment specs.
 func (m *MachineScope) RoleAssignmentSpecs(principalID *string) []azure.ResourceSpecGetter {
 	roles := make([]azure.ResourceSpecGetter, 1)
@@ -365,22 +582,29 @@ func (m *MachineScope) HasSystemAssignedIdentity() bool {
 // VMExtensionSpecs returns the VM extension specs.
 func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 	var extensionSpecs = []azure.ResourceSpecGetter{}
+	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachine.Spec.OSDisk.OSType, m.CloudEnvironment(), m.Name())
+
+	var protectedSettings map[string]map[string]string
+	if m.cache != nil {
+		protectedSettings = m.cache.VMExtensionsProtectedSettings
+	}
 	for _, extension := range m.AzureMachine.Spec.VMExtensions {
 		extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
-			ExtensionSpec: azure.ExtensionSpec{
-				Name:              extension.Name,
-				VMName:            m.Name(),
-				Publisher:         extension.Publisher,
-				Version:           extension.Version,
-				Settings:          extension.Settings,
-				ProtectedSettings: extension.ProtectedSettings,
-			},
+			ExtensionSpec: customVMExtensionSpec(extension, m.Name(), protectedSettings[extension.Name], bootstrapExtensionSpec),
 			ResourceGroup: m.ResourceGroup(),
 			Location:      m.Location(),
 		})
 	}
 
-	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachine.Spec.OSDisk.OSType, m.CloudEnvironment(), m.Name())
+	gpuDriverExtensionSpec := azure.GetGPUDriverVMExtension(m.AzureMachine.Spec.GPUDriver, m.AzureMachine.Spec.OSDisk.OSType, m.Name())
+
+	if gpuDriverExtensionSpec != nil {
+		extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
+			ExtensionSpec: *gpuDriverExtensionSpec,
+			ResourceGroup: m.ResourceGroup(),
+			Location:      m.Location(),
+		})
+	}
 
 	if bootstrapExtensionSpec != nil {
 		extensionSpecs = append(extensionSpecs, &vmextensions.VMExtensionSpec{
@@ -393,6 +617,61 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 	return extensionSpecs
 }
 
+// customVMExtensionSpec returns the extension spec of a custom VM extension, with the protected settings from its secret
+// and a dependency on the bootstrapping extension if the extension is provisioned after bootstrap.
+func customVMExtensionSpec(extension infrav1.VMExtension, vmName string, secretProtectedSettings map[string]string, bootstrapExtensionSpec *azure.ExtensionSpec) azure.ExtensionSpec {
+	spec := azure.ExtensionSpec{
+		Name:              extension.Name,
+		Type:              extension.Type,
+		VMName:            vmName,
+		Publisher:         extension.Publisher,
+		Version:           extension.Version,
+		Settings:          extension.Settings,
+		ProtectedSettings: extension.ProtectedSettings,
+	}
+
+	if len(secretProtectedSettings) > 0 {
+		spec.ProtectedSettings = make(map[string]string, len(extension.ProtectedSettings)+len(secretProtectedSettings))
+		for k, v := range extension.ProtectedSettings {
+			spec.ProtectedSettings[k] = v
+		}
+		for k, v := range secretProtectedSettings {
+			spec.ProtectedSettings[k] = v
+		}
+	}
+
+	if extension.ProvisionAfterBootstrap && bootstrapExtensionSpec != nil {
+		spec.ProvisionAfterExtensions = []string{bootstrapExtensionSpec.Name}
+	}
+
+	return spec
+}
+
+// getVMExtensionsProtectedSettings returns the protected settings from the secrets referenced by the VM extensions,
+// by extension name.
+func getVMExtensionsProtectedSettings(ctx context.Context, c client.Client, namespace string, extensions []infrav1.VMExtension) (map[string]map[string]string, error) {
+	protectedSettings := make(map[string]map[string]string)
+	for _, extension := range extensions {
+		if extension.ProtectedSettingsSecretRef == nil {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: namespace, Name: extension.ProtectedSettingsSecretRef.Name}
+		if err := c.Get(ctx, key, secret); err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve protected settings secret %s/%s of VM extension %s", namespace, key.Name, extension.Name)
+		}
+
+		settings := make(map[string]string, len(secret.Data))
+		for k, v := range secret.Data {
+			settings[k] = string(v)
+		}
+		protectedSettings[extension.Name] = settings
+	}
+
+	return protectedSettings, nil
+}
+
 // Subnet returns the machine's subnet.
 func (m *MachineScope) Subnet() infrav1.SubnetSpec {
 	for _, subnet := range m.Subnets() {
@@ -477,12 +756,27 @@ func (m *MachineScope) AvailabilitySetSpec() azure.ResourceSpecGetter {
 	}
 
 	spec := &availabilitysets.AvailabilitySetSpec{
-		Name:           availabilitySetName,
-		ResourceGroup:  m.ResourceGroup(),
-		ClusterName:    m.ClusterName(),
-		Location:       m.Location(),
-		SKU:            nil,
-		AdditionalTags: m.AdditionalTags(),
+		Name:                      availabilitySetName,
+		ResourceGroup:             m.ResourceGroup(),
+		ClusterName:               m.ClusterName(),
+		Location:                  m.Location(),
+		SKU:                       nil,
+		AdditionalTags:            m.AdditionalTags(),
+		ProximityPlacementGroupID: m.ProximityPlacementGroupID(),
+	}
+
+	// Domain counts set on the machine take precedence over the defaults of the cluster.
+	if counts := m.AvailabilitySetDomainCounts(); counts != nil {
+		spec.FaultDomainCount = counts.FaultDomainCount
+		spec.UpdateDomainCount = counts.UpdateDomainCount
+	}
+	if counts := m.AzureMachine.Spec.AvailabilitySetDomainCounts; counts != nil {
+		if counts.FaultDomainCount != nil {
+			spec.FaultDomainCount = counts.FaultDomainCount
+		}
+		if counts.UpdateDomainCount != nil {
+			spec.UpdateDomainCount = counts.UpdateDomainCount
+		}
 	}
 
 	if m.cache != nil {
@@ -499,18 +793,28 @@ func (m *MachineScope) AvailabilitySet() (string, bool) {
 		return "", false
 	}
 
+	// VMs on dedicated hosts are spread across the fault domains of their host group instead.
+	if m.AzureMachine != nil && m.AzureMachine.Spec.DedicatedHost != nil {
+		return "", false
+	}
+
+	// Capacity reservations can't be consumed by VMs in an availability set.
+	if m.AzureMachine != nil && m.AzureMachine.Spec.CapacityReservationGroupID != "" {
+		return "", false
+	}
+
 	if m.IsControlPlane() {
-		return azure.GenerateAvailabilitySetName(m.ClusterName(), azure.ControlPlaneNodeGroup), true
+		return namer.AvailabilitySetName(m.ClusterName(), azure.ControlPlaneNodeGroup), true
 	}
 
 	// get machine deployment name from labels for machines that maybe part of a machine deployment.
 	if mdName, ok := m.Machine.Labels[clusterv1.MachineDeploymentNameLabel]; ok {
-		return azure.GenerateAvailabilitySetName(m.ClusterName(), mdName), true
+		return namer.AvailabilitySetName(m.ClusterName(), mdName), true
 	}
 
 	// if machine deployment name label is not available, use machine set name.
 	if msName, ok := m.Machine.Labels[clusterv1.MachineSetNameLabel]; ok {
-		return azure.GenerateAvailabilitySetName(m.ClusterName(), msName), true
+		return namer.AvailabilitySetName(m.ClusterName(), msName), true
 	}
 
 	return "", false
@@ -525,6 +829,14 @@ func (m *MachineScope) AvailabilitySetID() string {
 	return asID
 }
 
+// ProximityPlacementGroupID returns the proximity placement group for this machine, or "" if there is none.
+func (m *MachineScope) ProximityPlacementGroupID() string {
+	if m.AzureMachine.Spec.ProximityPlacementGroupName == "" {
+		return ""
+	}
+	return azure.ProximityPlacementGroupID(m.SubscriptionID(), m.ResourceGroup(), m.AzureMachine.Spec.ProximityPlacementGroupName)
+}
+
 // SystemAssignedIdentityName returns the role assignment name for the system assigned identity.
 func (m *MachineScope) SystemAssignedIdentityName() string {
 	if m.AzureMachine.Spec.SystemAssignedIdentityRole != nil {
@@ -632,11 +944,21 @@ func (m *MachineScope) SetAddresses(addrs []corev1.NodeAddress) {
 	m.AzureMachine.Status.Addresses = addrs
 }
 
+// SetIPAddressClaims sets the IPAM claims status.
+func (m *MachineScope) SetIPAddressClaims(claims []infrav1.IPAddressClaim) {
+	m.AzureMachine.Status.IPAddressClaims = claims
+}
+
 // PatchObject persists the machine spec and status.
 func (m *MachineScope) PatchObject(ctx context.Context) error {
+	futures.SetBlockMoveAnnotation(m.AzureMachine)
+	if err := futures.Checkpoint(m.AzureMachine); err != nil {
+		return err
+	}
 	conditions.SetSummary(m.AzureMachine)
+	m.setOperationStatus()
 
-	return m.patchHelper.Patch(
+	if err := m.patchHelper.Patch(
 		ctx,
 		m.AzureMachine,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
@@ -644,7 +966,28 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 			infrav1.VMRunningCondition,
 			infrav1.AvailabilitySetReadyCondition,
 			infrav1.NetworkInterfaceReadyCondition,
-		}})
+			infrav1.IPAddressesClaimedCondition,
+			infrav1.DisksReadyCondition,
+			infrav1.PublicIPsReadyCondition,
+			infrav1.InboundNATRulesReadyCondition,
+			infrav1.RoleAssignmentReadyCondition,
+		}}); err != nil {
+		return err
+	}
+	m.notifications.Send(ctx)
+	return nil
+}
+
+// setOperationStatus reports the progress of the ongoing operation on the virtual machine, if any.
+func (m *MachineScope) setOperationStatus() {
+	state := operationFromFutures(m.VMState(), m.AzureMachine.Status.LongRunningOperationStates, "virtualmachine", m.Name())
+	message := fmt.Sprintf("%s virtual machine %s", state, m.Name())
+	m.AzureMachine.Status.OperationStatus = newOperationStatus(m.AzureMachine.Status.OperationStatus, state, message, 0, 0)
+}
+
+// Notify notifies that the AzureMachine reached a lifecycle milestone, once its status is persisted.
+func (m *MachineScope) Notify(eventType notify.EventType, message string) {
+	m.notifications.Add("AzureMachine", m.AzureMachine, eventType, message)
 }
 
 // Close the MachineScope by updating the machine spec, machine status.
@@ -666,6 +1009,16 @@ func (m *MachineScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
+// AzureServiceReconcileTimeout returns how long the named service may take to reconcile the Azure resources of the AzureMachine.
+func (m *MachineScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	return m.serviceOptions.Timeouts.AzureServiceReconcileTimeout(serviceName)
+}
+
+// DriftPolicy returns how the Azure resources of the AzureMachine which no longer match their spec are handled.
+func (m *MachineScope) DriftPolicy() async.DriftPolicy {
+	return m.serviceOptions.DriftPolicy
+}
+
 // GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
 func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetBootstrapData")
@@ -693,7 +1046,7 @@ func (m *MachineScope) GetVMImage(ctx context.Context) (*infrav1.Image, error) {
 	defer done()
 
 	// Use custom Marketplace image, Image ID or a Shared Image Gallery image if provided
-	if m.AzureMachine.Spec.Image != nil {
+	if m.AzureMachine.Spec.Image != nil && !virtualmachineimages.NeedsVersionResolution(m.AzureMachine.Spec.Image) {
 		return m.AzureMachine.Spec.Image, nil
 	}
 
@@ -702,6 +1055,16 @@ func (m *MachineScope) GetVMImage(ctx context.Context) (*infrav1.Image, error) {
 		return nil, errors.Wrap(err, "failed to create virtualmachineimages service")
 	}
 
+	// Resolve the version of an Azure Compute Gallery image and record it in the status, so it stays the same across reconciles.
+	if m.AzureMachine.Spec.Image != nil {
+		image, err := svc.ResolveComputeGalleryImage(ctx, m.AzureMachine.Spec.Image, m.AzureMachine.Status.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve compute gallery image version")
+		}
+		m.AzureMachine.Status.Image = image
+		return image, nil
+	}
+
 	if m.AzureMachine.Spec.OSDisk.OSType == azure.WindowsOS {
 		runtime := m.AzureMachine.Annotations["runtime"]
 		windowsServerVersion := m.AzureMachine.Annotations["windowsServerVersion"]
@@ -737,6 +1100,65 @@ func (m *MachineScope) SetSubnetName() error {
 	return nil
 }
 
+// ValidatePrivateIPAddresses returns a terminal error if a static private IP address of a network interface is not within
+// the address range of the subnet of the interface. Subnets which are not part of the cluster network spec are not checked.
+func (m *MachineScope) ValidatePrivateIPAddresses() error {
+	for i, nic := range m.AzureMachine.Spec.NetworkInterfaces {
+		addresses := []string{}
+		if nic.PrivateIPAddress != "" {
+			addresses = append(addresses, nic.PrivateIPAddress)
+		}
+		for _, ipConfig := range nic.IPConfigs {
+			if ipConfig.PrivateIPAddress != "" {
+				addresses = append(addresses, ipConfig.PrivateIPAddress)
+			}
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+
+		var cidrBlocks []string
+		for _, subnet := range m.Subnets() {
+			if subnet.Name == nic.SubnetName {
+				cidrBlocks = subnet.CIDRBlocks
+				break
+			}
+		}
+		if len(cidrBlocks) == 0 {
+			continue
+		}
+
+		for _, address := range addresses {
+			if !ipInCIDRBlocks(address, cidrBlocks) {
+				return azure.WithTerminalError(errors.Errorf("private IP address %s of network interface %d is not within the address range of subnet %s (%s)",
+					address, i, nic.SubnetName, strings.Join(cidrBlocks, ", ")))
+			}
+		}
+	}
+
+	return nil
+}
+
+// ipInCIDRBlocks returns true if the IP address is within one of the CIDR blocks.
+func ipInCIDRBlocks(address string, cidrBlocks []string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cidrBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportEvent emits an event on the AzureMachine for a change of one of its Azure resources.
+func (m *MachineScope) ReportEvent(eventType, reason, message string) {
+	record.Emit(m.AzureMachine, eventType, reason, message)
+}
+
 // SetLongRunningOperationState will set the future on the AzureMachine status to allow the resource to continue
 // in the next reconciliation.
 func (m *MachineScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -761,20 +1183,36 @@ func (m *MachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachine, condition, failedReason(err, infrav1.DeletionFailedReason), clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
 }
 
 // UpdatePutStatus updates a condition on the AzureMachine status after a PUT operation.
 func (m *MachineScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
+	case err == nil && len(m.driftedResources[service]) > 0:
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DriftDetectedReason, clusterv1.ConditionSeverityWarning, "%s does not match its spec: %s", service, strings.Join(m.driftedResources[service], ", "))
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachine, condition)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachine, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+	}
+}
+
+// ReportDrift emits an event for a resource which no longer matches its spec, and keeps track of it until the
+// status of its service is updated if it was not corrected.
+func (m *MachineScope) ReportDrift(serviceName, resourceName string, corrected bool) {
+	if corrected {
+		record.Warnf(m.AzureMachine, infrav1.DriftDetectedReason, "%s %s did not match its spec and was updated", serviceName, resourceName)
+		return
+	}
+	record.Warnf(m.AzureMachine, infrav1.DriftDetectedReason, "%s %s does not match its spec", serviceName, resourceName)
+	if m.driftedResources == nil {
+		m.driftedResources = make(map[string][]string)
 	}
+	m.driftedResources[serviceName] = append(m.driftedResources[serviceName], resourceName)
 }
 
 // UpdatePatchStatus updates a condition on the AzureMachine status after a PATCH operation.
@@ -785,6 +1223,6 @@ func (m *MachineScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
-		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachine, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
@@ -26,11 +26,14 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
@@ -41,6 +44,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestMachineScope_Name(t *testing.T) {
@@ -326,6 +330,79 @@ func TestMachineScope_PublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sets public IP prefix, DNS label and IP tags of node public IP",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						AllocatePublicIP: true,
+						PublicIPPrefixID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+						PublicIP: &infrav1.NodePublicIP{
+							DNSLabelPrefix: "nodes",
+							IPTags:         []infrav1.IPTag{{Type: "RoutingPreference", Tag: "Internet"}},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-cluster",
+							// Note: m.ClusterName() takes the value from the Cluster object, not the AzureCluster object
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-cluster",
+						},
+						Status: infrav1.AzureClusterStatus{
+							FailureDomains: map[string]clusterv1.FailureDomainSpec{
+								"failure-domain-id-1": {},
+								"failure-domain-id-2": {},
+								"failure-domain-id-3": {},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								SubscriptionID: "123",
+								Location:       "centralIndia",
+								AdditionalTags: infrav1.Tags{
+									"Name": "my-publicip-ipv6",
+									"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+								},
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								APIServerLB: infrav1.LoadBalancerSpec{
+									LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+										Type: infrav1.Internal,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "pip-machine-name",
+					ResourceGroup:  "my-rg",
+					DNSName:        "nodes-machine-name",
+					IsIPv6:         false,
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+					AdditionalTags: infrav1.Tags{
+						"Name": "my-publicip-ipv6",
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					},
+					IPTags:           []infrav1.IPTag{{Type: "RoutingPreference", Tag: "Internet"}},
+					PublicIPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -774,6 +851,52 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{},
 		},
+		{
+			name: "If the GPU driver is specified, it returns the GPU driver VM extension",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						OSDisk: infrav1.OSDisk{
+							OSType: "Windows",
+						},
+						GPUDriver: &infrav1.GPUDriver{},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.USGovernmentCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vmextensions.VMExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "NvidiaGpuDriverWindows",
+						VMName:    "machine-name",
+						Publisher: "Microsoft.HpcCompute",
+						Version:   "1.4",
+					},
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+				},
+			},
+		},
 		{
 			name: "If a custom VM extension is specified, it returns the custom VM extension",
 			machineScope: MachineScope{
@@ -1028,6 +1151,230 @@ func TestMachineScope_AvailabilityZone(t *testing.T) {
 	}
 }
 
+func TestMachineScope_ValidatePrivateIPAddresses(t *testing.T) {
+	tests := []struct {
+		name              string
+		networkInterfaces []infrav1.NetworkInterface
+		wantErr           string
+	}{
+		{
+			name:              "no static private IP addresses",
+			networkInterfaces: []infrav1.NetworkInterface{{SubnetName: "cp-subnet", PrivateIPConfigs: 1}},
+		},
+		{
+			name:              "private IP address within the subnet",
+			networkInterfaces: []infrav1.NetworkInterface{{SubnetName: "cp-subnet", PrivateIPAddress: "10.0.0.10", PrivateIPConfigs: 1}},
+		},
+		{
+			name:              "private IP address outside of the subnet",
+			networkInterfaces: []infrav1.NetworkInterface{{SubnetName: "cp-subnet", PrivateIPAddress: "10.1.0.10", PrivateIPConfigs: 1}},
+			wantErr:           "private IP address 10.1.0.10 of network interface 0 is not within the address range of subnet cp-subnet (10.0.0.0/24)",
+		},
+		{
+			name: "secondary ipConfig private IP address outside of the subnet",
+			networkInterfaces: []infrav1.NetworkInterface{{
+				SubnetName:       "cp-subnet",
+				PrivateIPConfigs: 2,
+				IPConfigs:        []infrav1.NetworkInterfaceIPConfig{{}, {PrivateIPAddress: "10.1.0.11"}},
+			}},
+			wantErr: "private IP address 10.1.0.11 of network interface 0 is not within the address range of subnet cp-subnet (10.0.0.0/24)",
+		},
+		{
+			name:              "subnet not in the cluster network spec",
+			networkInterfaces: []infrav1.NetworkInterface{{SubnetName: "other-subnet", PrivateIPAddress: "10.1.0.10", PrivateIPConfigs: 1}},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							NetworkSpec: infrav1.NetworkSpec{
+								Subnets: infrav1.Subnets{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role:       infrav1.SubnetControlPlane,
+											Name:       "cp-subnet",
+											CIDRBlocks: []string{"10.0.0.0/24"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						NetworkInterfaces: tc.networkInterfaces,
+					},
+				},
+			}
+
+			err := machineScope.ValidatePrivateIPAddresses()
+			if tc.wantErr != "" {
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+				g.Expect(reconcileErr.IsTerminal()).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring(tc.wantErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestMachineScope_AssignFailureDomain(t *testing.T) {
+	mdLabels := map[string]string{
+		clusterv1.ClusterNameLabel:           "cluster",
+		clusterv1.MachineDeploymentNameLabel: "md-0",
+	}
+	azureMachineInZone := func(name, zone string) *infrav1.AzureMachine {
+		return &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: mdLabels},
+			Spec:       infrav1.AzureMachineSpec{FailureDomain: pointer.String(zone)},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		policy                infrav1.FailureDomainPolicy
+		allowedFailureDomains []string
+		vmSizeZones           []string
+		machineFailureDomain  *string
+		providerID            *string
+		existing              []runtime.Object
+		want                  *string
+		wantErr               bool
+	}{
+		{
+			name:        "does not assign a failure domain without a policy",
+			vmSizeZones: []string{"1", "2", "3"},
+			want:        nil,
+		},
+		{
+			name:        "does not assign a failure domain with the None policy",
+			policy:      infrav1.FailureDomainPolicyNone,
+			vmSizeZones: []string{"1", "2", "3"},
+			want:        nil,
+		},
+		{
+			name:                 "does not assign a failure domain if the machine sets one",
+			policy:               infrav1.FailureDomainPolicySpread,
+			vmSizeZones:          []string{"1", "2", "3"},
+			machineFailureDomain: pointer.String("3"),
+			want:                 nil,
+		},
+		{
+			name:        "does not assign a failure domain if the VM was already created",
+			policy:      infrav1.FailureDomainPolicySpread,
+			vmSizeZones: []string{"1", "2", "3"},
+			providerID:  pointer.String("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"),
+			want:        nil,
+		},
+		{
+			name:        "spreads the machine to the failure domain with the fewest machines",
+			policy:      infrav1.FailureDomainPolicySpread,
+			vmSizeZones: []string{"1", "2", "3"},
+			existing: []runtime.Object{
+				azureMachineInZone("m-1", "1"),
+				azureMachineInZone("m-2", "1"),
+				azureMachineInZone("m-3", "2"),
+				azureMachineInZone("m-4", "3"),
+			},
+			want: pointer.String("2"),
+		},
+		{
+			name:        "packs the machine into the failure domain with the most machines",
+			policy:      infrav1.FailureDomainPolicyPack,
+			vmSizeZones: []string{"1", "2", "3"},
+			existing: []runtime.Object{
+				azureMachineInZone("m-1", "3"),
+				azureMachineInZone("m-2", "2"),
+				azureMachineInZone("m-3", "3"),
+			},
+			want: pointer.String("3"),
+		},
+		{
+			name:                  "only chooses from the allowed failure domains",
+			policy:                infrav1.FailureDomainPolicySpread,
+			allowedFailureDomains: []string{"1", "3"},
+			vmSizeZones:           []string{"1", "2", "3"},
+			existing: []runtime.Object{
+				azureMachineInZone("m-1", "1"),
+			},
+			want: pointer.String("3"),
+		},
+		{
+			name:        "only chooses failure domains where the VM size is available",
+			policy:      infrav1.FailureDomainPolicySpread,
+			vmSizeZones: []string{"2", "3"},
+			want:        pointer.String("2"),
+		},
+		{
+			name:                  "returns an error if no allowed failure domain has the VM size available",
+			policy:                infrav1.FailureDomainPolicySpread,
+			allowedFailureDomains: []string{"1"},
+			vmSizeZones:           []string{"2", "3"},
+			wantErr:               true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+
+			azureMachine := &infrav1.AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "m-0", Namespace: "default", Labels: mdLabels},
+				Spec: infrav1.AzureMachineSpec{
+					VMSize:                "Standard_D2s_v3",
+					ProviderID:            tt.providerID,
+					FailureDomainPolicy:   tt.policy,
+					AllowedFailureDomains: tt.allowedFailureDomains,
+				},
+			}
+			machineScope := MachineScope{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.existing...).Build(),
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "test-location"},
+						},
+						Status: infrav1.AzureClusterStatus{
+							FailureDomains: clusterv1.FailureDomains{
+								"1": clusterv1.FailureDomainSpec{},
+								"2": clusterv1.FailureDomainSpec{},
+								"3": clusterv1.FailureDomainSpec{},
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Labels: mdLabels},
+					Spec:       clusterv1.MachineSpec{FailureDomain: tt.machineFailureDomain},
+				},
+				AzureMachine: azureMachine,
+				cache:        &MachineCache{VMSizeZones: tt.vmSizeZones},
+			}
+
+			err := machineScope.AssignFailureDomain(context.TODO())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(azureMachine.Spec.FailureDomain).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestMachineScope_Namespace(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1159,6 +1506,77 @@ func TestMachineScope_Role(t *testing.T) {
 	}
 }
 
+func TestMachineScope_AvailabilitySetSpec(t *testing.T) {
+	tests := []struct {
+		name                  string
+		clusterDomainCounts   *infrav1.AvailabilitySetDomainCounts
+		machineDomainCounts   *infrav1.AvailabilitySetDomainCounts
+		wantFaultDomainCount  *int32
+		wantUpdateDomainCount *int32
+	}{
+		{
+			name:                  "no domain counts",
+			wantFaultDomainCount:  nil,
+			wantUpdateDomainCount: nil,
+		},
+		{
+			name:                  "domain counts of the cluster",
+			clusterDomainCounts:   &infrav1.AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(2), UpdateDomainCount: pointer.Int32(10)},
+			wantFaultDomainCount:  pointer.Int32(2),
+			wantUpdateDomainCount: pointer.Int32(10),
+		},
+		{
+			name:                  "domain counts of the machine override the cluster per field",
+			clusterDomainCounts:   &infrav1.AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(2), UpdateDomainCount: pointer.Int32(10)},
+			machineDomainCounts:   &infrav1.AvailabilitySetDomainCounts{UpdateDomainCount: pointer.Int32(20)},
+			wantFaultDomainCount:  pointer.Int32(2),
+			wantUpdateDomainCount: pointer.Int32(20),
+		},
+		{
+			name:                  "domain counts of the machine",
+			machineDomainCounts:   &infrav1.AvailabilitySetDomainCounts{FaultDomainCount: pointer.Int32(1), UpdateDomainCount: pointer.Int32(1)},
+			wantFaultDomainCount:  pointer.Int32(1),
+			wantUpdateDomainCount: pointer.Int32(1),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								AvailabilitySetDomainCounts: tt.clusterDomainCounts,
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{clusterv1.MachineDeploymentNameLabel: "md-0"},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						AvailabilitySetDomainCounts: tt.machineDomainCounts,
+					},
+				},
+			}
+
+			spec, ok := machineScope.AvailabilitySetSpec().(*availabilitysets.AvailabilitySetSpec)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(spec.Name).To(Equal("cluster_md-0-as"))
+			g.Expect(spec.FaultDomainCount).To(Equal(tt.wantFaultDomainCount))
+			g.Expect(spec.UpdateDomainCount).To(Equal(tt.wantUpdateDomainCount))
+		})
+	}
+}
+
 func TestMachineScope_AvailabilitySet(t *testing.T) {
 	tests := []struct {
 		name                         string
@@ -1214,9 +1632,8 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 			wantAvailabilitySetExistence: true,
 		},
 		{
-			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine which is part of machine deployment",
+			name: "returns empty and false if machine is placed on a dedicated host",
 			machineScope: MachineScope{
-
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -1230,18 +1647,24 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
-							clusterv1.MachineDeploymentNameLabel: "foo-machine-deployment",
+							clusterv1.MachineControlPlaneLabel: "",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						DedicatedHost: &infrav1.DedicatedHost{
+							HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
 						},
 					},
 				},
 			},
-			wantAvailabilitySetName:      "cluster_foo-machine-deployment-as",
-			wantAvailabilitySetExistence: true,
+			wantAvailabilitySetName:      "",
+			wantAvailabilitySetExistence: false,
 		},
 		{
-			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine which is part of machine set",
+			name: "returns empty and false if machine consumes a capacity reservation",
 			machineScope: MachineScope{
-
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -1255,11 +1678,66 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
-							clusterv1.MachineSetNameLabel: "foo-machine-set",
+							clusterv1.MachineControlPlaneLabel: "",
 						},
 					},
 				},
-			},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+					},
+				},
+			},
+			wantAvailabilitySetName:      "",
+			wantAvailabilitySetExistence: false,
+		},
+		{
+			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine which is part of machine deployment",
+			machineScope: MachineScope{
+
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineDeploymentNameLabel: "foo-machine-deployment",
+						},
+					},
+				},
+			},
+			wantAvailabilitySetName:      "cluster_foo-machine-deployment-as",
+			wantAvailabilitySetExistence: true,
+		},
+		{
+			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine which is part of machine set",
+			machineScope: MachineScope{
+
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineSetNameLabel: "foo-machine-set",
+						},
+					},
+				},
+			},
 			wantAvailabilitySetName:      "cluster_foo-machine-set-as",
 			wantAvailabilitySetExistence: true,
 		},
@@ -1407,6 +1885,49 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 			},
 			expectedErr: "",
 		},
+		{
+			name: "returns the image resolved in the AzureMachine status if it matches the compute gallery image version",
+			machineScope: MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						Image: &infrav1.Image{
+							ComputeGallery: &infrav1.AzureComputeGalleryImage{
+								Gallery:        "my-gallery",
+								Name:           "my-image",
+								Version:        "latest",
+								SubscriptionID: pointer.String("my-sub"),
+								ResourceGroup:  pointer.String("my-rg"),
+							},
+						},
+					},
+					Status: infrav1.AzureMachineStatus{
+						Image: &infrav1.Image{
+							ComputeGallery: &infrav1.AzureComputeGalleryImage{
+								Gallery:        "my-gallery",
+								Name:           "my-image",
+								Version:        "1.2.3",
+								SubscriptionID: pointer.String("my-sub"),
+								ResourceGroup:  pointer.String("my-rg"),
+							},
+						},
+					},
+				},
+				ClusterScoper: clusterMock,
+			},
+			want: &infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{
+					Gallery:        "my-gallery",
+					Name:           "my-image",
+					Version:        "1.2.3",
+					SubscriptionID: pointer.String("my-sub"),
+					ResourceGroup:  pointer.String("my-rg"),
+				},
+			},
+			expectedErr: "",
+		},
 		{
 			name: "if no image is specified and os specified is windows with version below 1.22, returns windows dockershim image",
 			machineScope: MachineScope{
@@ -1771,7 +2292,227 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "Node Machine with no NAT gateway and no public IP address and SKU is in machine cache",
+			name: "Node Machine with no NAT gateway and no public IP address and SKU is in machine cache",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: pointer.String("azure://compute/virtual-machines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+				cache: &MachineCache{
+					VMSKU: resourceskus.SKU{
+						Name: pointer.String("Standard_D2v2"),
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU: &resourceskus.SKU{
+						Name: pointer.String("Standard_D2v2"),
+					},
+					ClusterName: "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
+		{
+			name: "Node Machine with NAT gateway",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+										NatGateway: infrav1.NatGateway{
+											NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+												Name: "natgw",
+											},
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: pointer.String("azure://compute/virtual-machines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "",
+					PublicLBAddressPoolName:   "",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
+		{
+			name: "Node Machine with public IP address",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -1834,6 +2575,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 							SubnetName:       "subnet1",
 							PrivateIPConfigs: 1,
 						}},
+						AllocatePublicIP: true,
 					},
 				},
 				Machine: &clusterv1.Machine{
@@ -1844,11 +2586,6 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 						},
 					},
 				},
-				cache: &MachineCache{
-					VMSKU: resourceskus.SKU{
-						Name: pointer.String("Standard_D2v2"),
-					},
-				},
 			},
 			want: []azure.ResourceSpecGetter{
 				&networkinterfaces.NICSpec{
@@ -1861,20 +2598,18 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					IPConfigs:                 []networkinterfaces.IPConfig{{}},
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
-					PublicLBName:              "outbound-lb",
-					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBName:              "",
+					PublicLBAddressPoolName:   "",
 					PublicLBNATRuleName:       "",
 					InternalLBName:            "",
 					InternalLBAddressPoolName: "",
-					PublicIPName:              "",
+					PublicIPName:              "pip-machine-name",
 					AcceleratedNetworking:     nil,
 					DNSServers:                nil,
 					IPv6Enabled:               false,
 					EnableIPForwarding:        false,
-					SKU: &resourceskus.SKU{
-						Name: pointer.String("Standard_D2v2"),
-					},
-					ClusterName: "cluster",
+					SKU:                       nil,
+					ClusterName:               "cluster",
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
@@ -1882,7 +2617,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "Node Machine with NAT gateway",
+			name: "Control Plane Machine with private LB",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -1926,11 +2661,12 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 											Role: infrav1.SubnetNode,
 											Name: "subnet1",
 										},
-										NatGateway: infrav1.NatGateway{
-											NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
-												Name: "natgw",
-											},
-										},
+									},
+								},
+								APIServerLB: infrav1.LoadBalancerSpec{
+									Name: "api-lb",
+									LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+										Type: infrav1.Internal,
 									},
 								},
 								NodeOutboundLB: &infrav1.LoadBalancerSpec{
@@ -1954,9 +2690,9 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 				},
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:   "machine",
+						Name: "machine",
 						Labels: map[string]string{
-							// clusterv1.MachineControlPlaneLabel: "true",
+							clusterv1.MachineControlPlaneLabel: "true",
 						},
 					},
 				},
@@ -1975,8 +2711,8 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					PublicLBName:              "",
 					PublicLBAddressPoolName:   "",
 					PublicLBNATRuleName:       "",
-					InternalLBName:            "",
-					InternalLBAddressPoolName: "",
+					InternalLBName:            "api-lb",
+					InternalLBAddressPoolName: "api-lb-backendPool",
 					PublicIPName:              "",
 					AcceleratedNetworking:     nil,
 					DNSServers:                nil,
@@ -1991,7 +2727,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "Node Machine with public IP address",
+			name: "Control Plane Machine with public LB",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -2037,6 +2773,9 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 										},
 									},
 								},
+								APIServerLB: infrav1.LoadBalancerSpec{
+									Name: "api-lb",
+								},
 								NodeOutboundLB: &infrav1.LoadBalancerSpec{
 									Name: "outbound-lb",
 								},
@@ -2054,14 +2793,13 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 							SubnetName:       "subnet1",
 							PrivateIPConfigs: 1,
 						}},
-						AllocatePublicIP: true,
 					},
 				},
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:   "machine",
+						Name: "machine",
 						Labels: map[string]string{
-							// clusterv1.MachineControlPlaneLabel: "true",
+							clusterv1.MachineControlPlaneLabel: "true",
 						},
 					},
 				},
@@ -2077,12 +2815,12 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					IPConfigs:                 []networkinterfaces.IPConfig{{}},
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
-					PublicLBName:              "",
-					PublicLBAddressPoolName:   "",
-					PublicLBNATRuleName:       "",
+					PublicLBName:              "api-lb",
+					PublicLBAddressPoolName:   "api-lb-backendPool",
+					PublicLBNATRuleName:       "machine-name",
 					InternalLBName:            "",
 					InternalLBAddressPoolName: "",
-					PublicIPName:              "pip-machine-name",
+					PublicIPName:              "",
 					AcceleratedNetworking:     nil,
 					DNSServers:                nil,
 					IPv6Enabled:               false,
@@ -2096,7 +2834,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "Control Plane Machine with private LB",
+			name: "Control Plane Machine with public LB and a private frontend IP",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -2144,8 +2882,18 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 								},
 								APIServerLB: infrav1.LoadBalancerSpec{
 									Name: "api-lb",
+									FrontendIPs: []infrav1.FrontendIP{
+										{
+											Name:     "api-lb-frontend",
+											PublicIP: &infrav1.PublicIPSpec{Name: "api-lb-pip"},
+										},
+										{
+											Name:            "api-lb-private-frontend",
+											FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"},
+										},
+									},
 									LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
-										Type: infrav1.Internal,
+										Type: infrav1.Public,
 									},
 								},
 								NodeOutboundLB: &infrav1.LoadBalancerSpec{
@@ -2187,11 +2935,11 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					IPConfigs:                 []networkinterfaces.IPConfig{{}},
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
-					PublicLBName:              "",
-					PublicLBAddressPoolName:   "",
-					PublicLBNATRuleName:       "",
-					InternalLBName:            "api-lb",
-					InternalLBAddressPoolName: "api-lb-backendPool",
+					PublicLBName:              "api-lb",
+					PublicLBAddressPoolName:   "api-lb-backendPool",
+					PublicLBNATRuleName:       "machine-name",
+					InternalLBName:            "api-lb-internal",
+					InternalLBAddressPoolName: "api-lb-internal-backendPool",
 					PublicIPName:              "",
 					AcceleratedNetworking:     nil,
 					DNSServers:                nil,
@@ -2206,7 +2954,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "Control Plane Machine with public LB",
+			name: "Control Plane Machine with public LB and Custom DNS Servers",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -2272,6 +3020,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 							SubnetName:       "subnet1",
 							PrivateIPConfigs: 1,
 						}},
+						DNSServers: []string{"123.123.123.123", "124.124.124.124"},
 					},
 				},
 				Machine: &clusterv1.Machine{
@@ -2301,7 +3050,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					InternalLBAddressPoolName: "",
 					PublicIPName:              "",
 					AcceleratedNetworking:     nil,
-					DNSServers:                nil,
+					DNSServers:                []string{"123.123.123.123", "124.124.124.124"},
 					IPv6Enabled:               false,
 					EnableIPForwarding:        false,
 					SKU:                       nil,
@@ -2313,7 +3062,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 			},
 		},
 		{
-			name: "Control Plane Machine with public LB and Custom DNS Servers",
+			name: "Node Machine with multiple Network Interfaces",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -2375,25 +3124,30 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					},
 					Spec: infrav1.AzureMachineSpec{
 						ProviderID: pointer.String("azure://compute/virtual-machines/machine-name"),
-						NetworkInterfaces: []infrav1.NetworkInterface{{
-							SubnetName:       "subnet1",
-							PrivateIPConfigs: 1,
-						}},
-						DNSServers: []string{"123.123.123.123", "124.124.124.124"},
+						NetworkInterfaces: []infrav1.NetworkInterface{
+							{
+								SubnetName:            "subnet1",
+								AcceleratedNetworking: pointer.Bool(true),
+								PrivateIPConfigs:      1,
+							},
+							{
+								SubnetName:            "subnet2",
+								AcceleratedNetworking: pointer.Bool(true),
+								PrivateIPConfigs:      2,
+							},
+						},
 					},
 				},
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
-						Name: "machine",
-						Labels: map[string]string{
-							clusterv1.MachineControlPlaneLabel: "true",
-						},
+						Name:   "machine",
+						Labels: map[string]string{},
 					},
 				},
 			},
 			want: []azure.ResourceSpecGetter{
 				&networkinterfaces.NICSpec{
-					Name:                      "machine-name-nic",
+					Name:                      "machine-name-nic-0",
 					ResourceGroup:             "my-rg",
 					Location:                  "westus",
 					SubscriptionID:            "123",
@@ -2402,26 +3156,50 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					IPConfigs:                 []networkinterfaces.IPConfig{{}},
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
-					PublicLBName:              "api-lb",
-					PublicLBAddressPoolName:   "api-lb-backendPool",
-					PublicLBNATRuleName:       "machine-name",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
 					InternalLBName:            "",
 					InternalLBAddressPoolName: "",
 					PublicIPName:              "",
-					AcceleratedNetworking:     nil,
-					DNSServers:                []string{"123.123.123.123", "124.124.124.124"},
+					AcceleratedNetworking:     pointer.Bool(true),
 					IPv6Enabled:               false,
 					EnableIPForwarding:        false,
 					SKU:                       nil,
 					ClusterName:               "cluster",
-					AdditionalTags: infrav1.Tags{
+					AdditionalTags: map[string]string{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic-1",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet2",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}, {}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "",
+					PublicLBAddressPoolName:   "",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     pointer.Bool(true),
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: map[string]string{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
 				},
 			},
 		},
 		{
-			name: "Node Machine with multiple Network Interfaces",
+			name: "Node Machine with multiple Network Interfaces and IP configurations",
 			machineScope: MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
@@ -2492,7 +3270,13 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 							{
 								SubnetName:            "subnet2",
 								AcceleratedNetworking: pointer.Bool(true),
-								PrivateIPConfigs:      2,
+								PrivateIPConfigs:      3,
+								IPConfigs: []infrav1.NetworkInterfaceIPConfig{
+									{PrivateIPAddress: "10.1.0.4"},
+									{},
+									{PrivateIPAddress: "10.1.0.6"},
+								},
+								EnableIPForwarding: pointer.Bool(true),
 							},
 						},
 					},
@@ -2537,7 +3321,8 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					SubscriptionID:            "123",
 					MachineName:               "machine-name",
 					SubnetName:                "subnet2",
-					IPConfigs:                 []networkinterfaces.IPConfig{{}, {}},
+					StaticIPAddress:           "10.1.0.4",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}, {}, {PrivateIP: pointer.String("10.1.0.6")}},
 					VNetName:                  "vnet1",
 					VNetResourceGroup:         "rg1",
 					PublicLBName:              "",
@@ -2548,7 +3333,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					PublicIPName:              "",
 					AcceleratedNetworking:     pointer.Bool(true),
 					IPv6Enabled:               false,
-					EnableIPForwarding:        false,
+					EnableIPForwarding:        true,
 					SKU:                       nil,
 					ClusterName:               "cluster",
 					AdditionalTags: map[string]string{
@@ -2802,6 +3587,120 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Node Machine with IPConfigs claimed from IPAM pools",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								APIServerLB: infrav1.LoadBalancerSpec{
+									Name: "api-lb",
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: pointer.String("azure://compute/virtual-machines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{
+							{
+								SubnetName:            "subnet1",
+								AcceleratedNetworking: pointer.Bool(true),
+								PrivateIPConfigs:      3,
+							},
+						},
+					},
+					Status: infrav1.AzureMachineStatus{
+						IPAddressClaims: []infrav1.IPAddressClaim{
+							{Name: "machine-nic-0-0", NetworkInterface: 0, IPConfig: 0, Address: "10.0.0.10"},
+							{Name: "machine-nic-0-1", NetworkInterface: 0, IPConfig: 1, Address: "10.0.0.11"},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					StaticIPAddress:           "10.0.0.10",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}, {PrivateIP: pointer.String("10.0.0.11")}, {}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     pointer.Bool(true),
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: map[string]string{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -20,9 +20,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/pkg/errors"
@@ -33,10 +35,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	machinepool "sigs.k8s.io/cluster-api-provider-azure/azure/scope/strategies/machinepool_deployments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -73,6 +78,17 @@ type (
 		patchHelper                *patch.Helper
 		capiMachinePoolPatchHelper *patch.Helper
 		vmssState                  *azure.VMSS
+		cache                      *MachinePoolCache
+		// serviceOptions are those of the cluster scope.
+		serviceOptions ServiceOptions
+		// notifications are sent once the AzureMachinePool is patched.
+		notifications notify.Pending
+	}
+
+	// MachinePoolCache stores common machine pool information so we don't have to hit the API multiple times within the same reconcile loop.
+	MachinePoolCache struct {
+		// VMExtensionsProtectedSettings are the protected settings from the secrets referenced by VM extensions, by extension name.
+		VMExtensionsProtectedSettings map[string]map[string]string
 	}
 
 	// NodeStatus represents the status of a Kubernetes node.
@@ -102,6 +118,10 @@ func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, erro
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 
+	// Resume the long running operations which were in flight before the AzureMachinePool lost its status,
+	// e.g. when it was moved to another management cluster.
+	futures.Restore(params.AzureMachinePool)
+
 	capiMachinePoolPatchHelper, err := patch.NewHelper(params.MachinePool, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init capi patch helper")
@@ -114,9 +134,28 @@ func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, erro
 		patchHelper:                helper,
 		capiMachinePoolPatchHelper: capiMachinePoolPatchHelper,
 		ClusterScoper:              params.ClusterScope,
+		serviceOptions:             serviceOptionsOf(params.ClusterScope),
 	}, nil
 }
 
+// InitMachinePoolCache sets cached information about the machine pool to be used in the scope.
+func (m *MachinePoolScope) InitMachinePoolCache(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azure.MachinePoolScope.InitMachinePoolCache")
+	defer done()
+
+	if m.cache == nil {
+		var err error
+		m.cache = &MachinePoolCache{}
+
+		m.cache.VMExtensionsProtectedSettings, err = getVMExtensionsProtectedSettings(ctx, m.client, m.AzureMachinePool.Namespace, m.AzureMachinePool.Spec.Template.VMExtensions)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ScaleSetSpec returns the scale set spec.
 func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 	return azure.ScaleSetSpec{
@@ -130,19 +169,35 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		VNetName:                     m.Vnet().Name,
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
-		PublicLBAddressPoolName:      azure.GenerateOutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
+		PublicLBAddressPoolName:      namer.OutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
+		AllocatePublicIP:             m.AzureMachinePool.Spec.Template.AllocatePublicIP,
+		PublicIPPrefixID:             pointer.StringDeref(m.AzureMachinePool.Spec.Template.PublicIPPrefixID, ""),
+		PublicIP:                     m.AzureMachinePool.Spec.Template.PublicIP,
 		AcceleratedNetworking:        m.AzureMachinePool.Spec.Template.NetworkInterfaces[0].AcceleratedNetworking,
 		Identity:                     m.AzureMachinePool.Spec.Identity,
 		UserAssignedIdentities:       m.AzureMachinePool.Spec.UserAssignedIdentities,
 		DiagnosticsProfile:           m.AzureMachinePool.Spec.Template.Diagnostics,
 		SecurityProfile:              m.AzureMachinePool.Spec.Template.SecurityProfile,
 		SpotVMOptions:                m.AzureMachinePool.Spec.Template.SpotVMOptions,
+		GPUDriver:                    m.AzureMachinePool.Spec.Template.GPUDriver,
 		FailureDomains:               m.MachinePool.Spec.FailureDomains,
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
 		NetworkInterfaces:            m.AzureMachinePool.Spec.Template.NetworkInterfaces,
 		IPv6Enabled:                  m.IsIPv6Enabled(),
 		OrchestrationMode:            m.AzureMachinePool.Spec.OrchestrationMode,
+		PlatformFaultDomainCount:     m.AzureMachinePool.Spec.PlatformFaultDomainCount,
+		ProximityPlacementGroupID:    m.ProximityPlacementGroupID(),
+		CapacityReservationGroupID:   m.AzureMachinePool.Spec.CapacityReservationGroupID,
+		ScaleInPolicy:                string(m.AzureMachinePool.Spec.ScaleInPolicy),
+	}
+}
+
+// ProximityPlacementGroupID returns the proximity placement group for the scale set, or "" if there is none.
+func (m *MachinePoolScope) ProximityPlacementGroupID() string {
+	if m.AzureMachinePool.Spec.ProximityPlacementGroupName == "" {
+		return ""
 	}
+	return azure.ProximityPlacementGroupID(m.SubscriptionID(), m.ResourceGroup(), m.AzureMachinePool.Spec.ProximityPlacementGroupName)
 }
 
 // Name returns the Azure Machine Pool Name.
@@ -418,6 +473,11 @@ func (m *MachinePoolScope) createMachine(ctx context.Context, machine azure.VMSS
 	return nil
 }
 
+// ReportEvent emits an event on the AzureMachinePool for a change of one of its Azure resources.
+func (m *MachinePoolScope) ReportEvent(eventType, reason, message string) {
+	record.Emit(m.AzureMachinePool, eventType, reason, message)
+}
+
 // SetLongRunningOperationState will set the future on the AzureMachinePool status to allow the resource to continue
 // in the next reconciliation.
 func (m *MachinePoolScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -471,9 +531,17 @@ func (m *MachinePoolScope) setProvisioningStateAndConditions(v infrav1.Provision
 
 // SetReady sets the AzureMachinePool Ready Status to true.
 func (m *MachinePoolScope) SetReady() {
+	if !m.AzureMachinePool.Status.Ready {
+		m.Notify(notify.InfrastructureReady, "machine pool infrastructure is ready")
+	}
 	m.AzureMachinePool.Status.Ready = true
 }
 
+// Notify notifies that the AzureMachinePool reached a lifecycle milestone, once its status is persisted.
+func (m *MachinePoolScope) Notify(eventType notify.EventType, message string) {
+	m.notifications.Add("AzureMachinePool", m.AzureMachinePool, eventType, message)
+}
+
 // SetNotReady sets the AzureMachinePool Ready Status to false.
 func (m *MachinePoolScope) SetNotReady() {
 	m.AzureMachinePool.Status.Ready = false
@@ -503,6 +571,16 @@ func (m *MachinePoolScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
+// AzureServiceReconcileTimeout returns how long the named service may take to reconcile the Azure resources of the AzureMachinePool.
+func (m *MachinePoolScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	return m.serviceOptions.Timeouts.AzureServiceReconcileTimeout(serviceName)
+}
+
+// DriftPolicy returns how the Azure resources of the AzureMachinePool which no longer match their spec are handled.
+func (m *MachinePoolScope) DriftPolicy() async.DriftPolicy {
+	return m.serviceOptions.DriftPolicy
+}
+
 // SetAnnotation sets a key value annotation on the AzureMachinePool.
 func (m *MachinePoolScope) SetAnnotation(key, value string) {
 	if m.AzureMachinePool.Annotations == nil {
@@ -511,13 +589,45 @@ func (m *MachinePoolScope) SetAnnotation(key, value string) {
 	m.AzureMachinePool.Annotations[key] = value
 }
 
+// AnnotationJSON returns a map[string]interface from a JSON annotation.
+func (m *MachinePoolScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	jsonAnnotation := m.AzureMachinePool.GetAnnotations()[annotation]
+	if jsonAnnotation == "" {
+		return out, nil
+	}
+	err := json.Unmarshal([]byte(jsonAnnotation), &out)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// UpdateAnnotationJSON updates the `annotation` with
+// `content`. `content` in this case should be a `map[string]interface{}`
+// suitable for turning into JSON. This `content` map will be marshalled into a
+// JSON string before being set as the given `annotation`.
+func (m *MachinePoolScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	m.SetAnnotation(annotation, string(b))
+	return nil
+}
+
 // PatchObject persists the AzureMachinePool spec and status.
 func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.PatchObject")
 	defer done()
 
+	futures.SetBlockMoveAnnotation(m.AzureMachinePool)
+	if err := futures.Checkpoint(m.AzureMachinePool); err != nil {
+		return err
+	}
 	conditions.SetSummary(m.AzureMachinePool)
-	return m.patchHelper.Patch(
+	m.setOperationStatus()
+	if err := m.patchHelper.Patch(
 		ctx,
 		m.AzureMachinePool,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
@@ -526,7 +636,38 @@ func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 			infrav1.ScaleSetDesiredReplicasCondition,
 			infrav1.ScaleSetModelUpdatedCondition,
 			infrav1.ScaleSetRunningCondition,
-		}})
+			infrav1.RoleAssignmentReadyCondition,
+		}}); err != nil {
+		return err
+	}
+	m.notifications.Send(ctx)
+	return nil
+}
+
+// setOperationStatus reports the progress of the ongoing operation on the scale set, if any. While the scale set is
+// updated, the progress is the share of the desired instances which run the latest model.
+func (m *MachinePoolScope) setOperationStatus() {
+	var state infrav1.ProvisioningState
+	if m.AzureMachinePool.Status.ProvisioningState != nil {
+		state = *m.AzureMachinePool.Status.ProvisioningState
+	}
+	state = operationFromFutures(state, m.AzureMachinePool.Status.LongRunningOperationStates, ScalesetsServiceName, m.Name())
+
+	if state != infrav1.Updating || m.vmssState == nil {
+		message := fmt.Sprintf("%s scale set %s", state, m.Name())
+		m.AzureMachinePool.Status.OperationStatus = newOperationStatus(m.AzureMachinePool.Status.OperationStatus, state, message, 0, 0)
+		return
+	}
+
+	var upToDate int32
+	for _, instance := range m.vmssState.Instances {
+		if instance.State == infrav1.Succeeded && m.vmssState.HasLatestModelApplied(instance) {
+			upToDate++
+		}
+	}
+	desired := m.DesiredReplicas()
+	message := fmt.Sprintf("Updating instances, %d/%d up to date", upToDate, desired)
+	m.AzureMachinePool.Status.OperationStatus = newOperationStatus(m.AzureMachinePool.Status.OperationStatus, state, message, upToDate, desired)
 }
 
 // Close the MachinePoolScope by updating the AzureMachinePool spec and AzureMachinePool status.
@@ -622,7 +763,7 @@ func (m *MachinePoolScope) GetVMImage(ctx context.Context) (*infrav1.Image, erro
 	defer done()
 
 	// Use custom Marketplace image, Image ID or a Shared Image Gallery image if provided
-	if m.AzureMachinePool.Spec.Template.Image != nil {
+	if m.AzureMachinePool.Spec.Template.Image != nil && !virtualmachineimages.NeedsVersionResolution(m.AzureMachinePool.Spec.Template.Image) {
 		return m.AzureMachinePool.Spec.Template.Image, nil
 	}
 
@@ -636,6 +777,16 @@ func (m *MachinePoolScope) GetVMImage(ctx context.Context) (*infrav1.Image, erro
 		return nil, errors.Wrap(err, "failed to create virtualmachineimages service")
 	}
 
+	// Resolve the version of an Azure Compute Gallery image against the image in the status, so the VMSS model
+	// doesn't change when a new image version is published.
+	if m.AzureMachinePool.Spec.Template.Image != nil {
+		image, err := svc.ResolveComputeGalleryImage(ctx, m.AzureMachinePool.Spec.Template.Image, m.AzureMachinePool.Status.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve compute gallery image version")
+		}
+		return image, nil
+	}
+
 	if m.AzureMachinePool.Spec.Template.OSDisk.OSType == azure.WindowsOS {
 		runtime := m.AzureMachinePool.Annotations["runtime"]
 		windowsServerVersion := m.AzureMachinePool.Annotations["windowsServerVersion"]
@@ -689,22 +840,27 @@ func (m *MachinePoolScope) HasSystemAssignedIdentity() bool {
 // VMSSExtensionSpecs returns the VMSS extension specs.
 func (m *MachinePoolScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
 	var extensionSpecs = []azure.ResourceSpecGetter{}
+	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.CloudEnvironment(), m.Name())
 
+	var protectedSettings map[string]map[string]string
+	if m.cache != nil {
+		protectedSettings = m.cache.VMExtensionsProtectedSettings
+	}
 	for _, extension := range m.AzureMachinePool.Spec.Template.VMExtensions {
 		extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
-			ExtensionSpec: azure.ExtensionSpec{
-				Name:              extension.Name,
-				VMName:            m.Name(),
-				Publisher:         extension.Publisher,
-				Version:           extension.Version,
-				Settings:          extension.Settings,
-				ProtectedSettings: extension.ProtectedSettings,
-			},
+			ExtensionSpec: customVMExtensionSpec(extension, m.Name(), protectedSettings[extension.Name], bootstrapExtensionSpec),
 			ResourceGroup: m.ResourceGroup(),
 		})
 	}
 
-	bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.CloudEnvironment(), m.Name())
+	gpuDriverExtensionSpec := azure.GetGPUDriverVMExtension(m.AzureMachinePool.Spec.Template.GPUDriver, m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.Name())
+
+	if gpuDriverExtensionSpec != nil {
+		extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
+			ExtensionSpec: *gpuDriverExtensionSpec,
+			ResourceGroup: m.ResourceGroup(),
+		})
+	}
 
 	if bootstrapExtensionSpec != nil {
 		extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
@@ -751,10 +907,15 @@ func (m *MachinePoolScope) UpdateDeleteStatus(condition clusterv1.ConditionType,
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachinePool, condition, failedReason(err, infrav1.DeletionFailedReason), clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
 }
 
+// SetConditionFalse sets the specified AzureMachinePool condition to false.
+func (m *MachinePoolScope) SetConditionFalse(conditionType clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(m.AzureMachinePool, conditionType, reason, severity, message)
+}
+
 // UpdatePutStatus updates a condition on the AzureMachinePool status after a PUT operation.
 func (m *MachinePoolScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -763,7 +924,7 @@ func (m *MachinePoolScope) UpdatePutStatus(condition clusterv1.ConditionType, se
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachinePool, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
 }
 
@@ -775,7 +936,7 @@ func (m *MachinePoolScope) UpdatePatchStatus(condition clusterv1.ConditionType,
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
-		conditions.MarkFalse(m.AzureMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(m.AzureMachinePool, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
 
@@ -659,6 +659,88 @@ func TestMachinePoolScope_updateReplicasAndProviderIDs(t *testing.T) {
 	}
 }
 
+func TestMachinePoolScope_InitMachinePoolCache(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	cases := []struct {
+		Name   string
+		Setup  func(cb *fake.ClientBuilder)
+		Verify func(g *WithT, cache *MachinePoolCache, err error)
+	}{
+		{
+			Name: "should read the protected settings of VM extensions from their secret",
+			Setup: func(cb *fake.ClientBuilder) {
+				cb.WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "custom-script-settings",
+						Namespace: "default",
+					},
+					Data: map[string][]byte{
+						"commandToExecute": []byte("echo secret"),
+					},
+				})
+			},
+			Verify: func(g *WithT, cache *MachinePoolCache, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(cache.VMExtensionsProtectedSettings).To(Equal(map[string]map[string]string{
+					"custom-script": {
+						"commandToExecute": "echo secret",
+					},
+				}))
+			},
+		},
+		{
+			Name:  "should fail if the protected settings secret of a VM extension doesn't exist",
+			Setup: func(cb *fake.ClientBuilder) {},
+			Verify: func(g *WithT, cache *MachinePoolCache, err error) {
+				g.Expect(err).To(MatchError(ContainSubstring("failed to retrieve protected settings secret default/custom-script-settings of VM extension custom-script")))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				g   = NewWithT(t)
+				cb  = fake.NewClientBuilder().WithScheme(scheme)
+				amp = &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "amp1",
+						Namespace: "default",
+					},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							VMExtensions: []infrav1.VMExtension{
+								{
+									Name:                       "custom-script",
+									Publisher:                  "Microsoft.Azure.Extensions",
+									Version:                    "2.1",
+									ProtectedSettingsSecretRef: &corev1.LocalObjectReference{Name: "custom-script-settings"},
+								},
+								{
+									Name:      "other-extension",
+									Publisher: "Microsoft.Azure.Extensions",
+									Version:   "1.0",
+								},
+							},
+						},
+					},
+				}
+			)
+
+			c.Setup(cb)
+			s := &MachinePoolScope{
+				client:           cb.Build(),
+				AzureMachinePool: amp,
+			}
+			err := s.InitMachinePoolCache(context.TODO())
+			c.Verify(g, s.cache, err)
+		})
+	}
+}
+
 func TestMachinePoolScope_RoleAssignmentSpecs(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1008,6 +1090,50 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{},
 		},
+		{
+			name: "If the GPU driver is specified, it returns the GPU driver VM extension",
+			machinePoolScope: MachinePoolScope{
+				MachinePool: &expv1.MachinePool{},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machinepool-name",
+					},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							OSDisk: infrav1.OSDisk{
+								OSType: "Linux",
+							},
+							GPUDriver: &infrav1.GPUDriver{Version: "1.9"},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.USGovernmentCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&scalesets.VMSSExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "NvidiaGpuDriverLinux",
+						VMName:    "machinepool-name",
+						Publisher: "Microsoft.HpcCompute",
+						Version:   "1.9",
+					},
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
 		{
 			name: "If a custom VM extension is specified, it returns the custom VM extension",
 			machinePoolScope: MachinePoolScope{
@@ -1085,6 +1211,88 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "If a custom VM extension is provisioned after bootstrap with protected settings from a secret, it depends on the bootstrap extension",
+			machinePoolScope: MachinePoolScope{
+				MachinePool: &expv1.MachinePool{},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machinepool-name",
+					},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							OSDisk: infrav1.OSDisk{
+								OSType: "Linux",
+							},
+							VMExtensions: []infrav1.VMExtension{
+								{
+									Name:      "custom-script",
+									Type:      "CustomScript",
+									Publisher: "Microsoft.Azure.Extensions",
+									Version:   "2.1",
+									ProtectedSettings: map[string]string{
+										"commandToExecute": "echo hello world",
+										"timestamp":        "1234567890",
+									},
+									ProtectedSettingsSecretRef: &corev1.LocalObjectReference{Name: "custom-script-settings"},
+									ProvisionAfterBootstrap:    true,
+								},
+							},
+						},
+					},
+				},
+				cache: &MachinePoolCache{
+					VMExtensionsProtectedSettings: map[string]map[string]string{
+						"custom-script": {
+							"commandToExecute": "echo secret",
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: azureautorest.Environment{
+								Name: azureautorest.PublicCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&scalesets.VMSSExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "custom-script",
+						Type:      "CustomScript",
+						VMName:    "machinepool-name",
+						Publisher: "Microsoft.Azure.Extensions",
+						Version:   "2.1",
+						ProtectedSettings: map[string]string{
+							"commandToExecute": "echo secret",
+							"timestamp":        "1234567890",
+						},
+						ProvisionAfterExtensions: []string{"CAPZ.Linux.Bootstrapping"},
+					},
+					ResourceGroup: "my-rg",
+				},
+				&scalesets.VMSSExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "CAPZ.Linux.Bootstrapping",
+						VMName:    "machinepool-name",
+						Publisher: "Microsoft.Azure.ContainerUpstream",
+						Version:   "1.0",
+						ProtectedSettings: map[string]string{
+							"commandToExecute": azure.LinuxBootstrapExtensionCommand,
+						},
+					},
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
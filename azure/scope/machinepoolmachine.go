@@ -32,6 +32,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -48,6 +49,9 @@ import (
 const (
 	// MachinePoolMachineScopeName is the sourceName, or more specifically the UserAgent, of client used in cordon and drain.
 	MachinePoolMachineScopeName = "azuremachinepoolmachine-scope"
+
+	// vmPowerStateRunning is the power state of a running VM.
+	vmPowerStateRunning = "running"
 )
 
 type (
@@ -86,6 +90,9 @@ type (
 
 		// workloadNodeGetter is only used for testing purposes and provides a way for mocking requests to the workload cluster
 		workloadNodeGetter nodeGetter
+
+		// serviceOptions are those of the cluster scope.
+		serviceOptions ServiceOptions
 	}
 )
 
@@ -146,9 +153,20 @@ func NewMachinePoolMachineScope(params MachinePoolMachineScopeParams) (*MachineP
 		client:                  params.Client,
 		patchHelper:             helper,
 		workloadNodeGetter:      params.workloadNodeGetter,
+		serviceOptions:          serviceOptionsOf(params.ClusterScope),
 	}, nil
 }
 
+// AzureServiceReconcileTimeout returns how long the named service may take to reconcile the Azure resources of the AzureMachinePoolMachine.
+func (s *MachinePoolMachineScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	return s.serviceOptions.Timeouts.AzureServiceReconcileTimeout(serviceName)
+}
+
+// DriftPolicy returns how the Azure resources of the AzureMachinePoolMachine which no longer match their spec are handled.
+func (s *MachinePoolMachineScope) DriftPolicy() async.DriftPolicy {
+	return s.serviceOptions.DriftPolicy
+}
+
 // Name is the name of the Machine Pool Machine.
 func (s *MachinePoolMachineScope) Name() string {
 	return s.AzureMachinePoolMachine.Name
@@ -193,7 +211,7 @@ func (s *MachinePoolMachineScope) UpdateDeleteStatus(condition clusterv1.Conditi
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, failedReason(err, infrav1.DeletionFailedReason), clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
 }
 
@@ -205,7 +223,7 @@ func (s *MachinePoolMachineScope) UpdatePutStatus(condition clusterv1.ConditionT
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
 }
 
@@ -217,7 +235,7 @@ func (s *MachinePoolMachineScope) UpdatePatchStatus(condition clusterv1.Conditio
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
-		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
 
@@ -257,14 +275,25 @@ func (s *MachinePoolMachineScope) ProviderID() string {
 
 // PatchObject persists the MachinePoolMachine spec and status.
 func (s *MachinePoolMachineScope) PatchObject(ctx context.Context) error {
-	conditions.SetSummary(s.AzureMachinePoolMachine)
+	// an instance that doesn't run the latest model yet is still ready, so LatestModelApplied isn't part of the summary.
+	conditions.SetSummary(s.AzureMachinePoolMachine, conditions.WithConditions(
+		infrav1.BootstrapSucceededCondition,
+		infrav1.GPUDriverSucceededCondition,
+		infrav1.VMHealthyCondition,
+		clusterv1.MachineNodeHealthyCondition,
+		clusterv1.PreDrainDeleteHookSucceededCondition,
+		clusterv1.DrainingSucceededCondition,
+	))
 
 	return s.patchHelper.Patch(
 		ctx,
 		s.AzureMachinePoolMachine,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
+			infrav1.VMHealthyCondition,
+			infrav1.LatestModelAppliedCondition,
 			clusterv1.MachineNodeHealthyCondition,
+			clusterv1.PreDrainDeleteHookSucceededCondition,
 			clusterv1.DrainingSucceededCondition,
 		}})
 }
@@ -301,6 +330,16 @@ func (s *MachinePoolMachineScope) UpdateNodeStatus(ctx context.Context) error {
 			log.Info("VM bootstrapping succeeded")
 			conditions.MarkTrue(s.AzureMachinePoolMachine, infrav1.BootstrapSucceededCondition)
 		}
+
+		switch s.instance.GPUDriverState {
+		case infrav1.Creating:
+			conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.GPUDriverSucceededCondition, infrav1.GPUDriverInProgressReason, clusterv1.ConditionSeverityInfo, "GPU driver installing")
+		case infrav1.Failed:
+			log.Info("GPU driver installation failed")
+			conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.GPUDriverSucceededCondition, infrav1.GPUDriverFailedReason, clusterv1.ConditionSeverityError, "GPU driver installation failed")
+		case infrav1.Succeeded:
+			conditions.MarkTrue(s.AzureMachinePoolMachine, infrav1.GPUDriverSucceededCondition)
+		}
 	}
 
 	var node *corev1.Node
@@ -345,8 +384,8 @@ func (s *MachinePoolMachineScope) UpdateNodeStatus(ctx context.Context) error {
 	return nil
 }
 
-// UpdateInstanceStatus updates the provisioning state of the AzureMachinePoolMachine and if it has the latest model applied
-// using the VMSS VM instance.
+// UpdateInstanceStatus updates the provisioning and power state of the AzureMachinePoolMachine and if it has the latest
+// model applied using the VMSS VM instance, along with the matching VMHealthy and LatestModelApplied conditions.
 // Note: This func should be called at the end of a reconcile request and after updating the scope with the most recent Azure data.
 func (s *MachinePoolMachineScope) UpdateInstanceStatus(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(
@@ -357,17 +396,46 @@ func (s *MachinePoolMachineScope) UpdateInstanceStatus(ctx context.Context) erro
 
 	if s.instance != nil {
 		s.AzureMachinePoolMachine.Status.ProvisioningState = &s.instance.State
+		s.AzureMachinePoolMachine.Status.PowerState = s.instance.PowerState
+		s.updateVMHealthyCondition()
+
 		hasLatestModel, err := s.hasLatestModelApplied(ctx)
 		if err != nil {
 			return errors.Wrap(err, "failed to determine if the VMSS instance has the latest model")
 		}
 
 		s.AzureMachinePoolMachine.Status.LatestModelApplied = hasLatestModel
+		if hasLatestModel {
+			conditions.MarkTrue(s.AzureMachinePoolMachine, infrav1.LatestModelAppliedCondition)
+		} else {
+			conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.LatestModelAppliedCondition, infrav1.ModelOutOfDateReason, clusterv1.ConditionSeverityInfo, "")
+		}
 	}
 
 	return nil
 }
 
+// updateVMHealthyCondition sets the VMHealthy condition from the provisioning and power state of the VMSS VM instance.
+func (s *MachinePoolMachineScope) updateVMHealthyCondition() {
+	switch s.instance.State {
+	case infrav1.Creating:
+		conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.VMHealthyCondition, infrav1.VMCreatingReason, clusterv1.ConditionSeverityInfo, "")
+	case infrav1.Updating:
+		conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.VMHealthyCondition, infrav1.VMUpdatingReason, clusterv1.ConditionSeverityInfo, "")
+	case infrav1.Deleting:
+		conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.VMHealthyCondition, infrav1.VMDeletingReason, clusterv1.ConditionSeverityWarning, "")
+	case infrav1.Failed:
+		conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.VMHealthyCondition, infrav1.VMProvisionFailedReason, clusterv1.ConditionSeverityError, "")
+	case infrav1.Succeeded:
+		// the power state is unknown when the instance view wasn't fetched, in which case only the provisioning state counts.
+		if s.instance.PowerState != "" && s.instance.PowerState != vmPowerStateRunning {
+			conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.VMHealthyCondition, infrav1.VMNotRunningReason, clusterv1.ConditionSeverityWarning, "VM is %s", s.instance.PowerState)
+			return
+		}
+		conditions.MarkTrue(s.AzureMachinePoolMachine, infrav1.VMHealthyCondition)
+	}
+}
+
 // CordonAndDrain will cordon and drain the Kubernetes node associated with this AzureMachinePoolMachine.
 func (s *MachinePoolMachineScope) CordonAndDrain(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(
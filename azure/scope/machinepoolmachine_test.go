@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	mock_scope "sigs.k8s.io/cluster-api-provider-azure/azure/scope/mocks"
@@ -274,6 +275,104 @@ func TestMachineScope_UpdateNodeStatus(t *testing.T) {
 	}
 }
 
+func TestMachinePoolMachineScope_UpdateInstanceStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	clusterScope := mock_azure.NewMockClusterScoper(mockCtrl)
+	clusterScope.EXPECT().Authorizer().AnyTimes()
+	clusterScope.EXPECT().BaseURI().AnyTimes()
+	clusterScope.EXPECT().Location().AnyTimes()
+	clusterScope.EXPECT().SubscriptionID().AnyTimes()
+	clusterScope.EXPECT().ClusterName().Return("cluster-foo").AnyTimes()
+
+	var (
+		latestImage = infrav1.Image{ID: pointer.String("image-2")}
+		oldImage    = infrav1.Image{ID: pointer.String("image-1")}
+	)
+
+	cases := []struct {
+		Name     string
+		Instance *azure.VMSSVM
+		Verify   func(g *WithT, ampm *infrav1exp.AzureMachinePoolMachine)
+	}{
+		{
+			Name:     "should mark a running instance with the latest model healthy",
+			Instance: &azure.VMSSVM{State: infrav1.Succeeded, PowerState: "running", Image: latestImage},
+			Verify: func(g *WithT, ampm *infrav1exp.AzureMachinePoolMachine) {
+				g.Expect(ampm.Status.PowerState).To(Equal("running"))
+				g.Expect(ampm.Status.LatestModelApplied).To(BeTrue())
+				assertCondition(t, ampm, conditions.TrueCondition(infrav1.VMHealthyCondition))
+				assertCondition(t, ampm, conditions.TrueCondition(infrav1.LatestModelAppliedCondition))
+			},
+		},
+		{
+			Name:     "should mark a deallocated instance not healthy",
+			Instance: &azure.VMSSVM{State: infrav1.Succeeded, PowerState: "deallocated", Image: latestImage},
+			Verify: func(g *WithT, ampm *infrav1exp.AzureMachinePoolMachine) {
+				g.Expect(ampm.Status.PowerState).To(Equal("deallocated"))
+				assertCondition(t, ampm, conditions.FalseCondition(infrav1.VMHealthyCondition, infrav1.VMNotRunningReason, clusterv1.ConditionSeverityWarning, "VM is deallocated"))
+			},
+		},
+		{
+			Name:     "should mark a failed instance not healthy",
+			Instance: &azure.VMSSVM{State: infrav1.Failed, Image: latestImage},
+			Verify: func(g *WithT, ampm *infrav1exp.AzureMachinePoolMachine) {
+				assertCondition(t, ampm, conditions.FalseCondition(infrav1.VMHealthyCondition, infrav1.VMProvisionFailedReason, clusterv1.ConditionSeverityError, ""))
+			},
+		},
+		{
+			Name:     "should mark an instance with an old model out of date",
+			Instance: &azure.VMSSVM{State: infrav1.Succeeded, Image: oldImage},
+			Verify: func(g *WithT, ampm *infrav1exp.AzureMachinePoolMachine) {
+				g.Expect(ampm.Status.LatestModelApplied).To(BeFalse())
+				assertCondition(t, ampm, conditions.TrueCondition(infrav1.VMHealthyCondition))
+				assertCondition(t, ampm, conditions.FalseCondition(infrav1.LatestModelAppliedCondition, infrav1.ModelOutOfDateReason, clusterv1.ConditionSeverityInfo, ""))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s, err := NewMachinePoolMachineScope(MachinePoolMachineScopeParams{
+				Client:       fake.NewClientBuilder().WithScheme(scheme).Build(),
+				ClusterScope: clusterScope,
+				MachinePool: &expv1.MachinePool{
+					Spec: expv1.MachinePoolSpec{
+						Template: clusterv1.MachineTemplateSpec{
+							Spec: clusterv1.MachineSpec{
+								Version: pointer.String("v1.19.11"),
+							},
+						},
+					},
+				},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							Image: &latestImage,
+						},
+					},
+				},
+				AzureMachinePoolMachine: &infrav1exp.AzureMachinePoolMachine{
+					Spec: infrav1exp.AzureMachinePoolMachineSpec{
+						ProviderID: FakeProviderID,
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s.instance = c.Instance
+
+			g.Expect(s.UpdateInstanceStatus(context.TODO())).To(Succeed())
+			c.Verify(g, s.AzureMachinePoolMachine)
+		})
+	}
+}
+
 func TestMachinePoolMachineScope_CordonAndDrain(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -18,7 +18,11 @@ package scope
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,14 +31,19 @@ import (
 	"golang.org/x/mod/semver"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -47,6 +56,24 @@ import (
 
 const resourceHealthWarningInitialGracePeriod = 1 * time.Hour
 
+// ParseIPRanges parses IPs or CIDR ranges, converting single IPs to /32 or /128 ranges.
+func ParseIPRanges(ranges []string) ([]string, error) {
+	normalized := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		if ip := net.ParseIP(r); ip != nil {
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			r = fmt.Sprintf("%s/%d", ip, bits)
+		} else if _, _, err := net.ParseCIDR(r); err != nil {
+			return nil, errors.Errorf("invalid IP range %q", r)
+		}
+		normalized = append(normalized, r)
+	}
+	return normalized, nil
+}
+
 // ManagedControlPlaneScopeParams defines the input parameters used to create a new managed
 // control plane.
 type ManagedControlPlaneScopeParams struct {
@@ -56,6 +83,11 @@ type ManagedControlPlaneScopeParams struct {
 	ControlPlane        *infrav1.AzureManagedControlPlane
 	ManagedMachinePools []ManagedMachinePool
 	Cache               *ManagedControlPlaneCache
+	// ManagementClusterEgressIPRanges are the IP ranges the management cluster reaches the AKS API servers from,
+	// added to the authorized IP ranges of the control planes including them.
+	ManagementClusterEgressIPRanges []string
+	// ServiceOptions configure how the services reconcile the Azure resources of the control plane.
+	ServiceOptions ServiceOptions
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -96,6 +128,10 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 
+	// Resume the long running operations which were in flight before the AzureManagedControlPlane lost its status,
+	// e.g. when it was moved to another management cluster.
+	futures.Restore(params.ControlPlane)
+
 	return &ManagedControlPlaneScope{
 		Client:              params.Client,
 		AzureClients:        params.AzureClients,
@@ -104,6 +140,9 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		ManagedMachinePools: params.ManagedMachinePools,
 		patchHelper:         helper,
 		cache:               params.Cache,
+
+		managementClusterEgressIPRanges: params.ManagementClusterEgressIPRanges,
+		serviceOptions:                  params.ServiceOptions,
 	}, nil
 }
 
@@ -114,15 +153,46 @@ type ManagedControlPlaneScope struct {
 	kubeConfigData []byte
 	cache          *ManagedControlPlaneCache
 
+	managementClusterEgressIPRanges []string
+	serviceOptions                  ServiceOptions
+
 	AzureClients
 	Cluster             *clusterv1.Cluster
 	ControlPlane        *infrav1.AzureManagedControlPlane
 	ManagedMachinePools []ManagedMachinePool
+
+	// notifications are sent once the AzureManagedControlPlane is patched.
+	notifications notify.Pending
 }
 
 // ManagedControlPlaneCache stores ManagedControlPlane data locally so we don't have to hit the API multiple times within the same reconcile loop.
 type ManagedControlPlaneCache struct {
 	isVnetManaged *bool
+	// httpProxyTrustedCA is the base64 encoded CA certificate from the secret referenced by the HTTP proxy configuration.
+	httpProxyTrustedCA *string
+}
+
+// InitManagedControlPlaneCache sets cached information about the managed control plane to be used in the scope.
+func (s *ManagedControlPlaneScope) InitManagedControlPlaneCache(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ManagedControlPlaneScope.InitManagedControlPlaneCache")
+	defer done()
+
+	proxyConfig := s.ControlPlane.Spec.HTTPProxyConfig
+	if proxyConfig == nil || proxyConfig.TrustedCASecretRef == nil {
+		return nil
+	}
+
+	caSecret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: s.ControlPlane.Namespace, Name: proxyConfig.TrustedCASecretRef.Name}
+	if err := s.Client.Get(ctx, key, caSecret); err != nil {
+		return errors.Wrapf(err, "failed to retrieve HTTP proxy trusted CA secret %s/%s", key.Namespace, key.Name)
+	}
+	ca, ok := caSecret.Data[infrav1.HTTPProxyTrustedCAKey]
+	if !ok {
+		return errors.Errorf("HTTP proxy trusted CA secret %s/%s has no %s key", key.Namespace, key.Name, infrav1.HTTPProxyTrustedCAKey)
+	}
+	s.cache.httpProxyTrustedCA = pointer.String(base64.StdEncoding.EncodeToString(ca))
+	return nil
 }
 
 // ResourceGroup returns the managed control plane's resource group.
@@ -174,15 +244,41 @@ func (s *ManagedControlPlaneScope) AvailabilitySetEnabled() bool {
 	return false // not applicable for a managed control plane
 }
 
+// AvailabilitySetDomainCounts is always nil for a managed control plane.
+func (s *ManagedControlPlaneScope) AvailabilitySetDomainCounts() *infrav1.AvailabilitySetDomainCounts {
+	return nil // not applicable for a managed control plane
+}
+
+// NamingConvention is always nil for a managed control plane.
+func (s *ManagedControlPlaneScope) NamingConvention() *infrav1.NamingConvention {
+	return nil // not applicable for a managed control plane
+}
+
 // AdditionalTags returns AdditionalTags from the ControlPlane spec.
 func (s *ManagedControlPlaneScope) AdditionalTags() infrav1.Tags {
 	tags := make(infrav1.Tags)
-	if s.ControlPlane.Spec.AdditionalTags != nil {
-		tags = s.ControlPlane.Spec.AdditionalTags.DeepCopy()
-	}
+	// Start with the default tags...
+	tags.Merge(s.serviceOptions.DefaultTags)
+	// ... and merge in the AzureManagedControlPlane's
+	tags.Merge(s.ControlPlane.Spec.AdditionalTags)
 	return tags
 }
 
+// ServiceOptions returns the options the services reconcile the Azure resources of the AzureManagedControlPlane with.
+func (s *ManagedControlPlaneScope) ServiceOptions() ServiceOptions {
+	return s.serviceOptions
+}
+
+// AzureServiceReconcileTimeout returns how long the named service may take to reconcile the Azure resources of the AzureManagedControlPlane.
+func (s *ManagedControlPlaneScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	return s.serviceOptions.Timeouts.AzureServiceReconcileTimeout(serviceName)
+}
+
+// DriftPolicy returns how the Azure resources of the AzureManagedControlPlane which no longer match their spec are handled.
+func (s *ManagedControlPlaneScope) DriftPolicy() async.DriftPolicy {
+	return s.serviceOptions.DriftPolicy
+}
+
 // SubscriptionID returns the Azure client Subscription ID.
 func (s *ManagedControlPlaneScope) SubscriptionID() string {
 	return s.AzureClients.SubscriptionID()
@@ -203,9 +299,14 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ManagedControlPlaneScope.PatchObject")
 	defer done()
 
+	futures.SetBlockMoveAnnotation(s.ControlPlane)
+	if err := futures.Checkpoint(s.ControlPlane); err != nil {
+		return err
+	}
 	conditions.SetSummary(s.ControlPlane)
+	s.setOperationStatus()
 
-	return s.patchHelper.Patch(
+	if err := s.patchHelper.Patch(
 		ctx,
 		s.ControlPlane,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
@@ -216,7 +317,26 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 			infrav1.ManagedClusterRunningCondition,
 			infrav1.AgentPoolsReadyCondition,
 			infrav1.AzureResourceAvailableCondition,
-		}})
+		}}); err != nil {
+		return err
+	}
+	s.notifications.Send(ctx)
+	return nil
+}
+
+// setOperationStatus reports the progress of the ongoing operation on the managed cluster, if any.
+func (s *ManagedControlPlaneScope) setOperationStatus() {
+	var state infrav1.ProvisioningState
+	if s.ControlPlane.Status.Ready {
+		state = infrav1.Succeeded
+	}
+	state = operationFromFutures(state, s.ControlPlane.Status.LongRunningOperationStates, "managedcluster", s.ControlPlane.Name)
+
+	message := fmt.Sprintf("%s managed cluster %s", state, s.ControlPlane.Name)
+	if state == infrav1.Updating && s.ControlPlane.Spec.Version != "" {
+		message = fmt.Sprintf("%s to Kubernetes version %s", message, s.ControlPlane.Spec.Version)
+	}
+	s.ControlPlane.Status.OperationStatus = newOperationStatus(s.ControlPlane.Status.OperationStatus, state, message, 0, 0)
 }
 
 // Close closes the current scope persisting the cluster configuration and status.
@@ -227,6 +347,11 @@ func (s *ManagedControlPlaneScope) Close(ctx context.Context) error {
 	return s.PatchObject(ctx)
 }
 
+// Notify notifies that the AzureManagedControlPlane reached a lifecycle milestone, once its status is persisted.
+func (s *ManagedControlPlaneScope) Notify(eventType notify.EventType, message string) {
+	s.notifications.Add("AzureManagedControlPlane", s.ControlPlane, eventType, message)
+}
+
 // Vnet returns the cluster Vnet.
 func (s *ManagedControlPlaneScope) Vnet() *infrav1.VnetSpec {
 	return &infrav1.VnetSpec{
@@ -481,9 +606,11 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 	if s.ControlPlane.Spec.AADProfile != nil {
 		managedClusterSpec.AADProfile = &managedclusters.AADProfile{
 			Managed:             s.ControlPlane.Spec.AADProfile.Managed,
-			EnableAzureRBAC:     s.ControlPlane.Spec.AADProfile.Managed,
+			EnableAzureRBAC:     pointer.BoolDeref(s.ControlPlane.Spec.AADProfile.EnableAzureRBAC, s.ControlPlane.Spec.AADProfile.Managed),
 			AdminGroupObjectIDs: s.ControlPlane.Spec.AADProfile.AdminGroupObjectIDs,
 		}
+
+		managedClusterSpec.DisableLocalAccounts = s.ControlPlane.Spec.DisableLocalAccounts
 	}
 
 	if s.ControlPlane.Spec.AddonProfiles != nil {
@@ -495,6 +622,7 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 			})
 		}
 	}
+	managedClusterSpec.AddonProfiles = append(managedClusterSpec.AddonProfiles, s.addons()...)
 
 	if s.ControlPlane.Spec.SKU != nil {
 		managedClusterSpec.SKU = &managedclusters.SKU{
@@ -512,15 +640,75 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec() azure.ResourceSpecGetter
 		}
 	}
 
+	if s.ControlPlane.Spec.NATGatewayProfile != nil {
+		managedClusterSpec.NATGatewayProfile = &managedclusters.NATGatewayProfile{
+			ManagedOutboundIPs:   s.ControlPlane.Spec.NATGatewayProfile.ManagedOutboundIPs,
+			IdleTimeoutInMinutes: s.ControlPlane.Spec.NATGatewayProfile.IdleTimeoutInMinutes,
+		}
+	}
+
 	if s.ControlPlane.Spec.APIServerAccessProfile != nil {
 		managedClusterSpec.APIServerAccessProfile = &managedclusters.APIServerAccessProfile{
-			AuthorizedIPRanges:             s.ControlPlane.Spec.APIServerAccessProfile.AuthorizedIPRanges,
+			AuthorizedIPRanges:             s.authorizedIPRanges(),
 			EnablePrivateCluster:           s.ControlPlane.Spec.APIServerAccessProfile.EnablePrivateCluster,
 			PrivateDNSZone:                 s.ControlPlane.Spec.APIServerAccessProfile.PrivateDNSZone,
+			FQDNSubdomain:                  s.ControlPlane.Spec.APIServerAccessProfile.FQDNSubdomain,
 			EnablePrivateClusterPublicFQDN: s.ControlPlane.Spec.APIServerAccessProfile.EnablePrivateClusterPublicFQDN,
 		}
 	}
 
+	if s.ControlPlane.Spec.SecurityProfile != nil {
+		managedClusterSpec.SecurityProfile = &managedclusters.SecurityProfile{}
+		if kms := s.ControlPlane.Spec.SecurityProfile.AzureKeyVaultKms; kms != nil {
+			managedClusterSpec.SecurityProfile.AzureKeyVaultKms = &managedclusters.AzureKeyVaultKms{
+				Enabled:               kms.Enabled,
+				KeyID:                 kms.KeyID,
+				KeyVaultNetworkAccess: (*string)(kms.KeyVaultNetworkAccess),
+				KeyVaultResourceID:    kms.KeyVaultResourceID,
+			}
+		}
+		if defender := s.ControlPlane.Spec.SecurityProfile.Defender; defender != nil {
+			managedClusterSpec.SecurityProfile.Defender = &managedclusters.Defender{
+				LogAnalyticsWorkspaceResourceID: defender.LogAnalyticsWorkspaceResourceID,
+				SecurityMonitoringEnabled:       defender.SecurityMonitoring.Enabled,
+			}
+		}
+		if workloadIdentity := s.ControlPlane.Spec.SecurityProfile.WorkloadIdentity; workloadIdentity != nil {
+			managedClusterSpec.SecurityProfile.WorkloadIdentity = &managedclusters.WorkloadIdentity{
+				Enabled: workloadIdentity.Enabled,
+			}
+		}
+	}
+
+	if oidcIssuerProfile := s.ControlPlane.Spec.OIDCIssuerProfile; oidcIssuerProfile != nil {
+		managedClusterSpec.OIDCIssuerProfile = &managedclusters.OIDCIssuerProfile{
+			Enabled: oidcIssuerProfile.Enabled,
+		}
+	}
+
+	if s.ControlPlane.Spec.PowerState != nil {
+		managedClusterSpec.Stopped = pointer.Bool(*s.ControlPlane.Spec.PowerState == infrav1.PowerStateStopped)
+	}
+
+	if identity := s.ControlPlane.Spec.Identity; identity != nil && identity.Type == infrav1.ManagedControlPlaneIdentityTypeUserAssigned {
+		managedClusterSpec.UserAssignedIdentityResourceID = identity.UserAssignedIdentityResourceID
+	}
+
+	if proxyConfig := s.ControlPlane.Spec.HTTPProxyConfig; proxyConfig != nil {
+		managedClusterSpec.HTTPProxyConfig = &managedclusters.HTTPProxyConfig{
+			HTTPProxy:  proxyConfig.HTTPProxy,
+			HTTPSProxy: proxyConfig.HTTPSProxy,
+			NoProxy:    proxyConfig.NoProxy,
+			TrustedCA:  s.cache.httpProxyTrustedCA,
+		}
+	}
+
+	if s.ControlPlane.Spec.AutoUpgradeProfile != nil {
+		managedClusterSpec.AutoUpgradeProfile = &managedclusters.AutoUpgradeProfile{
+			UpgradeChannel: (*string)(s.ControlPlane.Spec.AutoUpgradeProfile.UpgradeChannel),
+		}
+	}
+
 	if s.ControlPlane.Spec.AutoScalerProfile != nil {
 		managedClusterSpec.AutoScalerProfile = &managedclusters.AutoScalerProfile{
 			BalanceSimilarNodeGroups:      (*string)(s.ControlPlane.Spec.AutoScalerProfile.BalanceSimilarNodeGroups),
@@ -565,7 +753,7 @@ func (s *ManagedControlPlaneScope) GetAllAgentPoolSpecs() ([]azure.ResourceSpecG
 			foundSystemPool = true
 		}
 
-		ammp := buildAgentPoolSpec(s.ControlPlane, pool.MachinePool, pool.InfraMachinePool, pool.InfraMachinePool.Annotations)
+		ammp := buildAgentPoolSpec(s.ControlPlane, pool.MachinePool, pool.InfraMachinePool, pool.InfraMachinePool.Annotations, s.serviceOptions.DefaultTags)
 		ammps = append(ammps, ammp)
 	}
 
@@ -582,6 +770,28 @@ func (s *ManagedControlPlaneScope) SetControlPlaneEndpoint(endpoint clusterv1.AP
 	s.ControlPlane.Spec.ControlPlaneEndpoint.Port = endpoint.Port
 }
 
+// SetVersionStatus sets the Kubernetes version of the managed cluster reported by AKS.
+func (s *ManagedControlPlaneScope) SetVersionStatus(version string) {
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	s.ControlPlane.Status.Version = version
+}
+
+// SetPowerStateStatus sets the power state of the managed cluster reported by AKS.
+func (s *ManagedControlPlaneScope) SetPowerStateStatus(powerState infrav1.PowerState) {
+	s.ControlPlane.Status.PowerState = powerState
+}
+
+// SetOIDCIssuerURLStatus sets the OIDC issuer URL of the managed cluster reported by AKS.
+func (s *ManagedControlPlaneScope) SetOIDCIssuerURLStatus(issuerURL *string) {
+	if issuerURL == nil {
+		s.ControlPlane.Status.OIDCIssuerProfile = nil
+		return
+	}
+	s.ControlPlane.Status.OIDCIssuerProfile = &infrav1.OIDCIssuerProfileStatus{IssuerURL: issuerURL}
+}
+
 // MakeEmptyKubeConfigSecret creates an empty secret object that is used for storing kubeconfig secret data.
 func (s *ManagedControlPlaneScope) MakeEmptyKubeConfigSecret() corev1.Secret {
 	return corev1.Secret{
@@ -605,6 +815,11 @@ func (s *ManagedControlPlaneScope) SetKubeConfigData(kubeConfigData []byte) {
 	s.kubeConfigData = kubeConfigData
 }
 
+// ReportEvent emits an event on the AzureManagedControlPlane for a change of one of its Azure resources.
+func (s *ManagedControlPlaneScope) ReportEvent(eventType, reason, message string) {
+	record.Emit(s.ControlPlane, eventType, reason, message)
+}
+
 // SetLongRunningOperationState will set the future on the AzureManagedControlPlane status to allow the resource to continue
 // in the next reconciliation.
 func (s *ManagedControlPlaneScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -629,7 +844,7 @@ func (s *ManagedControlPlaneScope) UpdateDeleteStatus(condition clusterv1.Condit
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.ControlPlane, condition, failedReason(err, infrav1.DeletionFailedReason), clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
 }
 
@@ -641,7 +856,7 @@ func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.Condition
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.ControlPlane, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
 }
 
@@ -653,7 +868,7 @@ func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.Conditi
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
-		conditions.MarkFalse(s.ControlPlane, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.ControlPlane, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
 
@@ -749,6 +964,7 @@ func (s *ManagedControlPlaneScope) PrivateEndpointSpecs() []azure.ResourceSpecGe
 			ManualApproval:            privateEndpoint.ManualApproval,
 			ClusterName:               s.ClusterName(),
 			AdditionalTags:            s.AdditionalTags(),
+			PrivateDNSZoneIDs:         privateEndpoint.PrivateDNSZoneIDs,
 		}
 
 		for _, privateLinkServiceConnection := range privateEndpoint.PrivateLinkServiceConnections {
@@ -766,3 +982,106 @@ func (s *ManagedControlPlaneScope) PrivateEndpointSpecs() []azure.ResourceSpecGe
 
 	return privateEndpointSpecs
 }
+
+// MaintenanceConfigurationSpecs returns the maintenance configuration specs of the managed cluster.
+func (s *ManagedControlPlaneScope) MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.ControlPlane.Spec.MaintenanceConfigurations))
+	for _, config := range s.ControlPlane.Spec.MaintenanceConfigurations {
+		spec := &maintenanceconfigurations.MaintenanceConfigurationSpec{
+			Name:          config.Name,
+			ResourceGroup: s.ResourceGroup(),
+			Cluster:       s.ControlPlane.Name,
+		}
+		for _, timeInWeek := range config.TimeInWeek {
+			spec.TimeInWeek = append(spec.TimeInWeek, maintenanceconfigurations.TimeInWeek{
+				Day:       string(timeInWeek.Day),
+				HourSlots: timeInWeek.HourSlots,
+			})
+		}
+		for _, timeSpan := range config.NotAllowedTime {
+			spec.NotAllowedTime = append(spec.NotAllowedTime, maintenanceconfigurations.TimeSpan{
+				Start: timeSpan.Start.Time,
+				End:   timeSpan.End.Time,
+			})
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// authorizedIPRanges returns the IP ranges authorized to reach the API server, along with the egress IP ranges of the
+// management cluster when the control plane includes them. Those are only added when the access to the API server is
+// already restricted, as adding them to an empty list would restrict it.
+func (s *ManagedControlPlaneScope) authorizedIPRanges() []string {
+	profile := s.ControlPlane.Spec.APIServerAccessProfile
+	if len(profile.AuthorizedIPRanges) == 0 || !pointer.BoolDeref(profile.IncludeManagementClusterEgressIPs, false) {
+		return profile.AuthorizedIPRanges
+	}
+
+	ranges := append([]string{}, profile.AuthorizedIPRanges...)
+	for _, egress := range s.managementClusterEgressIPRanges {
+		found := false
+		for _, r := range ranges {
+			if r == egress {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ranges = append(ranges, egress)
+		}
+	}
+	return ranges
+}
+
+// addons returns the add-on profiles of the typed add-ons of the control plane.
+func (s *ManagedControlPlaneScope) addons() []managedclusters.AddonProfile {
+	addons := s.ControlPlane.Spec.Addons
+	if addons == nil {
+		return nil
+	}
+
+	var profiles []managedclusters.AddonProfile
+	if addons.AzurePolicy != nil {
+		profiles = append(profiles, managedclusters.AddonProfile{
+			Name:    infrav1.AzurePolicyAddonName,
+			Enabled: addons.AzurePolicy.Enabled,
+		})
+	}
+	if addons.Monitoring != nil {
+		profile := managedclusters.AddonProfile{
+			Name:    infrav1.MonitoringAddonName,
+			Enabled: addons.Monitoring.Enabled,
+		}
+		if addons.Monitoring.LogAnalyticsWorkspaceResourceID != "" {
+			profile.Config = map[string]string{
+				"logAnalyticsWorkspaceResourceID": addons.Monitoring.LogAnalyticsWorkspaceResourceID,
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+	if addons.AzureKeyvaultSecretsProvider != nil {
+		profile := managedclusters.AddonProfile{
+			Name:    infrav1.AzureKeyvaultSecretsProviderAddonName,
+			Enabled: addons.AzureKeyvaultSecretsProvider.Enabled,
+		}
+		config := map[string]string{}
+		if addons.AzureKeyvaultSecretsProvider.EnableSecretRotation != nil {
+			config["enableSecretRotation"] = strconv.FormatBool(*addons.AzureKeyvaultSecretsProvider.EnableSecretRotation)
+		}
+		if addons.AzureKeyvaultSecretsProvider.RotationPollInterval != nil {
+			config["rotationPollInterval"] = *addons.AzureKeyvaultSecretsProvider.RotationPollInterval
+		}
+		if len(config) > 0 {
+			profile.Config = config
+		}
+		profiles = append(profiles, profile)
+	}
+	if addons.OpenServiceMesh != nil {
+		profiles = append(profiles, managedclusters.AddonProfile{
+			Name:    infrav1.OpenServiceMeshAddonName,
+			Enabled: addons.OpenServiceMesh.Enabled,
+		})
+	}
+	return profiles
+}
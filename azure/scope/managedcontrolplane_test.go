@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
@@ -316,6 +317,58 @@ func TestManagedControlPlaneScope_AddonProfiles(t *testing.T) {
 				{Name: "addon2", Config: map[string]string{"k1": "v1", "k2": "v2"}, Enabled: true},
 			},
 		},
+		{
+			Name: "With typed add-ons",
+			Input: ManagedControlPlaneScopeParams{
+				AzureClients: AzureClients{
+					Authorizer: autorest.NullAuthorizer{},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+				},
+				ControlPlane: &infrav1.AzureManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+					Spec: infrav1.AzureManagedControlPlaneSpec{
+						SubscriptionID: "00000000-0000-0000-0000-000000000000",
+						AddonProfiles: []infrav1.AddonProfile{
+							{Name: "addon1", Config: nil, Enabled: true},
+						},
+						Addons: &infrav1.ManagedClusterAddons{
+							AzurePolicy: &infrav1.AzurePolicyAddon{Enabled: true},
+							Monitoring: &infrav1.MonitoringAddon{
+								Enabled:                         true,
+								LogAnalyticsWorkspaceResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/ws",
+							},
+							AzureKeyvaultSecretsProvider: &infrav1.AzureKeyvaultSecretsProviderAddon{
+								Enabled:              true,
+								EnableSecretRotation: pointer.Bool(true),
+								RotationPollInterval: pointer.String("2m"),
+							},
+							OpenServiceMesh: &infrav1.OpenServiceMeshAddon{Enabled: false},
+						},
+					},
+				},
+				ManagedMachinePools: []ManagedMachinePool{
+					{
+						MachinePool:      getMachinePool("pool0"),
+						InfraMachinePool: getAzureMachinePool("pool0", infrav1.NodePoolModeSystem),
+					},
+				},
+			},
+			Expected: []managedclusters.AddonProfile{
+				{Name: "addon1", Config: nil, Enabled: true},
+				{Name: "azurepolicy", Config: nil, Enabled: true},
+				{Name: "omsagent", Config: map[string]string{"logAnalyticsWorkspaceResourceID": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/ws"}, Enabled: true},
+				{Name: "azureKeyvaultSecretsProvider", Config: map[string]string{"enableSecretRotation": "true", "rotationPollInterval": "2m"}, Enabled: true},
+				{Name: "openServiceMesh", Config: nil, Enabled: false},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -603,3 +656,120 @@ func TestManagedControlPlaneScope_IsVnetManagedCache(t *testing.T) {
 		})
 	}
 }
+
+func TestManagedControlPlaneScope_AuthorizedIPRanges(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Profile  *infrav1.APIServerAccessProfile
+		Expected []string
+	}{
+		{
+			Name:     "egress IPs not included",
+			Profile:  &infrav1.APIServerAccessProfile{AuthorizedIPRanges: []string{"12.34.56.78/32"}},
+			Expected: []string{"12.34.56.78/32"},
+		},
+		{
+			Name: "egress IPs included",
+			Profile: &infrav1.APIServerAccessProfile{
+				AuthorizedIPRanges:                []string{"12.34.56.78/32", "20.1.2.3/32"},
+				IncludeManagementClusterEgressIPs: pointer.Bool(true),
+			},
+			Expected: []string{"12.34.56.78/32", "20.1.2.3/32", "20.1.4.0/28"},
+		},
+		{
+			Name:     "egress IPs not added to an unrestricted API server",
+			Profile:  &infrav1.APIServerAccessProfile{IncludeManagementClusterEgressIPs: pointer.Bool(true)},
+			Expected: nil,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &ManagedControlPlaneScope{
+				ControlPlane: &infrav1.AzureManagedControlPlane{
+					Spec: infrav1.AzureManagedControlPlaneSpec{
+						APIServerAccessProfile: c.Profile,
+					},
+				},
+				managementClusterEgressIPRanges: []string{"20.1.2.3/32", "20.1.4.0/28"},
+			}
+			g.Expect(s.authorizedIPRanges()).To(Equal(c.Expected))
+		})
+	}
+}
+
+func TestManagedControlPlaneScope_HTTPProxyTrustedCA(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "proxy-ca",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			infrav1.HTTPProxyTrustedCAKey: []byte("ca"),
+		},
+	}
+
+	cases := []struct {
+		Name        string
+		SecretRef   *corev1.LocalObjectReference
+		Expected    *string
+		ExpectedErr bool
+	}{
+		{
+			Name:     "without trusted CA",
+			Expected: nil,
+		},
+		{
+			Name:      "with trusted CA",
+			SecretRef: &corev1.LocalObjectReference{Name: "proxy-ca"},
+			Expected:  pointer.String("Y2E="),
+		},
+		{
+			Name:        "with missing trusted CA secret",
+			SecretRef:   &corev1.LocalObjectReference{Name: "missing-proxy-ca"},
+			ExpectedErr: true,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &ManagedControlPlaneScope{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(caSecret).Build(),
+				ControlPlane: &infrav1.AzureManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+					Spec: infrav1.AzureManagedControlPlaneSpec{
+						HTTPProxyConfig: &infrav1.HTTPProxyConfig{
+							HTTPProxy:          pointer.String("http://proxy.example.com:3128/"),
+							TrustedCASecretRef: c.SecretRef,
+						},
+					},
+				},
+				cache: &ManagedControlPlaneCache{},
+			}
+			err := s.InitManagedControlPlaneCache(context.TODO())
+			if c.ExpectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(s.cache.httpProxyTrustedCA).To(Equal(c.Expected))
+		})
+	}
+}
+
+func TestParseIPRanges(t *testing.T) {
+	g := NewWithT(t)
+
+	ranges, err := ParseIPRanges([]string{"20.1.2.3", "2001:db8::1", "20.1.4.0/28"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ranges).To(Equal([]string{"20.1.2.3/32", "2001:db8::1/128", "20.1.4.0/28"}))
+	_, err = ParseIPRanges([]string{"not-an-ip"})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -20,12 +20,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -71,6 +74,10 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 
+	// Resume the long running operations which were in flight before the AzureManagedMachinePool lost its status,
+	// e.g. when it was moved to another management cluster.
+	futures.Restore(params.InfraMachinePool)
+
 	capiMachinePoolPatchHelper, err := patch.NewHelper(params.MachinePool, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
@@ -85,6 +92,7 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		patchHelper:                helper,
 		capiMachinePoolPatchHelper: capiMachinePoolPatchHelper,
 		ManagedClusterScoper:       params.ManagedControlPlaneScope,
+		serviceOptions:             serviceOptionsOf(params.ManagedControlPlaneScope),
 	}, nil
 }
 
@@ -99,6 +107,9 @@ type ManagedMachinePoolScope struct {
 	MachinePool      *expv1.MachinePool
 	ControlPlane     *infrav1.AzureManagedControlPlane
 	InfraMachinePool *infrav1.AzureManagedMachinePool
+
+	// serviceOptions are those of the managed control plane scope.
+	serviceOptions ServiceOptions
 }
 
 // PatchObject persists the cluster configuration and status.
@@ -106,6 +117,10 @@ func (s *ManagedMachinePoolScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ManagedMachinePoolScope.PatchObject")
 	defer done()
 
+	futures.SetBlockMoveAnnotation(s.InfraMachinePool)
+	if err := futures.Checkpoint(s.InfraMachinePool); err != nil {
+		return err
+	}
 	conditions.SetSummary(s.InfraMachinePool)
 
 	return s.patchHelper.Patch(
@@ -141,7 +156,17 @@ func (s *ManagedMachinePoolScope) SetSubnetName() {
 
 // AgentPoolSpec returns an azure.ResourceSpecGetter for currently reconciled AzureManagedMachinePool.
 func (s *ManagedMachinePoolScope) AgentPoolSpec() azure.ResourceSpecGetter {
-	return buildAgentPoolSpec(s.ControlPlane, s.MachinePool, s.InfraMachinePool, s.AgentPoolAnnotations())
+	return buildAgentPoolSpec(s.ControlPlane, s.MachinePool, s.InfraMachinePool, s.AgentPoolAnnotations(), s.serviceOptions.DefaultTags)
+}
+
+// AzureServiceReconcileTimeout returns how long the named service may take to reconcile the Azure resources of the AzureManagedMachinePool.
+func (s *ManagedMachinePoolScope) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	return s.serviceOptions.Timeouts.AzureServiceReconcileTimeout(serviceName)
+}
+
+// DriftPolicy returns how the Azure resources of the AzureManagedMachinePool which no longer match their spec are handled.
+func (s *ManagedMachinePoolScope) DriftPolicy() async.DriftPolicy {
+	return s.serviceOptions.DriftPolicy
 }
 
 func getAgentPoolSubnet(controlPlane *infrav1.AzureManagedControlPlane, infraMachinePool *infrav1.AzureManagedMachinePool) *string {
@@ -154,7 +179,8 @@ func getAgentPoolSubnet(controlPlane *infrav1.AzureManagedControlPlane, infraMac
 func buildAgentPoolSpec(managedControlPlane *infrav1.AzureManagedControlPlane,
 	machinePool *expv1.MachinePool,
 	managedMachinePool *infrav1.AzureManagedMachinePool,
-	agentPoolAnnotations map[string]string) azure.ResourceSpecGetter {
+	agentPoolAnnotations map[string]string,
+	defaultTags infrav1.Tags) azure.ResourceSpecGetter {
 	var normalizedVersion *string
 	if machinePool.Spec.Template.Spec.Version != nil {
 		v := strings.TrimPrefix(*machinePool.Spec.Template.Spec.Version, "v")
@@ -180,18 +206,27 @@ func buildAgentPoolSpec(managedControlPlane *infrav1.AzureManagedControlPlane,
 			managedControlPlane.Spec.VirtualNetwork.Name,
 			pointer.StringDeref(getAgentPoolSubnet(managedControlPlane, managedMachinePool), ""),
 		),
-		Mode:                 managedMachinePool.Spec.Mode,
-		MaxPods:              managedMachinePool.Spec.MaxPods,
-		AvailabilityZones:    managedMachinePool.Spec.AvailabilityZones,
-		OsDiskType:           managedMachinePool.Spec.OsDiskType,
-		EnableUltraSSD:       managedMachinePool.Spec.EnableUltraSSD,
-		Headers:              maps.FilterByKeyPrefix(agentPoolAnnotations, infrav1.CustomHeaderPrefix),
-		EnableNodePublicIP:   managedMachinePool.Spec.EnableNodePublicIP,
-		NodePublicIPPrefixID: managedMachinePool.Spec.NodePublicIPPrefixID,
-		ScaleSetPriority:     managedMachinePool.Spec.ScaleSetPriority,
-		AdditionalTags:       managedMachinePool.Spec.AdditionalTags,
-		KubeletDiskType:      managedMachinePool.Spec.KubeletDiskType,
-		LinuxOSConfig:        managedMachinePool.Spec.LinuxOSConfig,
+		Mode:                   managedMachinePool.Spec.Mode,
+		MaxPods:                managedMachinePool.Spec.MaxPods,
+		AvailabilityZones:      managedMachinePool.Spec.AvailabilityZones,
+		OsDiskType:             managedMachinePool.Spec.OsDiskType,
+		EnableUltraSSD:         managedMachinePool.Spec.EnableUltraSSD,
+		Headers:                maps.FilterByKeyPrefix(agentPoolAnnotations, infrav1.CustomHeaderPrefix),
+		EnableNodePublicIP:     managedMachinePool.Spec.EnableNodePublicIP,
+		NodePublicIPPrefixID:   managedMachinePool.Spec.NodePublicIPPrefixID,
+		ScaleSetPriority:       managedMachinePool.Spec.ScaleSetPriority,
+		ScaleSetEvictionPolicy: (*string)(managedMachinePool.Spec.ScaleSetEvictionPolicy),
+		SpotMaxPrice:           managedMachinePool.Spec.SpotMaxPrice,
+		AdditionalTags:         managedMachinePool.Spec.AdditionalTags,
+		KubeletDiskType:        managedMachinePool.Spec.KubeletDiskType,
+		LinuxOSConfig:          managedMachinePool.Spec.LinuxOSConfig,
+	}
+
+	if len(defaultTags) > 0 {
+		tags := make(infrav1.Tags)
+		tags.Merge(defaultTags)
+		tags.Merge(managedMachinePool.Spec.AdditionalTags)
+		agentPoolSpec.AdditionalTags = tags
 	}
 
 	if managedMachinePool.Spec.OSDiskSizeGB != nil {
@@ -255,6 +290,26 @@ func (s *ManagedMachinePoolScope) SetAgentPoolReady(ready bool) {
 	s.InfraMachinePool.Status.Ready = ready
 }
 
+// SetAgentPoolOperationStatus reports the progress of the ongoing operation on the agent pool, if any, based on the
+// provisioning state of its scale set and the number of its instances which run the latest model.
+func (s *ManagedMachinePoolScope) SetAgentPoolOperationStatus(state infrav1.ProvisioningState, upToDate, desired int32) {
+	name := pointer.StringDeref(s.InfraMachinePool.Spec.Name, "")
+	state = operationFromFutures(state, s.InfraMachinePool.Status.LongRunningOperationStates, "agentpools", name)
+
+	message := fmt.Sprintf("%s agent pool %s", state, name)
+	if state == infrav1.Updating && desired > 0 {
+		message = fmt.Sprintf("Updating instances, %d/%d up to date", upToDate, desired)
+	} else {
+		desired = 0
+	}
+	s.InfraMachinePool.Status.OperationStatus = newOperationStatus(s.InfraMachinePool.Status.OperationStatus, state, message, upToDate, desired)
+}
+
+// ReportEvent emits an event on the AzureManagedMachinePool for a change of one of its Azure resources.
+func (s *ManagedMachinePoolScope) ReportEvent(eventType, reason, message string) {
+	record.Emit(s.InfraMachinePool, eventType, reason, message)
+}
+
 // SetLongRunningOperationState will set the future on the AzureManagedMachinePool status to allow the resource to continue
 // in the next reconciliation.
 func (s *ManagedMachinePoolScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -279,7 +334,7 @@ func (s *ManagedMachinePoolScope) UpdateDeleteStatus(condition clusterv1.Conditi
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.InfraMachinePool, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
-		conditions.MarkFalse(s.InfraMachinePool, condition, infrav1.DeletionFailedReason, clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
+		conditions.MarkFalse(s.InfraMachinePool, condition, failedReason(err, infrav1.DeletionFailedReason), clusterv1.ConditionSeverityError, "%s failed to delete. err: %s", service, err.Error())
 	}
 }
 
@@ -291,7 +346,7 @@ func (s *ManagedMachinePoolScope) UpdatePutStatus(condition clusterv1.ConditionT
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.InfraMachinePool, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
-		conditions.MarkFalse(s.InfraMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.InfraMachinePool, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to create or update. err: %s", service, err.Error())
 	}
 }
 
@@ -303,7 +358,7 @@ func (s *ManagedMachinePoolScope) UpdatePatchStatus(condition clusterv1.Conditio
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.InfraMachinePool, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
-		conditions.MarkFalse(s.InfraMachinePool, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
+		conditions.MarkFalse(s.InfraMachinePool, condition, failedReason(err, infrav1.FailedReason), clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
 
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// Namer generates the names of the Azure resources the scopes derive from other resources, such as the network
+// interfaces and disks of a machine. Names set explicitly in the spec of a resource are not passed to the Namer.
+type Namer interface {
+	// NICName returns the name of a network interface of a machine.
+	NICName(machineName string, multiNIC bool, index int) string
+	// NodePublicIPName returns the name of the public IP of a machine.
+	NodePublicIPName(machineName string) string
+	// OSDiskName returns the name of the OS disk of a machine.
+	OSDiskName(machineName string) string
+	// DataDiskName returns the name of a data disk of a machine.
+	DataDiskName(machineName, nameSuffix string) string
+	// AvailabilitySetName returns the name of the availability set of a node group.
+	AvailabilitySetName(clusterName, nodeGroup string) string
+	// BackendAddressPoolName returns the name of the backend address pool of a load balancer.
+	BackendAddressPoolName(lbName string) string
+	// OutboundBackendAddressPoolName returns the name of the outbound backend address pool of a load balancer.
+	OutboundBackendAddressPoolName(lbName string) string
+	// InternalAPIServerLBName returns the name of the internal load balancer serving the private frontend IPs of a
+	// public API server load balancer.
+	InternalAPIServerLBName(lbName string) string
+	// PrivateDNSZoneName returns the name of the private DNS zone of a cluster.
+	PrivateDNSZoneName(clusterName string) string
+	// VNetLinkName returns the name of the link between a private DNS zone and a virtual network.
+	VNetLinkName(vnetName string) string
+	// VnetPeeringName returns the name of a peering between two virtual networks.
+	VnetPeeringName(sourceVnetName, remoteVnetName string) string
+}
+
+// DefaultNamer is the Namer used unless another one is set with SetNamer. It generates the names CAPZ has always used.
+type DefaultNamer struct{}
+
+var _ Namer = DefaultNamer{}
+
+// namer generates the names of the Azure resources of all clusters.
+var namer Namer = DefaultNamer{}
+
+// SetNamer sets the Namer used to generate the names of the Azure resources of all clusters. It must be called before
+// the controllers are started, as changing the names of existing resources makes CAPZ create new ones instead.
+func SetNamer(n Namer) {
+	if n == nil {
+		n = DefaultNamer{}
+	}
+	namer = n
+}
+
+// NICName returns the name of a network interface of a machine.
+func (DefaultNamer) NICName(machineName string, multiNIC bool, index int) string {
+	return azure.GenerateNICName(machineName, multiNIC, index)
+}
+
+// NodePublicIPName returns the name of the public IP of a machine.
+func (DefaultNamer) NodePublicIPName(machineName string) string {
+	return azure.GenerateNodePublicIPName(machineName)
+}
+
+// OSDiskName returns the name of the OS disk of a machine.
+func (DefaultNamer) OSDiskName(machineName string) string {
+	return azure.GenerateOSDiskName(machineName)
+}
+
+// DataDiskName returns the name of a data disk of a machine.
+func (DefaultNamer) DataDiskName(machineName, nameSuffix string) string {
+	return azure.GenerateDataDiskName(machineName, nameSuffix)
+}
+
+// AvailabilitySetName returns the name of the availability set of a node group.
+func (DefaultNamer) AvailabilitySetName(clusterName, nodeGroup string) string {
+	return azure.GenerateAvailabilitySetName(clusterName, nodeGroup)
+}
+
+// BackendAddressPoolName returns the name of the backend address pool of a load balancer.
+func (DefaultNamer) BackendAddressPoolName(lbName string) string {
+	return azure.GenerateBackendAddressPoolName(lbName)
+}
+
+// OutboundBackendAddressPoolName returns the name of the outbound backend address pool of a load balancer.
+func (DefaultNamer) OutboundBackendAddressPoolName(lbName string) string {
+	return azure.GenerateOutboundBackendAddressPoolName(lbName)
+}
+
+// InternalAPIServerLBName returns the name of the internal load balancer serving the private frontend IPs of a public
+// API server load balancer.
+func (DefaultNamer) InternalAPIServerLBName(lbName string) string {
+	return azure.GenerateInternalAPIServerLBName(lbName)
+}
+
+// PrivateDNSZoneName returns the name of the private DNS zone of a cluster.
+func (DefaultNamer) PrivateDNSZoneName(clusterName string) string {
+	return azure.GeneratePrivateDNSZoneName(clusterName)
+}
+
+// VNetLinkName returns the name of the link between a private DNS zone and a virtual network.
+func (DefaultNamer) VNetLinkName(vnetName string) string {
+	return azure.GenerateVNetLinkName(vnetName)
+}
+
+// VnetPeeringName returns the name of a peering between two virtual networks.
+func (DefaultNamer) VnetPeeringName(sourceVnetName, remoteVnetName string) string {
+	return azure.GenerateVnetPeeringName(sourceVnetName, remoteVnetName)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// prefixNamer is a Namer prefixing the disk names of the DefaultNamer.
+type prefixNamer struct {
+	DefaultNamer
+}
+
+func (prefixNamer) OSDiskName(machineName string) string {
+	return fmt.Sprintf("disk-os-%s", machineName)
+}
+
+func (prefixNamer) DataDiskName(machineName, nameSuffix string) string {
+	return fmt.Sprintf("disk-%s-%s", nameSuffix, machineName)
+}
+
+func TestSetNamer(t *testing.T) {
+	g := NewWithT(t)
+
+	SetNamer(prefixNamer{})
+	defer SetNamer(nil)
+
+	machineScope := MachineScope{
+		ClusterScoper: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-azure-machine",
+			},
+			Spec: infrav1.AzureMachineSpec{
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix: "etcddisk",
+					},
+				},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "machine",
+			},
+		},
+	}
+
+	g.Expect(machineScope.DiskSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&disks.DiskSpec{
+			Name:          "disk-os-my-azure-machine",
+			ResourceGroup: "my-rg",
+		},
+		&disks.DiskSpec{
+			Name:          "disk-etcddisk-my-azure-machine",
+			ResourceGroup: "my-rg",
+		},
+	}))
+
+	// the VM is created with the same data disk names the disks are deleted with
+	g.Expect(machineScope.dataDiskNames()).To(Equal([]string{"disk-etcddisk-my-azure-machine"}))
+
+	// names not overridden are generated by the DefaultNamer
+	g.Expect(machineScope.ClusterScoper.(*ClusterScope).OutboundPoolName("my-lb")).To(Equal("my-lb-outboundBackendPool"))
+
+	SetNamer(nil)
+	g.Expect(machineScope.DiskSpecs()[0].ResourceName()).To(Equal("my-azure-machine_OSDisk"))
+}
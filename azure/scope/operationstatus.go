@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// isOperationInProgress returns true if the provisioning state is one of an Azure resource being changed.
+func isOperationInProgress(state infrav1.ProvisioningState) bool {
+	switch state {
+	case infrav1.Creating, infrav1.Updating, infrav1.Deleting, infrav1.Migrating:
+		return true
+	default:
+		return false
+	}
+}
+
+// operationFromFutures returns the provisioning state implied by the long running operations on a resource when
+// Azure doesn't report one yet, for example while the resource is being created.
+func operationFromFutures(state infrav1.ProvisioningState, futures infrav1.Futures, serviceName, name string) infrav1.ProvisioningState {
+	if isOperationInProgress(state) {
+		return state
+	}
+	for _, future := range futures {
+		if future.ServiceName != serviceName || future.Name != name {
+			continue
+		}
+		switch future.Type {
+		case infrav1.DeleteFuture:
+			return infrav1.Deleting
+		case infrav1.PutFuture:
+			if state == "" {
+				return infrav1.Creating
+			}
+			return infrav1.Updating
+		case infrav1.PatchFuture:
+			return infrav1.Updating
+		}
+	}
+	return state
+}
+
+// newOperationStatus returns the status of the operation in the given provisioning state, or nil if no operation is
+// in progress. The start time of the previous status is kept as long as the provisioning state doesn't change. The
+// percentage of completion is only set when total is positive.
+func newOperationStatus(previous *infrav1.OperationStatus, state infrav1.ProvisioningState, message string, done, total int32) *infrav1.OperationStatus {
+	if !isOperationInProgress(state) {
+		return nil
+	}
+
+	status := &infrav1.OperationStatus{
+		ProvisioningState: state,
+		Message:           message,
+	}
+	if previous != nil && previous.ProvisioningState == state && previous.StartTime != nil {
+		status.StartTime = previous.StartTime
+	} else {
+		now := metav1.Now()
+		status.StartTime = &now
+	}
+	if total > 0 {
+		if done > total {
+			done = total
+		}
+		percent := done * 100 / total
+		status.PercentComplete = &percent
+	}
+	return status
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestNewOperationStatus(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	previous := &infrav1.OperationStatus{
+		ProvisioningState: infrav1.Updating,
+		StartTime:         &startTime,
+	}
+	tests := []struct {
+		name            string
+		previous        *infrav1.OperationStatus
+		state           infrav1.ProvisioningState
+		done            int32
+		total           int32
+		expectNil       bool
+		expectStartTime *metav1.Time
+		expectPercent   *int32
+	}{
+		{
+			name:      "no operation in progress",
+			previous:  previous,
+			state:     infrav1.Succeeded,
+			expectNil: true,
+		},
+		{
+			name:            "same operation keeps its start time",
+			previous:        previous,
+			state:           infrav1.Updating,
+			done:            3,
+			total:           5,
+			expectStartTime: &startTime,
+			expectPercent:   pointer.Int32(60),
+		},
+		{
+			name:     "new operation starts now",
+			previous: previous,
+			state:    infrav1.Deleting,
+		},
+		{
+			name:          "progress doesn't exceed 100 percent",
+			state:         infrav1.Updating,
+			done:          4,
+			total:         2,
+			expectPercent: pointer.Int32(100),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			status := newOperationStatus(tt.previous, tt.state, "message", tt.done, tt.total)
+			if tt.expectNil {
+				g.Expect(status).To(BeNil())
+				return
+			}
+			g.Expect(status.ProvisioningState).To(Equal(tt.state))
+			g.Expect(status.Message).To(Equal("message"))
+			g.Expect(status.PercentComplete).To(Equal(tt.expectPercent))
+			g.Expect(status.StartTime).NotTo(BeNil())
+			if tt.expectStartTime != nil {
+				g.Expect(status.StartTime).To(Equal(tt.expectStartTime))
+			} else {
+				g.Expect(status.StartTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			}
+		})
+	}
+}
+
+func TestOperationFromFutures(t *testing.T) {
+	futures := infrav1.Futures{
+		{Type: infrav1.PutFuture, ServiceName: "virtualmachine", Name: "my-vm"},
+	}
+	tests := []struct {
+		name     string
+		state    infrav1.ProvisioningState
+		futures  infrav1.Futures
+		expected infrav1.ProvisioningState
+	}{
+		{
+			name:     "state reported by Azure is kept",
+			state:    infrav1.Deleting,
+			futures:  futures,
+			expected: infrav1.Deleting,
+		},
+		{
+			name:     "put future on a new resource is a creation",
+			futures:  futures,
+			expected: infrav1.Creating,
+		},
+		{
+			name:     "put future on an existing resource is an update",
+			state:    infrav1.Succeeded,
+			futures:  futures,
+			expected: infrav1.Updating,
+		},
+		{
+			name:     "delete future is a deletion",
+			state:    infrav1.Succeeded,
+			futures:  infrav1.Futures{{Type: infrav1.DeleteFuture, ServiceName: "virtualmachine", Name: "my-vm"}},
+			expected: infrav1.Deleting,
+		},
+		{
+			name:     "futures of other resources are ignored",
+			state:    infrav1.Succeeded,
+			futures:  infrav1.Futures{{Type: infrav1.DeleteFuture, ServiceName: "virtualmachine", Name: "other-vm"}},
+			expected: infrav1.Succeeded,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(operationFromFutures(tt.state, tt.futures, "virtualmachine", "my-vm")).To(Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+// ServiceOptions configure how the services reconcile the Azure resources of a scope. They are set by the controller
+// manager flags.
+type ServiceOptions struct {
+	// DefaultTags are applied to all the Azure resources, beneath the additional tags of their spec.
+	DefaultTags infrav1.Tags
+	// Timeouts bound the reconciles of the Azure services.
+	Timeouts reconciler.Timeouts
+	// DriftPolicy sets how the Azure resources which no longer match their spec are handled. Drifted resources are
+	// corrected if it is empty.
+	DriftPolicy async.DriftPolicy
+	// ARMRateLimitRemainingThreshold is the number of remaining Azure Resource Manager requests of a subscription
+	// below which the reconciles of its Azure resources are throttled.
+	ARMRateLimitRemainingThreshold int64
+}
+
+// serviceOptionsGetter is a scope which has ServiceOptions.
+type serviceOptionsGetter interface {
+	ServiceOptions() ServiceOptions
+}
+
+// serviceOptionsOf returns the ServiceOptions of a cluster scope, or the zero ServiceOptions if it has none.
+func serviceOptionsOf(clusterScope interface{}) ServiceOptions {
+	if getter, ok := clusterScope.(serviceOptionsGetter); ok {
+		return getter.ServiceOptions()
+	}
+	return ServiceOptions{}
+}
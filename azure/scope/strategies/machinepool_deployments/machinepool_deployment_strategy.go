@@ -27,9 +27,15 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+// surgeProvisioningTimeout is how long the machines without the latest model are kept while a surge machine is being
+// provisioned. Past it, they are deleted within maxUnavailable, so a surge machine which never finishes provisioning
+// doesn't block the rollout.
+const surgeProvisioningTimeout = 20 * time.Minute
+
 type (
 	// Surger is the ability to surge a number of replica.
 	Surger interface {
@@ -116,6 +122,11 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		return nil, err
 	}
 
+	maxSurge, err := rollingUpdateStrategy.Surge(int(desiredReplicaCount))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get scaled value or int from maxSurge")
+	}
+
 	var (
 		order = func() func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
 			switch rollingUpdateStrategy.DeletePolicy {
@@ -131,7 +142,9 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		failedMachines             = order(getFailedMachines(machinesByProviderID))
 		deletingMachines           = order(getDeletingMachines(machinesByProviderID))
 		readyMachines              = order(getReadyMachines(machinesByProviderID))
+		machinesMarkedForDeletion  = order(getMachinesMarkedForDeletion(machinesByProviderID))
 		machinesWithoutLatestModel = order(getMachinesWithoutLatestModel(machinesByProviderID))
+		provisioningMachines       = getProvisioningMachines(machinesByProviderID, time.Now())
 		overProvisionCount         = len(readyMachines) - int(desiredReplicaCount)
 		disruptionBudget           = func() int {
 			if maxUnavailable > int(desiredReplicaCount) {
@@ -150,6 +163,7 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		"machinesWithoutTheLatestModel", len(machinesWithoutLatestModel),
 		"failedMachines", len(failedMachines),
 		"deletingMachines", len(deletingMachines),
+		"provisioningMachines", len(provisioningMachines),
 	)
 
 	// if we have failed or deleting machines, remove them
@@ -167,13 +181,27 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 	// we have too many machines, let's choose the oldest to remove
 	if overProvisionCount > 0 {
 		var toDelete []infrav1exp.AzureMachinePoolMachine
+		log.Info("over-provisioned marked for deletion", "desiredReplicaCount", desiredReplicaCount, "overProvisionCount", overProvisionCount, "machinesMarkedForDeletion", getProviderIDs(machinesMarkedForDeletion))
+		// we are over-provisioned, first remove the machines marked for deletion
+		for _, v := range machinesMarkedForDeletion {
+			if len(toDelete) >= overProvisionCount {
+				return toDelete, nil
+			}
+
+			toDelete = append(toDelete, v)
+		}
+
 		log.Info("over-provisioned", "desiredReplicaCount", desiredReplicaCount, "overProvisionCount", overProvisionCount, "machinesWithoutLatestModel", getProviderIDs(machinesWithoutLatestModel))
-		// we are over-provisioned try to remove old models
+		// try to remove old models
 		for _, v := range machinesWithoutLatestModel {
 			if len(toDelete) >= overProvisionCount {
 				return toDelete, nil
 			}
 
+			if isMarkedForDeletion(v) {
+				continue
+			}
+
 			toDelete = append(toDelete, v)
 		}
 
@@ -184,6 +212,10 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 				return toDelete, nil
 			}
 
+			if isMarkedForDeletion(v) {
+				continue
+			}
+
 			toDelete = append(toDelete, v)
 		}
 
@@ -195,6 +227,16 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		return []infrav1exp.AzureMachinePoolMachine{}, nil
 	}
 
+	// surge first: while the surge machines are provisioning with the latest model, keep the machines with an older
+	// model. Once the surge machines are ready the pool is over-provisioned, and the machines with an older model are
+	// deleted above without reducing the number of ready machines. Surge machines still provisioning after
+	// surgeProvisioningTimeout are not waited for, and the machines with an older model are deleted within
+	// maxUnavailable below.
+	if maxSurge > 0 && len(provisioningMachines) > 0 {
+		log.Info("waiting for the surge machines to be ready before deleting machines without the latest model", "maxSurge", maxSurge, "provisioningMachines", getProviderIDs(provisioningMachines))
+		return []infrav1exp.AzureMachinePoolMachine{}, nil
+	}
+
 	if disruptionBudget <= 0 {
 		log.Info("exit early since disruption budget is less than or equal to zero", "disruptionBudget", disruptionBudget, "desiredReplicaCount", desiredReplicaCount, "maxUnavailable", maxUnavailable, "readyMachines", getProviderIDs(readyMachines), "readyMachinesCount", len(readyMachines))
 		return []infrav1exp.AzureMachinePoolMachine{}, nil
@@ -262,6 +304,45 @@ func getReadyMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePoo
 	return readyMachines
 }
 
+// getProvisioningMachines returns the machines with the latest model which are being provisioned, e.g. the machines
+// added to the pool by a surge, and were created less than surgeProvisioningTimeout before now.
+func getProvisioningMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine, now time.Time) []infrav1exp.AzureMachinePoolMachine {
+	var machines []infrav1exp.AzureMachinePoolMachine
+	for _, v := range machinesByProviderID {
+		if !v.DeletionTimestamp.IsZero() || !v.Status.LatestModelApplied {
+			continue
+		}
+		// A machine without provisioning state has not been reported by the scale set yet.
+		if v.Status.ProvisioningState != nil && *v.Status.ProvisioningState != infrav1.Creating && *v.Status.ProvisioningState != infrav1.Updating {
+			continue
+		}
+		if now.Sub(v.CreationTimestamp.Time) > surgeProvisioningTimeout {
+			continue
+		}
+		machines = append(machines, v)
+	}
+
+	return machines
+}
+
+// getMachinesMarkedForDeletion returns the machines with the CAPI delete-machine annotation, which are deleted first
+// when the pool is scaled down.
+func getMachinesMarkedForDeletion(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	var machines []infrav1exp.AzureMachinePoolMachine
+	for _, v := range machinesByProviderID {
+		if isMarkedForDeletion(v) {
+			machines = append(machines, v)
+		}
+	}
+
+	return machines
+}
+
+func isMarkedForDeletion(machine infrav1exp.AzureMachinePoolMachine) bool {
+	_, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]
+	return ok
+}
+
 func getMachinesWithoutLatestModel(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
 	var machinesWithLatestModel []infrav1exp.AzureMachinePoolMachine
 	for _, v := range machinesByProviderID {
@@ -109,6 +109,20 @@ func (mr *MockAgentPoolScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockAgentPoolScope)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockAgentPoolScope) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockAgentPoolScopeMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockAgentPoolScope)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockAgentPoolScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -331,6 +345,20 @@ func (mr *MockAgentPoolScopeMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAgentPoolScope)(nil).Name))
 }
 
+// NamingConvention mocks base method.
+func (m *MockAgentPoolScope) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockAgentPoolScopeMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockAgentPoolScope)(nil).NamingConvention))
+}
+
 // NodeResourceGroup mocks base method.
 func (m *MockAgentPoolScope) NodeResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -371,6 +399,18 @@ func (mr *MockAgentPoolScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockAgentPoolScope)(nil).ResourceGroup))
 }
 
+// SetAgentPoolOperationStatus mocks base method.
+func (m *MockAgentPoolScope) SetAgentPoolOperationStatus(arg0 v1beta1.ProvisioningState, arg1, arg2 int32) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAgentPoolOperationStatus", arg0, arg1, arg2)
+}
+
+// SetAgentPoolOperationStatus indicates an expected call of SetAgentPoolOperationStatus.
+func (mr *MockAgentPoolScopeMockRecorder) SetAgentPoolOperationStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentPoolOperationStatus", reflect.TypeOf((*MockAgentPoolScope)(nil).SetAgentPoolOperationStatus), arg0, arg1, arg2)
+}
+
 // SetAgentPoolProviderIDList mocks base method.
 func (m *MockAgentPoolScope) SetAgentPoolProviderIDList(arg0 []string) {
 	m.ctrl.T.Helper()
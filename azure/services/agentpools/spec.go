@@ -19,11 +19,13 @@ package agentpools
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -32,6 +34,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// spotNodeTaint is the taint AKS adds to the nodes of Spot node pools.
+const spotNodeTaint = "kubernetes.azure.com/scalesetpriority=spot:NoSchedule"
+
 // KubeletConfig defines the set of kubelet configurations for nodes in pools.
 type KubeletConfig struct {
 	// CPUManagerPolicy - CPU Manager policy to use.
@@ -129,6 +134,12 @@ type AgentPoolSpec struct {
 	// ScaleSetPriority specifies the ScaleSetPriority for the node pool. Allowed values are 'Spot' and 'Regular'
 	ScaleSetPriority *string `json:"scaleSetPriority,omitempty"`
 
+	// ScaleSetEvictionPolicy specifies what happens to the nodes of a Spot node pool when they are evicted. Allowed values are 'Delete' and 'Deallocate'
+	ScaleSetEvictionPolicy *string `json:"scaleSetEvictionPolicy,omitempty"`
+
+	// SpotMaxPrice is the maximum price per hour of the nodes of a Spot node pool, -1 meaning up to the on-demand price.
+	SpotMaxPrice *resource.Quantity `json:"spotMaxPrice,omitempty"`
+
 	// KubeletConfig specifies the kubelet configurations for nodes.
 	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
 
@@ -168,6 +179,7 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 	defer done()
 
 	nodeLabels := s.NodeLabels
+	desiredTaints := s.nodeTaints()
 	if existing != nil {
 		existingPool, ok := existing.(containerservice.AgentPool)
 		if !ok {
@@ -206,7 +218,7 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 				MinCount:            s.MinCount,
 				MaxCount:            s.MaxCount,
 				NodeLabels:          s.NodeLabels,
-				NodeTaints:          &s.NodeTaints,
+				NodeTaints:          &desiredTaints,
 				Tags:                converters.TagsToMap(s.AdditionalTags),
 			},
 		}
@@ -258,8 +270,8 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 		availabilityZones = &s.AvailabilityZones
 	}
 	var nodeTaints *[]string
-	if len(s.NodeTaints) > 0 {
-		nodeTaints = &s.NodeTaints
+	if len(desiredTaints) > 0 {
+		nodeTaints = &desiredTaints
 	}
 	var sku *string
 	if s.SKU != "" {
@@ -274,6 +286,14 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 	if s.VnetSubnetID != "" {
 		vnetSubnetID = &s.VnetSubnetID
 	}
+	var spotMaxPrice *float64
+	if s.SpotMaxPrice != nil {
+		maxPrice, err := strconv.ParseFloat(s.SpotMaxPrice.AsDec().String(), 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse spot max price %s", s.SpotMaxPrice.String())
+		}
+		spotMaxPrice = &maxPrice
+	}
 
 	var kubeletConfig *containerservice.KubeletConfig
 	if s.KubeletConfig != nil {
@@ -335,36 +355,52 @@ func (s *AgentPoolSpec) Parameters(ctx context.Context, existing interface{}) (p
 
 	agentPool := containerservice.AgentPool{
 		ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
-			AvailabilityZones:    availabilityZones,
-			Count:                &s.Replicas,
-			EnableAutoScaling:    s.EnableAutoScaling,
-			EnableUltraSSD:       s.EnableUltraSSD,
-			KubeletConfig:        kubeletConfig,
-			KubeletDiskType:      containerservice.KubeletDiskType(pointer.StringDeref((*string)(s.KubeletDiskType), "")),
-			MaxCount:             s.MaxCount,
-			MaxPods:              s.MaxPods,
-			MinCount:             s.MinCount,
-			Mode:                 containerservice.AgentPoolMode(s.Mode),
-			NodeLabels:           nodeLabels,
-			NodeTaints:           nodeTaints,
-			OrchestratorVersion:  s.Version,
-			OsDiskSizeGB:         &s.OSDiskSizeGB,
-			OsDiskType:           containerservice.OSDiskType(pointer.StringDeref(s.OsDiskType, "")),
-			OsType:               containerservice.OSType(pointer.StringDeref(s.OSType, "")),
-			ScaleSetPriority:     containerservice.ScaleSetPriority(pointer.StringDeref(s.ScaleSetPriority, "")),
-			Type:                 containerservice.AgentPoolTypeVirtualMachineScaleSets,
-			VMSize:               sku,
-			VnetSubnetID:         vnetSubnetID,
-			EnableNodePublicIP:   s.EnableNodePublicIP,
-			NodePublicIPPrefixID: s.NodePublicIPPrefixID,
-			Tags:                 tags,
-			LinuxOSConfig:        linuxOSConfig,
+			AvailabilityZones:      availabilityZones,
+			Count:                  &s.Replicas,
+			EnableAutoScaling:      s.EnableAutoScaling,
+			EnableUltraSSD:         s.EnableUltraSSD,
+			KubeletConfig:          kubeletConfig,
+			KubeletDiskType:        containerservice.KubeletDiskType(pointer.StringDeref((*string)(s.KubeletDiskType), "")),
+			MaxCount:               s.MaxCount,
+			MaxPods:                s.MaxPods,
+			MinCount:               s.MinCount,
+			Mode:                   containerservice.AgentPoolMode(s.Mode),
+			NodeLabels:             nodeLabels,
+			NodeTaints:             nodeTaints,
+			OrchestratorVersion:    s.Version,
+			OsDiskSizeGB:           &s.OSDiskSizeGB,
+			OsDiskType:             containerservice.OSDiskType(pointer.StringDeref(s.OsDiskType, "")),
+			OsType:                 containerservice.OSType(pointer.StringDeref(s.OSType, "")),
+			ScaleSetPriority:       containerservice.ScaleSetPriority(pointer.StringDeref(s.ScaleSetPriority, "")),
+			ScaleSetEvictionPolicy: containerservice.ScaleSetEvictionPolicy(pointer.StringDeref(s.ScaleSetEvictionPolicy, "")),
+			SpotMaxPrice:           spotMaxPrice,
+			Type:                   containerservice.VirtualMachineScaleSets,
+			VMSize:                 sku,
+			VnetSubnetID:           vnetSubnetID,
+			EnableNodePublicIP:     s.EnableNodePublicIP,
+			NodePublicIPPrefixID:   s.NodePublicIPPrefixID,
+			Tags:                   tags,
+			LinuxOSConfig:          linuxOSConfig,
 		},
 	}
 
 	return agentPool, nil
 }
 
+// nodeTaints returns the taints of the nodes of the agent pool. AKS adds spotNodeTaint to the nodes of Spot node pools
+// and doesn't allow removing it, so it's part of the desired taints of those node pools.
+func (s *AgentPoolSpec) nodeTaints() []string {
+	if pointer.StringDeref(s.ScaleSetPriority, "") != string(containerservice.Spot) {
+		return s.NodeTaints
+	}
+	for _, taint := range s.NodeTaints {
+		if taint == spotNodeTaint {
+			return s.NodeTaints
+		}
+	}
+	return append(append([]string{}, s.NodeTaints...), spotNodeTaint)
+}
+
 // mergeSystemNodeLabels appends any kubernetes.azure.com-prefixed labels from the AKS label set
 // into the local capz label set.
 func mergeSystemNodeLabels(capz, aks map[string]*string) map[string]*string {
@@ -22,10 +22,11 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -76,6 +77,14 @@ func withAutoscaling(enabled bool) func(*AgentPoolSpec) {
 	}
 }
 
+func withSpot(evictionPolicy *string, maxPrice resource.Quantity) func(*AgentPoolSpec) {
+	return func(pool *AgentPoolSpec) {
+		pool.ScaleSetPriority = pointer.String("Spot")
+		pool.ScaleSetEvictionPolicy = evictionPolicy
+		pool.SpotMaxPrice = &maxPrice
+	}
+}
+
 func sdkFakeAgentPool(changes ...func(*containerservice.AgentPool)) containerservice.AgentPool {
 	pool := containerservice.AgentPool{
 		ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
@@ -95,7 +104,7 @@ func sdkFakeAgentPool(changes ...func(*containerservice.AgentPool)) containerser
 			OsDiskType:          containerservice.OSDiskType("fake-os-disk-type"),
 			OsType:              containerservice.OSType("fake-os-type"),
 			Tags:                map[string]*string{"fake": pointer.String("tag")},
-			Type:                containerservice.AgentPoolTypeVirtualMachineScaleSets,
+			Type:                containerservice.VirtualMachineScaleSets,
 			VMSize:              pointer.String("fake-sku"),
 			VnetSubnetID:        pointer.String("fake-vnet-subnet-id"),
 		},
@@ -299,6 +308,34 @@ func TestParameters(t *testing.T) {
 			expected:      nil,
 			expectedError: nil,
 		},
+		{
+			name:     "parameters for a Spot agent pool",
+			spec:     fakeAgentPool(withSpot(pointer.String("Delete"), resource.MustParse("0.05"))),
+			existing: nil,
+			expected: sdkFakeAgentPool(
+				func(pool *containerservice.AgentPool) {
+					pool.ScaleSetPriority = containerservice.Spot
+					pool.ScaleSetEvictionPolicy = containerservice.ScaleSetEvictionPolicyDelete
+					pool.SpotMaxPrice = pointer.Float64(0.05)
+					pool.NodeTaints = &[]string{"fake-taint", spotNodeTaint}
+				},
+			),
+			expectedError: nil,
+		},
+		{
+			name: "node taint added by AKS to a Spot agent pool should not trigger an update",
+			spec: fakeAgentPool(withSpot(nil, resource.MustParse("-1"))),
+			existing: sdkFakeAgentPool(
+				func(pool *containerservice.AgentPool) {
+					pool.ScaleSetPriority = containerservice.Spot
+					pool.SpotMaxPrice = pointer.Float64(-1)
+					pool.NodeTaints = &[]string{"fake-taint", spotNodeTaint}
+				},
+				sdkWithProvisioningState("Succeeded"),
+			),
+			expected:      nil,
+			expectedError: nil,
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways/mock_applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeGatewaySpec = ApplicationGatewaySpec{
+		Name:           "my-appgw",
+		ResourceGroup:  "my-rg",
+		SubscriptionID: "123",
+		Location:       "westus",
+		ClusterName:    "my-cluster",
+		SubnetID:       "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/ApplicationGatewaySubnet",
+		PublicIPID:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-appgw-pip",
+		SKUTier:        infrav1.StandardV2ApplicationGatewaySKUTier,
+	}
+	fakeGateway = func(state network.ProvisioningState) network.ApplicationGateway {
+		return network.ApplicationGateway{
+			Name: pointer.String("my-appgw"),
+			ApplicationGatewayPropertiesFormat: &network.ApplicationGatewayPropertiesFormat{
+				ProvisioningState: state,
+			},
+		}
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{Type: "resourceType", ResourceGroup: "my-rg", Name: "resourceName"})
+)
+
+func TestReconcileApplicationGateway(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no gateway spec",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(nil)
+			},
+		},
+		{
+			name:          "gateway successfully created",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(fakeGateway(network.ProvisioningStateSucceeded), nil)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "gateway creation in progress",
+			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, notDoneError)
+			},
+		},
+		{
+			name:          "gateway creation fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "existing gateway being updated requeues",
+			expectedError: "application gateway my-appgw is in provisioning state Updating. Object will be requeued after 30s",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(fakeGateway(network.ProvisioningStateUpdating), nil)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, gomockinternal.ErrStrEq("application gateway my-appgw is in provisioning state Updating. Object will be requeued after 30s"))
+			},
+		},
+		{
+			name:          "gateway failed to provision",
+			expectedError: "application gateway my-appgw failed to provision",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(fakeGateway(network.ProvisioningStateFailed), nil)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, gomockinternal.ErrStrEq("application gateway my-appgw failed to provision"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationgateways.NewMockApplicationGatewayScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteApplicationGateway(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no gateway spec",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(nil)
+			},
+		},
+		{
+			name:          "gateway successfully deleted",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "gateway deletion fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationGatewaySpec().Return(&fakeGatewaySpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeGatewaySpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationgateways.NewMockApplicationGatewayScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
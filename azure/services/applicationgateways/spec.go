@@ -0,0 +1,214 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+const (
+	// Names of the placeholder sub-resources Azure requires on a new application gateway. AGIC replaces them
+	// with the configuration of the cluster ingresses.
+	gatewayIPConfigName    = "appGatewayIpConfig"
+	frontendIPConfigName   = "appGatewayFrontendIP"
+	frontendPortName       = "appGatewayFrontendPort"
+	backendPoolName        = "appGatewayBackendPool"
+	backendHTTPSettingName = "appGatewayBackendHttpSettings"
+	httpListenerName       = "appGatewayHttpListener"
+	requestRoutingRuleName = "appGatewayRoutingRule"
+	placeholderPort        = 80
+	// requestRoutingRulePriority is the priority of the placeholder rule, required since API version 2021-08-01.
+	// It is set close to the maximum of 20000 to leave room for the rules created by AGIC.
+	requestRoutingRulePriority = 19500
+
+	// wafRuleSetType and wafRuleSetVersion are the OWASP core rule set used by the web application firewall.
+	wafRuleSetType    = "OWASP"
+	wafRuleSetVersion = "3.2"
+)
+
+// ApplicationGatewaySpec defines the specification for an application gateway used by the Application Gateway Ingress Controller.
+type ApplicationGatewaySpec struct {
+	Name           string
+	ResourceGroup  string
+	SubscriptionID string
+	Location       string
+	ClusterName    string
+	SubnetID       string
+	PublicIPID     string
+	SKUTier        infrav1.ApplicationGatewaySKUTier
+	FirewallMode   infrav1.ApplicationGatewayFirewallMode
+	MinCapacity    *int32
+	MaxCapacity    *int32
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the application gateway.
+func (s *ApplicationGatewaySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *ApplicationGatewaySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for application gateways.
+func (s *ApplicationGatewaySpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the application gateway.
+func (s *ApplicationGatewaySpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	if existing != nil {
+		existingGateway, ok := existing.(network.ApplicationGateway)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.ApplicationGateway", existing)
+		}
+		// The configuration of an existing gateway is owned by AGIC, so it is only updated again when it failed
+		// to provision to give it a chance to recover.
+		if existingGateway.ApplicationGatewayPropertiesFormat == nil ||
+			existingGateway.ProvisioningState != network.ProvisioningStateFailed {
+			// application gateway already exists.
+			return nil, nil
+		}
+	}
+
+	minCapacity := s.MinCapacity
+	if minCapacity == nil {
+		minCapacity = pointer.Int32(0)
+	}
+
+	gateway := network.ApplicationGateway{
+		Name:     pointer.String(s.Name),
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Role:        pointer.String(infrav1.ApplicationGatewayRole),
+			Additional:  s.AdditionalTags,
+		})),
+		ApplicationGatewayPropertiesFormat: &network.ApplicationGatewayPropertiesFormat{
+			Sku: &network.ApplicationGatewaySku{
+				Name: network.ApplicationGatewaySkuName(s.SKUTier),
+				Tier: network.ApplicationGatewayTier(s.SKUTier),
+			},
+			AutoscaleConfiguration: &network.ApplicationGatewayAutoscaleConfiguration{
+				MinCapacity: minCapacity,
+				MaxCapacity: s.MaxCapacity,
+			},
+			GatewayIPConfigurations: &[]network.ApplicationGatewayIPConfiguration{
+				{
+					Name: pointer.String(gatewayIPConfigName),
+					ApplicationGatewayIPConfigurationPropertiesFormat: &network.ApplicationGatewayIPConfigurationPropertiesFormat{
+						Subnet: &network.SubResource{
+							ID: pointer.String(s.SubnetID),
+						},
+					},
+				},
+			},
+			FrontendIPConfigurations: &[]network.ApplicationGatewayFrontendIPConfiguration{
+				{
+					Name: pointer.String(frontendIPConfigName),
+					ApplicationGatewayFrontendIPConfigurationPropertiesFormat: &network.ApplicationGatewayFrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &network.SubResource{
+							ID: pointer.String(s.PublicIPID),
+						},
+					},
+				},
+			},
+			FrontendPorts: &[]network.ApplicationGatewayFrontendPort{
+				{
+					Name: pointer.String(frontendPortName),
+					ApplicationGatewayFrontendPortPropertiesFormat: &network.ApplicationGatewayFrontendPortPropertiesFormat{
+						Port: pointer.Int32(placeholderPort),
+					},
+				},
+			},
+			BackendAddressPools: &[]network.ApplicationGatewayBackendAddressPool{
+				{
+					Name: pointer.String(backendPoolName),
+				},
+			},
+			BackendHTTPSettingsCollection: &[]network.ApplicationGatewayBackendHTTPSettings{
+				{
+					Name: pointer.String(backendHTTPSettingName),
+					ApplicationGatewayBackendHTTPSettingsPropertiesFormat: &network.ApplicationGatewayBackendHTTPSettingsPropertiesFormat{
+						Port:                pointer.Int32(placeholderPort),
+						Protocol:            network.ApplicationGatewayProtocolHTTP,
+						CookieBasedAffinity: network.ApplicationGatewayCookieBasedAffinityDisabled,
+					},
+				},
+			},
+			HTTPListeners: &[]network.ApplicationGatewayHTTPListener{
+				{
+					Name: pointer.String(httpListenerName),
+					ApplicationGatewayHTTPListenerPropertiesFormat: &network.ApplicationGatewayHTTPListenerPropertiesFormat{
+						FrontendIPConfiguration: &network.SubResource{
+							ID: pointer.String(s.subResourceID("frontendIPConfigurations", frontendIPConfigName)),
+						},
+						FrontendPort: &network.SubResource{
+							ID: pointer.String(s.subResourceID("frontendPorts", frontendPortName)),
+						},
+						Protocol: network.ApplicationGatewayProtocolHTTP,
+					},
+				},
+			},
+			RequestRoutingRules: &[]network.ApplicationGatewayRequestRoutingRule{
+				{
+					Name: pointer.String(requestRoutingRuleName),
+					ApplicationGatewayRequestRoutingRulePropertiesFormat: &network.ApplicationGatewayRequestRoutingRulePropertiesFormat{
+						RuleType: network.ApplicationGatewayRequestRoutingRuleTypeBasic,
+						Priority: pointer.Int32(requestRoutingRulePriority),
+						HTTPListener: &network.SubResource{
+							ID: pointer.String(s.subResourceID("httpListeners", httpListenerName)),
+						},
+						BackendAddressPool: &network.SubResource{
+							ID: pointer.String(s.subResourceID("backendAddressPools", backendPoolName)),
+						},
+						BackendHTTPSettings: &network.SubResource{
+							ID: pointer.String(s.subResourceID("backendHttpSettingsCollection", backendHTTPSettingName)),
+						},
+					},
+				},
+			},
+		},
+	}
+	if s.SKUTier == infrav1.WAFV2ApplicationGatewaySKUTier {
+		gateway.WebApplicationFirewallConfiguration = &network.ApplicationGatewayWebApplicationFirewallConfiguration{
+			Enabled:        pointer.Bool(true),
+			FirewallMode:   network.ApplicationGatewayFirewallMode(s.FirewallMode),
+			RuleSetType:    pointer.String(wafRuleSetType),
+			RuleSetVersion: pointer.String(wafRuleSetVersion),
+		}
+	}
+
+	return gateway, nil
+}
+
+// subResourceID returns the ID of a sub-resource of the application gateway.
+func (s *ApplicationGatewaySpec) subResourceID(kind, name string) string {
+	return fmt.Sprintf("%s/%s/%s", azure.ApplicationGatewayID(s.SubscriptionID, s.ResourceGroup, s.Name), kind, name)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestApplicationGatewaySpecParameters(t *testing.T) {
+	wafSpec := fakeGatewaySpec
+	wafSpec.SKUTier = infrav1.WAFV2ApplicationGatewaySKUTier
+	wafSpec.FirewallMode = infrav1.DetectionApplicationGatewayFirewallMode
+	wafSpec.MinCapacity = pointer.Int32(2)
+	wafSpec.MaxCapacity = pointer.Int32(10)
+
+	testcases := []struct {
+		name          string
+		spec          *ApplicationGatewaySpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new Standard_v2 gateway",
+			spec:     &fakeGatewaySpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.ApplicationGateway{}))
+				gateway := result.(network.ApplicationGateway)
+				g.Expect(*gateway.Name).To(Equal("my-appgw"))
+				g.Expect(gateway.Sku.Name).To(Equal(network.ApplicationGatewaySkuNameStandardV2))
+				g.Expect(gateway.Sku.Tier).To(Equal(network.ApplicationGatewayTierStandardV2))
+				g.Expect(*gateway.AutoscaleConfiguration.MinCapacity).To(Equal(int32(0)))
+				g.Expect(gateway.AutoscaleConfiguration.MaxCapacity).To(BeNil())
+				g.Expect(gateway.WebApplicationFirewallConfiguration).To(BeNil())
+				g.Expect(*(*gateway.GatewayIPConfigurations)[0].Subnet.ID).To(Equal(fakeGatewaySpec.SubnetID))
+				g.Expect(*(*gateway.FrontendIPConfigurations)[0].PublicIPAddress.ID).To(Equal(fakeGatewaySpec.PublicIPID))
+				g.Expect(*(*gateway.HTTPListeners)[0].FrontendPort.ID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationGateways/my-appgw/frontendPorts/appGatewayFrontendPort"))
+				g.Expect(*(*gateway.RequestRoutingRules)[0].BackendAddressPool.ID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationGateways/my-appgw/backendAddressPools/appGatewayBackendPool"))
+				g.Expect(gateway.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", HaveValue(Equal("owned"))))
+				g.Expect(gateway.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_role", HaveValue(Equal("applicationgateway"))))
+			},
+		},
+		{
+			name:     "new WAF_v2 gateway",
+			spec:     &wafSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.ApplicationGateway{}))
+				gateway := result.(network.ApplicationGateway)
+				g.Expect(gateway.Sku.Name).To(Equal(network.ApplicationGatewaySkuNameWAFV2))
+				g.Expect(gateway.Sku.Tier).To(Equal(network.ApplicationGatewayTierWAFV2))
+				g.Expect(*gateway.AutoscaleConfiguration.MinCapacity).To(Equal(int32(2)))
+				g.Expect(*gateway.AutoscaleConfiguration.MaxCapacity).To(Equal(int32(10)))
+				g.Expect(*gateway.WebApplicationFirewallConfiguration.Enabled).To(BeTrue())
+				g.Expect(gateway.WebApplicationFirewallConfiguration.FirewallMode).To(Equal(network.ApplicationGatewayFirewallModeDetection))
+			},
+		},
+		{
+			name:     "existing gateway",
+			spec:     &fakeGatewaySpec,
+			existing: fakeGateway(network.ProvisioningStateSucceeded),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing gateway being updated",
+			spec:     &fakeGatewaySpec,
+			existing: fakeGateway(network.ProvisioningStateUpdating),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing gateway in failed state is updated",
+			spec:     &fakeGatewaySpec,
+			existing: fakeGateway(network.ProvisioningStateFailed),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.ApplicationGateway{}))
+				g.Expect(*result.(network.ApplicationGateway).Name).To(Equal("my-appgw"))
+			},
+		},
+		{
+			name:          "existing is not a gateway",
+			spec:          &fakeGatewaySpec,
+			existing:      "wrong type",
+			expectedError: "string is not a network.ApplicationGateway",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
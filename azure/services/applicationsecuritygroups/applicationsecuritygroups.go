@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "applicationsecuritygroups"
+
+// ApplicationSecurityGroupScope defines the scope interface for a application security groups service.
+type ApplicationSecurityGroupScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter
+	IsVnetManaged() bool
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ApplicationSecurityGroupScope
+	async.Reconciler
+}
+
+// New creates a new application security groups service.
+func New(scope ApplicationSecurityGroupScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates a set of application security groups.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of application security groups to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resErr error
+	for _, asgSpec := range specs {
+		if _, err := s.CreateOrUpdateResource(ctx, asgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, resErr)
+	return resErr
+}
+
+// Delete deletes application security groups.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	// Only delete the application security groups if their lifecycle is managed by this controller.
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping application security groups delete in custom VNet mode")
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to check if application security groups are managed")
+	}
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of application security groups to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var resErr error
+	for _, asgSpec := range specs {
+		if err := s.DeleteResource(ctx, asgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, resErr)
+	return resErr
+}
+
+// IsManaged returns true if the application security groups' lifecycles are managed.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.IsManaged")
+	defer done()
+
+	return s.Scope.IsVnetManaged(), nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// ApplicationSecurityGroupSpec defines the specification for an application security group.
+type ApplicationSecurityGroupSpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the application security group.
+func (s *ApplicationSecurityGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *ApplicationSecurityGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for application security groups.
+func (s *ApplicationSecurityGroupSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the application security group.
+func (s *ApplicationSecurityGroupSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(network.ApplicationSecurityGroup); !ok {
+			return nil, errors.Errorf("%T is not a network.ApplicationSecurityGroup", existing)
+		}
+		// application security group already exists
+		return nil, nil
+	}
+
+	return network.ApplicationSecurityGroup{
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Role:        pointer.String(infrav1.CommonRole),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
@@ -26,13 +26,26 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// DriftPolicy determines how existing Azure resources which no longer match their spec are handled.
+type DriftPolicy string
+
+const (
+	// DriftPolicyCorrect updates drifted resources to match their spec again.
+	DriftPolicyCorrect DriftPolicy = "Correct"
+	// DriftPolicyDetect only reports drifted resources and leaves them as they are.
+	DriftPolicyDetect DriftPolicy = "Detect"
+)
+
 // Service is an implementation of the Reconciler interface. It handles asynchronous creation and deletion of resources.
 type Service struct {
 	Scope FutureScope
@@ -66,6 +79,7 @@ func processOngoingOperation(ctx context.Context, scope FutureScope, client Futu
 		// In theory, this should never happen, but if for some reason the future that is already stored in Status isn't properly formatted
 		// and we don't reset it we would be stuck in an infinite loop trying to parse it.
 		scope.DeleteLongRunningOperationState(resourceName, serviceName, futureType)
+		trackFuture(*future, false)
 		return nil, errors.Wrap(err, "could not decode future data, resetting long-running operation state")
 	}
 
@@ -79,7 +93,8 @@ func processOngoingOperation(ctx context.Context, scope FutureScope, client Futu
 		}
 
 		// Operation is still in progress, update conditions and requeue.
-		log.V(2).Info("long running operation is still ongoing", "service", serviceName, "resource", resourceName)
+		log.V(2).Info("long running operation is still ongoing", "service", serviceName, "resource", resourceName, "startTime", future.StartTime)
+		trackFuture(*future, true)
 		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
 	}
 	if err != nil {
@@ -91,10 +106,31 @@ func processOngoingOperation(ctx context.Context, scope FutureScope, client Futu
 	// If the resource is not found, we also reset the long-running operation state so we can attempt to create it again.
 	// This can happen if the resource was deleted by another process before we could get the result.
 	scope.DeleteLongRunningOperationState(resourceName, serviceName, futureType)
+	trackFuture(*future, false)
+
+	if future.Type == infrav1.PostFuture {
+		// Actions have no result, a failed action is only reported by the future.
+		if err != nil {
+			return nil, errors.Wrapf(err, "long running operation on resource %s failed (service: %s)", resourceName, serviceName)
+		}
+		log.V(2).Info("long running operation has completed", "service", serviceName, "resource", resourceName)
+		return nil, nil
+	}
 
 	// Resource has been created/deleted/updated.
 	log.V(2).Info("long running operation has completed", "service", serviceName, "resource", resourceName)
-	return client.Result(ctx, sdkFuture, future.Type)
+	result, err = client.Result(ctx, sdkFuture, future.Type)
+	switch {
+	case future.Type == infrav1.DeleteFuture && err != nil:
+		reportFailure(scope, serviceName, resourceName, deletionFailedEvent, err)
+	case future.Type == infrav1.DeleteFuture:
+		reportSuccess(scope, serviceName, resourceName, deletedEvent)
+	case err != nil:
+		reportFailure(scope, serviceName, resourceName, provisioningFailedEvent, err)
+	default:
+		reportSuccess(scope, serviceName, resourceName, provisionedEvent)
+	}
+	return result, err
 }
 
 // CreateOrUpdateResource implements the logic for creating a new, or updating an existing, resource Asynchronously.
@@ -105,6 +141,7 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 	resourceName := spec.ResourceName()
 	rgName := spec.ResourceGroupName()
 	futureType := infrav1.PutFuture
+	setSpanAttributes(ctx, serviceName, resourceName, rgName)
 
 	// Check if there is an ongoing long running operation.
 	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType)
@@ -136,6 +173,17 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 	logMessageVerbPrefix := "creat"
 	if existingResource != nil {
 		logMessageVerbPrefix = "updat"
+		corrected := true
+		if getter, ok := s.Scope.(DriftPolicyGetter); ok {
+			corrected = getter.DriftPolicy() != DriftPolicyDetect
+		}
+		log.Info("resource does not match its spec", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "corrected", corrected)
+		if reporter, ok := s.Scope.(DriftReporter); ok {
+			reporter.ReportDrift(serviceName, resourceName, corrected)
+		}
+		if !corrected {
+			return existingResource, nil
+		}
 	}
 	log.V(2).Info(fmt.Sprintf("%sing resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	result, sdkFuture, err := s.Creator.CreateOrUpdateAsync(ctx, spec, parameters)
@@ -147,6 +195,7 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 			return nil, errWrapped
 		}
 		s.Scope.SetLongRunningOperationState(future)
+		trackFuture(*future, true)
 		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
 	} else if err != nil {
 		// If it is an intermittent failure with context deadline exceeded or canceled as the reconciler could not complete
@@ -154,10 +203,16 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 		if azure.IsContextDeadlineExceededOrCanceledError(ctx.Err()) {
 			return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
 		}
+		reportFailure(s.Scope, serviceName, resourceName, provisioningFailedEvent, err)
 		return nil, errWrapped
 	}
 
 	log.V(2).Info(fmt.Sprintf("successfully %sed resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	if existingResource != nil {
+		reportSuccess(s.Scope, serviceName, resourceName, updatedEvent)
+	} else {
+		reportSuccess(s.Scope, serviceName, resourceName, createdEvent)
+	}
 	return result, nil
 }
 
@@ -169,6 +224,7 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 	resourceName := spec.ResourceName()
 	rgName := spec.ResourceGroupName()
 	futureType := infrav1.DeleteFuture
+	setSpanAttributes(ctx, serviceName, resourceName, rgName)
 
 	// Check if there is an ongoing long running operation.
 	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType)
@@ -187,6 +243,7 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 			return errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 		}
 		s.Scope.SetLongRunningOperationState(future)
+		trackFuture(*future, true)
 		return azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
 	} else if err != nil {
 		if azure.ResourceNotFound(err) {
@@ -198,13 +255,105 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 		if azure.IsContextDeadlineExceededOrCanceledError(ctx.Err()) {
 			return azure.WithTransientError(err, getRetryAfterFromError(err))
 		}
+		reportFailure(s.Scope, serviceName, resourceName, deletionFailedEvent, err)
 		return errors.Wrapf(err, "failed to delete resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 	}
 
 	log.V(2).Info("successfully deleted resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	reportSuccess(s.Scope, serviceName, resourceName, deletedEvent)
+	return nil
+}
+
+// RunAction runs an action on an existing resource other than updating or deleting it, such as starting or stopping
+// it, and tracks the long running operation Azure returns for it until it completes, like CreateOrUpdateResource and
+// DeleteResource do. While an action is in progress, RunAction returns a transient error without running action again.
+// If action is nil, RunAction only waits for an action in progress.
+func (s *Service) RunAction(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, action func(context.Context) (azureautorest.FutureAPI, error)) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.RunAction")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+	futureType := infrav1.PostFuture
+	setSpanAttributes(ctx, serviceName, resourceName, rgName)
+
+	// Check if there is an ongoing long running operation.
+	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType)
+	if future != nil {
+		_, err := processOngoingOperation(ctx, s.Scope, s.Creator, resourceName, serviceName, futureType)
+		return err
+	}
+	if action == nil {
+		return nil
+	}
+
+	log.V(2).Info("running action on resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	sdkFuture, err := action(ctx)
+	if err != nil {
+		if azure.IsContextDeadlineExceededOrCanceledError(ctx.Err()) {
+			return azure.WithTransientError(err, getRetryAfterFromError(err))
+		}
+		return errors.Wrapf(err, "failed to run action on resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+	if sdkFuture == nil {
+		return nil
+	}
+	future, err = converters.SDKToFuture(sdkFuture, futureType, serviceName, resourceName, rgName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run action on resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+	s.Scope.SetLongRunningOperationState(future)
+	trackFuture(*future, true)
+	return azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
+}
+
+// PollFutures polls the long running operations in flight of obj once and deletes those which are done, without
+// reconciling any resource. It is used while obj is paused, so that the operations started before it was paused
+// complete without new operations being started.
+func PollFutures(ctx context.Context, obj futures.Setter, sender autorest.Sender) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.PollFutures")
+	defer done()
+
+	for _, future := range append(infrav1.Futures{}, obj.GetFutures()...) {
+		sdkFuture, err := converters.FutureToSDK(future)
+		if err != nil {
+			// A future which can't be decoded would be polled forever, so it is reset like in processOngoingOperation.
+			log.Error(err, "could not decode future data, resetting long-running operation state", "service", future.ServiceName, "resource", future.Name)
+			futures.Delete(obj, future.Name, future.ServiceName, future.Type)
+			trackFuture(future, false)
+			continue
+		}
+
+		isDone, err := sdkFuture.DoneWithContext(ctx, sender)
+		if !isDone {
+			if err != nil {
+				return errors.Wrapf(err, "failed checking if the operation on %s %s was complete", future.ServiceName, future.Name)
+			}
+			log.V(2).Info("long running operation is still ongoing", "service", future.ServiceName, "resource", future.Name, "startTime", future.StartTime)
+			trackFuture(future, true)
+			continue
+		}
+		if err != nil {
+			log.V(2).Error(err, "error checking long running operation status after it finished")
+		}
+
+		futures.Delete(obj, future.Name, future.ServiceName, future.Type)
+		trackFuture(future, false)
+		log.V(2).Info("long running operation has completed", "service", future.ServiceName, "resource", future.Name)
+	}
 	return nil
 }
 
+// setSpanAttributes adds the service and the resource being reconciled to the span in ctx, so that the spans of the
+// Azure operations of each service can be found in traces.
+func setSpanAttributes(ctx context.Context, serviceName, resourceName, rgName string) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("service", serviceName),
+		attribute.String("resource", resourceName),
+		attribute.String("resourceGroup", rgName),
+	)
+}
+
 // getRequeueAfterFromFuture returns the max between the `RETRY-AFTER` header and the default requeue time.
 // This ensures we respect the retry-after header if it is set and avoid retrying too often during an API throttling event.
 func getRequeueAfterFromFuture(sdkFuture azureautorest.FutureAPI) time.Duration {
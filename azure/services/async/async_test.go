@@ -50,6 +50,13 @@ var (
 		ResourceGroup: "test-group",
 		Data:          "eyJtZXRob2QiOiJERUxFVEUiLCJwb2xsaW5nTWV0aG9kIjoiTG9jYXRpb24iLCJscm9TdGF0ZSI6IkluUHJvZ3Jlc3MifQ==",
 	}
+	validPostFuture = infrav1.Future{
+		Type:          infrav1.PostFuture,
+		ServiceName:   "test-service",
+		Name:          "test-resource",
+		ResourceGroup: "test-group",
+		Data:          "eyJtZXRob2QiOiJQT1NUIiwicG9sbGluZ01ldGhvZCI6IkxvY2F0aW9uIiwibHJvU3RhdGUiOiJJblByb2dyZXNzIn0=",
+	}
 	invalidFuture = infrav1.Future{
 		Type:          infrav1.DeleteFuture,
 		ServiceName:   "test-service",
@@ -345,6 +352,74 @@ func TestCreateOrUpdateResource(t *testing.T) {
 	}
 }
 
+// driftReportingScope is a FutureScope with a drift policy which records the drift reported to it.
+type driftReportingScope struct {
+	*mock_async.MockFutureScope
+	policy    DriftPolicy
+	corrected []bool
+}
+
+func (s *driftReportingScope) DriftPolicy() DriftPolicy {
+	return s.policy
+}
+
+func (s *driftReportingScope) ReportDrift(_, _ string, corrected bool) {
+	s.corrected = append(s.corrected, corrected)
+}
+
+func TestCreateOrUpdateResourceDrift(t *testing.T) {
+	testcases := []struct {
+		name              string
+		policy            DriftPolicy
+		expectedResult    interface{}
+		expectedCorrected []bool
+		expect            func(c *mock_async.MockCreatorMockRecorder)
+	}{
+		{
+			name:              "drifted resource is corrected",
+			policy:            DriftPolicyCorrect,
+			expectedResult:    "test-resource",
+			expectedCorrected: []bool{true},
+			expect: func(c *mock_async.MockCreatorMockRecorder) {
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:              "drifted resource is only detected",
+			policy:            DriftPolicyDetect,
+			expectedResult:    &fakeExistingResource,
+			expectedCorrected: []bool{false},
+			expect:            func(c *mock_async.MockCreatorMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := &driftReportingScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl), policy: tc.policy}
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			specMock.EXPECT().ResourceName().Return("test-resource")
+			specMock.EXPECT().ResourceGroupName().Return("test-group")
+			scopeMock.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+			creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+			specMock.EXPECT().Parameters(gomockinternal.AContext(), &fakeExistingResource).Return(&fakeResourceParameters, nil)
+			tc.expect(creatorMock.EXPECT())
+
+			s := New(scopeMock, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(tc.expectedResult))
+			g.Expect(scopeMock.corrected).To(Equal(tc.expectedCorrected))
+		})
+	}
+}
+
 // TestDeleteResource tests the DeleteResource function.
 func TestDeleteResource(t *testing.T) {
 	testcases := []struct {
@@ -437,6 +512,104 @@ func TestDeleteResource(t *testing.T) {
 	}
 }
 
+func TestRunAction(t *testing.T) {
+	testcases := []struct {
+		name          string
+		action        func(ctx context.Context) (azureautorest.FutureAPI, error)
+		expectedError string
+		expect        func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:          "action is already in progress",
+			action:        func(ctx context.Context) (azureautorest.FutureAPI, error) { panic("action should not run again") },
+			expectedError: "operation type POST on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture).Times(2).Return(&validPostFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(false, nil)
+			},
+		},
+		{
+			name:          "action in progress completes",
+			expectedError: "",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture).Times(2).Return(&validPostFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(true, nil)
+				s.DeleteLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture)
+			},
+		},
+		{
+			name:          "action in progress fails",
+			expectedError: "long running operation on resource test-resource failed (service: test-service)",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture).Times(2).Return(&validPostFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(true, fakeInternalError)
+				s.DeleteLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture)
+			},
+		},
+		{
+			name:          "no action in progress and no action to run",
+			expectedError: "",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture).Return(nil)
+			},
+		},
+		{
+			name:          "action returns a future",
+			action:        func(ctx context.Context) (azureautorest.FutureAPI, error) { return &azureautorest.Future{}, nil },
+			expectedError: "operation type POST on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture).Return(nil)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+			},
+		},
+		{
+			name:          "action fails",
+			action:        func(ctx context.Context) (azureautorest.FutureAPI, error) { return nil, fakeInternalError },
+			expectedError: "failed to run action on resource test-group/test-resource (service: test-service)",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PostFuture).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(scopeMock, creatorMock, nil)
+			err := s.RunAction(context.TODO(), specMock, "test-service", tc.action)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestGetRetryAfterFromError(t *testing.T) {
 	cases := []struct {
 		name                   string
@@ -519,3 +692,78 @@ func TestGetRetryAfterFromError(t *testing.T) {
 		})
 	}
 }
+
+func TestPollFutures(t *testing.T) {
+	pollableDeleteFuture := infrav1.Future{
+		Type:          infrav1.DeleteFuture,
+		ServiceName:   "test-service",
+		Name:          "test-resource",
+		ResourceGroup: "test-group",
+		Data:          "eyJtZXRob2QiOiJERUxFVEUiLCJwb2xsaW5nTWV0aG9kIjoiTG9jYXRpb24iLCJwb2xsaW5nVVJJIjoiaHR0cHM6Ly9tYW5hZ2VtZW50LmF6dXJlLmNvbS9vcGVyYXRpb25zL3Rlc3QiLCJscm9TdGF0ZSI6IkluUHJvZ3Jlc3MifQ==",
+	}
+
+	testcases := []struct {
+		name            string
+		futures         infrav1.Futures
+		statusCode      int
+		sendErr         error
+		expectedFutures infrav1.Futures
+		expectedError   string
+	}{
+		{
+			name:            "operation which is done is deleted",
+			futures:         infrav1.Futures{pollableDeleteFuture},
+			statusCode:      http.StatusOK,
+			expectedFutures: infrav1.Futures{},
+		},
+		{
+			name:            "operation which is still ongoing is kept",
+			futures:         infrav1.Futures{pollableDeleteFuture},
+			statusCode:      http.StatusAccepted,
+			expectedFutures: infrav1.Futures{pollableDeleteFuture},
+		},
+		{
+			name:            "invalid operation is deleted",
+			futures:         infrav1.Futures{invalidFuture},
+			expectedFutures: infrav1.Futures{},
+		},
+		{
+			name:            "failure to poll an operation is returned",
+			futures:         infrav1.Futures{pollableDeleteFuture},
+			sendErr:         errors.New("connection refused"),
+			expectedFutures: infrav1.Futures{pollableDeleteFuture},
+			expectedError:   "failed checking if the operation on test-service test-resource was complete",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			obj := &infrav1.AzureCluster{}
+			obj.SetFutures(tc.futures)
+			sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+				if tc.sendErr != nil {
+					return nil, tc.sendErr
+				}
+				return &http.Response{
+					StatusCode: tc.statusCode,
+					Request:    r,
+					Header:     http.Header{},
+					Body:       http.NoBody,
+				}, nil
+			})
+
+			err := PollFutures(context.TODO(), obj, sender)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(obj.GetFutures()).To(ConsistOf(tc.expectedFutures))
+		})
+	}
+}
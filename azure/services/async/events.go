@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+const (
+	// createdEvent is the suffix of the reason of the event emitted when a resource is created.
+	createdEvent = "Created"
+	// updatedEvent is the suffix of the reason of the event emitted when a resource is updated.
+	updatedEvent = "Updated"
+	// provisionedEvent is the suffix of the reason of the event emitted when a long running create or update completes.
+	provisionedEvent = "Provisioned"
+	// deletedEvent is the suffix of the reason of the event emitted when a resource is deleted.
+	deletedEvent = "Deleted"
+	// provisioningFailedEvent is the suffix of the reason of the event emitted when a resource fails to be created or updated.
+	provisioningFailedEvent = "ProvisioningFailed"
+	// deletionFailedEvent is the suffix of the reason of the event emitted when a resource fails to be deleted.
+	deletionFailedEvent = "DeletionFailed"
+	// quotaExceededEvent is the reason of the event emitted when an operation fails because of a quota of the subscription.
+	quotaExceededEvent = "QuotaExceeded"
+)
+
+// resourceKinds maps the names of the services to the kinds of Azure resources used in the reasons of their events.
+var resourceKinds = map[string]string{
+	"agentpools":                "AgentPool",
+	"applicationgateways":       "ApplicationGateway",
+	"applicationsecuritygroups": "ApplicationSecurityGroup",
+	"availabilitysets":          "AvailabilitySet",
+	"azurefirewalls":            "AzureFirewall",
+	"bastionhosts":              "BastionHost",
+	"disks":                     "Disk",
+	"group":                     "ResourceGroup",
+	"inboundnatrules":           "InboundNATRule",
+	"interfaces":                "NetworkInterface",
+	"loadbalancers":             "LoadBalancer",
+	"managedcluster":            "ManagedCluster",
+	"natgateways":               "NATGateway",
+	"privatedns":                "PrivateDNSZone",
+	"privateendpoints":          "PrivateEndpoint",
+	"proximityplacementgroups":  "ProximityPlacementGroup",
+	"publicips":                 "PublicIP",
+	"roleassignments":           "RoleAssignment",
+	"routetables":               "RouteTable",
+	"scalesets":                 "ScaleSet",
+	"scalesetvms":               "ScaleSetVM",
+	"securitygroups":            "SecurityGroup",
+	"subnets":                   "Subnet",
+	"virtualmachine":            "VM",
+	"virtualnetworkgateways":    "VirtualNetworkGateway",
+	"virtualnetworks":           "VirtualNetwork",
+	"vmextensions":              "VMExtension",
+	"vnetpeerings":              "VnetPeering",
+}
+
+// resourceKind returns the kind of Azure resource reconciled by a service.
+func resourceKind(serviceName string) string {
+	if kind, ok := resourceKinds[serviceName]; ok {
+		return kind
+	}
+	if serviceName == "" {
+		return "Resource"
+	}
+	return strings.ToUpper(serviceName[:1]) + serviceName[1:]
+}
+
+// isQuotaExceeded returns true if the error is caused by an operation exceeding a quota of the subscription.
+func isQuotaExceeded(err error) bool {
+	code := azure.ErrorCode(err)
+	if strings.Contains(code, "Quota") {
+		return true
+	}
+	// Azure reports core quota errors as a generic OperationNotAllowed error.
+	return code == "OperationNotAllowed" && strings.Contains(strings.ToLower(err.Error()), "quota")
+}
+
+// reportEvent emits an event on the object of the scope, if the scope reports events.
+func reportEvent(scope FutureScope, eventType, reason, message string) {
+	if reporter, ok := scope.(EventReporter); ok {
+		reporter.ReportEvent(eventType, reason, message)
+	}
+}
+
+// reportSuccess emits an event for a resource which was changed in Azure.
+func reportSuccess(scope FutureScope, serviceName, resourceName, suffix string) {
+	reason := resourceKind(serviceName) + suffix
+	message := fmt.Sprintf("%s %s %s", serviceName, resourceName, strings.ToLower(suffix))
+	reportEvent(scope, corev1.EventTypeNormal, reason, message)
+}
+
+// reportFailure emits a warning event for a resource which failed to be changed in Azure, including the ARM error
+// code of the failure.
+func reportFailure(scope FutureScope, serviceName, resourceName, suffix string, err error) {
+	reason := resourceKind(serviceName) + suffix
+	if isQuotaExceeded(err) {
+		reason = quotaExceededEvent
+	}
+	message := fmt.Sprintf("%s %s failed", serviceName, resourceName)
+	if code := azure.ErrorCode(err); code != "" {
+		message = fmt.Sprintf("%s with error code %s", message, code)
+	}
+	reportEvent(scope, corev1.EventTypeWarning, reason, fmt.Sprintf("%s: %v", message, err))
+}
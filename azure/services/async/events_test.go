@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+)
+
+// eventScope is a FutureScope which records the events reported to it.
+type eventScope struct {
+	*mock_async.MockFutureScope
+	events []string
+}
+
+func (s *eventScope) ReportEvent(eventType, reason, message string) {
+	s.events = append(s.events, eventType+" "+reason+" "+message)
+}
+
+func TestReportEvents(t *testing.T) {
+	armError := func(code, message string) error {
+		return autorest.NewErrorWithError(&azureautorest.RequestError{
+			ServiceError: &azureautorest.ServiceError{Code: code, Message: message},
+		}, "compute.VirtualMachinesClient", "CreateOrUpdate", &http.Response{StatusCode: http.StatusConflict}, "Failure sending request")
+	}
+	tests := []struct {
+		name     string
+		report   func(scope FutureScope)
+		expected string
+	}{
+		{
+			name: "resource created",
+			report: func(scope FutureScope) {
+				reportSuccess(scope, "group", "my-rg", createdEvent)
+			},
+			expected: corev1.EventTypeNormal + " ResourceGroupCreated group my-rg created",
+		},
+		{
+			name: "resource of an unknown service deleted",
+			report: func(scope FutureScope) {
+				reportSuccess(scope, "widgets", "my-widget", deletedEvent)
+			},
+			expected: corev1.EventTypeNormal + " WidgetsDeleted widgets my-widget deleted",
+		},
+		{
+			name: "provisioning failed with an ARM error code",
+			report: func(scope FutureScope) {
+				reportFailure(scope, "virtualmachine", "my-vm", provisioningFailedEvent, armError("SkuNotAvailable", "The requested size is not available"))
+			},
+			expected: corev1.EventTypeWarning + " VMProvisioningFailed virtualmachine my-vm failed with error code SkuNotAvailable: ",
+		},
+		{
+			name: "provisioning failed because of a quota",
+			report: func(scope FutureScope) {
+				reportFailure(scope, "virtualmachine", "my-vm", provisioningFailedEvent, armError("OperationNotAllowed", "Operation results in exceeding approved Total Regional Cores quota"))
+			},
+			expected: corev1.EventTypeWarning + " QuotaExceeded virtualmachine my-vm failed with error code OperationNotAllowed: ",
+		},
+		{
+			name: "deletion failed without an ARM error code",
+			report: func(scope FutureScope) {
+				reportFailure(scope, "disks", "my-disk", deletionFailedEvent, errors.New("connection reset"))
+			},
+			expected: corev1.EventTypeWarning + " DiskDeletionFailed disks my-disk failed: connection reset",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scope := &eventScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl)}
+
+			tt.report(scope)
+			g.Expect(scope.events).To(HaveLen(1))
+			g.Expect(scope.events[0]).To(HavePrefix(tt.expected))
+		})
+	}
+}
+
+func TestReportEventWithoutReporter(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// Scopes which don't report events are left alone.
+	reportSuccess(mock_async.NewMockFutureScope(mockCtrl), "group", "my-rg", createdEvent)
+}
@@ -29,6 +29,22 @@ type FutureScope interface {
 	azure.AsyncStatusUpdater
 }
 
+// DriftReporter is a scope that reports existing Azure resources which no longer match their spec.
+type DriftReporter interface {
+	ReportDrift(serviceName, resourceName string, corrected bool)
+}
+
+// DriftPolicyGetter is a scope which sets how its Azure resources which no longer match their spec are handled. The
+// drifted resources of other scopes are corrected.
+type DriftPolicyGetter interface {
+	DriftPolicy() DriftPolicy
+}
+
+// EventReporter is a scope that emits events for the changes of the Azure resources it reconciles.
+type EventReporter interface {
+	ReportEvent(eventType, reason, message string)
+}
+
 // FutureHandler is a client that can check on the progress of a future.
 type FutureHandler interface {
 	// IsDone returns true if the operation is complete.
@@ -64,4 +80,5 @@ type Deleter interface {
 type Reconciler interface {
 	CreateOrUpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result interface{}, err error)
 	DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (err error)
+	RunAction(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, action func(context.Context) (azureautorest.FutureAPI, error)) (err error)
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var futuresInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capz_futures_in_flight",
+	Help: "Number of long running Azure operations in progress, by service and type (PUT, PATCH or DELETE).",
+}, []string{"service", "type"})
+
+func init() {
+	metrics.Registry.MustRegister(futuresInFlight)
+}
+
+// futureKey identifies a long running operation on an Azure resource.
+type futureKey struct {
+	serviceName   string
+	resourceGroup string
+	name          string
+	futureType    string
+}
+
+var (
+	inFlightMu sync.Mutex
+	inFlight   = map[futureKey]struct{}{}
+)
+
+// trackFuture records whether a long running operation is still in progress. Operations are tracked individually so
+// that operations resumed after a restart of the controller or in several reconciles are only counted once.
+func trackFuture(future infrav1.Future, inProgress bool) {
+	key := futureKey{
+		serviceName:   future.ServiceName,
+		resourceGroup: strings.ToLower(future.ResourceGroup),
+		name:          strings.ToLower(future.Name),
+		futureType:    future.Type,
+	}
+
+	inFlightMu.Lock()
+	defer inFlightMu.Unlock()
+	_, tracked := inFlight[key]
+	switch {
+	case inProgress && !tracked:
+		inFlight[key] = struct{}{}
+		futuresInFlight.WithLabelValues(future.ServiceName, future.Type).Inc()
+	case !inProgress && tracked:
+		delete(inFlight, key)
+		futuresInFlight.WithLabelValues(future.ServiceName, future.Type).Dec()
+	}
+}
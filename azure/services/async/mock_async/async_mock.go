@@ -129,6 +129,76 @@ func (mr *MockFutureScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockFutureScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
 
+// MockDriftReporter is a mock of DriftReporter interface.
+type MockDriftReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriftReporterMockRecorder
+}
+
+// MockDriftReporterMockRecorder is the mock recorder for MockDriftReporter.
+type MockDriftReporterMockRecorder struct {
+	mock *MockDriftReporter
+}
+
+// NewMockDriftReporter creates a new mock instance.
+func NewMockDriftReporter(ctrl *gomock.Controller) *MockDriftReporter {
+	mock := &MockDriftReporter{ctrl: ctrl}
+	mock.recorder = &MockDriftReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriftReporter) EXPECT() *MockDriftReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportDrift mocks base method.
+func (m *MockDriftReporter) ReportDrift(serviceName, resourceName string, corrected bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportDrift", serviceName, resourceName, corrected)
+}
+
+// ReportDrift indicates an expected call of ReportDrift.
+func (mr *MockDriftReporterMockRecorder) ReportDrift(serviceName, resourceName, corrected interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportDrift", reflect.TypeOf((*MockDriftReporter)(nil).ReportDrift), serviceName, resourceName, corrected)
+}
+
+// MockEventReporter is a mock of EventReporter interface.
+type MockEventReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventReporterMockRecorder
+}
+
+// MockEventReporterMockRecorder is the mock recorder for MockEventReporter.
+type MockEventReporterMockRecorder struct {
+	mock *MockEventReporter
+}
+
+// NewMockEventReporter creates a new mock instance.
+func NewMockEventReporter(ctrl *gomock.Controller) *MockEventReporter {
+	mock := &MockEventReporter{ctrl: ctrl}
+	mock.recorder = &MockEventReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventReporter) EXPECT() *MockEventReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportEvent mocks base method.
+func (m *MockEventReporter) ReportEvent(eventType, reason, message string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportEvent", eventType, reason, message)
+}
+
+// ReportEvent indicates an expected call of ReportEvent.
+func (mr *MockEventReporterMockRecorder) ReportEvent(eventType, reason, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportEvent", reflect.TypeOf((*MockEventReporter)(nil).ReportEvent), eventType, reason, message)
+}
+
 // MockFutureHandler is a mock of FutureHandler interface.
 type MockFutureHandler struct {
 	ctrl     *gomock.Controller
@@ -461,3 +531,17 @@ func (mr *MockReconcilerMockRecorder) DeleteResource(ctx, spec, serviceName inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockReconciler)(nil).DeleteResource), ctx, spec, serviceName)
 }
+
+// RunAction mocks base method.
+func (m *MockReconciler) RunAction(ctx context.Context, spec azure0.ResourceSpecGetter, serviceName string, action func(context.Context) (azure.FutureAPI, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunAction", ctx, spec, serviceName, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunAction indicates an expected call of RunAction.
+func (mr *MockReconcilerMockRecorder) RunAction(ctx, spec, serviceName, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunAction", reflect.TypeOf((*MockReconciler)(nil).RunAction), ctx, spec, serviceName, action)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 )
@@ -36,6 +37,13 @@ type AvailabilitySetSpec struct {
 	Location       string
 	SKU            *resourceskus.SKU
 	AdditionalTags infrav1.Tags
+	// ProximityPlacementGroupID is the resource ID of the proximity placement group the availability set joins, if any.
+	ProximityPlacementGroupID string
+	// FaultDomainCount is the number of fault domains of the availability set.
+	// If nil, the maximum number of fault domains of the location is used.
+	FaultDomainCount *int32
+	// UpdateDomainCount is the number of update domains of the availability set. If nil, the Azure default is used.
+	UpdateDomainCount *int32
 }
 
 // ResourceName returns the name of the availability set.
@@ -78,12 +86,20 @@ func (s *AvailabilitySetSpec) Parameters(ctx context.Context, existing interface
 	}
 	faultDomainCount = pointer.Int32(int32(count))
 
+	if s.FaultDomainCount != nil {
+		if int64(*s.FaultDomainCount) > count {
+			return nil, azure.WithTerminalError(errors.Errorf("availability set fault domain count %d exceeds the maximum of %d fault domains in location %s", *s.FaultDomainCount, count, s.Location))
+		}
+		faultDomainCount = s.FaultDomainCount
+	}
+
 	asParams := compute.AvailabilitySet{
 		Sku: &compute.Sku{
 			Name: pointer.String(string(compute.AvailabilitySetSkuTypesAligned)),
 		},
 		AvailabilitySetProperties: &compute.AvailabilitySetProperties{
-			PlatformFaultDomainCount: faultDomainCount,
+			PlatformFaultDomainCount:  faultDomainCount,
+			PlatformUpdateDomainCount: s.UpdateDomainCount,
 		},
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
@@ -95,5 +111,9 @@ func (s *AvailabilitySetSpec) Parameters(ctx context.Context, existing interface
 		Location: pointer.String(s.Location),
 	}
 
+	if s.ProximityPlacementGroupID != "" {
+		asParams.ProximityPlacementGroup = &compute.SubResource{ID: pointer.String(s.ProximityPlacementGroupID)}
+	}
+
 	return asParams, nil
 }
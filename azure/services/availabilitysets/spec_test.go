@@ -70,9 +70,47 @@ func TestParameters(t *testing.T) {
 			expect: func(g *WithT, result interface{}) {
 				g.Expect(result).To(BeAssignableToTypeOf(compute.AvailabilitySet{}))
 				g.Expect(result.(compute.AvailabilitySet).PlatformFaultDomainCount).To(Equal(pointer.Int32(int32(fakeFaultDomainCount))))
+				g.Expect(result.(compute.AvailabilitySet).PlatformUpdateDomainCount).To(BeNil())
 			},
 			expectedError: "",
 		},
+		{
+			name: "get parameters with fault and update domain counts",
+			spec: &AvailabilitySetSpec{
+				Name:              "test-as",
+				ResourceGroup:     "test-rg",
+				ClusterName:       "test-cluster",
+				Location:          "test-location",
+				SKU:               &fakeSku,
+				AdditionalTags:    map[string]string{},
+				FaultDomainCount:  pointer.Int32(2),
+				UpdateDomainCount: pointer.Int32(10),
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.AvailabilitySet{}))
+				g.Expect(result.(compute.AvailabilitySet).PlatformFaultDomainCount).To(Equal(pointer.Int32(2)))
+				g.Expect(result.(compute.AvailabilitySet).PlatformUpdateDomainCount).To(Equal(pointer.Int32(10)))
+			},
+			expectedError: "",
+		},
+		{
+			name: "error when fault domain count exceeds the maximum of the location",
+			spec: &AvailabilitySetSpec{
+				Name:             "test-as",
+				ResourceGroup:    "test-rg",
+				ClusterName:      "test-cluster",
+				Location:         "test-location",
+				SKU:              &fakeSku,
+				AdditionalTags:   map[string]string{},
+				FaultDomainCount: pointer.Int32(4),
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: availability set fault domain count 4 exceeds the maximum of 3 fault domains in location test-location. Object will not be requeued",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	serviceName = "azurefirewalls"
+
+	// privateIPRequeueInterval is how long to wait before checking again on a firewall that has no private IP address yet.
+	privateIPRequeueInterval = 15 * time.Second
+)
+
+// AzureFirewallScope defines the scope interface for an Azure Firewall service.
+type AzureFirewallScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	AzureFirewallSpec() azure.ResourceSpecGetter
+	// AzureFirewallRouteSpecs returns the routes sending node egress traffic to the firewall.
+	// It must be called after the firewall private IP has been set.
+	AzureFirewallRouteSpecs() []azure.ResourceSpecGetter
+	SetAzureFirewallPrivateIP(ip string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope              AzureFirewallScope
+	firewallReconciler async.Reconciler
+	routeReconciler    async.Reconciler
+}
+
+// New creates a new service.
+func New(scope AzureFirewallScope) *Service {
+	firewallsClient := newAzureFirewallsClient(scope)
+	routesClient := newRoutesClient(scope)
+	return &Service{
+		Scope:              scope,
+		firewallReconciler: async.New(scope, firewallsClient, firewallsClient),
+		routeReconciler:    async.New(scope, routesClient, routesClient),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates an Azure Firewall and routes node egress traffic through it.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	firewallSpec := s.Scope.AzureFirewallSpec()
+	if firewallSpec == nil {
+		return nil
+	}
+
+	resultingErr := s.reconcileFirewall(ctx, firewallSpec)
+	if resultingErr == nil {
+		resultingErr = s.reconcileRoutes(ctx)
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// reconcileFirewall creates or updates the Azure Firewall and stores its private IP address.
+func (s *Service) reconcileFirewall(ctx context.Context, firewallSpec azure.ResourceSpecGetter) error {
+	result, err := s.firewallReconciler.CreateOrUpdateResource(ctx, firewallSpec, serviceName)
+	if err != nil {
+		return err
+	}
+
+	firewall, ok := result.(network.AzureFirewall)
+	if !ok {
+		return errors.Errorf("%T is not a network.AzureFirewall", result)
+	}
+	privateIP := firewallPrivateIP(firewall)
+	if privateIP == "" {
+		return azure.WithTransientError(errors.Errorf("azure firewall %s has no private IP address yet", firewallSpec.ResourceName()), privateIPRequeueInterval)
+	}
+	s.Scope.SetAzureFirewallPrivateIP(privateIP)
+	return nil
+}
+
+// reconcileRoutes creates or updates the default routes of the node route tables.
+func (s *Service) reconcileRoutes(ctx context.Context) error {
+	// We go through the list of routes to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resultingErr error
+	for _, routeSpec := range s.Scope.AzureFirewallRouteSpecs() {
+		if _, err := s.routeReconciler.CreateOrUpdateResource(ctx, routeSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+		}
+	}
+	return resultingErr
+}
+
+// Delete deletes the node default routes and the Azure Firewall.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	firewallSpec := s.Scope.AzureFirewallSpec()
+	if firewallSpec == nil {
+		return nil
+	}
+
+	// Remove the routes first so that node egress traffic isn't sent to a firewall that no longer exists.
+	var resultingErr error
+	for _, routeSpec := range s.Scope.AzureFirewallRouteSpecs() {
+		if err := s.routeReconciler.DeleteResource(ctx, routeSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+		}
+	}
+	if resultingErr == nil {
+		resultingErr = s.firewallReconciler.DeleteResource(ctx, firewallSpec, serviceName)
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO Azure Firewall.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// firewallPrivateIP returns the private IP address of the firewall, which is the next hop of the node default routes.
+func firewallPrivateIP(firewall network.AzureFirewall) string {
+	if firewall.AzureFirewallPropertiesFormat == nil || firewall.IPConfigurations == nil {
+		return ""
+	}
+	for _, ipConfig := range *firewall.IPConfigurations {
+		if ipConfig.AzureFirewallIPConfigurationPropertiesFormat != nil && ipConfig.PrivateIPAddress != nil {
+			return *ipConfig.PrivateIPAddress
+		}
+	}
+	return ""
+}
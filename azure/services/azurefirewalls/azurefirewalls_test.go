@@ -0,0 +1,257 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls/mock_azurefirewalls"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeFirewallSpec = AzureFirewallSpec{
+		Name:            "my-firewall",
+		ResourceGroup:   "my-rg",
+		Location:        "westus",
+		ClusterName:     "my-cluster",
+		SubnetID:        "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/AzureFirewallSubnet",
+		PublicIPID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-firewall-pip",
+		SKUTier:         infrav1.StandardAzureFirewallSKUTier,
+		SourceAddresses: []string{"10.1.0.0/16"},
+		APIServerPort:   6443,
+	}
+	fakeRouteSpec1 = RouteSpec{
+		Name:             "my-firewall-default-route",
+		ResourceGroup:    "my-rg",
+		RouteTableName:   "my-node-routetable-1",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopIPAddress: "10.255.255.132",
+	}
+	fakeRouteSpec2 = RouteSpec{
+		Name:             "my-firewall-default-route",
+		ResourceGroup:    "my-rg",
+		RouteTableName:   "my-node-routetable-2",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopIPAddress: "10.255.255.132",
+	}
+	fakeFirewall = network.AzureFirewall{
+		Name: pointer.String("my-firewall"),
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+			IPConfigurations: &[]network.AzureFirewallIPConfiguration{
+				{
+					AzureFirewallIPConfigurationPropertiesFormat: &network.AzureFirewallIPConfigurationPropertiesFormat{
+						PrivateIPAddress: pointer.String("10.255.255.132"),
+					},
+				},
+			},
+		},
+	}
+	fakeFirewallWithoutIP = network.AzureFirewall{
+		Name:                          pointer.String("my-firewall"),
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{},
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{Type: "resourceType", ResourceGroup: "my-rg", Name: "resourceName"})
+)
+
+func TestReconcileAzureFirewall(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no firewall spec",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create firewall and routes successfully",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				f.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(fakeFirewall, nil)
+				s.SetAzureFirewallPrivateIP("10.255.255.132")
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{&fakeRouteSpec1, &fakeRouteSpec2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRouteSpec1, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRouteSpec2, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "firewall creation in progress",
+			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				f.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, notDoneError)
+			},
+		},
+		{
+			name:          "firewall creation fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				f.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "firewall without private IP requeues",
+			expectedError: "azure firewall my-firewall has no private IP address yet. Object will be requeued after 15s",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				f.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(fakeFirewallWithoutIP, nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, gomockinternal.ErrStrEq("azure firewall my-firewall has no private IP address yet. Object will be requeued after 15s"))
+			},
+		},
+		{
+			name:          "route creation fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				f.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(fakeFirewall, nil)
+				s.SetAzureFirewallPrivateIP("10.255.255.132")
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{&fakeRouteSpec1, &fakeRouteSpec2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRouteSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRouteSpec2, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_azurefirewalls.NewMockAzureFirewallScope(mockCtrl)
+			firewallReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			routeReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), firewallReconcilerMock.EXPECT(), routeReconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:              scopeMock,
+				firewallReconciler: firewallReconcilerMock,
+				routeReconciler:    routeReconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteAzureFirewall(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no firewall spec",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete routes and firewall successfully",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{&fakeRouteSpec1, &fakeRouteSpec2})
+				r.DeleteResource(gomockinternal.AContext(), &fakeRouteSpec1, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRouteSpec2, serviceName).Return(nil)
+				f.DeleteResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "route deletion in progress does not delete firewall",
+			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{&fakeRouteSpec1, &fakeRouteSpec2})
+				r.DeleteResource(gomockinternal.AContext(), &fakeRouteSpec1, serviceName).Return(notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRouteSpec2, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, notDoneError)
+			},
+		},
+		{
+			name:          "firewall deletion fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_azurefirewalls.MockAzureFirewallScopeMockRecorder, f, r *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpec().Return(&fakeFirewallSpec)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{&fakeRouteSpec1})
+				r.DeleteResource(gomockinternal.AContext(), &fakeRouteSpec1, serviceName).Return(nil)
+				f.DeleteResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_azurefirewalls.NewMockAzureFirewallScope(mockCtrl)
+			firewallReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			routeReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), firewallReconcilerMock.EXPECT(), routeReconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:              scopeMock,
+				firewallReconciler: firewallReconcilerMock,
+				routeReconciler:    routeReconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
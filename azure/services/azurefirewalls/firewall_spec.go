@@ -0,0 +1,264 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+const (
+	// applicationRuleCollectionName is the name of the application rule collection allowing node egress to the required FQDNs.
+	applicationRuleCollectionName = "kubernetes-egress-fqdns"
+	// networkRuleCollectionName is the name of the network rule collection allowing node egress on the required ports.
+	networkRuleCollectionName = "kubernetes-egress-ports"
+	// ruleCollectionPriority is the priority of the rule collections created by CAPZ.
+	ruleCollectionPriority = 100
+	// ntpPort is the port used by nodes to synchronize their clocks.
+	ntpPort = 123
+)
+
+// requiredFQDNs are the endpoints nodes need to reach to bootstrap and run Kubernetes on Azure.
+// See https://learn.microsoft.com/en-us/azure/aks/outbound-rules-control-egress#azure-global-required-fqdn--application-rules.
+var requiredFQDNs = []string{
+	"mcr.microsoft.com",
+	"*.data.mcr.microsoft.com",
+	"management.azure.com",
+	"login.microsoftonline.com",
+	"packages.microsoft.com",
+	"acs-mirror.azureedge.net",
+	"registry.k8s.io",
+	"*.pkg.dev",
+	"dl.k8s.io",
+	"*.blob.core.windows.net",
+}
+
+// AzureFirewallSpec defines the specification for an Azure Firewall.
+type AzureFirewallSpec struct {
+	Name          string
+	ResourceGroup string
+	Location      string
+	ClusterName   string
+	SubnetID      string
+	PublicIPID    string
+	SKUTier       infrav1.AzureFirewallSKUTier
+	// SourceAddresses are the address prefixes of the node subnets whose egress traffic is allowed.
+	SourceAddresses        []string
+	APIServerPort          int32
+	AdditionalAllowedFQDNs []string
+	AdditionalTags         infrav1.Tags
+}
+
+// ResourceName returns the name of the Azure Firewall.
+func (s *AzureFirewallSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *AzureFirewallSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Azure Firewalls.
+func (s *AzureFirewallSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the Azure Firewall.
+func (s *AzureFirewallSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	applicationRuleCollections := s.applicationRuleCollections()
+	networkRuleCollections := s.networkRuleCollections()
+
+	if existing != nil {
+		existingFirewall, ok := existing.(network.AzureFirewall)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.AzureFirewall", existing)
+		}
+		if existingFirewall.AzureFirewallPropertiesFormat != nil &&
+			applicationRulesEqual(existingFirewall.ApplicationRuleCollections, applicationRuleCollections) &&
+			networkRulesEqual(existingFirewall.NetworkRuleCollections, networkRuleCollections) {
+			// Azure Firewall already exists with the desired rules.
+			return nil, nil
+		}
+	}
+
+	return network.AzureFirewall{
+		Name:     pointer.String(s.Name),
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Role:        pointer.String("Firewall"),
+			Additional:  s.AdditionalTags,
+		})),
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+			Sku: &network.AzureFirewallSku{
+				Name: network.AzureFirewallSkuNameAZFWVNet,
+				Tier: network.AzureFirewallSkuTier(s.SKUTier),
+			},
+			ThreatIntelMode: network.AzureFirewallThreatIntelModeAlert,
+			IPConfigurations: &[]network.AzureFirewallIPConfiguration{
+				{
+					Name: pointer.String(fmt.Sprintf("%s-ipconfig", s.Name)),
+					AzureFirewallIPConfigurationPropertiesFormat: &network.AzureFirewallIPConfigurationPropertiesFormat{
+						Subnet: &network.SubResource{
+							ID: pointer.String(s.SubnetID),
+						},
+						PublicIPAddress: &network.SubResource{
+							ID: pointer.String(s.PublicIPID),
+						},
+					},
+				},
+			},
+			ApplicationRuleCollections: &applicationRuleCollections,
+			NetworkRuleCollections:     &networkRuleCollections,
+		},
+	}, nil
+}
+
+// applicationRuleCollections returns the application rules allowing the nodes to reach the required and additional FQDNs over HTTP(S).
+func (s *AzureFirewallSpec) applicationRuleCollections() []network.AzureFirewallApplicationRuleCollection {
+	fqdns := append(append([]string{}, requiredFQDNs...), s.AdditionalAllowedFQDNs...)
+	return []network.AzureFirewallApplicationRuleCollection{
+		{
+			Name: pointer.String(applicationRuleCollectionName),
+			AzureFirewallApplicationRuleCollectionPropertiesFormat: &network.AzureFirewallApplicationRuleCollectionPropertiesFormat{
+				Priority: pointer.Int32(ruleCollectionPriority),
+				Action:   &network.AzureFirewallRCAction{Type: network.AzureFirewallRCActionTypeAllow},
+				Rules: &[]network.AzureFirewallApplicationRule{
+					{
+						Name:            pointer.String("allow-fqdns"),
+						SourceAddresses: &s.SourceAddresses,
+						Protocols: &[]network.AzureFirewallApplicationRuleProtocol{
+							{ProtocolType: network.AzureFirewallApplicationRuleProtocolTypeHTTP, Port: pointer.Int32(80)},
+							{ProtocolType: network.AzureFirewallApplicationRuleProtocolTypeHTTPS, Port: pointer.Int32(443)},
+						},
+						TargetFqdns: &fqdns,
+					},
+				},
+			},
+		},
+	}
+}
+
+// networkRuleCollections returns the network rules allowing the nodes to synchronize their clocks and reach the API server.
+func (s *AzureFirewallSpec) networkRuleCollections() []network.AzureFirewallNetworkRuleCollection {
+	return []network.AzureFirewallNetworkRuleCollection{
+		{
+			Name: pointer.String(networkRuleCollectionName),
+			AzureFirewallNetworkRuleCollectionPropertiesFormat: &network.AzureFirewallNetworkRuleCollectionPropertiesFormat{
+				Priority: pointer.Int32(ruleCollectionPriority),
+				Action:   &network.AzureFirewallRCAction{Type: network.AzureFirewallRCActionTypeAllow},
+				Rules: &[]network.AzureFirewallNetworkRule{
+					{
+						Name:                 pointer.String("allow-ntp"),
+						SourceAddresses:      &s.SourceAddresses,
+						Protocols:            &[]network.AzureFirewallNetworkRuleProtocol{network.AzureFirewallNetworkRuleProtocolUDP},
+						DestinationAddresses: &[]string{"*"},
+						DestinationPorts:     &[]string{strconv.Itoa(ntpPort)},
+					},
+					{
+						Name:                 pointer.String("allow-apiserver"),
+						SourceAddresses:      &s.SourceAddresses,
+						Protocols:            &[]network.AzureFirewallNetworkRuleProtocol{network.AzureFirewallNetworkRuleProtocolTCP},
+						DestinationAddresses: &[]string{"*"},
+						DestinationPorts:     &[]string{strconv.Itoa(int(s.APIServerPort))},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applicationRulesEqual returns true if the existing application rule collections allow the same FQDNs to the same sources as the desired ones.
+func applicationRulesEqual(existing *[]network.AzureFirewallApplicationRuleCollection, desired []network.AzureFirewallApplicationRuleCollection) bool {
+	existingRules := map[string]network.AzureFirewallApplicationRule{}
+	if existing != nil {
+		for _, collection := range *existing {
+			if collection.AzureFirewallApplicationRuleCollectionPropertiesFormat == nil || collection.Rules == nil {
+				continue
+			}
+			for _, rule := range *collection.Rules {
+				existingRules[pointer.StringDeref(collection.Name, "")+"/"+pointer.StringDeref(rule.Name, "")] = rule
+			}
+		}
+	}
+	for _, collection := range desired {
+		for _, rule := range *collection.Rules {
+			existingRule, ok := existingRules[pointer.StringDeref(collection.Name, "")+"/"+pointer.StringDeref(rule.Name, "")]
+			if !ok || !stringSetsEqual(existingRule.TargetFqdns, rule.TargetFqdns) || !stringSetsEqual(existingRule.SourceAddresses, rule.SourceAddresses) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// networkRulesEqual returns true if the existing network rule collections allow the same ports to the same sources as the desired ones.
+func networkRulesEqual(existing *[]network.AzureFirewallNetworkRuleCollection, desired []network.AzureFirewallNetworkRuleCollection) bool {
+	existingRules := map[string]network.AzureFirewallNetworkRule{}
+	if existing != nil {
+		for _, collection := range *existing {
+			if collection.AzureFirewallNetworkRuleCollectionPropertiesFormat == nil || collection.Rules == nil {
+				continue
+			}
+			for _, rule := range *collection.Rules {
+				existingRules[pointer.StringDeref(collection.Name, "")+"/"+pointer.StringDeref(rule.Name, "")] = rule
+			}
+		}
+	}
+	for _, collection := range desired {
+		for _, rule := range *collection.Rules {
+			existingRule, ok := existingRules[pointer.StringDeref(collection.Name, "")+"/"+pointer.StringDeref(rule.Name, "")]
+			if !ok || !stringSetsEqual(existingRule.DestinationPorts, rule.DestinationPorts) || !stringSetsEqual(existingRule.SourceAddresses, rule.SourceAddresses) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// stringSetsEqual returns true if both slices contain the same strings, regardless of order.
+func stringSetsEqual(a, b *[]string) bool {
+	var x, y []string
+	if a != nil {
+		x = append(x, *a...)
+	}
+	if b != nil {
+		y = append(y, *b...)
+	}
+	if len(x) != len(y) {
+		return false
+	}
+	sort.Strings(x)
+	sort.Strings(y)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
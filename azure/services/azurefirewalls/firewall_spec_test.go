@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+)
+
+func TestAzureFirewallSpecParameters(t *testing.T) {
+	spec := fakeFirewallSpec
+	spec.AdditionalAllowedFQDNs = []string{"example.com"}
+	upToDate, err := spec.Parameters(context.TODO(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *AzureFirewallSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new firewall",
+			spec:     &spec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.AzureFirewall{}))
+				firewall := result.(network.AzureFirewall)
+				g.Expect(*firewall.Name).To(Equal("my-firewall"))
+				g.Expect(firewall.Sku.Name).To(Equal(network.AzureFirewallSkuNameAZFWVNet))
+				g.Expect(firewall.Sku.Tier).To(Equal(network.AzureFirewallSkuTierStandard))
+				g.Expect(*(*firewall.IPConfigurations)[0].Subnet.ID).To(Equal(spec.SubnetID))
+				g.Expect(*(*firewall.IPConfigurations)[0].PublicIPAddress.ID).To(Equal(spec.PublicIPID))
+
+				appRule := (*(*firewall.ApplicationRuleCollections)[0].Rules)[0]
+				g.Expect(*appRule.TargetFqdns).To(ContainElements("mcr.microsoft.com", "management.azure.com", "example.com"))
+				g.Expect(*appRule.SourceAddresses).To(Equal([]string{"10.1.0.0/16"}))
+
+				networkRules := *(*firewall.NetworkRuleCollections)[0].Rules
+				g.Expect(networkRules).To(HaveLen(2))
+				g.Expect(*networkRules[0].DestinationPorts).To(Equal([]string{"123"}))
+				g.Expect(*networkRules[1].DestinationPorts).To(Equal([]string{"6443"}))
+			},
+		},
+		{
+			name:     "existing firewall with the desired rules",
+			spec:     &spec,
+			existing: upToDate,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing firewall missing an allowed FQDN",
+			spec: &spec,
+			existing: func() network.AzureFirewall {
+				withoutFQDN := fakeFirewallSpec
+				existing, _ := withoutFQDN.Parameters(context.TODO(), nil)
+				return existing.(network.AzureFirewall)
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.AzureFirewall{}))
+				appRule := (*(*result.(network.AzureFirewall).ApplicationRuleCollections)[0].Rules)[0]
+				g.Expect(*appRule.TargetFqdns).To(ContainElement("example.com"))
+			},
+		},
+		{
+			name:          "existing is not a firewall",
+			spec:          &spec,
+			existing:      "wrong type",
+			expectedError: "string is not a network.AzureFirewall",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+)
+
+// RouteSpec defines the specification for a route sending traffic to the Azure Firewall.
+type RouteSpec struct {
+	Name             string
+	ResourceGroup    string
+	RouteTableName   string
+	AddressPrefix    string
+	NextHopIPAddress string
+}
+
+// ResourceName returns the name of the route.
+func (s *RouteSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the route table.
+func (s *RouteSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the route table the route belongs to.
+func (s *RouteSpec) OwnerResourceName() string {
+	return s.RouteTableName
+}
+
+// Parameters returns the parameters for the route.
+func (s *RouteSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	if existing != nil {
+		existingRoute, ok := existing.(network.Route)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.Route", existing)
+		}
+		if props := existingRoute.RoutePropertiesFormat; props != nil &&
+			props.NextHopType == network.RouteNextHopTypeVirtualAppliance &&
+			pointer.StringDeref(props.NextHopIPAddress, "") == s.NextHopIPAddress &&
+			pointer.StringDeref(props.AddressPrefix, "") == s.AddressPrefix {
+			// route already points at the firewall.
+			return nil, nil
+		}
+	}
+
+	return network.Route{
+		Name: pointer.String(s.Name),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    pointer.String(s.AddressPrefix),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: pointer.String(s.NextHopIPAddress),
+		},
+	}, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestRouteSpecParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "new route",
+			existing: nil,
+			expected: network.Route{
+				Name: pointer.String("my-firewall-default-route"),
+				RoutePropertiesFormat: &network.RoutePropertiesFormat{
+					AddressPrefix:    pointer.String("0.0.0.0/0"),
+					NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+					NextHopIPAddress: pointer.String("10.255.255.132"),
+				},
+			},
+		},
+		{
+			name: "existing route pointing at the firewall",
+			existing: network.Route{
+				Name: pointer.String("my-firewall-default-route"),
+				RoutePropertiesFormat: &network.RoutePropertiesFormat{
+					AddressPrefix:    pointer.String("0.0.0.0/0"),
+					NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+					NextHopIPAddress: pointer.String("10.255.255.132"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "existing route pointing elsewhere",
+			existing: network.Route{
+				Name: pointer.String("my-firewall-default-route"),
+				RoutePropertiesFormat: &network.RoutePropertiesFormat{
+					AddressPrefix: pointer.String("0.0.0.0/0"),
+					NextHopType:   network.RouteNextHopTypeInternet,
+				},
+			},
+			expected: network.Route{
+				Name: pointer.String("my-firewall-default-route"),
+				RoutePropertiesFormat: &network.RoutePropertiesFormat{
+					AddressPrefix:    pointer.String("0.0.0.0/0"),
+					NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+					NextHopIPAddress: pointer.String("10.255.255.132"),
+				},
+			},
+		},
+		{
+			name:          "existing is not a route",
+			existing:      "wrong type",
+			expectedError: "string is not a network.Route",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := fakeRouteSpec1.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+		})
+	}
+}
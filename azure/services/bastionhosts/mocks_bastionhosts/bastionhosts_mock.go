@@ -123,6 +123,20 @@ func (mr *MockBastionScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockBastionScope)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockBastionScope) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockBastionScopeMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockBastionScope)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockBastionScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -429,6 +443,20 @@ func (mr *MockBastionScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockBastionScope)(nil).Location))
 }
 
+// NamingConvention mocks base method.
+func (m *MockBastionScope) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockBastionScopeMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockBastionScope)(nil).NamingConvention))
+}
+
 // NodeSubnets mocks base method.
 func (m *MockBastionScope) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -30,14 +30,17 @@ import (
 
 // AzureBastionSpec defines the specification for azure bastion feature.
 type AzureBastionSpec struct {
-	Name            string
-	ResourceGroup   string
-	Location        string
-	ClusterName     string
-	SubnetID        string
-	PublicIPID      string
-	Sku             infrav1.BastionHostSkuName
-	EnableTunneling bool
+	Name                string
+	ResourceGroup       string
+	Location            string
+	ClusterName         string
+	SubnetID            string
+	PublicIPID          string
+	Sku                 infrav1.BastionHostSkuName
+	ScaleUnits          *int32
+	EnableTunneling     bool
+	EnableIPConnect     bool
+	EnableShareableLink bool
 }
 
 // AzureBastionSpecInput defines the required inputs to construct an azure bastion spec.
@@ -65,11 +68,12 @@ func (s *AzureBastionSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the bastion host.
 func (s *AzureBastionSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.BastionHost); !ok {
+		existingBastionHost, ok := existing.(network.BastionHost)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.BastionHost", existing)
 		}
-		// bastion host already exists
-		return nil, nil
+		// bastion host already exists, only its SKU, scale units and features are reconciled
+		return s.updatedParameters(existingBastionHost), nil
 	}
 
 	bastionHostIPConfigName := fmt.Sprintf("%s-%s", s.Name, "bastionIP")
@@ -87,8 +91,11 @@ func (s *AzureBastionSpec) Parameters(ctx context.Context, existing interface{})
 			Name: network.BastionHostSkuName(s.Sku),
 		},
 		BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
-			EnableTunneling: pointer.Bool(s.EnableTunneling),
-			DNSName:         pointer.String(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name))),
+			EnableTunneling:     pointer.Bool(s.EnableTunneling),
+			EnableIPConnect:     pointer.Bool(s.EnableIPConnect),
+			EnableShareableLink: pointer.Bool(s.EnableShareableLink),
+			ScaleUnits:          s.ScaleUnits,
+			DNSName:             pointer.String(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name))),
 			IPConfigurations: &[]network.BastionHostIPConfiguration{
 				{
 					Name: pointer.String(bastionHostIPConfigName),
@@ -106,3 +113,39 @@ func (s *AzureBastionSpec) Parameters(ctx context.Context, existing interface{})
 		},
 	}, nil
 }
+
+// updatedParameters returns the existing bastion host with its SKU, scale units and features reconciled to the spec,
+// or nil if no update is needed. Scale units are only reconciled when set in the spec.
+func (s *AzureBastionSpec) updatedParameters(existing network.BastionHost) interface{} {
+	sku := network.BastionHostSkuName(s.Sku)
+	if sku == "" {
+		sku = network.BastionHostSkuNameBasic
+	}
+
+	props := network.BastionHostPropertiesFormat{}
+	if existing.BastionHostPropertiesFormat != nil {
+		props = *existing.BastionHostPropertiesFormat
+	}
+	existingSku := network.BastionHostSkuNameBasic
+	if existing.Sku != nil && existing.Sku.Name != "" {
+		existingSku = existing.Sku.Name
+	}
+
+	if strings.EqualFold(string(existingSku), string(sku)) &&
+		(s.ScaleUnits == nil || pointer.Int32Deref(props.ScaleUnits, 0) == *s.ScaleUnits) &&
+		pointer.BoolDeref(props.EnableTunneling, false) == s.EnableTunneling &&
+		pointer.BoolDeref(props.EnableIPConnect, false) == s.EnableIPConnect &&
+		pointer.BoolDeref(props.EnableShareableLink, false) == s.EnableShareableLink {
+		return nil
+	}
+
+	existing.Sku = &network.Sku{Name: sku}
+	if s.ScaleUnits != nil {
+		props.ScaleUnits = s.ScaleUnits
+	}
+	props.EnableTunneling = pointer.Bool(s.EnableTunneling)
+	props.EnableIPConnect = pointer.Bool(s.EnableIPConnect)
+	props.EnableShareableLink = pointer.Bool(s.EnableShareableLink)
+	existing.BastionHostPropertiesFormat = &props
+	return existing
+}
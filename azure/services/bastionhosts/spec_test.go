@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastionhosts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	standardSpec := fakeAzureBastionSpec
+	standardSpec.Sku = infrav1.StandardBastionHostSku
+	standardSpec.ScaleUnits = pointer.Int32(4)
+	standardSpec.EnableTunneling = true
+	standardSpec.EnableIPConnect = true
+	standardSpec.EnableShareableLink = true
+
+	testcases := []struct {
+		name          string
+		spec          AzureBastionSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new standard bastion host",
+			spec:     standardSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				bastionHost := result.(network.BastionHost)
+				g.Expect(bastionHost.Sku).To(Equal(&network.Sku{Name: network.BastionHostSkuNameStandard}))
+				g.Expect(bastionHost.ScaleUnits).To(Equal(pointer.Int32(4)))
+				g.Expect(bastionHost.EnableTunneling).To(Equal(pointer.Bool(true)))
+				g.Expect(bastionHost.EnableIPConnect).To(Equal(pointer.Bool(true)))
+				g.Expect(bastionHost.EnableShareableLink).To(Equal(pointer.Bool(true)))
+			},
+		},
+		{
+			name: "existing bastion host is up to date",
+			spec: standardSpec,
+			existing: network.BastionHost{
+				Name: pointer.String("my-bastion"),
+				Sku:  &network.Sku{Name: network.BastionHostSkuNameStandard},
+				BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+					ScaleUnits:          pointer.Int32(4),
+					EnableTunneling:     pointer.Bool(true),
+					EnableIPConnect:     pointer.Bool(true),
+					EnableShareableLink: pointer.Bool(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing basic bastion host without an explicit SKU is up to date",
+			spec: fakeAzureBastionSpec,
+			existing: network.BastionHost{
+				Name: pointer.String("my-bastion"),
+				BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+					ScaleUnits: pointer.Int32(2),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing basic bastion host is upgraded",
+			spec: standardSpec,
+			existing: network.BastionHost{
+				Name: pointer.String("my-bastion"),
+				Sku:  &network.Sku{Name: network.BastionHostSkuNameBasic},
+				BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+					DNSName:         pointer.String("my-bastion-bastion"),
+					ScaleUnits:      pointer.Int32(2),
+					EnableTunneling: pointer.Bool(false),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.BastionHost{
+					Name: pointer.String("my-bastion"),
+					Sku:  &network.Sku{Name: network.BastionHostSkuNameStandard},
+					BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+						DNSName:             pointer.String("my-bastion-bastion"),
+						ScaleUnits:          pointer.Int32(4),
+						EnableTunneling:     pointer.Bool(true),
+						EnableIPConnect:     pointer.Bool(true),
+						EnableShareableLink: pointer.Bool(true),
+					},
+				}))
+			},
+		},
+		{
+			name: "scale units of existing bastion host are left untouched when not set",
+			spec: func() AzureBastionSpec {
+				spec := standardSpec
+				spec.ScaleUnits = nil
+				spec.EnableShareableLink = false
+				return spec
+			}(),
+			existing: network.BastionHost{
+				Name: pointer.String("my-bastion"),
+				Sku:  &network.Sku{Name: network.BastionHostSkuNameStandard},
+				BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+					ScaleUnits:          pointer.Int32(6),
+					EnableTunneling:     pointer.Bool(true),
+					EnableIPConnect:     pointer.Bool(true),
+					EnableShareableLink: pointer.Bool(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.BastionHost{}))
+				bastionHost := result.(network.BastionHost)
+				g.Expect(bastionHost.ScaleUnits).To(Equal(pointer.Int32(6)))
+				g.Expect(bastionHost.EnableShareableLink).To(Equal(pointer.Bool(false)))
+			},
+		},
+		{
+			name:          "existing is not a bastion host",
+			spec:          fakeAzureBastionSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.BastionHost",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
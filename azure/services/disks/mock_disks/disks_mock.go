@@ -81,6 +81,20 @@ func (mr *MockDiskScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockDiskScope)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockDiskScope) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockDiskScopeMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockDiskScope)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockDiskScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -303,6 +317,20 @@ func (mr *MockDiskScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockDiskScope)(nil).Location))
 }
 
+// NamingConvention mocks base method.
+func (m *MockDiskScope) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockDiskScopeMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockDiskScope)(nil).NamingConvention))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockDiskScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
@@ -31,6 +32,8 @@ type GroupSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	// Adopt makes a pre-existing resource group which isn't tagged for any cluster owned by the cluster.
+	Adopt bool
 }
 
 // ResourceName returns the name of the group.
@@ -52,6 +55,23 @@ func (s *GroupSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the group.
 func (s *GroupSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
+		existingGroup, ok := existing.(resources.Group)
+		if !ok {
+			return nil, errors.Errorf("%T is not a resources.Group", existing)
+		}
+
+		existingTags := infrav1.Tags{}
+		existingTags.Merge(converters.MapToTags(existingGroup.Tags))
+		if s.Adopt && !existingTags.HasAnyCluster() {
+			// adopt the rg by tagging it as owned by the cluster.
+			existingTags.Merge(s.tags())
+			return resources.Group{
+				Location:  existingGroup.Location,
+				ManagedBy: existingGroup.ManagedBy,
+				Tags:      converters.TagsToMap(existingTags),
+			}, nil
+		}
+
 		// rg already exists, nothing to update.
 		// Note that rg tags are updated separately using tags service.
 		return nil, nil
@@ -59,12 +79,17 @@ func (s *GroupSpec) Parameters(ctx context.Context, existing interface{}) (param
 	return resources.Group{
 		Location: pointer.String(s.Location),
 		// User defined additional tags are created with the resource group and updated using tags service.
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        pointer.String(s.Name),
-			Role:        pointer.String(infrav1.CommonRole),
-			Additional:  s.AdditionalTags,
-		})),
+		Tags: converters.TagsToMap(s.tags()),
 	}, nil
 }
+
+// tags returns the tags of a resource group owned by the cluster.
+func (s *GroupSpec) tags() infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        pointer.String(s.Name),
+		Role:        pointer.String(infrav1.CommonRole),
+		Additional:  s.AdditionalTags,
+	})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package groups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *GroupSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "new resource group",
+			spec: &GroupSpec{
+				Name:        "test-group",
+				Location:    "test-location",
+				ClusterName: "test-cluster",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(resources.Group{
+					Location: pointer.String("test-location"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":                 pointer.String("common"),
+						"Name": pointer.String("test-group"),
+					},
+				}))
+			},
+		},
+		{
+			name: "existing resource group is not updated",
+			spec: &GroupSpec{
+				Name:        "test-group",
+				Location:    "test-location",
+				ClusterName: "test-cluster",
+			},
+			existing: resources.Group{
+				Location: pointer.String("test-location"),
+				Tags:     map[string]*string{"foo": pointer.String("bar")},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "untagged existing resource group is adopted",
+			spec: &GroupSpec{
+				Name:        "test-group",
+				Location:    "test-location",
+				ClusterName: "test-cluster",
+				Adopt:       true,
+			},
+			existing: resources.Group{
+				Location: pointer.String("test-location"),
+				Tags:     map[string]*string{"foo": pointer.String("bar")},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(resources.Group{
+					Location: pointer.String("test-location"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":                 pointer.String("common"),
+						"Name": pointer.String("test-group"),
+						"foo":  pointer.String("bar"),
+					},
+				}))
+			},
+		},
+		{
+			name: "existing resource group of another cluster is not adopted",
+			spec: &GroupSpec{
+				Name:        "test-group",
+				Location:    "test-location",
+				ClusterName: "test-cluster",
+				Adopt:       true,
+			},
+			existing: resources.Group{
+				Location: pointer.String("test-location"),
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("owned"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
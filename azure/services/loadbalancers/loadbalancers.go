@@ -31,6 +31,8 @@ const (
 	tcpProbe    = "TCPProbe"
 	lbRuleHTTPS = "LBRuleHTTPS"
 	outboundNAT = "OutboundNATAllProtocols"
+	// outboundNATNodes is the name of the outbound rule of the nodes sharing the API server load balancer.
+	outboundNATNodes = "OutboundNATNodes"
 )
 
 // LBScope defines the scope interface for a load balancer service.
@@ -65,7 +67,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	specs := s.Scope.LBSpecs()
@@ -94,7 +96,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	specs := s.Scope.LBSpecs()
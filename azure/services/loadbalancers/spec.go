@@ -18,6 +18,7 @@ package loadbalancers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
@@ -45,7 +46,16 @@ type LBSpec struct {
 	FrontendIPConfigs    []infrav1.FrontendIP
 	APIServerPort        int32
 	IdleTimeoutInMinutes *int32
-	AdditionalTags       map[string]string
+	// EnableTCPReset is set on the load balancing and outbound rules when not nil.
+	EnableTCPReset *bool
+	// AllocatedOutboundPorts is set on the outbound rules when not nil.
+	AllocatedOutboundPorts *int32
+	AdditionalTags         map[string]string
+	// NodeOutboundBackendPoolName is the name of an additional backend pool, with its own outbound rule, providing
+	// outbound connectivity to the nodes of the cluster through the frontend IPs of the load balancer.
+	NodeOutboundBackendPoolName string
+	// IPv6Enabled adds an IPv6 frontend, backend pool and rules alongside each IPv4 one.
+	IPv6Enabled bool
 }
 
 // ResourceName returns the name of the load balancer.
@@ -68,6 +78,7 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 	var (
 		etag                *string
 		frontendIDs         []network.SubResource
+		ipv6FrontendIDs     []network.SubResource
 		frontendIPConfigs   = make([]network.FrontendIPConfiguration, 0)
 		loadBalancingRules  = make([]network.LoadBalancingRule, 0)
 		backendAddressPools = make([]network.BackendAddressPool, 0)
@@ -87,7 +98,7 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 
 		// merge existing LB properties with desired properties
 		frontendIPConfigs = *existingLB.FrontendIPConfigurations
-		wantedIPs, wantedFrontendIDs := getFrontendIPConfigs(*s)
+		wantedIPs, wantedFrontendIDs, wantedIPv6FrontendIDs := getFrontendIPConfigs(*s)
 		for _, ip := range wantedIPs {
 			if !ipExists(frontendIPConfigs, ip) {
 				update = true
@@ -95,11 +106,17 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			}
 		}
 
-		loadBalancingRules = *existingLB.LoadBalancingRules
-		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs) {
-			if !lbRuleExists(loadBalancingRules, rule) {
+		loadBalancingRules = append([]network.LoadBalancingRule{}, *existingLB.LoadBalancingRules...)
+		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs, wantedIPv6FrontendIDs) {
+			i := lbRuleIndex(loadBalancingRules, rule)
+			if i < 0 {
 				update = true
 				loadBalancingRules = append(loadBalancingRules, rule)
+				continue
+			}
+			if updated, ok := s.updatedLoadBalancingRule(loadBalancingRules[i]); ok {
+				update = true
+				loadBalancingRules[i] = updated
 			}
 		}
 
@@ -111,11 +128,17 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			}
 		}
 
-		outboundRules = *existingLB.OutboundRules
-		for _, rule := range getOutboundRules(*s, wantedFrontendIDs) {
-			if !outboundRuleExists(outboundRules, rule) {
+		outboundRules = append([]network.OutboundRule{}, *existingLB.OutboundRules...)
+		for _, rule := range getOutboundRules(*s, wantedFrontendIDs, wantedIPv6FrontendIDs) {
+			i := outboundRuleIndex(outboundRules, rule)
+			if i < 0 {
 				update = true
 				outboundRules = append(outboundRules, rule)
+				continue
+			}
+			if updated, ok := s.updatedOutboundRule(outboundRules[i], rule); ok {
+				update = true
+				outboundRules[i] = updated
 			}
 		}
 
@@ -132,10 +155,10 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			return nil, nil
 		}
 	} else {
-		frontendIPConfigs, frontendIDs = getFrontendIPConfigs(*s)
-		loadBalancingRules = getLoadBalancingRules(*s, frontendIDs)
+		frontendIPConfigs, frontendIDs, ipv6FrontendIDs = getFrontendIPConfigs(*s)
+		loadBalancingRules = getLoadBalancingRules(*s, frontendIDs, ipv6FrontendIDs)
 		backendAddressPools = getBackendAddressPools(*s)
-		outboundRules = getOutboundRules(*s, frontendIDs)
+		outboundRules = getOutboundRules(*s, frontendIDs, ipv6FrontendIDs)
 		probes = getProbes(*s)
 	}
 
@@ -162,9 +185,12 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 	return lb, nil
 }
 
-func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []network.SubResource) {
+// getFrontendIPConfigs returns the frontend IP configurations of the load balancer, along with the IDs of the IPv4
+// and IPv6 frontends. IPv6 frontends are only generated when IPv6 is enabled.
+func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []network.SubResource, []network.SubResource) {
 	frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0)
 	frontendIDs := make([]network.SubResource, 0)
+	ipv6FrontendIDs := make([]network.SubResource, 0)
 	for _, ipConfig := range lbSpec.FrontendIPConfigs {
 		var properties network.FrontendIPConfigurationPropertiesFormat
 		if lbSpec.Type == infrav1.Internal {
@@ -189,30 +215,81 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []n
 		frontendIDs = append(frontendIDs, network.SubResource{
 			ID: pointer.String(azure.FrontendIPConfigID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, ipConfig.Name)),
 		})
+
+		if lbSpec.IPv6Enabled {
+			ipv6Config := getIPv6FrontendIPConfig(lbSpec, ipConfig)
+			frontendIPConfigurations = append(frontendIPConfigurations, ipv6Config)
+			ipv6FrontendIDs = append(ipv6FrontendIDs, network.SubResource{
+				ID: pointer.String(azure.FrontendIPConfigID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, *ipv6Config.Name)),
+			})
+		}
+	}
+	return frontendIPConfigurations, frontendIDs, ipv6FrontendIDs
+}
+
+// getIPv6FrontendIPConfig returns the IPv6 counterpart of an IPv4 frontend IP configuration.
+// Internal frontends get a dynamically allocated IPv6 address from the subnet, public frontends use the IPv6 public IP
+// created next to the IPv4 one.
+func getIPv6FrontendIPConfig(lbSpec LBSpec, ipConfig infrav1.FrontendIP) network.FrontendIPConfiguration {
+	var properties network.FrontendIPConfigurationPropertiesFormat
+	if lbSpec.Type == infrav1.Internal {
+		properties = network.FrontendIPConfigurationPropertiesFormat{
+			PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+			PrivateIPAddressVersion:   network.IPVersionIPv6,
+			Subnet: &network.Subnet{
+				ID: pointer.String(azure.SubnetID(lbSpec.SubscriptionID, lbSpec.VNetResourceGroup, lbSpec.VNetName, lbSpec.SubnetName)),
+			},
+		}
+	} else {
+		properties = network.FrontendIPConfigurationPropertiesFormat{
+			PublicIPAddress: &network.PublicIPAddress{
+				ID: pointer.String(azure.PublicIPID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, azure.GenerateIPv6Name(ipConfig.PublicIP.Name))),
+			},
+		}
+	}
+	return network.FrontendIPConfiguration{
+		FrontendIPConfigurationPropertiesFormat: &properties,
+		Name:                                    pointer.String(azure.GenerateIPv6Name(ipConfig.Name)),
 	}
-	return frontendIPConfigurations, frontendIDs
 }
 
-func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.OutboundRule {
+func getOutboundRules(lbSpec LBSpec, frontendIDs, ipv6FrontendIDs []network.SubResource) []network.OutboundRule {
 	if lbSpec.Type == infrav1.Internal {
 		return []network.OutboundRule{}
 	}
-	return []network.OutboundRule{
-		{
-			Name: pointer.String(outboundNAT),
-			OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
-				Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
-				IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
-				FrontendIPConfigurations: &frontendIDs,
-				BackendAddressPool: &network.SubResource{
-					ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
-				},
+	rules := []network.OutboundRule{
+		newOutboundRule(lbSpec, outboundNAT, frontendIDs, lbSpec.BackendPoolName),
+	}
+	if lbSpec.IPv6Enabled {
+		rules = append(rules, newOutboundRule(lbSpec, azure.GenerateIPv6Name(outboundNAT), ipv6FrontendIDs, azure.GenerateIPv6Name(lbSpec.BackendPoolName)))
+	}
+	if lbSpec.NodeOutboundBackendPoolName != "" {
+		rules = append(rules, newOutboundRule(lbSpec, outboundNATNodes, frontendIDs, lbSpec.NodeOutboundBackendPoolName))
+		if lbSpec.IPv6Enabled {
+			rules = append(rules, newOutboundRule(lbSpec, azure.GenerateIPv6Name(outboundNATNodes), ipv6FrontendIDs, azure.GenerateIPv6Name(lbSpec.NodeOutboundBackendPoolName)))
+		}
+	}
+	return rules
+}
+
+// newOutboundRule returns an outbound rule for all protocols from a backend pool through the given frontends.
+func newOutboundRule(lbSpec LBSpec, name string, frontendIDs []network.SubResource, backendPoolName string) network.OutboundRule {
+	return network.OutboundRule{
+		Name: pointer.String(name),
+		OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+			Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
+			IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
+			EnableTCPReset:           lbSpec.EnableTCPReset,
+			AllocatedOutboundPorts:   lbSpec.AllocatedOutboundPorts,
+			FrontendIPConfigurations: &frontendIDs,
+			BackendAddressPool: &network.SubResource{
+				ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, backendPoolName)),
 			},
 		},
 	}
 }
 
-func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.LoadBalancingRule {
+func getLoadBalancingRules(lbSpec LBSpec, frontendIDs, ipv6FrontendIDs []network.SubResource) []network.LoadBalancingRule {
 	if lbSpec.Role == infrav1.APIServerRole {
 		// We disable outbound SNAT explicitly in the HTTPS LB rule and enable TCP and UDP outbound NAT with an outbound rule.
 		// For more information on Standard LB outbound connections see https://docs.microsoft.com/en-us/azure/load-balancer/load-balancer-outbound-connections.
@@ -220,37 +297,64 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 		if len(frontendIDs) != 0 {
 			frontendIPConfig = frontendIDs[0]
 		}
-		return []network.LoadBalancingRule{
-			{
-				Name: pointer.String(lbRuleHTTPS),
-				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
-					DisableOutboundSnat:     pointer.Bool(true),
-					Protocol:                network.TransportProtocolTCP,
-					FrontendPort:            pointer.Int32(lbSpec.APIServerPort),
-					BackendPort:             pointer.Int32(lbSpec.APIServerPort),
-					IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
-					EnableFloatingIP:        pointer.Bool(false),
-					LoadDistribution:        network.LoadDistributionDefault,
-					FrontendIPConfiguration: &frontendIPConfig,
-					BackendAddressPool: &network.SubResource{
-						ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
-					},
-					Probe: &network.SubResource{
-						ID: pointer.String(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, tcpProbe)),
-					},
-				},
-			},
+		rules := []network.LoadBalancingRule{
+			newAPIServerLoadBalancingRule(lbSpec, lbRuleHTTPS, frontendIPConfig, lbSpec.BackendPoolName),
 		}
+		// Azure requires an IPv4 rule to be present before an IPv6 one can be added.
+		if lbSpec.IPv6Enabled && len(ipv6FrontendIDs) != 0 {
+			rules = append(rules, newAPIServerLoadBalancingRule(lbSpec, azure.GenerateIPv6Name(lbRuleHTTPS), ipv6FrontendIDs[0], azure.GenerateIPv6Name(lbSpec.BackendPoolName)))
+		}
+		return rules
 	}
 	return []network.LoadBalancingRule{}
 }
 
+// newAPIServerLoadBalancingRule returns a rule forwarding API server traffic from a frontend to a backend pool.
+func newAPIServerLoadBalancingRule(lbSpec LBSpec, name string, frontendIPConfig network.SubResource, backendPoolName string) network.LoadBalancingRule {
+	return network.LoadBalancingRule{
+		Name: pointer.String(name),
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			DisableOutboundSnat:     pointer.Bool(true),
+			Protocol:                network.TransportProtocolTCP,
+			FrontendPort:            pointer.Int32(lbSpec.APIServerPort),
+			BackendPort:             pointer.Int32(lbSpec.APIServerPort),
+			IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
+			EnableTCPReset:          lbSpec.EnableTCPReset,
+			EnableFloatingIP:        pointer.Bool(false),
+			LoadDistribution:        network.LoadDistributionDefault,
+			FrontendIPConfiguration: &frontendIPConfig,
+			BackendAddressPool: &network.SubResource{
+				ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, backendPoolName)),
+			},
+			Probe: &network.SubResource{
+				ID: pointer.String(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, tcpProbe)),
+			},
+		},
+	}
+}
+
 func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
-	return []network.BackendAddressPool{
+	pools := []network.BackendAddressPool{
 		{
 			Name: pointer.String(lbSpec.BackendPoolName),
 		},
 	}
+	if lbSpec.IPv6Enabled {
+		pools = append(pools, network.BackendAddressPool{
+			Name: pointer.String(azure.GenerateIPv6Name(lbSpec.BackendPoolName)),
+		})
+	}
+	if lbSpec.NodeOutboundBackendPoolName != "" {
+		pools = append(pools, network.BackendAddressPool{
+			Name: pointer.String(lbSpec.NodeOutboundBackendPoolName),
+		})
+		if lbSpec.IPv6Enabled {
+			pools = append(pools, network.BackendAddressPool{
+				Name: pointer.String(azure.GenerateIPv6Name(lbSpec.NodeOutboundBackendPoolName)),
+			})
+		}
+	}
+	return pools
 }
 
 func getProbes(lbSpec LBSpec) []network.Probe {
@@ -279,13 +383,75 @@ func probeExists(probes []network.Probe, probe network.Probe) bool {
 	return false
 }
 
-func outboundRuleExists(rules []network.OutboundRule, rule network.OutboundRule) bool {
-	for _, r := range rules {
+// outboundRuleIndex returns the index of the outbound rule with the same name as rule, or -1 if there is none.
+func outboundRuleIndex(rules []network.OutboundRule, rule network.OutboundRule) int {
+	for i, r := range rules {
 		if pointer.StringDeref(r.Name, "") == pointer.StringDeref(rule.Name, "") {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
+}
+
+// updatedOutboundRule returns the existing outbound rule with its frontend IPs, allocated ports and TCP reset reconciled
+// to the desired rule, and whether it had to be updated. The frontend IPs change with the number of outbound IPs of
+// the load balancer.
+func (s *LBSpec) updatedOutboundRule(existing, desired network.OutboundRule) (network.OutboundRule, bool) {
+	if existing.OutboundRulePropertiesFormat == nil {
+		return existing, false
+	}
+	props := *existing.OutboundRulePropertiesFormat
+	update := false
+	if !subResourcesEqual(props.FrontendIPConfigurations, desired.FrontendIPConfigurations) {
+		props.FrontendIPConfigurations = desired.FrontendIPConfigurations
+		update = true
+	}
+	if s.AllocatedOutboundPorts != nil && pointer.Int32Deref(props.AllocatedOutboundPorts, 0) != *s.AllocatedOutboundPorts {
+		props.AllocatedOutboundPorts = s.AllocatedOutboundPorts
+		update = true
+	}
+	if s.EnableTCPReset != nil && pointer.BoolDeref(props.EnableTCPReset, false) != *s.EnableTCPReset {
+		props.EnableTCPReset = s.EnableTCPReset
+		update = true
+	}
+	existing.OutboundRulePropertiesFormat = &props
+	return existing, update
+}
+
+// updatedLoadBalancingRule returns the existing load balancing rule with its TCP reset reconciled to the spec, and
+// whether it had to be updated.
+func (s *LBSpec) updatedLoadBalancingRule(existing network.LoadBalancingRule) (network.LoadBalancingRule, bool) {
+	if existing.LoadBalancingRulePropertiesFormat == nil || s.EnableTCPReset == nil ||
+		pointer.BoolDeref(existing.EnableTCPReset, false) == *s.EnableTCPReset {
+		return existing, false
+	}
+	props := *existing.LoadBalancingRulePropertiesFormat
+	props.EnableTCPReset = s.EnableTCPReset
+	existing.LoadBalancingRulePropertiesFormat = &props
+	return existing, true
+}
+
+// subResourcesEqual returns true if both lists reference the same resources, regardless of their order and case.
+func subResourcesEqual(a, b *[]network.SubResource) bool {
+	ids := func(resources *[]network.SubResource) map[string]bool {
+		set := map[string]bool{}
+		if resources != nil {
+			for _, r := range *resources {
+				set[strings.ToLower(pointer.StringDeref(r.ID, ""))] = true
+			}
+		}
+		return set
+	}
+	aIDs, bIDs := ids(a), ids(b)
+	if len(aIDs) != len(bIDs) {
+		return false
+	}
+	for id := range aIDs {
+		if !bIDs[id] {
+			return false
+		}
+	}
+	return true
 }
 
 func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressPool) bool {
@@ -297,13 +463,14 @@ func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressP
 	return false
 }
 
-func lbRuleExists(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) bool {
-	for _, r := range rules {
+// lbRuleIndex returns the index of the load balancing rule with the same name as rule, or -1 if there is none.
+func lbRuleIndex(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) int {
+	for i, r := range rules {
 		if pointer.StringDeref(r.Name, "") == pointer.StringDeref(rule.Name, "") {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
 }
 
 func ipExists(configs []network.FrontendIPConfiguration, config network.FrontendIPConfiguration) bool {
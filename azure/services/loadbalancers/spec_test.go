@@ -61,6 +61,27 @@ func getExistingLBWithMissingOutboundRules() network.LoadBalancer {
 	return existingLB
 }
 
+func getDualStackLBSpec(spec LBSpec) *LBSpec {
+	spec.IPv6Enabled = true
+	return &spec
+}
+
+func getLBResourceNames(lb network.LoadBalancer) (frontends, pools, lbRules, outboundRules []string) {
+	for _, ip := range *lb.FrontendIPConfigurations {
+		frontends = append(frontends, *ip.Name)
+	}
+	for _, pool := range *lb.BackendAddressPools {
+		pools = append(pools, *pool.Name)
+	}
+	for _, rule := range *lb.LoadBalancingRules {
+		lbRules = append(lbRules, *rule.Name)
+	}
+	for _, rule := range *lb.OutboundRules {
+		outboundRules = append(outboundRules, *rule.Name)
+	}
+	return frontends, pools, lbRules, outboundRules
+}
+
 func TestParameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -146,6 +167,168 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "dual-stack public API load balancer",
+			spec:     getDualStackLBSpec(fakePublicAPILBSpec),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				frontends, pools, lbRules, outboundRules := getLBResourceNames(lb)
+				g.Expect(frontends).To(Equal([]string{"my-publiclb-frontEnd", "my-publiclb-frontEnd-ipv6"}))
+				g.Expect(pools).To(Equal([]string{"my-publiclb-backendPool", "my-publiclb-backendPool-ipv6"}))
+				g.Expect(lbRules).To(Equal([]string{"LBRuleHTTPS", "LBRuleHTTPS-ipv6"}))
+				g.Expect(outboundRules).To(Equal([]string{"OutboundNATAllProtocols", "OutboundNATAllProtocols-ipv6"}))
+				g.Expect((*lb.FrontendIPConfigurations)[1].PublicIPAddress.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip-ipv6")))
+				ipv6Rule := (*lb.LoadBalancingRules)[1]
+				g.Expect(ipv6Rule.FrontendIPConfiguration.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd-ipv6")))
+				g.Expect(ipv6Rule.BackendAddressPool.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/backendAddressPools/my-publiclb-backendPool-ipv6")))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "dual-stack internal API load balancer",
+			spec:     getDualStackLBSpec(fakeInternalAPILBSpec),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				frontends, pools, lbRules, outboundRules := getLBResourceNames(lb)
+				g.Expect(frontends).To(Equal([]string{"my-private-lb-frontEnd", "my-private-lb-frontEnd-ipv6"}))
+				g.Expect(pools).To(Equal([]string{"my-private-lb-backendPool", "my-private-lb-backendPool-ipv6"}))
+				g.Expect(lbRules).To(Equal([]string{"LBRuleHTTPS", "LBRuleHTTPS-ipv6"}))
+				g.Expect(outboundRules).To(BeEmpty())
+				ipv6Frontend := (*lb.FrontendIPConfigurations)[1]
+				g.Expect(ipv6Frontend.PrivateIPAllocationMethod).To(Equal(network.IPAllocationMethodDynamic))
+				g.Expect(ipv6Frontend.PrivateIPAddressVersion).To(Equal(network.IPVersionIPv6))
+				g.Expect(ipv6Frontend.PrivateIPAddress).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing node outbound load balancer becomes dual-stack",
+			spec:     getDualStackLBSpec(fakeNodeOutboundLBSpec),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				frontends, pools, lbRules, outboundRules := getLBResourceNames(result.(network.LoadBalancer))
+				g.Expect(frontends).To(Equal([]string{"my-cluster-frontEnd", "my-cluster-frontEnd-ipv6"}))
+				g.Expect(pools).To(Equal([]string{"my-cluster-outboundBackendPool", "my-cluster-outboundBackendPool-ipv6"}))
+				g.Expect(lbRules).To(BeEmpty())
+				g.Expect(outboundRules).To(Equal([]string{"OutboundNATAllProtocols", "OutboundNATAllProtocols-ipv6"}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer with allocated outbound ports and TCP reset",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.AllocatedOutboundPorts = pointer.Int32(1024)
+				spec.EnableTCPReset = pointer.Bool(true)
+				return &spec
+			}(),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				outboundRule := (*result.(network.LoadBalancer).OutboundRules)[0]
+				g.Expect(outboundRule.AllocatedOutboundPorts).To(Equal(pointer.Int32(1024)))
+				g.Expect(outboundRule.EnableTCPReset).To(Equal(pointer.Bool(true)))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing node outbound load balancer gets allocated outbound ports and TCP reset",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.AllocatedOutboundPorts = pointer.Int32(1024)
+				spec.EnableTCPReset = pointer.Bool(true)
+				return &spec
+			}(),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				expected := newDefaultNodeOutboundLB()
+				(*expected.OutboundRules)[0].AllocatedOutboundPorts = pointer.Int32(1024)
+				(*expected.OutboundRules)[0].EnableTCPReset = pointer.Bool(true)
+				g.Expect(*result.(network.LoadBalancer).OutboundRules).To(Equal(*expected.OutboundRules))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing node outbound load balancer gets an additional outbound IP",
+			spec: func() *LBSpec {
+				spec := fakeNodeOutboundLBSpec
+				spec.FrontendIPConfigs = append(spec.FrontendIPConfigs, infrav1.FrontendIP{
+					Name:     "my-cluster-frontEnd-2",
+					PublicIP: &infrav1.PublicIPSpec{Name: "outbound-publicip-2"},
+				})
+				return &spec
+			}(),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				frontends, _, _, _ := getLBResourceNames(lb)
+				g.Expect(frontends).To(Equal([]string{"my-cluster-frontEnd", "my-cluster-frontEnd-2"}))
+				g.Expect((*lb.OutboundRules)[0].FrontendIPConfigurations).To(Equal(&[]network.SubResource{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd")},
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd-2")},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "public API load balancer shared with the nodes for outbound traffic",
+			spec: func() *LBSpec {
+				spec := fakePublicAPILBSpec
+				spec.NodeOutboundBackendPoolName = "my-publiclb-outboundBackendPool"
+				return &spec
+			}(),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				_, pools, _, outboundRules := getLBResourceNames(lb)
+				g.Expect(pools).To(Equal([]string{"my-publiclb-backendPool", "my-publiclb-outboundBackendPool"}))
+				g.Expect(outboundRules).To(Equal([]string{"OutboundNATAllProtocols", "OutboundNATNodes"}))
+				nodeRule := (*lb.OutboundRules)[1]
+				g.Expect(nodeRule.FrontendIPConfigurations).To(Equal((*lb.OutboundRules)[0].FrontendIPConfigurations))
+				g.Expect(nodeRule.BackendAddressPool.ID).To(Equal(pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/backendAddressPools/my-publiclb-outboundBackendPool")))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing public API load balancer gets shared with the nodes for outbound traffic",
+			spec: func() *LBSpec {
+				spec := fakePublicAPILBSpec
+				spec.NodeOutboundBackendPoolName = "my-publiclb-outboundBackendPool"
+				return &spec
+			}(),
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				_, pools, _, outboundRules := getLBResourceNames(result.(network.LoadBalancer))
+				g.Expect(pools).To(Equal([]string{"my-publiclb-backendPool", "my-publiclb-outboundBackendPool"}))
+				g.Expect(outboundRules).To(Equal([]string{"OutboundNATAllProtocols", "OutboundNATNodes"}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing public API load balancer enables TCP reset",
+			spec: func() *LBSpec {
+				spec := fakePublicAPILBSpec
+				spec.EnableTCPReset = pointer.Bool(true)
+				return &spec
+			}(),
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect((*lb.LoadBalancingRules)[0].EnableTCPReset).To(Equal(pointer.Bool(true)))
+				g.Expect((*lb.OutboundRules)[0].EnableTCPReset).To(Equal(pointer.Bool(true)))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	maintenanceconfigurations containerservice.MaintenanceConfigurationsClient
+}
+
+// newClient creates a new maintenance configurations client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newMaintenanceConfigurationsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{c}
+}
+
+// newMaintenanceConfigurationsClient creates a new maintenance configurations client from subscription ID.
+func newMaintenanceConfigurationsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) containerservice.MaintenanceConfigurationsClient {
+	maintenanceConfigurationsClient := containerservice.NewMaintenanceConfigurationsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&maintenanceConfigurationsClient.Client, authorizer)
+	return maintenanceConfigurationsClient
+}
+
+// Get gets a maintenance configuration of a managed cluster.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.Get")
+	defer done()
+
+	return ac.maintenanceconfigurations.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+}
+
+// CreateOrUpdateAsync creates or updates a maintenance configuration.
+// Creating a maintenance configuration is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	maintenanceConfiguration, ok := parameters.(containerservice.MaintenanceConfiguration)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a containerservice.MaintenanceConfiguration", parameters)
+	}
+
+	result, err = ac.maintenanceconfigurations.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), maintenanceConfiguration)
+	return result, nil, err
+}
+
+// DeleteAsync deletes a maintenance configuration.
+// Deleting a maintenance configuration is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.DeleteAsync")
+	defer done()
+
+	_, err = ac.maintenanceconfigurations.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.maintenanceconfigurations)
+}
+
+// Result is a no-op for maintenance configurations as they are never created or deleted with a long-running
+// operation.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	return nil, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "maintenanceconfigurations"
+
+// MaintenanceConfigurationScope defines the scope interface for a maintenance configuration service.
+type MaintenanceConfigurationScope interface {
+	azure.AsyncStatusUpdater
+	azure.Authorizer
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+	MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter
+	ResourceGroup() string
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope MaintenanceConfigurationScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope MaintenanceConfigurationScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates the maintenance configurations of a managed cluster, and deletes the
+// ones which were removed from the spec.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied maintenance configurations")
+	}
+
+	specs := s.Scope.MaintenanceConfigurationSpecs()
+	if len(specs) == 0 && len(lastApplied) == 0 {
+		return nil
+	}
+
+	// We go through the list of MaintenanceConfigurationSpecs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var result error
+	for _, maintenanceConfigurationSpec := range specs {
+		log.V(2).Info("reconciling maintenance configuration", "name", maintenanceConfigurationSpec.ResourceName())
+		if _, err := s.CreateOrUpdateResource(ctx, maintenanceConfigurationSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	if err := s.deleteStaleMaintenanceConfigurations(ctx, lastApplied, specs); err != nil {
+		result = err
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, result)
+	return result
+}
+
+// deleteStaleMaintenanceConfigurations deletes the last applied maintenance configurations which are not in the
+// desired maintenance configuration specs, and records the desired maintenance configurations as the last applied ones.
+func (s *Service) deleteStaleMaintenanceConfigurations(ctx context.Context, lastApplied map[string]interface{}, maintenanceConfigurationSpecs []azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.deleteStaleMaintenanceConfigurations")
+	defer done()
+
+	applied := make(map[string]interface{}, len(maintenanceConfigurationSpecs))
+	for _, maintenanceConfigurationSpec := range maintenanceConfigurationSpecs {
+		applied[maintenanceConfigurationSpec.ResourceName()] = maintenanceConfigurationSpec.OwnerResourceName()
+	}
+
+	for name, cluster := range lastApplied {
+		cluster, ok := cluster.(string)
+		if !ok || applied[name] == cluster {
+			continue
+		}
+		log.V(2).Info("deleting stale maintenance configuration", "name", name, "cluster", cluster)
+		spec := &MaintenanceConfigurationSpec{
+			Name:          name,
+			ResourceGroup: s.Scope.ResourceGroup(),
+			Cluster:       cluster,
+		}
+		if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+			return errors.Wrapf(err, "failed to delete stale maintenance configuration %s", name)
+		}
+	}
+
+	return s.Scope.UpdateAnnotationJSON(azure.MaintenanceConfigurationsLastAppliedAnnotation, applied)
+}
+
+// Delete is a no-op as the maintenance configurations get deleted as part of the managed cluster deletion.
+// Maintenance configurations which are removed from the spec while the managed cluster exists are deleted by Reconcile.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Delete")
+	defer done()
+	return nil
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO maintenance configurations.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
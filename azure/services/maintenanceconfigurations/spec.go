@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+// TimeInWeek is a weekly maintenance window of a managed cluster.
+type TimeInWeek struct {
+	// Day is the day of the week of the window.
+	Day string
+	// HourSlots are the hours of the window, in UTC.
+	HourSlots []int32
+}
+
+// TimeSpan is a span of time during which maintenance is not allowed.
+type TimeSpan struct {
+	Start time.Time
+	End   time.Time
+}
+
+// MaintenanceConfigurationSpec defines the specification for a maintenance configuration of a managed cluster.
+type MaintenanceConfigurationSpec struct {
+	Name           string
+	ResourceGroup  string
+	Cluster        string
+	TimeInWeek     []TimeInWeek
+	NotAllowedTime []TimeSpan
+}
+
+// ResourceName returns the name of the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *MaintenanceConfigurationSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the managed cluster the maintenance configuration belongs to.
+func (s *MaintenanceConfigurationSpec) OwnerResourceName() string {
+	return s.Cluster
+}
+
+// Parameters returns the parameters for the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingConfig, ok := existing.(containerservice.MaintenanceConfiguration)
+		if !ok {
+			return nil, errors.Errorf("%T is not a containerservice.MaintenanceConfiguration", existing)
+		}
+
+		existingSpec := &MaintenanceConfigurationSpec{}
+		if existingConfig.MaintenanceConfigurationProperties != nil {
+			if existingConfig.TimeInWeek != nil {
+				for _, timeInWeek := range *existingConfig.TimeInWeek {
+					existingSpec.TimeInWeek = append(existingSpec.TimeInWeek, TimeInWeek{
+						Day:       string(timeInWeek.Day),
+						HourSlots: derefInt32Slice(timeInWeek.HourSlots),
+					})
+				}
+			}
+			if existingConfig.NotAllowedTime != nil {
+				for _, timeSpan := range *existingConfig.NotAllowedTime {
+					existingSpec.NotAllowedTime = append(existingSpec.NotAllowedTime, TimeSpan{
+						Start: derefTime(timeSpan.Start),
+						End:   derefTime(timeSpan.End),
+					})
+				}
+			}
+		}
+
+		// time.Time values are compared with their Equal method, so that the location of the times returned by Azure
+		// doesn't matter.
+		if cmp.Equal(s.TimeInWeek, existingSpec.TimeInWeek) && cmp.Equal(s.NotAllowedTime, existingSpec.NotAllowedTime) {
+			// Maintenance configuration is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	properties := &containerservice.MaintenanceConfigurationProperties{}
+	if len(s.TimeInWeek) > 0 {
+		timeInWeeks := make([]containerservice.TimeInWeek, 0, len(s.TimeInWeek))
+		for _, timeInWeek := range s.TimeInWeek {
+			hourSlots := timeInWeek.HourSlots
+			timeInWeeks = append(timeInWeeks, containerservice.TimeInWeek{
+				Day:       containerservice.WeekDay(timeInWeek.Day),
+				HourSlots: &hourSlots,
+			})
+		}
+		properties.TimeInWeek = &timeInWeeks
+	}
+	if len(s.NotAllowedTime) > 0 {
+		timeSpans := make([]containerservice.TimeSpan, 0, len(s.NotAllowedTime))
+		for _, timeSpan := range s.NotAllowedTime {
+			timeSpans = append(timeSpans, containerservice.TimeSpan{
+				Start: &date.Time{Time: timeSpan.Start},
+				End:   &date.Time{Time: timeSpan.End},
+			})
+		}
+		properties.NotAllowedTime = &timeSpans
+	}
+
+	return containerservice.MaintenanceConfiguration{
+		MaintenanceConfigurationProperties: properties,
+	}, nil
+}
+
+func derefInt32Slice(s *[]int32) []int32 {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func derefTime(t *date.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/date"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	start := time.Date(2023, time.December, 24, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, time.December, 27, 0, 0, 0, 0, time.UTC)
+	spec := &MaintenanceConfigurationSpec{
+		Name:          "default",
+		ResourceGroup: "test-rg",
+		Cluster:       "test-cluster",
+		TimeInWeek: []TimeInWeek{
+			{Day: "Saturday", HourSlots: []int32{0, 1, 2}},
+		},
+		NotAllowedTime: []TimeSpan{
+			{Start: start, End: end},
+		},
+	}
+	expected := containerservice.MaintenanceConfiguration{
+		MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+			TimeInWeek: &[]containerservice.TimeInWeek{
+				{Day: containerservice.Saturday, HourSlots: &[]int32{0, 1, 2}},
+			},
+			NotAllowedTime: &[]containerservice.TimeSpan{
+				{Start: &date.Time{Time: start}, End: &date.Time{Time: end}},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expectedError string
+		expect        func(g *WithT, result interface{})
+	}{
+		{
+			name:     "maintenance configuration does not exist",
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expected))
+			},
+		},
+		{
+			name: "maintenance configuration exists, no update needed",
+			existing: containerservice.MaintenanceConfiguration{
+				MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+					TimeInWeek: &[]containerservice.TimeInWeek{
+						{Day: containerservice.Saturday, HourSlots: &[]int32{0, 1, 2}},
+					},
+					NotAllowedTime: &[]containerservice.TimeSpan{
+						{Start: &date.Time{Time: start.In(time.Local)}, End: &date.Time{Time: end.In(time.Local)}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "maintenance configuration exists and an update is needed",
+			existing: containerservice.MaintenanceConfiguration{
+				MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+					TimeInWeek: &[]containerservice.TimeInWeek{
+						{Day: containerservice.Sunday, HourSlots: &[]int32{0, 1, 2}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(expected))
+			},
+		},
+		{
+			name:          "existing is not a maintenance configuration",
+			existing:      struct{}{},
+			expectedError: "struct {} is not a containerservice.MaintenanceConfiguration",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
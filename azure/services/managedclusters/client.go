@@ -20,7 +20,8 @@ import (
 	"context"
 	"encoding/json"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
@@ -33,17 +34,33 @@ import (
 // CredentialGetter is a helper interface for getting managed cluster credentials.
 type CredentialGetter interface {
 	GetCredentials(context.Context, string, string) ([]byte, error)
+	GetUserCredentials(context.Context, string, string) ([]byte, error)
+}
+
+// PowerStateSetter is a helper interface for starting and stopping managed clusters.
+type PowerStateSetter interface {
+	StartAsync(context.Context, string, string) (azureautorest.FutureAPI, error)
+	StopAsync(context.Context, string, string) (azureautorest.FutureAPI, error)
+}
+
+// OIDCIssuerSetter is a helper interface for getting and updating managed clusters with the 2022-03-02-preview AKS
+// API, which has the OIDC issuer and workload identity settings.
+type OIDCIssuerSetter interface {
+	GetPreview(context.Context, string, string) (previewcontainerservice.ManagedCluster, error)
+	CreateOrUpdatePreviewAsync(context.Context, string, string, previewcontainerservice.ManagedCluster) (azureautorest.FutureAPI, error)
 }
 
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
-	managedclusters containerservice.ManagedClustersClient
+	managedclusters        containerservice.ManagedClustersClient
+	previewManagedClusters previewcontainerservice.ManagedClustersClient
 }
 
 // newClient creates a new managed cluster client from an authorizer.
 func newClient(auth azure.Authorizer) *azureClient {
 	return &azureClient{
-		managedclusters: newManagedClustersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		managedclusters:        newManagedClustersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		previewManagedClusters: newPreviewManagedClustersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
 	}
 }
 
@@ -54,6 +71,14 @@ func newManagedClustersClient(subscriptionID string, baseURI string, authorizer
 	return managedClustersClient
 }
 
+// newPreviewManagedClustersClient creates a new managed clusters client for the 2022-03-02-preview AKS API from
+// subscription ID.
+func newPreviewManagedClustersClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) previewcontainerservice.ManagedClustersClient {
+	managedClustersClient := previewcontainerservice.NewManagedClustersClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&managedClustersClient.Client, authorizer)
+	return managedClustersClient
+}
+
 // Get gets a managed cluster.
 func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.Get")
@@ -79,6 +104,72 @@ func (ac *azureClient) GetCredentials(ctx context.Context, resourceGroupName, na
 	return *(*credentialList.Kubeconfigs)[0].Value, nil
 }
 
+// GetUserCredentials fetches the user kubeconfig for a managed cluster. The kubeconfig authenticates with Azure
+// Active Directory through the kubelogin exec plugin, so it can be used when the local accounts are disabled.
+func (ac *azureClient) GetUserCredentials(ctx context.Context, resourceGroupName, name string) ([]byte, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.GetUserCredentials")
+	defer done()
+
+	credentialList, err := ac.managedclusters.ListClusterUserCredentials(ctx, resourceGroupName, name, "", containerservice.Exec)
+	if err != nil {
+		return nil, err
+	}
+
+	if credentialList.Kubeconfigs == nil || len(*credentialList.Kubeconfigs) < 1 {
+		return nil, errors.New("no kubeconfigs available for the managed cluster cluster")
+	}
+
+	return *(*credentialList.Kubeconfigs)[0].Value, nil
+}
+
+// StartAsync starts a stopped managed cluster. It sends a POST request to Azure and if accepted without error, the func
+// will return a Future which can be used to track the ongoing progress of the operation.
+func (ac *azureClient) StartAsync(ctx context.Context, resourceGroupName, name string) (azureautorest.FutureAPI, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.StartAsync")
+	defer done()
+
+	startFuture, err := ac.managedclusters.Start(ctx, resourceGroupName, name)
+	if err != nil {
+		return nil, err
+	}
+	return &startFuture, nil
+}
+
+// StopAsync stops a running managed cluster. It sends a POST request to Azure and if accepted without error, the func
+// will return a Future which can be used to track the ongoing progress of the operation.
+func (ac *azureClient) StopAsync(ctx context.Context, resourceGroupName, name string) (azureautorest.FutureAPI, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.StopAsync")
+	defer done()
+
+	stopFuture, err := ac.managedclusters.Stop(ctx, resourceGroupName, name)
+	if err != nil {
+		return nil, err
+	}
+	return &stopFuture, nil
+}
+
+// GetPreview gets a managed cluster with the 2022-03-02-preview AKS API.
+func (ac *azureClient) GetPreview(ctx context.Context, resourceGroupName, name string) (previewcontainerservice.ManagedCluster, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.GetPreview")
+	defer done()
+
+	return ac.previewManagedClusters.Get(ctx, resourceGroupName, name)
+}
+
+// CreateOrUpdatePreviewAsync updates a managed cluster with the 2022-03-02-preview AKS API. It sends a PUT request to
+// Azure and if accepted without error, the func will return a Future which can be used to track the ongoing progress
+// of the operation.
+func (ac *azureClient) CreateOrUpdatePreviewAsync(ctx context.Context, resourceGroupName, name string, managedCluster previewcontainerservice.ManagedCluster) (azureautorest.FutureAPI, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.CreateOrUpdatePreviewAsync")
+	defer done()
+
+	createOrUpdateFuture, err := ac.previewManagedClusters.CreateOrUpdate(ctx, resourceGroupName, name, managedCluster)
+	if err != nil {
+		return nil, err
+	}
+	return &createOrUpdateFuture, nil
+}
+
 // CreateOrUpdateAsync creates or updates a managed cluster.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
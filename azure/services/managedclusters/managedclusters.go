@@ -18,20 +18,32 @@ package managedclusters
 
 import (
 	"context"
+	"fmt"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-const serviceName = "managedcluster"
+const (
+	serviceName = "managedcluster"
+
+	// powerStateRequeueAfter is how long to wait before checking whether a managed cluster was started or stopped.
+	powerStateRequeueAfter = 20 * time.Second
+
+	// oidcIssuerRequeueAfter is how long to wait before checking whether the OIDC issuer and workload identity
+	// settings of a managed cluster were updated.
+	oidcIssuerRequeueAfter = 20 * time.Second
+)
 
 // ManagedClusterScope defines the scope interface for a managed cluster.
 type ManagedClusterScope interface {
@@ -39,6 +51,9 @@ type ManagedClusterScope interface {
 	azure.AsyncStatusUpdater
 	ManagedClusterSpec() azure.ResourceSpecGetter
 	SetControlPlaneEndpoint(clusterv1.APIEndpoint)
+	SetVersionStatus(string)
+	SetPowerStateStatus(infrav1.PowerState)
+	SetOIDCIssuerURLStatus(*string)
 	MakeEmptyKubeConfigSecret() corev1.Secret
 	GetKubeConfigData() []byte
 	SetKubeConfigData([]byte)
@@ -49,6 +64,8 @@ type Service struct {
 	Scope ManagedClusterScope
 	async.Reconciler
 	CredentialGetter
+	PowerStateSetter
+	OIDCIssuerSetter
 }
 
 // New creates a new service.
@@ -58,6 +75,8 @@ func New(scope ManagedClusterScope) *Service {
 		Scope:            scope,
 		Reconciler:       async.New(scope, client, client),
 		CredentialGetter: client,
+		PowerStateSetter: client,
+		OIDCIssuerSetter: client,
 	}
 }
 
@@ -71,7 +90,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	managedClusterSpec := s.Scope.ManagedClusterSpec()
@@ -91,25 +110,105 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			Port: 443,
 		}
 		s.Scope.SetControlPlaneEndpoint(endpoint)
+		s.Scope.SetVersionStatus(pointer.StringDeref(managedCluster.CurrentKubernetesVersion, ""))
 
-		// Update kubeconfig data
-		// Always fetch credentials in case of rotation
-		kubeConfigData, err := s.GetCredentials(ctx, managedClusterSpec.ResourceGroupName(), managedClusterSpec.ResourceName())
-		if err != nil {
-			return errors.Wrap(err, "failed to get credentials for managed cluster")
+		powerState := infrav1.PowerStateRunning
+		if managedCluster.PowerState != nil && managedCluster.PowerState.Code == containerservice.Stopped {
+			powerState = infrav1.PowerStateStopped
+		}
+		s.Scope.SetPowerStateStatus(powerState)
+
+		// The managed cluster is only started or stopped when its power state is set, so that a cluster stopped
+		// outside of CAPZ isn't started again.
+		if spec, ok := managedClusterSpec.(*ManagedClusterSpec); ok && spec.Stopped != nil && *spec.Stopped != (powerState == infrav1.PowerStateStopped) {
+			resultErr = s.setPowerState(ctx, managedClusterSpec, *spec.Stopped)
+		} else if powerState == infrav1.PowerStateRunning {
+			// Update kubeconfig data
+			// Always fetch credentials in case of rotation
+			// The admin credentials can't be fetched when the local accounts are disabled, use the AAD user ones instead.
+			getCredentials := s.GetCredentials
+			if managedCluster.ManagedClusterProperties != nil && pointer.BoolDeref(managedCluster.DisableLocalAccounts, false) {
+				getCredentials = s.GetUserCredentials
+			}
+			kubeConfigData, err := getCredentials(ctx, managedClusterSpec.ResourceGroupName(), managedClusterSpec.ResourceName())
+			if err != nil {
+				return errors.Wrap(err, "failed to get credentials for managed cluster")
+			}
+			s.Scope.SetKubeConfigData(kubeConfigData)
+
+			if ok && spec.hasOIDCIssuerSettings() {
+				resultErr = s.reconcileOIDCIssuer(ctx, spec)
+			}
 		}
-		s.Scope.SetKubeConfigData(kubeConfigData)
 	}
 	s.Scope.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, resultErr)
 	return resultErr
 }
 
+// setPowerState starts or stops the managed cluster. The operation isn't tracked as a long-running operation, as the
+// provisioning state of the managed cluster is Starting or Stopping until it completes, which makes the next
+// reconciliations wait for it before updating the managed cluster.
+func (s *Service) setPowerState(ctx context.Context, spec azure.ResourceSpecGetter, stopped bool) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.setPowerState")
+	defer done()
+
+	setPowerState, operation := s.StartAsync, "start"
+	if stopped {
+		setPowerState, operation = s.StopAsync, "stop"
+	}
+	log.V(2).Info(fmt.Sprintf("calling %s on managed cluster", operation), "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+	sdkFuture, err := setPowerState(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	if err != nil {
+		return errors.Wrapf(err, "failed to %s managed cluster %s/%s", operation, spec.ResourceGroupName(), spec.ResourceName())
+	}
+	future, err := converters.SDKToFuture(sdkFuture, infrav1.PutFuture, serviceName, spec.ResourceName(), spec.ResourceGroupName())
+	if err != nil {
+		return errors.Wrapf(err, "failed to %s managed cluster %s/%s", operation, spec.ResourceGroupName(), spec.ResourceName())
+	}
+	return azure.WithTransientError(azure.NewOperationNotDoneError(future), powerStateRequeueAfter)
+}
+
+// reconcileOIDCIssuer updates the OIDC issuer and workload identity settings of the managed cluster, and sets the OIDC
+// issuer URL status. They are only in the 2022-03-02-preview AKS API, so they are reconciled apart from the rest of the
+// managed cluster, with a PUT of the managed cluster returned by that API. AKS keeps the properties missing from an
+// older API version on a PUT. As for setPowerState, the provisioning state of the managed cluster makes the next
+// reconciliations wait for the update to complete.
+func (s *Service) reconcileOIDCIssuer(ctx context.Context, spec *ManagedClusterSpec) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.reconcileOIDCIssuer")
+	defer done()
+
+	existing, err := s.GetPreview(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get managed cluster %s/%s", spec.ResourceGroupName(), spec.ResourceName())
+	}
+	var issuerURL *string
+	if existing.ManagedClusterProperties != nil && existing.OidcIssuerProfile != nil {
+		issuerURL = existing.OidcIssuerProfile.IssuerURL
+	}
+	s.Scope.SetOIDCIssuerURLStatus(issuerURL)
+
+	parameters := spec.oidcIssuerParameters(existing)
+	if parameters == nil {
+		return nil
+	}
+	log.V(2).Info("updating OIDC issuer and workload identity of managed cluster", "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+	sdkFuture, err := s.CreateOrUpdatePreviewAsync(ctx, spec.ResourceGroupName(), spec.ResourceName(), *parameters)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update OIDC issuer of managed cluster %s/%s", spec.ResourceGroupName(), spec.ResourceName())
+	}
+	future, err := converters.SDKToFuture(sdkFuture, infrav1.PutFuture, serviceName, spec.ResourceName(), spec.ResourceGroupName())
+	if err != nil {
+		return errors.Wrapf(err, "failed to update OIDC issuer of managed cluster %s/%s", spec.ResourceGroupName(), spec.ResourceName())
+	}
+	return azure.WithTransientError(azure.NewOperationNotDoneError(future), oidcIssuerRequeueAfter)
+}
+
 // Delete deletes the managed cluster.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	managedClusterSpec := s.Scope.ManagedClusterSpec()
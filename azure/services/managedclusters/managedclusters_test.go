@@ -21,7 +21,9 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
@@ -63,19 +65,45 @@ func TestReconcile(t *testing.T) {
 				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
 					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
-						Fqdn:              pointer.String("my-managedcluster-fqdn"),
-						ProvisioningState: pointer.String("Succeeded"),
+						Fqdn:                     pointer.String("my-managedcluster-fqdn"),
+						ProvisioningState:        pointer.String("Succeeded"),
+						CurrentKubernetesVersion: pointer.String("1.25.6"),
 					},
 				}, nil)
 				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
+				s.SetVersionStatus("1.25.6")
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
 				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
 				s.SetKubeConfigData([]byte("credentials"))
 				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "create managed cluster with local accounts disabled fetches the user credentials",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec().Return(fakeManagedClusterSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
+					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+						Fqdn:                 pointer.String("my-managedcluster-fqdn"),
+						ProvisioningState:    pointer.String("Succeeded"),
+						DisableLocalAccounts: pointer.Bool(true),
+					},
+				}, nil)
+				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+					Host: "my-managedcluster-fqdn",
+					Port: 443,
+				})
+				s.SetVersionStatus("")
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
+				m.GetUserCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("user-credentials"), nil)
+				s.SetKubeConfigData([]byte("user-credentials"))
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
 		{
 			name:          "fail to get managed cluster credentials",
 			expectedError: "failed to get credentials for managed cluster: internal server error",
@@ -91,6 +119,8 @@ func TestReconcile(t *testing.T) {
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
+				s.SetVersionStatus("")
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
 				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte(""), errors.New("internal server error"))
 			},
 		},
@@ -126,6 +156,233 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcilePowerState(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *ManagedClusterSpec
+		powerState    containerservice.Code
+		expectedError string
+		expect        func(p *mock_managedclusters.MockPowerStateSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder)
+	}{
+		{
+			name:          "stop running managed cluster",
+			spec:          &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", Stopped: pointer.Bool(true)},
+			powerState:    containerservice.Running,
+			expectedError: "operation type PUT on Azure resource my-rg/my-managedcluster is not done. Object will be requeued after 20s",
+			expect: func(p *mock_managedclusters.MockPowerStateSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
+				p.StopAsync(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(&azureautorest.Future{}, nil)
+			},
+		},
+		{
+			name:          "start stopped managed cluster",
+			spec:          &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", Stopped: pointer.Bool(false)},
+			powerState:    containerservice.Stopped,
+			expectedError: "operation type PUT on Azure resource my-rg/my-managedcluster is not done. Object will be requeued after 20s",
+			expect: func(p *mock_managedclusters.MockPowerStateSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.SetPowerStateStatus(infrav1.PowerStateStopped)
+				p.StartAsync(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(&azureautorest.Future{}, nil)
+			},
+		},
+		{
+			name:          "fail to stop managed cluster",
+			spec:          &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", Stopped: pointer.Bool(true)},
+			powerState:    containerservice.Running,
+			expectedError: "failed to stop managed cluster my-rg/my-managedcluster: internal server error",
+			expect: func(p *mock_managedclusters.MockPowerStateSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.SetPowerStateStatus(infrav1.PowerStateRunning)
+				p.StopAsync(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(nil, errors.New("internal server error"))
+			},
+		},
+		{
+			name:       "stopped managed cluster is left stopped",
+			spec:       &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", Stopped: pointer.Bool(true)},
+			powerState: containerservice.Stopped,
+			expect: func(p *mock_managedclusters.MockPowerStateSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.SetPowerStateStatus(infrav1.PowerStateStopped)
+			},
+		},
+		{
+			name:       "managed cluster stopped without a power state is left stopped",
+			spec:       &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg"},
+			powerState: containerservice.Stopped,
+			expect: func(p *mock_managedclusters.MockPowerStateSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.SetPowerStateStatus(infrav1.PowerStateStopped)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			powerStateSetterMock := mock_managedclusters.NewMockPowerStateSetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			scopeMock.EXPECT().ManagedClusterSpec().Return(tc.spec)
+			reconcilerMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), tc.spec, serviceName).Return(containerservice.ManagedCluster{
+				ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+					Fqdn:                     pointer.String("my-managedcluster-fqdn"),
+					ProvisioningState:        pointer.String("Succeeded"),
+					CurrentKubernetesVersion: pointer.String("1.25.6"),
+					PowerState:               &containerservice.PowerState{Code: tc.powerState},
+				},
+			}, nil)
+			scopeMock.EXPECT().SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+				Host: "my-managedcluster-fqdn",
+				Port: 443,
+			})
+			scopeMock.EXPECT().SetVersionStatus("1.25.6")
+			scopeMock.EXPECT().UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, gomock.Any())
+			tc.expect(powerStateSetterMock.EXPECT(), scopeMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				PowerStateSetter: powerStateSetterMock,
+				Reconciler:       reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileOIDCIssuer(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *ManagedClusterSpec
+		expectedError string
+		expect        func(o *mock_managedclusters.MockOIDCIssuerSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder)
+	}{
+		{
+			name:          "enable OIDC issuer",
+			spec:          &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)}},
+			expectedError: "operation type PUT on Azure resource my-rg/my-managedcluster is not done. Object will be requeued after 20s",
+			expect: func(o *mock_managedclusters.MockOIDCIssuerSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				o.GetPreview(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(previewcontainerservice.ManagedCluster{
+					ManagedClusterProperties: &previewcontainerservice.ManagedClusterProperties{
+						ProvisioningState: pointer.String("Succeeded"),
+					},
+				}, nil)
+				s.SetOIDCIssuerURLStatus(nil)
+				o.CreateOrUpdatePreviewAsync(gomockinternal.AContext(), "my-rg", "my-managedcluster", previewcontainerservice.ManagedCluster{
+					ManagedClusterProperties: &previewcontainerservice.ManagedClusterProperties{
+						ProvisioningState: pointer.String("Succeeded"),
+						OidcIssuerProfile: &previewcontainerservice.ManagedClusterOIDCIssuerProfile{Enabled: pointer.Bool(true)},
+					},
+				}).Return(&azureautorest.Future{}, nil)
+			},
+		},
+		{
+			name: "enable workload identity",
+			spec: &ManagedClusterSpec{
+				Name:              "my-managedcluster",
+				ResourceGroup:     "my-rg",
+				OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)},
+				SecurityProfile:   &SecurityProfile{WorkloadIdentity: &WorkloadIdentity{Enabled: true}},
+			},
+			expectedError: "operation type PUT on Azure resource my-rg/my-managedcluster is not done. Object will be requeued after 20s",
+			expect: func(o *mock_managedclusters.MockOIDCIssuerSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				o.GetPreview(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(previewcontainerservice.ManagedCluster{
+					ManagedClusterProperties: &previewcontainerservice.ManagedClusterProperties{
+						ProvisioningState: pointer.String("Succeeded"),
+						OidcIssuerProfile: &previewcontainerservice.ManagedClusterOIDCIssuerProfile{Enabled: pointer.Bool(true), IssuerURL: pointer.String("https://oidc.example.com/")},
+					},
+				}, nil)
+				s.SetOIDCIssuerURLStatus(pointer.String("https://oidc.example.com/"))
+				o.CreateOrUpdatePreviewAsync(gomockinternal.AContext(), "my-rg", "my-managedcluster", previewcontainerservice.ManagedCluster{
+					ManagedClusterProperties: &previewcontainerservice.ManagedClusterProperties{
+						ProvisioningState: pointer.String("Succeeded"),
+						OidcIssuerProfile: &previewcontainerservice.ManagedClusterOIDCIssuerProfile{Enabled: pointer.Bool(true), IssuerURL: pointer.String("https://oidc.example.com/")},
+						SecurityProfile: &previewcontainerservice.ManagedClusterSecurityProfile{
+							WorkloadIdentity: &previewcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{Enabled: pointer.Bool(true)},
+						},
+					},
+				}).Return(&azureautorest.Future{}, nil)
+			},
+		},
+		{
+			name: "OIDC issuer already enabled",
+			spec: &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)}},
+			expect: func(o *mock_managedclusters.MockOIDCIssuerSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				o.GetPreview(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(previewcontainerservice.ManagedCluster{
+					ManagedClusterProperties: &previewcontainerservice.ManagedClusterProperties{
+						ProvisioningState: pointer.String("Succeeded"),
+						OidcIssuerProfile: &previewcontainerservice.ManagedClusterOIDCIssuerProfile{Enabled: pointer.Bool(true), IssuerURL: pointer.String("https://oidc.example.com/")},
+					},
+				}, nil)
+				s.SetOIDCIssuerURLStatus(pointer.String("https://oidc.example.com/"))
+			},
+		},
+		{
+			name:          "fail to get managed cluster with the preview API",
+			spec:          &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg", OIDCIssuerProfile: &OIDCIssuerProfile{Enabled: pointer.Bool(true)}},
+			expectedError: "failed to get managed cluster my-rg/my-managedcluster: internal server error",
+			expect: func(o *mock_managedclusters.MockOIDCIssuerSetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				o.GetPreview(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(previewcontainerservice.ManagedCluster{}, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			credsGetterMock := mock_managedclusters.NewMockCredentialGetter(mockCtrl)
+			oidcIssuerSetterMock := mock_managedclusters.NewMockOIDCIssuerSetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			scopeMock.EXPECT().ManagedClusterSpec().Return(tc.spec)
+			reconcilerMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), tc.spec, serviceName).Return(containerservice.ManagedCluster{
+				ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+					Fqdn:                     pointer.String("my-managedcluster-fqdn"),
+					ProvisioningState:        pointer.String("Succeeded"),
+					CurrentKubernetesVersion: pointer.String("1.25.6"),
+				},
+			}, nil)
+			scopeMock.EXPECT().SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+				Host: "my-managedcluster-fqdn",
+				Port: 443,
+			})
+			scopeMock.EXPECT().SetVersionStatus("1.25.6")
+			scopeMock.EXPECT().SetPowerStateStatus(infrav1.PowerStateRunning)
+			credsGetterMock.EXPECT().GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+			scopeMock.EXPECT().SetKubeConfigData([]byte("credentials"))
+			scopeMock.EXPECT().UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, gomock.Any())
+			tc.expect(oidcIssuerSetterMock.EXPECT(), scopeMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				CredentialGetter: credsGetterMock,
+				OIDCIssuerSetter: oidcIssuerSetterMock,
+				Reconciler:       reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testcases := []struct {
 		name          string
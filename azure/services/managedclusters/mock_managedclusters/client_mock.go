@@ -24,6 +24,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	containerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	azure "github.com/Azure/go-autorest/autorest/azure"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -64,3 +66,124 @@ func (mr *MockCredentialGetterMockRecorder) GetCredentials(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockCredentialGetter)(nil).GetCredentials), arg0, arg1, arg2)
 }
+
+// GetUserCredentials mocks base method.
+func (m *MockCredentialGetter) GetUserCredentials(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredentials", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredentials indicates an expected call of GetUserCredentials.
+func (mr *MockCredentialGetterMockRecorder) GetUserCredentials(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredentials", reflect.TypeOf((*MockCredentialGetter)(nil).GetUserCredentials), arg0, arg1, arg2)
+}
+
+// MockPowerStateSetter is a mock of PowerStateSetter interface.
+type MockPowerStateSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPowerStateSetterMockRecorder
+}
+
+// MockPowerStateSetterMockRecorder is the mock recorder for MockPowerStateSetter.
+type MockPowerStateSetterMockRecorder struct {
+	mock *MockPowerStateSetter
+}
+
+// NewMockPowerStateSetter creates a new mock instance.
+func NewMockPowerStateSetter(ctrl *gomock.Controller) *MockPowerStateSetter {
+	mock := &MockPowerStateSetter{ctrl: ctrl}
+	mock.recorder = &MockPowerStateSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPowerStateSetter) EXPECT() *MockPowerStateSetterMockRecorder {
+	return m.recorder
+}
+
+// StartAsync mocks base method.
+func (m *MockPowerStateSetter) StartAsync(arg0 context.Context, arg1, arg2 string) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAsync", arg0, arg1, arg2)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAsync indicates an expected call of StartAsync.
+func (mr *MockPowerStateSetterMockRecorder) StartAsync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAsync", reflect.TypeOf((*MockPowerStateSetter)(nil).StartAsync), arg0, arg1, arg2)
+}
+
+// StopAsync mocks base method.
+func (m *MockPowerStateSetter) StopAsync(arg0 context.Context, arg1, arg2 string) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopAsync", arg0, arg1, arg2)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopAsync indicates an expected call of StopAsync.
+func (mr *MockPowerStateSetterMockRecorder) StopAsync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAsync", reflect.TypeOf((*MockPowerStateSetter)(nil).StopAsync), arg0, arg1, arg2)
+}
+
+// MockOIDCIssuerSetter is a mock of OIDCIssuerSetter interface.
+type MockOIDCIssuerSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCIssuerSetterMockRecorder
+}
+
+// MockOIDCIssuerSetterMockRecorder is the mock recorder for MockOIDCIssuerSetter.
+type MockOIDCIssuerSetterMockRecorder struct {
+	mock *MockOIDCIssuerSetter
+}
+
+// NewMockOIDCIssuerSetter creates a new mock instance.
+func NewMockOIDCIssuerSetter(ctrl *gomock.Controller) *MockOIDCIssuerSetter {
+	mock := &MockOIDCIssuerSetter{ctrl: ctrl}
+	mock.recorder = &MockOIDCIssuerSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCIssuerSetter) EXPECT() *MockOIDCIssuerSetterMockRecorder {
+	return m.recorder
+}
+
+// CreateOrUpdatePreviewAsync mocks base method.
+func (m *MockOIDCIssuerSetter) CreateOrUpdatePreviewAsync(arg0 context.Context, arg1, arg2 string, arg3 containerservice.ManagedCluster) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePreviewAsync", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePreviewAsync indicates an expected call of CreateOrUpdatePreviewAsync.
+func (mr *MockOIDCIssuerSetterMockRecorder) CreateOrUpdatePreviewAsync(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePreviewAsync", reflect.TypeOf((*MockOIDCIssuerSetter)(nil).CreateOrUpdatePreviewAsync), arg0, arg1, arg2, arg3)
+}
+
+// GetPreview mocks base method.
+func (m *MockOIDCIssuerSetter) GetPreview(arg0 context.Context, arg1, arg2 string) (containerservice.ManagedCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreview", arg0, arg1, arg2)
+	ret0, _ := ret[0].(containerservice.ManagedCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreview indicates an expected call of GetPreview.
+func (mr *MockOIDCIssuerSetterMockRecorder) GetPreview(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreview", reflect.TypeOf((*MockOIDCIssuerSetter)(nil).GetPreview), arg0, arg1, arg2)
+}
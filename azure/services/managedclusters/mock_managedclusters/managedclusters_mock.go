@@ -242,6 +242,42 @@ func (mr *MockManagedClusterScopeMockRecorder) SetLongRunningOperationState(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockManagedClusterScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetOIDCIssuerURLStatus mocks base method.
+func (m *MockManagedClusterScope) SetOIDCIssuerURLStatus(arg0 *string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOIDCIssuerURLStatus", arg0)
+}
+
+// SetOIDCIssuerURLStatus indicates an expected call of SetOIDCIssuerURLStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetOIDCIssuerURLStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOIDCIssuerURLStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetOIDCIssuerURLStatus), arg0)
+}
+
+// SetPowerStateStatus mocks base method.
+func (m *MockManagedClusterScope) SetPowerStateStatus(arg0 v1beta1.PowerState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPowerStateStatus", arg0)
+}
+
+// SetPowerStateStatus indicates an expected call of SetPowerStateStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetPowerStateStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPowerStateStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetPowerStateStatus), arg0)
+}
+
+// SetVersionStatus mocks base method.
+func (m *MockManagedClusterScope) SetVersionStatus(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVersionStatus", arg0)
+}
+
+// SetVersionStatus indicates an expected call of SetVersionStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetVersionStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVersionStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetVersionStatus), arg0)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockManagedClusterScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -21,11 +21,15 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
+	previewcontainerservice "github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -92,12 +96,18 @@ type ManagedClusterSpec struct {
 	// AADProfile is Azure Active Directory configuration to integrate with AKS, for aad authentication.
 	AADProfile *AADProfile
 
+	// DisableLocalAccounts defines whether to disable the local accounts of the cluster.
+	DisableLocalAccounts *bool
+
 	// SKU is the SKU of the AKS to be provisioned.
 	SKU *SKU
 
 	// LoadBalancerProfile is the profile of the cluster load balancer.
 	LoadBalancerProfile *LoadBalancerProfile
 
+	// NATGatewayProfile is the profile of the managed NAT gateway of the cluster.
+	NATGatewayProfile *NATGatewayProfile
+
 	// APIServerAccessProfile is the access profile for AKS API server.
 	APIServerAccessProfile *APIServerAccessProfile
 
@@ -106,6 +116,61 @@ type ManagedClusterSpec struct {
 
 	// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler when enabled.
 	AutoScalerProfile *AutoScalerProfile
+
+	// SecurityProfile is the security profile of the managed cluster.
+	SecurityProfile *SecurityProfile
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the managed cluster.
+	OIDCIssuerProfile *OIDCIssuerProfile
+
+	// AutoUpgradeProfile is the auto-upgrade configuration of the managed cluster.
+	AutoUpgradeProfile *AutoUpgradeProfile
+
+	// Stopped is whether the managed cluster should be stopped. The managed cluster is neither started nor stopped
+	// when it's nil.
+	Stopped *bool
+
+	// HTTPProxyConfig is the HTTP proxy configuration of the managed cluster.
+	HTTPProxyConfig *HTTPProxyConfig
+
+	// UserAssignedIdentityResourceID is the resource ID of the user-assigned identity of the control plane. A
+	// system-assigned identity is used when it is empty.
+	UserAssignedIdentityResourceID string
+}
+
+// HTTPProxyConfig is the HTTP proxy configuration of a managed cluster.
+type HTTPProxyConfig struct {
+	// HTTPProxy is the HTTP proxy server endpoint to use.
+	HTTPProxy *string
+
+	// HTTPSProxy is the HTTPS proxy server endpoint to use.
+	HTTPSProxy *string
+
+	// NoProxy is the list of endpoints that should not go through the proxy.
+	NoProxy []string
+
+	// TrustedCA is the base64 encoded certificate of the alternative CA to trust when connecting to the proxy servers.
+	TrustedCA *string
+}
+
+// AutoUpgradeProfile is the auto-upgrade configuration of a managed cluster.
+type AutoUpgradeProfile struct {
+	// UpgradeChannel is the channel used to automatically upgrade the cluster.
+	UpgradeChannel *string
+	// TODO: add NodeOSUpgradeChannel once CAPZ uses an AKS API version that has it.
+}
+
+// upgradesKubernetesVersion returns whether the auto-upgrade channel upgrades the Kubernetes version of the cluster.
+func (p *AutoUpgradeProfile) upgradesKubernetesVersion() bool {
+	if p == nil || p.UpgradeChannel == nil {
+		return false
+	}
+	switch containerservice.UpgradeChannel(*p.UpgradeChannel) {
+	case containerservice.UpgradeChannelRapid, containerservice.UpgradeChannelStable, containerservice.UpgradeChannelPatch:
+		return true
+	default:
+		return false
+	}
 }
 
 // AADProfile is Azure Active Directory configuration to integrate with AKS, for aad authentication.
@@ -156,6 +221,15 @@ type LoadBalancerProfile struct {
 	IdleTimeoutInMinutes *int32
 }
 
+// NATGatewayProfile is the profile of the managed NAT gateway of the cluster.
+type NATGatewayProfile struct {
+	// ManagedOutboundIPs is the desired number of outbound IPs created and managed by AKS for the NAT gateway.
+	ManagedOutboundIPs *int32
+
+	// IdleTimeoutInMinutes is the desired outbound flow idle timeout in minutes.
+	IdleTimeoutInMinutes *int32
+}
+
 // APIServerAccessProfile is the access profile for AKS API server.
 type APIServerAccessProfile struct {
 	// AuthorizedIPRanges are the authorized IP Ranges to kubernetes API server.
@@ -164,10 +238,55 @@ type APIServerAccessProfile struct {
 	EnablePrivateCluster *bool
 	// PrivateDNSZone is the private dns zone for private clusters.
 	PrivateDNSZone *string
+	// FQDNSubdomain is the subdomain of the FQDN of a private cluster using an existing private DNS zone.
+	FQDNSubdomain *string
 	// EnablePrivateClusterPublicFQDN defines whether to create additional public FQDN for private cluster or not.
 	EnablePrivateClusterPublicFQDN *bool
 }
 
+// SecurityProfile is the security profile of a managed cluster.
+type SecurityProfile struct {
+	// AzureKeyVaultKms is the Azure Key Vault key management service settings, used to encrypt the secrets in etcd.
+	AzureKeyVaultKms *AzureKeyVaultKms
+	// Defender is the Microsoft Defender settings.
+	Defender *Defender
+	// WorkloadIdentity is the workload identity settings.
+	WorkloadIdentity *WorkloadIdentity
+	// TODO: add Image Cleaner (securityProfile.imageCleaner) once CAPZ uses an AKS API version that has it.
+}
+
+// Defender is the Microsoft Defender settings of a managed cluster.
+type Defender struct {
+	// LogAnalyticsWorkspaceResourceID is the resource ID of the Log Analytics workspace associated with Microsoft Defender.
+	LogAnalyticsWorkspaceResourceID string
+	// SecurityMonitoringEnabled defines whether to enable Microsoft Defender threat detection.
+	SecurityMonitoringEnabled bool
+}
+
+// WorkloadIdentity is the workload identity settings of a managed cluster.
+type WorkloadIdentity struct {
+	// Enabled defines whether to enable workload identity.
+	Enabled bool
+}
+
+// OIDCIssuerProfile is the OIDC issuer profile of a managed cluster.
+type OIDCIssuerProfile struct {
+	// Enabled defines whether to enable the OIDC issuer.
+	Enabled *bool
+}
+
+// AzureKeyVaultKms is the Azure Key Vault key management service settings of a managed cluster.
+type AzureKeyVaultKms struct {
+	// Enabled defines whether to enable Azure Key Vault key management service.
+	Enabled bool
+	// KeyID is the identifier of the Azure Key Vault key.
+	KeyID string
+	// KeyVaultNetworkAccess is the network access of the key vault, Public or Private.
+	KeyVaultNetworkAccess *string
+	// KeyVaultResourceID is the resource ID of the key vault, required when its network access is Private.
+	KeyVaultResourceID *string
+}
+
 // AutoScalerProfile parameters to be applied to the cluster-autoscaler when enabled.
 type AutoScalerProfile struct {
 	// BalanceSimilarNodeGroups - Valid values are 'true' and 'false'
@@ -228,6 +347,28 @@ func (s *ManagedClusterSpec) CustomHeaders() map[string]string {
 	return s.Headers
 }
 
+// defaultAutoScalerProfile is the autoscaler profile of a managed cluster created without one. Values are from
+// https://learn.microsoft.com/en-us/azure/aks/cluster-autoscaler#using-the-autoscaler-profile.
+var defaultAutoScalerProfile = AutoScalerProfile{
+	BalanceSimilarNodeGroups:      pointer.String("false"),
+	Expander:                      pointer.String(string(containerservice.Random)),
+	MaxEmptyBulkDelete:            pointer.String("10"),
+	MaxGracefulTerminationSec:     pointer.String("600"),
+	MaxNodeProvisionTime:          pointer.String("15m"),
+	MaxTotalUnreadyPercentage:     pointer.String("45"),
+	NewPodScaleUpDelay:            pointer.String("0s"),
+	OkTotalUnreadyCount:           pointer.String("3"),
+	ScanInterval:                  pointer.String("10s"),
+	ScaleDownDelayAfterAdd:        pointer.String("10m"),
+	ScaleDownDelayAfterDelete:     pointer.String("10s"),
+	ScaleDownDelayAfterFailure:    pointer.String("3m"),
+	ScaleDownUnneededTime:         pointer.String("10m"),
+	ScaleDownUnreadyTime:          pointer.String("20m"),
+	ScaleDownUtilizationThreshold: pointer.String("0.5"),
+	SkipNodesWithLocalStorage:     pointer.String("false"),
+	SkipNodesWithSystemPods:       pointer.String("true"),
+}
+
 // buildAutoScalerProfile builds the AutoScalerProfile for the ManagedClusterProperties.
 func buildAutoScalerProfile(autoScalerProfile *AutoScalerProfile) *containerservice.ManagedClusterPropertiesAutoScalerProfile {
 	if autoScalerProfile == nil {
@@ -338,6 +479,10 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 		}
 	}
 
+	if s.DisableLocalAccounts != nil {
+		managedCluster.DisableLocalAccounts = s.DisableLocalAccounts
+	}
+
 	for i := range s.AddonProfiles {
 		if managedCluster.AddonProfiles == nil {
 			managedCluster.AddonProfiles = map[string]*containerservice.ManagedClusterAddonProfile{}
@@ -380,21 +525,97 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 		}
 	}
 
+	if s.NATGatewayProfile != nil {
+		managedCluster.NetworkProfile.NatGatewayProfile = &containerservice.ManagedClusterNATGatewayProfile{
+			IdleTimeoutInMinutes: s.NATGatewayProfile.IdleTimeoutInMinutes,
+		}
+		if s.NATGatewayProfile.ManagedOutboundIPs != nil {
+			managedCluster.NetworkProfile.NatGatewayProfile.ManagedOutboundIPProfile = &containerservice.ManagedClusterManagedOutboundIPProfile{Count: s.NATGatewayProfile.ManagedOutboundIPs}
+		}
+	}
+
 	if s.APIServerAccessProfile != nil {
+		// An empty list rather than null is sent when there are no authorized IP ranges, so that removing all of
+		// them from the spec removes them from the managed cluster too.
+		authorizedIPRanges := make([]string, len(s.APIServerAccessProfile.AuthorizedIPRanges))
+		copy(authorizedIPRanges, s.APIServerAccessProfile.AuthorizedIPRanges)
 		managedCluster.APIServerAccessProfile = &containerservice.ManagedClusterAPIServerAccessProfile{
-			AuthorizedIPRanges:             &s.APIServerAccessProfile.AuthorizedIPRanges,
+			AuthorizedIPRanges:             &authorizedIPRanges,
 			EnablePrivateCluster:           s.APIServerAccessProfile.EnablePrivateCluster,
 			PrivateDNSZone:                 s.APIServerAccessProfile.PrivateDNSZone,
 			EnablePrivateClusterPublicFQDN: s.APIServerAccessProfile.EnablePrivateClusterPublicFQDN,
 		}
 	}
 
+	if s.APIServerAccessProfile != nil && s.APIServerAccessProfile.FQDNSubdomain != nil {
+		// The DNS prefix and the FQDN subdomain are mutually exclusive.
+		managedCluster.DNSPrefix = nil
+		managedCluster.FqdnSubdomain = s.APIServerAccessProfile.FQDNSubdomain
+	}
+
+	if s.UserAssignedIdentityResourceID != "" {
+		managedCluster.Identity = &containerservice.ManagedClusterIdentity{
+			Type: containerservice.ResourceIdentityTypeUserAssigned,
+			UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+				s.UserAssignedIdentityResourceID: {},
+			},
+		}
+	}
+
 	if s.OutboundType != nil {
 		managedCluster.NetworkProfile.OutboundType = containerservice.OutboundType(*s.OutboundType)
 	}
 
 	managedCluster.AutoScalerProfile = buildAutoScalerProfile(s.AutoScalerProfile)
 
+	if s.AutoUpgradeProfile != nil && s.AutoUpgradeProfile.UpgradeChannel != nil {
+		managedCluster.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{
+			UpgradeChannel: containerservice.UpgradeChannel(*s.AutoUpgradeProfile.UpgradeChannel),
+		}
+	}
+
+	if s.SecurityProfile != nil && s.SecurityProfile.Defender != nil {
+		defender := &containerservice.ManagedClusterSecurityProfileDefender{
+			SecurityMonitoring: &containerservice.ManagedClusterSecurityProfileDefenderSecurityMonitoring{
+				Enabled: pointer.Bool(s.SecurityProfile.Defender.SecurityMonitoringEnabled),
+			},
+		}
+		if s.SecurityProfile.Defender.LogAnalyticsWorkspaceResourceID != "" {
+			defender.LogAnalyticsWorkspaceResourceID = pointer.String(s.SecurityProfile.Defender.LogAnalyticsWorkspaceResourceID)
+		}
+		managedCluster.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{
+			Defender: defender,
+		}
+	}
+
+	if s.SecurityProfile != nil && s.SecurityProfile.AzureKeyVaultKms != nil {
+		kms := &containerservice.AzureKeyVaultKms{
+			Enabled:            pointer.Bool(s.SecurityProfile.AzureKeyVaultKms.Enabled),
+			KeyVaultResourceID: s.SecurityProfile.AzureKeyVaultKms.KeyVaultResourceID,
+		}
+		if s.SecurityProfile.AzureKeyVaultKms.KeyID != "" {
+			kms.KeyID = pointer.String(s.SecurityProfile.AzureKeyVaultKms.KeyID)
+		}
+		if s.SecurityProfile.AzureKeyVaultKms.KeyVaultNetworkAccess != nil {
+			kms.KeyVaultNetworkAccess = containerservice.KeyVaultNetworkAccessTypes(*s.SecurityProfile.AzureKeyVaultKms.KeyVaultNetworkAccess)
+		}
+		if managedCluster.SecurityProfile == nil {
+			managedCluster.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{}
+		}
+		managedCluster.SecurityProfile.AzureKeyVaultKms = kms
+	}
+
+	if s.HTTPProxyConfig != nil {
+		managedCluster.HTTPProxyConfig = &containerservice.ManagedClusterHTTPProxyConfig{
+			HTTPProxy:  s.HTTPProxyConfig.HTTPProxy,
+			HTTPSProxy: s.HTTPProxyConfig.HTTPSProxy,
+			TrustedCa:  s.HTTPProxyConfig.TrustedCA,
+		}
+		if len(s.HTTPProxyConfig.NoProxy) > 0 {
+			managedCluster.HTTPProxyConfig.NoProxy = &s.HTTPProxyConfig.NoProxy
+		}
+	}
+
 	if existing != nil {
 		existingMC, ok := existing.(containerservice.ManagedCluster)
 		if !ok {
@@ -405,6 +626,12 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 			return nil, azure.WithTransientError(errors.Errorf("Unable to update existing managed cluster in non-terminal state. Managed cluster must be in one of the following provisioning states: Canceled, Failed, or Succeeded. Actual state: %s", ps), 20*time.Second)
 		}
 
+		// A stopped managed cluster can't be updated, the changes are applied once it is started again.
+		if existingMC.PowerState != nil && existingMC.PowerState.Code == containerservice.Stopped {
+			log.V(4).Info("managed cluster is stopped, skipping update")
+			return nil, nil
+		}
+
 		// Normalize the LoadBalancerProfile so the diff below doesn't get thrown off by AKS added properties.
 		if managedCluster.NetworkProfile.LoadBalancerProfile == nil {
 			// If our LoadBalancerProfile generated by the spec is nil, then don't worry about what AKS has added.
@@ -415,10 +642,37 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 			existingMC.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = nil
 		}
 
+		// Normalize the NatGatewayProfile the same way. AKS also sets defaults for the settings left out of the spec,
+		// which mustn't trigger an update either.
+		if desired := managedCluster.NetworkProfile.NatGatewayProfile; desired == nil {
+			existingMC.NetworkProfile.NatGatewayProfile = nil
+		} else if existing := existingMC.NetworkProfile.NatGatewayProfile; existing != nil {
+			existing.EffectiveOutboundIPs = nil
+			if desired.ManagedOutboundIPProfile == nil {
+				existing.ManagedOutboundIPProfile = nil
+			}
+			if desired.IdleTimeoutInMinutes == nil {
+				existing.IdleTimeoutInMinutes = nil
+			}
+		}
+
+		// AKS keeps the autoscaler profile last applied when it is left out of the request, so reset it to the
+		// defaults when it was removed from the spec. Nothing is updated if the cluster already uses the defaults.
+		if managedCluster.AutoScalerProfile == nil && existingMC.AutoScalerProfile != nil {
+			managedCluster.AutoScalerProfile = buildAutoScalerProfile(&defaultAutoScalerProfile)
+		}
+
 		// Avoid changing agent pool profiles through AMCP and just use the existing agent pool profiles
 		// AgentPool changes are managed through AMMP.
 		managedCluster.AgentPoolProfiles = existingMC.AgentPoolProfiles
 
+		// The version reported by AKS is authoritative when the auto-upgrade channel upgraded the cluster past the
+		// version of the spec, as AKS doesn't allow downgrades.
+		if s.AutoUpgradeProfile.upgradesKubernetesVersion() && existingMC.KubernetesVersion != nil &&
+			semver.Compare("v"+strings.TrimPrefix(*existingMC.KubernetesVersion, "v"), "v"+strings.TrimPrefix(s.Version, "v")) > 0 {
+			managedCluster.KubernetesVersion = existingMC.KubernetesVersion
+		}
+
 		diff := computeDiffOfNormalizedClusters(managedCluster, existingMC)
 		if diff == "" {
 			log.V(4).Info("no changes found between user-updated spec and existing spec")
@@ -450,6 +704,46 @@ func (s *ManagedClusterSpec) Parameters(ctx context.Context, existing interface{
 	return managedCluster, nil
 }
 
+// hasOIDCIssuerSettings returns whether the spec sets the OIDC issuer or workload identity, which are only in the
+// 2022-03-02-preview AKS API.
+func (s *ManagedClusterSpec) hasOIDCIssuerSettings() bool {
+	return (s.OIDCIssuerProfile != nil && s.OIDCIssuerProfile.Enabled != nil) ||
+		(s.SecurityProfile != nil && s.SecurityProfile.WorkloadIdentity != nil)
+}
+
+// oidcIssuerParameters returns the existing managed cluster with the OIDC issuer and workload identity settings of the
+// spec, or nil if it already has them or can't be updated yet.
+func (s *ManagedClusterSpec) oidcIssuerParameters(existing previewcontainerservice.ManagedCluster) *previewcontainerservice.ManagedCluster {
+	if existing.ManagedClusterProperties == nil || pointer.StringDeref(existing.ProvisioningState, "") != string(infrav1.Succeeded) {
+		return nil
+	}
+
+	update := false
+	if s.OIDCIssuerProfile != nil && s.OIDCIssuerProfile.Enabled != nil {
+		if existing.OidcIssuerProfile == nil || pointer.BoolDeref(existing.OidcIssuerProfile.Enabled, false) != *s.OIDCIssuerProfile.Enabled {
+			existing.OidcIssuerProfile = &previewcontainerservice.ManagedClusterOIDCIssuerProfile{
+				Enabled: pointer.Bool(*s.OIDCIssuerProfile.Enabled),
+			}
+			update = true
+		}
+	}
+	if s.SecurityProfile != nil && s.SecurityProfile.WorkloadIdentity != nil {
+		if existing.SecurityProfile == nil {
+			existing.SecurityProfile = &previewcontainerservice.ManagedClusterSecurityProfile{}
+		}
+		if existing.SecurityProfile.WorkloadIdentity == nil || pointer.BoolDeref(existing.SecurityProfile.WorkloadIdentity.Enabled, false) != s.SecurityProfile.WorkloadIdentity.Enabled {
+			existing.SecurityProfile.WorkloadIdentity = &previewcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+				Enabled: pointer.Bool(s.SecurityProfile.WorkloadIdentity.Enabled),
+			}
+			update = true
+		}
+	}
+	if !update {
+		return nil
+	}
+	return &existing
+}
+
 func convertToResourceReferences(resources []string) *[]containerservice.ResourceReference {
 	resourceReferences := make([]containerservice.ResourceReference, len(resources))
 	for i := range resources {
@@ -458,6 +752,18 @@ func convertToResourceReferences(resources []string) *[]containerservice.Resourc
 	return &resourceReferences
 }
 
+// normalizeAuthorizedIPRanges returns the sorted authorized IP ranges of a managed cluster, or nil if there are none,
+// since AKS doesn't keep their order and omits an empty list.
+func normalizeAuthorizedIPRanges(ranges *[]string) *[]string {
+	if ranges == nil || len(*ranges) == 0 {
+		return nil
+	}
+	normalized := make([]string, len(*ranges))
+	copy(normalized, *ranges)
+	sort.Strings(normalized)
+	return &normalized
+}
+
 func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedCluster, existingMC containerservice.ManagedCluster) string {
 	// Normalize properties for the desired (CR spec) and existing managed
 	// cluster, so that we check only those fields that were specified in
@@ -466,9 +772,10 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 	// difference in desired and existing, which would result in sending
 	// unnecessary Azure API requests.
 	propertiesNormalized := &containerservice.ManagedClusterProperties{
-		KubernetesVersion: managedCluster.ManagedClusterProperties.KubernetesVersion,
-		NetworkProfile:    &containerservice.NetworkProfile{},
-		AutoScalerProfile: &containerservice.ManagedClusterPropertiesAutoScalerProfile{},
+		KubernetesVersion:    managedCluster.ManagedClusterProperties.KubernetesVersion,
+		DisableLocalAccounts: managedCluster.ManagedClusterProperties.DisableLocalAccounts,
+		NetworkProfile:       &containerservice.NetworkProfile{},
+		AutoScalerProfile:    &containerservice.ManagedClusterPropertiesAutoScalerProfile{},
 	}
 
 	existingMCPropertiesNormalized := &containerservice.ManagedClusterProperties{
@@ -477,6 +784,16 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 		AutoScalerProfile: &containerservice.ManagedClusterPropertiesAutoScalerProfile{},
 	}
 
+	// DisableLocalAccounts is only compared when it is set in the spec, as AKS defaults it.
+	if managedCluster.DisableLocalAccounts != nil {
+		existingMCPropertiesNormalized.DisableLocalAccounts = existingMC.DisableLocalAccounts
+	}
+
+	if managedCluster.AutoUpgradeProfile != nil {
+		propertiesNormalized.AutoUpgradeProfile = managedCluster.AutoUpgradeProfile
+		existingMCPropertiesNormalized.AutoUpgradeProfile = existingMC.AutoUpgradeProfile
+	}
+
 	if managedCluster.AadProfile != nil {
 		propertiesNormalized.AadProfile = &containerservice.ManagedClusterAADProfile{
 			Managed:             managedCluster.AadProfile.Managed,
@@ -495,21 +812,23 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 
 	if managedCluster.NetworkProfile != nil {
 		propertiesNormalized.NetworkProfile.LoadBalancerProfile = managedCluster.NetworkProfile.LoadBalancerProfile
+		propertiesNormalized.NetworkProfile.NatGatewayProfile = managedCluster.NetworkProfile.NatGatewayProfile
 	}
 
 	if existingMC.NetworkProfile != nil {
 		existingMCPropertiesNormalized.NetworkProfile.LoadBalancerProfile = existingMC.NetworkProfile.LoadBalancerProfile
+		existingMCPropertiesNormalized.NetworkProfile.NatGatewayProfile = existingMC.NetworkProfile.NatGatewayProfile
 	}
 
 	if managedCluster.APIServerAccessProfile != nil {
 		propertiesNormalized.APIServerAccessProfile = &containerservice.ManagedClusterAPIServerAccessProfile{
-			AuthorizedIPRanges: managedCluster.APIServerAccessProfile.AuthorizedIPRanges,
+			AuthorizedIPRanges: normalizeAuthorizedIPRanges(managedCluster.APIServerAccessProfile.AuthorizedIPRanges),
 		}
 	}
 
 	if existingMC.APIServerAccessProfile != nil {
 		existingMCPropertiesNormalized.APIServerAccessProfile = &containerservice.ManagedClusterAPIServerAccessProfile{
-			AuthorizedIPRanges: existingMC.APIServerAccessProfile.AuthorizedIPRanges,
+			AuthorizedIPRanges: normalizeAuthorizedIPRanges(existingMC.APIServerAccessProfile.AuthorizedIPRanges),
 		}
 	}
 
@@ -564,6 +883,52 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 		propertiesNormalized.AutoScalerProfile = nil
 	}
 
+	// Only the security profile settings set in the spec are compared, so that a rotation of the key of the Azure Key
+	// Vault key management service and changes to Microsoft Defender are applied to the managed cluster.
+	if managedCluster.SecurityProfile != nil {
+		propertiesNormalized.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{}
+		existingMCPropertiesNormalized.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{}
+		existingSecurityProfile := existingMC.SecurityProfile
+		if existingSecurityProfile == nil {
+			existingSecurityProfile = &containerservice.ManagedClusterSecurityProfile{}
+		}
+		if managedCluster.SecurityProfile.AzureKeyVaultKms != nil {
+			propertiesNormalized.SecurityProfile.AzureKeyVaultKms = &containerservice.AzureKeyVaultKms{
+				Enabled: managedCluster.SecurityProfile.AzureKeyVaultKms.Enabled,
+				KeyID:   managedCluster.SecurityProfile.AzureKeyVaultKms.KeyID,
+			}
+			if existingSecurityProfile.AzureKeyVaultKms != nil {
+				existingMCPropertiesNormalized.SecurityProfile.AzureKeyVaultKms = &containerservice.AzureKeyVaultKms{
+					Enabled: existingSecurityProfile.AzureKeyVaultKms.Enabled,
+					KeyID:   existingSecurityProfile.AzureKeyVaultKms.KeyID,
+				}
+			}
+		}
+		if desired := managedCluster.SecurityProfile.Defender; desired != nil {
+			propertiesNormalized.SecurityProfile.Defender = desired
+			if existing := existingSecurityProfile.Defender; existing != nil {
+				existingDefender := *existing
+				// Azure may return the workspace resource ID with a different casing.
+				if desired.LogAnalyticsWorkspaceResourceID != nil && existing.LogAnalyticsWorkspaceResourceID != nil &&
+					strings.EqualFold(*desired.LogAnalyticsWorkspaceResourceID, *existing.LogAnalyticsWorkspaceResourceID) {
+					existingDefender.LogAnalyticsWorkspaceResourceID = desired.LogAnalyticsWorkspaceResourceID
+				}
+				existingMCPropertiesNormalized.SecurityProfile.Defender = &existingDefender
+			}
+		}
+	}
+
+	// Only the trusted CA of the HTTP proxy configuration can be updated, so that it can be rotated.
+	if managedCluster.HTTPProxyConfig != nil && managedCluster.HTTPProxyConfig.TrustedCa != nil {
+		propertiesNormalized.HTTPProxyConfig = &containerservice.ManagedClusterHTTPProxyConfig{
+			TrustedCa: managedCluster.HTTPProxyConfig.TrustedCa,
+		}
+		existingMCPropertiesNormalized.HTTPProxyConfig = &containerservice.ManagedClusterHTTPProxyConfig{}
+		if existingMC.HTTPProxyConfig != nil {
+			existingMCPropertiesNormalized.HTTPProxyConfig.TrustedCa = existingMC.HTTPProxyConfig.TrustedCa
+		}
+	}
+
 	clusterNormalized := &containerservice.ManagedCluster{
 		ManagedClusterProperties: propertiesNormalized,
 	}
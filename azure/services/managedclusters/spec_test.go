@@ -20,7 +20,7 @@ import (
 	"context"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
@@ -54,6 +54,22 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "Unable to update existing managed cluster in non-terminal state. Managed cluster must be in one of the following provisioning states: Canceled, Failed, or Succeeded. Actual state: Deleting. Object will be requeued after 20s",
 		},
+		{
+			name: "managedcluster is stopped",
+			existing: containerservice.ManagedCluster{
+				ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+					ProvisioningState: pointer.String("Succeeded"),
+					PowerState:        &containerservice.PowerState{Code: containerservice.Stopped},
+				},
+			},
+			spec: &ManagedClusterSpec{
+				Name:    "test-managedcluster",
+				Version: "v1.22.0",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
 		{
 			name:     "managedcluster does not exist",
 			existing: nil,
@@ -102,6 +118,37 @@ func TestParameters(t *testing.T) {
 				g.Expect(gomockinternal.DiffEq(result).Matches(getSampleManagedCluster())).To(BeTrue(), cmp.Diff(result, getSampleManagedCluster()))
 			},
 		},
+		{
+			name:     "private managedcluster with an existing private DNS zone does not exist",
+			existing: nil,
+			spec: &ManagedClusterSpec{
+				Name:              "test-managedcluster",
+				ResourceGroup:     "test-rg",
+				NodeResourceGroup: "test-node-rg",
+				ClusterName:       "test-cluster",
+				Location:          "test-location",
+				Version:           "v1.22.0",
+				LoadBalancerSKU:   "Standard",
+				APIServerAccessProfile: &APIServerAccessProfile{
+					EnablePrivateCluster: pointer.Bool(true),
+					PrivateDNSZone:       pointer.String("/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.test-location.azmk8s.io"),
+					FQDNSubdomain:        pointer.String("my-cluster"),
+				},
+				UserAssignedIdentityResourceID: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity",
+				GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{}, nil
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				mc := result.(containerservice.ManagedCluster)
+				g.Expect(mc.DNSPrefix).To(BeNil())
+				g.Expect(mc.FqdnSubdomain).To(Equal(pointer.String("my-cluster")))
+				g.Expect(mc.APIServerAccessProfile.PrivateDNSZone).To(Equal(pointer.String("/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.test-location.azmk8s.io")))
+				g.Expect(mc.Identity.Type).To(Equal(containerservice.ResourceIdentityTypeUserAssigned))
+				g.Expect(mc.Identity.UserAssignedIdentities).To(HaveKey("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity"))
+			},
+		},
 		{
 			name:     "managedcluster exists, no update needed",
 			existing: getExistingCluster(),
@@ -137,6 +184,416 @@ func TestParameters(t *testing.T) {
 				g.Expect(result.(containerservice.ManagedCluster).KubernetesVersion).To(Equal(pointer.String("v1.22.99")))
 			},
 		},
+		{
+			name: "managedcluster exists, authorized IP ranges in a different order",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.APIServerAccessProfile = &containerservice.ManagedClusterAPIServerAccessProfile{
+					AuthorizedIPRanges: &[]string{"20.1.4.0/28", "12.34.56.78/32"},
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				APIServerAccessProfile: &APIServerAccessProfile{
+					AuthorizedIPRanges: []string{"12.34.56.78/32", "20.1.4.0/28"},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists, authorized IP ranges removed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.APIServerAccessProfile = &containerservice.ManagedClusterAPIServerAccessProfile{
+					AuthorizedIPRanges: &[]string{"12.34.56.78/32"},
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:                "v1.22.0",
+				LoadBalancerSKU:        "Standard",
+				APIServerAccessProfile: &APIServerAccessProfile{},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).APIServerAccessProfile.AuthorizedIPRanges).To(Equal(&[]string{}))
+			},
+		},
+		{
+			name: "managedcluster exists, Azure RBAC enabled and local accounts disabled",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.AadProfile = &containerservice.ManagedClusterAADProfile{
+					Managed:             pointer.Bool(true),
+					EnableAzureRBAC:     pointer.Bool(false),
+					AdminGroupObjectIDs: &[]string{"admin-group"},
+				}
+				mc.DisableLocalAccounts = pointer.Bool(false)
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AADProfile: &AADProfile{
+					Managed:             true,
+					EnableAzureRBAC:     true,
+					AdminGroupObjectIDs: []string{"admin-group"},
+				},
+				DisableLocalAccounts: pointer.Bool(true),
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).AadProfile.EnableAzureRBAC).To(Equal(pointer.Bool(true)))
+				g.Expect(result.(containerservice.ManagedCluster).DisableLocalAccounts).To(Equal(pointer.Bool(true)))
+			},
+		},
+		{
+			name: "managedcluster exists, Azure Key Vault KMS key rotated",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{
+					AzureKeyVaultKms: &containerservice.AzureKeyVaultKms{
+						Enabled:               pointer.Bool(true),
+						KeyID:                 pointer.String("https://my-vault.vault.azure.net/keys/my-key/1"),
+						KeyVaultNetworkAccess: containerservice.Public,
+					},
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				SecurityProfile: &SecurityProfile{
+					AzureKeyVaultKms: &AzureKeyVaultKms{
+						Enabled: true,
+						KeyID:   "https://my-vault.vault.azure.net/keys/my-key/2",
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).SecurityProfile.AzureKeyVaultKms.KeyID).To(Equal(pointer.String("https://my-vault.vault.azure.net/keys/my-key/2")))
+			},
+		},
+		{
+			name: "managedcluster exists, HTTP proxy trusted CA rotated",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.HTTPProxyConfig = &containerservice.ManagedClusterHTTPProxyConfig{
+					HTTPProxy:  pointer.String("http://proxy.example.com:3128/"),
+					NoProxy:    &[]string{"localhost", "10.0.0.0/16", "konnectivity"},
+					TrustedCa:  pointer.String("b2xkLWNh"),
+					HTTPSProxy: pointer.String("http://proxy.example.com:3128/"),
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				HTTPProxyConfig: &HTTPProxyConfig{
+					HTTPProxy: pointer.String("http://proxy.example.com:3128/"),
+					NoProxy:   []string{"localhost"},
+					TrustedCA: pointer.String("bmV3LWNh"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).HTTPProxyConfig.TrustedCa).To(Equal(pointer.String("bmV3LWNh")))
+			},
+		},
+		{
+			name: "managedcluster exists, HTTP proxy settings added by AKS are ignored",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.HTTPProxyConfig = &containerservice.ManagedClusterHTTPProxyConfig{
+					HTTPProxy:  pointer.String("http://proxy.example.com:3128/"),
+					NoProxy:    &[]string{"localhost", "10.0.0.0/16", "konnectivity"},
+					TrustedCa:  pointer.String("b2xkLWNh"),
+					HTTPSProxy: pointer.String("http://proxy.example.com:3128/"),
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				HTTPProxyConfig: &HTTPProxyConfig{
+					HTTPProxy: pointer.String("http://proxy.example.com:3128/"),
+					NoProxy:   []string{"localhost"},
+					TrustedCA: pointer.String("b2xkLWNh"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists, Microsoft Defender workspace in a different casing",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{
+					Defender: &containerservice.ManagedClusterSecurityProfileDefender{
+						LogAnalyticsWorkspaceResourceID: pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/rg/providers/microsoft.operationalinsights/workspaces/ws"),
+						SecurityMonitoring: &containerservice.ManagedClusterSecurityProfileDefenderSecurityMonitoring{
+							Enabled: pointer.Bool(true),
+						},
+					},
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				SecurityProfile: &SecurityProfile{
+					Defender: &Defender{
+						LogAnalyticsWorkspaceResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/ws",
+						SecurityMonitoringEnabled:       true,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists, autoscaler profile changed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.AutoScalerProfile = buildAutoScalerProfile(&AutoScalerProfile{
+					ScanInterval:          pointer.String("10s"),
+					ScaleDownUnneededTime: pointer.String("10m"),
+				})
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoScalerProfile: &AutoScalerProfile{
+					ScanInterval:          pointer.String("30s"),
+					ScaleDownUnneededTime: pointer.String("10m"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).AutoScalerProfile.ScanInterval).To(Equal(pointer.String("30s")))
+			},
+		},
+		{
+			name: "managedcluster exists, autoscaler profile removed from the spec",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.AutoScalerProfile = buildAutoScalerProfile(&AutoScalerProfile{
+					ScanInterval: pointer.String("30s"),
+				})
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).AutoScalerProfile).To(Equal(buildAutoScalerProfile(&defaultAutoScalerProfile)))
+			},
+		},
+		{
+			name: "managedcluster exists, autoscaler profile removed from the spec and default",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.AutoScalerProfile = buildAutoScalerProfile(&defaultAutoScalerProfile)
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists, NAT gateway effective outbound IPs added by AKS are ignored",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.NetworkProfile.OutboundType = containerservice.ManagedNATGateway
+				mc.NetworkProfile.NatGatewayProfile = &containerservice.ManagedClusterNATGatewayProfile{
+					ManagedOutboundIPProfile: &containerservice.ManagedClusterManagedOutboundIPProfile{Count: pointer.Int32(2)},
+					EffectiveOutboundIPs: &[]containerservice.ResourceReference{
+						{ID: pointer.String("/subscriptions/123/resourceGroups/test-node-rg/providers/Microsoft.Network/publicIPAddresses/test-ip")},
+					},
+					IdleTimeoutInMinutes: pointer.Int32(4),
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				OutboundType:    (*infrav1.ManagedControlPlaneOutboundType)(pointer.String(string(infrav1.ManagedControlPlaneOutboundTypeManagedNATGateway))),
+				NATGatewayProfile: &NATGatewayProfile{
+					ManagedOutboundIPs:   pointer.Int32(2),
+					IdleTimeoutInMinutes: pointer.Int32(4),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists, NAT gateway managed outbound IPs changed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.NetworkProfile.OutboundType = containerservice.ManagedNATGateway
+				mc.NetworkProfile.NatGatewayProfile = &containerservice.ManagedClusterNATGatewayProfile{
+					ManagedOutboundIPProfile: &containerservice.ManagedClusterManagedOutboundIPProfile{Count: pointer.Int32(1)},
+					IdleTimeoutInMinutes:     pointer.Int32(4),
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				OutboundType:    (*infrav1.ManagedControlPlaneOutboundType)(pointer.String(string(infrav1.ManagedControlPlaneOutboundTypeManagedNATGateway))),
+				NATGatewayProfile: &NATGatewayProfile{
+					ManagedOutboundIPs: pointer.Int32(3),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).NetworkProfile.NatGatewayProfile.ManagedOutboundIPProfile.Count).To(Equal(pointer.Int32(3)))
+			},
+		},
+		{
+			name: "managedcluster exists, version upgraded by the auto-upgrade channel",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.KubernetesVersion = pointer.String("v1.22.6")
+				mc.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{
+					UpgradeChannel: containerservice.UpgradeChannelPatch,
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					UpgradeChannel: pointer.String("patch"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists, auto-upgrade channel changed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.KubernetesVersion = pointer.String("v1.22.6")
+				mc.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{
+					UpgradeChannel: containerservice.UpgradeChannelPatch,
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					UpgradeChannel: pointer.String("stable"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				mc := result.(containerservice.ManagedCluster)
+				g.Expect(mc.AutoUpgradeProfile.UpgradeChannel).To(Equal(containerservice.UpgradeChannelStable))
+				g.Expect(mc.KubernetesVersion).To(Equal(pointer.String("v1.22.6")))
+			},
+		},
 		{
 			name:     "delete all tags",
 			existing: getExistingCluster(),
@@ -190,7 +647,7 @@ func getSampleManagedCluster() containerservice.ManagedCluster {
 					Name:         pointer.String("test-agentpool-0"),
 					Mode:         containerservice.AgentPoolMode(infrav1.NodePoolModeSystem),
 					Count:        pointer.Int32(2),
-					Type:         containerservice.AgentPoolTypeVirtualMachineScaleSets,
+					Type:         containerservice.VirtualMachineScaleSets,
 					OsDiskSizeGB: pointer.Int32(0),
 					Tags: map[string]*string{
 						"test-tag": pointer.String("test-value"),
@@ -200,7 +657,7 @@ func getSampleManagedCluster() containerservice.ManagedCluster {
 					Name:                pointer.String("test-agentpool-1"),
 					Mode:                containerservice.AgentPoolMode(infrav1.NodePoolModeUser),
 					Count:               pointer.Int32(4),
-					Type:                containerservice.AgentPoolTypeVirtualMachineScaleSets,
+					Type:                containerservice.VirtualMachineScaleSets,
 					OsDiskSizeGB:        pointer.Int32(0),
 					VMSize:              pointer.String("test_SKU"),
 					OrchestratorVersion: pointer.String("v1.22.0"),
@@ -18,6 +18,7 @@ package natgateways
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
@@ -30,13 +31,16 @@ import (
 
 // NatGatewaySpec defines the specification for a NAT gateway.
 type NatGatewaySpec struct {
-	Name           string
-	ResourceGroup  string
-	SubscriptionID string
-	Location       string
-	NatGatewayIP   infrav1.PublicIPSpec
-	ClusterName    string
-	AdditionalTags infrav1.Tags
+	Name                 string
+	ResourceGroup        string
+	SubscriptionID       string
+	Location             string
+	NatGatewayIP         infrav1.PublicIPSpec
+	IdleTimeoutInMinutes *int32
+	Zones                []string
+	PublicIPPrefixIDs    []string
+	ClusterName          string
+	AdditionalTags       infrav1.Tags
 }
 
 // ResourceName returns the name of the NAT gateway.
@@ -56,16 +60,22 @@ func (s *NatGatewaySpec) OwnerResourceName() string {
 
 // Parameters returns the parameters for the NAT gateway.
 func (s *NatGatewaySpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	zones := s.Zones
 	if existing != nil {
 		existingNatGateway, ok := existing.(network.NatGateway)
 		if !ok {
 			return nil, errors.Errorf("%T is not a network.NatGateway", existing)
 		}
 
-		if hasPublicIP(existingNatGateway, s.NatGatewayIP.Name) {
+		if hasPublicIP(existingNatGateway, s.NatGatewayIP.Name) && s.hasIdleTimeout(existingNatGateway) && s.hasPublicIPPrefixes(existingNatGateway) {
 			// Skip update for NAT gateway as it exists with expected values
 			return nil, nil
 		}
+
+		// The zones of a NAT gateway cannot be changed once it is created.
+		if existingNatGateway.Zones != nil {
+			zones = *existingNatGateway.Zones
+		}
 	}
 
 	natGatewayToCreate := network.NatGateway{
@@ -73,6 +83,7 @@ func (s *NatGatewaySpec) Parameters(ctx context.Context, existing interface{}) (
 		Location: pointer.String(s.Location),
 		Sku:      &network.NatGatewaySku{Name: network.NatGatewaySkuNameStandard},
 		NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+			IdleTimeoutInMinutes: s.IdleTimeoutInMinutes,
 			PublicIPAddresses: &[]network.SubResource{
 				{
 					ID: pointer.String(azure.PublicIPID(s.SubscriptionID, s.ResourceGroupName(), s.NatGatewayIP.Name)),
@@ -87,6 +98,18 @@ func (s *NatGatewaySpec) Parameters(ctx context.Context, existing interface{}) (
 		})),
 	}
 
+	if len(zones) > 0 {
+		natGatewayToCreate.Zones = &zones
+	}
+
+	if len(s.PublicIPPrefixIDs) > 0 {
+		prefixes := make([]network.SubResource, 0, len(s.PublicIPPrefixIDs))
+		for _, id := range s.PublicIPPrefixIDs {
+			prefixes = append(prefixes, network.SubResource{ID: pointer.String(id)})
+		}
+		natGatewayToCreate.PublicIPPrefixes = &prefixes
+	}
+
 	return natGatewayToCreate, nil
 }
 
@@ -107,3 +130,36 @@ func hasPublicIP(natGateway network.NatGateway, publicIPName string) bool {
 	}
 	return false
 }
+
+// hasIdleTimeout returns true if the NAT gateway has the desired idle timeout.
+// An unset idle timeout leaves the value chosen by Azure untouched.
+func (s *NatGatewaySpec) hasIdleTimeout(natGateway network.NatGateway) bool {
+	if s.IdleTimeoutInMinutes == nil {
+		return true
+	}
+	return pointer.Int32Deref(natGateway.IdleTimeoutInMinutes, 0) == *s.IdleTimeoutInMinutes
+}
+
+// hasPublicIPPrefixes returns true if the NAT gateway uses exactly the desired public IP prefixes.
+func (s *NatGatewaySpec) hasPublicIPPrefixes(natGateway network.NatGateway) bool {
+	var existing []network.SubResource
+	if natGateway.PublicIPPrefixes != nil {
+		existing = *natGateway.PublicIPPrefixes
+	}
+	if len(existing) != len(s.PublicIPPrefixIDs) {
+		return false
+	}
+	for _, id := range s.PublicIPPrefixIDs {
+		found := false
+		for _, prefix := range existing {
+			if strings.EqualFold(pointer.StringDeref(prefix.ID, ""), id) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
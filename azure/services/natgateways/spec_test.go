@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natgateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+const (
+	fakePublicIPID       = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-natgateway-ip"
+	fakePublicIPPrefixID = "/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"
+)
+
+var fakeNatGatewaySpec = NatGatewaySpec{
+	Name:           "my-natgateway",
+	ResourceGroup:  "my-rg",
+	SubscriptionID: "123",
+	Location:       "westus",
+	NatGatewayIP: infrav1.PublicIPSpec{
+		Name: "my-natgateway-ip",
+	},
+	IdleTimeoutInMinutes: pointer.Int32(10),
+	Zones:                []string{"1"},
+	PublicIPPrefixIDs:    []string{fakePublicIPPrefixID},
+	ClusterName:          "my-cluster",
+}
+
+func newExistingNatGateway(idleTimeout *int32, zones []string, prefixIDs ...string) network.NatGateway {
+	natGateway := network.NatGateway{
+		Name: pointer.String("my-natgateway"),
+		NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+			IdleTimeoutInMinutes: idleTimeout,
+			PublicIPAddresses: &[]network.SubResource{
+				{ID: pointer.String(fakePublicIPID)},
+			},
+		},
+	}
+	if zones != nil {
+		natGateway.Zones = &zones
+	}
+	if len(prefixIDs) > 0 {
+		prefixes := []network.SubResource{}
+		for _, id := range prefixIDs {
+			prefixes = append(prefixes, network.SubResource{ID: pointer.String(id)})
+		}
+		natGateway.PublicIPPrefixes = &prefixes
+	}
+	return natGateway
+}
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          NatGatewaySpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new NAT gateway with idle timeout, zone and public IP prefixes",
+			spec:     fakeNatGatewaySpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				natGateway := result.(network.NatGateway)
+				g.Expect(natGateway.IdleTimeoutInMinutes).To(Equal(pointer.Int32(10)))
+				g.Expect(natGateway.Zones).To(Equal(&[]string{"1"}))
+				g.Expect(natGateway.PublicIPAddresses).To(Equal(&[]network.SubResource{{ID: pointer.String(fakePublicIPID)}}))
+				g.Expect(natGateway.PublicIPPrefixes).To(Equal(&[]network.SubResource{{ID: pointer.String(fakePublicIPPrefixID)}}))
+			},
+		},
+		{
+			name: "new NAT gateway with defaults",
+			spec: NatGatewaySpec{
+				Name:           "my-natgateway",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				Location:       "westus",
+				NatGatewayIP: infrav1.PublicIPSpec{
+					Name: "my-natgateway-ip",
+				},
+				ClusterName: "my-cluster",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				natGateway := result.(network.NatGateway)
+				g.Expect(natGateway.IdleTimeoutInMinutes).To(BeNil())
+				g.Expect(natGateway.Zones).To(BeNil())
+				g.Expect(natGateway.PublicIPPrefixes).To(BeNil())
+			},
+		},
+		{
+			name:     "existing NAT gateway is up to date",
+			spec:     fakeNatGatewaySpec,
+			existing: newExistingNatGateway(pointer.Int32(10), []string{"1"}, fakePublicIPPrefixID),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing NAT gateway with a different idle timeout is updated",
+			spec:     fakeNatGatewaySpec,
+			existing: newExistingNatGateway(pointer.Int32(4), []string{"1"}, fakePublicIPPrefixID),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).IdleTimeoutInMinutes).To(Equal(pointer.Int32(10)))
+			},
+		},
+		{
+			name:     "existing NAT gateway without the public IP prefixes is updated",
+			spec:     fakeNatGatewaySpec,
+			existing: newExistingNatGateway(pointer.Int32(10), []string{"1"}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).PublicIPPrefixes).To(Equal(&[]network.SubResource{{ID: pointer.String(fakePublicIPPrefixID)}}))
+			},
+		},
+		{
+			name:     "existing NAT gateway keeps its zones on update",
+			spec:     fakeNatGatewaySpec,
+			existing: newExistingNatGateway(pointer.Int32(4), []string{"2"}, fakePublicIPPrefixID),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).Zones).To(Equal(&[]string{"2"}))
+			},
+		},
+		{
+			name:          "existing is not a NAT gateway",
+			spec:          fakeNatGatewaySpec,
+			existing:      "wrong type",
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "string is not a network.NatGateway",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
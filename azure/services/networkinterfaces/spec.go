@@ -19,6 +19,7 @@ package networkinterfaces
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
@@ -55,6 +56,9 @@ type NICSpec struct {
 	AdditionalTags            infrav1.Tags
 	ClusterName               string
 	IPConfigs                 []IPConfig
+	// ApplicationSecurityGroupIDs are the IDs of the application security groups joined by all the IP configurations
+	// of the network interface.
+	ApplicationSecurityGroupIDs []string
 }
 
 // IPConfig defines the specification for an IP address configuration.
@@ -81,15 +85,18 @@ func (s *NICSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the network interface.
 func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.Interface); !ok {
+		existingNIC, ok := existing.(network.Interface)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.Interface", existing)
 		}
-		// network interface already exists
-		return nil, nil
+		// network interface already exists, only its secondary IP configurations, application security groups and IP
+		// forwarding are reconciled
+		return s.updatedParameters(existingNIC), nil
 	}
 
 	primaryIPConfig := &network.InterfaceIPConfigurationPropertiesFormat{
-		Primary: pointer.Bool(true),
+		Primary:                   pointer.Bool(true),
+		ApplicationSecurityGroups: s.applicationSecurityGroups(),
 	}
 
 	subnet := &network.Subnet{
@@ -155,13 +162,66 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		},
 	}
 
-	// Build additional IPConfigs if more than 1 is specified
+	ipConfigurations = append(ipConfigurations, s.secondaryIPConfigs(subnet)...)
+	if s.IPv6Enabled {
+		// The IPv6 configuration joins the IPv6 counterparts of the backend pools of the primary configuration.
+		ipv6BackendAddressPools := []network.BackendAddressPool{}
+		if s.PublicLBName != "" && s.PublicLBAddressPoolName != "" {
+			ipv6BackendAddressPools = append(ipv6BackendAddressPools,
+				network.BackendAddressPool{
+					ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, azure.GenerateIPv6Name(s.PublicLBAddressPoolName))),
+				})
+		}
+		if s.InternalLBName != "" && s.InternalLBAddressPoolName != "" {
+			ipv6BackendAddressPools = append(ipv6BackendAddressPools,
+				network.BackendAddressPool{
+					ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.InternalLBName, azure.GenerateIPv6Name(s.InternalLBAddressPoolName))),
+				})
+		}
+		ipv6Config := network.InterfaceIPConfiguration{
+			Name: pointer.String("ipConfigv6"),
+			InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+				PrivateIPAddressVersion:   "IPv6",
+				Primary:                   pointer.Bool(false),
+				Subnet:                    &network.Subnet{ID: subnet.ID},
+				ApplicationSecurityGroups: s.applicationSecurityGroups(),
+			},
+		}
+		if len(ipv6BackendAddressPools) > 0 {
+			ipv6Config.LoadBalancerBackendAddressPools = &ipv6BackendAddressPools
+		}
+
+		ipConfigurations = append(ipConfigurations, ipv6Config)
+	}
+
+	return network.Interface{
+		Location:         pointer.String(s.Location),
+		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			EnableAcceleratedNetworking: s.AcceleratedNetworking,
+			IPConfigurations:            &ipConfigurations,
+			DNSSettings:                 &dnsSettings,
+			EnableIPForwarding:          pointer.Bool(s.EnableIPForwarding),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// secondaryIPConfigs returns the secondary IPv4 configurations of the network interface.
+func (s *NICSpec) secondaryIPConfigs(subnet *network.Subnet) []network.InterfaceIPConfiguration {
+	ipConfigurations := []network.InterfaceIPConfiguration{}
 	for i := 1; i < len(s.IPConfigs); i++ {
 		c := s.IPConfigs[i]
 		newIPConfigPropertiesFormat := &network.InterfaceIPConfigurationPropertiesFormat{}
 		newIPConfigPropertiesFormat.Subnet = subnet
+		newIPConfigPropertiesFormat.ApplicationSecurityGroups = s.applicationSecurityGroups()
 		config := network.InterfaceIPConfiguration{
-			Name:                                     pointer.String(s.Name + "-" + strconv.Itoa(i)),
+			Name:                                     pointer.String(s.secondaryIPConfigName(i)),
 			InterfaceIPConfigurationPropertiesFormat: newIPConfigPropertiesFormat,
 		}
 		if c.PrivateIP != nil && *c.PrivateIP != "" {
@@ -188,33 +248,117 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		config.InterfaceIPConfigurationPropertiesFormat.Primary = pointer.Bool(false)
 		ipConfigurations = append(ipConfigurations, config)
 	}
-	if s.IPv6Enabled {
-		ipv6Config := network.InterfaceIPConfiguration{
-			Name: pointer.String("ipConfigv6"),
-			InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
-				PrivateIPAddressVersion: "IPv6",
-				Primary:                 pointer.Bool(false),
-				Subnet:                  &network.Subnet{ID: subnet.ID},
-			},
+	return ipConfigurations
+}
+
+// applicationSecurityGroups returns the application security groups joined by the IP configurations, or nil if there
+// are none.
+func (s *NICSpec) applicationSecurityGroups() *[]network.ApplicationSecurityGroup {
+	if len(s.ApplicationSecurityGroupIDs) == 0 {
+		return nil
+	}
+	asgs := make([]network.ApplicationSecurityGroup, 0, len(s.ApplicationSecurityGroupIDs))
+	for _, id := range s.ApplicationSecurityGroupIDs {
+		asgs = append(asgs, network.ApplicationSecurityGroup{ID: pointer.String(id)})
+	}
+	return &asgs
+}
+
+// applicationSecurityGroupsUpToDate returns true if the IP configuration joins exactly the application security groups
+// of the spec, regardless of their order and case.
+func (s *NICSpec) applicationSecurityGroupsUpToDate(config network.InterfaceIPConfiguration) bool {
+	existing := map[string]bool{}
+	if config.InterfaceIPConfigurationPropertiesFormat != nil && config.ApplicationSecurityGroups != nil {
+		for _, asg := range *config.ApplicationSecurityGroups {
+			existing[strings.ToLower(pointer.StringDeref(asg.ID, ""))] = true
 		}
+	}
+	desired := map[string]bool{}
+	for _, id := range s.ApplicationSecurityGroupIDs {
+		desired[strings.ToLower(id)] = true
+	}
+	if len(existing) != len(desired) {
+		return false
+	}
+	for id := range desired {
+		if !existing[id] {
+			return false
+		}
+	}
+	return true
+}
 
-		ipConfigurations = append(ipConfigurations, ipv6Config)
+// secondaryIPConfigName returns the name of the secondary IPv4 configuration with the given index.
+func (s *NICSpec) secondaryIPConfigName(index int) string {
+	return s.Name + "-" + strconv.Itoa(index)
+}
+
+// isSecondaryIPConfigName returns true if the name is the name of a secondary IPv4 configuration managed by the spec.
+func (s *NICSpec) isSecondaryIPConfigName(name string) bool {
+	suffix := strings.TrimPrefix(name, s.Name+"-")
+	if suffix == name {
+		return false
 	}
+	index, err := strconv.Atoi(suffix)
+	return err == nil && index > 0
+}
 
-	return network.Interface{
-		Location:         pointer.String(s.Location),
-		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
-		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
-			EnableAcceleratedNetworking: s.AcceleratedNetworking,
-			IPConfigurations:            &ipConfigurations,
-			DNSSettings:                 &dnsSettings,
-			EnableIPForwarding:          pointer.Bool(s.EnableIPForwarding),
-		},
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        pointer.String(s.Name),
-			Additional:  s.AdditionalTags,
-		})),
-	}, nil
+// updatedParameters returns the existing network interface with its secondary IP configurations, application security
+// groups and IP forwarding reconciled to the spec, or nil if no update is needed.
+func (s *NICSpec) updatedParameters(existing network.Interface) interface{} {
+	if existing.InterfacePropertiesFormat == nil || existing.IPConfigurations == nil {
+		return nil
+	}
+
+	changed := pointer.BoolDeref(existing.EnableIPForwarding, false) != s.EnableIPForwarding
+
+	ipConfigurations := []network.InterfaceIPConfiguration{}
+	existingSecondaries := make(map[string]network.InterfaceIPConfiguration)
+	for _, config := range *existing.IPConfigurations {
+		if config.Name != nil && s.isSecondaryIPConfigName(*config.Name) {
+			existingSecondaries[*config.Name] = config
+			continue
+		}
+		ipConfigurations = append(ipConfigurations, config)
+	}
+
+	subnet := &network.Subnet{
+		ID: pointer.String(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
+	}
+	desiredSecondaries := s.secondaryIPConfigs(subnet)
+	for _, desired := range desiredSecondaries {
+		current, ok := existingSecondaries[*desired.Name]
+		if ok && (desired.PrivateIPAllocationMethod == network.IPAllocationMethodDynamic ||
+			(current.InterfaceIPConfigurationPropertiesFormat != nil && pointer.StringDeref(current.PrivateIPAddress, "") == *desired.PrivateIPAddress)) {
+			ipConfigurations = append(ipConfigurations, current)
+			continue
+		}
+		ipConfigurations = append(ipConfigurations, desired)
+		changed = true
+	}
+	if len(existingSecondaries) != len(desiredSecondaries) {
+		changed = true
+	}
+
+	for i, config := range ipConfigurations {
+		if config.InterfaceIPConfigurationPropertiesFormat == nil || s.applicationSecurityGroupsUpToDate(config) {
+			continue
+		}
+		props := *config.InterfaceIPConfigurationPropertiesFormat
+		props.ApplicationSecurityGroups = s.applicationSecurityGroups()
+		if props.ApplicationSecurityGroups == nil {
+			// an empty list is required to detach the network interface from its application security groups
+			props.ApplicationSecurityGroups = &[]network.ApplicationSecurityGroup{}
+		}
+		ipConfigurations[i].InterfaceIPConfigurationPropertiesFormat = &props
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	existing.IPConfigurations = &ipConfigurations
+	existing.EnableIPForwarding = pointer.Bool(s.EnableIPForwarding)
+	return existing
 }
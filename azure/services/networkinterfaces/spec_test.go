@@ -18,6 +18,7 @@ package networkinterfaces
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
@@ -162,6 +163,25 @@ var (
 		ClusterName:           "my-cluster",
 	}
 
+	fakeIpv6ControlPlaneNICSpec = NICSpec{
+		Name:                      "my-net-interface",
+		ResourceGroup:             "my-rg",
+		Location:                  "fake-location",
+		SubscriptionID:            "123",
+		MachineName:               "azure-test1",
+		SubnetName:                "my-subnet",
+		VNetName:                  "my-vnet",
+		VNetResourceGroup:         "my-rg",
+		IPv6Enabled:               true,
+		PublicLBName:              "my-public-lb",
+		PublicLBAddressPoolName:   "my-public-lb-backendPool",
+		InternalLBName:            "my-internal-lb",
+		InternalLBAddressPoolName: "my-internal-lb-backendPool",
+		AcceleratedNetworking:     nil,
+		SKU:                       &fakeSku,
+		ClusterName:               "my-cluster",
+	}
+
 	fakeControlPlaneCustomDNSSettingsNICSpec = NICSpec{
 		Name:                      "my-net-interface",
 		ResourceGroup:             "my-rg",
@@ -249,6 +269,21 @@ var (
 		IPConfigs:             []IPConfig{{}, {}},
 		ClusterName:           "my-cluster",
 	}
+	fakeASGID                            = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-asg"
+	fakeApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                        "my-net-interface",
+		ResourceGroup:               "my-rg",
+		Location:                    "fake-location",
+		SubscriptionID:              "123",
+		MachineName:                 "azure-test1",
+		SubnetName:                  "my-subnet",
+		VNetName:                    "my-vnet",
+		VNetResourceGroup:           "my-rg",
+		AcceleratedNetworking:       pointer.Bool(false),
+		IPConfigs:                   []IPConfig{{}, {}},
+		ClusterName:                 "my-cluster",
+		ApplicationSecurityGroupIDs: []string{fakeASGID},
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -478,6 +513,22 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for control plane network interface ipv6",
+			spec:     &fakeIpv6ControlPlaneNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				ipConfigs := *result.(network.Interface).IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				g.Expect(ipConfigs[1].Name).To(Equal(pointer.String("ipConfigv6")))
+				g.Expect(ipConfigs[1].LoadBalancerBackendAddressPools).To(Equal(&[]network.BackendAddressPool{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-backendPool-ipv6")},
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-internal-lb/backendAddressPools/my-internal-lb-backendPool-ipv6")},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface default ipconfig",
 			spec:     &fakeDefaultIPconfigNICSpec,
@@ -631,6 +682,188 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "no update needed for existing network interface with the desired ipconfigs",
+			spec: &fakeTwoIPconfigNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableIPForwarding: pointer.Bool(true),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{Primary: pointer.Bool(true)}},
+						{Name: pointer.String("my-net-interface-1"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddress: pointer.String("10.0.0.5")}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "add missing ipconfig to existing network interface",
+			spec: &fakeTwoIPconfigNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableIPForwarding: pointer.Bool(true),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{Primary: pointer.Bool(true)}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Interface{
+					Name: pointer.String("my-net-interface"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableIPForwarding: pointer.Bool(true),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{Primary: pointer.Bool(true)}},
+							{
+								Name: pointer.String("my-net-interface-1"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                   pointer.Bool(false),
+									Subnet:                    &network.Subnet{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "remove extra ipconfig and enable ip forwarding on existing network interface",
+			spec: &fakeTwoIPconfigNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableIPForwarding: pointer.Bool(false),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{Primary: pointer.Bool(true)}},
+						{Name: pointer.String("my-net-interface-1"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddress: pointer.String("10.0.0.5")}},
+						{Name: pointer.String("my-net-interface-2"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddress: pointer.String("10.0.0.6")}},
+						{Name: pointer.String("ipConfigv6"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddressVersion: "IPv6"}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Interface{
+					Name: pointer.String("my-net-interface"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableIPForwarding: pointer.Bool(true),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{Primary: pointer.Bool(true)}},
+							{Name: pointer.String("ipConfigv6"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddressVersion: "IPv6"}},
+							{Name: pointer.String("my-net-interface-1"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddress: pointer.String("10.0.0.5")}},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get parameters for network interface with application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				ipConfigs := *result.(network.Interface).IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				for _, ipConfig := range ipConfigs {
+					g.Expect(ipConfig.ApplicationSecurityGroups).To(Equal(&[]network.ApplicationSecurityGroup{{ID: pointer.String(fakeASGID)}}))
+				}
+			},
+			expectedError: "",
+		},
+		{
+			name: "no update needed for existing network interface with the desired application security groups",
+			spec: &fakeApplicationSecurityGroupsNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableIPForwarding: pointer.Bool(false),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+							Primary:                   pointer.Bool(true),
+							ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{{ID: pointer.String(strings.ToUpper(fakeASGID))}},
+						}},
+						{Name: pointer.String("my-net-interface-1"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+							ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{{ID: pointer.String(fakeASGID)}},
+						}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "attach existing network interface to application security groups",
+			spec: &fakeApplicationSecurityGroupsNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableIPForwarding: pointer.Bool(false),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{Primary: pointer.Bool(true)}},
+						{Name: pointer.String("my-net-interface-1"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{PrivateIPAddress: pointer.String("10.0.0.5")}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Interface{
+					Name: pointer.String("my-net-interface"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableIPForwarding: pointer.Bool(false),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+								Primary:                   pointer.Bool(true),
+								ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{{ID: pointer.String(fakeASGID)}},
+							}},
+							{Name: pointer.String("my-net-interface-1"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+								PrivateIPAddress:          pointer.String("10.0.0.5"),
+								ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{{ID: pointer.String(fakeASGID)}},
+							}},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "detach existing network interface from application security groups",
+			spec: &fakeOneIPconfigNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableIPForwarding: pointer.Bool(true),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+							Primary:                   pointer.Bool(true),
+							ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{{ID: pointer.String(fakeASGID)}},
+						}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Interface{
+					Name: pointer.String("my-net-interface"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableIPForwarding: pointer.Bool(true),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{Name: pointer.String("pipConfig"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+								Primary:                   pointer.Bool(true),
+								ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{},
+							}},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for control plane network interface with DNS servers",
 			spec:     &fakeControlPlaneCustomDNSSettingsNICSpec,
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package previewfeatures
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// ComputeNamespace is the resource provider namespace of compute preview features.
+	ComputeNamespace = "Microsoft.Compute"
+	// EncryptionAtHost is the name of the subscription feature required to enable encryption at host on VMs.
+	EncryptionAtHost = "EncryptionAtHost"
+
+	registeredState = "Registered"
+)
+
+// Cache remembers which preview features are registered for a subscription.
+// A feature is looked up in Azure until it is found to be registered, so that
+// registering a feature while a cluster is running is picked up on the next reconcile.
+type Cache struct {
+	client Client
+
+	mu sync.Mutex
+	// registered holds the registered features keyed by "<namespace>/<feature name>" in lower case.
+	registered map[string]struct{}
+}
+
+// Cacher describes the ability to get and to add items to cache.
+type Cacher interface {
+	Get(key interface{}) (value interface{}, ok bool)
+	Add(key interface{}, value interface{}) bool
+}
+
+var (
+	doOnce      sync.Once
+	clientCache Cacher
+)
+
+// newCache instantiates an empty cache.
+func newCache(auth azure.Authorizer) *Cache {
+	return &Cache{
+		client:     NewClient(auth),
+		registered: make(map[string]struct{}),
+	}
+}
+
+// GetCache either creates a new preview features cache or returns an existing one based on the Authorizer HashKey().
+func GetCache(auth azure.Authorizer) (*Cache, error) {
+	var err error
+	doOnce.Do(func() {
+		clientCache, err = ttllru.New(128, 24*time.Hour)
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for preview features cache")
+	}
+
+	key := auth.HashKey()
+	c, ok := clientCache.Get(key)
+	if ok {
+		return c.(*Cache), nil
+	}
+
+	c = newCache(auth)
+	_ = clientCache.Add(key, c)
+	return c.(*Cache), nil
+}
+
+// NewStaticCache initializes a cache with the given registered features and no ability to refresh. Used for testing.
+// Each feature is given as "<namespace>/<feature name>".
+func NewStaticCache(registered ...string) *Cache {
+	c := &Cache{
+		registered: make(map[string]struct{}),
+	}
+	for _, feature := range registered {
+		c.registered[strings.ToLower(feature)] = struct{}{}
+	}
+	return c
+}
+
+// IsRegistered returns true if the preview feature of the resource provider namespace is registered for the subscription.
+func (c *Cache) IsRegistered(ctx context.Context, namespace, name string) (bool, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "previewfeatures.Cache.IsRegistered")
+	defer done()
+
+	key := strings.ToLower(namespace + "/" + name)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.registered[key]; ok {
+		return true, nil
+	}
+
+	if c.client == nil {
+		return false, nil
+	}
+
+	result, err := c.client.Get(ctx, namespace, name)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get the registration state of feature %s/%s", namespace, name)
+	}
+
+	if result.Properties == nil || !strings.EqualFold(pointer.StringDeref(result.Properties.State, ""), registeredState) {
+		return false, nil
+	}
+
+	c.registered[key] = struct{}{}
+	return true, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package previewfeatures
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/previewfeatures/mock_previewfeatures"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func TestCacheIsRegistered(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(m *mock_previewfeatures.MockClientMockRecorder)
+		want          bool
+		expectedError string
+	}{
+		{
+			name: "registered feature",
+			expect: func(m *mock_previewfeatures.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), ComputeNamespace, EncryptionAtHost).Return(features.Result{
+					Properties: &features.Properties{State: pointer.String("Registered")},
+				}, nil).Times(1)
+			},
+			want: true,
+		},
+		{
+			name: "feature in registering state",
+			expect: func(m *mock_previewfeatures.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), ComputeNamespace, EncryptionAtHost).Return(features.Result{
+					Properties: &features.Properties{State: pointer.String("Registering")},
+				}, nil).Times(2)
+			},
+			want: false,
+		},
+		{
+			name: "feature not found",
+			expect: func(m *mock_previewfeatures.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), ComputeNamespace, EncryptionAtHost).Return(features.Result{},
+					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")).Times(2)
+			},
+			want: false,
+		},
+		{
+			name: "error getting feature",
+			expect: func(m *mock_previewfeatures.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), ComputeNamespace, EncryptionAtHost).Return(features.Result{},
+					errors.New("boom")).Times(2)
+			},
+			expectedError: "failed to get the registration state of feature Microsoft.Compute/EncryptionAtHost: boom",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			clientMock := mock_previewfeatures.NewMockClient(mockCtrl)
+			tc.expect(clientMock.EXPECT())
+
+			c := &Cache{
+				client:     clientMock,
+				registered: make(map[string]struct{}),
+			}
+
+			// the second lookup is only sent to Azure when the feature is not known to be registered.
+			for i := 0; i < 2; i++ {
+				got, err := c.IsRegistered(context.TODO(), ComputeNamespace, EncryptionAtHost)
+				if tc.expectedError != "" {
+					g.Expect(err).To(MatchError(tc.expectedError))
+				} else {
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(got).To(Equal(tc.want))
+				}
+			}
+		})
+	}
+}
+
+func TestStaticCacheIsRegistered(t *testing.T) {
+	g := NewWithT(t)
+
+	c := NewStaticCache("Microsoft.Compute/EncryptionAtHost")
+
+	registered, err := c.IsRegistered(context.TODO(), "microsoft.compute", "encryptionAtHost")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registered).To(BeTrue())
+
+	registered, err = c.IsRegistered(context.TODO(), ComputeNamespace, "OtherFeature")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registered).To(BeFalse())
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package previewfeatures
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	Get(ctx context.Context, resourceProviderNamespace, featureName string) (features.Result, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	features features.Client
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new preview features client from subscription ID.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		features: newFeaturesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newFeaturesClient creates a new preview features client from subscription ID.
+func newFeaturesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) features.Client {
+	c := features.NewClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// Get returns the preview feature with the given name of a resource provider namespace.
+func (ac *AzureClient) Get(ctx context.Context, resourceProviderNamespace, featureName string) (features.Result, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "previewfeatures.AzureClient.Get")
+	defer done()
+
+	result, err := ac.features.Get(ctx, resourceProviderNamespace, featureName)
+	if err != nil {
+		return features.Result{}, errors.Wrapf(err, "could not get feature %s/%s", resourceProviderNamespace, featureName)
+	}
+	return result, nil
+}
@@ -81,6 +81,20 @@ func (mr *MockScopeMockRecorder) Authorizer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockScope)(nil).Authorizer))
 }
 
+// AvailabilitySetDomainCounts mocks base method.
+func (m *MockScope) AvailabilitySetDomainCounts() *v1beta1.AvailabilitySetDomainCounts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetDomainCounts")
+	ret0, _ := ret[0].(*v1beta1.AvailabilitySetDomainCounts)
+	return ret0
+}
+
+// AvailabilitySetDomainCounts indicates an expected call of AvailabilitySetDomainCounts.
+func (mr *MockScopeMockRecorder) AvailabilitySetDomainCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetDomainCounts", reflect.TypeOf((*MockScope)(nil).AvailabilitySetDomainCounts))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -275,6 +289,20 @@ func (mr *MockScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockScope)(nil).HashKey))
 }
 
+// IsPrivateDNSZoneBYO mocks base method.
+func (m *MockScope) IsPrivateDNSZoneBYO() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrivateDNSZoneBYO")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPrivateDNSZoneBYO indicates an expected call of IsPrivateDNSZoneBYO.
+func (mr *MockScopeMockRecorder) IsPrivateDNSZoneBYO() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrivateDNSZoneBYO", reflect.TypeOf((*MockScope)(nil).IsPrivateDNSZoneBYO))
+}
+
 // Location mocks base method.
 func (m *MockScope) Location() string {
 	m.ctrl.T.Helper()
@@ -289,6 +317,20 @@ func (mr *MockScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockScope)(nil).Location))
 }
 
+// NamingConvention mocks base method.
+func (m *MockScope) NamingConvention() *v1beta1.NamingConvention {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamingConvention")
+	ret0, _ := ret[0].(*v1beta1.NamingConvention)
+	return ret0
+}
+
+// NamingConvention indicates an expected call of NamingConvention.
+func (mr *MockScopeMockRecorder) NamingConvention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamingConvention", reflect.TypeOf((*MockScope)(nil).NamingConvention))
+}
+
 // PrivateDNSSpec mocks base method.
 func (m *MockScope) PrivateDNSSpec() (azure.ResourceSpecGetter, []azure.ResourceSpecGetter, []azure.ResourceSpecGetter) {
 	m.ctrl.T.Helper()
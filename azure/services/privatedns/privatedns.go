@@ -24,7 +24,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
-	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcegraph"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -37,6 +37,7 @@ type Scope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linksSpec, recordsSpec []azure.ResourceSpecGetter)
+	IsPrivateDNSZoneBYO() bool
 }
 
 // Service provides operations on Azure resources.
@@ -53,7 +54,7 @@ func New(scope Scope) *Service {
 	zoneClient := newPrivateZonesClient(scope)
 	vnetLinkClient := newVirtualNetworkLinksClient(scope)
 	recordSetsClient := newRecordSetsClient(scope)
-	tagsClient := tags.NewClient(scope)
+	tagsClient := resourcegraph.NewTagsGetter(scope)
 	return &Service{
 		Scope:              scope,
 		TagsGetter:         tagsClient,
@@ -73,7 +74,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
@@ -103,14 +104,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 }
 
 // Delete deletes the private zone and vnet links.
+// When the zone is an existing zone that isn't managed by capz, only the records created for the cluster are deleted.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
-	zoneSpec, links, _ := s.Scope.PrivateDNSSpec()
+	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
 	if zoneSpec == nil {
 		return nil
 	}
@@ -127,6 +129,15 @@ func (s *Service) Delete(ctx context.Context) error {
 	if managed {
 		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
 		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.Scope.IsPrivateDNSZoneBYO() {
+		err = s.deleteRecords(ctx, records)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
 	}
 
 	return err
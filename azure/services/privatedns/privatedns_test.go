@@ -116,6 +116,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
@@ -140,6 +141,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, notDoneError)
 				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
@@ -153,6 +155,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, errFake)
 				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
@@ -188,6 +191,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
@@ -210,6 +214,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
@@ -232,6 +237,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
@@ -253,6 +259,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, nil)
@@ -275,6 +282,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, nil)
@@ -299,6 +307,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(false)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
@@ -315,6 +324,17 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
 			},
 		},
+		{
+			name:          "existing zone not found",
+			expectedError: "private DNS zone my-zone not found in resource group my-rg",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
+
+				s.SubscriptionID().Return("123")
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+				s.IsPrivateDNSZoneBYO().Return(true)
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -356,19 +376,19 @@ func TestDeletePrivateDNS(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_privatedns.MockScopeMockRecorder, linkReconciler, zoneReconciler *mock_async.MockReconcilerMockRecorder, tagsGetter *mock_async.MockTagsGetterMockRecorder)
+		expect        func(s *mock_privatedns.MockScopeMockRecorder, linkReconciler, zoneReconciler, recordReconciler *mock_async.MockReconcilerMockRecorder, tagsGetter *mock_async.MockTagsGetterMockRecorder)
 	}{
 		{
 			name:          "no private dns",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(nil, nil, nil)
 			},
 		},
 		{
 			name:          "dns and links deletion succeeds",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -396,7 +416,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 		{
 			name:          "skips if zone and links are unmanaged",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -410,12 +430,13 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, nil)
 				s.ClusterName().Return(clusterName)
+				s.IsPrivateDNSZoneBYO().Return(false)
 			},
 		},
 		{
 			name:          "skips if unmanaged, but deletes the next resource if it is managed",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -440,7 +461,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 		{
 			name:          "link1 is deleted, link2 is long running. It returns not done error",
 			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1})
 
 				s.SubscriptionID().Return("123")
@@ -460,7 +481,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 		{
 			name:          "link1 deletion fails and link2 is long running, returns the more pressing error",
 			expectedError: "this is an error",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1})
 
 				s.SubscriptionID().Return("123")
@@ -480,7 +501,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 		{
 			name:          "links are deleted, zone is long running",
 			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -509,7 +530,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 		{
 			name:          "links are deleted, zone deletion fails with error",
 			expectedError: "this is an error",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -535,6 +556,48 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
 			},
 		},
+		{
+			name:          "deletes links and records but not an existing zone",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
+
+				s.SubscriptionID().Return("123")
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return(clusterName)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
+
+				s.SubscriptionID().Return("123")
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, nil)
+				s.ClusterName().Return(clusterName)
+
+				s.IsPrivateDNSZoneBYO().Return(true)
+				rr.DeleteResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "record deletion in an existing zone fails",
+			expectedError: "this is an error",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
+
+				s.SubscriptionID().Return("123")
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return(clusterName)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
+
+				s.SubscriptionID().Return("123")
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, nil)
+				s.ClusterName().Return(clusterName)
+
+				s.IsPrivateDNSZoneBYO().Return(true)
+				rr.DeleteResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -548,14 +611,16 @@ func TestDeletePrivateDNS(t *testing.T) {
 			scopeMock := mock_privatedns.NewMockScope(mockCtrl)
 			vnetLinkReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
 			zoneReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			recordReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
 			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), zoneReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), zoneReconcilerMock.EXPECT(), recordReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
 
 			s := &Service{
 				Scope:              scopeMock,
 				zoneReconciler:     zoneReconcilerMock,
 				vnetLinkReconciler: vnetLinkReconcilerMock,
+				recordReconciler:   recordReconcilerMock,
 				TagsGetter:         tagsGetterMock,
 			}
 
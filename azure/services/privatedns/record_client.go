@@ -23,6 +23,7 @@ import (
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -76,9 +77,19 @@ func (arc *azureRecordsClient) Get(ctx context.Context, spec azure.ResourceSpecG
 	return nil, nil
 }
 
-// DeleteAsync deletes a record asynchronously. Noop for records.
+// DeleteAsync deletes a record asynchronously.
+// Deleting a record set is not a long running operation, so we don't ever return a future.
 func (arc *azureRecordsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.DeleteAsync")
+	defer done()
+
+	recordSpec, ok := spec.(RecordSpec)
+	if !ok {
+		return nil, errors.Errorf("%T is not a RecordSpec", spec)
+	}
+
+	_, err = arc.recordsets.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), converters.GetRecordType(recordSpec.Record.IP), spec.ResourceName(), "")
+	return nil, err
 }
 
 // IsDone returns true if the long-running operation has completed. Noop for records.
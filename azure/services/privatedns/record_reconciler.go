@@ -42,3 +42,23 @@ func (s *Service) reconcileRecords(ctx context.Context, records []azure.Resource
 
 	return resErr
 }
+
+func (s *Service) deleteRecords(ctx context.Context, records []azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.deleteRecords")
+	defer done()
+
+	var resErr error
+
+	// We go through the list of records to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	for _, recordSpec := range records {
+		if err := s.recordReconciler.DeleteResource(ctx, recordSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	return resErr
+}
@@ -32,6 +32,10 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 	managed, err = s.IsManaged(ctx)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
+			// An existing zone referenced by resource ID is never created by capz.
+			if s.Scope.IsPrivateDNSZoneBYO() {
+				return false, errors.Errorf("private DNS zone %s not found in resource group %s", zoneSpec.ResourceName(), zoneSpec.ResourceGroupName())
+			}
 			managed = true
 		} else {
 			return managed, err
@@ -52,7 +52,7 @@ func newPrivateEndpointClient(subscriptionID string, baseURI string, authorizer
 func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (interface{}, error) {
 	ctx, span := tele.Tracer().Start(ctx, "privateendpoints.AzureClient.Get")
 	defer span.End()
-	return ac.privateendpoints.Get(ctx, spec.OwnerResourceName(), spec.ResourceName(), "")
+	return ac.privateendpoints.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
 }
 
 // CreateOrUpdateAsync creates a private endpoint.
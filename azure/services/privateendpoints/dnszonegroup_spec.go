@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+)
+
+// PrivateDNSZoneGroupSpec defines the specification for a private DNS zone group of a private endpoint.
+type PrivateDNSZoneGroupSpec struct {
+	Name                string
+	PrivateEndpointName string
+	ResourceGroup       string
+	PrivateDNSZoneIDs   []string
+}
+
+// ResourceName returns the name of the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateDNSZoneGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the private endpoint that owns the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) OwnerResourceName() string {
+	return s.PrivateEndpointName
+}
+
+// Parameters returns the parameters for the PrivateDNSZoneGroupSpec.
+func (s *PrivateDNSZoneGroupSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	if existing != nil {
+		existingGroup, ok := existing.(network.PrivateDNSZoneGroup)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateDNSZoneGroup", existing)
+		}
+
+		var existingZoneIDs []string
+		if existingGroup.PrivateDNSZoneGroupPropertiesFormat != nil && existingGroup.PrivateDNSZoneConfigs != nil {
+			for _, config := range *existingGroup.PrivateDNSZoneConfigs {
+				if config.PrivateDNSZonePropertiesFormat != nil {
+					existingZoneIDs = append(existingZoneIDs, strings.ToLower(pointer.StringDeref(config.PrivateDNSZoneID, "")))
+				}
+			}
+		}
+		desiredZoneIDs := make([]string, 0, len(s.PrivateDNSZoneIDs))
+		for _, zoneID := range s.PrivateDNSZoneIDs {
+			desiredZoneIDs = append(desiredZoneIDs, strings.ToLower(zoneID))
+		}
+		sort.Strings(existingZoneIDs)
+		sort.Strings(desiredZoneIDs)
+		if strings.Join(existingZoneIDs, ",") == strings.Join(desiredZoneIDs, ",") {
+			// private DNS zone group is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	configs := make([]network.PrivateDNSZoneConfig, 0, len(s.PrivateDNSZoneIDs))
+	for _, zoneID := range s.PrivateDNSZoneIDs {
+		configs = append(configs, network.PrivateDNSZoneConfig{
+			Name: pointer.String(privateDNSZoneConfigName(zoneID)),
+			PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+				PrivateDNSZoneID: pointer.String(zoneID),
+			},
+		})
+	}
+
+	return network.PrivateDNSZoneGroup{
+		Name: pointer.String(s.Name),
+		PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+			PrivateDNSZoneConfigs: &configs,
+		},
+	}, nil
+}
+
+// privateDNSZoneConfigName returns the name of the zone config for a private DNS zone ID,
+// e.g. privatelink-vaultcore-azure-net for the privatelink.vaultcore.azure.net zone.
+func privateDNSZoneConfigName(zoneID string) string {
+	return strings.ReplaceAll(zoneID[strings.LastIndex(zoneID, "/")+1:], ".", "-")
+}
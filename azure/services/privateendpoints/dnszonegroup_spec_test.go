@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	fakeVaultZoneID = "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"
+	fakeBlobZoneID  = "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+)
+
+var fakePrivateDNSZoneGroup = network.PrivateDNSZoneGroup{
+	Name: pointer.String("test-private-endpoint-dns-zone-group"),
+	PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+		PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+			{
+				Name:                           pointer.String("privatelink-vaultcore-azure-net"),
+				PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{PrivateDNSZoneID: pointer.String(fakeVaultZoneID)},
+			},
+			{
+				Name:                           pointer.String("privatelink-blob-core-windows-net"),
+				PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{PrivateDNSZoneID: pointer.String(fakeBlobZoneID)},
+			},
+		},
+	},
+}
+
+func TestPrivateDNSZoneGroupParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *PrivateDNSZoneGroupSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "new private DNS zone group",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:                "test-private-endpoint-dns-zone-group",
+				PrivateEndpointName: "test-private-endpoint",
+				ResourceGroup:       "test-group",
+				PrivateDNSZoneIDs:   []string{fakeVaultZoneID, fakeBlobZoneID},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakePrivateDNSZoneGroup))
+			},
+		},
+		{
+			name: "private DNS zone group already exists with the same zones",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:                "test-private-endpoint-dns-zone-group",
+				PrivateEndpointName: "test-private-endpoint",
+				ResourceGroup:       "test-group",
+				PrivateDNSZoneIDs:   []string{fakeBlobZoneID, fakeVaultZoneID},
+			},
+			existing: fakePrivateDNSZoneGroup,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "private DNS zone group already exists with different zones",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:                "test-private-endpoint-dns-zone-group",
+				PrivateEndpointName: "test-private-endpoint",
+				ResourceGroup:       "test-group",
+				PrivateDNSZoneIDs:   []string{fakeVaultZoneID},
+			},
+			existing: fakePrivateDNSZoneGroup,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateDNSZoneGroup{
+					Name: pointer.String("test-private-endpoint-dns-zone-group"),
+					PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+						PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+							(*fakePrivateDNSZoneGroup.PrivateDNSZoneConfigs)[0],
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "existing is not a private DNS zone group",
+			spec: &PrivateDNSZoneGroupSpec{
+				Name:              "test-private-endpoint-dns-zone-group",
+				PrivateDNSZoneIDs: []string{fakeVaultZoneID},
+			},
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a network.PrivateDNSZoneGroup",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
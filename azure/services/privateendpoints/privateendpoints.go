@@ -40,14 +40,17 @@ type PrivateEndpointScope interface {
 type Service struct {
 	Scope PrivateEndpointScope
 	async.Reconciler
+	dnsZoneGroupReconciler async.Reconciler
 }
 
 // New creates a new service.
 func New(scope PrivateEndpointScope) *Service {
 	Client := newClient(scope)
+	dnsZoneGroupsClient := newDNSZoneGroupsClient(scope)
 	return &Service{
-		Scope:      scope,
-		Reconciler: async.New(scope, Client, Client),
+		Scope:                  scope,
+		Reconciler:             async.New(scope, Client, Client),
+		dnsZoneGroupReconciler: async.New(scope, dnsZoneGroupsClient, dnsZoneGroupsClient),
 	}
 }
 
@@ -56,12 +59,12 @@ func (s *Service) Name() string {
 	return ServiceName
 }
 
-// Reconcile idempotently creates or updates a private endpoint.
+// Reconcile idempotently creates or updates a private endpoint and its private DNS zone group.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, ServiceName))
 	defer cancel()
 
 	specs := s.Scope.PrivateEndpointSpecs()
@@ -81,6 +84,21 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
+			continue
+		}
+
+		// The private DNS zone group is a child resource of the private endpoint, so it can only be
+		// created once the private endpoint exists. It is deleted by Azure along with the private endpoint.
+		peSpec, ok := privateEndpointSpec.(*PrivateEndpointSpec)
+		if !ok {
+			continue
+		}
+		if dnsZoneGroupSpec := peSpec.PrivateDNSZoneGroupSpec(); dnsZoneGroupSpec != nil {
+			if _, err := s.dnsZoneGroupReconciler.CreateOrUpdateResource(ctx, dnsZoneGroupSpec, ServiceName); err != nil {
+				if !azure.IsOperationNotDoneError(err) || result == nil {
+					result = err
+				}
+			}
 		}
 	}
 
@@ -93,7 +111,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, ServiceName))
 	defer cancel()
 
 	specs := s.Scope.PrivateEndpointSpecs()
@@ -162,6 +162,83 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 	}
 }
 
+func TestReconcilePrivateEndpointWithPrivateDNSZoneGroup(t *testing.T) {
+	fakePrivateEndpointWithDNSZones := PrivateEndpointSpec{
+		Name:                          "fake-private-endpoint-dns",
+		PrivateLinkServiceConnections: []PrivateLinkServiceConnection{{PrivateLinkServiceID: "testPl", GroupIDs: []string{"vault"}}},
+		SubnetID:                      "mySubnet",
+		ResourceGroup:                 "my-rg",
+		PrivateDNSZoneIDs:             []string{fakeVaultZoneID},
+	}
+	fakeDNSZoneGroupSpec := fakePrivateEndpointWithDNSZones.PrivateDNSZoneGroupSpec()
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "create a private endpoint and its private DNS zone group",
+			expectedError: "",
+			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointWithDNSZones, &fakePrivateEndpoint1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointWithDNSZones, ServiceName).Return(&fakePrivateEndpointWithDNSZones, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeDNSZoneGroupSpec, ServiceName).Return(fakeDNSZoneGroupSpec, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(&fakePrivateEndpoint1, nil)
+				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "private DNS zone group is not created before the private endpoint",
+			expectedError: "operation type  on Azure resource / is not done",
+			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointWithDNSZones})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointWithDNSZones, ServiceName).Return(nil, notDoneError)
+				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "return error when creating the private DNS zone group fails",
+			expectedError: internalError.Error(),
+			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointWithDNSZones})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointWithDNSZones, ServiceName).Return(&fakePrivateEndpointWithDNSZones, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeDNSZoneGroupSpec, ServiceName).Return(nil, internalError)
+				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privateendpoints.NewMockPrivateEndpointScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			dnsZoneGroupAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), dnsZoneGroupAsyncMock.EXPECT())
+
+			s := &Service{
+				Scope:                  scopeMock,
+				Reconciler:             asyncMock,
+				dnsZoneGroupReconciler: dnsZoneGroupAsyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeletePrivateEndpoints(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -52,6 +52,7 @@ type PrivateEndpointSpec struct {
 	PrivateLinkServiceConnections []PrivateLinkServiceConnection
 	AdditionalTags                infrav1.Tags
 	ClusterName                   string
+	PrivateDNSZoneIDs             []string
 }
 
 // ResourceName returns the name of the private endpoint.
@@ -69,6 +70,20 @@ func (s *PrivateEndpointSpec) OwnerResourceName() string {
 	return ""
 }
 
+// PrivateDNSZoneGroupSpec returns the spec of the private DNS zone group of the private endpoint,
+// or nil if the private endpoint isn't linked to any private DNS zone.
+func (s *PrivateEndpointSpec) PrivateDNSZoneGroupSpec() *PrivateDNSZoneGroupSpec {
+	if len(s.PrivateDNSZoneIDs) == 0 {
+		return nil
+	}
+	return &PrivateDNSZoneGroupSpec{
+		Name:                azure.GeneratePrivateDNSZoneGroupName(s.Name),
+		PrivateEndpointName: s.Name,
+		ResourceGroup:       s.ResourceGroup,
+		PrivateDNSZoneIDs:   s.PrivateDNSZoneIDs,
+	}
+}
+
 // Parameters returns the parameters for the PrivateEndpointSpec.
 func (s *PrivateEndpointSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
 	_, log, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Parameters")
@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// ServiceName is the name of this service.
+	ServiceName = "privatelinks"
+
+	// aliasRequeueInterval is how long to wait before checking again on a private link service that has no alias yet.
+	aliasRequeueInterval = 15 * time.Second
+)
+
+// PrivateLinkServiceScope defines the scope interface for a private link service.
+type PrivateLinkServiceScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	APIServerPrivateLinkServiceSpec() azure.ResourceSpecGetter
+	SetAPIServerPrivateLinkServiceAlias(alias string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PrivateLinkServiceScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PrivateLinkServiceScope) *Service {
+	client := newPrivateLinkServicesClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the private link service of the API server load balancer and stores its alias.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinks.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, ServiceName))
+	defer cancel()
+
+	plsSpec := s.Scope.APIServerPrivateLinkServiceSpec()
+	if plsSpec == nil {
+		return nil
+	}
+
+	result, resultingErr := s.CreateOrUpdateResource(ctx, plsSpec, ServiceName)
+	if resultingErr == nil {
+		resultingErr = s.setAlias(plsSpec.ResourceName(), result)
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, resultingErr)
+	return resultingErr
+}
+
+// setAlias stores the alias of the private link service, which clients need to create private endpoints connected to it.
+func (s *Service) setAlias(name string, result interface{}) error {
+	pls, ok := result.(network.PrivateLinkService)
+	if !ok {
+		return errors.Errorf("%T is not a network.PrivateLinkService", result)
+	}
+	if pls.PrivateLinkServiceProperties == nil || pointer.StringDeref(pls.Alias, "") == "" {
+		return azure.WithTransientError(errors.Errorf("private link service %s has no alias yet", name), aliasRequeueInterval)
+	}
+	s.Scope.SetAPIServerPrivateLinkServiceAlias(*pls.Alias)
+	return nil
+}
+
+// Delete deletes the private link service.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinks.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, ServiceName))
+	defer cancel()
+
+	plsSpec := s.Scope.APIServerPrivateLinkServiceSpec()
+	if plsSpec == nil {
+		return nil
+	}
+
+	err := s.DeleteResource(ctx, plsSpec, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, err)
+	return err
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO private link services.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinks/mock_privatelinks"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePLSSpec = PrivateLinkServiceSpec{
+		Name:                  "my-cluster-apiserver-pls",
+		ResourceGroup:         "my-rg",
+		Location:              "westus",
+		ClusterName:           "my-cluster",
+		SubnetID:              "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-cp-subnet",
+		LBFrontendIPConfigIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb/frontendIPConfigurations/my-lb-frontEnd"},
+	}
+	fakePLS = func(alias string) network.PrivateLinkService {
+		return network.PrivateLinkService{
+			Name: pointer.String("my-cluster-apiserver-pls"),
+			PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+				Alias: pointer.String(alias),
+			},
+		}
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{Type: "resourceType", ResourceGroup: "my-rg", Name: "resourceName"})
+)
+
+func TestReconcilePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no private link service spec",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "private link service successfully created",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(&fakePLSSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePLSSpec, ServiceName).Return(fakePLS("my-cluster-apiserver-pls.guid.westus.azure.privatelinkservice"), nil)
+				s.SetAPIServerPrivateLinkServiceAlias("my-cluster-apiserver-pls.guid.westus.azure.privatelinkservice")
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "private link service creation in progress",
+			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(&fakePLSSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePLSSpec, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, notDoneError)
+			},
+		},
+		{
+			name:          "private link service creation fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(&fakePLSSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePLSSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "private link service without alias requeues",
+			expectedError: "private link service my-cluster-apiserver-pls has no alias yet. Object will be requeued after 15s",
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(&fakePLSSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePLSSpec, ServiceName).Return(fakePLS(""), nil)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, gomockinternal.ErrStrEq("private link service my-cluster-apiserver-pls has no alias yet. Object will be requeued after 15s"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinks.NewMockPrivateLinkServiceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no private link service spec",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "private link service successfully deleted",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(&fakePLSSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePLSSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "private link service deletion fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privatelinks.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerPrivateLinkServiceSpec().Return(&fakePLSSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePLSSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinks.NewMockPrivateLinkServiceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PrivateLinkServiceSpec defines the specification for a private link service attached to a load balancer.
+type PrivateLinkServiceSpec struct {
+	Name                      string
+	ResourceGroup             string
+	Location                  string
+	ClusterName               string
+	SubnetID                  string
+	LBFrontendIPConfigIDs     []string
+	AllowedSubscriptions      []string
+	AutoApprovedSubscriptions []string
+	EnableProxyProtocol       *bool
+	AdditionalTags            infrav1.Tags
+}
+
+// ResourceName returns the name of the private link service.
+func (s *PrivateLinkServiceSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateLinkServiceSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private link services.
+func (s *PrivateLinkServiceSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private link service.
+func (s *PrivateLinkServiceSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	if existing != nil {
+		existingPLS, ok := existing.(network.PrivateLinkService)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateLinkService", existing)
+		}
+		// Only the subscription lists can be updated.
+		if existingPLS.PrivateLinkServiceProperties != nil &&
+			existingPLS.ProvisioningState != network.ProvisioningStateFailed &&
+			equalSubscriptions(s.AllowedSubscriptions, visibilitySubscriptions(existingPLS)) &&
+			equalSubscriptions(s.AutoApprovedSubscriptions, autoApprovalSubscriptions(existingPLS)) {
+			// private link service is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	frontendIPConfigs := make([]network.FrontendIPConfiguration, 0, len(s.LBFrontendIPConfigIDs))
+	for _, id := range s.LBFrontendIPConfigIDs {
+		frontendIPConfigs = append(frontendIPConfigs, network.FrontendIPConfiguration{ID: pointer.String(id)})
+	}
+
+	return network.PrivateLinkService{
+		Name:     pointer.String(s.Name),
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Role:        pointer.String(infrav1.APIServerRole),
+			Additional:  s.AdditionalTags,
+		})),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: &frontendIPConfigs,
+			IPConfigurations: &[]network.PrivateLinkServiceIPConfiguration{
+				{
+					Name: pointer.String(fmt.Sprintf("%s-nat-ipconfig", s.Name)),
+					PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+						PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+						PrivateIPAddressVersion:   network.IPVersionIPv4,
+						Primary:                   pointer.Bool(true),
+						Subnet: &network.Subnet{
+							ID: pointer.String(s.SubnetID),
+						},
+					},
+				},
+			},
+			Visibility: &network.PrivateLinkServicePropertiesVisibility{
+				Subscriptions: &s.AllowedSubscriptions,
+			},
+			AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{
+				Subscriptions: &s.AutoApprovedSubscriptions,
+			},
+			EnableProxyProtocol: s.EnableProxyProtocol,
+		},
+	}, nil
+}
+
+// visibilitySubscriptions returns the subscriptions allowed to connect to a private link service.
+func visibilitySubscriptions(pls network.PrivateLinkService) []string {
+	if pls.Visibility == nil || pls.Visibility.Subscriptions == nil {
+		return nil
+	}
+	return *pls.Visibility.Subscriptions
+}
+
+// autoApprovalSubscriptions returns the subscriptions whose connections to a private link service are approved automatically.
+func autoApprovalSubscriptions(pls network.PrivateLinkService) []string {
+	if pls.AutoApproval == nil || pls.AutoApproval.Subscriptions == nil {
+		return nil
+	}
+	return *pls.AutoApproval.Subscriptions
+}
+
+// equalSubscriptions returns true if both lists contain the same subscriptions, regardless of order and case.
+func equalSubscriptions(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	normalize := func(subscriptions []string) []string {
+		normalized := make([]string, 0, len(subscriptions))
+		for _, subscription := range subscriptions {
+			normalized = append(normalized, strings.ToLower(subscription))
+		}
+		sort.Strings(normalized)
+		return normalized
+	}
+	normalizedA, normalizedB := normalize(a), normalize(b)
+	for i := range normalizedA {
+		if normalizedA[i] != normalizedB[i] {
+			return false
+		}
+	}
+	return true
+}
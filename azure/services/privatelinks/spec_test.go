@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestPrivateLinkServiceSpecParameters(t *testing.T) {
+	specWithSubscriptions := fakePLSSpec
+	specWithSubscriptions.AllowedSubscriptions = []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"}
+	specWithSubscriptions.AutoApprovedSubscriptions = []string{"00000000-0000-0000-0000-000000000001"}
+	specWithSubscriptions.EnableProxyProtocol = pointer.Bool(true)
+
+	existingPLS := func(allowed, autoApproved []string, state network.ProvisioningState) network.PrivateLinkService {
+		return network.PrivateLinkService{
+			Name: pointer.String("my-cluster-apiserver-pls"),
+			PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+				ProvisioningState: state,
+				Visibility:        &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &allowed},
+				AutoApproval:      &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &autoApproved},
+				Alias:             pointer.String("my-cluster-apiserver-pls.guid.westus.azure.privatelinkservice"),
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *PrivateLinkServiceSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new private link service",
+			spec:     &specWithSubscriptions,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateLinkService{}))
+				pls := result.(network.PrivateLinkService)
+				g.Expect(*pls.Name).To(Equal("my-cluster-apiserver-pls"))
+				g.Expect(*pls.Location).To(Equal("westus"))
+				g.Expect(*pls.LoadBalancerFrontendIPConfigurations).To(HaveLen(1))
+				g.Expect(*(*pls.LoadBalancerFrontendIPConfigurations)[0].ID).To(Equal(fakePLSSpec.LBFrontendIPConfigIDs[0]))
+				g.Expect(*pls.IPConfigurations).To(HaveLen(1))
+				g.Expect(*(*pls.IPConfigurations)[0].Name).To(Equal("my-cluster-apiserver-pls-nat-ipconfig"))
+				g.Expect((*pls.IPConfigurations)[0].PrivateIPAllocationMethod).To(Equal(network.IPAllocationMethodDynamic))
+				g.Expect(*(*pls.IPConfigurations)[0].Subnet.ID).To(Equal(fakePLSSpec.SubnetID))
+				g.Expect(*pls.Visibility.Subscriptions).To(Equal(specWithSubscriptions.AllowedSubscriptions))
+				g.Expect(*pls.AutoApproval.Subscriptions).To(Equal(specWithSubscriptions.AutoApprovedSubscriptions))
+				g.Expect(*pls.EnableProxyProtocol).To(BeTrue())
+				g.Expect(pls.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", HaveValue(Equal("owned"))))
+			},
+		},
+		{
+			name: "existing private link service is up to date",
+			spec: &specWithSubscriptions,
+			existing: existingPLS(
+				[]string{"00000000-0000-0000-0000-000000000002", "00000000-0000-0000-0000-000000000001"},
+				[]string{"00000000-0000-0000-0000-000000000001"},
+				network.ProvisioningStateSucceeded),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing private link service with different subscriptions is updated",
+			spec: &specWithSubscriptions,
+			existing: existingPLS(
+				[]string{"00000000-0000-0000-0000-000000000001"},
+				[]string{"00000000-0000-0000-0000-000000000001"},
+				network.ProvisioningStateSucceeded),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateLinkService{}))
+				pls := result.(network.PrivateLinkService)
+				g.Expect(*pls.Visibility.Subscriptions).To(Equal(specWithSubscriptions.AllowedSubscriptions))
+			},
+		},
+		{
+			name:     "failed private link service is updated",
+			spec:     &fakePLSSpec,
+			existing: existingPLS(nil, nil, network.ProvisioningStateFailed),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateLinkService{}))
+			},
+		},
+		{
+			name:     "existing is not a private link service",
+			spec:     &fakePLSSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a network.PrivateLinkService",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
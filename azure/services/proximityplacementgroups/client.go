@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	proximityPlacementGroups compute.ProximityPlacementGroupsClient
+}
+
+// newClient creates a new proximity placement groups client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newProximityPlacementGroupsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{c}
+}
+
+// newProximityPlacementGroupsClient creates a new proximity placement groups client from subscription ID.
+func newProximityPlacementGroupsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.ProximityPlacementGroupsClient {
+	ppgClient := compute.NewProximityPlacementGroupsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&ppgClient.Client, authorizer)
+	return ppgClient
+}
+
+// Get gets the specified proximity placement group.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.azureClient.Get")
+	defer done()
+
+	return ac.proximityPlacementGroups.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
+}
+
+// CreateOrUpdateAsync creates or updates a proximity placement group.
+// Proximity placement groups are created synchronously, so a nil future is always returned.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	ppg, ok := parameters.(compute.ProximityPlacementGroup)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a compute.ProximityPlacementGroup", parameters)
+	}
+
+	result, err = ac.proximityPlacementGroups.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), ppg)
+	return result, nil, err
+}
+
+// DeleteAsync deletes a proximity placement group.
+// Proximity placement groups are deleted synchronously, so a nil future is always returned.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.azureClient.DeleteAsync")
+	defer done()
+
+	_, err = ac.proximityPlacementGroups.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.azureClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.proximityPlacementGroups)
+}
+
+// Result fetches the result of a long-running operation future.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	// Result is a no-op for proximity placement groups as their operations never return a future.
+	return nil, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "proximityplacementgroups"
+
+// ProximityPlacementGroupScope defines the scope interface for a proximity placement groups service.
+type ProximityPlacementGroupScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ProximityPlacementGroupSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ProximityPlacementGroupScope
+	async.Reconciler
+}
+
+// New creates a new proximity placement groups service.
+func New(scope ProximityPlacementGroupScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates a set of proximity placement groups.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	specs := s.Scope.ProximityPlacementGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of proximity placement groups to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resErr error
+	for _, ppgSpec := range specs {
+		if _, err := s.CreateOrUpdateResource(ctx, ppgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, resErr)
+	return resErr
+}
+
+// Delete deletes proximity placement groups.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	specs := s.Scope.ProximityPlacementGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We go through the list of proximity placement groups to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var resErr error
+	for _, ppgSpec := range specs {
+		if err := s.DeleteResource(ctx, ppgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, resErr)
+	return resErr
+}
+
+// IsManaged returns always returns true as CAPZ only reconciles the proximity placement groups of the AzureCluster spec.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
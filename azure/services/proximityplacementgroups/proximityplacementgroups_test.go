@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups/mock_proximityplacementgroups"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePPG = ProximityPlacementGroupSpec{
+		Name:          "test-ppg-1",
+		ResourceGroup: "test-rg",
+		Location:      "fake-location",
+		ClusterName:   "test-cluster",
+		AdditionalTags: map[string]string{
+			"foo": "bar",
+		},
+	}
+	fakePPG2 = ProximityPlacementGroupSpec{
+		Name:          "test-ppg-2",
+		ResourceGroup: "test-rg",
+		Location:      "fake-location",
+		ClusterName:   "test-cluster",
+	}
+	errFake      = errors.New("this is an error")
+	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
+)
+
+func TestReconcileProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no proximity placement group specs are found",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create multiple proximity placement groups succeeds",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPG, &fakePPG2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePPG, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePPG2, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "first proximity placement group create fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPG, &fakePPG2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePPG, serviceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePPG2, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "second proximity placement group create not done",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPG, &fakePPG2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePPG, serviceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePPG2, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_proximityplacementgroups.NewMockProximityPlacementGroupScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no proximity placement group specs are found",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete multiple proximity placement groups succeeds",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPG, &fakePPG2})
+				r.DeleteResource(gomockinternal.AContext(), &fakePPG, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePPG2, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "first proximity placement group delete fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPG, &fakePPG2})
+				r.DeleteResource(gomockinternal.AContext(), &fakePPG, serviceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakePPG2, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "second proximity placement group delete not done",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPG, &fakePPG2})
+				r.DeleteResource(gomockinternal.AContext(), &fakePPG, serviceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakePPG2, serviceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.ProximityPlacementGroupsReadyCondition, serviceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_proximityplacementgroups.NewMockProximityPlacementGroupScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
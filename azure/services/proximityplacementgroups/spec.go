@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// ProximityPlacementGroupSpec defines the specification for a proximity placement group.
+type ProximityPlacementGroupSpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the proximity placement group.
+func (s *ProximityPlacementGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *ProximityPlacementGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for proximity placement groups.
+func (s *ProximityPlacementGroupSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the proximity placement group.
+func (s *ProximityPlacementGroupSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(compute.ProximityPlacementGroup); !ok {
+			return nil, errors.Errorf("%T is not a compute.ProximityPlacementGroup", existing)
+		}
+		// proximity placement group already exists
+		return nil, nil
+	}
+
+	return compute.ProximityPlacementGroup{
+		Location: pointer.String(s.Location),
+		ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+			ProximityPlacementGroupType: compute.ProximityPlacementGroupTypeStandard,
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Role:        pointer.String(infrav1.CommonRole),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
@@ -24,7 +24,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
-	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcegraph"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -49,7 +49,7 @@ type Service struct {
 // New creates a new service.
 func New(scope PublicIPScope) *Service {
 	client := NewClient(scope)
-	tagsClient := tags.NewClient(scope)
+	tagsClient := resourcegraph.NewTagsGetter(scope)
 	return &Service{
 		Scope:      scope,
 		Getter:     client,
@@ -39,6 +39,7 @@ type PublicIPSpec struct {
 	FailureDomains   []string
 	AdditionalTags   infrav1.Tags
 	IPTags           []infrav1.IPTag
+	PublicIPPrefixID string
 }
 
 // ResourceName returns the name of the public IP.
@@ -59,11 +60,12 @@ func (s *PublicIPSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the public IP.
 func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.PublicIPAddress); !ok {
+		existingPublicIP, ok := existing.(network.PublicIPAddress)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.PublicIPAddress", existing)
 		}
-		// public IP already exists
-		return nil, nil
+		// public IP already exists, only its DNS label is reconciled
+		return s.updatedParameters(existingPublicIP), nil
 	}
 
 	addressVersion := network.IPVersionIPv4
@@ -72,12 +74,11 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 	}
 
 	// only set DNS properties if there is a DNS name specified
-	var dnsSettings *network.PublicIPAddressDNSSettings
-	if s.DNSName != "" {
-		dnsSettings = &network.PublicIPAddressDNSSettings{
-			DomainNameLabel: pointer.String(strings.Split(s.DNSName, ".")[0]),
-			Fqdn:            pointer.String(s.DNSName),
-		}
+	dnsSettings := s.dnsSettings()
+
+	var publicIPPrefix *network.SubResource
+	if s.PublicIPPrefixID != "" {
+		publicIPPrefix = &network.SubResource{ID: pointer.String(s.PublicIPPrefixID)}
 	}
 
 	return network.PublicIPAddress{
@@ -96,7 +97,40 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
 			DNSSettings:              dnsSettings,
 			IPTags:                   converters.IPTagsToSDK(s.IPTags),
+			PublicIPPrefix:           publicIPPrefix,
 		},
 		Zones: &s.FailureDomains,
 	}, nil
 }
+
+// dnsSettings returns the DNS settings of the public IP, or nil if it has no DNS name.
+// The DNS name is either a fully qualified domain name or only a DNS label.
+func (s *PublicIPSpec) dnsSettings() *network.PublicIPAddressDNSSettings {
+	if s.DNSName == "" {
+		return nil
+	}
+	dnsSettings := &network.PublicIPAddressDNSSettings{
+		DomainNameLabel: pointer.String(strings.Split(s.DNSName, ".")[0]),
+	}
+	if strings.Contains(s.DNSName, ".") {
+		dnsSettings.Fqdn = pointer.String(s.DNSName)
+	}
+	return dnsSettings
+}
+
+// updatedParameters returns the existing public IP with its DNS label set to the one of the spec,
+// or nil if no update is needed. The DNS label of a public IP is left untouched if the spec has no DNS name.
+func (s *PublicIPSpec) updatedParameters(existing network.PublicIPAddress) interface{} {
+	dnsSettings := s.dnsSettings()
+	if dnsSettings == nil || existing.PublicIPAddressPropertiesFormat == nil {
+		return nil
+	}
+	if existing.DNSSettings != nil && pointer.StringDeref(existing.DNSSettings.DomainNameLabel, "") == *dnsSettings.DomainNameLabel {
+		return nil
+	}
+
+	existing.DNSSettings = &network.PublicIPAddressDNSSettings{
+		DomainNameLabel: dnsSettings.DomainNameLabel,
+	}
+	return existing
+}
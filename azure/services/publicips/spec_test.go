@@ -50,6 +50,14 @@ var (
 		FailureDomains: []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
 	}
 
+	fakePublicIPSpecWithPrefix = PublicIPSpec{
+		Name:             "my-publicip-3",
+		Location:         "centralIndia",
+		ClusterName:      "my-cluster",
+		FailureDomains:   []string{"failure-domain-id-1"},
+		PublicIPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+	}
+
 	fakePublicIPWithDNS = network.PublicIPAddress{
 		Name:     pointer.String("my-publicip"),
 		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
@@ -86,6 +94,24 @@ var (
 		Zones: &[]string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
 	}
 
+	fakePublicIPWithPrefix = network.PublicIPAddress{
+		Name:     pointer.String("my-publicip-3"),
+		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+		Location: pointer.String("centralIndia"),
+		Tags: map[string]*string{
+			"Name": pointer.String("my-publicip-3"),
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+		},
+		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			PublicIPAddressVersion:   network.IPVersionIPv4,
+			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+			PublicIPPrefix: &network.SubResource{
+				ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+			},
+		},
+		Zones: &[]string{"failure-domain-id-1"},
+	}
+
 	fakePublicIPIpv6 = network.PublicIPAddress{
 		Name:     pointer.String("my-publicip-ipv6"),
 		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
@@ -122,6 +148,68 @@ func TestParameters(t *testing.T) {
 			expected:      nil,
 			expectedError: "",
 		},
+		{
+			name: "update DNS label of existing public IP",
+			existing: network.PublicIPAddress{
+				Name: pointer.String("my-publicip"),
+				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+					IPAddress: pointer.String("20.1.2.3"),
+					DNSSettings: &network.PublicIPAddressDNSSettings{
+						DomainNameLabel: pointer.String("olddns"),
+						Fqdn:            pointer.String("olddns.centralindia.cloudapp.azure.com"),
+					},
+				},
+			},
+			spec: fakePublicIPSpecWithDNS,
+			expected: network.PublicIPAddress{
+				Name: pointer.String("my-publicip"),
+				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+					IPAddress: pointer.String("20.1.2.3"),
+					DNSSettings: &network.PublicIPAddressDNSSettings{
+						DomainNameLabel: pointer.String("fakedns"),
+					},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name:          "noop if existing public IP has a DNS label but the spec has no DNS name",
+			existing:      fakePublicIPWithDNS,
+			spec:          fakePublicIPSpecWithoutDNS,
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name:     "public ipv4 address with a DNS label only",
+			existing: nil,
+			spec: PublicIPSpec{
+				Name:           "my-publicip-4",
+				DNSName:        "mylabel",
+				Location:       "centralIndia",
+				ClusterName:    "my-cluster",
+				IPTags:         []infrav1.IPTag{{Type: "RoutingPreference", Tag: "Internet"}},
+				FailureDomains: []string{"failure-domain-id-1"},
+			},
+			expected: network.PublicIPAddress{
+				Name:     pointer.String("my-publicip-4"),
+				Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+				Location: pointer.String("centralIndia"),
+				Tags: map[string]*string{
+					"Name": pointer.String("my-publicip-4"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				},
+				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+					PublicIPAddressVersion:   network.IPVersionIPv4,
+					PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+					DNSSettings: &network.PublicIPAddressDNSSettings{
+						DomainNameLabel: pointer.String("mylabel"),
+					},
+					IPTags: &[]network.IPTag{{IPTagType: pointer.String("RoutingPreference"), Tag: pointer.String("Internet")}},
+				},
+				Zones: &[]string{"failure-domain-id-1"},
+			},
+			expectedError: "",
+		},
 		{
 			name:          "public ipv4 address with dns",
 			existing:      nil,
@@ -143,6 +231,13 @@ func TestParameters(t *testing.T) {
 			expected:      fakePublicIPIpv6,
 			expectedError: "",
 		},
+		{
+			name:          "public ipv4 address from a public IP prefix",
+			existing:      nil,
+			spec:          fakePublicIPSpecWithPrefix,
+			expected:      fakePublicIPWithPrefix,
+			expectedError: "",
+		},
 	}
 
 	for _, tc := range testCases {
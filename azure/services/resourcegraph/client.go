@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcegraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/services/resourcegraph/mgmt/2021-03-01/resourcegraph"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Resource is a row of a Resource Graph query projecting the id, name, type, resourceGroup and tags columns.
+type Resource struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	Type          string             `json:"type"`
+	ResourceGroup string             `json:"resourceGroup"`
+	Tags          map[string]*string `json:"tags"`
+}
+
+// Client wraps go-sdk.
+type Client interface {
+	Query(ctx context.Context, query string) ([]Resource, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	resourcegraph  resourcegraph.BaseClient
+	subscriptionID string
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new Resource Graph client.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		resourcegraph:  newResourceGraphClient(auth.BaseURI(), auth.Authorizer()),
+		subscriptionID: auth.SubscriptionID(),
+	}
+}
+
+// newResourceGraphClient creates a new Resource Graph client.
+func newResourceGraphClient(baseURI string, authorizer autorest.Authorizer) resourcegraph.BaseClient {
+	c := resourcegraph.NewWithBaseURI(baseURI)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// Query runs a Resource Graph query against the subscription and returns all the resulting rows.
+func (ac *AzureClient) Query(ctx context.Context, query string) ([]Resource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourcegraph.AzureClient.Query")
+	defer done()
+
+	request := resourcegraph.QueryRequest{
+		Subscriptions: &[]string{ac.subscriptionID},
+		Query:         pointer.String(query),
+		Options: &resourcegraph.QueryRequestOptions{
+			ResultFormat: resourcegraph.ResultFormatObjectArray,
+		},
+	}
+
+	var result []Resource
+	for {
+		response, err := ac.resourcegraph.Resources(ctx, request)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query resource graph")
+		}
+
+		// The rows are returned as a generic JSON array, so round trip them through JSON to decode them.
+		data, err := json.Marshal(response.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal resource graph query result")
+		}
+		var rows []Resource
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal resource graph query result")
+		}
+		result = append(result, rows...)
+
+		if pointer.StringDeref(response.SkipToken, "") == "" {
+			return result, nil
+		}
+		request.Options.SkipToken = response.SkipToken
+	}
+}
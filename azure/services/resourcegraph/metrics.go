@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcegraph
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var clusterOwnedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capz_cluster_owned_resources",
+	Help: "Number of Azure resources owned by a cluster, by cluster name and resource group, as of the latest Resource Graph inventory of the resource group.",
+}, []string{"cluster", "resource_group"})
+
+func init() {
+	metrics.Registry.MustRegister(clusterOwnedResources)
+}
+
+// countOwnedResources returns the number of resources owned by each cluster, keyed by cluster name.
+func countOwnedResources(tags map[string]map[string]*string) map[string]int {
+	owned := map[string]int{}
+	for _, resourceTags := range tags {
+		for key, value := range resourceTags {
+			if value == nil || *value != string(infrav1.ResourceLifecycleOwned) {
+				continue
+			}
+			if cluster := strings.TrimPrefix(key, infrav1.NameAzureProviderOwned); cluster != key && cluster != "" {
+				owned[cluster]++
+			}
+		}
+	}
+	return owned
+}
+
+// recordOwnedResources updates the number of resources owned by each cluster in a resource group, removing the
+// clusters which no longer own resources in it.
+func recordOwnedResources(resourceGroup string, previous, current map[string]int) {
+	for cluster := range previous {
+		if _, ok := current[cluster]; !ok {
+			clusterOwnedResources.DeleteLabelValues(cluster, resourceGroup)
+		}
+	}
+	for cluster, count := range current {
+		clusterOwnedResources.WithLabelValues(cluster, resourceGroup).Set(float64(count))
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcegraph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// inventoryTimeToLive is how long the resources of a resource group are cached. It is short enough for the
+// inventory to only be shared by the services of a single reconciliation.
+const inventoryTimeToLive = 30 * time.Second
+
+// TagsGetter gets the tags of resources while keeping a Resource Graph inventory of their resource group, which is
+// queried once for all the resources of a cluster and used to count the resources owned by each cluster.
+// Resource Graph lags behind Azure Resource Manager, and CAPZ acts on every ownership check: it skips the resources it
+// doesn't own, and updates or deletes the ones it owns. The tags returned are therefore always read from Azure
+// Resource Manager, and the inventory is only used to report the tags Resource Graph has not caught up with yet.
+type TagsGetter struct {
+	client   Client
+	fallback async.TagsGetter
+	now      func() time.Time
+
+	mu          sync.Mutex
+	inventories map[string]*inventory
+}
+
+// inventory is the tags of the resources of a resource group, keyed by lowercase resource ID, and the number of
+// resources owned by each cluster.
+type inventory struct {
+	tags     map[string]map[string]*string
+	owned    map[string]int
+	loadedAt time.Time
+}
+
+var (
+	tagsGetters   = map[string]*TagsGetter{}
+	tagsGettersMu sync.Mutex
+)
+
+// NewTagsGetter returns a tags getter backed by Resource Graph if the ResourceGraph feature is enabled,
+// or a plain Azure Resource Manager tags client otherwise. Resource Graph tags getters are shared by all the
+// scopes using the same credentials so that their inventories are reused across services.
+func NewTagsGetter(auth azure.Authorizer) async.TagsGetter {
+	if !feature.Gates.Enabled(feature.ResourceGraph) {
+		return tags.NewClient(auth)
+	}
+
+	tagsGettersMu.Lock()
+	defer tagsGettersMu.Unlock()
+	key := auth.HashKey()
+	if getter, ok := tagsGetters[key]; ok {
+		return getter
+	}
+	getter := newTagsGetter(NewClient(auth), tags.NewClient(auth))
+	tagsGetters[key] = getter
+	return getter
+}
+
+func newTagsGetter(client Client, fallback async.TagsGetter) *TagsGetter {
+	return &TagsGetter{
+		client:      client,
+		fallback:    fallback,
+		now:         time.Now,
+		inventories: map[string]*inventory{},
+	}
+}
+
+// GetAtScope returns the tags of the resource with the given ID, read from Azure Resource Manager.
+func (t *TagsGetter) GetAtScope(ctx context.Context, scope string) (resources.TagsResource, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourcegraph.TagsGetter.GetAtScope")
+	defer done()
+
+	result, err := t.fallback.GetAtScope(ctx, scope)
+	if err != nil {
+		return result, err
+	}
+
+	resourceID, err := azureautorest.ParseResourceID(scope)
+	if err != nil {
+		return result, nil
+	}
+	inv, err := t.inventory(ctx, resourceID.SubscriptionID, resourceID.ResourceGroup)
+	if err != nil {
+		log.V(4).Info("failed to get resource group inventory from resource graph", "resourceGroup", resourceID.ResourceGroup, "error", err.Error())
+		return result, nil
+	}
+	var resourceTags map[string]*string
+	if result.Properties != nil {
+		resourceTags = result.Properties.Tags
+	}
+	if inventoryTags, ok := inv.tags[strings.ToLower(scope)]; ok && !equalTags(inventoryTags, resourceTags) {
+		log.V(4).Info("resource graph tags are out of date", "resource", scope)
+	}
+	return result, nil
+}
+
+// equalTags returns whether two sets of tags have the same keys and values.
+func equalTags(a, b map[string]*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		other, ok := b[key]
+		if !ok || pointer.StringDeref(value, "") != pointer.StringDeref(other, "") {
+			return false
+		}
+	}
+	return true
+}
+
+// inventory returns the cached inventory of a resource group, querying Resource Graph if it is missing or expired.
+func (t *TagsGetter) inventory(ctx context.Context, subscriptionID, resourceGroup string) (*inventory, error) {
+	key := strings.ToLower(subscriptionID + "/" + resourceGroup)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if inv, ok := t.inventories[key]; ok && t.now().Sub(inv.loadedAt) < inventoryTimeToLive {
+		return inv, nil
+	}
+
+	query := fmt.Sprintf("Resources | where subscriptionId =~ '%s' and resourceGroup =~ '%s' | project id, name, type, resourceGroup, tags",
+		escape(subscriptionID), escape(resourceGroup))
+	rows, err := t.client.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query resources of resource group %s", resourceGroup)
+	}
+
+	inv := &inventory{
+		tags:     make(map[string]map[string]*string, len(rows)),
+		loadedAt: t.now(),
+	}
+	for _, row := range rows {
+		inv.tags[strings.ToLower(row.ID)] = row.Tags
+	}
+	inv.owned = countOwnedResources(inv.tags)
+	var previousOwned map[string]int
+	if previous, ok := t.inventories[key]; ok {
+		previousOwned = previous.owned
+	}
+	recordOwnedResources(strings.ToLower(resourceGroup), previousOwned, inv.owned)
+	t.inventories[key] = inv
+	return inv, nil
+}
+
+// escape escapes a value used in a single-quoted Kusto string literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
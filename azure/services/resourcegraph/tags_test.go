@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcegraph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeVnetID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	fakeIPID    = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"
+	fakeQuery   = "Resources | where subscriptionId =~ '123' and resourceGroup =~ 'my-rg' | project id, name, type, resourceGroup, tags"
+	ownedTagKey = "sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster"
+)
+
+var (
+	fakeVnetTags = map[string]*string{ownedTagKey: pointer.String("owned")}
+	fakeRows     = []Resource{
+		{
+			// Resource Graph returns lowercase IDs for some resource types.
+			ID:            "/subscriptions/123/resourcegroups/my-rg/providers/microsoft.network/virtualnetworks/my-vnet",
+			Name:          "my-vnet",
+			Type:          "microsoft.network/virtualnetworks",
+			ResourceGroup: "my-rg",
+			Tags:          fakeVnetTags,
+		},
+	}
+	fakeVnetTagsResource = resources.TagsResource{
+		ID:         pointer.String(fakeVnetID),
+		Properties: &resources.Tags{Tags: fakeVnetTags},
+	}
+	fakeIPTags = resources.TagsResource{
+		ID:         pointer.String(fakeIPID),
+		Properties: &resources.Tags{Tags: map[string]*string{"foo": pointer.String("bar")}},
+	}
+)
+
+func TestGetAtScope(t *testing.T) {
+	testcases := []struct {
+		name   string
+		scope  string
+		rows   []Resource
+		rowErr error
+		expect func(f *mock_async.MockTagsGetterMockRecorder)
+		result resources.TagsResource
+		err    string
+	}{
+		{
+			name:  "resource found in the inventory is read from resource manager",
+			scope: fakeVnetID,
+			rows:  fakeRows,
+			expect: func(f *mock_async.MockTagsGetterMockRecorder) {
+				f.GetAtScope(gomockinternal.AContext(), fakeVnetID).Return(fakeVnetTagsResource, nil)
+			},
+			result: fakeVnetTagsResource,
+		},
+		{
+			name:  "out of date inventory tags are ignored",
+			scope: fakeVnetID,
+			rows:  fakeRows,
+			expect: func(f *mock_async.MockTagsGetterMockRecorder) {
+				// The vnet is no longer owned by the cluster, which Resource Graph doesn't report yet.
+				f.GetAtScope(gomockinternal.AContext(), fakeVnetID).Return(resources.TagsResource{ID: pointer.String(fakeVnetID), Properties: &resources.Tags{}}, nil)
+			},
+			result: resources.TagsResource{ID: pointer.String(fakeVnetID), Properties: &resources.Tags{}},
+		},
+		{
+			name:  "resource not indexed yet is read from resource manager",
+			scope: fakeIPID,
+			rows:  fakeRows,
+			expect: func(f *mock_async.MockTagsGetterMockRecorder) {
+				f.GetAtScope(gomockinternal.AContext(), fakeIPID).Return(fakeIPTags, nil)
+			},
+			result: fakeIPTags,
+		},
+		{
+			name:   "query failure is ignored",
+			scope:  fakeIPID,
+			rowErr: errors.New("throttled"),
+			expect: func(f *mock_async.MockTagsGetterMockRecorder) {
+				f.GetAtScope(gomockinternal.AContext(), fakeIPID).Return(fakeIPTags, nil)
+			},
+			result: fakeIPTags,
+		},
+		{
+			name:  "resource manager error is returned",
+			scope: fakeVnetID,
+			rows:  fakeRows,
+			expect: func(f *mock_async.MockTagsGetterMockRecorder) {
+				f.GetAtScope(gomockinternal.AContext(), fakeVnetID).Return(resources.TagsResource{}, errors.New("not found"))
+			},
+			err: "not found",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := &fakeClient{rows: tc.rows, err: tc.rowErr}
+			fallbackMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(fallbackMock.EXPECT())
+
+			result, err := newTagsGetter(client, fallbackMock).GetAtScope(context.TODO(), tc.scope)
+			if tc.err != "" {
+				g.Expect(err).To(MatchError(tc.err))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(tc.result))
+		})
+	}
+}
+
+func TestInventoryIsCached(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := &fakeClient{rows: fakeRows}
+	fallbackMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+	now := time.Now()
+	getter := newTagsGetter(client, fallbackMock)
+	getter.now = func() time.Time { return now }
+	fallbackMock.EXPECT().GetAtScope(gomockinternal.AContext(), fakeVnetID).Return(fakeVnetTagsResource, nil).Times(4)
+
+	// The inventory is queried once while it is fresh, then again once it expires.
+	for i := 0; i < 3; i++ {
+		_, err := getter.GetAtScope(context.TODO(), fakeVnetID)
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(client.queries).To(Equal([]string{fakeQuery}))
+	now = now.Add(inventoryTimeToLive)
+	_, err := getter.GetAtScope(context.TODO(), fakeVnetID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.queries).To(Equal([]string{fakeQuery, fakeQuery}))
+}
+
+// fakeClient returns canned rows and records the queries it receives.
+type fakeClient struct {
+	rows    []Resource
+	err     error
+	queries []string
+}
+
+func (f *fakeClient) Query(_ context.Context, query string) ([]Resource, error) {
+	f.queries = append(f.queries, query)
+	return f.rows, f.err
+}
+
+func TestCountOwnedResources(t *testing.T) {
+	g := NewWithT(t)
+	tags := map[string]map[string]*string{
+		fakeVnetID: fakeVnetTags,
+		fakeIPID:   {ownedTagKey: pointer.String("owned"), "sigs.k8s.io_cluster-api-provider-azure_cluster_other": pointer.String("shared")},
+		"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/natGateways/my-natgw": {
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_other": pointer.String("owned"),
+			"foo": pointer.String("bar"),
+		},
+		"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/routeTables/my-rt": nil,
+	}
+	g.Expect(countOwnedResources(tags)).To(Equal(map[string]int{"my-cluster": 2, "other": 1}))
+}
@@ -31,20 +31,29 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Cache loads resource SKUs at the beginning of reconcile to expose
+// refreshInterval is how long the SKUs of a location are used before they are listed again, so that changes to the
+// restrictions of the subscription are eventually picked up.
+const refreshInterval = 1 * time.Hour
+
+// Cache loads resource SKUs on first use to expose
 // features available on compute resources. It exposes convenience
-// functionality for trawling Azure SKU capabilities. It may be adapted
-// to periodically refresh data in the background.
+// functionality for trawling Azure SKU capabilities. Caches are shared
+// by all the clusters of a subscription and location, and their data is
+// refreshed every refreshInterval.
 type Cache struct {
 	client Client
 
 	// location is the Azure location for which this cache stores sku info.
-	// we do lookup once per reconcile for the given cluster/location.
 	location string
 
+	// mu guards data and refreshedAt, as the cache is shared by concurrent reconciles.
+	mu sync.Mutex
+
 	// data is the cached sku information from Azure.
-	// synchronization required if data is cached across reconcile calls, (i.e., refreshed in background as Runnable via mgr.Add(...))
 	data []compute.ResourceSku
+
+	// refreshedAt is when data was last listed from Azure.
+	refreshedAt time.Time
 }
 
 // Cacher describes the ability to get and to add items to cache.
@@ -70,7 +79,9 @@ func newCache(auth azure.Authorizer, location string) *Cache {
 	}
 }
 
-// GetCache either creates a new SKUs cache or returns an existing one based on the location + Authorizer HashKey().
+// GetCache either creates a new SKUs cache or returns an existing one for the cloud environment, subscription and
+// location. SKUs don't depend on the identity used to list them, so a cache is shared by all the clusters of a
+// subscription and location regardless of their namespace and identity.
 func GetCache(auth azure.Authorizer, location string) (*Cache, error) {
 	var err error
 	doOnce.Do(func() {
@@ -81,7 +92,7 @@ func GetCache(auth azure.Authorizer, location string) (*Cache, error) {
 		return nil, errors.Wrap(err, "failed creating LRU cache for resourceSKUs cache")
 	}
 
-	key := location + "_" + auth.HashKey()
+	key := strings.Join([]string{auth.CloudEnvironment(), auth.SubscriptionID(), location}, "_")
 	c, ok := clientCache.Get(key)
 	if ok {
 		return c.(*Cache), nil
@@ -100,16 +111,44 @@ func NewStaticCache(data []compute.ResourceSku, location string) *Cache {
 	}
 }
 
+// skus returns the cached SKUs, listing them from Azure on first use and every refreshInterval. Static caches are
+// never refreshed. When a refresh fails, the previously listed SKUs are returned.
+func (c *Cache) skus(ctx context.Context) ([]compute.ResourceSku, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.skus")
+	defer done()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data != nil && (c.client == nil || time.Since(c.refreshedAt) < refreshInterval) {
+		cacheRequestsTotal.WithLabelValues(c.location, "hit").Inc()
+		return c.data, nil
+	}
+	cacheRequestsTotal.WithLabelValues(c.location, "miss").Inc()
+
+	if err := c.refresh(ctx, c.location); err != nil {
+		if c.data != nil {
+			log.Error(err, "failed to refresh resource SKUs, using the previously listed SKUs", "location", c.location)
+			return c.data, nil
+		}
+		return nil, err
+	}
+	return c.data, nil
+}
+
 func (c *Cache) refresh(ctx context.Context, location string) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.refresh")
 	defer done()
 
 	data, err := c.client.List(ctx, fmt.Sprintf("location eq '%s'", location))
 	if err != nil {
+		cacheRefreshesTotal.WithLabelValues(location, "failure").Inc()
 		return errors.Wrap(err, "failed to refresh resource sku cache")
 	}
+	cacheRefreshesTotal.WithLabelValues(location, "success").Inc()
 
 	c.data = data
+	c.refreshedAt = time.Now()
 
 	return nil
 }
@@ -123,13 +162,12 @@ func (c *Cache) Get(ctx context.Context, name string, kind ResourceType) (SKU, e
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Get")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return SKU{}, err
-		}
+	data, err := c.skus(ctx)
+	if err != nil {
+		return SKU{}, err
 	}
 
-	for _, sku := range c.data {
+	for _, sku := range data {
 		if sku.Name != nil && *sku.Name == name {
 			return SKU(sku), nil
 		}
@@ -142,20 +180,46 @@ func (c *Cache) Map(ctx context.Context, mapFn func(sku SKU)) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Map")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return err
-		}
+	data, err := c.skus(ctx)
+	if err != nil {
+		return err
 	}
 
-	for i := range c.data {
-		val := SKU(c.data[i])
+	for i := range data {
+		val := SKU(data[i])
 		mapFn(val)
 	}
 
 	return nil
 }
 
+// Filter returns the SKUs of the given resource type which match all the filters, e.g. the VM sizes supporting
+// accelerated networking and encryption at host:
+//
+//	cache.Filter(ctx, VirtualMachines, WithCapability(AcceleratedNetworking), WithCapability(EncryptionAtHost))
+func (c *Cache) Filter(ctx context.Context, kind ResourceType, filters ...Filter) ([]SKU, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Filter")
+	defer done()
+
+	var skus []SKU
+	mapFn := func(sku SKU) {
+		if sku.ResourceType == nil || !strings.EqualFold(*sku.ResourceType, string(kind)) {
+			return
+		}
+		for _, filter := range filters {
+			if !filter(sku) {
+				return
+			}
+		}
+		skus = append(skus, sku)
+	}
+
+	if err := c.Map(ctx, mapFn); err != nil {
+		return nil, err
+	}
+	return skus, nil
+}
+
 // GetZones looks at all virtual machine sizes and returns the unique
 // set of zones into which some machine size may deploy. It removes
 // restricted virtual machine sizes and duplicates.
@@ -226,9 +290,22 @@ func (c *Cache) GetZonesWithVMSize(ctx context.Context, size, location string) (
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.GetZonesWithVMSize")
 	defer done()
 
+	return c.getZonesWithSKU(ctx, size, VirtualMachines, location)
+}
+
+// GetZonesWithDiskType returns available zones for a managed disk storage account type in the given location.
+func (c *Cache) GetZonesWithDiskType(ctx context.Context, diskType, location string) ([]string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.GetZonesWithDiskType")
+	defer done()
+
+	return c.getZonesWithSKU(ctx, diskType, Disks, location)
+}
+
+// getZonesWithSKU returns the zones of the given location where a SKU of the given resource type isn't restricted.
+func (c *Cache) getZonesWithSKU(ctx context.Context, name string, kind ResourceType, location string) ([]string, error) {
 	var allZones = make(map[string]bool)
 	mapFn := func(sku SKU) {
-		if sku.Name != nil && strings.EqualFold(*sku.Name, size) && sku.ResourceType != nil && strings.EqualFold(*sku.ResourceType, string(VirtualMachines)) {
+		if sku.Name != nil && strings.EqualFold(*sku.Name, name) && sku.ResourceType != nil && strings.EqualFold(*sku.ResourceType, string(kind)) {
 			// find matching location
 			for _, locationInfo := range *sku.LocationInfo {
 				if !strings.EqualFold(*locationInfo.Location, location) {
@@ -18,12 +18,17 @@ package resourceskus
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus/mock_resourceskus"
 )
 
 func TestCacheGet(t *testing.T) {
@@ -394,3 +399,194 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheGetZonesWithDiskType(t *testing.T) {
+	cases := map[string]struct {
+		have []compute.ResourceSku
+		want []string
+	}{
+		"should find zones of the disk type": {
+			have: []compute.ResourceSku{
+				{
+					Name:         pointer.String("PremiumV2_LRS"),
+					ResourceType: pointer.String(string(Disks)),
+					Locations: &[]string{
+						"baz",
+					},
+					LocationInfo: &[]compute.ResourceSkuLocationInfo{
+						{
+							Location: pointer.String("baz"),
+							Zones:    &[]string{"1", "3"},
+						},
+					},
+				},
+			},
+			want: []string{"1", "3"},
+		},
+		"should not find due to resource type mismatch": {
+			have: []compute.ResourceSku{
+				{
+					Name:         pointer.String("PremiumV2_LRS"),
+					ResourceType: pointer.String(string(VirtualMachines)),
+					Locations: &[]string{
+						"baz",
+					},
+					LocationInfo: &[]compute.ResourceSkuLocationInfo{
+						{
+							Location: pointer.String("baz"),
+							Zones:    &[]string{"1"},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"should not find restricted zones": {
+			have: []compute.ResourceSku{
+				{
+					Name:         pointer.String("PremiumV2_LRS"),
+					ResourceType: pointer.String(string(Disks)),
+					Locations: &[]string{
+						"baz",
+					},
+					LocationInfo: &[]compute.ResourceSkuLocationInfo{
+						{
+							Location: pointer.String("baz"),
+							Zones:    &[]string{"1", "2"},
+						},
+					},
+					Restrictions: &[]compute.ResourceSkuRestrictions{
+						{
+							Type: compute.ResourceSkuRestrictionsTypeZone,
+							RestrictionInfo: &compute.ResourceSkuRestrictionInfo{
+								Zones: &[]string{"1"},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"2"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &Cache{
+				data: tc.have,
+			}
+
+			zones, err := cache.GetZonesWithDiskType(context.Background(), "PremiumV2_LRS", "baz")
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(zones, tc.want, []cmp.Option{cmpopts.EquateEmpty()}...); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestCacheRefresh(t *testing.T) {
+	skus := []compute.ResourceSku{{Name: pointer.String("Standard_D2s_v3")}}
+	cases := map[string]struct {
+		refreshedAt time.Time
+		expect      func(m *mock_resourceskus.MockClientMockRecorder)
+	}{
+		"should list skus on first use": {
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomock.Any(), "location eq 'test'").Return(skus, nil)
+			},
+		},
+		"should not list fresh skus again": {
+			refreshedAt: time.Now(),
+		},
+		"should list stale skus again": {
+			refreshedAt: time.Now().Add(-2 * refreshInterval),
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomock.Any(), "location eq 'test'").Return(skus, nil)
+			},
+		},
+		"should use stale skus when the refresh fails": {
+			refreshedAt: time.Now().Add(-2 * refreshInterval),
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomock.Any(), "location eq 'test'").Return(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mock_resourceskus.NewMockClient(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(client.EXPECT())
+			}
+
+			cache := &Cache{
+				client:      client,
+				location:    "test",
+				refreshedAt: tc.refreshedAt,
+			}
+			if !tc.refreshedAt.IsZero() {
+				cache.data = skus
+			}
+
+			sku, err := cache.Get(context.Background(), "Standard_D2s_v3", VirtualMachines)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(*sku.Name).To(Equal("Standard_D2s_v3"))
+		})
+	}
+}
+
+func TestCacheFilter(t *testing.T) {
+	g := NewWithT(t)
+	cache := NewStaticCache([]compute.ResourceSku{
+		{
+			Name:         pointer.String("accelerated"),
+			ResourceType: pointer.String(string(VirtualMachines)),
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: pointer.String(AcceleratedNetworking), Value: pointer.String(string(CapabilitySupported))},
+				{Name: pointer.String(VCPUs), Value: pointer.String("4")},
+			},
+		},
+		{
+			Name:         pointer.String("confidential"),
+			ResourceType: pointer.String(string(VirtualMachines)),
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: pointer.String(AcceleratedNetworking), Value: pointer.String(string(CapabilitySupported))},
+				{Name: pointer.String(ConfidentialComputingType), Value: pointer.String("SNP")},
+				{Name: pointer.String(VCPUs), Value: pointer.String("2")},
+			},
+		},
+		{
+			Name:         pointer.String("disk"),
+			ResourceType: pointer.String(string(Disks)),
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: pointer.String(AcceleratedNetworking), Value: pointer.String(string(CapabilitySupported))},
+			},
+		},
+	}, "test")
+
+	names := func(filters ...Filter) []string {
+		skus, err := cache.Filter(context.Background(), VirtualMachines, filters...)
+		g.Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, sku := range skus {
+			names = append(names, *sku.Name)
+		}
+		return names
+	}
+
+	g.Expect(names()).To(Equal([]string{"accelerated", "confidential"}))
+	g.Expect(names(WithCapability(AcceleratedNetworking))).To(Equal([]string{"accelerated", "confidential"}))
+	g.Expect(names(WithConfidentialComputing())).To(Equal([]string{"confidential"}))
+	g.Expect(names(WithCapabilityValue(ConfidentialComputingType, "snp"))).To(Equal([]string{"confidential"}))
+	g.Expect(names(WithCapability(AcceleratedNetworking), WithMinimumCapacity(VCPUs, 4))).To(Equal([]string{"accelerated"}))
+	g.Expect(names(WithCapability(EncryptionAtHost))).To(BeEmpty())
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"strings"
+)
+
+// Filter selects the SKUs returned by Cache.Filter.
+type Filter func(sku SKU) bool
+
+// WithCapability selects the SKUs supporting a boolean capability, e.g. AcceleratedNetworking or EncryptionAtHost.
+func WithCapability(name string) Filter {
+	return func(sku SKU) bool {
+		return sku.HasCapability(name)
+	}
+}
+
+// WithCapabilityValue selects the SKUs whose capability has the given value, ignoring case.
+func WithCapabilityValue(name, value string) Filter {
+	return func(sku SKU) bool {
+		v, ok := sku.GetCapability(name)
+		return ok && strings.EqualFold(v, value)
+	}
+}
+
+// WithMinimumCapacity selects the SKUs whose numeric capability is at least value, e.g. VCPUs or MemoryGB.
+func WithMinimumCapacity(name string, value int64) Filter {
+	return func(sku SKU) bool {
+		ok, err := sku.HasCapabilityWithCapacity(name, value)
+		return err == nil && ok
+	}
+}
+
+// WithLocationCapability selects the SKUs supporting a capability in a zone of a location, e.g. UltraSSDAvailable.
+func WithLocationCapability(name, location, zone string) Filter {
+	return func(sku SKU) bool {
+		return sku.HasLocationCapability(name, location, zone)
+	}
+}
+
+// WithConfidentialComputing selects the VM sizes supporting confidential VMs.
+func WithConfidentialComputing() Filter {
+	return func(sku SKU) bool {
+		v, ok := sku.GetCapability(ConfidentialComputingType)
+		return ok && v != ""
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	cacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capz_resourceskus_cache_requests_total",
+		Help: "Total number of resource SKU cache requests, by location and result (hit or miss).",
+	}, []string{"location", "result"})
+
+	cacheRefreshesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capz_resourceskus_cache_refreshes_total",
+		Help: "Total number of resource SKU cache refreshes from Azure, by location and result (success or failure).",
+	}, []string{"location", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheRequestsTotal, cacheRefreshesTotal)
+}
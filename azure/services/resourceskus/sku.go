@@ -52,6 +52,10 @@ const (
 const (
 	// EphemeralOSDisk identifies the capability for ephemeral os support.
 	EphemeralOSDisk = "EphemeralOSDiskSupported"
+	// CachedDiskBytes identifies the size of the cache disk of a VM size, in bytes.
+	CachedDiskBytes = "CachedDiskBytes"
+	// MaxResourceVolumeMB identifies the size of the resource (temp) disk of a VM size, in MB.
+	MaxResourceVolumeMB = "MaxResourceVolumeMB"
 	// AcceleratedNetworking identifies the capability for accelerated networking support.
 	AcceleratedNetworking = "AcceleratedNetworkingEnabled"
 	// VCPUs identifies the capability for the number of vCPUS.
@@ -68,6 +72,17 @@ const (
 	MaximumPlatformFaultDomainCount = "MaximumPlatformFaultDomainCount"
 	// UltraSSDAvailable identifies the capability for the support of UltraSSD data disks.
 	UltraSSDAvailable = "UltraSSDAvailable"
+	// TrustedLaunchDisabled identifies the absence of the trusted launch capability.
+	TrustedLaunchDisabled = "TrustedLaunchDisabled"
+	// ConfidentialComputingType identifies the type of confidential computing supported by a VM size, e.g. "SNP".
+	// VM sizes without this capability don't support confidential VMs.
+	ConfidentialComputingType = "ConfidentialComputingType"
+	// HyperVGenerations identifies the capability listing the Hyper-V generations supported by a VM size, e.g. "V1,V2".
+	HyperVGenerations = "HyperVGenerations"
+	// GPUs identifies the capability for the number of GPUs of a VM size.
+	GPUs = "GPUs"
+	// MinimumGPUs is the minimum number of GPUs required to install a GPU driver.
+	MinimumGPUs = 1
 )
 
 // HasCapability return true for a capability which can be either
@@ -87,6 +102,15 @@ func (s SKU) HasCapability(name string) bool {
 	return false
 }
 
+// SupportsTrustedLaunch returns true if the VM size supports trusted launch, which requires generation 2 VMs.
+func (s SKU) SupportsTrustedLaunch() bool {
+	if s.HasCapability(TrustedLaunchDisabled) {
+		return false
+	}
+	generations, ok := s.GetCapability(HyperVGenerations)
+	return ok && strings.Contains(generations, "V2")
+}
+
 // HasCapabilityWithCapacity returns true when the provided resource
 // exposes a numeric capability and the maximum value exposed by that
 // capability exceeds the value requested by the user. Examples include
@@ -118,6 +142,25 @@ func (s SKU) HasCapabilityWithCapacity(name string, value int64) (bool, error) {
 	return false, nil
 }
 
+// SupportsEphemeralOSDiskPlacement returns true when the SKU has a cache disk or resource disk, depending on the
+// placement, large enough to hold an ephemeral OS disk of the given size. A diskSizeGB of 0 only checks the disk exists.
+func (s SKU) SupportsEphemeralOSDiskPlacement(placement string, diskSizeGB int32) (bool, error) {
+	switch compute.DiffDiskPlacement(placement) {
+	case compute.DiffDiskPlacementCacheDisk:
+		if diskSizeGB <= 0 {
+			return s.HasCapabilityWithCapacity(CachedDiskBytes, 1)
+		}
+		return s.HasCapabilityWithCapacity(CachedDiskBytes, int64(diskSizeGB)*1024*1024*1024)
+	case compute.DiffDiskPlacementResourceDisk:
+		if diskSizeGB <= 0 {
+			return s.HasCapabilityWithCapacity(MaxResourceVolumeMB, 1)
+		}
+		return s.HasCapabilityWithCapacity(MaxResourceVolumeMB, int64(diskSizeGB)*1024)
+	default:
+		return false, errors.Errorf("unknown ephemeral OS disk placement %q", placement)
+	}
+}
+
 // GetCapability gets the value assigned to the given capability.
 // Eg. MaximumPlatformFaultDomainCount -> "3" will return "3" for the capability "MaximumPlatformFaultDomainCount".
 func (s SKU) GetCapability(name string) (string, bool) {
@@ -53,6 +53,21 @@ func (m *MockRoleAssignmentScope) EXPECT() *MockRoleAssignmentScopeMockRecorder
 	return m.recorder
 }
 
+// AnnotationJSON mocks base method.
+func (m *MockRoleAssignmentScope) AnnotationJSON(arg0 string) (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotationJSON", arg0)
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnnotationJSON indicates an expected call of AnnotationJSON.
+func (mr *MockRoleAssignmentScopeMockRecorder) AnnotationJSON(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotationJSON", reflect.TypeOf((*MockRoleAssignmentScope)(nil).AnnotationJSON), arg0)
+}
+
 // Authorizer mocks base method.
 func (m *MockRoleAssignmentScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -273,6 +288,20 @@ func (mr *MockRoleAssignmentScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockRoleAssignmentScope)(nil).TenantID))
 }
 
+// UpdateAnnotationJSON mocks base method.
+func (m *MockRoleAssignmentScope) UpdateAnnotationJSON(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotationJSON", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotationJSON indicates an expected call of UpdateAnnotationJSON.
+func (mr *MockRoleAssignmentScopeMockRecorder) UpdateAnnotationJSON(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotationJSON", reflect.TypeOf((*MockRoleAssignmentScope)(nil).UpdateAnnotationJSON), arg0, arg1)
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockRoleAssignmentScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
@@ -21,6 +21,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
@@ -35,6 +36,8 @@ const serviceName = "roleassignments"
 type RoleAssignmentScope interface {
 	azure.AsyncStatusUpdater
 	azure.Authorizer
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
 	RoleAssignmentSpecs(principalID *string) []azure.ResourceSpecGetter
 	HasSystemAssignedIdentity() bool
 	RoleAssignmentResourceType() string
@@ -56,7 +59,7 @@ func New(scope RoleAssignmentScope) *Service {
 	return &Service{
 		Scope:                        scope,
 		virtualMachinesGetter:        virtualmachines.NewClient(scope),
-		virtualMachineScaleSetClient: scalesets.NewClient(scope),
+		virtualMachineScaleSetClient: scalesets.NewClient(scope, nil),
 		Reconciler:                   async.New(scope, client, client),
 	}
 }
@@ -67,19 +70,27 @@ func (s *Service) Name() string {
 }
 
 // Reconcile idempotently creates or updates a role assignment.
-func (s *Service) Reconcile(ctx context.Context) error {
+func (s *Service) Reconcile(ctx context.Context) (resultErr error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.Reconcile")
 	defer done()
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 	log.V(2).Info("reconciling role assignment")
 
-	// Return early if the identity is not system assigned as there will be no
-	// role assignment spec in this case.
+	lastApplied, err := s.Scope.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied role assignments")
+	}
+
+	// Remove the role assignments created earlier and return early if the identity is not system assigned,
+	// as there will be no role assignment spec in this case.
 	if !s.Scope.HasSystemAssignedIdentity() {
 		log.V(2).Info("no role assignment spec to reconcile")
-		return nil
+		return s.deleteStaleRoleAssignments(ctx, lastApplied, nil)
 	}
+	defer func() {
+		s.Scope.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, resultErr)
+	}()
 
 	var principalID *string
 	resourceType := s.Scope.RoleAssignmentResourceType()
@@ -101,7 +112,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			azure.VirtualMachine, azure.VirtualMachineScaleSet)
 	}
 
-	for _, roleAssignmentSpec := range s.Scope.RoleAssignmentSpecs(principalID) {
+	roleAssignmentSpecs := s.Scope.RoleAssignmentSpecs(principalID)
+	for _, roleAssignmentSpec := range roleAssignmentSpecs {
 		log.V(2).Info("Creating role assignment")
 		if roleAssignmentSpec.ResourceName() == "" {
 			log.V(2).Info("RoleAssignmentName is empty. This is not expected and will cause this System Assigned Identity to have no permissions.")
@@ -112,7 +124,42 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return s.deleteStaleRoleAssignments(ctx, lastApplied, roleAssignmentSpecs)
+}
+
+// deleteStaleRoleAssignments deletes the last applied role assignments which are not in the desired role assignment
+// specs, e.g. after the scope of the role changed or the identity is no longer system assigned, and records the desired
+// role assignments as the last applied ones.
+func (s *Service) deleteStaleRoleAssignments(ctx context.Context, lastApplied map[string]interface{}, roleAssignmentSpecs []azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.deleteStaleRoleAssignments")
+	defer done()
+
+	applied := make(map[string]interface{}, len(roleAssignmentSpecs))
+	for _, roleAssignmentSpec := range roleAssignmentSpecs {
+		if roleAssignmentSpec.ResourceName() != "" {
+			applied[roleAssignmentSpec.ResourceName()] = roleAssignmentSpec.OwnerResourceName()
+		}
+	}
+
+	for name, scope := range lastApplied {
+		scope, ok := scope.(string)
+		if !ok || applied[name] == scope {
+			continue
+		}
+		log.V(2).Info("Deleting stale role assignment", "name", name, "scope", scope)
+		spec := &RoleAssignmentSpec{
+			Name:  name,
+			Scope: scope,
+		}
+		if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+			return errors.Wrapf(err, "failed to delete stale role assignment %s", name)
+		}
+	}
+
+	if len(lastApplied) == 0 && len(applied) == 0 {
+		return nil
+	}
+	return s.Scope.UpdateAnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation, applied)
 }
 
 // getVMPrincipalID returns the VM principal ID.
@@ -149,6 +196,7 @@ func (s *Service) getVMSSPrincipalID(ctx context.Context) (*string, error) {
 }
 
 // Delete is a no-op as the role assignments get deleted as part of VM deletion.
+// Role assignments which are no longer needed while the machine exists are deleted by Reconcile.
 func (s *Service) Delete(ctx context.Context) error {
 	_, _, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.Delete")
 	defer done()
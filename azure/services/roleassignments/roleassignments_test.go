@@ -27,6 +27,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments/mock_roleassignments"
@@ -74,6 +75,8 @@ func TestReconcileRoleAssignmentsVM(t *testing.T) {
 				s.ResourceGroup().Return("my-rg")
 				s.Name().Return(fakeRoleAssignment1.MachineName)
 				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
 				s.RoleAssignmentResourceType().Return("VirtualMachine")
 				s.RoleAssignmentSpecs(&fakePrincipalID).Return(fakeRoleAssignmentSpecs[:1])
 				m.Get(gomockinternal.AContext(), &fakeVMSpec).Return(compute.VirtualMachine{
@@ -94,6 +97,8 @@ func TestReconcileRoleAssignmentsVM(t *testing.T) {
 				s.ResourceGroup().Return("my-rg")
 				s.Name().Return(fakeRoleAssignment1.MachineName)
 				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
 				s.RoleAssignmentResourceType().Return("VirtualMachine")
 				m.Get(gomockinternal.AContext(), &fakeVMSpec).Return(compute.VirtualMachine{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
 			},
@@ -109,6 +114,8 @@ func TestReconcileRoleAssignmentsVM(t *testing.T) {
 				s.Name().Return(fakeRoleAssignment1.MachineName)
 				s.RoleAssignmentResourceType().Return("VirtualMachine")
 				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
 				s.RoleAssignmentSpecs(&fakePrincipalID).Return(fakeRoleAssignmentSpecs[0:1])
 				m.Get(gomockinternal.AContext(), &fakeVMSpec).Return(compute.VirtualMachine{
 					Identity: &compute.VirtualMachineIdentity{
@@ -165,6 +172,8 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 				r *mock_async.MockReconcilerMockRecorder,
 				mvmss *mock_scalesets.MockClientMockRecorder) {
 				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
 				s.RoleAssignmentSpecs(&fakePrincipalID).Return(fakeRoleAssignmentSpecs[1:2])
 				s.RoleAssignmentResourceType().Return(azure.VirtualMachineScaleSet)
 				s.ResourceGroup().Return("my-rg")
@@ -187,6 +196,8 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 				s.ResourceGroup().Return("my-rg")
 				s.Name().Return("test-vmss")
 				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
 				mvmss.Get(gomockinternal.AContext(), "my-rg", "test-vmss").Return(compute.VirtualMachineScaleSet{},
 					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
 			},
@@ -198,6 +209,8 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 				r *mock_async.MockReconcilerMockRecorder,
 				mvmss *mock_scalesets.MockClientMockRecorder) {
 				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
 				s.RoleAssignmentSpecs(&fakePrincipalID).Return(fakeRoleAssignmentSpecs[1:2])
 				s.RoleAssignmentResourceType().Return(azure.VirtualMachineScaleSet)
 				s.ResourceGroup().Return("my-rg")
@@ -242,3 +255,114 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileStaleRoleAssignments(t *testing.T) {
+	desiredRoleAssignment := RoleAssignmentSpec{
+		Name:         "fake-role-assignment",
+		MachineName:  "test-vmss",
+		ResourceType: azure.VirtualMachineScaleSet,
+		PrincipalID:  &fakePrincipalID,
+		Scope:        "/subscriptions/12345/resourceGroups/my-vnet-rg",
+	}
+	testcases := []struct {
+		name   string
+		expect func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder,
+			mvmss *mock_scalesets.MockClientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "no role assignment to delete when the identity is not system assigned",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder,
+				mvmss *mock_scalesets.MockClientMockRecorder) {
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.HasSystemAssignedIdentity().Return(false)
+			},
+		},
+		{
+			name:          "delete the role assignment when the identity is no longer system assigned",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder,
+				mvmss *mock_scalesets.MockClientMockRecorder) {
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{
+					"fake-role-assignment": "/subscriptions/12345/",
+				}, nil)
+				s.HasSystemAssignedIdentity().Return(false)
+				r.DeleteResource(gomockinternal.AContext(), &RoleAssignmentSpec{Name: "fake-role-assignment", Scope: "/subscriptions/12345/"}, serviceName).Return(nil)
+				s.UpdateAnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation, map[string]interface{}{}).Return(nil)
+			},
+		},
+		{
+			name:          "delete the role assignment with the previous scope after the scope changed",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder,
+				mvmss *mock_scalesets.MockClientMockRecorder) {
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{
+					"fake-role-assignment": "/subscriptions/12345/",
+				}, nil)
+				s.HasSystemAssignedIdentity().Return(true)
+				s.UpdatePutStatus(infrav1.RoleAssignmentReadyCondition, serviceName, gomock.Any())
+				s.RoleAssignmentSpecs(&fakePrincipalID).Return([]azure.ResourceSpecGetter{&desiredRoleAssignment})
+				s.RoleAssignmentResourceType().Return(azure.VirtualMachineScaleSet)
+				s.ResourceGroup().Return("my-rg")
+				s.Name().Return("test-vmss")
+				mvmss.Get(gomockinternal.AContext(), "my-rg", "test-vmss").Return(compute.VirtualMachineScaleSet{
+					Identity: &compute.VirtualMachineScaleSetIdentity{
+						PrincipalID: &fakePrincipalID,
+					},
+				}, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &desiredRoleAssignment, serviceName).Return(&desiredRoleAssignment, nil)
+				r.DeleteResource(gomockinternal.AContext(), &RoleAssignmentSpec{Name: "fake-role-assignment", Scope: "/subscriptions/12345/"}, serviceName).Return(nil)
+				s.UpdateAnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation, map[string]interface{}{
+					"fake-role-assignment": "/subscriptions/12345/resourceGroups/my-vnet-rg",
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "return error when deleting a stale role assignment",
+			expectedError: "failed to delete stale role assignment fake-role-assignment: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder,
+				mvmss *mock_scalesets.MockClientMockRecorder) {
+				s.AnnotationJSON(azure.RoleAssignmentsLastAppliedAnnotation).Return(map[string]interface{}{
+					"fake-role-assignment": "/subscriptions/12345/",
+				}, nil)
+				s.HasSystemAssignedIdentity().Return(false)
+				r.DeleteResource(gomockinternal.AContext(), &RoleAssignmentSpec{Name: "fake-role-assignment", Scope: "/subscriptions/12345/"}, serviceName).
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_roleassignments.NewMockRoleAssignmentScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			vmMock := mock_scalesets.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), vmMock.EXPECT())
+
+			s := &Service{
+				Scope:                        scopeMock,
+				Reconciler:                   asyncMock,
+				virtualMachineScaleSetClient: vmMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -19,7 +19,9 @@ package routetables
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
@@ -27,7 +29,12 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "routetables"
+const (
+	serviceName = "routetables"
+
+	// defaultRouteAddressPrefix is the address prefix of a route matching all outbound IPv4 traffic.
+	defaultRouteAddressPrefix = "0.0.0.0/0"
+)
 
 // RouteTableScope defines the scope interface for route table service.
 type RouteTableScope interface {
@@ -41,6 +48,7 @@ type RouteTableScope interface {
 type Service struct {
 	Scope RouteTableScope
 	async.Reconciler
+	async.Getter
 }
 
 // New creates a new service.
@@ -49,6 +57,7 @@ func New(scope RouteTableScope) *Service {
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, client, client),
+		Getter:     client,
 	}
 }
 
@@ -62,14 +71,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "routetables.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	var resErr error
 
 	if managed, err := s.IsManaged(ctx); err == nil && !managed {
 		log.V(4).Info("Skipping route tables reconcile in custom vnet mode")
-		return nil
+		return s.validateExistingDefaultRoutes(ctx)
 	} else if err != nil {
 		return errors.Wrap(err, "failed to check if route tables are managed")
 	}
@@ -83,10 +92,13 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	for _, rtSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, rtSpec, serviceName); err != nil {
+		result, err := s.CreateOrUpdateResource(ctx, rtSpec, serviceName)
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resErr == nil {
 				resErr = err
 			}
+		} else if err := validateDefaultRoute(rtSpec, result); err != nil {
+			resErr = err
 		}
 	}
 
@@ -99,7 +111,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "routetables.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	// Only delete the route tables if their lifecycle is managed by this controller.
@@ -138,3 +150,42 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 
 	return s.Scope.IsVnetManaged(), nil
 }
+
+// validateExistingDefaultRoutes checks that the unmanaged route tables which require a default route have one.
+func (s *Service) validateExistingDefaultRoutes(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "routetables.Service.validateExistingDefaultRoutes")
+	defer done()
+
+	for _, rtSpec := range s.Scope.RouteTableSpecs() {
+		if spec, ok := rtSpec.(*RouteTableSpec); !ok || !spec.RequireDefaultRoute {
+			continue
+		}
+		result, err := s.Get(ctx, rtSpec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get route table %s", rtSpec.ResourceName())
+		}
+		if err := validateDefaultRoute(rtSpec, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDefaultRoute returns an error if the spec requires a default route and the route table doesn't have one.
+func validateDefaultRoute(rtSpec azure.ResourceSpecGetter, result interface{}) error {
+	if spec, ok := rtSpec.(*RouteTableSpec); !ok || !spec.RequireDefaultRoute {
+		return nil
+	}
+	rt, ok := result.(network.RouteTable)
+	if !ok {
+		return errors.Errorf("%T is not a network.RouteTable", result)
+	}
+	if rt.RouteTablePropertiesFormat != nil && rt.Routes != nil {
+		for _, route := range *rt.Routes {
+			if route.RoutePropertiesFormat != nil && pointer.StringDeref(route.AddressPrefix, "") == defaultRouteAddressPrefix {
+				return nil
+			}
+		}
+	}
+	return errors.Errorf("route table %s has no default route for %s, which is required when outboundType is userDefinedRouting", rtSpec.ResourceName(), defaultRouteAddressPrefix)
+}
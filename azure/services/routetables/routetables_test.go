@@ -21,8 +21,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
@@ -46,6 +48,27 @@ var (
 		Location:      "fake-location",
 		ClusterName:   "test-cluster",
 	}
+	fakeUDRRT = RouteTableSpec{
+		Name:                "test-rt-udr",
+		ResourceGroup:       "test-rg",
+		Location:            "fake-location",
+		ClusterName:         "test-cluster",
+		RequireDefaultRoute: true,
+	}
+	fakeDefaultRouteRT = network.RouteTable{
+		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+			Routes: &[]network.Route{
+				{
+					Name: pointer.String("default"),
+					RoutePropertiesFormat: &network.RoutePropertiesFormat{
+						AddressPrefix:    pointer.String("0.0.0.0/0"),
+						NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+						NextHopIPAddress: pointer.String("10.1.0.4"),
+					},
+				},
+			},
+		},
+	}
 	errFake      = errors.New("this is an error")
 	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
 )
@@ -55,12 +78,12 @@ func TestReconcileRouteTables(t *testing.T) {
 		name          string
 		tags          infrav1.Tags
 		expectedError string
-		expect        func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder)
 	}{
 		{
 			name:          "noop if no route table specs are found",
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{})
 			},
@@ -68,7 +91,7 @@ func TestReconcileRouteTables(t *testing.T) {
 		{
 			name:          "create multiple route tables succeeds",
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
@@ -79,7 +102,7 @@ func TestReconcileRouteTables(t *testing.T) {
 		{
 			name:          "first route table create fails",
 			expectedError: errFake.Error(),
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
@@ -90,7 +113,7 @@ func TestReconcileRouteTables(t *testing.T) {
 		{
 			name:          "second route table create not done",
 			expectedError: errFake.Error(),
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
@@ -98,11 +121,60 @@ func TestReconcileRouteTables(t *testing.T) {
 				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
 			},
 		},
+		{
+			name:          "route table without default route fails when one is required",
+			expectedError: "route table test-rt-udr has no default route for 0.0.0.0/0, which is required when outboundType is userDefinedRouting",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeUDRRT})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeUDRRT, serviceName).Return(network.RouteTable{RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{}}, nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "route table with default route succeeds when one is required",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeUDRRT})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeUDRRT, serviceName).Return(fakeDefaultRouteRT, nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+			},
+		},
 		{
 			name:          "noop if vnet is not managed",
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.IsVnetManaged().Return(false)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
+			},
+		},
+		{
+			name:          "existing route table with default route succeeds if vnet is not managed",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.IsVnetManaged().Return(false)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeUDRRT})
+				g.Get(gomockinternal.AContext(), &fakeUDRRT).Return(fakeDefaultRouteRT, nil)
+			},
+		},
+		{
+			name:          "existing route table without default route fails if vnet is not managed",
+			expectedError: "route table test-rt-udr has no default route for 0.0.0.0/0, which is required when outboundType is userDefinedRouting",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.IsVnetManaged().Return(false)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeUDRRT})
+				g.Get(gomockinternal.AContext(), &fakeUDRRT).Return(network.RouteTable{}, nil)
+			},
+		},
+		{
+			name:          "fail to get existing route table if vnet is not managed",
+			expectedError: "failed to get route table test-rt-udr: this is an error",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.IsVnetManaged().Return(false)
+				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeUDRRT})
+				g.Get(gomockinternal.AContext(), &fakeUDRRT).Return(nil, errFake)
 			},
 		},
 	}
@@ -116,12 +188,14 @@ func TestReconcileRouteTables(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_routetables.NewMockRouteTableScope(mockCtrl)
 			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT(), getterMock.EXPECT())
 
 			s := &Service{
 				Scope:      scopeMock,
 				Reconciler: reconcilerMock,
+				Getter:     getterMock,
 			}
 
 			err := s.Reconcile(context.TODO())
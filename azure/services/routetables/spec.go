@@ -33,6 +33,12 @@ type RouteTableSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	// RequireDefaultRoute is true when the route table must have a default route,
+	// i.e. for node subnets using the userDefinedRouting outbound type.
+	RequireDefaultRoute bool
+	// Routes are the user-defined routes of the route table. When empty, the routes of an existing
+	// route table are left untouched.
+	Routes []infrav1.Route
 }
 
 // ResourceName returns the name of the route table.
@@ -53,16 +59,31 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.RouteTable); !ok {
+		existingRouteTable, ok := existing.(network.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.RouteTable", existing)
 		}
-		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// Routes of route tables without declared routes, e.g. added by the cloud provider, are left untouched.
+		if len(s.Routes) == 0 || s.routesUpToDate(existingRouteTable) {
+			return nil, nil
+		}
+		// Update the routes of the existing route table in place, without mutating the existing object.
+		props := network.RouteTablePropertiesFormat{}
+		if existingRouteTable.RouteTablePropertiesFormat != nil {
+			props = *existingRouteTable.RouteTablePropertiesFormat
+		}
+		props.Routes = s.routes()
+		existingRouteTable.RouteTablePropertiesFormat = &props
+		return existingRouteTable, nil
+	}
+
+	props := &network.RouteTablePropertiesFormat{}
+	if len(s.Routes) > 0 {
+		props.Routes = s.routes()
 	}
 	return network.RouteTable{
 		Location:                   pointer.String(s.Location),
-		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
+		RouteTablePropertiesFormat: props,
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -71,3 +92,53 @@ func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (
 		})),
 	}, nil
 }
+
+// routes returns the Azure routes of the route table.
+func (s *RouteTableSpec) routes() *[]network.Route {
+	routes := make([]network.Route, 0, len(s.Routes))
+	for _, route := range s.Routes {
+		props := &network.RoutePropertiesFormat{
+			AddressPrefix: pointer.String(route.AddressPrefix),
+			NextHopType:   network.RouteNextHopType(route.NextHopType),
+		}
+		if route.NextHopIPAddress != "" {
+			props.NextHopIPAddress = pointer.String(route.NextHopIPAddress)
+		}
+		routes = append(routes, network.Route{
+			Name:                  pointer.String(route.Name),
+			RoutePropertiesFormat: props,
+		})
+	}
+	return &routes
+}
+
+// routesUpToDate returns true if the existing route table has exactly the declared routes.
+func (s *RouteTableSpec) routesUpToDate(existing network.RouteTable) bool {
+	var existingRoutes []network.Route
+	if existing.RouteTablePropertiesFormat != nil && existing.Routes != nil {
+		existingRoutes = *existing.Routes
+	}
+	if len(existingRoutes) != len(s.Routes) {
+		return false
+	}
+
+	existingByName := make(map[string]infrav1.Route, len(existingRoutes))
+	for _, route := range existingRoutes {
+		name := pointer.StringDeref(route.Name, "")
+		existingByName[name] = infrav1.Route{Name: name}
+		if props := route.RoutePropertiesFormat; props != nil {
+			existingByName[name] = infrav1.Route{
+				Name:             name,
+				AddressPrefix:    pointer.StringDeref(props.AddressPrefix, ""),
+				NextHopType:      infrav1.RouteNextHopType(props.NextHopType),
+				NextHopIPAddress: pointer.StringDeref(props.NextHopIPAddress, ""),
+			}
+		}
+	}
+	for _, route := range s.Routes {
+		if existingRoute, ok := existingByName[route.Name]; !ok || existingRoute != route {
+			return false
+		}
+	}
+	return true
+}
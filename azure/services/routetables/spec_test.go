@@ -0,0 +1,179 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	fakeRoutesRT = RouteTableSpec{
+		Name:          "test-rt-routes",
+		ResourceGroup: "test-rg",
+		Location:      "fake-location",
+		ClusterName:   "test-cluster",
+		Routes: []infrav1.Route{
+			{
+				Name:             "default",
+				AddressPrefix:    "0.0.0.0/0",
+				NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+				NextHopIPAddress: "10.1.0.4",
+			},
+			{
+				Name:          "on-premises",
+				AddressPrefix: "192.168.0.0/16",
+				NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+			},
+		},
+	}
+
+	fakeRoutes = []network.Route{
+		{
+			Name: pointer.String("default"),
+			RoutePropertiesFormat: &network.RoutePropertiesFormat{
+				AddressPrefix:    pointer.String("0.0.0.0/0"),
+				NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+				NextHopIPAddress: pointer.String("10.1.0.4"),
+			},
+		},
+		{
+			Name: pointer.String("on-premises"),
+			RoutePropertiesFormat: &network.RoutePropertiesFormat{
+				AddressPrefix: pointer.String("192.168.0.0/16"),
+				NextHopType:   network.RouteNextHopTypeVirtualNetworkGateway,
+			},
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          RouteTableSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new route table without routes",
+			spec:     fakeRT2,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result.(network.RouteTable).Location).To(Equal(pointer.String("fake-location")))
+				g.Expect(result.(network.RouteTable).Routes).To(BeNil())
+			},
+		},
+		{
+			name:     "new route table with routes",
+			spec:     fakeRoutesRT,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(*result.(network.RouteTable).Routes).To(Equal(fakeRoutes))
+			},
+		},
+		{
+			name: "existing route table is left untouched when no routes are declared",
+			spec: fakeRT2,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &fakeRoutes,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing route table with the declared routes in a different order is up to date",
+			spec: fakeRoutesRT,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{fakeRoutes[1], fakeRoutes[0]},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "routes of existing route table are updated in place",
+			spec: fakeRoutesRT,
+			existing: network.RouteTable{
+				ID:       pointer.String("test-rt-routes-id"),
+				Location: pointer.String("fake-location"),
+				Tags:     map[string]*string{"foo": pointer.String("bar")},
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						{
+							Name: pointer.String("default"),
+							RoutePropertiesFormat: &network.RoutePropertiesFormat{
+								AddressPrefix:    pointer.String("0.0.0.0/0"),
+								NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+								NextHopIPAddress: pointer.String("10.1.0.5"),
+							},
+						},
+						{
+							Name: pointer.String("stale"),
+							RoutePropertiesFormat: &network.RoutePropertiesFormat{
+								AddressPrefix: pointer.String("10.2.0.0/16"),
+								NextHopType:   network.RouteNextHopTypeNone,
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				routeTable := result.(network.RouteTable)
+				g.Expect(routeTable.ID).To(Equal(pointer.String("test-rt-routes-id")))
+				g.Expect(routeTable.Tags).To(HaveKeyWithValue("foo", pointer.String("bar")))
+				g.Expect(*routeTable.Routes).To(Equal(fakeRoutes))
+			},
+		},
+		{
+			name:          "existing is not a route table",
+			spec:          fakeRoutesRT,
+			existing:      network.Route{},
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "network.Route is not a network.RouteTable",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
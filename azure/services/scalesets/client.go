@@ -50,8 +50,10 @@ type Client interface {
 type (
 	// AzureClient contains the Azure go-sdk Client.
 	AzureClient struct {
-		scalesetvms compute.VirtualMachineScaleSetVMsClient
-		scalesets   compute.VirtualMachineScaleSetsClient
+		subscriptionID string
+		scalesetvms    compute.VirtualMachineScaleSetVMsClient
+		scalesets      compute.VirtualMachineScaleSetsClient
+		instances      *InstanceCache
 	}
 
 	genericScaleSetFuture interface {
@@ -71,11 +73,14 @@ type (
 
 var _ Client = &AzureClient{}
 
-// NewClient creates a new VMSS client from subscription ID.
-func NewClient(auth azure.Authorizer) *AzureClient {
+// NewClient creates a new VMSS client from subscription ID. The instances of the scale sets are cached in instances,
+// which may be nil.
+func NewClient(auth azure.Authorizer, instances *InstanceCache) *AzureClient {
 	return &AzureClient{
-		scalesetvms: newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
-		scalesets:   newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		subscriptionID: auth.SubscriptionID(),
+		scalesetvms:    newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		scalesets:      newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		instances:      instances,
 	}
 }
 
@@ -93,11 +98,17 @@ func newVirtualMachineScaleSetsClient(subscriptionID string, baseURI string, aut
 	return c
 }
 
-// ListInstances retrieves information about the model views of a virtual machine scale set.
+// ListInstances retrieves information about the model views of a virtual machine scale set. The instances are served
+// from the instance cache of the client, if any.
 func (ac *AzureClient) ListInstances(ctx context.Context, resourceGroupName, vmssName string) ([]compute.VirtualMachineScaleSetVM, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.ListInstances")
 	defer done()
 
+	key := instanceCacheKey{subscriptionID: ac.subscriptionID, resourceGroup: resourceGroupName, vmssName: vmssName}
+	if instances, ok := ac.instances.get(key); ok {
+		return instances, nil
+	}
+
 	itr, err := ac.scalesetvms.ListComplete(ctx, resourceGroupName, vmssName, "", "", "")
 	if err != nil {
 		return nil, err
@@ -111,6 +122,7 @@ func (ac *AzureClient) ListInstances(ctx context.Context, resourceGroupName, vms
 		vm := itr.Value()
 		instances = append(instances, vm)
 	}
+	ac.instances.add(key, instances)
 	return instances, nil
 }
 
@@ -148,6 +160,7 @@ func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, vmssName stri
 func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupName, vmssName string, vmss compute.VirtualMachineScaleSet) (*infrav1.Future, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.CreateOrUpdateAsync")
 	defer done()
+	defer ac.instances.Invalidate(ac.subscriptionID, resourceGroupName, vmssName)
 
 	future, err := ac.scalesets.CreateOrUpdate(ctx, resourceGroupName, vmssName, vmss)
 	if err != nil {
@@ -182,6 +195,7 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupNam
 func (ac *AzureClient) UpdateAsync(ctx context.Context, resourceGroupName, vmssName string, parameters compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.UpdateAsync")
 	defer done()
+	defer ac.instances.Invalidate(ac.subscriptionID, resourceGroupName, vmssName)
 
 	future, err := ac.scalesets.Update(ctx, resourceGroupName, vmssName, parameters)
 	if err != nil {
@@ -254,6 +268,7 @@ func (ac *AzureClient) GetResultIfDone(ctx context.Context, future *infrav1.Futu
 	if !done {
 		return compute.VirtualMachineScaleSet{}, azure.WithTransientError(azure.NewOperationNotDoneError(future), 15*time.Second)
 	}
+	ac.instances.Invalidate(ac.subscriptionID, future.ResourceGroup, future.Name)
 
 	vmss, err := genericFuture.Result(ac.scalesets)
 	if err != nil {
@@ -267,6 +282,7 @@ func (ac *AzureClient) GetResultIfDone(ctx context.Context, future *infrav1.Futu
 func (ac *AzureClient) UpdateInstances(ctx context.Context, resourceGroupName, vmssName string, instanceIDs []string) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.UpdateInstances")
 	defer done()
+	defer ac.instances.Invalidate(ac.subscriptionID, resourceGroupName, vmssName)
 
 	params := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
 		InstanceIds: &instanceIDs,
@@ -294,6 +310,7 @@ func (ac *AzureClient) UpdateInstances(ctx context.Context, resourceGroupName, v
 func (ac *AzureClient) DeleteAsync(ctx context.Context, resourceGroupName, vmssName string) (*infrav1.Future, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.DeleteAsync")
 	defer done()
+	defer ac.instances.Invalidate(ac.subscriptionID, resourceGroupName, vmssName)
 
 	future, err := ac.scalesets.Delete(ctx, resourceGroupName, vmssName, pointer.Bool(false))
 	if err != nil {
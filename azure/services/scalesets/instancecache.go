@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+)
+
+// instanceCacheSize is the maximum number of scale sets whose instances are kept in the cache.
+const instanceCacheSize = 1024
+
+// InstanceCache holds the instance lists of scale sets, so that reconciling many AzureMachinePools doesn't list the
+// instances of each scale set on every reconcile. The cache of a scale set is invalidated whenever CAPZ writes to the
+// scale set or its instances. A nil InstanceCache caches nothing.
+type InstanceCache struct {
+	cache ttllru.PeekingCacher
+}
+
+// instanceCacheKey identifies a scale set whose instances are cached.
+type instanceCacheKey struct {
+	subscriptionID string
+	resourceGroup  string
+	vmssName       string
+}
+
+// NewInstanceCache creates an InstanceCache which caches the instances of a scale set for ttl. It returns a nil
+// InstanceCache, which caches nothing, when ttl is zero.
+func NewInstanceCache(ttl time.Duration) (*InstanceCache, error) {
+	if ttl <= 0 {
+		return nil, nil
+	}
+	cache, err := ttllru.New(instanceCacheSize, ttl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for scale set instances")
+	}
+	return &InstanceCache{cache: cache}, nil
+}
+
+// Invalidate removes the cached instances of a scale set. It must be called after any operation changing the
+// instances of the scale set.
+func (c *InstanceCache) Invalidate(subscriptionID, resourceGroup, vmssName string) {
+	if c == nil {
+		return
+	}
+	c.cache.Remove(instanceCacheKey{subscriptionID: subscriptionID, resourceGroup: resourceGroup, vmssName: vmssName})
+}
+
+// get returns the cached instances of a scale set, if any.
+func (c *InstanceCache) get(key instanceCacheKey) ([]compute.VirtualMachineScaleSetVM, bool) {
+	if c == nil {
+		return nil, false
+	}
+	// Peek doesn't extend the lifetime of the entry, so the instances are listed again every TTL.
+	instances, _, ok := c.cache.Peek(key)
+	if !ok {
+		return nil, false
+	}
+	return append([]compute.VirtualMachineScaleSetVM(nil), instances.([]compute.VirtualMachineScaleSetVM)...), true
+}
+
+// add caches the instances of a scale set.
+func (c *InstanceCache) add(key instanceCacheKey, instances []compute.VirtualMachineScaleSetVM) {
+	if c == nil {
+		return
+	}
+	_ = c.cache.Add(key, append([]compute.VirtualMachineScaleSetVM(nil), instances...))
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestInstanceCache(t *testing.T) {
+	g := NewWithT(t)
+
+	key := instanceCacheKey{subscriptionID: "sub", resourceGroup: "my-rg", vmssName: "my-vmss"}
+	instances := []compute.VirtualMachineScaleSetVM{{InstanceID: pointer.String("0")}}
+
+	// caching is disabled without a TTL
+	c, err := NewInstanceCache(0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(c).To(BeNil())
+	c.add(key, instances)
+	_, ok := c.get(key)
+	g.Expect(ok).To(BeFalse())
+	c.Invalidate("sub", "my-rg", "my-vmss")
+
+	c, err = NewInstanceCache(time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+	c.add(key, instances)
+	cached, ok := c.get(key)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cached).To(Equal(instances))
+
+	// other scale sets are not affected by the invalidation of a scale set
+	c.Invalidate("sub", "my-rg", "other-vmss")
+	_, ok = c.get(key)
+	g.Expect(ok).To(BeTrue())
+
+	c.Invalidate("sub", "my-rg", "my-vmss")
+	_, ok = c.get(key)
+	g.Expect(ok).To(BeFalse())
+
+	c, err = NewInstanceCache(time.Millisecond)
+	g.Expect(err).NotTo(HaveOccurred())
+	c.add(key, instances)
+	time.Sleep(2 * time.Millisecond)
+	_, ok = c.get(key)
+	g.Expect(ok).To(BeFalse())
+}
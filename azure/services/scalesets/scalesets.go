@@ -30,10 +30,12 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/previewfeatures"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
 	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const serviceName = "scalesets"
@@ -50,6 +52,7 @@ type (
 		ScaleSetSpec() azure.ScaleSetSpec
 		VMSSExtensionSpecs() []azure.ResourceSpecGetter
 		SetAnnotation(string, string)
+		SetConditionFalse(clusterv1.ConditionType, string, clusterv1.ConditionSeverity, string)
 		SetProviderID(string)
 		SetVMSSState(*azure.VMSS)
 		ReconcileReplicas(context.Context, *azure.VMSS) error
@@ -62,15 +65,17 @@ type (
 		Scope ScaleSetScope
 		Client
 		resourceSKUCache *resourceskus.Cache
+		featureCache     *previewfeatures.Cache
 	}
 )
 
 // New creates a new service.
-func New(scope ScaleSetScope, skuCache *resourceskus.Cache) *Service {
+func New(scope ScaleSetScope, skuCache *resourceskus.Cache, featureCache *previewfeatures.Cache, instanceCache *InstanceCache) *Service {
 	return &Service{
-		Client:           NewClient(scope),
+		Client:           NewClient(scope, instanceCache),
 		Scope:            scope,
 		resourceSKUCache: skuCache,
+		featureCache:     featureCache,
 	}
 }
 
@@ -292,7 +297,16 @@ func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS)
 		}
 	}
 
-	hasModelChanges := hasModelModifyingDifferences(infraVMSS, vmss)
+	desired := converters.SDKToVMSS(vmss, []compute.VirtualMachineScaleSetVM{})
+	hasModelChanges := infraVMSS.HasModelChanges(*desired)
+	// the max price of spot instances is updated in place, so it doesn't need a surge.
+	hasSpotMaxPriceChanges := infraVMSS.HasSpotMaxPriceChanges(*desired)
+	if hasSpotMaxPriceChanges && desired.SpotMaxPrice == nil {
+		// an empty billing profile leaves the max price unchanged, so reset it to the on-demand price explicitly.
+		patch.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{MaxPrice: pointer.Float64(converters.SpotMaxPriceOnDemand)}
+	}
+	// the scale-in policy only applies to the scale set, so it doesn't need a surge either.
+	hasScaleInPolicyChanges := desired.ScaleInPolicy != "" && desired.ScaleInPolicy != infraVMSS.ScaleInPolicy
 	isFlex := s.Scope.ScaleSetSpec().OrchestrationMode == infrav1.FlexibleOrchestrationMode
 	updated := true
 	if !isFlex {
@@ -308,8 +322,8 @@ func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS)
 	// If the VMSS is managed by an external autoscaler, we should patch the VMSS if customData has changed.
 	// If there are no model changes and no increase in the replica count, do not update the VMSS.
 	// Decreases in replica count is handled by deleting AzureMachinePoolMachine instances in the MachinePoolScope
-	if *patch.Sku.Capacity <= infraVMSS.Capacity && !hasModelChanges && !shouldPatchCustomData {
-		log.V(4).Info("nothing to update on vmss", "scale set", spec.Name, "newReplicas", *patch.Sku.Capacity, "oldReplicas", infraVMSS.Capacity, "hasModelChanges", hasModelChanges, "shouldPatchCustomData", shouldPatchCustomData)
+	if *patch.Sku.Capacity <= infraVMSS.Capacity && !hasModelChanges && !hasSpotMaxPriceChanges && !hasScaleInPolicyChanges && !shouldPatchCustomData {
+		log.V(4).Info("nothing to update on vmss", "scale set", spec.Name, "newReplicas", *patch.Sku.Capacity, "oldReplicas", infraVMSS.Capacity, "hasModelChanges", hasModelChanges, "hasSpotMaxPriceChanges", hasSpotMaxPriceChanges, "hasScaleInPolicyChanges", hasScaleInPolicyChanges, "shouldPatchCustomData", shouldPatchCustomData)
 		return nil, nil
 	}
 
@@ -327,11 +341,6 @@ func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS)
 	return future, err
 }
 
-func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss compute.VirtualMachineScaleSet) bool {
-	other := converters.SDKToVMSS(vmss, []compute.VirtualMachineScaleSetVM{})
-	return infraVMSS.HasModelChanges(*other)
-}
-
 func (s *Service) validateSpec(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.validateSpec")
 	defer done()
@@ -368,10 +377,53 @@ func (s *Service) validateSpec(ctx context.Context) error {
 		return azure.WithTerminalError(fmt.Errorf("vm size %s does not support ephemeral os. select a different vm size or disable ephemeral os", spec.Size))
 	}
 
-	if spec.SecurityProfile != nil && !sku.HasCapability(resourceskus.EncryptionAtHost) {
+	if spec.OSDisk.DiffDiskSettings != nil && spec.OSDisk.DiffDiskSettings.Placement != nil {
+		placement := string(*spec.OSDisk.DiffDiskSettings.Placement)
+		supported, err := sku.SupportsEphemeralOSDiskPlacement(placement, pointer.Int32Deref(spec.OSDisk.DiskSizeGB, 0))
+		if err != nil {
+			return azure.WithTerminalError(errors.Wrap(err, "failed to validate the ephemeral os disk placement"))
+		}
+		if !supported {
+			return azure.WithTerminalError(fmt.Errorf("vm size %s does not have a %s large enough for the ephemeral os disk. select a different vm size or placement", spec.Size, placement))
+		}
+	}
+
+	if spec.SecurityProfile != nil && pointer.BoolDeref(spec.SecurityProfile.EncryptionAtHost, false) && !sku.HasCapability(resourceskus.EncryptionAtHost) {
 		return azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", spec.Size))
 	}
 
+	if spec.GPUDriver != nil {
+		gpuCapability, err := sku.HasCapabilityWithCapacity(resourceskus.GPUs, resourceskus.MinimumGPUs)
+		if err != nil {
+			return azure.WithTerminalError(errors.Wrap(err, "failed to validate the GPU capability"))
+		}
+		if !gpuCapability {
+			return azure.WithTerminalError(errors.Errorf("vm size %s does not have GPUs. select a different vm size or remove the GPU driver", spec.Size))
+		}
+	}
+
+	if spec.SecurityProfile != nil && pointer.BoolDeref(spec.SecurityProfile.EncryptionAtHost, false) {
+		registered, err := s.featureCache.IsRegistered(ctx, previewfeatures.ComputeNamespace, previewfeatures.EncryptionAtHost)
+		if err != nil {
+			return errors.Wrap(err, "failed to check whether encryption at host is enabled for the subscription")
+		}
+		if !registered {
+			msg := fmt.Sprintf("encryption at host is not enabled for subscription %s. register the %s/%s feature or disable encryption at host", s.Scope.SubscriptionID(), previewfeatures.ComputeNamespace, previewfeatures.EncryptionAtHost)
+			s.Scope.SetConditionFalse(infrav1.ScaleSetRunningCondition, infrav1.EncryptionAtHostNotRegisteredReason, clusterv1.ConditionSeverityError, msg)
+			return azure.WithTerminalError(errors.New(msg))
+		}
+	}
+
+	if spec.SecurityProfile != nil && spec.SecurityProfile.SecurityType == infrav1.SecurityTypesTrustedLaunch && !sku.SupportsTrustedLaunch() {
+		return azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", spec.Size))
+	}
+
+	if spec.SecurityProfile != nil && spec.SecurityProfile.SecurityType == infrav1.SecurityTypesConfidentialVM {
+		if _, ok := sku.GetCapability(resourceskus.ConfidentialComputingType); !ok {
+			return azure.WithTerminalError(errors.Errorf("confidential computing is not supported for VM type %s", spec.Size))
+		}
+	}
+
 	// Fetch location and zone to check for their support of ultra disks.
 	location := s.Scope.Location()
 	zones, err := s.resourceSKUCache.GetZones(ctx, location)
@@ -434,6 +486,26 @@ func (s *Service) validateSpec(ctx context.Context) error {
 		}
 	}
 
+	// Premium v2 disks can only be attached to zonal scale sets in zones where they are available.
+	for _, disk := range spec.DataDisks {
+		if disk.ManagedDisk == nil || disk.ManagedDisk.StorageAccountType != infrav1.StorageAccountTypePremiumV2LRS {
+			continue
+		}
+		if len(spec.FailureDomains) == 0 {
+			return azure.WithTerminalError(errors.Errorf("premium v2 disks can only be attached to vms in an availability zone. select a failure domain or a different storage account type for data disk %s", disk.NameSuffix))
+		}
+		premiumV2Zones, err := s.resourceSKUCache.GetZonesWithDiskType(ctx, infrav1.StorageAccountTypePremiumV2LRS, s.Scope.Location())
+		if err != nil {
+			return errors.Wrapf(err, "failed to get zones for disk type %s in location %s", infrav1.StorageAccountTypePremiumV2LRS, s.Scope.Location())
+		}
+		for _, az := range spec.FailureDomains {
+			if !slice.Contains(premiumV2Zones, az) {
+				return azure.WithTerminalError(errors.Errorf("premium v2 disks are not supported in zone %s of location %s. select a different failure domain or storage account type for data disk %s", az, s.Scope.Location(), disk.NameSuffix))
+			}
+		}
+		break
+	}
+
 	return nil
 }
 
@@ -522,6 +594,29 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 		if len(vmssSpec.FailureDomains) > 1 {
 			vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = pointer.Int32(int32(len(vmssSpec.FailureDomains)))
 		}
+		if vmssSpec.PlatformFaultDomainCount != nil {
+			vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = vmssSpec.PlatformFaultDomainCount
+		}
+	}
+
+	if vmssSpec.ProximityPlacementGroupID != "" {
+		vmss.VirtualMachineScaleSetProperties.ProximityPlacementGroup = &compute.SubResource{
+			ID: pointer.String(vmssSpec.ProximityPlacementGroupID),
+		}
+	}
+
+	if vmssSpec.ScaleInPolicy != "" {
+		vmss.VirtualMachineScaleSetProperties.ScaleInPolicy = &compute.ScaleInPolicy{
+			Rules: &[]compute.VirtualMachineScaleSetScaleInRules{compute.VirtualMachineScaleSetScaleInRules(vmssSpec.ScaleInPolicy)},
+		}
+	}
+
+	if vmssSpec.CapacityReservationGroupID != "" {
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.CapacityReservation = &compute.CapacityReservationProfile{
+			CapacityReservationGroup: &compute.SubResource{
+				ID: pointer.String(vmssSpec.CapacityReservationGroupID),
+			},
+		}
 	}
 
 	// Assign Identity to VMSS
@@ -579,6 +674,41 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 	return vmss, nil
 }
 
+// getVirtualMachineScaleSetPublicIPAddressConfiguration returns the configuration of the public IPs of the scale set instances,
+// which are allocated from the public IP prefix of the spec if there is one. Azure derives a unique DNS name per instance from the DNS label.
+func getVirtualMachineScaleSetPublicIPAddressConfiguration(vmssSpec azure.ScaleSetSpec) *compute.VirtualMachineScaleSetPublicIPAddressConfiguration {
+	publicIPConfig := &compute.VirtualMachineScaleSetPublicIPAddressConfiguration{
+		Name: pointer.String(vmssSpec.Name + "-public-ip"),
+		Sku: &compute.PublicIPAddressSku{
+			Name: compute.PublicIPAddressSkuNameStandard,
+		},
+		VirtualMachineScaleSetPublicIPAddressConfigurationProperties: &compute.VirtualMachineScaleSetPublicIPAddressConfigurationProperties{
+			PublicIPAddressVersion: compute.IPVersionIPv4,
+		},
+	}
+	if vmssSpec.PublicIPPrefixID != "" {
+		publicIPConfig.PublicIPPrefix = &compute.SubResource{ID: pointer.String(vmssSpec.PublicIPPrefixID)}
+	}
+	if vmssSpec.PublicIP != nil {
+		if vmssSpec.PublicIP.DNSLabelPrefix != "" {
+			publicIPConfig.DNSSettings = &compute.VirtualMachineScaleSetPublicIPAddressConfigurationDNSSettings{
+				DomainNameLabel: pointer.String(azure.GenerateNodePublicIPDNSLabel(vmssSpec.PublicIP.DNSLabelPrefix, vmssSpec.Name)),
+			}
+		}
+		if len(vmssSpec.PublicIP.IPTags) > 0 {
+			ipTags := make([]compute.VirtualMachineScaleSetIPTag, 0, len(vmssSpec.PublicIP.IPTags))
+			for _, ipTag := range vmssSpec.PublicIP.IPTags {
+				ipTags = append(ipTags, compute.VirtualMachineScaleSetIPTag{
+					IPTagType: pointer.String(ipTag.Type),
+					Tag:       pointer.String(ipTag.Tag),
+				})
+			}
+			publicIPConfig.IPTags = &ipTags
+		}
+	}
+	return publicIPConfig
+}
+
 func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.ScaleSetSpec) *[]compute.VirtualMachineScaleSetNetworkConfiguration {
 	var backendAddressPools []compute.SubResource
 	if vmssSpec.PublicLBName != "" {
@@ -595,6 +725,9 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 		nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties = &compute.VirtualMachineScaleSetNetworkConfigurationProperties{}
 		nicConfig.Name = pointer.String(vmssSpec.Name + "-nic-" + strconv.Itoa(i))
 		nicConfig.EnableIPForwarding = pointer.Bool(true)
+		if n.EnableIPForwarding != nil {
+			nicConfig.EnableIPForwarding = n.EnableIPForwarding
+		}
 		if n.AcceleratedNetworking != nil {
 			nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.EnableAcceleratedNetworking = n.AcceleratedNetworking
 		} else {
@@ -619,6 +752,9 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 			if j == 0 {
 				// Always use the first IPConfig as the Primary
 				ipconfig.Primary = pointer.Bool(true)
+				if i == 0 && vmssSpec.AllocatePublicIP {
+					ipconfig.PublicIPAddressConfiguration = getVirtualMachineScaleSetPublicIPAddressConfiguration(vmssSpec)
+				}
 			}
 			ipconfigs = append(ipconfigs, ipconfig)
 		}
@@ -633,6 +769,14 @@ func (s *Service) getVirtualMachineScaleSetNetworkConfiguration(vmssSpec azure.S
 					},
 				},
 			}
+			if i == 0 && vmssSpec.PublicLBName != "" && vmssSpec.PublicLBAddressPoolName != "" {
+				// The IPv6 configuration of the primary NIC joins the IPv6 counterpart of the outbound backend pool.
+				ipv6Config.LoadBalancerBackendAddressPools = &[]compute.SubResource{
+					{
+						ID: pointer.String(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.PublicLBName, azure.GenerateIPv6Name(vmssSpec.PublicLBAddressPoolName))),
+					},
+				}
+			}
 			ipconfigs = append(ipconfigs, ipv6Config)
 		}
 		if i == 0 {
@@ -721,6 +865,9 @@ func (s *Service) generateStorageProfile(ctx context.Context, vmssSpec azure.Sca
 		storageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
 			Option: compute.DiffDiskOptions(vmssSpec.OSDisk.DiffDiskSettings.Option),
 		}
+		if vmssSpec.OSDisk.DiffDiskSettings.Placement != nil {
+			storageProfile.OsDisk.DiffDiskSettings.Placement = compute.DiffDiskPlacement(*vmssSpec.OSDisk.DiffDiskSettings.Placement)
+		}
 	}
 
 	if vmssSpec.OSDisk.ManagedDisk != nil {
@@ -731,6 +878,9 @@ func (s *Service) generateStorageProfile(ctx context.Context, vmssSpec azure.Sca
 		if vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
 		}
+		if vmssSpec.OSDisk.ManagedDisk.SecurityProfile != nil {
+			storageProfile.OsDisk.ManagedDisk.SecurityProfile = converters.VMDiskSecurityProfileToSDK(vmssSpec.OSDisk.ManagedDisk.SecurityProfile)
+		}
 	}
 
 	if vmssSpec.OSDisk.CachingType != "" {
@@ -831,27 +981,7 @@ func (s *Service) generateImagePlan(ctx context.Context) *compute.Plan {
 		return nil
 	}
 
-	if image.SharedGallery != nil && image.SharedGallery.Publisher != nil && image.SharedGallery.SKU != nil && image.SharedGallery.Offer != nil {
-		return &compute.Plan{
-			Publisher: image.SharedGallery.Publisher,
-			Name:      image.SharedGallery.SKU,
-			Product:   image.SharedGallery.Offer,
-		}
-	}
-
-	if image.Marketplace == nil || !image.Marketplace.ThirdPartyImage {
-		return nil
-	}
-
-	if image.Marketplace.Publisher == "" || image.Marketplace.SKU == "" || image.Marketplace.Offer == "" {
-		return nil
-	}
-
-	return &compute.Plan{
-		Publisher: pointer.String(image.Marketplace.Publisher),
-		Name:      pointer.String(image.Marketplace.SKU),
-		Product:   pointer.String(image.Marketplace.Offer),
-	}
+	return converters.ImageToPlan(image)
 }
 
 func getVMSSUpdateFromVMSS(vmss compute.VirtualMachineScaleSet) (compute.VirtualMachineScaleSetUpdate, error) {
@@ -875,13 +1005,28 @@ func getSecurityProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*com
 		return nil, nil
 	}
 
-	if !sku.HasCapability(resourceskus.EncryptionAtHost) {
+	if pointer.BoolDeref(vmssSpec.SecurityProfile.EncryptionAtHost, false) && !sku.HasCapability(resourceskus.EncryptionAtHost) {
 		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", vmssSpec.Size))
 	}
 
-	return &compute.SecurityProfile{
-		EncryptionAtHost: pointer.Bool(*vmssSpec.SecurityProfile.EncryptionAtHost),
-	}, nil
+	if vmssSpec.SecurityProfile.SecurityType == infrav1.SecurityTypesTrustedLaunch && !sku.SupportsTrustedLaunch() {
+		return nil, azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", vmssSpec.Size))
+	}
+
+	securityProfile := &compute.SecurityProfile{
+		EncryptionAtHost: vmssSpec.SecurityProfile.EncryptionAtHost,
+	}
+	if vmssSpec.SecurityProfile.SecurityType != "" {
+		securityProfile.SecurityType = compute.SecurityTypes(vmssSpec.SecurityProfile.SecurityType)
+		if vmssSpec.SecurityProfile.UefiSettings != nil {
+			securityProfile.UefiSettings = &compute.UefiSettings{
+				SecureBootEnabled: vmssSpec.SecurityProfile.UefiSettings.SecureBootEnabled,
+				VTpmEnabled:       vmssSpec.SecurityProfile.UefiSettings.VTpmEnabled,
+			}
+		}
+	}
+
+	return securityProfile, nil
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO scale set.
@@ -30,6 +30,7 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/previewfeatures"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -541,6 +542,134 @@ func TestReconcileVMSS(t *testing.T) {
 				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
 			},
 		},
+		{
+			name:          "should start creating a vmss consuming a capacity reservation",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.CapacityReservationGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.Sku.Name = pointer.String(spec.Size)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.CapacityReservation = &compute.CapacityReservationProfile{
+					CapacityReservationGroup: &compute.SubResource{
+						ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"),
+					},
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a vmss with public IPs from a public IP prefix",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.AllocatePublicIP = true
+				spec.PublicIPPrefixID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.Sku.Name = pointer.String(spec.Size)
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				(*(*netConfigs)[0].IPConfigurations)[0].PublicIPAddressConfiguration = &compute.VirtualMachineScaleSetPublicIPAddressConfiguration{
+					Name: pointer.String("my-vmss-public-ip"),
+					Sku: &compute.PublicIPAddressSku{
+						Name: compute.PublicIPAddressSkuNameStandard,
+					},
+					VirtualMachineScaleSetPublicIPAddressConfigurationProperties: &compute.VirtualMachineScaleSetPublicIPAddressConfigurationProperties{
+						PublicIPAddressVersion: compute.IPVersionIPv4,
+						PublicIPPrefix: &compute.SubResource{
+							ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"),
+						},
+					},
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a vmss with public IPs with a DNS label and IP tags",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.AllocatePublicIP = true
+				spec.PublicIP = &infrav1.NodePublicIP{
+					DNSLabelPrefix: "nodes",
+					IPTags:         []infrav1.IPTag{{Type: "RoutingPreference", Tag: "Internet"}},
+				}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.Sku.Name = pointer.String(spec.Size)
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				(*(*netConfigs)[0].IPConfigurations)[0].PublicIPAddressConfiguration = &compute.VirtualMachineScaleSetPublicIPAddressConfiguration{
+					Name: pointer.String("my-vmss-public-ip"),
+					Sku: &compute.PublicIPAddressSku{
+						Name: compute.PublicIPAddressSkuNameStandard,
+					},
+					VirtualMachineScaleSetPublicIPAddressConfigurationProperties: &compute.VirtualMachineScaleSetPublicIPAddressConfigurationProperties{
+						PublicIPAddressVersion: compute.IPVersionIPv4,
+						DNSSettings: &compute.VirtualMachineScaleSetPublicIPAddressConfigurationDNSSettings{
+							DomainNameLabel: pointer.String("nodes-my-vmss"),
+						},
+						IPTags: &[]compute.VirtualMachineScaleSetIPTag{
+							{IPTagType: pointer.String("RoutingPreference"), Tag: pointer.String("Internet")},
+						},
+					},
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a vmss in flexible orchestration mode with a platform fault domain count",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.OrchestrationMode = infrav1.FlexibleOrchestrationMode
+				spec.PlatformFaultDomainCount = pointer.Int32(2)
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.Sku.Name = pointer.String(spec.Size)
+				vmss.OrchestrationMode = compute.OrchestrationModeFlexible
+				vmss.Overprovision = nil
+				vmss.UpgradePolicy = nil
+				vmss.PlatformFaultDomainCount = pointer.Int32(2)
+				vmss.VirtualMachineProfile.NetworkProfile.NetworkAPIVersion = compute.NetworkAPIVersionTwoZeroTwoZeroHyphenMinusOneOneHyphenMinusZeroOne
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a vmss with a scale-in policy",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.ScaleInPolicy = "NewestVM"
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.Sku.Name = pointer.String(spec.Size)
+				vmss.ScaleInPolicy = &compute.ScaleInPolicy{
+					Rules: &[]compute.VirtualMachineScaleSetScaleInRules{compute.VirtualMachineScaleSetScaleInRulesNewestVM},
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+			},
+		},
 		{
 			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
 			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
@@ -554,6 +683,104 @@ func TestReconcileVMSS(t *testing.T) {
 				})
 			},
 		},
+		{
+			name:          "should start creating a trusted launch vmss",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_TL"
+				spec.SecurityProfile = &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_TL")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesTrustedLaunch,
+					UefiSettings: &compute.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				}
+				vmss.Sku.Name = pointer.String(spec.Size)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_TL"), putFuture)
+			},
+		},
+		{
+			name:          "creating a trusted launch vmss for unsupported VM type fails",
+			expectedError: "reconcile error that cannot be recovered occurred: trusted launch is not supported for VM type VM_SIZE. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:       defaultVMSSName,
+					Size:       "VM_SIZE",
+					Capacity:   2,
+					SSHKeyData: "ZmFrZXNzaGtleQo=",
+					SecurityProfile: &infrav1.SecurityProfile{
+						SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					},
+				})
+			},
+		},
+		{
+			name:          "creating a confidential vmss for unsupported VM type fails",
+			expectedError: "reconcile error that cannot be recovered occurred: confidential computing is not supported for VM type VM_SIZE. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:       defaultVMSSName,
+					Size:       "VM_SIZE",
+					Capacity:   2,
+					SSHKeyData: "ZmFrZXNzaGtleQo=",
+					SecurityProfile: &infrav1.SecurityProfile{
+						SecurityType: infrav1.SecurityTypesConfidentialVM,
+					},
+				})
+			},
+		},
+		{
+			name:          "should start creating a vmss with ephemeral osdisk on the cache disk",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				defaultSpec := newDefaultVMSSSpec()
+				defaultSpec.Size = "VM_SIZE_EPH"
+				defaultSpec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option:    "Local",
+					Placement: (*infrav1.DiffDiskPlacement)(pointer.String(string(infrav1.DiffDiskPlacementCacheDisk))),
+				}
+				defaultSpec.OSDisk.CachingType = "ReadOnly"
+
+				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE_EPH")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+					Option:    compute.DiffDiskOptionsLocal,
+					Placement: compute.DiffDiskPlacementCacheDisk,
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.Caching = compute.CachingTypesReadOnly
+
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EPH"), putFuture)
+			},
+		},
+		{
+			name:          "creating a vmss with ephemeral osdisk on a missing resource disk fails",
+			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_EPH does not have a ResourceDisk large enough for the ephemeral os disk. select a different vm size or placement. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				defaultSpec := newDefaultVMSSSpec()
+				defaultSpec.Size = "VM_SIZE_EPH"
+				defaultSpec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option:    "Local",
+					Placement: (*infrav1.DiffDiskPlacement)(pointer.String(string(infrav1.DiffDiskPlacementResourceDisk))),
+				}
+				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
+			},
+		},
 		{
 			name:          "should start creating a vmss with ephemeral osdisk",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
@@ -620,6 +847,118 @@ func TestReconcileVMSS(t *testing.T) {
 				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Times(2).Return(false)
 			},
 		},
+		{
+			name:          "should update the spot max price in place without surging",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				maxPrice := resource.MustParse("0.5")
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{MaxPrice: &maxPrice}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+
+				setupDefaultVMSSUpdateExpectations(s)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE_EAH")
+				existingVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				existingVMSS.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				existingVMSS.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{MaxPrice: pointer.Float64(-1)}
+				instances := newDefaultInstances()
+				for i := range instances {
+					instances[i].StorageProfile.ImageReference.Version = pointer.String("2.0")
+				}
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+
+				clone := newDefaultExistingVMSS("VM_SIZE_EAH")
+				clone.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				clone.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				clone.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{MaxPrice: pointer.Float64(0.5)}
+
+				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(*patchVMSS.Sku.Capacity).To(Equal(int64(2)))
+				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
+					Return(patchFuture, nil)
+				s.SetLongRunningOperationState(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
+			},
+		},
+		{
+			name:          "should update the scale-in policy in place without surging",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.ScaleInPolicy = "OldestVM"
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+
+				setupDefaultVMSSUpdateExpectations(s)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE_EAH")
+				existingVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				existingVMSS.ScaleInPolicy = &compute.ScaleInPolicy{Rules: &[]compute.VirtualMachineScaleSetScaleInRules{compute.VirtualMachineScaleSetScaleInRulesDefault}}
+				instances := newDefaultInstances()
+				for i := range instances {
+					instances[i].StorageProfile.ImageReference.Version = pointer.String("2.0")
+				}
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+
+				clone := newDefaultExistingVMSS("VM_SIZE_EAH")
+				clone.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				clone.ScaleInPolicy = &compute.ScaleInPolicy{Rules: &[]compute.VirtualMachineScaleSetScaleInRules{compute.VirtualMachineScaleSetScaleInRulesOldestVM}}
+
+				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(*patchVMSS.Sku.Capacity).To(Equal(int64(2)))
+				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
+					Return(patchFuture, nil)
+				s.SetLongRunningOperationState(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
+			},
+		},
+		{
+			name:          "should reset the spot max price to the on-demand price when it is removed",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Size = "VM_SIZE_EAH"
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+
+				setupDefaultVMSSUpdateExpectations(s)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE_EAH")
+				existingVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				existingVMSS.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				existingVMSS.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{MaxPrice: pointer.Float64(0.5)}
+				instances := newDefaultInstances()
+				for i := range instances {
+					instances[i].StorageProfile.ImageReference.Version = pointer.String("2.0")
+				}
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+
+				clone := newDefaultExistingVMSS("VM_SIZE_EAH")
+				clone.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				clone.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+
+				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
+				g.Expect(err).NotTo(HaveOccurred())
+				patchVMSS.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{MaxPrice: pointer.Float64(-1)}
+				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
+					Return(patchFuture, nil)
+				s.SetLongRunningOperationState(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+				s.HasReplicasExternallyManaged(gomockinternal.AContext()).Return(false)
+			},
+		},
 		{
 			name:          "less than 2 vCPUs",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size should be bigger or equal to at least 2 vCPUs. Object will not be requeued",
@@ -669,6 +1008,51 @@ func TestReconcileVMSS(t *testing.T) {
 					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
 			},
 		},
+		{
+			name:          "fail to create a vmss with a premium v2 data disk without failure domains",
+			expectedError: "reconcile error that cannot be recovered occurred: premium v2 disks can only be attached to vms in an availability zone. select a failure domain or a different storage account type for data disk my-disk. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:       defaultVMSSName,
+					Size:       "VM_SIZE",
+					Capacity:   2,
+					SSHKeyData: "ZmFrZXNzaGtleQo=",
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:  "my-disk",
+							CachingType: "None",
+							ManagedDisk: &infrav1.ManagedDiskParameters{
+								StorageAccountType: infrav1.StorageAccountTypePremiumV2LRS,
+							},
+						},
+					},
+				})
+				s.Location().AnyTimes().Return("test-location")
+			},
+		},
+		{
+			name:          "fail to create a vmss with a premium v2 data disk in an unsupported zone",
+			expectedError: "reconcile error that cannot be recovered occurred: premium v2 disks are not supported in zone 3 of location test-location. select a different failure domain or storage account type for data disk my-disk. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:           defaultVMSSName,
+					Size:           "VM_SIZE",
+					Capacity:       2,
+					SSHKeyData:     "ZmFrZXNzaGtleQo=",
+					FailureDomains: []string{"1", "3"},
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:  "my-disk",
+							CachingType: "None",
+							ManagedDisk: &infrav1.ManagedDiskParameters{
+								StorageAccountType: infrav1.StorageAccountTypePremiumV2LRS,
+							},
+						},
+					},
+				})
+				s.Location().AnyTimes().Return("test-location")
+			},
+		},
 		{
 			name:          "fail to create a vm with ultra disk implicitly enabled by data disk, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
@@ -873,6 +1257,7 @@ func TestReconcileVMSS(t *testing.T) {
 				Scope:            scopeMock,
 				Client:           clientMock,
 				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+				featureCache:     previewfeatures.NewStaticCache("Microsoft.Compute/EncryptionAtHost"),
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -886,6 +1271,104 @@ func TestReconcileVMSS(t *testing.T) {
 	}
 }
 
+func TestReconcileVMSSEncryptionAtHostNotRegistered(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
+	clientMock := mock_scalesets.NewMockClient(mockCtrl)
+
+	spec := newDefaultVMSSSpec()
+	spec.Size = "VM_SIZE_EAH"
+	spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: pointer.Bool(true)}
+	scopeMock.EXPECT().ScaleSetSpec().Return(spec).AnyTimes()
+	scopeMock.EXPECT().SubscriptionID().Return("123").AnyTimes()
+	msg := "encryption at host is not enabled for subscription 123. register the Microsoft.Compute/EncryptionAtHost feature or disable encryption at host"
+	scopeMock.EXPECT().SetConditionFalse(infrav1.ScaleSetRunningCondition, infrav1.EncryptionAtHostNotRegisteredReason, clusterv1.ConditionSeverityError, msg)
+
+	s := &Service{
+		Scope:            scopeMock,
+		Client:           clientMock,
+		resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+		featureCache:     previewfeatures.NewStaticCache(),
+	}
+
+	err := s.Reconcile(context.TODO())
+	g.Expect(err).To(MatchError("reconcile error that cannot be recovered occurred: " + msg + ". Object will not be requeued"))
+}
+
+func TestGenerateImagePlan(t *testing.T) {
+	testcases := []struct {
+		name   string
+		image  *infrav1.Image
+		result *compute.Plan
+	}{
+		{
+			name: "marketplace image without plan",
+			image: &infrav1.Image{
+				Marketplace: &infrav1.AzureMarketplaceImage{
+					ImagePlan: infrav1.ImagePlan{Publisher: "my-publisher", Offer: "my-offer", SKU: "my-sku"},
+					Version:   "1.0.0",
+				},
+			},
+		},
+		{
+			name: "third party marketplace image",
+			image: &infrav1.Image{
+				Marketplace: &infrav1.AzureMarketplaceImage{
+					ImagePlan:       infrav1.ImagePlan{Publisher: "my-publisher", Offer: "my-offer", SKU: "my-sku"},
+					Version:         "1.0.0",
+					ThirdPartyImage: true,
+				},
+			},
+			result: &compute.Plan{Publisher: pointer.String("my-publisher"), Product: pointer.String("my-offer"), Name: pointer.String("my-sku")},
+		},
+		{
+			name: "community gallery image with plan",
+			image: &infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{
+					Gallery: "my-community-gallery",
+					Name:    "my-image",
+					Version: "1.0.0",
+					Plan:    &infrav1.ImagePlan{Publisher: "my-publisher", Offer: "my-offer", SKU: "my-sku"},
+				},
+			},
+			result: &compute.Plan{Publisher: pointer.String("my-publisher"), Product: pointer.String("my-offer"), Name: pointer.String("my-sku")},
+		},
+		{
+			name: "directly shared gallery image without plan",
+			image: &infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{
+					Gallery:      "my-shared-gallery",
+					Name:         "my-image",
+					Version:      "1.0.0",
+					DirectShared: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
+			scopeMock.EXPECT().GetVMImage(gomockinternal.AContext()).Return(tc.image, nil)
+
+			s := &Service{
+				Scope: scopeMock,
+			}
+
+			g.Expect(s.generateImagePlan(context.TODO())).To(Equal(tc.result))
+		})
+	}
+}
+
 func TestDeleteVMSS(t *testing.T) {
 	const (
 		resourceGroup = "my-rg"
@@ -1155,6 +1638,34 @@ func getFakeSkus() []compute.ResourceSku {
 				},
 			},
 		},
+		{
+			Name:         pointer.String("VM_SIZE_TL"),
+			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
+			Kind:         pointer.String(string(resourceskus.VirtualMachines)),
+			Locations: &[]string{
+				"test-location",
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: pointer.String("test-location"),
+					Zones:    &[]string{"1", "3"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  pointer.String(resourceskus.VCPUs),
+					Value: pointer.String("4"),
+				},
+				{
+					Name:  pointer.String(resourceskus.MemoryGB),
+					Value: pointer.String("8"),
+				},
+				{
+					Name:  pointer.String(resourceskus.HyperVGenerations),
+					Value: pointer.String("V1,V2"),
+				},
+			},
+		},
 		{
 			Name:         pointer.String("VM_SIZE_USSD"),
 			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
@@ -1224,6 +1735,24 @@ func getFakeSkus() []compute.ResourceSku {
 					Name:  pointer.String(resourceskus.EphemeralOSDisk),
 					Value: pointer.String("True"),
 				},
+				{
+					Name:  pointer.String(resourceskus.CachedDiskBytes),
+					Value: pointer.String("274877906944"),
+				},
+			},
+		},
+		{
+			Name:         pointer.String("PremiumV2_LRS"),
+			ResourceType: pointer.String(string(resourceskus.Disks)),
+			Kind:         pointer.String(string(resourceskus.Disks)),
+			Locations: &[]string{
+				"test-location",
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: pointer.String("test-location"),
+					Zones:    &[]string{"1"},
+				},
 			},
 		},
 	}
@@ -58,14 +58,20 @@ func (s *VMSSExtensionSpec) Parameters(ctx context.Context, existing interface{}
 		return nil, nil
 	}
 
-	return compute.VirtualMachineScaleSetExtension{
+	extension := compute.VirtualMachineScaleSetExtension{
 		Name: pointer.String(s.Name),
 		VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
 			Publisher:          pointer.String(s.Publisher),
-			Type:               pointer.String(s.Name),
+			Type:               pointer.String(s.ExtensionType()),
 			TypeHandlerVersion: pointer.String(s.Version),
 			Settings:           s.Settings,
 			ProtectedSettings:  s.ProtectedSettings,
 		},
-	}, nil
+	}
+
+	if len(s.ProvisionAfterExtensions) > 0 {
+		extension.ProvisionAfterExtensions = &s.ProvisionAfterExtensions
+	}
+
+	return extension, nil
 }
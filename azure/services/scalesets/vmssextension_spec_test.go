@@ -39,6 +39,20 @@ var (
 		"my-rg",
 	}
 
+	fakeVMSSExtensionSpecAfterBootstrap = VMSSExtensionSpec{
+		azure.ExtensionSpec{
+			Name:                     "my-vm-extension",
+			Type:                     "CustomScript",
+			VMName:                   "my-vm",
+			Publisher:                "my-publisher",
+			Version:                  "1.0",
+			Settings:                 map[string]string{"commandToExecute": "echo hello"},
+			ProtectedSettings:        map[string]string{"my-protected-setting": "my-protected-value"},
+			ProvisionAfterExtensions: []string{azure.BootstrappingExtensionLinux},
+		},
+		"my-rg",
+	}
+
 	fakeVMSSExtensionParams = compute.VirtualMachineScaleSetExtension{
 		Name: pointer.String("my-vm-extension"),
 		VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
@@ -68,6 +82,25 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for vmextension provisioned after bootstrap",
+			spec:     &fakeVMSSExtensionSpecAfterBootstrap,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineScaleSetExtension{
+					Name: pointer.String("my-vm-extension"),
+					VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
+						Publisher:                pointer.String("my-publisher"),
+						Type:                     pointer.String("CustomScript"),
+						TypeHandlerVersion:       pointer.String("1.0"),
+						Settings:                 map[string]string{"commandToExecute": "echo hello"},
+						ProtectedSettings:        map[string]string{"my-protected-setting": "my-protected-value"},
+						ProvisionAfterExtensions: &[]string{azure.BootstrappingExtensionLinux},
+					},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "vmextension that already exists",
 			spec:     &fakeVMSSExtensionSpec,
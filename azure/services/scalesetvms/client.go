@@ -29,6 +29,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -42,7 +43,9 @@ type client interface {
 type (
 	// azureClient contains the Azure go-sdk Client.
 	azureClient struct {
-		scalesetvms compute.VirtualMachineScaleSetVMsClient
+		subscriptionID string
+		scalesetvms    compute.VirtualMachineScaleSetVMsClient
+		instances      *scalesets.InstanceCache
 	}
 
 	genericScaleSetVMFuture interface {
@@ -58,9 +61,11 @@ type (
 var _ client = &azureClient{}
 
 // newClient creates a new VMSS client from subscription ID.
-func newClient(auth azure.Authorizer) *azureClient {
+func newClient(auth azure.Authorizer, instances *scalesets.InstanceCache) *azureClient {
 	return &azureClient{
-		scalesetvms: newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		subscriptionID: auth.SubscriptionID(),
+		scalesetvms:    newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		instances:      instances,
 	}
 }
 
@@ -78,7 +83,7 @@ func (ac *azureClient) Get(ctx context.Context, resourceGroupName, vmssName, ins
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.Get")
 	defer done()
 
-	return ac.scalesetvms.Get(ctx, resourceGroupName, vmssName, instanceID, "")
+	return ac.scalesetvms.Get(ctx, resourceGroupName, vmssName, instanceID, compute.InstanceViewTypesInstanceView)
 }
 
 // GetResultIfDone fetches the result of a long-running operation future if it is done.
@@ -135,6 +140,7 @@ func (ac *azureClient) GetResultIfDone(ctx context.Context, future *infrav1.Futu
 func (ac *azureClient) DeleteAsync(ctx context.Context, resourceGroupName, vmssName, instanceID string) (*infrav1.Future, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.DeleteAsync")
 	defer done()
+	defer ac.instances.Invalidate(ac.subscriptionID, resourceGroupName, vmssName)
 
 	future, err := ac.scalesetvms.Delete(ctx, resourceGroupName, vmssName, instanceID, pointer.Bool(false))
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -51,15 +52,19 @@ type (
 		Client   client
 		VMClient virtualmachines.Client
 		Scope    ScaleSetVMScope
+
+		// instances is the cache of the instances of the scale sets, which may be nil.
+		instances *scalesets.InstanceCache
 	}
 )
 
 // NewService creates a new service.
-func NewService(scope ScaleSetVMScope) *Service {
+func NewService(scope ScaleSetVMScope, instanceCache *scalesets.InstanceCache) *Service {
 	return &Service{
-		Client:   newClient(scope),
-		VMClient: virtualmachines.NewClient(scope),
-		Scope:    scope,
+		Client:    newClient(scope, instanceCache),
+		VMClient:  virtualmachines.NewClient(scope),
+		Scope:     scope,
+		instances: instanceCache,
 	}
 }
 
@@ -227,6 +232,7 @@ func (s *Service) deleteVMSSUniformInstance(ctx context.Context, resourceGroup s
 
 		// there was no error in fetching the result, the future has been completed
 		log.V(4).Info("successfully deleted the instance")
+		s.invalidateInstances(resourceGroup, vmssName)
 		s.Scope.DeleteLongRunningOperationState(instanceID, serviceName, infrav1.DeleteFuture)
 		return nil
 	}
@@ -249,10 +255,18 @@ func (s *Service) deleteVMSSUniformInstance(ctx context.Context, resourceGroup s
 		return errors.Wrap(err, "failed to get result of long running operation")
 	}
 
+	s.invalidateInstances(resourceGroup, vmssName)
 	s.Scope.DeleteLongRunningOperationState(instanceID, serviceName, infrav1.DeleteFuture)
 	return nil
 }
 
+// invalidateInstances removes the cached instances of the scale set once the deletion of one of its instances has
+// completed. The cache is also invalidated when the deletion starts, but the scale set may be listed again while the
+// instance is being deleted, which would keep the deleted instance in the cache until it expires.
+func (s *Service) invalidateInstances(resourceGroup, vmssName string) {
+	s.instances.Invalidate(s.Scope.SubscriptionID(), resourceGroup, vmssName)
+}
+
 // VMSSFlexVMGetter gets the information required to create, update, or delete an Azure resource.
 type VMSSFlexVMGetter struct {
 	Name          string
@@ -89,7 +89,7 @@ func TestNewService(t *testing.T) {
 		ClusterScope:            s,
 	})
 	g.Expect(err).NotTo(HaveOccurred())
-	actual := NewService(mpms)
+	actual := NewService(mpms, nil)
 	g.Expect(actual).NotTo(BeNil())
 }
 
@@ -154,7 +154,7 @@ func TestService_Reconcile(t *testing.T) {
 			scopeMock.EXPECT().Authorizer().Return(nil).AnyTimes()
 			scopeMock.EXPECT().OrchestrationMode().Return(infrav1.UniformOrchestrationMode).AnyTimes()
 
-			service := NewService(scopeMock)
+			service := NewService(scopeMock, nil)
 			service.Client = clientMock
 			c.Setup(scopeMock.EXPECT(), clientMock.EXPECT())
 
@@ -312,7 +312,7 @@ func TestService_Delete(t *testing.T) {
 			scopeMock.EXPECT().BaseURI().Return("https://localhost/").AnyTimes()
 			scopeMock.EXPECT().Authorizer().Return(nil).AnyTimes()
 
-			service := NewService(scopeMock)
+			service := NewService(scopeMock, nil)
 			service.Client = clientMock
 			service.VMClient = vmClientMock
 			c.Setup(scopeMock.EXPECT(), clientMock.EXPECT(), vmClientMock.EXPECT())
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
@@ -34,6 +35,7 @@ type NSGSpec struct {
 	Location       string
 	ClusterName    string
 	ResourceGroup  string
+	SubscriptionID string
 	AdditionalTags infrav1.Tags
 }
 
@@ -65,24 +67,33 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 		// security group already exists
 		// We append the existing NSG etag to the header to ensure we only apply the updates if the NSG has not been modified.
 		etag = existingNSG.Etag
-		// Check if the expected rules are present
+		// Check if the expected rules are present and haven't been modified out of band.
+		// Rules that aren't part of the spec, e.g. added by the cloud provider, are not managed by CAPZ and are kept as is.
 		update := false
-		securityRules = *existingNSG.SecurityRules
+		if existingNSG.SecurityGroupPropertiesFormat != nil && existingNSG.SecurityRules != nil {
+			securityRules = append(securityRules, *existingNSG.SecurityRules...)
+		}
 		for _, rule := range s.SecurityRules {
-			sdkRule := converters.SecurityRuleToSDK(rule)
-			if !ruleExists(securityRules, sdkRule) {
-				update = true
+			sdkRule := s.securityRuleToSDK(rule)
+			if ruleExists(securityRules, sdkRule) {
+				continue
+			}
+			update = true
+			if i := ruleIndex(securityRules, pointer.StringDeref(sdkRule.Name, "")); i >= 0 {
+				// Revert the drifted rule to its specification.
+				securityRules[i] = sdkRule
+			} else {
 				securityRules = append(securityRules, sdkRule)
 			}
 		}
 		if !update {
-			// Skip update for NSG as the required default rules are present
+			// Skip update for NSG as the required rules are present and up to date
 			return nil, nil
 		}
 	} else {
 		// new security group
 		for _, rule := range s.SecurityRules {
-			securityRules = append(securityRules, converters.SecurityRuleToSDK(rule))
+			securityRules = append(securityRules, s.securityRuleToSDK(rule))
 		}
 	}
 
@@ -101,26 +112,99 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 	}, nil
 }
 
-// TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
+// securityRuleToSDK converts a security rule to an Azure security rule, referencing its application security groups
+// in the resource group of the security group.
+func (s *NSGSpec) securityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
+	sdkRule := converters.SecurityRuleToSDK(rule)
+	if len(rule.SourceApplicationSecurityGroups) > 0 {
+		sdkRule.SourceApplicationSecurityGroups = s.applicationSecurityGroups(rule.SourceApplicationSecurityGroups)
+	}
+	if len(rule.DestinationApplicationSecurityGroups) > 0 {
+		sdkRule.DestinationApplicationSecurityGroups = s.applicationSecurityGroups(rule.DestinationApplicationSecurityGroups)
+	}
+	return sdkRule
+}
+
+// applicationSecurityGroups returns references to the application security groups with the given names.
+func (s *NSGSpec) applicationSecurityGroups(names []string) *[]network.ApplicationSecurityGroup {
+	asgs := make([]network.ApplicationSecurityGroup, 0, len(names))
+	for _, name := range names {
+		asgs = append(asgs, network.ApplicationSecurityGroup{
+			ID: pointer.String(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+		})
+	}
+	return &asgs
+}
+
+// ruleExists returns true if the rules contain a rule with the same name and properties as the given rule.
 func ruleExists(rules []network.SecurityRule, rule network.SecurityRule) bool {
-	for _, existingRule := range rules {
-		if !strings.EqualFold(pointer.StringDeref(existingRule.Name, ""), pointer.StringDeref(rule.Name, "")) {
-			continue
-		}
-		if !strings.EqualFold(pointer.StringDeref(existingRule.DestinationPortRange, ""), pointer.StringDeref(rule.DestinationPortRange, "")) {
-			continue
-		}
-		if existingRule.Protocol != network.SecurityRuleProtocolTCP &&
-			existingRule.Access != network.SecurityRuleAccessAllow &&
-			existingRule.Direction != network.SecurityRuleDirectionInbound {
-			continue
+	i := ruleIndex(rules, pointer.StringDeref(rule.Name, ""))
+	if i < 0 {
+		return false
+	}
+	existing, desired := rules[i].SecurityRulePropertiesFormat, rule.SecurityRulePropertiesFormat
+	if existing == nil || desired == nil {
+		return existing == desired
+	}
+	return strings.EqualFold(pointer.StringDeref(existing.Description, ""), pointer.StringDeref(desired.Description, "")) &&
+		existing.Protocol == desired.Protocol &&
+		existing.Access == desired.Access &&
+		existing.Direction == desired.Direction &&
+		pointer.Int32Deref(existing.Priority, 0) == pointer.Int32Deref(desired.Priority, 0) &&
+		strings.EqualFold(pointer.StringDeref(existing.SourcePortRange, ""), pointer.StringDeref(desired.SourcePortRange, "")) &&
+		strings.EqualFold(pointer.StringDeref(existing.DestinationPortRange, ""), pointer.StringDeref(desired.DestinationPortRange, "")) &&
+		strings.EqualFold(pointer.StringDeref(existing.SourceAddressPrefix, ""), pointer.StringDeref(desired.SourceAddressPrefix, "")) &&
+		strings.EqualFold(pointer.StringDeref(existing.DestinationAddressPrefix, ""), pointer.StringDeref(desired.DestinationAddressPrefix, "")) &&
+		prefixesEqual(existing.SourceAddressPrefixes, desired.SourceAddressPrefixes) &&
+		prefixesEqual(existing.DestinationAddressPrefixes, desired.DestinationAddressPrefixes) &&
+		prefixesEqual(applicationSecurityGroupIDs(existing.SourceApplicationSecurityGroups), applicationSecurityGroupIDs(desired.SourceApplicationSecurityGroups)) &&
+		prefixesEqual(applicationSecurityGroupIDs(existing.DestinationApplicationSecurityGroups), applicationSecurityGroupIDs(desired.DestinationApplicationSecurityGroups))
+}
+
+// applicationSecurityGroupIDs returns the IDs of the application security groups.
+func applicationSecurityGroupIDs(asgs *[]network.ApplicationSecurityGroup) *[]string {
+	if asgs == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(*asgs))
+	for _, asg := range *asgs {
+		ids = append(ids, pointer.StringDeref(asg.ID, ""))
+	}
+	return &ids
+}
+
+// ruleIndex returns the index of the rule with the given name, or -1 if there is none.
+func ruleIndex(rules []network.SecurityRule, name string) int {
+	for i, rule := range rules {
+		if strings.EqualFold(pointer.StringDeref(rule.Name, ""), name) {
+			return i
 		}
-		if !strings.EqualFold(pointer.StringDeref(existingRule.SourcePortRange, ""), "*") &&
-			!strings.EqualFold(pointer.StringDeref(existingRule.SourceAddressPrefix, ""), "*") &&
-			!strings.EqualFold(pointer.StringDeref(existingRule.DestinationAddressPrefix, ""), "*") {
-			continue
+	}
+	return -1
+}
+
+// prefixesEqual returns true if both lists contain the same address prefixes or resource IDs, regardless of their
+// order and case. Azure returns an empty list rather than nil when a rule has no such list.
+func prefixesEqual(a, b *[]string) bool {
+	var x, y []string
+	if a != nil {
+		x = *a
+	}
+	if b != nil {
+		y = *b
+	}
+	if len(x) != len(y) {
+		return false
+	}
+	counts := make(map[string]int, len(x))
+	for _, prefix := range x {
+		counts[strings.ToLower(prefix)]++
+	}
+	for _, prefix := range y {
+		if counts[strings.ToLower(prefix)] == 0 {
+			return false
 		}
-		return true
+		counts[strings.ToLower(prefix)]--
 	}
-	return false
+	return true
 }
@@ -61,6 +61,38 @@ var (
 		Destination:      pointer.String("*"),
 		DestinationPorts: pointer.String("80"),
 	}
+	asgRule = infrav1.SecurityRule{
+		Name:                                 "asg_rule",
+		Description:                          "Test Rule",
+		Priority:                             502,
+		Protocol:                             infrav1.SecurityGroupProtocolTCP,
+		Direction:                            infrav1.SecurityRuleDirectionInbound,
+		SourceApplicationSecurityGroups:      []string{"frontend"},
+		SourcePorts:                          pointer.String("*"),
+		DestinationApplicationSecurityGroups: []string{"backend"},
+		DestinationPorts:                     pointer.String("8080"),
+	}
+)
+
+func newModifiedRule(rule infrav1.SecurityRule, sourceAddressPrefix string) network.SecurityRule {
+	modified := converters.SecurityRuleToSDK(rule)
+	modified.SourceAddressPrefix = pointer.String(sourceAddressPrefix)
+	return modified
+}
+
+func newASGRule(sourceASGIDs ...string) network.SecurityRule {
+	rule := converters.SecurityRuleToSDK(asgRule)
+	asgs := []network.ApplicationSecurityGroup{}
+	for _, id := range sourceASGIDs {
+		asgs = append(asgs, network.ApplicationSecurityGroup{ID: pointer.String(id)})
+	}
+	rule.SourceApplicationSecurityGroups = &asgs
+	return rule
+}
+
+var (
+	modifiedSSHRule    = newModifiedRule(sshRule, "10.0.0.0/8")
+	modifiedCustomRule = newModifiedRule(customRule, "10.0.0.0/8")
 )
 
 func TestParameters(t *testing.T) {
@@ -138,6 +170,64 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG already exists but a rule was modified out of band",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					sshRule,
+					otherRule,
+				},
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+			},
+			existing: network.SecurityGroup{
+				Name:     pointer.String("test-nsg"),
+				Location: pointer.String("test-location"),
+				Etag:     pointer.String("fake-etag"),
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						modifiedSSHRule,
+						converters.SecurityRuleToSDK(customRule),
+						converters.SecurityRuleToSDK(otherRule),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.SecurityGroup{}))
+				g.Expect(*result.(network.SecurityGroup).SecurityRules).To(Equal([]network.SecurityRule{
+					converters.SecurityRuleToSDK(sshRule),
+					converters.SecurityRuleToSDK(customRule),
+					converters.SecurityRuleToSDK(otherRule),
+				}))
+				g.Expect(pointer.StringDeref(modifiedSSHRule.SourceAddressPrefix, "")).To(Equal("10.0.0.0/8"))
+			},
+		},
+		{
+			name: "NSG already exists with rules that aren't in the spec",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					sshRule,
+				},
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+			},
+			existing: network.SecurityGroup{
+				Name: pointer.String("test-nsg"),
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						converters.SecurityRuleToSDK(sshRule),
+						modifiedCustomRule,
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
 		{
 			name: "NSG does not exist",
 			spec: &NSGSpec{
@@ -168,6 +258,64 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG does not exist with a rule between application security groups",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					asgRule,
+				},
+				ResourceGroup:  "test-group",
+				SubscriptionID: "123",
+				ClusterName:    "my-cluster",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.SecurityGroup{}))
+				rules := *result.(network.SecurityGroup).SecurityRules
+				g.Expect(rules).To(HaveLen(1))
+				g.Expect(rules[0].SourceAddressPrefix).To(BeNil())
+				g.Expect(rules[0].DestinationAddressPrefix).To(BeNil())
+				g.Expect(rules[0].SourceApplicationSecurityGroups).To(Equal(&[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/frontend")},
+				}))
+				g.Expect(rules[0].DestinationApplicationSecurityGroups).To(Equal(&[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/backend")},
+				}))
+			},
+		},
+		{
+			name: "NSG already exists but the application security groups of a rule were modified out of band",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					asgRule,
+				},
+				ResourceGroup:  "test-group",
+				SubscriptionID: "123",
+				ClusterName:    "my-cluster",
+			},
+			existing: network.SecurityGroup{
+				Name:     pointer.String("test-nsg"),
+				Location: pointer.String("test-location"),
+				Etag:     pointer.String("fake-etag"),
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						converters.SecurityRuleToSDK(asgRule),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.SecurityGroup{}))
+				rules := *result.(network.SecurityGroup).SecurityRules
+				g.Expect(rules).To(HaveLen(1))
+				g.Expect(rules[0].SourceApplicationSecurityGroups).To(Equal(&[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/frontend")},
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -213,6 +361,52 @@ func TestRuleExists(t *testing.T) {
 			rule:     ruleBModified,
 			expected: false,
 		},
+		{
+			name:     "rule exists but its source has been modified",
+			rules:    []network.SecurityRule{converters.SecurityRuleToSDK(sshRule), modifiedCustomRule},
+			rule:     converters.SecurityRuleToSDK(customRule),
+			expected: false,
+		},
+		{
+			name: "rule exists with the same address prefixes in a different order",
+			rules: []network.SecurityRule{converters.SecurityRuleToSDK(infrav1.SecurityRule{
+				Name:      "dual_stack",
+				Priority:  300,
+				Protocol:  infrav1.SecurityGroupProtocolTCP,
+				Direction: infrav1.SecurityRuleDirectionInbound,
+				Sources:   []string{"2001:db8::/64", "10.0.0.0/8"},
+			})},
+			rule: converters.SecurityRuleToSDK(infrav1.SecurityRule{
+				Name:      "dual_stack",
+				Priority:  300,
+				Protocol:  infrav1.SecurityGroupProtocolTCP,
+				Direction: infrav1.SecurityRuleDirectionInbound,
+				Sources:   []string{"10.0.0.0/8", "2001:db8::/64"},
+			}),
+			expected: true,
+		},
+		{
+			name: "rule exists with the same application security groups in a different order and case",
+			rules: []network.SecurityRule{newASGRule(
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/frontend",
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web",
+			)},
+			rule: newASGRule(
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/WEB",
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/frontend",
+			),
+			expected: true,
+		},
+		{
+			name: "rule exists but its application security groups have been modified",
+			rules: []network.SecurityRule{newASGRule(
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/frontend",
+			)},
+			rule: newASGRule(
+				"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/web",
+			),
+			expected: false,
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -18,6 +18,7 @@ package subnets
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/google/go-cmp/cmp"
@@ -41,6 +42,9 @@ type SubnetSpec struct {
 	Role              infrav1.SubnetRole
 	NatGatewayName    string
 	ServiceEndpoints  infrav1.ServiceEndpoints
+	Delegations       infrav1.SubnetDelegations
+	// DisablePrivateLinkServiceNetworkPolicies must be set on subnets from which private link services allocate NAT IP addresses.
+	DisablePrivateLinkServiceNetworkPolicies bool
 }
 
 // ResourceName returns the name of the subnet.
@@ -83,12 +87,44 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 			newServiceEndpoints = append(newServiceEndpoints, network.ServiceEndpointPropertiesFormat{Service: pointer.String(se.Service), Locations: &se.Locations})
 		}
 
-		// Right now only serviceEndpoints are allowed to be updated. More to come later
-		diff := cmp.Diff(newServiceEndpoints, existingServiceEndpoints)
-		if diff == "" {
+		var existingDelegations []infrav1.SubnetDelegation
+		if existingSubnet.Delegations != nil {
+			for _, d := range *existingSubnet.Delegations {
+				delegation := infrav1.SubnetDelegation{Name: pointer.StringDeref(d.Name, "")}
+				if d.ServiceDelegationPropertiesFormat != nil {
+					delegation.ServiceName = pointer.StringDeref(d.ServiceName, "")
+				}
+				existingDelegations = append(existingDelegations, delegation)
+			}
+		}
+		var newDelegations []infrav1.SubnetDelegation
+		for _, d := range s.Delegations {
+			newDelegations = append(newDelegations, infrav1.SubnetDelegation{Name: delegationName(d), ServiceName: d.ServiceName})
+		}
+
+		privateLinkServiceNetworkPoliciesUpToDate := !s.DisablePrivateLinkServiceNetworkPolicies ||
+			(existingSubnet.SubnetPropertiesFormat != nil &&
+				existingSubnet.PrivateLinkServiceNetworkPolicies == network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled)
+
+		// Right now only serviceEndpoints, delegations and private link service network policies are allowed to be updated. More to come later
+		if cmp.Diff(newServiceEndpoints, existingServiceEndpoints) == "" && cmp.Diff(newDelegations, existingDelegations) == "" &&
+			privateLinkServiceNetworkPoliciesUpToDate {
 			// up to date, nothing to do
 			return nil, nil
 		}
+
+		// Update the existing subnet in place so that properties not managed here are preserved.
+		var subnetProperties network.SubnetPropertiesFormat
+		if existingSubnet.SubnetPropertiesFormat != nil {
+			subnetProperties = *existingSubnet.SubnetPropertiesFormat
+		}
+		subnetProperties.ServiceEndpoints = s.serviceEndpoints()
+		subnetProperties.Delegations = s.delegations()
+		if s.DisablePrivateLinkServiceNetworkPolicies {
+			subnetProperties.PrivateLinkServiceNetworkPolicies = network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled
+		}
+		existingSubnet.SubnetPropertiesFormat = &subnetProperties
+		return existingSubnet, nil
 	}
 
 	if !s.IsVNetManaged {
@@ -124,14 +160,48 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 		}
 	}
 
+	subnetProperties.ServiceEndpoints = s.serviceEndpoints()
+	if len(s.Delegations) > 0 {
+		subnetProperties.Delegations = s.delegations()
+	}
+
+	if s.DisablePrivateLinkServiceNetworkPolicies {
+		subnetProperties.PrivateLinkServiceNetworkPolicies = network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled
+	}
+
+	return network.Subnet{
+		SubnetPropertiesFormat: &subnetProperties,
+	}, nil
+}
+
+// serviceEndpoints returns the service endpoints to enable on the subnet.
+func (s *SubnetSpec) serviceEndpoints() *[]network.ServiceEndpointPropertiesFormat {
 	serviceEndpoints := make([]network.ServiceEndpointPropertiesFormat, 0, len(s.ServiceEndpoints))
 	for _, se := range s.ServiceEndpoints {
 		se := se
 		serviceEndpoints = append(serviceEndpoints, network.ServiceEndpointPropertiesFormat{Service: pointer.String(se.Service), Locations: &se.Locations})
 	}
-	subnetProperties.ServiceEndpoints = &serviceEndpoints
+	return &serviceEndpoints
+}
 
-	return network.Subnet{
-		SubnetPropertiesFormat: &subnetProperties,
-	}, nil
+// delegations returns the service delegations of the subnet.
+func (s *SubnetSpec) delegations() *[]network.Delegation {
+	delegations := make([]network.Delegation, 0, len(s.Delegations))
+	for _, d := range s.Delegations {
+		delegations = append(delegations, network.Delegation{
+			Name: pointer.String(delegationName(d)),
+			ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{
+				ServiceName: pointer.String(d.ServiceName),
+			},
+		})
+	}
+	return &delegations
+}
+
+// delegationName returns the name of a delegation, defaulting to the service name with "/" replaced by ".".
+func delegationName(d infrav1.SubnetDelegation) string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return strings.ReplaceAll(d.ServiceName, "/", ".")
 }
@@ -79,6 +79,41 @@ var (
 		},
 	}
 
+	fakeSubnetWithDelegationsSpec = SubnetSpec{
+		Name:              "my-subnet-1",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		CIDRs:             []string{"10.0.0.0/16"},
+		IsVNetManaged:     true,
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		Role:              infrav1.SubnetNode,
+		ServiceEndpoints:  infrav1.ServiceEndpoints{{Service: "Microsoft.Storage", Locations: []string{"eastus"}}},
+		Delegations: infrav1.SubnetDelegations{
+			{ServiceName: "Microsoft.ContainerInstance/containerGroups"},
+			{Name: "web", ServiceName: "Microsoft.Web/serverFarms"},
+		},
+	}
+
+	fakeSubnetWithDelegations = network.Subnet{
+		ID:   pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet-1"),
+		Name: pointer.String("my-subnet-1"),
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			AddressPrefix:    pointer.String("10.0.0.0/16"),
+			ServiceEndpoints: &[]network.ServiceEndpointPropertiesFormat{{Service: pointer.String("Microsoft.Storage"), Locations: &[]string{"eastus"}}},
+			Delegations: &[]network.Delegation{
+				{
+					Name:                              pointer.String("Microsoft.ContainerInstance.containerGroups"),
+					ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{ServiceName: pointer.String("Microsoft.ContainerInstance/containerGroups")},
+				},
+				{
+					Name:                              pointer.String("web"),
+					ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{ServiceName: pointer.String("Microsoft.Web/serverFarms")},
+				},
+			},
+		},
+	}
+
 	fakeIpv6SubnetSpecNotManaged = SubnetSpec{
 		Name:              "my-ipv6-subnet",
 		ResourceGroup:     "my-rg",
@@ -132,6 +167,113 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for subnet with service endpoints and delegations",
+			spec:     &fakeSubnetWithDelegationsSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:    pointer.String("10.0.0.0/16"),
+					ServiceEndpoints: fakeSubnetWithDelegations.ServiceEndpoints,
+					Delegations:      fakeSubnetWithDelegations.Delegations,
+				}}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "subnet with service endpoints and delegations is up to date",
+			spec:     &fakeSubnetWithDelegationsSpec,
+			existing: fakeSubnetWithDelegations,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "subnet delegations are updated in place",
+			spec: &SubnetSpec{
+				Name:              "my-subnet-1",
+				CIDRs:             []string{"10.0.0.0/16"},
+				IsVNetManaged:     true,
+				VNetName:          "my-vnet",
+				VNetResourceGroup: "my-rg",
+				ServiceEndpoints:  fakeSubnetWithDelegationsSpec.ServiceEndpoints,
+				Delegations:       infrav1.SubnetDelegations{{ServiceName: "Microsoft.Web/serverFarms"}},
+			},
+			existing: fakeSubnetWithDelegations,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Subnet{}))
+				subnet := result.(network.Subnet)
+				g.Expect(subnet.ID).To(Equal(fakeSubnetWithDelegations.ID))
+				g.Expect(subnet.ServiceEndpoints).To(Equal(fakeSubnetWithDelegations.ServiceEndpoints))
+				g.Expect(*subnet.Delegations).To(Equal([]network.Delegation{{
+					Name:                              pointer.String("Microsoft.Web.serverFarms"),
+					ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{ServiceName: pointer.String("Microsoft.Web/serverFarms")},
+				}}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "subnet service endpoints are updated in place",
+			spec: &SubnetSpec{
+				Name:              "my-subnet-1",
+				CIDRs:             []string{"10.0.0.0/16"},
+				IsVNetManaged:     true,
+				VNetName:          "my-vnet",
+				VNetResourceGroup: "my-rg",
+				Delegations:       fakeSubnetWithDelegationsSpec.Delegations,
+			},
+			existing: fakeSubnetWithDelegations,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Subnet{}))
+				subnet := result.(network.Subnet)
+				g.Expect(*subnet.ServiceEndpoints).To(BeEmpty())
+				g.Expect(subnet.Delegations).To(Equal(fakeSubnetWithDelegations.Delegations))
+			},
+			expectedError: "",
+		},
+		{
+			name: "get parameters for private link service subnet",
+			spec: &SubnetSpec{
+				Name:                                     "my-subnet-1",
+				CIDRs:                                    []string{"10.0.0.0/16"},
+				IsVNetManaged:                            true,
+				VNetName:                                 "my-vnet",
+				VNetResourceGroup:                        "my-rg",
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:                     pointer.String("10.0.0.0/16"),
+					ServiceEndpoints:                  &[]network.ServiceEndpointPropertiesFormat{},
+					PrivateLinkServiceNetworkPolicies: network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled,
+				}}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "subnet private link service network policies are disabled in place",
+			spec: &SubnetSpec{
+				Name:                                     "my-subnet-1",
+				CIDRs:                                    []string{"10.0.0.0/16"},
+				IsVNetManaged:                            true,
+				VNetName:                                 "my-vnet",
+				VNetResourceGroup:                        "my-rg",
+				ServiceEndpoints:                         fakeSubnetWithDelegationsSpec.ServiceEndpoints,
+				Delegations:                              fakeSubnetWithDelegationsSpec.Delegations,
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: fakeSubnetWithDelegations,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Subnet{}))
+				subnet := result.(network.Subnet)
+				g.Expect(subnet.ID).To(Equal(fakeSubnetWithDelegations.ID))
+				g.Expect(subnet.PrivateLinkServiceNetworkPolicies).To(Equal(network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled))
+				g.Expect(fakeSubnetWithDelegations.PrivateLinkServiceNetworkPolicies).To(BeEmpty())
+			},
+			expectedError: "",
+		},
 		{
 			name:     "error vnet is not managed but subnet is missing",
 			spec:     &fakeSubnetSpecNotManaged,
@@ -72,7 +72,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	for _, tagsSpec := range s.Scope.TagsSpecs() {
 		existingTags, err := s.client.GetAtScope(ctx, tagsSpec.Scope)
-		if err != nil {
+		if azure.ResourceNotFound(err) {
+			log.V(4).Info("Skipping tags reconcile for not found resource", "scope", tagsSpec.Scope)
+			continue
+		} else if err != nil {
 			return errors.Wrap(err, "failed to get existing tags")
 		}
 		tags := make(map[string]*string)
@@ -85,7 +88,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			continue
 		}
 
-		lastAppliedTags, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
+		lastAppliedTags, err := s.lastAppliedTags(tagsSpec)
 		if err != nil {
 			return err
 		}
@@ -118,13 +121,39 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 		// We also need to update the annotation even if nothing changed to
 		// ensure it's set immediately following resource creation.
-		if err := s.Scope.UpdateAnnotationJSON(tagsSpec.Annotation, newAnnotation); err != nil {
+		if err := s.updateLastAppliedTags(tagsSpec, newAnnotation); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// lastAppliedTags returns the tags last applied to the resource of a tags spec.
+func (s *Service) lastAppliedTags(tagsSpec azure.TagsSpec) (map[string]interface{}, error) {
+	annotation, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
+	if err != nil || tagsSpec.AnnotationKey == "" {
+		return annotation, err
+	}
+	lastAppliedTags, _ := annotation[tagsSpec.AnnotationKey].(map[string]interface{})
+	return lastAppliedTags, nil
+}
+
+// updateLastAppliedTags sets the tags last applied to the resource of a tags spec.
+func (s *Service) updateLastAppliedTags(tagsSpec azure.TagsSpec, tags map[string]interface{}) error {
+	if tagsSpec.AnnotationKey == "" {
+		return s.Scope.UpdateAnnotationJSON(tagsSpec.Annotation, tags)
+	}
+	annotation, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
+	if err != nil {
+		return err
+	}
+	if annotation == nil {
+		annotation = make(map[string]interface{})
+	}
+	annotation[tagsSpec.AnnotationKey] = tags
+	return s.Scope.UpdateAnnotationJSON(tagsSpec.Annotation, annotation)
+}
+
 func (s *Service) isResourceManaged(tags map[string]*string) bool {
 	return converters.MapToTags(tags).HasOwned(s.Scope.ClusterName())
 }
@@ -181,6 +181,70 @@ func TestReconcileTags(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:          "delete removed tags of resources tracked in a shared annotation",
+			expectedError: "",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				gomock.InOrder(
+					s.TagsSpecs().Return([]azure.TagsSpec{
+						{
+							Scope: "/sub/123/fake/scope",
+							Tags: map[string]string{
+								"foo": "bar",
+							},
+							Annotation:    "my-annotation",
+							AnnotationKey: "/sub/123/fake/scope",
+						},
+					}),
+					m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{Properties: &resources.Tags{
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+							"foo":   pointer.String("bar"),
+							"thing": pointer.String("stuff"),
+						},
+					}}, nil),
+					s.AnnotationJSON("my-annotation").Return(map[string]interface{}{
+						"/sub/123/fake/scope":  map[string]interface{}{"foo": "bar", "thing": "stuff"},
+						"/sub/123/other/scope": map[string]interface{}{"tag1": "value1"},
+					}, nil),
+					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/fake/scope", resources.TagsPatchResource{
+						Operation: "Delete",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"thing": pointer.String("stuff"),
+							},
+						},
+					}),
+					s.AnnotationJSON("my-annotation").Return(map[string]interface{}{
+						"/sub/123/fake/scope":  map[string]interface{}{"foo": "bar", "thing": "stuff"},
+						"/sub/123/other/scope": map[string]interface{}{"tag1": "value1"},
+					}, nil),
+					s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{
+						"/sub/123/fake/scope":  map[string]interface{}{"foo": "bar"},
+						"/sub/123/other/scope": map[string]interface{}{"tag1": "value1"},
+					}),
+				)
+			},
+		},
+		{
+			name:          "skip resources which don't exist",
+			expectedError: "",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.TagsSpecs().Return([]azure.TagsSpec{
+					{
+						Scope: "/sub/123/fake/scope",
+						Tags: map[string]string{
+							"foo": "bar",
+						},
+						Annotation:    "my-annotation",
+						AnnotationKey: "/sub/123/fake/scope",
+					},
+				})
+				m.GetAtScope(gomockinternal.AContext(), "/sub/123/fake/scope").Return(resources.TagsResource{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found"))
+			},
+		},
 		{
 			name:          "error getting existing tags",
 			expectedError: "failed to get existing tags: #: Internal Server Error: StatusCode=500",
@@ -19,6 +19,8 @@ package virtualmachineimages
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
 	"github.com/pkg/errors"
@@ -29,34 +31,41 @@ import (
 // Client is an interface for listing VM images.
 type Client interface {
 	List(ctx context.Context, location, publisher, offer, sku string) (armcompute.VirtualMachineImagesClientListResponse, error)
+	ListGalleryImageVersions(ctx context.Context, subscriptionID, resourceGroup, gallery, image string) ([]*armcompute.GalleryImageVersion, error)
 }
 
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
-	images armcompute.VirtualMachineImagesClient
+	images     armcompute.VirtualMachineImagesClient
+	credential azcore.TokenCredential
+	opts       *arm.ClientOptions
 }
 
 var _ Client = (*AzureClient)(nil)
 
 // NewClient creates an AzureClient from an Authorizer.
 func NewClient(auth azure.Authorizer) (*AzureClient, error) {
-	c, err := newVirtualMachineImagesClient(auth.SubscriptionID(), auth.CloudEnvironment())
+	credential, err := azidentity.NewDefaultAzureCredential(nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create VM images client")
+		return nil, errors.Wrap(err, "failed to create default Azure credential")
 	}
-	return &AzureClient{c}, nil
-}
-
-// newVirtualMachineImagesClient creates a new VM images client from subscription ID and base URI.
-func newVirtualMachineImagesClient(subscriptionID, azureEnvironment string) (armcompute.VirtualMachineImagesClient, error) {
-	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
 	if err != nil {
-		return armcompute.VirtualMachineImagesClient{}, errors.Wrap(err, "failed to create default Azure credential")
+		return nil, errors.Wrap(err, "failed to create ARM client options")
 	}
-	opts, err := azure.ARMClientOptions(azureEnvironment)
+	c, err := newVirtualMachineImagesClient(auth.SubscriptionID(), credential, opts)
 	if err != nil {
-		return armcompute.VirtualMachineImagesClient{}, errors.Wrap(err, "failed to create ARM client options")
+		return nil, errors.Wrap(err, "failed to create VM images client")
 	}
+	return &AzureClient{
+		images:     c,
+		credential: credential,
+		opts:       opts,
+	}, nil
+}
+
+// newVirtualMachineImagesClient creates a new VM images client from subscription ID, credential and client options.
+func newVirtualMachineImagesClient(subscriptionID string, credential azcore.TokenCredential, opts *arm.ClientOptions) (armcompute.VirtualMachineImagesClient, error) {
 	computeClientFactory, err := armcompute.NewClientFactory(subscriptionID, credential, opts)
 	if err != nil {
 		return armcompute.VirtualMachineImagesClient{}, errors.Wrap(err, "failed to create ARM compute client factory")
@@ -72,3 +81,26 @@ func (ac *AzureClient) List(ctx context.Context, location, publisher, offer, sku
 	opts := &armcompute.VirtualMachineImagesClientListOptions{}
 	return ac.images.List(ctx, location, publisher, offer, sku, opts)
 }
+
+// ListGalleryImageVersions returns the versions of an image in an Azure Compute Gallery.
+// The gallery may live in a different subscription than the cluster, so a client is created for its subscription.
+func (ac *AzureClient) ListGalleryImageVersions(ctx context.Context, subscriptionID, resourceGroup, gallery, image string) ([]*armcompute.GalleryImageVersion, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.AzureClient.ListGalleryImageVersions")
+	defer done()
+
+	versionsClient, err := armcompute.NewGalleryImageVersionsClient(subscriptionID, ac.credential, ac.opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gallery image versions client")
+	}
+
+	var versions []*armcompute.GalleryImageVersion
+	pager := versionsClient.NewListByGalleryImagePager(resourceGroup, gallery, image, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate gallery image versions")
+		}
+		versions = append(versions, page.Value...)
+	}
+	return versions, nil
+}
@@ -22,8 +22,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -206,3 +208,107 @@ func k8sVersionInSKUName(major, minor, patch uint64) bool {
 		(major == 1 && minor == 22 && patch <= 9) ||
 		(major == 1 && minor == 23 && patch <= 6)
 }
+
+// ResolveComputeGalleryImage returns a copy of the image with its version resolved when it is a private Azure Compute
+// Gallery image with a "latest" or semver range version. The previously resolved image is returned as long as it refers
+// to the same gallery image and its version still matches the requested version, so that the resolved version doesn't
+// change between reconciliations. Any other image is returned as is.
+func (s *Service) ResolveComputeGalleryImage(ctx context.Context, image, resolved *infrav1.Image) (*infrav1.Image, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.Service.ResolveComputeGalleryImage")
+	defer done()
+
+	if !NeedsVersionResolution(image) {
+		return image, nil
+	}
+
+	gallery := image.ComputeGallery
+	versionRange, err := parseVersionRange(gallery.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved != nil && resolved.ComputeGallery != nil && sameGalleryImage(gallery, resolved.ComputeGallery) {
+		if v, err := semver.Parse(resolved.ComputeGallery.Version); err == nil && versionRange(v) {
+			return withComputeGalleryVersion(image, resolved.ComputeGallery.Version), nil
+		}
+	}
+
+	subscriptionID, resourceGroup := pointer.StringDeref(gallery.SubscriptionID, ""), pointer.StringDeref(gallery.ResourceGroup, "")
+	versions, err := s.Client.ListGalleryImageVersions(ctx, subscriptionID, resourceGroup, gallery.Gallery, gallery.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list versions of compute gallery image \"%s\" in gallery \"%s\"", gallery.Name, gallery.Gallery)
+	}
+
+	version, err := latestMatchingVersion(versions, versionRange)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve version \"%s\" of compute gallery image \"%s\" in gallery \"%s\"", gallery.Version, gallery.Name, gallery.Gallery)
+	}
+
+	log.V(4).Info("Resolved compute gallery image version", "gallery", gallery.Gallery, "image", gallery.Name, "requestedVersion", gallery.Version, "version", version)
+
+	return withComputeGalleryVersion(image, version), nil
+}
+
+// NeedsVersionResolution returns true if the image is a private compute gallery image whose version is not an exact version.
+// Community gallery images are left for Azure to resolve.
+func NeedsVersionResolution(image *infrav1.Image) bool {
+	if image == nil || image.ComputeGallery == nil || image.ComputeGallery.SubscriptionID == nil || image.ComputeGallery.ResourceGroup == nil {
+		return false
+	}
+	_, err := semver.Parse(image.ComputeGallery.Version)
+	return err != nil
+}
+
+// parseVersionRange parses a "latest" or semver range version into a semver Range.
+func parseVersionRange(version string) (semver.Range, error) {
+	if version == azure.LatestVersion {
+		return func(semver.Version) bool { return true }, nil
+	}
+	versionRange, err := semver.ParseRange(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse compute gallery image version \"%s\", expected \"latest\" or a valid semver range", version)
+	}
+	return versionRange, nil
+}
+
+// latestMatchingVersion returns the highest successfully provisioned image version in the range, skipping versions excluded from latest.
+func latestMatchingVersion(versions []*armcompute.GalleryImageVersion, versionRange semver.Range) (string, error) {
+	var latest *semver.Version
+	for _, version := range versions {
+		if version == nil || version.Name == nil || version.Properties == nil {
+			continue
+		}
+		if version.Properties.ProvisioningState != nil && *version.Properties.ProvisioningState != armcompute.GalleryProvisioningStateSucceeded {
+			continue
+		}
+		if version.Properties.PublishingProfile != nil && pointer.BoolDeref(version.Properties.PublishingProfile.ExcludeFromLatest, false) {
+			continue
+		}
+		v, err := semver.Parse(*version.Name)
+		if err != nil || !versionRange(v) {
+			continue
+		}
+		if latest == nil || v.GT(*latest) {
+			latest = &v
+		}
+	}
+	if latest == nil {
+		return "", errors.New("no matching image version found")
+	}
+	return latest.String(), nil
+}
+
+// sameGalleryImage returns true if both compute gallery images refer to the same gallery image, regardless of version.
+func sameGalleryImage(a, b *infrav1.AzureComputeGalleryImage) bool {
+	return a.Gallery == b.Gallery &&
+		a.Name == b.Name &&
+		pointer.StringDeref(a.SubscriptionID, "") == pointer.StringDeref(b.SubscriptionID, "") &&
+		pointer.StringDeref(a.ResourceGroup, "") == pointer.StringDeref(b.ResourceGroup, "")
+}
+
+// withComputeGalleryVersion returns a copy of the compute gallery image with the given version.
+func withComputeGalleryVersion(image *infrav1.Image, version string) *infrav1.Image {
+	resolved := image.DeepCopy()
+	resolved.ComputeGallery.Version = version
+	return resolved
+}
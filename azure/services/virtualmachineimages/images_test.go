@@ -25,9 +25,11 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
 func TestGetDefaultUbuntuImage(t *testing.T) {
@@ -595,3 +597,120 @@ func TestGetDefaultImageSKUID(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveComputeGalleryImage(t *testing.T) {
+	galleryImage := func(version string) *infrav1.Image {
+		return &infrav1.Image{
+			ComputeGallery: &infrav1.AzureComputeGalleryImage{
+				Gallery:        "my-gallery",
+				Name:           "my-image",
+				Version:        version,
+				SubscriptionID: pointer.String("my-sub"),
+				ResourceGroup:  pointer.String("my-rg"),
+			},
+		}
+	}
+	imageVersion := func(name string, state armcompute.GalleryProvisioningState, excludeFromLatest bool) *armcompute.GalleryImageVersion {
+		return &armcompute.GalleryImageVersion{
+			Name: pointer.String(name),
+			Properties: &armcompute.GalleryImageVersionProperties{
+				ProvisioningState: &state,
+				PublishingProfile: &armcompute.GalleryImageVersionPublishingProfile{
+					ExcludeFromLatest: pointer.Bool(excludeFromLatest),
+				},
+			},
+		}
+	}
+	versions := []*armcompute.GalleryImageVersion{
+		imageVersion("1.2.0", armcompute.GalleryProvisioningStateSucceeded, false),
+		imageVersion("1.10.0", armcompute.GalleryProvisioningStateSucceeded, false),
+		imageVersion("2.0.0", armcompute.GalleryProvisioningStateSucceeded, false),
+		imageVersion("2.1.0", armcompute.GalleryProvisioningStateCreating, false),
+		imageVersion("3.0.0", armcompute.GalleryProvisioningStateSucceeded, true),
+	}
+
+	tests := []struct {
+		name        string
+		image       *infrav1.Image
+		resolved    *infrav1.Image
+		expect      func(m *mock_virtualmachineimages.MockClientMockRecorder)
+		want        *infrav1.Image
+		expectedErr string
+	}{
+		{
+			name:  "image with an exact version is returned as is",
+			image: galleryImage("1.2.0"),
+			want:  galleryImage("1.2.0"),
+		},
+		{
+			name: "community gallery image is returned as is",
+			image: &infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{Gallery: "community", Name: "my-image", Version: "latest"},
+			},
+			want: &infrav1.Image{
+				ComputeGallery: &infrav1.AzureComputeGalleryImage{Gallery: "community", Name: "my-image", Version: "latest"},
+			},
+		},
+		{
+			name:  "latest resolves to the highest succeeded version not excluded from latest",
+			image: galleryImage("latest"),
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.ListGalleryImageVersions(gomockinternal.AContext(), "my-sub", "my-rg", "my-gallery", "my-image").Return(versions, nil)
+			},
+			want: galleryImage("2.0.0"),
+		},
+		{
+			name:  "semver range resolves to the highest matching version",
+			image: galleryImage(">=1.0.0 <2.0.0"),
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.ListGalleryImageVersions(gomockinternal.AContext(), "my-sub", "my-rg", "my-gallery", "my-image").Return(versions, nil)
+			},
+			want: galleryImage("1.10.0"),
+		},
+		{
+			name:     "previously resolved version is kept while it matches",
+			image:    galleryImage("latest"),
+			resolved: galleryImage("1.2.0"),
+			want:     galleryImage("1.2.0"),
+		},
+		{
+			name:     "previously resolved version is replaced when it no longer matches",
+			image:    galleryImage("2.x"),
+			resolved: galleryImage("1.2.0"),
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.ListGalleryImageVersions(gomockinternal.AContext(), "my-sub", "my-rg", "my-gallery", "my-image").Return(versions, nil)
+			},
+			want: galleryImage("2.0.0"),
+		},
+		{
+			name:  "no matching version",
+			image: galleryImage("4.x"),
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.ListGalleryImageVersions(gomockinternal.AContext(), "my-sub", "my-rg", "my-gallery", "my-image").Return(versions, nil)
+			},
+			expectedErr: "failed to resolve version \"4.x\" of compute gallery image \"my-image\" in gallery \"my-gallery\": no matching image version found",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockClient := mock_virtualmachineimages.NewMockClient(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(mockClient.EXPECT())
+			}
+			svc := Service{Client: mockClient}
+
+			got, err := svc.ResolveComputeGalleryImage(context.TODO(), tc.image, tc.resolved)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
@@ -65,3 +65,18 @@ func (mr *MockClientMockRecorder) List(ctx, location, publisher, offer, sku inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClient)(nil).List), ctx, location, publisher, offer, sku)
 }
+
+// ListGalleryImageVersions mocks base method.
+func (m *MockClient) ListGalleryImageVersions(ctx context.Context, subscriptionID, resourceGroup, gallery, image string) ([]*armcompute.GalleryImageVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGalleryImageVersions", ctx, subscriptionID, resourceGroup, gallery, image)
+	ret0, _ := ret[0].([]*armcompute.GalleryImageVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGalleryImageVersions indicates an expected call of ListGalleryImageVersions.
+func (mr *MockClientMockRecorder) ListGalleryImageVersions(ctx, subscriptionID, resourceGroup, gallery, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGalleryImageVersions", reflect.TypeOf((*MockClient)(nil).ListGalleryImageVersions), ctx, subscriptionID, resourceGroup, gallery, image)
+}
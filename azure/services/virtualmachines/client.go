@@ -47,6 +47,8 @@ type (
 		GetByID(context.Context, string) (compute.VirtualMachine, error)
 		CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
 		DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
+		DeallocateAsync(ctx context.Context, spec azure.ResourceSpecGetter, hibernate bool) (future azureautorest.FutureAPI, err error)
+		StartAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
 		IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error)
 		Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error)
 		GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachine, error)
@@ -97,7 +99,7 @@ func (ac *AzureClient) GetByID(ctx context.Context, resourceID string) (compute.
 
 	log.V(4).Info("parsed VM resourceID", "parsed", parsed)
 
-	return ac.virtualmachines.Get(ctx, parsed.ResourceGroupName, parsed.Name, "")
+	return ac.virtualmachines.Get(ctx, parsed.ResourceGroupName, parsed.Name, compute.InstanceViewTypesInstanceView)
 }
 
 // CreateOrUpdateAsync creates or updates a virtual machine asynchronously.
@@ -158,6 +160,33 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	return nil, err
 }
 
+// DeallocateAsync deallocates a virtual machine, hibernating it first if hibernate is true. It sends a POST request to
+// Azure and if accepted without error, the func will return a Future which can be used to track the ongoing progress
+// of the operation.
+func (ac *AzureClient) DeallocateAsync(ctx context.Context, spec azure.ResourceSpecGetter, hibernate bool) (azureautorest.FutureAPI, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.DeallocateAsync")
+	defer done()
+
+	deallocateFuture, err := ac.virtualmachines.Deallocate(ctx, spec.ResourceGroupName(), spec.ResourceName(), pointer.Bool(hibernate))
+	if err != nil {
+		return nil, err
+	}
+	return &deallocateFuture, nil
+}
+
+// StartAsync starts a virtual machine. It sends a POST request to Azure and if accepted without error, the func will
+// return a Future which can be used to track the ongoing progress of the operation.
+func (ac *AzureClient) StartAsync(ctx context.Context, spec azure.ResourceSpecGetter) (azureautorest.FutureAPI, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.StartAsync")
+	defer done()
+
+	startFuture, err := ac.virtualmachines.Start(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	if err != nil {
+		return nil, err
+	}
+	return &startFuture, nil
+}
+
 // IsDone returns true if the long-running operation has completed.
 func (ac *AzureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.IsDone")
@@ -71,6 +71,21 @@ func (mr *MockClientMockRecorder) CreateOrUpdateAsync(ctx, spec, parameters inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateAsync", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateAsync), ctx, spec, parameters)
 }
 
+// DeallocateAsync mocks base method.
+func (m *MockClient) DeallocateAsync(ctx context.Context, spec azure0.ResourceSpecGetter, hibernate bool) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeallocateAsync", ctx, spec, hibernate)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeallocateAsync indicates an expected call of DeallocateAsync.
+func (mr *MockClientMockRecorder) DeallocateAsync(ctx, spec, hibernate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeallocateAsync", reflect.TypeOf((*MockClient)(nil).DeallocateAsync), ctx, spec, hibernate)
+}
+
 // DeleteAsync mocks base method.
 func (m *MockClient) DeleteAsync(ctx context.Context, spec azure0.ResourceSpecGetter) (azure.FutureAPI, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +176,21 @@ func (mr *MockClientMockRecorder) Result(ctx, future, futureType interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Result", reflect.TypeOf((*MockClient)(nil).Result), ctx, future, futureType)
 }
 
+// StartAsync mocks base method.
+func (m *MockClient) StartAsync(ctx context.Context, spec azure0.ResourceSpecGetter) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAsync", ctx, spec)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAsync indicates an expected call of StartAsync.
+func (mr *MockClientMockRecorder) StartAsync(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAsync", reflect.TypeOf((*MockClient)(nil).StartAsync), ctx, spec)
+}
+
 // MockgenericVMFuture is a mock of genericVMFuture interface.
 type MockgenericVMFuture struct {
 	ctrl     *gomock.Controller
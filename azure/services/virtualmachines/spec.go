@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
+	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
 )
 
 // VMSpec defines the specification for a Virtual Machine.
@@ -50,13 +51,30 @@ type VMSpec struct {
 	UserAssignedIdentities []infrav1.UserAssignedIdentity
 	SpotVMOptions          *infrav1.SpotVMOptions
 	SecurityProfile        *infrav1.SecurityProfile
-	AdditionalTags         infrav1.Tags
-	AdditionalCapabilities *infrav1.AdditionalCapabilities
-	DiagnosticsProfile     *infrav1.Diagnostics
-	SKU                    resourceskus.SKU
-	Image                  *infrav1.Image
-	BootstrapData          string
-	ProviderID             string
+	DedicatedHost          *infrav1.DedicatedHost
+	// OSDiskName is the name of the OS disk. The default OS disk name of the VM is used when it is empty.
+	OSDiskName string
+	// DataDiskNames are the names of the data disks, in the same order as DataDisks. The default data disk names of the VM
+	// are used when it is empty.
+	DataDiskNames []string
+	// CapacityReservationGroupID is the resource ID of the capacity reservation group the VM is allocated from, if any.
+	CapacityReservationGroupID string
+	// ProximityPlacementGroupID is the resource ID of the proximity placement group the VM joins, if any.
+	ProximityPlacementGroupID string
+	AdditionalTags            infrav1.Tags
+	AdditionalCapabilities    *infrav1.AdditionalCapabilities
+	DiagnosticsProfile        *infrav1.Diagnostics
+	GPUDriver                 *infrav1.GPUDriver
+	SKU                       resourceskus.SKU
+	// PremiumV2DiskZones are the zones of the location where Premium SSD v2 disks are available.
+	// It is only set when a data disk uses the PremiumV2_LRS storage account type.
+	PremiumV2DiskZones []string
+	Image              *infrav1.Image
+	BootstrapData      string
+	ProviderID         string
+	// PowerState is the power state requested for the VM with the PowerStateAnnotation.
+	// An empty power state means the power state of the VM is not managed.
+	PowerState infrav1.VMPowerState
 }
 
 // ResourceName returns the name of the virtual machine.
@@ -104,6 +122,16 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 		return nil, errors.Wrap(err, "failed to generate OS Profile")
 	}
 
+	if s.GPUDriver != nil {
+		gpuCapability, err := s.SKU.HasCapabilityWithCapacity(resourceskus.GPUs, resourceskus.MinimumGPUs)
+		if err != nil {
+			return nil, azure.WithTerminalError(errors.Wrap(err, "failed to validate the GPU capability"))
+		}
+		if !gpuCapability {
+			return nil, azure.WithTerminalError(errors.Errorf("vm size %s does not have GPUs. select a different vm size or remove the GPU driver", s.Size))
+		}
+	}
+
 	priority, evictionPolicy, billingProfile, err := converters.GetSpotVMOptions(s.SpotVMOptions, s.OSDisk.DiffDiskSettings)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get Spot VM options")
@@ -126,8 +154,12 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 			Additional:  s.AdditionalTags,
 		})),
 		VirtualMachineProperties: &compute.VirtualMachineProperties{
-			AdditionalCapabilities: s.generateAdditionalCapabilities(),
-			AvailabilitySet:        s.getAvailabilitySet(),
+			AdditionalCapabilities:  s.generateAdditionalCapabilities(),
+			AvailabilitySet:         s.getAvailabilitySet(),
+			Host:                    s.getHost(),
+			HostGroup:               s.getHostGroup(),
+			ProximityPlacementGroup: s.getProximityPlacementGroup(),
+			CapacityReservation:     s.getCapacityReservation(),
 			HardwareProfile: &compute.HardwareProfile{
 				VMSize: compute.VirtualMachineSizeTypes(s.Size),
 			},
@@ -147,11 +179,27 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 	}, nil
 }
 
+// osDiskName returns the name of the OS disk of the VM.
+func (s *VMSpec) osDiskName() string {
+	if s.OSDiskName != "" {
+		return s.OSDiskName
+	}
+	return azure.GenerateOSDiskName(s.Name)
+}
+
+// dataDiskName returns the name of the data disk at index i of the VM.
+func (s *VMSpec) dataDiskName(i int, disk infrav1.DataDisk) string {
+	if i < len(s.DataDiskNames) && s.DataDiskNames[i] != "" {
+		return s.DataDiskNames[i]
+	}
+	return azure.GenerateDataDiskName(s.Name, disk.NameSuffix)
+}
+
 // generateStorageProfile generates a pointer to a compute.StorageProfile which can utilized for VM creation.
 func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 	storageProfile := &compute.StorageProfile{
 		OsDisk: &compute.OSDisk{
-			Name:         pointer.String(azure.GenerateOSDiskName(s.Name)),
+			Name:         pointer.String(s.osDiskName()),
 			OsType:       compute.OperatingSystemTypes(s.OSDisk.OSType),
 			CreateOption: compute.DiskCreateOptionTypesFromImage,
 			DiskSizeGB:   s.OSDisk.DiskSizeGB,
@@ -186,6 +234,18 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 		storageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
 			Option: compute.DiffDiskOptions(s.OSDisk.DiffDiskSettings.Option),
 		}
+
+		if s.OSDisk.DiffDiskSettings.Placement != nil {
+			placement := string(*s.OSDisk.DiffDiskSettings.Placement)
+			supported, err := s.SKU.SupportsEphemeralOSDiskPlacement(placement, pointer.Int32Deref(s.OSDisk.DiskSizeGB, 0))
+			if err != nil {
+				return nil, azure.WithTerminalError(errors.Wrap(err, "failed to validate the ephemeral os disk placement"))
+			}
+			if !supported {
+				return nil, azure.WithTerminalError(fmt.Errorf("vm size %s does not have a %s large enough for the ephemeral os disk. select a different vm size or placement", s.Size, placement))
+			}
+			storageProfile.OsDisk.DiffDiskSettings.Placement = compute.DiffDiskPlacement(placement)
+		}
 	}
 
 	if s.OSDisk.ManagedDisk != nil {
@@ -196,6 +256,9 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 		if s.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(s.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
 		}
+		if s.OSDisk.ManagedDisk.SecurityProfile != nil {
+			storageProfile.OsDisk.ManagedDisk.SecurityProfile = converters.VMDiskSecurityProfileToSDK(s.OSDisk.ManagedDisk.SecurityProfile)
+		}
 	}
 
 	dataDisks := make([]compute.DataDisk, len(s.DataDisks))
@@ -204,7 +267,7 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 			CreateOption: compute.DiskCreateOptionTypesEmpty,
 			DiskSizeGB:   pointer.Int32(disk.DiskSizeGB),
 			Lun:          disk.Lun,
-			Name:         pointer.String(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
+			Name:         pointer.String(s.dataDiskName(i, disk)),
 			Caching:      compute.CachingTypes(disk.CachingType),
 		}
 
@@ -221,6 +284,16 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 			if disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.Zone) {
 				return nil, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
 			}
+
+			// premium v2 disks can only be attached to zonal VMs in zones where they are available
+			if disk.ManagedDisk.StorageAccountType == infrav1.StorageAccountTypePremiumV2LRS {
+				if s.Zone == "" {
+					return nil, azure.WithTerminalError(fmt.Errorf("premium v2 disks can only be attached to vms in an availability zone. select a failure domain or a different storage account type for data disk %s", disk.NameSuffix))
+				}
+				if !slice.Contains(s.PremiumV2DiskZones, s.Zone) {
+					return nil, azure.WithTerminalError(fmt.Errorf("premium v2 disks are not supported in zone %s of location %s. select a different failure domain or storage account type for data disk %s", s.Zone, s.Location, disk.NameSuffix))
+				}
+			}
 		}
 	}
 	storageProfile.DataDisks = &dataDisks
@@ -282,13 +355,34 @@ func (s *VMSpec) generateSecurityProfile() (*compute.SecurityProfile, error) {
 		return nil, nil
 	}
 
-	if !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
+	if pointer.BoolDeref(s.SecurityProfile.EncryptionAtHost, false) && !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
 		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", s.Size))
 	}
 
-	return &compute.SecurityProfile{
+	if s.SecurityProfile.SecurityType == infrav1.SecurityTypesTrustedLaunch && !s.SKU.SupportsTrustedLaunch() {
+		return nil, azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", s.Size))
+	}
+
+	if s.SecurityProfile.SecurityType == infrav1.SecurityTypesConfidentialVM {
+		if _, ok := s.SKU.GetCapability(resourceskus.ConfidentialComputingType); !ok {
+			return nil, azure.WithTerminalError(errors.Errorf("confidential computing is not supported for VM type %s", s.Size))
+		}
+	}
+
+	securityProfile := &compute.SecurityProfile{
 		EncryptionAtHost: s.SecurityProfile.EncryptionAtHost,
-	}, nil
+	}
+	if s.SecurityProfile.SecurityType != "" {
+		securityProfile.SecurityType = compute.SecurityTypes(s.SecurityProfile.SecurityType)
+		if s.SecurityProfile.UefiSettings != nil {
+			securityProfile.UefiSettings = &compute.UefiSettings{
+				SecureBootEnabled: s.SecurityProfile.UefiSettings.SecureBootEnabled,
+				VTpmEnabled:       s.SecurityProfile.UefiSettings.VTpmEnabled,
+			}
+		}
+	}
+
+	return securityProfile, nil
 }
 
 func (s *VMSpec) generateNICRefs() *[]compute.NetworkInterfaceReference {
@@ -328,6 +422,10 @@ func (s *VMSpec) generateAdditionalCapabilities() *compute.AdditionalCapabilitie
 		if s.AdditionalCapabilities.UltraSSDEnabled != nil {
 			capabilities.UltraSSDEnabled = s.AdditionalCapabilities.UltraSSDEnabled
 		}
+		// Set HibernationEnabled if a specific value is set on the spec for it.
+		if s.AdditionalCapabilities.HibernationEnabled != nil {
+			capabilities.HibernationEnabled = s.AdditionalCapabilities.HibernationEnabled
+		}
 	}
 
 	return capabilities
@@ -341,6 +439,36 @@ func (s *VMSpec) getAvailabilitySet() *compute.SubResource {
 	return as
 }
 
+func (s *VMSpec) getHost() *compute.SubResource {
+	if s.DedicatedHost == nil || s.DedicatedHost.HostID == "" {
+		return nil
+	}
+	return &compute.SubResource{ID: pointer.String(s.DedicatedHost.HostID)}
+}
+
+func (s *VMSpec) getProximityPlacementGroup() *compute.SubResource {
+	if s.ProximityPlacementGroupID == "" {
+		return nil
+	}
+	return &compute.SubResource{ID: pointer.String(s.ProximityPlacementGroupID)}
+}
+
+func (s *VMSpec) getHostGroup() *compute.SubResource {
+	if s.DedicatedHost == nil || s.DedicatedHost.HostGroupID == "" {
+		return nil
+	}
+	return &compute.SubResource{ID: pointer.String(s.DedicatedHost.HostGroupID)}
+}
+
+func (s *VMSpec) getCapacityReservation() *compute.CapacityReservationProfile {
+	if s.CapacityReservationGroupID == "" {
+		return nil
+	}
+	return &compute.CapacityReservationProfile{
+		CapacityReservationGroup: &compute.SubResource{ID: pointer.String(s.CapacityReservationGroupID)},
+	}
+}
+
 func (s *VMSpec) getZones() *[]string {
 	var zones *[]string
 	if s.Zone != "" {
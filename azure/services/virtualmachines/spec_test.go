@@ -72,6 +72,72 @@ var (
 		},
 	}
 
+	validSKUWithGPUs = resourceskus.SKU{
+		Name: pointer.String("Standard_NC6s_v3"),
+		Kind: pointer.String(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  pointer.String(resourceskus.VCPUs),
+				Value: pointer.String("6"),
+			},
+			{
+				Name:  pointer.String(resourceskus.MemoryGB),
+				Value: pointer.String("112"),
+			},
+			{
+				Name:  pointer.String(resourceskus.GPUs),
+				Value: pointer.String("1"),
+			},
+		},
+	}
+
+	validSKUWithTrustedLaunch = resourceskus.SKU{
+		Name: pointer.String("Standard_D2v3"),
+		Kind: pointer.String(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  pointer.String(resourceskus.VCPUs),
+				Value: pointer.String("2"),
+			},
+			{
+				Name:  pointer.String(resourceskus.MemoryGB),
+				Value: pointer.String("4"),
+			},
+			{
+				Name:  pointer.String(resourceskus.HyperVGenerations),
+				Value: pointer.String("V1,V2"),
+			},
+		},
+	}
+
+	validSKUWithConfidentialCompute = resourceskus.SKU{
+		Name: pointer.String("Standard_DC2as_v5"),
+		Kind: pointer.String(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  pointer.String(resourceskus.VCPUs),
+				Value: pointer.String("2"),
+			},
+			{
+				Name:  pointer.String(resourceskus.MemoryGB),
+				Value: pointer.String("8"),
+			},
+			{
+				Name:  pointer.String(resourceskus.ConfidentialComputingType),
+				Value: pointer.String("SNP"),
+			},
+		},
+	}
+
 	validSKUWithEphemeralOS = resourceskus.SKU{
 		Name: pointer.String("Standard_D2v3"),
 		Kind: pointer.String(string(resourceskus.VirtualMachines)),
@@ -91,6 +157,14 @@ var (
 				Name:  pointer.String(resourceskus.EphemeralOSDisk),
 				Value: pointer.String("True"),
 			},
+			{
+				Name:  pointer.String(resourceskus.CachedDiskBytes),
+				Value: pointer.String("214748364800"),
+			},
+			{
+				Name:  pointer.String(resourceskus.MaxResourceVolumeMB),
+				Value: pointer.String("65536"),
+			},
 		},
 	}
 
@@ -248,6 +322,94 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a vm on a dedicated host",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				DedicatedHost: &infrav1.DedicatedHost{
+					HostID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group/hosts/my-host",
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).Host).To(Equal(&compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group/hosts/my-host")}))
+				g.Expect(result.(compute.VirtualMachine).HostGroup).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm in a dedicated host group",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				DedicatedHost: &infrav1.DedicatedHost{
+					HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).Host).To(BeNil())
+				g.Expect(result.(compute.VirtualMachine).HostGroup).To(Equal(&compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group")}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm consuming a capacity reservation",
+			spec: &VMSpec{
+				Name:                       "my-vm",
+				Role:                       infrav1.Node,
+				NICIDs:                     []string{"my-nic"},
+				SSHKeyData:                 "fakesshpublickey",
+				Size:                       "Standard_D2v3",
+				Zone:                       "1",
+				Image:                      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+				SKU:                        validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).CapacityReservation).To(Equal(&compute.CapacityReservationProfile{
+					CapacityReservationGroup: &compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg")},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm in a proximity placement group",
+			spec: &VMSpec{
+				Name:                      "my-vm",
+				Role:                      infrav1.Node,
+				NICIDs:                    []string{"my-nic"},
+				SSHKeyData:                "fakesshpublickey",
+				Size:                      "Standard_D2v3",
+				Zone:                      "1",
+				Image:                     &infrav1.Image{ID: pointer.String("fake-image-id")},
+				ProximityPlacementGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg",
+				SKU:                       validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).ProximityPlacementGroup).To(Equal(&compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg")}))
+			},
+			expectedError: "",
+		},
 		{
 			name: "can create a spot vm",
 			spec: &VMSpec{
@@ -368,6 +530,121 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a trusted launch vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				},
+				SKU: validSKUWithTrustedLaunch,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				securityProfile := result.(compute.VirtualMachine).VirtualMachineProperties.SecurityProfile
+				g.Expect(securityProfile.SecurityType).To(Equal(compute.SecurityTypesTrustedLaunch))
+				g.Expect(securityProfile.EncryptionAtHost).To(BeNil())
+				g.Expect(*securityProfile.UefiSettings.SecureBootEnabled).To(BeTrue())
+				g.Expect(*securityProfile.UefiSettings.VTpmEnabled).To(BeTrue())
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a trusted launch vm for unsupported VM type fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+					},
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: trusted launch is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
+		{
+			name: "can create a confidential vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_DC2as_v5",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				OSDisk: infrav1.OSDisk{
+					OSType: "Linux",
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &infrav1.VMDiskSecurityProfile{
+							SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+						},
+					},
+				},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				},
+				SKU: validSKUWithConfidentialCompute,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.SecurityProfile.SecurityType).To(Equal(compute.SecurityTypesConfidentialVM))
+				g.Expect(vm.StorageProfile.OsDisk.ManagedDisk.SecurityProfile.SecurityEncryptionType).To(Equal(compute.SecurityEncryptionTypesDiskWithVMGuestState))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a confidential vm for unsupported VM type fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						VTpmEnabled: pointer.Bool(true),
+					},
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: confidential computing is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
 		{
 			name: "can create a vm and assign it to an availability set",
 			spec: &VMSpec{
@@ -417,6 +694,66 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a vm with EphemeralOSDisk on the cache disk",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: pointer.Int32(128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+					},
+					DiffDiskSettings: &infrav1.DiffDiskSettings{
+						Option:    string(compute.DiffDiskOptionsLocal),
+						Placement: (*infrav1.DiffDiskPlacement)(pointer.String(string(infrav1.DiffDiskPlacementCacheDisk))),
+					},
+				},
+				Image: &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SKU:   validSKUWithEphemeralOS,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).StorageProfile.OsDisk.DiffDiskSettings).To(Equal(&compute.DiffDiskSettings{
+					Option:    compute.DiffDiskOptionsLocal,
+					Placement: compute.DiffDiskPlacementCacheDisk,
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "cannot create a vm with EphemeralOSDisk on a resource disk that is too small",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: pointer.Int32(128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+					},
+					DiffDiskSettings: &infrav1.DiffDiskSettings{
+						Option:    string(compute.DiffDiskOptionsLocal),
+						Placement: (*infrav1.DiffDiskPlacement)(pointer.String(string(infrav1.DiffDiskPlacementResourceDisk))),
+					},
+				},
+				Image: &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SKU:   validSKUWithEphemeralOS,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: vm size Standard_D2v3 does not have a ResourceDisk large enough for the ephemeral os disk. select a different vm size or placement. Object will not be requeued",
+		},
 		{
 			name: "creating a vm with encryption at host enabled for unsupported VM type fails",
 			spec: &VMSpec{
@@ -437,6 +774,42 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type Standard_D2v3. Object will not be requeued",
 		},
+		{
+			name: "can create a vm with a GPU driver",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_NC6s_v3",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				GPUDriver:  &infrav1.GPUDriver{},
+				SKU:        validSKUWithGPUs,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a vm with a GPU driver for a VM type without GPUs fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				GPUDriver:  &infrav1.GPUDriver{},
+				SKU:        validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: vm size Standard_D2v3 does not have GPUs. select a different vm size or remove the GPU driver. Object will not be requeued",
+		},
 		{
 			name: "cannot create vm with EphemeralOSDisk if does not support ephemeral os",
 			spec: &VMSpec{
@@ -657,6 +1030,99 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a vm with a premium v2 data disk",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Location:   "test-location",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix:  "myDiskWithPremiumV2",
+						DiskSizeGB:  128,
+						Lun:         pointer.Int32(1),
+						CachingType: "None",
+						ManagedDisk: &infrav1.ManagedDiskParameters{
+							StorageAccountType: "PremiumV2_LRS",
+						},
+					},
+				},
+				SKU:                validSKU,
+				PremiumV2DiskZones: []string{"1", "2"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect((*result.(compute.VirtualMachine).StorageProfile.DataDisks)[0].ManagedDisk.StorageAccountType).To(Equal(compute.StorageAccountTypes("PremiumV2_LRS")))
+				g.Expect((*result.(compute.VirtualMachine).StorageProfile.DataDisks)[0].Caching).To(Equal(compute.CachingTypesNone))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a vm with a premium v2 data disk without a zone fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Location:   "test-location",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix:  "myDiskWithPremiumV2",
+						DiskSizeGB:  128,
+						Lun:         pointer.Int32(1),
+						CachingType: "None",
+						ManagedDisk: &infrav1.ManagedDiskParameters{
+							StorageAccountType: "PremiumV2_LRS",
+						},
+					},
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: premium v2 disks can only be attached to vms in an availability zone. select a failure domain or a different storage account type for data disk myDiskWithPremiumV2. Object will not be requeued",
+		},
+		{
+			name: "creating a vm with a premium v2 data disk in an unsupported zone fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Location:   "test-location",
+				Zone:       "3",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix:  "myDiskWithPremiumV2",
+						DiskSizeGB:  128,
+						Lun:         pointer.Int32(1),
+						CachingType: "None",
+						ManagedDisk: &infrav1.ManagedDiskParameters{
+							StorageAccountType: "PremiumV2_LRS",
+						},
+					},
+				},
+				SKU:                validSKU,
+				PremiumV2DiskZones: []string{"1", "2"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: premium v2 disks are not supported in zone 3 of location test-location. select a different failure domain or storage account type for data disk myDiskWithPremiumV2. Object will not be requeued",
+		},
 		{
 			name: "creating vm with ultra disk enabled in unsupported location fails",
 			spec: &VMSpec{
@@ -686,6 +1152,30 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "reconcile error that cannot be recovered occurred: vm size Standard_D2v3 does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
 		},
+		{
+			name: "creates a vm with AdditionalCapabilities.HibernationEnabled",
+			spec: &VMSpec{
+				Name:       "my-hibernation-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Location:   "test-location",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				AdditionalCapabilities: &infrav1.AdditionalCapabilities{
+					HibernationEnabled: pointer.Bool(true),
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).AdditionalCapabilities.HibernationEnabled).To(Equal(pointer.Bool(true)))
+				g.Expect(result.(compute.VirtualMachine).AdditionalCapabilities.UltraSSDEnabled).To(BeNil())
+			},
+			expectedError: "",
+		},
 		{
 			name: "creates a vm with AdditionalCapabilities.UltraSSDEnabled false, if an ultra disk is specified as data disk but AdditionalCapabilities.UltraSSDEnabled is false",
 			spec: &VMSpec{
@@ -19,9 +19,11 @@ package virtualmachines
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
@@ -40,6 +42,9 @@ import (
 
 const serviceName = "virtualmachine"
 
+// powerStateRequeueInterval is how long to wait before checking again whether a VM reached its requested power state.
+const powerStateRequeueInterval = 15 * time.Second
+
 // VMScope defines the scope interface for a virtual machines service.
 type VMScope interface {
 	azure.Authorizer
@@ -56,6 +61,7 @@ type VMScope interface {
 type Service struct {
 	Scope VMScope
 	async.Reconciler
+	client           Client
 	interfacesGetter async.Getter
 	publicIPsGetter  async.Getter
 	identitiesGetter identities.Client
@@ -66,6 +72,7 @@ func New(scope VMScope) *Service {
 	Client := NewClient(scope)
 	return &Service{
 		Scope:            scope,
+		client:           Client,
 		interfacesGetter: networkinterfaces.NewClient(scope),
 		publicIPsGetter:  publicips.NewClient(scope),
 		identitiesGetter: identities.NewClient(scope),
@@ -83,7 +90,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	vmSpec := s.Scope.VMSpec()
@@ -126,16 +133,102 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to check user assigned identities")
 		}
+
+		err = s.reconcilePowerState(ctx, spec, infraVM.ID)
 	}
 	return err
 }
 
+// reconcilePowerState starts, stops, or hibernates the VM until it reaches the power state requested in the spec,
+// and sets the VM state accordingly. Starting and deallocating the VM are tracked as long running operations, and it
+// returns a transient error while the VM is transitioning to the requested power state.
+func (s *Service) reconcilePowerState(ctx context.Context, spec *VMSpec, vmID string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.reconcilePowerState")
+	defer done()
+
+	if spec.PowerState == "" {
+		return nil
+	}
+
+	// Wait for a start or deallocation in progress before looking at the power state, so that its long running
+	// operation is removed once it completes, even if the VM already reached the requested power state.
+	if err := s.RunAction(ctx, spec, serviceName, nil); err != nil {
+		return err
+	}
+
+	sdkVM, err := s.client.GetByID(ctx, vmID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get VM instance view")
+	}
+	vm := converters.SDKToVM(sdkVM)
+	powerState := vm.PowerState
+
+	start := func(ctx context.Context) (azureautorest.FutureAPI, error) {
+		return s.client.StartAsync(ctx, spec)
+	}
+	deallocate := func(hibernate bool) func(context.Context) (azureautorest.FutureAPI, error) {
+		return func(ctx context.Context) (azureautorest.FutureAPI, error) {
+			return s.client.DeallocateAsync(ctx, spec, hibernate)
+		}
+	}
+
+	switch spec.PowerState {
+	case infrav1.VMPowerStateRunning:
+		if powerState == "running" {
+			return nil
+		}
+		s.Scope.SetVMState(infrav1.Starting)
+		if powerState == "deallocated" || powerState == "stopped" {
+			log.V(2).Info("starting VM", "vm", spec.Name, "powerState", powerState)
+			if err := s.RunAction(ctx, spec, serviceName, start); err != nil {
+				return errors.Wrapf(err, "failed to start VM %s", spec.Name)
+			}
+		}
+	case infrav1.VMPowerStateStopped:
+		if powerState == "deallocated" {
+			s.Scope.SetVMState(infrav1.Stopped)
+			return nil
+		}
+		s.Scope.SetVMState(infrav1.Stopping)
+		if powerState == "running" || powerState == "stopped" {
+			log.V(2).Info("deallocating VM", "vm", spec.Name, "powerState", powerState)
+			if err := s.RunAction(ctx, spec, serviceName, deallocate(false)); err != nil {
+				return errors.Wrapf(err, "failed to deallocate VM %s", spec.Name)
+			}
+		}
+	case infrav1.VMPowerStateHibernated:
+		if powerState == "deallocated" && vm.Hibernated {
+			s.Scope.SetVMState(infrav1.Hibernated)
+			return nil
+		}
+		s.Scope.SetVMState(infrav1.Stopping)
+		switch powerState {
+		case "running":
+			log.V(2).Info("hibernating VM", "vm", spec.Name)
+			if err := s.RunAction(ctx, spec, serviceName, deallocate(true)); err != nil {
+				return errors.Wrapf(err, "failed to hibernate VM %s", spec.Name)
+			}
+		case "stopped", "deallocated":
+			// Only a running VM can be hibernated, so a VM which was stopped or deallocated without hibernation is
+			// started first.
+			log.V(2).Info("starting VM before hibernating it", "vm", spec.Name, "powerState", powerState)
+			if err := s.RunAction(ctx, spec, serviceName, start); err != nil {
+				return errors.Wrapf(err, "failed to start VM %s", spec.Name)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported power state %q requested for VM %s", spec.PowerState, spec.Name)
+	}
+
+	return azure.WithTransientError(errors.Errorf("waiting for VM %s to reach power state %q, current power state is %q", spec.Name, spec.PowerState, powerState), powerStateRequeueInterval)
+}
+
 // Delete deletes the virtual machine with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	vmSpec := s.Scope.VMSpec()
@@ -20,16 +20,19 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities/mock_identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
@@ -396,3 +399,230 @@ func TestCheckUserAssignedIdentities(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilePowerState(t *testing.T) {
+	vmWithPowerState := func(powerState string, extraStatuses ...string) compute.VirtualMachine {
+		statuses := []compute.InstanceViewStatus{
+			{Code: pointer.String("ProvisioningState/succeeded")},
+			{Code: pointer.String("PowerState/" + powerState)},
+		}
+		for _, status := range extraStatuses {
+			statuses = append(statuses, compute.InstanceViewStatus{Code: pointer.String(status)})
+		}
+		return compute.VirtualMachine{
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				InstanceView: &compute.VirtualMachineInstanceView{Statuses: &statuses},
+			},
+		}
+	}
+	notDoneError := azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{Type: infrav1.PostFuture}), 15*time.Second)
+	// runAction runs the action passed to RunAction, and returns the error of the action or notDoneError like
+	// RunAction does when Azure returns a future for the action.
+	runAction := func(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, action func(context.Context) (azureautorest.FutureAPI, error)) error {
+		if _, err := action(ctx); err != nil {
+			return err
+		}
+		return notDoneError
+	}
+
+	testcases := []struct {
+		name            string
+		powerState      infrav1.VMPowerState
+		expect          func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec)
+		expectedError   string
+		expectTransient bool
+	}{
+		{
+			name:       "noop if no power state is requested",
+			powerState: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+			},
+		},
+		{
+			name:       "waits for a start or deallocation in progress",
+			powerState: infrav1.VMPowerStateRunning,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(notDoneError)
+			},
+			expectedError:   "operation type POST on Azure resource / is not done",
+			expectTransient: true,
+		},
+		{
+			name:       "noop if the vm is already running",
+			powerState: infrav1.VMPowerStateRunning,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("running"), nil)
+			},
+		},
+		{
+			name:       "starts a deallocated vm",
+			powerState: infrav1.VMPowerStateRunning,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("deallocated"), nil)
+				s.SetVMState(infrav1.Starting)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.StartAsync(gomockinternal.AContext(), spec).Return(&azureautorest.Future{}, nil)
+			},
+			expectedError:   "failed to start VM test-vm: operation type POST on Azure resource / is not done",
+			expectTransient: true,
+		},
+		{
+			name:       "returns an error if the vm fails to start",
+			powerState: infrav1.VMPowerStateRunning,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("stopped"), nil)
+				s.SetVMState(infrav1.Starting)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.StartAsync(gomockinternal.AContext(), spec).Return(nil, internalError)
+			},
+			expectedError: "failed to start VM test-vm: #: Internal Server Error: StatusCode=500",
+		},
+		{
+			name:       "hibernates a running vm",
+			powerState: infrav1.VMPowerStateHibernated,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("running"), nil)
+				s.SetVMState(infrav1.Stopping)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.DeallocateAsync(gomockinternal.AContext(), spec, true).Return(&azureautorest.Future{}, nil)
+			},
+			expectedError:   "failed to hibernate VM test-vm: operation type POST on Azure resource / is not done",
+			expectTransient: true,
+		},
+		{
+			name:       "starts a stopped vm before hibernating it",
+			powerState: infrav1.VMPowerStateHibernated,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("stopped"), nil)
+				s.SetVMState(infrav1.Stopping)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.StartAsync(gomockinternal.AContext(), spec).Return(&azureautorest.Future{}, nil)
+			},
+			expectedError:   "failed to start VM test-vm: operation type POST on Azure resource / is not done",
+			expectTransient: true,
+		},
+		{
+			name:       "starts a vm deallocated without hibernation before hibernating it",
+			powerState: infrav1.VMPowerStateHibernated,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("deallocated"), nil)
+				s.SetVMState(infrav1.Stopping)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.StartAsync(gomockinternal.AContext(), spec).Return(&azureautorest.Future{}, nil)
+			},
+			expectedError:   "failed to start VM test-vm: operation type POST on Azure resource / is not done",
+			expectTransient: true,
+		},
+		{
+			name:       "waits for a vm which is deallocating",
+			powerState: infrav1.VMPowerStateHibernated,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("deallocating"), nil)
+				s.SetVMState(infrav1.Stopping)
+			},
+			expectedError:   `waiting for VM test-vm to reach power state "hibernated", current power state is "deallocating"`,
+			expectTransient: true,
+		},
+		{
+			name:       "sets the vm state if the vm is already hibernated",
+			powerState: infrav1.VMPowerStateHibernated,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("deallocated", "HibernationState/Hibernated"), nil)
+				s.SetVMState(infrav1.Hibernated)
+			},
+		},
+		{
+			name:       "deallocates a running vm",
+			powerState: infrav1.VMPowerStateStopped,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("running"), nil)
+				s.SetVMState(infrav1.Stopping)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.DeallocateAsync(gomockinternal.AContext(), spec, false).Return(&azureautorest.Future{}, nil)
+			},
+			expectedError:   "failed to deallocate VM test-vm: operation type POST on Azure resource / is not done",
+			expectTransient: true,
+		},
+		{
+			name:       "returns an error if the vm fails to deallocate",
+			powerState: infrav1.VMPowerStateStopped,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("stopped"), nil)
+				s.SetVMState(infrav1.Stopping)
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Not(gomock.Nil())).DoAndReturn(runAction)
+				c.DeallocateAsync(gomockinternal.AContext(), spec, false).Return(nil, internalError)
+			},
+			expectedError: "failed to deallocate VM test-vm: #: Internal Server Error: StatusCode=500",
+		},
+		{
+			name:       "waits for a vm which is starting",
+			powerState: infrav1.VMPowerStateRunning,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("starting"), nil)
+				s.SetVMState(infrav1.Starting)
+			},
+			expectedError:   `waiting for VM test-vm to reach power state "running", current power state is "starting"`,
+			expectTransient: true,
+		},
+		{
+			name:       "sets the vm state if the vm is already stopped",
+			powerState: infrav1.VMPowerStateStopped,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(vmWithPowerState("deallocated"), nil)
+				s.SetVMState(infrav1.Stopped)
+			},
+		},
+		{
+			name:       "returns an error if the vm instance view cannot be fetched",
+			powerState: infrav1.VMPowerStateHibernated,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, spec *VMSpec) {
+				r.RunAction(gomockinternal.AContext(), spec, serviceName, gomock.Nil()).Return(nil)
+				c.GetByID(gomockinternal.AContext(), "vm-id").Return(compute.VirtualMachine{}, internalError)
+			},
+			expectedError: "failed to get VM instance view: #: Internal Server Error: StatusCode=500",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
+
+			spec := fakeVMSpec
+			spec.PowerState = tc.powerState
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT(), &spec)
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				client:     clientMock,
+			}
+
+			err := s.reconcilePowerState(context.TODO(), &spec, "vm-id")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr) && reconcileErr.IsTransient()).To(Equal(tc.expectTransient))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
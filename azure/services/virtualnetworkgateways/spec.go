@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkgateways
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// VirtualNetworkGatewaySpec defines the specification for a VPN or ExpressRoute virtual network gateway.
+type VirtualNetworkGatewaySpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	SubnetID       string
+	PublicIPID     string
+	GatewayType    infrav1.VirtualNetworkGatewayType
+	SKU            string
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the virtual network gateway.
+func (s *VirtualNetworkGatewaySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *VirtualNetworkGatewaySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for virtual network gateways.
+func (s *VirtualNetworkGatewaySpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the virtual network gateway.
+func (s *VirtualNetworkGatewaySpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	if existing != nil {
+		existingGateway, ok := existing.(network.VirtualNetworkGateway)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.VirtualNetworkGateway", existing)
+		}
+		// A gateway in a failed state is updated again to give it a chance to recover.
+		if existingGateway.VirtualNetworkGatewayPropertiesFormat == nil ||
+			existingGateway.ProvisioningState != network.ProvisioningStateFailed {
+			// virtual network gateway already exists.
+			return nil, nil
+		}
+	}
+
+	gateway := network.VirtualNetworkGateway{
+		Name:     pointer.String(s.Name),
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Role:        pointer.String("Gateway"),
+			Additional:  s.AdditionalTags,
+		})),
+		VirtualNetworkGatewayPropertiesFormat: &network.VirtualNetworkGatewayPropertiesFormat{
+			GatewayType: network.VirtualNetworkGatewayType(s.GatewayType),
+			Sku: &network.VirtualNetworkGatewaySku{
+				Name: network.VirtualNetworkGatewaySkuName(s.SKU),
+				Tier: network.VirtualNetworkGatewaySkuTier(s.SKU),
+			},
+			IPConfigurations: &[]network.VirtualNetworkGatewayIPConfiguration{
+				{
+					Name: pointer.String(fmt.Sprintf("%s-ipconfig", s.Name)),
+					VirtualNetworkGatewayIPConfigurationPropertiesFormat: &network.VirtualNetworkGatewayIPConfigurationPropertiesFormat{
+						PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+						Subnet: &network.SubResource{
+							ID: pointer.String(s.SubnetID),
+						},
+						PublicIPAddress: &network.SubResource{
+							ID: pointer.String(s.PublicIPID),
+						},
+					},
+				},
+			},
+		},
+	}
+	if s.GatewayType == infrav1.VpnVirtualNetworkGatewayType {
+		gateway.VpnType = network.VpnTypeRouteBased
+	}
+
+	return gateway, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkgateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestVirtualNetworkGatewaySpecParameters(t *testing.T) {
+	expressRouteSpec := fakeGatewaySpec
+	expressRouteSpec.GatewayType = infrav1.ExpressRouteVirtualNetworkGatewayType
+	expressRouteSpec.SKU = "ErGw1AZ"
+
+	testcases := []struct {
+		name          string
+		spec          *VirtualNetworkGatewaySpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new VPN gateway",
+			spec:     &fakeGatewaySpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetworkGateway{}))
+				gateway := result.(network.VirtualNetworkGateway)
+				g.Expect(*gateway.Name).To(Equal("my-gateway"))
+				g.Expect(gateway.GatewayType).To(Equal(network.VirtualNetworkGatewayTypeVpn))
+				g.Expect(gateway.VpnType).To(Equal(network.VpnTypeRouteBased))
+				g.Expect(gateway.Sku.Name).To(Equal(network.VirtualNetworkGatewaySkuNameVpnGw1AZ))
+				g.Expect(gateway.Sku.Tier).To(Equal(network.VirtualNetworkGatewaySkuTierVpnGw1AZ))
+				g.Expect(*(*gateway.IPConfigurations)[0].Subnet.ID).To(Equal(fakeGatewaySpec.SubnetID))
+				g.Expect(*(*gateway.IPConfigurations)[0].PublicIPAddress.ID).To(Equal(fakeGatewaySpec.PublicIPID))
+				g.Expect(gateway.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", HaveValue(Equal("owned"))))
+			},
+		},
+		{
+			name:     "new ExpressRoute gateway",
+			spec:     &expressRouteSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetworkGateway{}))
+				gateway := result.(network.VirtualNetworkGateway)
+				g.Expect(gateway.GatewayType).To(Equal(network.VirtualNetworkGatewayTypeExpressRoute))
+				g.Expect(gateway.VpnType).To(BeEmpty())
+				g.Expect(gateway.Sku.Name).To(Equal(network.VirtualNetworkGatewaySkuNameErGw1AZ))
+			},
+		},
+		{
+			name:     "existing gateway",
+			spec:     &fakeGatewaySpec,
+			existing: fakeGateway(network.ProvisioningStateSucceeded),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing gateway being updated",
+			spec:     &fakeGatewaySpec,
+			existing: fakeGateway(network.ProvisioningStateUpdating),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "existing gateway in failed state is updated",
+			spec:     &fakeGatewaySpec,
+			existing: fakeGateway(network.ProvisioningStateFailed),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetworkGateway{}))
+				g.Expect(*result.(network.VirtualNetworkGateway).Name).To(Equal("my-gateway"))
+			},
+		},
+		{
+			name:          "existing is not a gateway",
+			spec:          &fakeGatewaySpec,
+			existing:      "wrong type",
+			expectedError: "string is not a network.VirtualNetworkGateway",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkgateways
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	serviceName = "virtualnetworkgateways"
+
+	// provisioningRequeueInterval is how long to wait before checking again on a gateway that is still being provisioned.
+	// Virtual network gateways commonly take more than 30 minutes to provision.
+	provisioningRequeueInterval = 1 * time.Minute
+)
+
+// VirtualNetworkGatewayScope defines the scope interface for a virtual network gateway service.
+type VirtualNetworkGatewayScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	VirtualNetworkGatewaySpec() azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope VirtualNetworkGatewayScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope VirtualNetworkGatewayScope) *Service {
+	client := newVirtualNetworkGatewaysClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates a VPN or ExpressRoute virtual network gateway.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualnetworkgateways.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	gatewaySpec := s.Scope.VirtualNetworkGatewaySpec()
+	if gatewaySpec == nil {
+		return nil
+	}
+
+	result, resultingErr := s.CreateOrUpdateResource(ctx, gatewaySpec, serviceName)
+	if resultingErr == nil {
+		resultingErr = checkProvisioningState(gatewaySpec.ResourceName(), result)
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.VirtualNetworkGatewayReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// Delete deletes the virtual network gateway.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualnetworkgateways.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
+	defer cancel()
+
+	gatewaySpec := s.Scope.VirtualNetworkGatewaySpec()
+	if gatewaySpec == nil {
+		return nil
+	}
+
+	err := s.DeleteResource(ctx, gatewaySpec, serviceName)
+	s.Scope.UpdateDeleteStatus(infrav1.VirtualNetworkGatewayReadyCondition, serviceName, err)
+	return err
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO virtual network gateways.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// checkProvisioningState returns an error if the virtual network gateway isn't done provisioning or failed to provision.
+func checkProvisioningState(name string, result interface{}) error {
+	gateway, ok := result.(network.VirtualNetworkGateway)
+	if !ok {
+		return errors.Errorf("%T is not a network.VirtualNetworkGateway", result)
+	}
+	if gateway.VirtualNetworkGatewayPropertiesFormat == nil {
+		return nil
+	}
+	switch gateway.ProvisioningState {
+	case network.ProvisioningStateSucceeded, "":
+		return nil
+	case network.ProvisioningStateFailed:
+		return errors.Errorf("virtual network gateway %s failed to provision", name)
+	default:
+		return azure.WithTransientError(errors.Errorf("virtual network gateway %s is in provisioning state %s", name, gateway.ProvisioningState), provisioningRequeueInterval)
+	}
+}
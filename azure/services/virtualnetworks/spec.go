@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
@@ -34,6 +35,8 @@ type VNetSpec struct {
 	ExtendedLocation *infrav1.ExtendedLocationSpec
 	ClusterName      string
 	AdditionalTags   infrav1.Tags
+	// Adopt makes a pre-existing vnet which isn't tagged for any cluster owned by the cluster.
+	Adopt bool
 }
 
 // ResourceName returns the name of the vnet.
@@ -54,17 +57,26 @@ func (s *VNetSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the vnet.
 func (s *VNetSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
 	if existing != nil {
+		existingVnet, ok := existing.(network.VirtualNetwork)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.VirtualNetwork", existing)
+		}
+
+		existingTags := infrav1.Tags{}
+		existingTags.Merge(converters.MapToTags(existingVnet.Tags))
+		if s.Adopt && !existingTags.HasAnyCluster() {
+			// adopt the vnet by tagging it as owned by the cluster. The existing vnet is updated in place so that its
+			// subnets and other properties are preserved.
+			existingTags.Merge(s.tags())
+			existingVnet.Tags = converters.TagsToMap(existingTags)
+			return existingVnet, nil
+		}
+
 		// vnet already exists, nothing to update.
 		return nil, nil
 	}
 	return network.VirtualNetwork{
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        pointer.String(s.Name),
-			Role:        pointer.String(infrav1.CommonRole),
-			Additional:  s.AdditionalTags,
-		})),
+		Tags:             converters.TagsToMap(s.tags()),
 		Location:         pointer.String(s.Location),
 		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
 		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
@@ -74,3 +86,14 @@ func (s *VNetSpec) Parameters(ctx context.Context, existing interface{}) (interf
 		},
 	}, nil
 }
+
+// tags returns the tags of a vnet owned by the cluster.
+func (s *VNetSpec) tags() infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        pointer.String(s.Name),
+		Role:        pointer.String(infrav1.CommonRole),
+		Additional:  s.AdditionalTags,
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var (
+	fakeUntaggedVNetSpec = VNetSpec{
+		ResourceGroup: "test-group",
+		Name:          "test-vnet",
+		CIDRs:         []string{"10.0.0.0/8"},
+		Location:      "test-location",
+		ClusterName:   "test-cluster",
+	}
+
+	fakeAdoptingVNetSpec = VNetSpec{
+		ResourceGroup: "test-group",
+		Name:          "test-vnet",
+		CIDRs:         []string{"10.0.0.0/8"},
+		Location:      "test-location",
+		ClusterName:   "test-cluster",
+		Adopt:         true,
+	}
+
+	fakeExistingVNet = network.VirtualNetwork{
+		ID:       pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/virtualNetworks/test-vnet"),
+		Name:     pointer.String("test-vnet"),
+		Location: pointer.String("test-location"),
+		Tags:     map[string]*string{"foo": pointer.String("bar")},
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/16"}},
+			Subnets:      &[]network.Subnet{{Name: pointer.String("test-subnet")}},
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *VNetSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new vnet",
+			spec:     &fakeUntaggedVNetSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetwork{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":                 pointer.String("common"),
+						"Name": pointer.String("test-vnet"),
+					},
+					Location: pointer.String("test-location"),
+					VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+						AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+					},
+				}))
+			},
+		},
+		{
+			name:     "existing vnet is not updated",
+			spec:     &fakeUntaggedVNetSpec,
+			existing: fakeExistingVNet,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "untagged existing vnet is adopted in place",
+			spec:     &fakeAdoptingVNetSpec,
+			existing: fakeExistingVNet,
+			expect: func(g *WithT, result interface{}) {
+				adopted := fakeExistingVNet
+				adopted.Tags = map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": pointer.String("owned"),
+					"sigs.k8s.io_cluster-api-provider-azure_role":                 pointer.String("common"),
+					"Name": pointer.String("test-vnet"),
+					"foo":  pointer.String("bar"),
+				}
+				g.Expect(result).To(Equal(adopted))
+			},
+		},
+		{
+			name: "existing vnet shared with another cluster is not adopted",
+			spec: &fakeAdoptingVNetSpec,
+			existing: network.VirtualNetwork{
+				Name: pointer.String("test-vnet"),
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": pointer.String("shared"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a vnet",
+			spec:          &fakeUntaggedVNetSpec,
+			existing:      "not a vnet",
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "string is not a network.VirtualNetwork",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
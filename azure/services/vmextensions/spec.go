@@ -62,7 +62,7 @@ func (s *VMExtensionSpec) Parameters(ctx context.Context, existing interface{})
 	return compute.VirtualMachineExtension{
 		VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
 			Publisher:          pointer.String(s.Publisher),
-			Type:               pointer.String(s.Name),
+			Type:               pointer.String(s.ExtensionType()),
 			TypeHandlerVersion: pointer.String(s.Version),
 			Settings:           s.Settings,
 			ProtectedSettings:  s.ProtectedSettings,
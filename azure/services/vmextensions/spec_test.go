@@ -69,6 +69,36 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get parameters for vmextension with a type",
+			spec: &VMExtensionSpec{
+				ExtensionSpec: azure.ExtensionSpec{
+					Name:              "my-custom-script",
+					Type:              "CustomScript",
+					VMName:            "my-vm",
+					Publisher:         "Microsoft.Azure.Extensions",
+					Version:           "2.1",
+					Settings:          map[string]string{"my-setting": "my-value"},
+					ProtectedSettings: map[string]string{"my-protected-setting": "my-protected-value"},
+				},
+				ResourceGroup: "my-rg",
+				Location:      "my-location",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineExtension{
+					VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+						Publisher:          pointer.String("Microsoft.Azure.Extensions"),
+						Type:               pointer.String("CustomScript"),
+						TypeHandlerVersion: pointer.String("2.1"),
+						Settings:           map[string]string{"my-setting": "my-value"},
+						ProtectedSettings:  map[string]string{"my-protected-setting": "my-protected-value"},
+					},
+					Location: pointer.String("my-location"),
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "vmextension that already exists",
 			spec:     &fakeVMExtensionSpec,
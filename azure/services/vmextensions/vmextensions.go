@@ -61,7 +61,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vmextensions.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, serviceName))
 	defer cancel()
 
 	specs := s.Scope.VMExtensionSpecs()
@@ -72,9 +72,26 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// We go through the list of ExtensionSpecs to reconcile each one, independently of the result of the previous one.
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var resultErr error
-	for _, extensionSpec := range specs {
+	// The GPU driver extension is tracked by its own condition, so its failures aren't reported as bootstrap failures.
+	// Extensions which must be provisioned after other extensions are only reconciled once those succeeded, so their
+	// dependencies are reconciled first.
+	var resultErr, gpuDriverErr error
+	var hasGPUDriver bool
+	succeeded := make(map[string]bool)
+	for _, extensionSpec := range orderByDependencies(specs) {
+		if !dependenciesSucceeded(extensionSpec, succeeded) {
+			continue
+		}
+
 		_, err := s.CreateOrUpdateResource(ctx, extensionSpec, serviceName)
+		if err == nil {
+			succeeded[extensionSpec.ResourceName()] = true
+		}
+		if isGPUDriverExtension(extensionSpec) {
+			hasGPUDriver = true
+			gpuDriverErr = err
+			continue
+		}
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
 				resultErr = err
@@ -89,9 +106,63 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, resultErr)
+
+	if hasGPUDriver {
+		if azure.IsOperationNotDoneError(gpuDriverErr) {
+			gpuDriverErr = errors.Wrapf(gpuDriverErr, "GPU driver extension is still in provisioning state")
+		} else if gpuDriverErr != nil {
+			gpuDriverErr = errors.Wrapf(gpuDriverErr, "GPU driver extension state failed. Check that the VM size has NVIDIA GPUs and that the OS image is supported by the extension")
+		}
+		s.Scope.UpdatePutStatus(infrav1.GPUDriverSucceededCondition, serviceName, gpuDriverErr)
+
+		if gpuDriverErr != nil && (resultErr == nil || (azure.IsOperationNotDoneError(resultErr) && !azure.IsOperationNotDoneError(gpuDriverErr))) {
+			resultErr = gpuDriverErr
+		}
+	}
+
 	return resultErr
 }
 
+// orderByDependencies returns the specs of the extensions which don't depend on other extensions, followed by the
+// specs of the extensions which do.
+func orderByDependencies(specs []azure.ResourceSpecGetter) []azure.ResourceSpecGetter {
+	ordered := make([]azure.ResourceSpecGetter, 0, len(specs))
+	var dependents []azure.ResourceSpecGetter
+	for _, spec := range specs {
+		if len(provisionAfterExtensions(spec)) > 0 {
+			dependents = append(dependents, spec)
+			continue
+		}
+		ordered = append(ordered, spec)
+	}
+	return append(ordered, dependents...)
+}
+
+// dependenciesSucceeded returns true if all the extensions the spec's extension must be provisioned after succeeded.
+func dependenciesSucceeded(spec azure.ResourceSpecGetter, succeeded map[string]bool) bool {
+	for _, name := range provisionAfterExtensions(spec) {
+		if !succeeded[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// provisionAfterExtensions returns the names of the extensions that must be provisioned before the spec's extension.
+func provisionAfterExtensions(spec azure.ResourceSpecGetter) []string {
+	extensionSpec, ok := spec.(*VMExtensionSpec)
+	if !ok {
+		return nil
+	}
+	return extensionSpec.ProvisionAfterExtensions
+}
+
+// isGPUDriverExtension returns true if the spec is for the NVIDIA GPU driver extension.
+func isGPUDriverExtension(spec azure.ResourceSpecGetter) bool {
+	name := spec.ResourceName()
+	return name == azure.GPUDriverExtensionLinux || name == azure.GPUDriverExtensionWindows
+}
+
 // Delete is a no-op. VM Extensions will be deleted as part of VM deletion.
 func (s *Service) Delete(_ context.Context) error {
 	return nil
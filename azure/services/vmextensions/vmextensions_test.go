@@ -55,11 +55,48 @@ var (
 		Location:      "test-location",
 	}
 
+	bootstrapExtensionSpec = VMExtensionSpec{
+		ExtensionSpec: azure.ExtensionSpec{
+			Name:      azure.BootstrappingExtensionLinux,
+			VMName:    "my-vm",
+			Publisher: "Microsoft.Azure.ContainerUpstream",
+			Version:   "1.0",
+		},
+		ResourceGroup: "my-rg",
+		Location:      "test-location",
+	}
+
+	afterBootstrapExtensionSpec = VMExtensionSpec{
+		ExtensionSpec: azure.ExtensionSpec{
+			Name:                     "my-extension-after-bootstrap",
+			VMName:                   "my-vm",
+			Publisher:                "some-publisher",
+			Version:                  "1.0",
+			ProvisionAfterExtensions: []string{azure.BootstrappingExtensionLinux},
+		},
+		ResourceGroup: "my-rg",
+		Location:      "test-location",
+	}
+
+	gpuDriverExtensionSpec = VMExtensionSpec{
+		ExtensionSpec: azure.ExtensionSpec{
+			Name:      azure.GPUDriverExtensionLinux,
+			VMName:    "my-vm",
+			Publisher: azure.GPUDriverExtensionPublisher,
+			Version:   azure.DefaultGPUDriverExtensionLinuxVersion,
+		},
+		ResourceGroup: "my-rg",
+		Location:      "test-location",
+	}
+
 	internalError        = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
 	extensionFailedError = errors.Wrapf(internalError, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
 
 	notDoneError          = azure.NewOperationNotDoneError(&infrav1.Future{})
 	extensionNotDoneError = errors.Wrapf(notDoneError, "extension is still in provisioning state. This likely means that bootstrapping has not yet completed on the VM")
+
+	gpuDriverFailedError  = errors.Wrapf(internalError, "GPU driver extension state failed. Check that the VM size has NVIDIA GPUs and that the OS image is supported by the extension")
+	gpuDriverNotDoneError = errors.Wrapf(notDoneError, "GPU driver extension is still in provisioning state")
 )
 
 func TestReconcileVMExtension(t *testing.T) {
@@ -115,6 +152,60 @@ func TestReconcileVMExtension(t *testing.T) {
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
 			},
 		},
+		{
+			name:          "extension provisioned after bootstrap is reconciled once bootstrap succeeded",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&afterBootstrapExtensionSpec, &bootstrapExtensionSpec})
+				gomock.InOrder(
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &bootstrapExtensionSpec, serviceName).Return(nil, nil),
+					r.CreateOrUpdateResource(gomockinternal.AContext(), &afterBootstrapExtensionSpec, serviceName).Return(nil, nil),
+				)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "extension provisioned after bootstrap is not reconciled while bootstrap is still creating",
+			expectedError: extensionNotDoneError.Error(),
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&afterBootstrapExtensionSpec, &bootstrapExtensionSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &bootstrapExtensionSpec, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionNotDoneError.Error()))
+			},
+		},
+		{
+			name:          "GPU driver extension is in succeeded state",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&gpuDriverExtensionSpec, &extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &gpuDriverExtensionSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.GPUDriverSucceededCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "GPU driver extension is still creating",
+			expectedError: gpuDriverNotDoneError.Error(),
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&gpuDriverExtensionSpec, &extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &gpuDriverExtensionSpec, serviceName).Return(nil, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.GPUDriverSucceededCondition, serviceName, gomockinternal.ErrStrEq(gpuDriverNotDoneError.Error()))
+			},
+		},
+		{
+			name:          "GPU driver extension failure takes precedence over extension still creating",
+			expectedError: gpuDriverFailedError.Error(),
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&gpuDriverExtensionSpec, &extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &gpuDriverExtensionSpec, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionNotDoneError.Error()))
+				s.UpdatePutStatus(infrav1.GPUDriverSucceededCondition, serviceName, gomockinternal.ErrStrEq(gpuDriverFailedError.Error()))
+			},
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -32,13 +32,19 @@ import (
 
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
-	peerings network.VirtualNetworkPeeringsClient
+	peerings   network.VirtualNetworkPeeringsClient
+	baseURI    string
+	authorizer autorest.Authorizer
 }
 
 // NewClient creates a new virtual network peerings client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	c := newPeeringsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &AzureClient{c}
+	return &AzureClient{
+		peerings:   c,
+		baseURI:    auth.BaseURI(),
+		authorizer: auth.Authorizer(),
+	}
 }
 
 // newPeeringsClient creates a new virtual network peerings client from subscription ID.
@@ -48,12 +54,22 @@ func newPeeringsClient(subscriptionID string, baseURI string, authorizer autores
 	return peeringsClient
 }
 
+// peeringsClient returns a virtual network peerings client for the subscription of the spec's source virtual network,
+// which may differ from the cluster's subscription for reverse peerings.
+func (ac *AzureClient) peeringsClient(spec azure.ResourceSpecGetter) network.VirtualNetworkPeeringsClient {
+	peeringSpec, ok := spec.(*VnetPeeringSpec)
+	if !ok || peeringSpec.SubscriptionID == "" || peeringSpec.SubscriptionID == ac.peerings.SubscriptionID {
+		return ac.peerings
+	}
+	return newPeeringsClient(peeringSpec.SubscriptionID, ac.baseURI, ac.authorizer)
+}
+
 // Get gets the specified virtual network peering by the peering name, virtual network, and resource group.
 func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.AzureClient.Get")
 	defer done()
 
-	return ac.peerings.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	return ac.peeringsClient(spec).Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 }
 
 // CreateOrUpdateAsync creates or updates a virtual network peering asynchronously.
@@ -68,7 +84,8 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 		return nil, nil, errors.Errorf("%T is not a network.VirtualNetworkPeering", parameters)
 	}
 
-	createFuture, err := ac.peerings.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), peering, network.SyncRemoteAddressSpaceTrue)
+	peeringsClient := ac.peeringsClient(spec)
+	createFuture, err := peeringsClient.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), peering, network.SyncRemoteAddressSpaceTrue)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,14 +93,14 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = createFuture.WaitForCompletionRef(ctx, ac.peerings.Client)
+	err = createFuture.WaitForCompletionRef(ctx, peeringsClient.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
 		return nil, &createFuture, err
 	}
 
-	result, err = createFuture.Result(ac.peerings)
+	result, err = createFuture.Result(peeringsClient)
 	// if the operation completed, return a nil future
 	return result, nil, err
 }
@@ -95,7 +112,8 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.AzureClient.Delete")
 	defer done()
 
-	deleteFuture, err := ac.peerings.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	peeringsClient := ac.peeringsClient(spec)
+	deleteFuture, err := peeringsClient.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 	if err != nil {
 		return nil, err
 	}
@@ -103,13 +121,13 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = deleteFuture.WaitForCompletionRef(ctx, ac.peerings.Client)
+	err = deleteFuture.WaitForCompletionRef(ctx, peeringsClient.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
 		return &deleteFuture, err
 	}
-	_, err = deleteFuture.Result(ac.peerings)
+	_, err = deleteFuture.Result(peeringsClient)
 	// if the operation completed, return a nil future.
 	return nil, err
 }
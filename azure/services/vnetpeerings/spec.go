@@ -33,6 +33,7 @@ type VnetPeeringSpec struct {
 	RemoteVnetName            string
 	PeeringName               string
 	SubscriptionID            string
+	RemoteSubscriptionID      string
 	AllowForwardedTraffic     *bool
 	AllowGatewayTransit       *bool
 	AllowVirtualNetworkAccess *bool
@@ -57,13 +58,38 @@ func (s *VnetPeeringSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the virtual network peering.
 func (s *VnetPeeringSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.VirtualNetworkPeering); !ok {
+		existingPeering, ok := existing.(network.VirtualNetworkPeering)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.VnetPeering", existing)
 		}
-		// virtual network peering already exists
-		return nil, nil
+		if existingPeering.VirtualNetworkPeeringPropertiesFormat == nil {
+			return nil, nil
+		}
+		// virtual network peering already exists, only update it if one of the specified properties changed.
+		props := existingPeering.VirtualNetworkPeeringPropertiesFormat
+		if !isChanged(s.AllowForwardedTraffic, props.AllowForwardedTraffic) &&
+			!isChanged(s.AllowGatewayTransit, props.AllowGatewayTransit) &&
+			!isChanged(s.AllowVirtualNetworkAccess, props.AllowVirtualNetworkAccess) &&
+			!isChanged(s.UseRemoteGateways, props.UseRemoteGateways) {
+			return nil, nil
+		}
+		return network.VirtualNetworkPeering{
+			Name: pointer.String(s.PeeringName),
+			VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+				RemoteVirtualNetwork:      props.RemoteVirtualNetwork,
+				AllowForwardedTraffic:     desiredOrExisting(s.AllowForwardedTraffic, props.AllowForwardedTraffic),
+				AllowGatewayTransit:       desiredOrExisting(s.AllowGatewayTransit, props.AllowGatewayTransit),
+				AllowVirtualNetworkAccess: desiredOrExisting(s.AllowVirtualNetworkAccess, props.AllowVirtualNetworkAccess),
+				UseRemoteGateways:         desiredOrExisting(s.UseRemoteGateways, props.UseRemoteGateways),
+			},
+		}, nil
 	}
-	vnetID := azure.VNetID(s.SubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
+
+	remoteSubscriptionID := s.RemoteSubscriptionID
+	if remoteSubscriptionID == "" {
+		remoteSubscriptionID = s.SubscriptionID
+	}
+	vnetID := azure.VNetID(remoteSubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
 	peeringProperties := network.VirtualNetworkPeeringPropertiesFormat{
 		RemoteVirtualNetwork: &network.SubResource{
 			ID: pointer.String(vnetID),
@@ -78,3 +104,16 @@ func (s *VnetPeeringSpec) Parameters(ctx context.Context, existing interface{})
 		VirtualNetworkPeeringPropertiesFormat: &peeringProperties,
 	}, nil
 }
+
+// isChanged returns true if a desired peering property is set and differs from the existing one.
+func isChanged(desired, existing *bool) bool {
+	return desired != nil && (existing == nil || *desired != *existing)
+}
+
+// desiredOrExisting returns the desired peering property if set, and the existing one otherwise.
+func desiredOrExisting(desired, existing *bool) *bool {
+	if desired != nil {
+		return desired
+	}
+	return existing
+}
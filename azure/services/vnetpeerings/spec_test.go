@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnetpeerings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name     string
+		spec     VnetPeeringSpec
+		existing interface{}
+		expected interface{}
+	}{
+		{
+			name: "new peering to a remote vnet in the same subscription",
+			spec: VnetPeeringSpec{
+				PeeringName:         "vnet1-To-vnet2",
+				SourceVnetName:      "vnet1",
+				SourceResourceGroup: "group1",
+				RemoteVnetName:      "vnet2",
+				RemoteResourceGroup: "group2",
+				SubscriptionID:      "sub1",
+			},
+			expected: network.VirtualNetworkPeering{
+				Name: pointer.String("vnet1-To-vnet2"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					RemoteVirtualNetwork: &network.SubResource{
+						ID: pointer.String("/subscriptions/sub1/resourceGroups/group2/providers/Microsoft.Network/virtualNetworks/vnet2"),
+					},
+				},
+			},
+		},
+		{
+			name: "new peering to a remote vnet in another subscription",
+			spec: VnetPeeringSpec{
+				PeeringName:           "vnet1-To-vnet2",
+				SourceVnetName:        "vnet1",
+				SourceResourceGroup:   "group1",
+				RemoteVnetName:        "vnet2",
+				RemoteResourceGroup:   "group2",
+				SubscriptionID:        "sub1",
+				RemoteSubscriptionID:  "sub2",
+				AllowForwardedTraffic: pointer.Bool(true),
+			},
+			expected: network.VirtualNetworkPeering{
+				Name: pointer.String("vnet1-To-vnet2"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					RemoteVirtualNetwork: &network.SubResource{
+						ID: pointer.String("/subscriptions/sub2/resourceGroups/group2/providers/Microsoft.Network/virtualNetworks/vnet2"),
+					},
+					AllowForwardedTraffic: pointer.Bool(true),
+				},
+			},
+		},
+		{
+			name: "existing peering is up to date",
+			spec: VnetPeeringSpec{
+				PeeringName:           "vnet1-To-vnet2",
+				AllowForwardedTraffic: pointer.Bool(true),
+			},
+			existing: network.VirtualNetworkPeering{
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					AllowForwardedTraffic:     pointer.Bool(true),
+					AllowVirtualNetworkAccess: pointer.Bool(true),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "existing peering with changed properties is updated",
+			spec: VnetPeeringSpec{
+				PeeringName:         "vnet1-To-vnet2",
+				AllowGatewayTransit: pointer.Bool(true),
+			},
+			existing: network.VirtualNetworkPeering{
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					RemoteVirtualNetwork: &network.SubResource{
+						ID: pointer.String("/subscriptions/sub2/resourceGroups/group2/providers/Microsoft.Network/virtualNetworks/vnet2"),
+					},
+					AllowForwardedTraffic:     pointer.Bool(true),
+					AllowGatewayTransit:       pointer.Bool(false),
+					AllowVirtualNetworkAccess: pointer.Bool(true),
+					PeeringState:              network.VirtualNetworkPeeringStateConnected,
+				},
+			},
+			expected: network.VirtualNetworkPeering{
+				Name: pointer.String("vnet1-To-vnet2"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					RemoteVirtualNetwork: &network.SubResource{
+						ID: pointer.String("/subscriptions/sub2/resourceGroups/group2/providers/Microsoft.Network/virtualNetworks/vnet2"),
+					},
+					AllowForwardedTraffic:     pointer.Bool(true),
+					AllowGatewayTransit:       pointer.Bool(true),
+					AllowVirtualNetworkAccess: pointer.Bool(true),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+		})
+	}
+}
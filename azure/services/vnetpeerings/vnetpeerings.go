@@ -18,7 +18,12 @@ package vnetpeerings
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
@@ -26,8 +31,13 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// ServiceName is the name of this service.
-const ServiceName = "vnetpeerings"
+const (
+	// ServiceName is the name of this service.
+	ServiceName = "vnetpeerings"
+
+	// peeringStateRequeueInterval is how long to wait before checking again on peerings that aren't connected yet.
+	peeringStateRequeueInterval = 15 * time.Second
+)
 
 // VnetPeeringScope defines the scope interface for a subnet service.
 type VnetPeeringScope interface {
@@ -61,7 +71,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Reconcile")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, ServiceName))
 	defer cancel()
 
 	specs := s.Scope.VnetPeeringSpecs()
@@ -73,14 +83,24 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
+	var notConnected []string
 	for _, peeringSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, peeringSpec, ServiceName); err != nil {
+		peering, err := s.CreateOrUpdateResource(ctx, peeringSpec, ServiceName)
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
+		} else if state, ok := peeringState(peering); ok && state != network.VirtualNetworkPeeringStateConnected {
+			notConnected = append(notConnected, fmt.Sprintf("%s is %s", peeringSpec.ResourceName(), state))
 		}
 	}
 
+	// A peering stays Initiated until its reverse peering is created, and becomes Disconnected if the
+	// reverse peering is deleted out of band, so surface the peering state in the condition.
+	if result == nil && len(notConnected) > 0 {
+		result = azure.WithTransientError(errors.Errorf("virtual network peerings are not connected: %s", strings.Join(notConnected, ", ")), peeringStateRequeueInterval)
+	}
+
 	s.Scope.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, result)
 	return result
 }
@@ -90,7 +110,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Delete")
 	defer done()
 
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	ctx, cancel := context.WithTimeout(ctx, reconciler.AzureServiceReconcileTimeout(s.Scope, ServiceName))
 	defer cancel()
 
 	specs := s.Scope.VnetPeeringSpecs()
@@ -113,6 +133,15 @@ func (s *Service) Delete(ctx context.Context) error {
 	return result
 }
 
+// peeringState returns the peering state of a virtual network peering returned by Azure.
+func peeringState(result interface{}) (network.VirtualNetworkPeeringState, bool) {
+	peering, ok := result.(network.VirtualNetworkPeering)
+	if !ok || peering.VirtualNetworkPeeringPropertiesFormat == nil || peering.PeeringState == "" {
+		return "", false
+	}
+	return peering.PeeringState, true
+}
+
 // IsManaged returns always returns true as CAPZ does not support BYO VNet peering.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -203,6 +204,20 @@ func TestReconcileVnetPeerings(t *testing.T) {
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
+		{
+			name:          "peering that is not connected requeues",
+			expectedError: "virtual network peerings are not connected: vnet1-to-vnet2 is Initiated. Object will be requeued after 15s",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:2])
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(network.VirtualNetworkPeering{
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{PeeringState: network.VirtualNetworkPeeringStateInitiated},
+				}, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(network.VirtualNetworkPeering{
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{PeeringState: network.VirtualNetworkPeeringStateConnected},
+				}, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, gomockinternal.ErrStrEq("virtual network peerings are not connected: vnet1-to-vnet2 is Initiated. Object will be requeued after 15s"))
+			},
+		},
 		{
 			name:          "not done error in creating remains",
 			expectedError: "operation type  on Azure resource / is not done",
@@ -57,6 +57,9 @@ type ScaleSetSpec struct {
 	VNetResourceGroup            string
 	PublicLBName                 string
 	PublicLBAddressPoolName      string
+	AllocatePublicIP             bool
+	PublicIPPrefixID             string
+	PublicIP                     *infrav1.NodePublicIP
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
 	Identity                     infrav1.VMIdentity
@@ -67,9 +70,14 @@ type ScaleSetSpec struct {
 	DiagnosticsProfile           *infrav1.Diagnostics
 	FailureDomains               []string
 	VMExtensions                 []infrav1.VMExtension
+	GPUDriver                    *infrav1.GPUDriver
 	NetworkInterfaces            []infrav1.NetworkInterface
 	IPv6Enabled                  bool
 	OrchestrationMode            infrav1.OrchestrationModeType
+	PlatformFaultDomainCount     *int32
+	ProximityPlacementGroupID    string
+	CapacityReservationGroupID   string
+	ScaleInPolicy                string
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -80,16 +88,32 @@ type TagsSpec struct {
 	// The last applied tags are used to find out which tags are being managed by CAPZ
 	// and if any has to be deleted by comparing it with the new desired tags
 	Annotation string
+	// AnnotationKey is the key of the last applied tags within the annotation, for annotations
+	// which track the last applied tags of several resources. The annotation only tracks the
+	// last applied tags of this resource when empty.
+	AnnotationKey string
 }
 
 // ExtensionSpec defines the specification for a VM or VMSS extension.
 type ExtensionSpec struct {
-	Name              string
+	Name string
+	// Type is the type of the extension. If empty, the name of the extension is used as its type.
+	Type              string
 	VMName            string
 	Publisher         string
 	Version           string
 	Settings          map[string]string
 	ProtectedSettings map[string]string
+	// ProvisionAfterExtensions are the names of the extensions that must be provisioned before this extension.
+	ProvisionAfterExtensions []string
+}
+
+// ExtensionType returns the type of the extension, which defaults to its name.
+func (s ExtensionSpec) ExtensionType() string {
+	if s.Type != "" {
+		return s.Type
+	}
+	return s.Name
 }
 
 type (
@@ -102,7 +126,10 @@ type (
 		AvailabilityZone   string                        `json:"availabilityZone,omitempty"`
 		State              infrav1.ProvisioningState     `json:"vmState,omitempty"`
 		BootstrappingState infrav1.ProvisioningState     `json:"bootstrappingState,omitempty"`
+		GPUDriverState     infrav1.ProvisioningState     `json:"gpuDriverState,omitempty"`
 		OrchestrationMode  infrav1.OrchestrationModeType `json:"orchestrationMode,omitempty"`
+		// PowerState is the power state of the instance, e.g. running or deallocated, when its instance view is known.
+		PowerState string `json:"powerState,omitempty"`
 	}
 
 	// VMSS defines a virtual machine scale set.
@@ -117,6 +144,15 @@ type (
 		Identity  infrav1.VMIdentity        `json:"identity,omitempty"`
 		Tags      infrav1.Tags              `json:"tags,omitempty"`
 		Instances []VMSSVM                  `json:"instances,omitempty"`
+		// OSDiskEncryptionSetID is the lowercased ID of the disk encryption set of the OS disk in the scale set model.
+		OSDiskEncryptionSetID string `json:"osDiskEncryptionSetID,omitempty"`
+		// DataDiskEncryptionSetIDs maps the LUN of each data disk in the scale set model to the lowercased ID of its disk encryption set.
+		DataDiskEncryptionSetIDs map[int32]string `json:"dataDiskEncryptionSetIDs,omitempty"`
+		// SpotMaxPrice is the maximum price of the spot instances in the scale set model, or nil when they are
+		// capped at the on-demand price.
+		SpotMaxPrice *float64 `json:"spotMaxPrice,omitempty"`
+		// ScaleInPolicy is the rule Azure follows to select the instances to remove when the scale set is scaled in.
+		ScaleInPolicy string `json:"scaleInPolicy,omitempty"`
 	}
 )
 
@@ -126,10 +162,18 @@ func (vmss VMSS) HasModelChanges(other VMSS) bool {
 		cmp.Equal(vmss.Identity, other.Identity) &&
 		cmp.Equal(vmss.Zones, other.Zones) &&
 		cmp.Equal(vmss.Tags, other.Tags) &&
-		cmp.Equal(vmss.Sku, other.Sku)
+		cmp.Equal(vmss.Sku, other.Sku) &&
+		vmss.OSDiskEncryptionSetID == other.OSDiskEncryptionSetID &&
+		cmp.Equal(vmss.DataDiskEncryptionSetIDs, other.DataDiskEncryptionSetIDs)
 	return !equal
 }
 
+// HasSpotMaxPriceChanges returns true if the maximum price of the spot instances is different.
+// The maximum price can be updated in place, without rolling out a new model to the instances.
+func (vmss VMSS) HasSpotMaxPriceChanges(other VMSS) bool {
+	return !cmp.Equal(vmss.SpotMaxPrice, other.SpotMaxPrice)
+}
+
 // InstancesByProviderID returns VMSSVMs by ID.
 func (vmss VMSS) InstancesByProviderID(mode infrav1.OrchestrationModeType) map[string]VMSSVM {
 	instancesByProviderID := make(map[string]VMSSVM, len(vmss.Instances))
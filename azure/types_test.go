@@ -136,6 +136,38 @@ func TestVMSS_HasModelChanges(t *testing.T) {
 			},
 			HasModelChanges: true,
 		},
+		{
+			Name: "with different OS disk encryption set",
+			Factory: func() (VMSS, VMSS) {
+				l := getDefaultVMSSForModelTesting()
+				l.OSDiskEncryptionSetID = "/subscriptions/123/resourcegroups/my-rg/providers/microsoft.compute/diskencryptionsets/my-des-rotated"
+				r := getDefaultVMSSForModelTesting()
+				return r, l
+			},
+			HasModelChanges: true,
+		},
+		{
+			Name: "with different data disk encryption set",
+			Factory: func() (VMSS, VMSS) {
+				l := getDefaultVMSSForModelTesting()
+				l.DataDiskEncryptionSetIDs = map[int32]string{
+					0: "/subscriptions/123/resourcegroups/my-rg/providers/microsoft.compute/diskencryptionsets/my-des-rotated",
+				}
+				r := getDefaultVMSSForModelTesting()
+				return r, l
+			},
+			HasModelChanges: true,
+		},
+		{
+			Name: "different spot max price",
+			Factory: func() (VMSS, VMSS) {
+				l := getDefaultVMSSForModelTesting()
+				l.SpotMaxPrice = pointer.Float64(0.5)
+				r := getDefaultVMSSForModelTesting()
+				return r, l
+			},
+			HasModelChanges: false,
+		},
 	}
 
 	for _, c := range cases {
@@ -148,6 +180,45 @@ func TestVMSS_HasModelChanges(t *testing.T) {
 	}
 }
 
+func TestVMSS_HasSpotMaxPriceChanges(t *testing.T) {
+	cases := []struct {
+		Name                   string
+		Factory                func() (VMSS, VMSS)
+		HasSpotMaxPriceChanges bool
+	}{
+		{
+			Name: "same max price",
+			Factory: func() (VMSS, VMSS) {
+				return VMSS{SpotMaxPrice: pointer.Float64(0.5)}, VMSS{SpotMaxPrice: pointer.Float64(0.5)}
+			},
+			HasSpotMaxPriceChanges: false,
+		},
+		{
+			Name: "different max price",
+			Factory: func() (VMSS, VMSS) {
+				return VMSS{SpotMaxPrice: pointer.Float64(0.5)}, VMSS{SpotMaxPrice: pointer.Float64(0.7)}
+			},
+			HasSpotMaxPriceChanges: true,
+		},
+		{
+			Name: "max price removed",
+			Factory: func() (VMSS, VMSS) {
+				return VMSS{SpotMaxPrice: pointer.Float64(0.5)}, VMSS{}
+			},
+			HasSpotMaxPriceChanges: true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			l, r := c.Factory()
+			g := NewWithT(t)
+			g.Expect(l.HasSpotMaxPriceChanges(r)).To(Equal(c.HasSpotMaxPriceChanges))
+		})
+	}
+}
+
 func getDefaultVMSSForModelTesting() VMSS {
 	return VMSS{
 		Zones: []string{"0", "1"},
@@ -161,5 +232,9 @@ func getDefaultVMSSForModelTesting() VMSS {
 		Tags: infrav1.Tags{
 			"foo": "baz",
 		},
+		OSDiskEncryptionSetID: "/subscriptions/123/resourcegroups/my-rg/providers/microsoft.compute/diskencryptionsets/my-des",
+		DataDiskEncryptionSetIDs: map[int32]string{
+			0: "/subscriptions/123/resourcegroups/my-rg/providers/microsoft.compute/diskencryptionsets/my-des",
+		},
 	}
 }
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// capz-export renders the Azure resources CAPZ manages for an AzureCluster as a Bicep or Terraform document.
+// It reads the AzureCluster from the management cluster of the current kubeconfig context, so it runs with the
+// permissions of the user rather than those of the CAPZ controller manager.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/export"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func main() {
+	var (
+		namespace string
+		name      string
+		format    string
+		output    string
+	)
+	fs := pflag.NewFlagSet("capz-export", pflag.ExitOnError)
+	fs.StringVarP(&namespace, "namespace", "n", "default", "Namespace of the AzureCluster.")
+	fs.StringVar(&name, "name", "", "Name of the AzureCluster to export.")
+	fs.StringVar(&format, "format", string(export.FormatBicep), "Format of the export, bicep or terraform.")
+	fs.StringVarP(&output, "output", "o", "", "File the export is written to. Defaults to stdout.")
+	// The --kubeconfig flag is registered by controller-runtime on the go flag set.
+	fs.AddGoFlagSet(flag.CommandLine)
+	_ = fs.Parse(os.Args[1:])
+
+	if name == "" {
+		fmt.Fprintln(os.Stderr, "--name is required")
+		os.Exit(2)
+	}
+
+	if err := run(ctrl.SetupSignalHandler(), types.NamespacedName{Namespace: namespace, Name: name}, export.Format(format), output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, key types.NamespacedName, format export.Format, output string) error {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return export.AzureCluster(ctx, c, key, format, w)
+}
@@ -29,6 +29,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -51,17 +53,20 @@ type AzureClusterReconciler struct {
 	ReconcileTimeout          time.Duration
 	WatchFilterValue          string
 	createAzureClusterService azureClusterServiceCreator
+	// ServiceOptions configure how the services reconcile Azure resources.
+	ServiceOptions scope.ServiceOptions
 }
 
 type azureClusterServiceCreator func(clusterScope *scope.ClusterScope) (*azureClusterService, error)
 
 // NewAzureClusterReconciler returns a new AzureClusterReconciler instance.
-func NewAzureClusterReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureClusterReconciler {
+func NewAzureClusterReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serviceOptions scope.ServiceOptions) *AzureClusterReconciler {
 	acr := &AzureClusterReconciler{
 		Client:           client,
 		Recorder:         recorder,
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		ServiceOptions:   serviceOptions,
 	}
 
 	acr.createAzureClusterService = newAzureClusterService
@@ -82,9 +87,9 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		r = coalescing.NewReconciler(acr, options.Cache, log)
 	}
 
-	c, err := ctrl.NewControllerManagedBy(mgr).
-		WithOptions(options.Options).
-		For(&infrav1.AzureCluster{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options.Options)
+	c, err := ForWithPriority(b, &infrav1.AzureCluster{}, "AzureCluster", options.LowPriorityDelay).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, acr.WatchFilterValue)).
 		WithEventFilter(predicates.ResourceIsNotExternallyManaged(log)).
 		Build(r)
@@ -110,7 +115,9 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates;azuremachinetemplates/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 
 // Reconcile idempotently gets, creates, and updates a cluster.
 func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -151,11 +158,16 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	log = log.WithValues("cluster", cluster.Name)
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, azureCluster) {
-		acr.Recorder.Eventf(azureCluster, corev1.EventTypeNormal, "ClusterPaused", "AzureCluster or linked Cluster is marked as paused. Won't reconcile")
-		log.Info("AzureCluster or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+	// Return early if the object or Cluster is paused. Long running operations which are still in flight are only
+	// polled until they complete, so that clusterctl move doesn't leave them behind.
+	paused := annotations.IsPaused(cluster, azureCluster)
+	if paused {
+		if !futures.InFlight(azureCluster) {
+			acr.Recorder.Eventf(azureCluster, corev1.EventTypeNormal, "ClusterPaused", "AzureCluster or linked Cluster is marked as paused. Won't reconcile")
+			log.Info("AzureCluster or linked Cluster is marked as paused. Won't reconcile")
+			return ctrl.Result{}, nil
+		}
+		log.Info("AzureCluster or linked Cluster is marked as paused. Polling in-flight operations until they complete")
 	}
 
 	if azureCluster.Spec.IdentityRef != nil {
@@ -170,9 +182,10 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
-		Client:       acr.Client,
-		Cluster:      cluster,
-		AzureCluster: azureCluster,
+		Client:         acr.Client,
+		Cluster:        cluster,
+		AzureCluster:   azureCluster,
+		ServiceOptions: acr.ServiceOptions,
 	})
 	if err != nil {
 		err = errors.Wrap(err, "failed to create scope")
@@ -187,6 +200,10 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}()
 
+	if paused {
+		return PollFuturesWhilePaused(ctx, azureCluster, clusterScope)
+	}
+
 	// Handle deleted clusters
 	if !azureCluster.DeletionTimestamp.IsZero() {
 		return acr.reconcileDelete(ctx, clusterScope)
@@ -211,6 +228,23 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		}
 	}
 
+	if skipsAzureReconcile(azureCluster) {
+		log.Info("Skipping reconciliation of Azure resources because of the reconcile policy")
+		return reconcile.Result{}, nil
+	}
+
+	if result, throttled := throttledSubscription(ctx, clusterScope.SubscriptionID(), acr.ServiceOptions.ARMRateLimitRemainingThreshold); throttled {
+		return result, nil
+	}
+
+	// Claim the internal API server load balancer IP from an IPAM provider if requested.
+	if ready, err := reconcileAPIServerLBIPAddressClaim(ctx, acr.Client, clusterScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile API server load balancer IP address claim")
+	} else if !ready {
+		log.Info("Waiting for IPAM provider to allocate the API server load balancer IP address")
+		return reconcile.Result{RequeueAfter: ipAddressRequeueInterval}, nil
+	}
+
 	acs, err := acr.createAzureClusterService(clusterScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
@@ -249,8 +283,12 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 	if azureCluster.Spec.ControlPlaneEndpoint.Port == 0 {
 		azureCluster.Spec.ControlPlaneEndpoint.Port = clusterScope.APIServerPort()
 	}
+	azureCluster.Status.APIServerEndpoints = clusterScope.APIServerEndpoints()
 
 	// No errors, so mark us ready so the Cluster API Cluster Controller can pull it
+	if !azureCluster.Status.Ready {
+		clusterScope.Notify(notify.InfrastructureReady, "cluster infrastructure is ready")
+	}
 	azureCluster.Status.Ready = true
 	conditions.MarkTrue(azureCluster, infrav1.NetworkInfrastructureReadyCondition)
 
@@ -265,33 +303,42 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 
 	azureCluster := clusterScope.AzureCluster
 
-	acs, err := acr.createAzureClusterService(clusterScope)
-	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
-	}
+	if skipsAzureDelete(azureCluster) {
+		log.Info("Skipping deletion of Azure resources because of the reconcile policy")
+	} else {
+		if result, throttled := throttledSubscription(ctx, clusterScope.SubscriptionID(), acr.ServiceOptions.ARMRateLimitRemainingThreshold); throttled {
+			return result, nil
+		}
 
-	if err := acs.Delete(ctx); err != nil {
-		// Handle transient errors
-		var reconcileError azure.ReconcileError
-		if errors.As(err, &reconcileError) {
-			if reconcileError.IsTransient() {
-				if azure.IsOperationNotDoneError(reconcileError) {
-					log.V(2).Info(fmt.Sprintf("AzureCluster delete not done: %s", reconcileError.Error()))
-				} else {
-					log.V(2).Info("transient failure to delete AzureCluster, retrying")
+		acs, err := acr.createAzureClusterService(clusterScope)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
+		}
+
+		if err := acs.Delete(ctx); err != nil {
+			// Handle transient errors
+			var reconcileError azure.ReconcileError
+			if errors.As(err, &reconcileError) {
+				if reconcileError.IsTransient() {
+					if azure.IsOperationNotDoneError(reconcileError) {
+						log.V(2).Info(fmt.Sprintf("AzureCluster delete not done: %s", reconcileError.Error()))
+					} else {
+						log.V(2).Info("transient failure to delete AzureCluster, retrying")
+					}
+					return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
 				}
-				return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
 			}
-		}
 
-		wrappedErr := errors.Wrapf(err, "error deleting AzureCluster %s/%s", azureCluster.Namespace, azureCluster.Name)
-		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ClusterReconcilerDeleteFailed", wrappedErr.Error())
-		conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
-		return reconcile.Result{}, wrappedErr
+			wrappedErr := errors.Wrapf(err, "error deleting AzureCluster %s/%s", azureCluster.Namespace, azureCluster.Name)
+			acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ClusterReconcilerDeleteFailed", wrappedErr.Error())
+			conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return reconcile.Result{}, wrappedErr
+		}
 	}
 
 	// Cluster is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(azureCluster, infrav1.ClusterFinalizer)
+	clusterScope.Notify(notify.DeletionComplete, "cluster infrastructure has been deleted")
 
 	if azureCluster.Spec.IdentityRef != nil {
 		// Cluster is deleted so remove the identity finalizer.
@@ -18,22 +18,29 @@ package controllers
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinks"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworkgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -46,7 +53,11 @@ type azureClusterService struct {
 	// services is the list of services that are reconciled by this controller.
 	// The order of the services is important as it determines the order in which the services are reconciled.
 	services []azure.ServiceReconciler
-	skuCache *resourceskus.Cache
+	// dependencies are the services each service depends on, which must come before it in services. A service is
+	// reconciled concurrently with the other services once the services it depends on are reconciled. Services without
+	// dependencies listed depend on all the services before them.
+	dependencies map[azure.ServiceReconciler][]azure.ServiceReconciler
+	skuCache     *resourceskus.Cache
 }
 
 // newAzureClusterService populates all the services based on input scope.
@@ -55,28 +66,75 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
+	group := groups.New(scope)
+	virtualNetworks := virtualnetworks.New(scope)
+	proximityPlacementGroups := proximityplacementgroups.New(scope)
+	applicationSecurityGroups := applicationsecuritygroups.New(scope)
+	securityGroups := securitygroups.New(scope)
+	routeTables := routetables.New(scope)
+	publicIPs := publicips.New(scope)
+	natGateways := natgateways.New(scope)
+	subnetsSvc := subnets.New(scope)
+	azureFirewalls := azurefirewalls.New(scope)
+	virtualNetworkGateways := virtualnetworkgateways.New(scope)
+	applicationGateways := applicationgateways.New(scope)
+	vnetPeerings := vnetpeerings.New(scope)
+	loadBalancers := loadbalancers.New(scope)
+	privateLinks := privatelinks.New(scope)
+	privateDNS := privatedns.New(scope)
+	bastionHosts := bastionhosts.New(scope)
+	privateEndpoints := privateendpoints.New(scope)
 	return &azureClusterService{
 		scope: scope,
 		services: []azure.ServiceReconciler{
-			groups.New(scope),
-			virtualnetworks.New(scope),
-			securitygroups.New(scope),
-			routetables.New(scope),
-			publicips.New(scope),
-			natgateways.New(scope),
-			subnets.New(scope),
-			vnetpeerings.New(scope),
-			loadbalancers.New(scope),
-			privatedns.New(scope),
-			bastionhosts.New(scope),
-			privateendpoints.New(scope),
+			group,
+			virtualNetworks,
+			proximityPlacementGroups,
+			applicationSecurityGroups,
+			securityGroups,
+			routeTables,
+			publicIPs,
+			natGateways,
+			subnetsSvc,
+			azureFirewalls,
+			virtualNetworkGateways,
+			applicationGateways,
+			vnetPeerings,
+			loadBalancers,
+			privateLinks,
+			privateDNS,
+			bastionHosts,
+			privateEndpoints,
 			tags.New(scope),
 		},
+		// The virtual networks, NAT gateways and subnets update the vnet and the subnets of the AzureCluster spec, so
+		// they are not reconciled concurrently with the other services reading them. The tags are reconciled last, once
+		// all the resources exist.
+		dependencies: map[azure.ServiceReconciler][]azure.ServiceReconciler{
+			group:                     {},
+			virtualNetworks:           {group},
+			proximityPlacementGroups:  {virtualNetworks},
+			applicationSecurityGroups: {virtualNetworks},
+			publicIPs:                 {virtualNetworks},
+			securityGroups:            {virtualNetworks, applicationSecurityGroups},
+			routeTables:               {virtualNetworks},
+			natGateways:               {publicIPs, securityGroups, routeTables},
+			subnetsSvc:                {natGateways},
+			vnetPeerings:              {virtualNetworks},
+			azureFirewalls:            {subnetsSvc},
+			virtualNetworkGateways:    {subnetsSvc},
+			applicationGateways:       {subnetsSvc},
+			loadBalancers:             {subnetsSvc},
+			bastionHosts:              {subnetsSvc},
+			privateLinks:              {loadBalancers},
+			privateDNS:                {loadBalancers, vnetPeerings},
+			privateEndpoints:          {privateLinks, privateDNS},
+		},
 		skuCache: skuCache,
 	}, nil
 }
 
-// Reconcile reconciles all the services in a predetermined order.
+// Reconcile reconciles all the services, each service once the services it depends on are reconciled.
 func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Reconcile")
 	defer done()
@@ -89,15 +147,75 @@ func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
 
-	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
-			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
-		}
+	return s.reconcileServices(ctx)
+}
+
+// reconcileServices reconciles the services concurrently, each service once the services it depends on are reconciled.
+// The services depending on a service which fails to reconcile are not reconciled, while the other services are.
+// The error of the first service which failed to reconcile, in the order of the services, is returned.
+func (s *azureClusterService) reconcileServices(ctx context.Context) error {
+	index := make(map[azure.ServiceReconciler]int, len(s.services))
+	for i, service := range s.services {
+		index[service] = i
 	}
 
+	errs := make([]error, len(s.services))
+	done := make([]chan struct{}, len(s.services))
+	for i := range s.services {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for i, service := range s.services {
+		wg.Add(1)
+		go func(i int, service azure.ServiceReconciler) {
+			defer wg.Done()
+			defer close(done[i])
+			for _, dep := range s.dependencyIndexes(i, index) {
+				<-done[dep]
+				if errs[dep] != nil {
+					errs[i] = errDependencyNotReconciled
+					return
+				}
+			}
+			errs[i] = ReconcileService(ctx, service)
+		}(i, service)
+	}
+	wg.Wait()
+
+	// The services only depend on services before them, so the first error is never errDependencyNotReconciled.
+	for i, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", s.services[i].Name())
+		}
+	}
 	return nil
 }
 
+// errDependencyNotReconciled is the error of the services which were not reconciled because a service they depend on
+// failed to reconcile.
+var errDependencyNotReconciled = errors.New("a service it depends on failed to reconcile")
+
+// dependencyIndexes returns the indexes of the services the service at index i depends on.
+func (s *azureClusterService) dependencyIndexes(i int, index map[azure.ServiceReconciler]int) []int {
+	dependencies, ok := s.dependencies[s.services[i]]
+	if !ok {
+		indexes := make([]int, i)
+		for j := range indexes {
+			indexes[j] = j
+		}
+		return indexes
+	}
+
+	indexes := make([]int, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		if j, ok := index[dependency]; ok && j < i {
+			indexes = append(indexes, j)
+		}
+	}
+	return indexes
+}
+
 // Delete reconciles all the services in a predetermined order.
 func (s *azureClusterService) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
@@ -123,18 +241,18 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to get vnet peerings service")
 		}
-		if err := vnetPeeringsSvc.Delete(ctx); err != nil {
+		if err := DeleteService(ctx, vnetPeeringsSvc); err != nil {
 			return errors.Wrap(err, "failed to delete peerings")
 		}
 		// Delete the entire resource group directly.
-		if err := groupSvc.Delete(ctx); err != nil {
+		if err := DeleteService(ctx, groupSvc); err != nil {
 			return errors.Wrap(err, "failed to delete resource group")
 		}
 	} else {
 		// If the resource group is not managed we need to delete resources inside the group one by one.
 		// services are deleted in reverse order from the order in which they are reconciled.
 		for i := len(s.services) - 1; i >= 0; i-- {
-			if err := s.services[i].Delete(ctx); err != nil {
+			if err := DeleteService(ctx, s.services[i]); err != nil {
 				return errors.Wrapf(err, "failed to delete AzureCluster service %s", s.services[i].Name())
 			}
 		}
@@ -20,10 +20,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
@@ -33,6 +37,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureClusterServiceReconcile(t *testing.T) {
@@ -55,7 +60,7 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 				gomock.InOrder(
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("two"))
+					two.Name().Return("two").AnyTimes())
 			},
 		},
 	}
@@ -73,6 +78,10 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			// The name of each service is also used for its metrics.
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
@@ -98,6 +107,179 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 	}
 }
 
+func TestAzureClusterServiceReconcileDependencies(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	svcOneMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+	svcTwoMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+	svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+	svcFourMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+
+	// three doesn't depend on two, so it is reconciled even though two fails, unlike four.
+	one := svcOneMock.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil)
+	svcTwoMock.EXPECT().Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")).After(one)
+	svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+	svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+	svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
+	svcThreeMock.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil).After(one)
+
+	s := &azureClusterService{
+		scope: &scope.ClusterScope{
+			Cluster:      &clusterv1.Cluster{},
+			AzureCluster: &infrav1.AzureCluster{},
+		},
+		services: []azure.ServiceReconciler{
+			svcOneMock,
+			svcTwoMock,
+			svcThreeMock,
+			svcFourMock,
+		},
+		dependencies: map[azure.ServiceReconciler][]azure.ServiceReconciler{
+			svcOneMock:   {},
+			svcTwoMock:   {svcOneMock},
+			svcThreeMock: {svcOneMock},
+			svcFourMock:  {svcTwoMock},
+		},
+		skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
+	}
+
+	err := s.Reconcile(context.TODO())
+	g.Expect(err).To(MatchError("failed to reconcile AzureCluster service two: some error happened"))
+}
+
+func TestAzureClusterServiceReconcileConcurrently(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	t.Setenv(auth.ClientID, "fooClient")
+	t.Setenv(auth.ClientSecret, "fooSecret")
+	t.Setenv(auth.TenantID, "fooTenant")
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-azure-cluster", Namespace: "default"},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+				Location:       "westus2",
+			},
+			ResourceGroup: "my-rg",
+			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name: "my-vnet",
+					VnetClassSpec: infrav1.VnetClassSpec{
+						CIDRBlocks: []string{"10.0.0.0/8"},
+					},
+				},
+				Subnets: infrav1.Subnets{
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							Name:       "cp-subnet",
+							Role:       infrav1.SubnetControlPlane,
+							CIDRBlocks: []string{"10.0.0.0/16"},
+						},
+					},
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							Name:       "node-subnet",
+							Role:       infrav1.SubnetNode,
+							CIDRBlocks: []string{"10.1.0.0/16"},
+						},
+						NatGateway: infrav1.NatGateway{
+							NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: "node-natgw"},
+						},
+					},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(g)).WithRuntimeObjects(cluster, azureCluster).Build()
+	clusterScope, err := scope.NewClusterScope(context.Background(), scope.ClusterScopeParams{
+		AzureClients: scope.AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+		Client:       fakeClient,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s, err := newAzureClusterService(clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	s.skuCache = resourceskus.NewStaticCache([]compute.ResourceSku{}, "")
+
+	// Each service is replaced by a mock reading and updating the AzureCluster spec like the service does, so that
+	// running this test with the race detector catches services updating the spec while other services read it. The
+	// services updating the spec do it before and after waiting, like they do before and after calling Azure, so that
+	// the services which aren't ordered with them run in between.
+	update := func(f func()) {
+		f()
+		time.Sleep(100 * time.Millisecond)
+		f()
+	}
+	reconcileFuncs := map[string]func(){
+		"group": func() { clusterScope.GroupSpec() },
+		"virtualnetworks": func() {
+			clusterScope.VNetSpec()
+			update(func() {
+				clusterScope.Vnet().ID = "my-vnet-id"
+				clusterScope.Vnet().CIDRBlocks = []string{"10.0.0.0/8"}
+				clusterScope.UpdateSubnetCIDRs("node-subnet", []string{"10.1.0.0/16"})
+			})
+		},
+		"proximityplacementgroups":  func() { clusterScope.ProximityPlacementGroupSpecs() },
+		"applicationsecuritygroups": func() { clusterScope.ApplicationSecurityGroupSpecs() },
+		"securitygroups":            func() { clusterScope.NSGSpecs() },
+		"routetables":               func() { clusterScope.RouteTableSpecs() },
+		"publicips":                 func() { clusterScope.PublicIPSpecs() },
+		"natgateways": func() {
+			clusterScope.NatGatewaySpecs()
+			update(func() { clusterScope.SetNatGatewayIDInSubnets("node-natgw", "my-natgw-id") })
+		},
+		"subnets": func() {
+			clusterScope.SubnetSpecs()
+			update(func() { clusterScope.UpdateSubnetID("node-subnet", "my-subnet-id") })
+		},
+		"azurefirewalls":         func() { clusterScope.AzureFirewallSpec() },
+		"virtualnetworkgateways": func() { clusterScope.VirtualNetworkGatewaySpec() },
+		"applicationgateways":    func() { clusterScope.ApplicationGatewaySpec() },
+		"vnetpeerings":           func() { clusterScope.VnetPeeringSpecs() },
+		"loadbalancers":          func() { clusterScope.LBSpecs() },
+		"privatelinks":           func() { clusterScope.APIServerPrivateLinkServiceSpec() },
+		"privatedns":             func() { clusterScope.PrivateDNSSpec() },
+		"bastionhosts":           func() { clusterScope.AzureBastionSpec() },
+		"privateendpoints":       func() { clusterScope.PrivateEndpointSpecs() },
+		"tags":                   func() { clusterScope.TagsSpecs() },
+	}
+
+	mocks := make(map[azure.ServiceReconciler]azure.ServiceReconciler, len(s.services))
+	for i, service := range s.services {
+		reconcileFunc, ok := reconcileFuncs[service.Name()]
+		g.Expect(ok).To(BeTrue(), "service %s has no reconcile func", service.Name())
+		svcMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+		svcMock.EXPECT().Name().Return(service.Name()).AnyTimes()
+		svcMock.EXPECT().Reconcile(gomockinternal.AContext()).Do(func(context.Context) { reconcileFunc() }).Return(nil)
+		mocks[service] = svcMock
+		s.services[i] = svcMock
+	}
+	dependencies := make(map[azure.ServiceReconciler][]azure.ServiceReconciler, len(s.dependencies))
+	for service, deps := range s.dependencies {
+		for _, dep := range deps {
+			dependencies[mocks[service]] = append(dependencies[mocks[service]], mocks[dep])
+		}
+		if len(deps) == 0 {
+			dependencies[mocks[service]] = []azure.ServiceReconciler{}
+		}
+	}
+	s.dependencies = dependencies
+
+	g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+}
+
 func TestAzureClusterServiceDelete(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
@@ -156,7 +338,7 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 					grp.IsManaged(gomockinternal.AContext()).Return(false, nil),
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("two"))
+					two.Name().Return("two").AnyTimes())
 			},
 		},
 	}
@@ -176,6 +358,12 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			// The name of each service is also used for its metrics.
+			groupsMock.EXPECT().Name().Return(groups.ServiceName).AnyTimes()
+			vnetpeeringsMock.EXPECT().Name().Return(vnetpeerings.ServiceName).AnyTimes()
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
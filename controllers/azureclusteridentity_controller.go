@@ -0,0 +1,364 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	capifeature "sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// AzureClusterIdentityReconciler reconciles AzureClusterIdentity objects. It reports the clusters using an identity,
+// checks they are still in namespaces allowed to use it, removes the finalizers of the clusters which no longer use
+// it, and labels the identity and its secret so clusterctl move moves them along with the clusters.
+type AzureClusterIdentityReconciler struct {
+	client.Client
+	// APIReader reads the clusters referencing an identity without the cache, so that the finalizer of a cluster
+	// which was just created isn't removed because the cache hasn't seen the cluster yet.
+	APIReader        client.Reader
+	Recorder         record.EventRecorder
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// SetupWithManager initializes this controller with a manager.
+func (r *AzureClusterIdentityReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, log, done := tele.StartSpanWithLogger(ctx,
+		"controllers.AzureClusterIdentityReconciler.SetupWithManager",
+		tele.KVP("controller", "AzureClusterIdentity"),
+	)
+	defer done()
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		// The watch filter is set per watch rather than with WithEventFilter, which would drop the events of the
+		// Namespaces since they never have the watch label.
+		For(&infrav1.AzureClusterIdentity{}, builder.WithPredicates(predicates.ResourceHasFilterLabel(log, r.WatchFilterValue))).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "error creating controller")
+	}
+
+	// Add a watch on infrav1.AzureCluster to update the identities they reference.
+	if err = c.Watch(
+		&source.Kind{Type: &infrav1.AzureCluster{}},
+		handler.EnqueueRequestsFromMapFunc(identityRefToAzureClusterIdentity),
+		predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for AzureClusters")
+	}
+
+	// Add a watch on infrav1.AzureManagedControlPlane if Cluster API 'MachinePool' feature is enabled.
+	if feature.Gates.Enabled(capifeature.MachinePool) {
+		if err = c.Watch(
+			&source.Kind{Type: &infrav1.AzureManagedControlPlane{}},
+			handler.EnqueueRequestsFromMapFunc(identityRefToAzureClusterIdentity),
+			predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
+		); err != nil {
+			return errors.Wrap(err, "failed adding a watch for AzureManagedControlPlanes")
+		}
+	}
+
+	// Add a watch on corev1.Namespace to check the clusters are still allowed to use the identities selecting
+	// namespaces by label when the labels of a namespace change.
+	if err = c.Watch(
+		&source.Kind{Type: &corev1.Namespace{}},
+		handler.EnqueueRequestsFromMapFunc(r.namespaceToAzureClusterIdentities(ctx)),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for Namespaces")
+	}
+
+	return nil
+}
+
+// namespaceToAzureClusterIdentities returns a handler.MapFunc mapping a Namespace to the AzureClusterIdentities
+// selecting namespaces by label.
+func (r *AzureClusterIdentityReconciler) namespaceToAzureClusterIdentities(ctx context.Context) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+		identities := &infrav1.AzureClusterIdentityList{}
+		var opts []client.ListOption
+		if r.WatchFilterValue != "" {
+			opts = append(opts, client.MatchingLabels{clusterv1.WatchLabel: r.WatchFilterValue})
+		}
+		if err := r.List(ctx, identities, opts...); err != nil {
+			log.Error(err, "failed to list AzureClusterIdentities")
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range identities.Items {
+			identity := &identities.Items[i]
+			if identity.Spec.AllowedNamespaces == nil || identity.Spec.AllowedNamespaces.Selector == nil {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(identity)})
+		}
+		return requests
+	}
+}
+
+// identityRefToAzureClusterIdentity maps an AzureCluster or an AzureManagedControlPlane to the AzureClusterIdentity it
+// references.
+func identityRefToAzureClusterIdentity(o client.Object) []reconcile.Request {
+	ref := clusterIdentityRef(o)
+	if ref == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: identityRefKey(ref, o.GetNamespace())}}
+}
+
+// clusterIdentityRef returns the identity reference of an AzureCluster or an AzureManagedControlPlane.
+func clusterIdentityRef(o client.Object) *corev1.ObjectReference {
+	switch cluster := o.(type) {
+	case *infrav1.AzureCluster:
+		return cluster.Spec.IdentityRef
+	case *infrav1.AzureManagedControlPlane:
+		return cluster.Spec.IdentityRef
+	default:
+		return nil
+	}
+}
+
+// identityRefKey returns the key of the AzureClusterIdentity referenced by a cluster. The namespace of the cluster is
+// used when the reference doesn't set one.
+func identityRefKey(ref *corev1.ObjectReference, clusterNamespace string) client.ObjectKey {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = clusterNamespace
+	}
+	return client.ObjectKey{Namespace: namespace, Name: ref.Name}
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusters;azuremanagedcontrolplanes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+
+// Reconcile reconciles an AzureClusterIdentity.
+func (r *AzureClusterIdentityReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterIdentityReconciler.Reconcile",
+		tele.KVP("namespace", req.Namespace),
+		tele.KVP("name", req.Name),
+		tele.KVP("kind", "AzureClusterIdentity"),
+	)
+	defer done()
+
+	identity := &infrav1.AzureClusterIdentity{}
+	if err := r.Get(ctx, req.NamespacedName, identity); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("object was not found")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(identity, r.Client)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	// Always patch when exiting so we can persist changes to finalizers, labels and status.
+	defer func() {
+		if err := patchHelper.Patch(ctx, identity); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	clusters, err := r.referencingClusters(ctx, identity)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	identity.Status.ReferencedByClusters = nil
+	finalizers := map[string]bool{}
+	for _, cluster := range clusters {
+		identity.Status.ReferencedByClusters = append(identity.Status.ReferencedByClusters,
+			fmt.Sprintf("%s/%s", cluster.GetNamespace(), cluster.GetName()))
+		prefix := infrav1.ClusterFinalizer
+		if _, ok := cluster.(*infrav1.AzureManagedControlPlane); ok {
+			prefix = infrav1.ManagedClusterFinalizer
+		}
+		finalizers[clusterIdentityFinalizer(prefix, cluster.GetNamespace(), cluster.GetName())] = true
+
+		if err := r.reconcileIdentityAllowed(ctx, identity, cluster); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The finalizer of a cluster is only removed when the cluster is deleted, so it is left behind when the cluster
+	// is changed to use another identity.
+	for _, finalizer := range identity.GetFinalizers() {
+		if isClusterIdentityFinalizer(finalizer) && !finalizers[finalizer] {
+			log.V(2).Info("removing the finalizer of a cluster which no longer references the identity", "finalizer", finalizer)
+			controllerutil.RemoveFinalizer(identity, finalizer)
+		}
+	}
+
+	if !identity.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Label the identity and its secret so that clusterctl move moves them along with the clusters using them.
+	labels := identity.GetLabels()
+	if _, ok := labels[clusterctlv1.ClusterctlMoveHierarchyLabel]; !ok {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[clusterctlv1.ClusterctlMoveHierarchyLabel] = "true"
+		identity.SetLabels(labels)
+	}
+	if err := r.reconcileSecretMoveLabel(ctx, identity); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// referencingClusters returns the AzureClusters and AzureManagedControlPlanes referencing the identity, sorted by
+// namespace and name.
+func (r *AzureClusterIdentityReconciler) referencingClusters(ctx context.Context, identity *infrav1.AzureClusterIdentity) ([]conditions.Setter, error) {
+	var objects []conditions.Setter
+	azureClusters := &infrav1.AzureClusterList{}
+	if err := r.APIReader.List(ctx, azureClusters); err != nil {
+		return nil, errors.Wrap(err, "failed to list AzureClusters")
+	}
+	for i := range azureClusters.Items {
+		objects = append(objects, &azureClusters.Items[i])
+	}
+	if feature.Gates.Enabled(capifeature.MachinePool) {
+		controlPlanes := &infrav1.AzureManagedControlPlaneList{}
+		if err := r.APIReader.List(ctx, controlPlanes); err != nil {
+			return nil, errors.Wrap(err, "failed to list AzureManagedControlPlanes")
+		}
+		for i := range controlPlanes.Items {
+			objects = append(objects, &controlPlanes.Items[i])
+		}
+	}
+
+	key := client.ObjectKeyFromObject(identity)
+	var clusters []conditions.Setter
+	for _, o := range objects {
+		if ref := clusterIdentityRef(o); ref != nil && identityRefKey(ref, o.GetNamespace()) == key {
+			clusters = append(clusters, o)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].GetNamespace() != clusters[j].GetNamespace() {
+			return clusters[i].GetNamespace() < clusters[j].GetNamespace()
+		}
+		return clusters[i].GetName() < clusters[j].GetName()
+	})
+	return clusters, nil
+}
+
+// reconcileIdentityAllowed sets the IdentityAllowed condition of a cluster referencing the identity, so that a cluster
+// whose namespace is no longer allowed to use the identity, e.g. after the labels of the namespace changed, reports it
+// without waiting for its next reconciliation.
+func (r *AzureClusterIdentityReconciler) reconcileIdentityAllowed(ctx context.Context, identity *infrav1.AzureClusterIdentity, cluster conditions.Setter) error {
+	allowed := scope.IsClusterNamespaceAllowed(ctx, r.Client, identity.Spec.AllowedNamespaces, cluster.GetNamespace())
+	if condition := conditions.Get(cluster, infrav1.IdentityAllowedCondition); condition != nil && (condition.Status == corev1.ConditionTrue) == allowed {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(cluster, r.Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+	if allowed {
+		conditions.MarkTrue(cluster, infrav1.IdentityAllowedCondition)
+	} else {
+		conditions.MarkFalse(cluster, infrav1.IdentityAllowedCondition, infrav1.NamespaceNotAllowedByIdentity, clusterv1.ConditionSeverityError,
+			"namespace %s is not allowed to use AzureClusterIdentity %s/%s", cluster.GetNamespace(), identity.Namespace, identity.Name)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, infrav1.NamespaceNotAllowedByIdentity,
+				"namespace %s is not allowed to use AzureClusterIdentity %s/%s", cluster.GetNamespace(), identity.Namespace, identity.Name)
+		}
+	}
+	if err := patchHelper.Patch(ctx, cluster); err != nil {
+		return errors.Wrapf(err, "failed to patch %s/%s", cluster.GetNamespace(), cluster.GetName())
+	}
+	return nil
+}
+
+// isClusterIdentityFinalizer returns true if the finalizer was set on an identity by an AzureCluster or an
+// AzureManagedControlPlane.
+func isClusterIdentityFinalizer(finalizer string) bool {
+	return strings.HasPrefix(finalizer, infrav1.ClusterFinalizer+"/") || strings.HasPrefix(finalizer, infrav1.ManagedClusterFinalizer+"/")
+}
+
+// reconcileSecretMoveLabel adds the clusterctl move label to the client secret of the identity.
+func (r *AzureClusterIdentityReconciler) reconcileSecretMoveLabel(ctx context.Context, identity *infrav1.AzureClusterIdentity) error {
+	if identity.Spec.ClientSecret.Name == "" {
+		return nil
+	}
+	namespace := identity.Spec.ClientSecret.Namespace
+	if namespace == "" {
+		namespace = identity.Namespace
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: identity.Spec.ClientSecret.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get AzureClusterIdentity secret")
+	}
+	if _, ok := secret.Labels[clusterctlv1.ClusterctlMoveLabel]; ok {
+		return nil
+	}
+
+	before := secret.DeepCopy()
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[clusterctlv1.ClusterctlMoveLabel] = "true"
+	if err := r.Patch(ctx, secret, client.MergeFrom(before)); err != nil {
+		return errors.Wrap(err, "failed to label AzureClusterIdentity secret")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestAzureClusterIdentityReconciler(t *testing.T) {
+	g := NewWithT(t)
+	scheme, err := newScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sameNamespaceFinalizer := clusterIdentityFinalizer(infrav1.ClusterFinalizer, "default", "my-cluster")
+	otherNamespaceFinalizer := clusterIdentityFinalizer(infrav1.ClusterFinalizer, "other", "my-cluster")
+	staleFinalizer := clusterIdentityFinalizer(infrav1.ClusterFinalizer, "default", "my-old-cluster")
+
+	identity := &infrav1.AzureClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-identity",
+			Namespace:  "default",
+			Finalizers: []string{sameNamespaceFinalizer, otherNamespaceFinalizer, staleFinalizer, "some.other/finalizer"},
+		},
+		Spec: infrav1.AzureClusterIdentitySpec{
+			Type:         infrav1.ServicePrincipal,
+			ClientID:     "fake-client-id",
+			TenantID:     "fake-tenant-id",
+			ClientSecret: corev1.SecretReference{Name: "my-identity-secret"},
+			AllowedNamespaces: &infrav1.AllowedNamespaces{
+				NamespaceList: []string{"default"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-identity-secret",
+			Namespace: "default",
+		},
+	}
+	sameNamespaceCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				IdentityRef: &corev1.ObjectReference{Name: "my-identity"},
+			},
+		},
+	}
+	otherNamespaceCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "other",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				IdentityRef: &corev1.ObjectReference{Name: "my-identity", Namespace: "default"},
+			},
+		},
+	}
+	otherIdentityCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-old-cluster",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				IdentityRef: &corev1.ObjectReference{Name: "my-other-identity"},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(identity, secret, sameNamespaceCluster, otherNamespaceCluster, otherIdentityCluster).
+		Build()
+	reconciler := &AzureClusterIdentityReconciler{Client: c, APIReader: c}
+
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(identity)})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(identity), identity)).To(Succeed())
+	g.Expect(identity.Status.ReferencedByClusters).To(Equal([]string{"default/my-cluster", "other/my-cluster"}))
+	g.Expect(identity.Finalizers).To(ConsistOf(sameNamespaceFinalizer, otherNamespaceFinalizer, "some.other/finalizer"))
+	g.Expect(identity.Labels).To(HaveKeyWithValue(clusterctlv1.ClusterctlMoveHierarchyLabel, "true"))
+
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	g.Expect(secret.Labels).To(HaveKeyWithValue(clusterctlv1.ClusterctlMoveLabel, "true"))
+
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(sameNamespaceCluster), sameNamespaceCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(sameNamespaceCluster, infrav1.IdentityAllowedCondition)).To(BeTrue())
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(otherNamespaceCluster), otherNamespaceCluster)).To(Succeed())
+	g.Expect(conditions.IsFalse(otherNamespaceCluster, infrav1.IdentityAllowedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(otherNamespaceCluster, infrav1.IdentityAllowedCondition)).To(Equal(infrav1.NamespaceNotAllowedByIdentity))
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(otherIdentityCluster), otherIdentityCluster)).To(Succeed())
+	g.Expect(conditions.Has(otherIdentityCluster, infrav1.IdentityAllowedCondition)).To(BeFalse())
+}
+
+func TestAzureClusterIdentityReconcilerNamespaceToAzureClusterIdentities(t *testing.T) {
+	g := NewWithT(t)
+	scheme, err := newScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newIdentity := func(name string, labels map[string]string, allowedNamespaces *infrav1.AllowedNamespaces) *infrav1.AzureClusterIdentity {
+		return &infrav1.AzureClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec:       infrav1.AzureClusterIdentitySpec{AllowedNamespaces: allowedNamespaces},
+		}
+	}
+	selector := &infrav1.AllowedNamespaces{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}
+	watchLabels := map[string]string{clusterv1.WatchLabel: "my-filter"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newIdentity("selector-watched", watchLabels, selector),
+			newIdentity("selector-not-watched", nil, selector),
+			newIdentity("list-watched", watchLabels, &infrav1.AllowedNamespaces{NamespaceList: []string{"default"}}),
+		).
+		Build()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-namespace"}}
+
+	reconciler := &AzureClusterIdentityReconciler{Client: c}
+	requests := reconciler.namespaceToAzureClusterIdentities(context.Background())(namespace)
+	g.Expect(requests).To(ConsistOf(
+		reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "selector-watched"}},
+		reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "selector-not-watched"}},
+	))
+
+	reconciler.WatchFilterValue = "my-filter"
+	requests = reconciler.namespaceToAzureClusterIdentities(context.Background())(namespace)
+	g.Expect(requests).To(ConsistOf(
+		reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "selector-watched"}},
+	))
+}
@@ -33,6 +33,7 @@ import (
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -245,6 +246,7 @@ func newScheme() (*runtime.Scheme, error) {
 		clusterv1.AddToScheme,
 		infrav1exp.AddToScheme,
 		expv1.AddToScheme,
+		ipamv1.AddToScheme,
 	}
 	for _, fn := range schemeFn {
 		fn := fn
@@ -29,6 +29,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -52,17 +54,20 @@ type AzureMachineReconciler struct {
 	ReconcileTimeout          time.Duration
 	WatchFilterValue          string
 	createAzureMachineService azureMachineServiceCreator
+	// ServiceOptions configure how the services reconcile Azure resources.
+	ServiceOptions scope.ServiceOptions
 }
 
 type azureMachineServiceCreator func(machineScope *scope.MachineScope) (*azureMachineService, error)
 
 // NewAzureMachineReconciler returns a new AzureMachineReconciler instance.
-func NewAzureMachineReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureMachineReconciler {
+func NewAzureMachineReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serviceOptions scope.ServiceOptions) *AzureMachineReconciler {
 	amr := &AzureMachineReconciler{
 		Client:           client,
 		Recorder:         recorder,
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		ServiceOptions:   serviceOptions,
 	}
 
 	amr.createAzureMachineService = newAzureMachineService
@@ -89,9 +94,9 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		return errors.Wrap(err, "failed to create AzureCluster to AzureMachines mapper")
 	}
 
-	c, err := ctrl.NewControllerManagedBy(mgr).
-		WithOptions(options.Options).
-		For(&infrav1.AzureMachine{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options.Options)
+	c, err := ForWithPriority(b, &infrav1.AzureMachine{}, "AzureMachine", options.LowPriorityDelay).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, amr.WatchFilterValue)).
 		// watch for changes in CAPI Machine resources
 		Watches(
@@ -131,6 +136,8 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 
 // Reconcile idempotently gets, creates, and updates a machine.
 func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -179,10 +186,15 @@ func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	log = log.WithValues("cluster", cluster.Name)
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, azureMachine) {
-		log.Info("AzureMachine or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+	// Return early if the object or Cluster is paused. Long running operations which are still in flight are only
+	// polled until they complete, so that clusterctl move doesn't leave them behind.
+	paused := annotations.IsPaused(cluster, azureMachine)
+	if paused {
+		if !futures.InFlight(azureMachine) {
+			log.Info("AzureMachine or linked Cluster is marked as paused. Won't reconcile")
+			return ctrl.Result{}, nil
+		}
+		log.Info("AzureMachine or linked Cluster is marked as paused. Polling in-flight operations until they complete")
 	}
 
 	log = log.WithValues("AzureCluster", cluster.Spec.InfrastructureRef.Name)
@@ -199,9 +211,10 @@ func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Create the cluster scope
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
-		Client:       amr.Client,
-		Cluster:      cluster,
-		AzureCluster: azureCluster,
+		Client:         amr.Client,
+		Cluster:        cluster,
+		AzureCluster:   azureCluster,
+		ServiceOptions: amr.ServiceOptions,
 	})
 	if err != nil {
 		amr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "Error creating the cluster scope", err.Error())
@@ -227,6 +240,10 @@ func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}()
 
+	if paused {
+		return PollFuturesWhilePaused(ctx, azureMachine, clusterScope)
+	}
+
 	// Handle deleted machines
 	if !azureMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 		return amr.reconcileDelete(ctx, machineScope, clusterScope)
@@ -255,6 +272,15 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		}
 	}
 
+	if skipsAzureReconcile(machineScope.AzureMachine) || skipsAzureReconcile(clusterScope.AzureCluster) {
+		log.Info("Skipping reconciliation of Azure resources because of the reconcile policy")
+		return reconcile.Result{}, nil
+	}
+
+	if result, throttled := throttledSubscription(ctx, machineScope.SubscriptionID(), amr.ServiceOptions.ARMRateLimitRemainingThreshold); throttled {
+		return result, nil
+	}
+
 	// Make sure the Cluster Infrastructure is ready.
 	if !clusterScope.Cluster.Status.InfrastructureReady {
 		log.Info("Cluster infrastructure is not ready yet")
@@ -269,6 +295,14 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, nil
 	}
 
+	// Claim private IPs from IPAM providers before creating the network interfaces.
+	if ready, err := reconcileMachineIPAddressClaims(ctx, amr.Client, machineScope); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile IP address claims")
+	} else if !ready {
+		log.Info("Waiting for IPAM provider to allocate IP addresses")
+		return reconcile.Result{RequeueAfter: ipAddressRequeueInterval}, nil
+	}
+
 	var reconcileError azure.ReconcileError
 
 	// Initialize the cache to be used by the AzureMachine services.
@@ -285,6 +319,19 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to init machine scope cache")
 	}
 
+	// Choose a failure domain for machines which don't set one with their failure domain policy.
+	if err := machineScope.AssignFailureDomain(ctx); err != nil {
+		if errors.As(err, &reconcileError) && reconcileError.IsTerminal() {
+			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "FailureDomainNotFound", errors.Wrap(err, "failed to assign failure domain").Error())
+			log.Error(err, "Failed to assign failure domain")
+			machineScope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
+			machineScope.SetFailureMessage(err)
+			machineScope.SetNotReady()
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to assign failure domain")
+	}
+
 	// Mark the AzureMachine as failed if the identities are not ready.
 	cond := conditions.Get(machineScope.AzureMachine, infrav1.VMIdentitiesReadyCondition)
 	if cond != nil && cond.Status == corev1.ConditionFalse && cond.Reason == infrav1.UserAssignedIdentityMissingReason {
@@ -320,6 +367,7 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 				machineScope.SetFailureMessage(err)
 				machineScope.SetNotReady()
 				machineScope.SetVMState(infrav1.Failed)
+				machineScope.Notify(notify.ProvisionFailed, err.Error())
 				return reconcile.Result{}, nil
 			}
 
@@ -336,6 +384,9 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile AzureMachine")
 	}
 
+	if !machineScope.AzureMachine.Status.Ready {
+		machineScope.Notify(notify.InfrastructureReady, "machine infrastructure is ready")
+	}
 	machineScope.SetReady()
 
 	return reconcile.Result{}, nil
@@ -351,7 +402,13 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 		return reconcile.Result{}, err
 	}
 
-	if ShouldDeleteIndividualResources(ctx, clusterScope) {
+	if skipsAzureDelete(machineScope.AzureMachine) || skipsAzureDelete(clusterScope.AzureCluster) {
+		log.Info("Skipping AzureMachine Deletion because of the reconcile policy")
+	} else if ShouldDeleteIndividualResources(ctx, clusterScope) {
+		if result, throttled := throttledSubscription(ctx, machineScope.SubscriptionID(), amr.ServiceOptions.ARMRateLimitRemainingThreshold); throttled {
+			return result, nil
+		}
+
 		log.Info("Deleting AzureMachine")
 		ams, err := amr.createAzureMachineService(machineScope)
 		if err != nil {
@@ -382,6 +439,7 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 	// we're done deleting this AzureMachine so remove the finalizer.
 	log.Info("Removing finalizer from AzureMachine")
 	controllerutil.RemoveFinalizer(machineScope.AzureMachine, infrav1.MachineFinalizer)
+	machineScope.Notify(notify.DeletionComplete, "machine infrastructure has been deleted")
 
 	return reconcile.Result{}, nil
 }
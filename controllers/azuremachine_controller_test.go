@@ -270,6 +270,20 @@ func TestAzureMachineReconcileDelete(t *testing.T) {
 			cache:                     &scope.MachineCache{},
 			expectedErr:               "error deleting AzureMachine",
 		},
+		"should not delete Azure resources with the Abandon delete policy": {
+			createAzureMachineService: getFakeAzureMachineServiceWithGeneralError,
+			azureMachineOptions: func(am *infrav1.AzureMachine) {
+				am.Annotations = map[string]string{infrav1.DeletePolicyAnnotation: string(infrav1.DeletePolicyAbandon)}
+			},
+			cache: &scope.MachineCache{},
+		},
+		"should not delete Azure resources with the Skip reconcile policy": {
+			createAzureMachineService: getFakeAzureMachineServiceWithGeneralError,
+			azureMachineOptions: func(am *infrav1.AzureMachine) {
+				am.Annotations = map[string]string{infrav1.ReconcilePolicyAnnotation: string(infrav1.ReconcilePolicySkip)}
+			},
+			cache: &scope.MachineCache{},
+		},
 	}
 
 	for name, c := range cases {
@@ -670,7 +684,7 @@ func TestConditions(t *testing.T) {
 			client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
 			recorder := record.NewFakeRecorder(10)
 
-			reconciler := NewAzureMachineReconciler(client, recorder, reconciler.DefaultLoopTimeout, "")
+			reconciler := NewAzureMachineReconciler(client, recorder, reconciler.DefaultLoopTimeout, "", scope.ServiceOptions{})
 
 			clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
 				AzureClients: scope.AzureClients{
@@ -53,7 +53,7 @@ func TestAzureMachineServiceReconcile(t *testing.T) {
 				gomock.InOrder(
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("foo"))
+					two.Name().Return("foo").AnyTimes())
 			},
 		},
 	}
@@ -71,6 +71,10 @@ func TestAzureMachineServiceReconcile(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			// The name of each service is also used for its metrics.
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachineService{
 				scope: &scope.MachineScope{
@@ -124,7 +128,7 @@ func TestAzureMachineServiceDelete(t *testing.T) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("test-service-two"))
+					two.Name().Return("test-service-two").AnyTimes())
 			},
 		},
 	}
@@ -142,6 +146,10 @@ func TestAzureMachineServiceDelete(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			// The name of each service is also used for its metrics.
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachineService{
 				scope: &scope.MachineScope{
@@ -29,6 +29,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -51,6 +53,10 @@ type AzureManagedControlPlaneReconciler struct {
 	Recorder         record.EventRecorder
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// ManagementClusterEgressIPRanges are the IP ranges the management cluster reaches the AKS API servers from.
+	ManagementClusterEgressIPRanges []string
+	// ServiceOptions configure how the services reconcile Azure resources.
+	ServiceOptions scope.ServiceOptions
 }
 
 // SetupWithManager initializes this controller with a manager.
@@ -76,9 +82,9 @@ func (amcpr *AzureManagedControlPlaneReconciler) SetupWithManager(ctx context.Co
 	// map requests for machine pools corresponding to AzureManagedControlPlane's defaultPool back to the corresponding AzureManagedControlPlane.
 	azureManagedMachinePoolMapper := MachinePoolToAzureManagedControlPlaneMapFunc(ctx, amcpr.Client, infrav1.GroupVersion.WithKind("AzureManagedControlPlane"), log)
 
-	c, err := ctrl.NewControllerManagedBy(mgr).
-		WithOptions(options.Options).
-		For(azManagedControlPlane).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options.Options)
+	c, err := ForWithPriority(b, azManagedControlPlane, "AzureManagedControlPlane", options.LowPriorityDelay).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, amcpr.WatchFilterValue)).
 		// watch AzureManagedCluster resources
 		Watches(
@@ -146,10 +152,15 @@ func (amcpr *AzureManagedControlPlaneReconciler) Reconcile(ctx context.Context,
 
 	log = log.WithValues("cluster", cluster.Name)
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, azureControlPlane) {
-		log.Info("AzureManagedControlPlane or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+	// Return early if the object or Cluster is paused. Long running operations which are still in flight are only
+	// polled until they complete, so that clusterctl move doesn't leave them behind.
+	paused := annotations.IsPaused(cluster, azureControlPlane)
+	if paused {
+		if !futures.InFlight(azureControlPlane) {
+			log.Info("AzureManagedControlPlane or linked Cluster is marked as paused. Won't reconcile")
+			return ctrl.Result{}, nil
+		}
+		log.Info("AzureManagedControlPlane or linked Cluster is marked as paused. Polling in-flight operations until they complete")
 	}
 
 	// Fetch all the ManagedMachinePools owned by this Cluster.
@@ -197,6 +208,9 @@ func (amcpr *AzureManagedControlPlaneReconciler) Reconcile(ctx context.Context,
 		Cluster:             cluster,
 		ControlPlane:        azureControlPlane,
 		ManagedMachinePools: pools,
+
+		ManagementClusterEgressIPRanges: amcpr.ManagementClusterEgressIPRanges,
+		ServiceOptions:                  amcpr.ServiceOptions,
 	})
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create scope")
@@ -209,6 +223,10 @@ func (amcpr *AzureManagedControlPlaneReconciler) Reconcile(ctx context.Context,
 		}
 	}()
 
+	if paused {
+		return PollFuturesWhilePaused(ctx, azureControlPlane, mcpScope)
+	}
+
 	// Handle deleted clusters
 	if !azureControlPlane.DeletionTimestamp.IsZero() {
 		return amcpr.reconcileDelete(ctx, mcpScope)
@@ -233,6 +251,10 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 		}
 	}
 
+	if err := scope.InitManagedControlPlaneCache(ctx); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to init AzureManagedControlPlane cache")
+	}
+
 	if err := newAzureManagedControlPlaneReconciler(scope).Reconcile(ctx); err != nil {
 		// Handle transient and terminal errors
 		log := log.WithValues("name", scope.ControlPlane.Name, "namespace", scope.ControlPlane.Namespace)
@@ -240,6 +262,7 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
 				log.Error(err, "failed to reconcile AzureManagedControlPlane")
+				scope.Notify(notify.ProvisionFailed, err.Error())
 				return reconcile.Result{}, nil
 			}
 
@@ -255,6 +278,9 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 	}
 
 	// No errors, so mark us ready so the Cluster API Cluster Controller can pull it
+	if !scope.ControlPlane.Status.Ready {
+		scope.Notify(notify.InfrastructureReady, "managed control plane infrastructure is ready")
+	}
 	scope.ControlPlane.Status.Ready = true
 	scope.ControlPlane.Status.Initialized = true
 
@@ -285,6 +311,7 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileDelete(ctx context.Con
 
 	// Cluster is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(scope.ControlPlane, infrav1.ManagedClusterFinalizer)
+	scope.Notify(notify.DeletionComplete, "managed control plane infrastructure has been deleted")
 
 	if scope.ControlPlane.Spec.IdentityRef != nil {
 		err := RemoveClusterIdentityFinalizer(ctx, amcpr.Client, scope.ControlPlane, scope.ControlPlane.Spec.IdentityRef, infrav1.ManagedClusterFinalizer)
@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcehealth"
@@ -52,6 +53,7 @@ func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope
 			virtualnetworks.New(scope),
 			subnets.New(scope),
 			managedclusters.New(scope),
+			maintenanceconfigurations.New(scope),
 			privateendpoints.New(scope),
 			tags.New(scope),
 			resourcehealth.New(scope),
@@ -65,7 +67,7 @@ func (r *azureManagedControlPlaneService) Reconcile(ctx context.Context) error {
 	defer done()
 
 	for _, service := range r.services {
-		if err := service.Reconcile(ctx); err != nil {
+		if err := ReconcileService(ctx, service); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureManagedControlPlane service %s", service.Name())
 		}
 	}
@@ -84,7 +86,7 @@ func (r *azureManagedControlPlaneService) Delete(ctx context.Context) error {
 
 	// Delete services in reverse order of creation.
 	for i := len(r.services) - 1; i >= 0; i-- {
-		if err := r.services[i].Delete(ctx); err != nil {
+		if err := DeleteService(ctx, r.services[i]); err != nil {
 			return errors.Wrapf(err, "failed to delete AzureManagedControlPlane service %s", r.services[i].Name())
 		}
 	}
@@ -93,7 +95,7 @@ func (r *azureManagedControlPlaneService) Delete(ctx context.Context) error {
 }
 
 func (r *azureManagedControlPlaneService) reconcileKubeconfig(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.reconcileKubeconfig")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.reconcileKubeconfig")
 	defer done()
 
 	kubeConfigData := r.scope.GetKubeConfigData()
@@ -102,15 +104,21 @@ func (r *azureManagedControlPlaneService) reconcileKubeconfig(ctx context.Contex
 	}
 	kubeConfigSecret := r.scope.MakeEmptyKubeConfigSecret()
 
-	// Always update credentials in case of rotation
-	if _, err := controllerutil.CreateOrUpdate(ctx, r.kubeclient, &kubeConfigSecret, func() error {
+	// Always update credentials in case of rotation. The kubeconfig changes when the certificates of the cluster are
+	// rotated, and when its local accounts are disabled or enabled again, as the AAD user kubeconfig is used instead
+	// of the admin one.
+	result, err := controllerutil.CreateOrUpdate(ctx, r.kubeclient, &kubeConfigSecret, func() error {
 		kubeConfigSecret.Data = map[string][]byte{
 			secret.KubeconfigDataName: kubeConfigData,
 		}
 		return nil
-	}); err != nil {
+	})
+	if err != nil {
 		return errors.Wrap(err, "failed to kubeconfig secret for cluster")
 	}
+	if result == controllerutil.OperationResultUpdated {
+		log.Info("rotated kubeconfig secret", "secret", kubeConfigSecret.Name)
+	}
 
 	return nil
 }
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters/mock_managedclusters"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAzureManagedControlPlaneServiceReconcileKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	emptySecret := func() corev1.Secret {
+		return corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster-kubeconfig",
+				Namespace: "default",
+			},
+		}
+	}
+	existing := emptySecret()
+	existing.Data = map[string][]byte{secret.KubeconfigDataName: []byte("admin-kubeconfig")}
+	kubeclient := fake.NewClientBuilder().WithObjects(&existing).Build()
+
+	scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+	scopeMock.EXPECT().GetKubeConfigData().Return([]byte("user-kubeconfig"))
+	scopeMock.EXPECT().MakeEmptyKubeConfigSecret().Return(emptySecret())
+
+	r := &azureManagedControlPlaneService{
+		kubeclient: kubeclient,
+		scope:      scopeMock,
+	}
+	g.Expect(r.reconcileKubeconfig(context.TODO())).To(Succeed())
+
+	rotated := &corev1.Secret{}
+	g.Expect(kubeclient.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "my-cluster-kubeconfig"}, rotated)).To(Succeed())
+	g.Expect(rotated.Data).To(HaveKeyWithValue(secret.KubeconfigDataName, []byte("user-kubeconfig")))
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -50,17 +51,20 @@ type AzureManagedMachinePoolReconciler struct {
 	ReconcileTimeout                     time.Duration
 	WatchFilterValue                     string
 	createAzureManagedMachinePoolService azureManagedMachinePoolServiceCreator
+	// ServiceOptions configure how the services reconcile Azure resources.
+	ServiceOptions scope.ServiceOptions
 }
 
 type azureManagedMachinePoolServiceCreator func(managedMachinePoolScope *scope.ManagedMachinePoolScope) (*azureManagedMachinePoolService, error)
 
 // NewAzureManagedMachinePoolReconciler returns a new AzureManagedMachinePoolReconciler instance.
-func NewAzureManagedMachinePoolReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureManagedMachinePoolReconciler {
+func NewAzureManagedMachinePoolReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serviceOptions scope.ServiceOptions) *AzureManagedMachinePoolReconciler {
 	ampr := &AzureManagedMachinePoolReconciler{
 		Client:           client,
 		Recorder:         recorder,
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		ServiceOptions:   serviceOptions,
 	}
 
 	ampr.createAzureManagedMachinePoolService = newAzureManagedMachinePoolService
@@ -169,10 +173,15 @@ func (ammpr *AzureManagedMachinePoolReconciler) Reconcile(ctx context.Context, r
 
 	log = log.WithValues("ownerCluster", ownerCluster.Name)
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(ownerCluster, infraPool) {
-		log.Info("AzureManagedMachinePool or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+	// Return early if the object or Cluster is paused. Long running operations which are still in flight are only
+	// polled until they complete, so that clusterctl move doesn't leave them behind.
+	paused := annotations.IsPaused(ownerCluster, infraPool)
+	if paused {
+		if !futures.InFlight(infraPool) {
+			log.Info("AzureManagedMachinePool or linked Cluster is marked as paused. Won't reconcile")
+			return ctrl.Result{}, nil
+		}
+		log.Info("AzureManagedMachinePool or linked Cluster is marked as paused. Polling in-flight operations until they complete")
 	}
 
 	// Fetch the corresponding control plane which has all the interesting data.
@@ -193,11 +202,18 @@ func (ammpr *AzureManagedMachinePoolReconciler) Reconcile(ctx context.Context, r
 		return reconcile.Result{}, nil
 	}
 
+	// The agent pools of a stopped managed cluster can't be updated, they are reconciled again once it is started.
+	if controlPlane.Status.PowerState == infrav1.PowerStateStopped && ownerCluster.DeletionTimestamp.IsZero() {
+		log.Info("AzureManagedControlPlane is stopped")
+		return reconcile.Result{}, nil
+	}
+
 	// create the managed control plane scope
 	managedControlPlaneScope, err := scope.NewManagedControlPlaneScope(ctx, scope.ManagedControlPlaneScopeParams{
-		Client:       ammpr.Client,
-		ControlPlane: controlPlane,
-		Cluster:      ownerCluster,
+		Client:         ammpr.Client,
+		ControlPlane:   controlPlane,
+		Cluster:        ownerCluster,
+		ServiceOptions: ammpr.ServiceOptions,
 	})
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create ManagedControlPlane scope")
@@ -228,6 +244,10 @@ func (ammpr *AzureManagedMachinePoolReconciler) Reconcile(ctx context.Context, r
 		}
 	}()
 
+	if paused {
+		return PollFuturesWhilePaused(ctx, infraPool, managedControlPlaneScope)
+	}
+
 	// Handle deleted clusters
 	if !infraPool.DeletionTimestamp.IsZero() {
 		return ammpr.reconcileDelete(ctx, mcpScope)
@@ -71,6 +71,7 @@ func TestAzureManagedMachinePoolReconcile(t *testing.T) {
 				agentpools.SetAgentPoolProviderIDList(providerIDs)
 				agentpools.SetAgentPoolReplicas(int32(len(providerIDs))).Return()
 				agentpools.SetAgentPoolReady(true).Return()
+				agentpools.SetAgentPoolOperationStatus(infrav1.Succeeded, int32(0), int32(2)).Return()
 
 				nodelister.List(gomock2.AContext(), "fake-rg").Return(fakeVirtualMachineScaleSet, nil)
 				nodelister.ListInstances(gomock2.AContext(), "fake-rg", "vmssName").Return(fakeVirtualMachineScaleSetVM, nil)
@@ -124,7 +125,7 @@ func TestAzureManagedMachinePoolReconcile(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			c.Setup(cb, reconciler.EXPECT(), agentpools.EXPECT(), nodelister.EXPECT())
-			controller := NewAzureManagedMachinePoolReconciler(cb.Build(), nil, 30*time.Second, "foo")
+			controller := NewAzureManagedMachinePoolReconciler(cb.Build(), nil, 30*time.Second, "foo", scope.ServiceOptions{})
 			controller.createAzureManagedMachinePoolService = func(_ *scope.ManagedMachinePoolScope) (*azureManagedMachinePoolService, error) {
 				return &azureManagedMachinePoolService{
 					scope:         agentpools,
@@ -23,7 +23,9 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
 	azprovider "sigs.k8s.io/cloud-provider-azure/pkg/provider"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
@@ -82,7 +84,7 @@ func newAzureManagedMachinePoolService(scope *scope.ManagedMachinePoolScope) (*a
 	return &azureManagedMachinePoolService{
 		scope:         scope,
 		agentPoolsSvc: agentpools.New(scope),
-		scaleSetsSvc:  scalesets.NewClient(scaleSetAuthorizer),
+		scaleSetsSvc:  scalesets.NewClient(scaleSetAuthorizer, nil),
 	}, nil
 }
 
@@ -139,8 +141,15 @@ func (s *azureManagedMachinePoolService) Reconcile(ctx context.Context) error {
 		return errors.Wrapf(err, "failed to reconcile machine pool %s", agentPoolName)
 	}
 
+	var upToDate int32
 	var providerIDs = make([]string, len(instances))
 	for i := 0; i < len(instances); i++ {
+		if props := instances[i].VirtualMachineScaleSetVMProperties; props != nil &&
+			pointer.BoolDeref(props.LatestModelApplied, false) &&
+			pointer.StringDeref(props.ProvisioningState, "") == string(infrav1.Succeeded) {
+			upToDate++
+		}
+
 		// Transform the VMSS instance resource representation to conform to the cloud-provider-azure representation
 		providerID, err := azprovider.ConvertResourceGroupNameToLower(azure.ProviderIDPrefix + *instances[i].ID)
 		if err != nil {
@@ -153,6 +162,16 @@ func (s *azureManagedMachinePoolService) Reconcile(ctx context.Context) error {
 	s.scope.SetAgentPoolReplicas(int32(len(providerIDs)))
 	s.scope.SetAgentPoolReady(true)
 
+	var state infrav1.ProvisioningState
+	if match.VirtualMachineScaleSetProperties != nil {
+		state = infrav1.ProvisioningState(pointer.StringDeref(match.VirtualMachineScaleSetProperties.ProvisioningState, ""))
+	}
+	var desired int32
+	if match.Sku != nil {
+		desired = int32(pointer.Int64Deref(match.Sku.Capacity, 0))
+	}
+	s.scope.SetAgentPoolOperationStatus(state, upToDate, desired)
+
 	log.Info("reconciled managed machine pool successfully")
 	return nil
 }
@@ -22,10 +22,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,10 +40,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/throttle"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -64,6 +71,11 @@ const (
 	deprecatedManagerCredsWarning = "You're using deprecated functionality: " +
 		"Using Azure credentials from the manager environment is deprecated and will be removed in future releases. " +
 		"Please specify an AzureClusterIdentity for the AzureCluster instead, see: https://capz.sigs.k8s.io/topics/multitenancy.html "
+	// cloudProviderBackoffModeV2 is the back-off mode of the external cloud provider, which retries on the Azure SDK
+	// level and honors the Retry-After headers of Azure.
+	cloudProviderBackoffModeV2 = "v2"
+	// defaultAADClientCertPath is the path of the client certificate used by the cloud provider when none is specified.
+	defaultAADClientCertPath = "/etc/kubernetes/certs/aad-client-cert.pfx"
 )
 
 type (
@@ -71,6 +83,9 @@ type (
 	Options struct {
 		controller.Options
 		Cache *coalescing.ReconcileCache
+		// LowPriorityDelay is how long the requests of low priority events, such as periodic resyncs, are delayed.
+		// Events are not prioritized when it is zero.
+		LowPriorityDelay time.Duration
 	}
 )
 
@@ -174,6 +189,34 @@ func referSameObject(a, b metav1.OwnerReference) bool {
 	return aGV.Group == bGV.Group && a.Kind == b.Kind && a.Name == b.Name
 }
 
+// skipsAzureReconcile returns true if the reconcile policy of obj forbids creating or updating its Azure resources.
+func skipsAzureReconcile(obj metav1.Object) bool {
+	return infrav1.ReconcilePolicy(obj.GetAnnotations()[infrav1.ReconcilePolicyAnnotation]) == infrav1.ReconcilePolicySkip
+}
+
+// throttledSubscription returns true, with the result requeueing the reconcile, if the reconciles of the Azure
+// resources of a subscription are throttled because it is close to exhausting its Azure Resource Manager requests, i.e.
+// it has fewer remaining requests than threshold.
+func throttledSubscription(ctx context.Context, subscriptionID string, threshold int64) (reconcile.Result, bool) {
+	requeueAfter, throttled := throttle.RequeueAfter(subscriptionID, threshold)
+	if !throttled {
+		return reconcile.Result{}, false
+	}
+	_, log, done := tele.StartSpanWithLogger(ctx, "controllers.throttledSubscription")
+	defer done()
+
+	log.Info("Throttling the reconciliation of Azure resources because the subscription is close to its Azure Resource Manager request limit", "subscriptionID", subscriptionID, "requeueAfter", requeueAfter)
+	return reconcile.Result{RequeueAfter: requeueAfter}, true
+}
+
+// skipsAzureDelete returns true if the delete policy of obj requires leaving its Azure resources behind when it is
+// deleted, or if its reconcile policy skips its Azure resources altogether.
+func skipsAzureDelete(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	return infrav1.DeletePolicy(annotations[infrav1.DeletePolicyAnnotation]) == infrav1.DeletePolicyAbandon ||
+		infrav1.ReconcilePolicy(annotations[infrav1.ReconcilePolicyAnnotation]) == infrav1.ReconcilePolicySkip
+}
+
 // GetCloudProviderSecret returns the required azure json secret for the provided parameters.
 func GetCloudProviderSecret(d azure.ClusterScoper, namespace, name string, owner metav1.OwnerReference, identityType infrav1.VMIdentity, userIdentityID string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{
@@ -199,6 +242,8 @@ func GetCloudProviderSecret(d azure.ClusterScoper, namespace, name string, owner
 		controlPlaneConfig, workerNodeConfig = userAssignedIdentityCloudProviderConfig(d, userIdentityID)
 	case infrav1.VMIdentityNone:
 		controlPlaneConfig, workerNodeConfig = newCloudProviderConfig(d)
+		controlPlaneConfig = controlPlaneConfig.overrideCredentialsFromSpec(d)
+		workerNodeConfig = workerNodeConfig.overrideCredentialsFromSpec(d)
 	}
 
 	// Enable VMSS Flexible nodes if MachinePools are enabled
@@ -211,11 +256,29 @@ func GetCloudProviderSecret(d azure.ClusterScoper, namespace, name string, owner
 		}
 	}
 
-	controlPlaneData, err := json.MarshalIndent(controlPlaneConfig, "", "    ")
+	var extraConfig map[string]apiextensionsv1.JSON
+	var mode infrav1.CloudProviderConfigMode
+	if d.CloudProviderConfigOverrides() != nil {
+		extraConfig = d.CloudProviderConfigOverrides().ExtraConfig
+		mode = d.CloudProviderConfigOverrides().Mode
+	}
+
+	// With the external cloud provider only the cloud-controller-manager talks to Azure, so the kubelets don't get
+	// any credentials.
+	var cloudControllerManagerConfig *CloudProviderConfig
+	if mode == infrav1.ExternalCloudProviderConfigMode && controlPlaneConfig != nil && workerNodeConfig != nil {
+		cloudControllerManagerConfig = controlPlaneConfig
+		cloudControllerManagerConfig.CloudProviderBackoffMode = cloudProviderBackoffModeV2
+		controlPlaneConfig = controlPlaneConfig.withoutCredentials()
+		workerNodeConfig = workerNodeConfig.withoutCredentials()
+		workerNodeConfig.CloudProviderBackoffMode = cloudProviderBackoffModeV2
+	}
+
+	controlPlaneData, err := marshalCloudProviderConfig(controlPlaneConfig, extraConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed control plane json marshal")
 	}
-	workerNodeData, err := json.MarshalIndent(workerNodeConfig, "", "    ")
+	workerNodeData, err := marshalCloudProviderConfig(workerNodeConfig, extraConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed worker node json marshal")
 	}
@@ -227,9 +290,44 @@ func GetCloudProviderSecret(d azure.ClusterScoper, namespace, name string, owner
 		"azure.json": controlPlaneData,
 	}
 
+	if cloudControllerManagerConfig != nil {
+		cloudControllerManagerData, err := marshalCloudProviderConfig(cloudControllerManagerConfig, extraConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed cloud controller manager json marshal")
+		}
+		secret.Data["cloud-controller-manager-azure.json"] = cloudControllerManagerData
+	}
+
 	return secret, nil
 }
 
+// marshalCloudProviderConfig returns the indented JSON of the cloud provider config, with the extra config values
+// provided in cluster spec merged into it. Keys are matched case-insensitively, like the cloud provider does when it
+// reads the config.
+func marshalCloudProviderConfig(cpc *CloudProviderConfig, extraConfig map[string]apiextensionsv1.JSON) ([]byte, error) {
+	if len(extraConfig) == 0 {
+		return json.MarshalIndent(cpc, "", "    ")
+	}
+
+	data, err := json.Marshal(cpc)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	for extraKey, value := range extraConfig {
+		for key := range config {
+			if strings.EqualFold(key, extraKey) {
+				delete(config, key)
+			}
+		}
+		config[extraKey] = value.Raw
+	}
+	return json.MarshalIndent(config, "", "    ")
+}
+
 func systemAssignedIdentityCloudProviderConfig(d azure.ClusterScoper) (cpConfig *CloudProviderConfig, wkConfig *CloudProviderConfig) {
 	controlPlaneConfig, workerConfig := newCloudProviderConfig(d)
 	controlPlaneConfig.AadClientID = ""
@@ -321,6 +419,7 @@ type CloudProviderConfig struct {
 	SubscriptionID               string `json:"subscriptionId"`
 	AadClientID                  string `json:"aadClientId,omitempty"`
 	AadClientSecret              string `json:"aadClientSecret,omitempty"`
+	AadClientCertPath            string `json:"aadClientCertPath,omitempty"`
 	ResourceGroup                string `json:"resourceGroup"`
 	SecurityGroupName            string `json:"securityGroupName"`
 	SecurityGroupResourceGroup   string `json:"securityGroupResourceGroup"`
@@ -339,10 +438,46 @@ type CloudProviderConfig struct {
 	UseInstanceMetadata          bool   `json:"useInstanceMetadata"`
 	EnableVmssFlexNodes          bool   `json:"enableVmssFlexNodes,omitempty"`
 	UserAssignedIdentityID       string `json:"userAssignedIdentityID,omitempty"`
+	CloudProviderBackoffMode     string `json:"cloudProviderBackoffMode,omitempty"`
 	CloudProviderRateLimitConfig
 	BackOffConfig
 }
 
+// overrideCredentialsFromSpec replaces the client secret of the cloud provider config with the credentials provided in
+// cluster spec, so that the client secret isn't written to the disk of the VMs.
+func (cpc *CloudProviderConfig) overrideCredentialsFromSpec(d azure.ClusterScoper) *CloudProviderConfig {
+	if d.CloudProviderConfigOverrides() == nil || d.CloudProviderConfigOverrides().Credentials == nil {
+		return cpc
+	}
+
+	credentials := d.CloudProviderConfigOverrides().Credentials
+	switch credentials.Type {
+	case infrav1.ClientCertificateCloudProviderCredentials:
+		cpc.AadClientSecret = ""
+		cpc.AadClientCertPath = credentials.AADClientCertPath
+		if cpc.AadClientCertPath == "" {
+			cpc.AadClientCertPath = defaultAADClientCertPath
+		}
+	case infrav1.ManagedIdentityCloudProviderCredentials:
+		cpc.AadClientID = ""
+		cpc.AadClientSecret = ""
+		cpc.UseManagedIdentityExtension = true
+		cpc.UserAssignedIdentityID = credentials.UserAssignedIdentityID
+	}
+	return cpc
+}
+
+// withoutCredentials returns a copy of the cloud provider config without any credentials.
+func (cpc *CloudProviderConfig) withoutCredentials() *CloudProviderConfig {
+	config := *cpc
+	config.AadClientID = ""
+	config.AadClientSecret = ""
+	config.AadClientCertPath = ""
+	config.UseManagedIdentityExtension = false
+	config.UserAssignedIdentityID = ""
+	return &config
+}
+
 // overrideFromSpec overrides cloud provider config with the values provided in cluster spec.
 func (cpc *CloudProviderConfig) overrideFromSpec(d azure.ClusterScoper) *CloudProviderConfig {
 	if d.CloudProviderConfigOverrides() == nil {
@@ -593,6 +728,23 @@ func GetAzureMachinePoolByName(ctx context.Context, c client.Client, namespace,
 	return m, nil
 }
 
+// PollFuturesWhilePaused polls the long running operations in flight of a paused object, without reconciling any Azure
+// resource, and requeues until they complete, so that clusterctl move can move the object once they are done.
+func PollFuturesWhilePaused(ctx context.Context, obj futures.Setter, auth azure.Authorizer) (reconcile.Result, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.PollFuturesWhilePaused")
+	defer done()
+
+	client := autorest.NewClientWithUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&client, auth.Authorizer())
+	if err := async.PollFutures(ctx, obj, &client); err != nil {
+		return reconcile.Result{}, err
+	}
+	if futures.InFlight(obj) {
+		return reconcile.Result{RequeueAfter: reconciler.DefaultReconcilerRequeue}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
 // ShouldDeleteIndividualResources returns false if the resource group is managed and the whole cluster is being deleted
 // meaning that we can rely on a single resource group delete operation as opposed to deleting every individual VM resource.
 func ShouldDeleteIndividualResources(ctx context.Context, clusterScope *scope.ClusterScope) bool {
@@ -30,6 +30,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -111,6 +112,7 @@ func TestGetCloudProviderConfig(t *testing.T) {
 		machinePoolFeature         bool
 		expectedControlPlaneConfig string
 		expectedWorkerNodeConfig   string
+		expectedCCMConfig          string
 	}{
 		"serviceprincipal": {
 			cluster:                    cluster,
@@ -155,6 +157,42 @@ func TestGetCloudProviderConfig(t *testing.T) {
 			expectedControlPlaneConfig: backOffCloudConfig,
 			expectedWorkerNodeConfig:   backOffCloudConfig,
 		},
+		"with client certificate credentials": {
+			cluster:                    cluster,
+			azureCluster:               withCredentials(*azureCluster, infrav1.CloudProviderCredentials{Type: infrav1.ClientCertificateCloudProviderCredentials}),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: clientCertificateCloudConfig,
+			expectedWorkerNodeConfig:   clientCertificateCloudConfig,
+		},
+		"with managed identity credentials": {
+			cluster: cluster,
+			azureCluster: withCredentials(*azureCluster, infrav1.CloudProviderCredentials{
+				Type:                   infrav1.ManagedIdentityCloudProviderCredentials,
+				UserAssignedIdentityID: "foobar",
+			}),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: managedIdentityCloudConfig,
+			expectedWorkerNodeConfig:   managedIdentityCloudConfig,
+		},
+		"with extra config": {
+			cluster: cluster,
+			azureCluster: withExtraConfig(*azureCluster, map[string]apiextensionsv1.JSON{
+				"excludeMasterFromStandardLB": {Raw: []byte("false")},
+				"putVMSSVMBatchSize":          {Raw: []byte("10")},
+				"LoadBalancerSku":             {Raw: []byte(`"Basic"`)},
+			}),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: extraConfigCloudConfig,
+			expectedWorkerNodeConfig:   extraConfigCloudConfig,
+		},
+		"with external cloud provider": {
+			cluster:                    cluster,
+			azureCluster:               withExternalCloudProvider(*azureCluster),
+			identityType:               infrav1.VMIdentityNone,
+			expectedControlPlaneConfig: externalCloudProviderNodeCloudConfig,
+			expectedWorkerNodeConfig:   externalCloudProviderNodeCloudConfig,
+			expectedCCMConfig:          externalCloudProviderCCMCloudConfig,
+		},
 		"with machinepools": {
 			cluster:                    cluster,
 			azureCluster:               azureCluster,
@@ -200,6 +238,9 @@ func TestGetCloudProviderConfig(t *testing.T) {
 			if diff := cmp.Diff(tc.expectedControlPlaneConfig, string(cloudConfig.Data["azure.json"])); diff != "" {
 				t.Errorf(diff)
 			}
+			if diff := cmp.Diff(tc.expectedCCMConfig, string(cloudConfig.Data["cloud-controller-manager-azure.json"])); diff != "" {
+				t.Errorf(diff)
+			}
 		})
 	}
 }
@@ -431,6 +472,21 @@ func withbackOffConfig(ac infrav1.AzureCluster) *infrav1.AzureCluster {
 	return &ac
 }
 
+func withCredentials(ac infrav1.AzureCluster, credentials infrav1.CloudProviderCredentials) *infrav1.AzureCluster {
+	ac.Spec.CloudProviderConfigOverrides = &infrav1.CloudProviderConfigOverrides{Credentials: &credentials}
+	return &ac
+}
+
+func withExtraConfig(ac infrav1.AzureCluster, extraConfig map[string]apiextensionsv1.JSON) *infrav1.AzureCluster {
+	ac.Spec.CloudProviderConfigOverrides = &infrav1.CloudProviderConfigOverrides{ExtraConfig: extraConfig}
+	return &ac
+}
+
+func withExternalCloudProvider(ac infrav1.AzureCluster) *infrav1.AzureCluster {
+	ac.Spec.CloudProviderConfigOverrides = &infrav1.CloudProviderConfigOverrides{Mode: infrav1.ExternalCloudProviderConfigMode}
+	return &ac
+}
+
 func newAzureClusterWithCustomVnet(location string) *infrav1.AzureCluster {
 	return &infrav1.AzureCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -710,6 +766,113 @@ const (
     "cloudProviderBackoffExponent": 1.2000000000000002,
     "cloudProviderBackoffDuration": 60,
     "cloudProviderBackoffJitter": 1.2000000000000002
+}`
+	clientCertificateCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "aadClientId": "fooClient",
+    "aadClientCertPath": "/etc/kubernetes/certs/aad-client-cert.pfx",
+    "resourceGroup": "bar",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": false,
+    "useInstanceMetadata": true
+}`
+	managedIdentityCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "resourceGroup": "bar",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": true,
+    "useInstanceMetadata": true,
+    "userAssignedIdentityID": "foobar"
+}`
+	extraConfigCloudConfig = `{
+    "LoadBalancerSku": "Basic",
+    "aadClientId": "fooClient",
+    "aadClientSecret": "fooSecret",
+    "cloud": "AzurePublicCloud",
+    "excludeMasterFromStandardLB": false,
+    "loadBalancerName": "",
+    "location": "bar",
+    "maximumLoadBalancerRuleCount": 250,
+    "putVMSSVMBatchSize": 10,
+    "resourceGroup": "bar",
+    "routeTableName": "foo-node-routetable",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "subscriptionId": "baz",
+    "tenantId": "fooTenant",
+    "useInstanceMetadata": true,
+    "useManagedIdentityExtension": false,
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar"
+}`
+	externalCloudProviderNodeCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "resourceGroup": "bar",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": false,
+    "useInstanceMetadata": true,
+    "cloudProviderBackoffMode": "v2"
+}`
+	//nolint:gosec // Ignore "G101: Potential hardcoded credentials" check.
+	externalCloudProviderCCMCloudConfig = `{
+    "cloud": "AzurePublicCloud",
+    "tenantId": "fooTenant",
+    "subscriptionId": "baz",
+    "aadClientId": "fooClient",
+    "aadClientSecret": "fooSecret",
+    "resourceGroup": "bar",
+    "securityGroupName": "foo-node-nsg",
+    "securityGroupResourceGroup": "bar",
+    "location": "bar",
+    "vmType": "vmss",
+    "vnetName": "foo-vnet",
+    "vnetResourceGroup": "bar",
+    "subnetName": "foo-node-subnet",
+    "routeTableName": "foo-node-routetable",
+    "loadBalancerSku": "Standard",
+    "loadBalancerName": "",
+    "maximumLoadBalancerRuleCount": 250,
+    "useManagedIdentityExtension": false,
+    "useInstanceMetadata": true,
+    "cloudProviderBackoffMode": "v2"
 }`
 	vmssCloudConfig = `{
     "cloud": "AzurePublicCloud",
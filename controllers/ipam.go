@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ipAddressRequeueInterval is how long to wait before checking again whether an IPAM provider has fulfilled a claim.
+const ipAddressRequeueInterval = 15 * time.Second
+
+// reconcileIPAddressClaim ensures an IPAddressClaim for the given pool exists and returns the address allocated to it.
+// An empty address is returned while the IPAM provider has not yet fulfilled the claim.
+func reconcileIPAddressClaim(ctx context.Context, c client.Client, owner metav1.OwnerReference, namespace, name, clusterName string, pool corev1.TypedLocalObjectReference) (string, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.reconcileIPAddressClaim")
+	defer done()
+
+	claim := &ipamv1.IPAddressClaim{}
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := c.Get(ctx, key, claim); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "failed to get IPAddressClaim %s", name)
+		}
+		claim = &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				Labels:          map[string]string{clusterv1.ClusterNameLabel: clusterName},
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			Spec: ipamv1.IPAddressClaimSpec{
+				PoolRef: pool,
+			},
+		}
+		log.V(2).Info("creating IPAddressClaim", "name", name, "pool", pool.Name)
+		if err := c.Create(ctx, claim); err != nil && !apierrors.IsAlreadyExists(err) {
+			return "", errors.Wrapf(err, "failed to create IPAddressClaim %s", name)
+		}
+		return "", nil
+	}
+
+	if claim.Status.AddressRef.Name == "" {
+		return "", nil
+	}
+
+	address := &ipamv1.IPAddress{}
+	key = types.NamespacedName{Namespace: namespace, Name: claim.Status.AddressRef.Name}
+	if err := c.Get(ctx, key, address); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get IPAddress %s", key.Name)
+	}
+	return address.Spec.Address, nil
+}
+
+// reconcileMachineIPAddressClaims claims the private IPs of an AzureMachine's network interfaces from the IPAM pools in
+// its spec and records the claims in its status. It returns false while any claim is still waiting for an address.
+func reconcileMachineIPAddressClaims(ctx context.Context, c client.Client, machineScope *scope.MachineScope) (bool, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.reconcileMachineIPAddressClaims")
+	defer done()
+
+	azureMachine := machineScope.AzureMachine
+	apiVersion, kind := infrav1.GroupVersion.WithKind("AzureMachine").ToAPIVersionAndKind()
+	owner := metav1.OwnerReference{
+		APIVersion:         apiVersion,
+		Kind:               kind,
+		Name:               azureMachine.GetName(),
+		UID:                azureMachine.GetUID(),
+		Controller:         pointer.Bool(true),
+		BlockOwnerDeletion: pointer.Bool(true),
+	}
+
+	var claims []infrav1.IPAddressClaim
+	for i, nic := range azureMachine.Spec.NetworkInterfaces {
+		for j, pool := range nic.AddressesFromPools {
+			name := machineIPAddressClaimName(azureMachine.Name, i, j)
+			address, err := reconcileIPAddressClaim(ctx, c, owner, azureMachine.Namespace, name, machineScope.ClusterName(), pool)
+			if err != nil {
+				return false, err
+			}
+			claims = append(claims, infrav1.IPAddressClaim{
+				Name:             name,
+				NetworkInterface: i,
+				IPConfig:         j,
+				Address:          address,
+			})
+		}
+	}
+	machineScope.SetIPAddressClaims(claims)
+
+	if len(claims) == 0 {
+		return true, nil
+	}
+	for _, claim := range claims {
+		if claim.Address == "" {
+			conditions.MarkFalse(azureMachine, infrav1.IPAddressesClaimedCondition, infrav1.WaitingForIPAddressReason, clusterv1.ConditionSeverityInfo, "waiting for IPAddressClaim %s", claim.Name)
+			return false, nil
+		}
+	}
+	conditions.MarkTrue(azureMachine, infrav1.IPAddressesClaimedCondition)
+	return true, nil
+}
+
+// reconcileAPIServerLBIPAddressClaim claims the private IP of an internal API server load balancer from the IPAM pool in
+// its frontend IP and writes it to the AzureCluster spec. It returns false while the claim is waiting for an address.
+func reconcileAPIServerLBIPAddressClaim(ctx context.Context, c client.Client, clusterScope *scope.ClusterScope) (bool, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.reconcileAPIServerLBIPAddressClaim")
+	defer done()
+
+	azureCluster := clusterScope.AzureCluster
+	lb := &azureCluster.Spec.NetworkSpec.APIServerLB
+	if lb.Type != infrav1.Internal || len(lb.FrontendIPs) == 0 {
+		return true, nil
+	}
+	frontend := &lb.FrontendIPs[0]
+	if frontend.AddressFromPool == nil || frontend.PrivateIPAddress != "" {
+		return true, nil
+	}
+
+	apiVersion, kind := infrav1.GroupVersion.WithKind("AzureCluster").ToAPIVersionAndKind()
+	owner := metav1.OwnerReference{
+		APIVersion:         apiVersion,
+		Kind:               kind,
+		Name:               azureCluster.GetName(),
+		UID:                azureCluster.GetUID(),
+		Controller:         pointer.Bool(true),
+		BlockOwnerDeletion: pointer.Bool(true),
+	}
+	name := fmt.Sprintf("%s-%s", azureCluster.Name, frontend.Name)
+	address, err := reconcileIPAddressClaim(ctx, c, owner, azureCluster.Namespace, name, clusterScope.ClusterName(), *frontend.AddressFromPool)
+	if err != nil {
+		return false, err
+	}
+	if address == "" {
+		conditions.MarkFalse(azureCluster, infrav1.IPAddressesClaimedCondition, infrav1.WaitingForIPAddressReason, clusterv1.ConditionSeverityInfo, "waiting for IPAddressClaim %s", name)
+		return false, nil
+	}
+	frontend.PrivateIPAddress = address
+	conditions.MarkTrue(azureCluster, infrav1.IPAddressesClaimedCondition)
+	return true, nil
+}
+
+// machineIPAddressClaimName returns the name of the IPAddressClaim for an IP configuration of an AzureMachine NIC.
+func machineIPAddressClaimName(machineName string, nicIndex, ipConfigIndex int) string {
+	return fmt.Sprintf("%s-nic-%d-%d", machineName, nicIndex, ipConfigIndex)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testPool = corev1.TypedLocalObjectReference{
+	APIGroup: pointer.String("ipam.cluster.x-k8s.io"),
+	Kind:     "InClusterIPPool",
+	Name:     "pool",
+}
+
+func fulfilledClaim(name, address string) []client.Object {
+	return []client.Object{
+		&ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       ipamv1.IPAddressClaimSpec{PoolRef: testPool},
+			Status: ipamv1.IPAddressClaimStatus{
+				AddressRef: corev1.LocalObjectReference{Name: name},
+			},
+		},
+		&ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: ipamv1.IPAddressSpec{
+				ClaimRef: corev1.LocalObjectReference{Name: name},
+				PoolRef:  testPool,
+				Address:  address,
+				Prefix:   24,
+			},
+		},
+	}
+}
+
+func TestReconcileMachineIPAddressClaims(t *testing.T) {
+	tests := []struct {
+		name          string
+		objects       []client.Object
+		expectReady   bool
+		expectClaims  []infrav1.IPAddressClaim
+		expectCreated []string
+	}{
+		{
+			name:        "creates claims and waits for addresses",
+			expectReady: false,
+			expectClaims: []infrav1.IPAddressClaim{
+				{Name: "my-vm-nic-0-0", NetworkInterface: 0, IPConfig: 0},
+				{Name: "my-vm-nic-1-0", NetworkInterface: 1, IPConfig: 0},
+			},
+			expectCreated: []string{"my-vm-nic-0-0", "my-vm-nic-1-0"},
+		},
+		{
+			name:        "records addresses once claims are fulfilled",
+			objects:     append(fulfilledClaim("my-vm-nic-0-0", "10.0.0.10"), fulfilledClaim("my-vm-nic-1-0", "10.1.0.10")...),
+			expectReady: true,
+			expectClaims: []infrav1.IPAddressClaim{
+				{Name: "my-vm-nic-0-0", NetworkInterface: 0, IPConfig: 0, Address: "10.0.0.10"},
+				{Name: "my-vm-nic-1-0", NetworkInterface: 1, IPConfig: 0, Address: "10.1.0.10"},
+			},
+		},
+		{
+			name:        "waits while some claims are pending",
+			objects:     fulfilledClaim("my-vm-nic-0-0", "10.0.0.10"),
+			expectReady: false,
+			expectClaims: []infrav1.IPAddressClaim{
+				{Name: "my-vm-nic-0-0", NetworkInterface: 0, IPConfig: 0, Address: "10.0.0.10"},
+				{Name: "my-vm-nic-1-0", NetworkInterface: 1, IPConfig: 0},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := newScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			machineScope := &scope.MachineScope{
+				ClusterScoper: &scope.ClusterScope{
+					Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-vm", Namespace: "default"},
+					Spec: infrav1.AzureMachineSpec{
+						NetworkInterfaces: []infrav1.NetworkInterface{
+							{SubnetName: "subnet1", PrivateIPConfigs: 1, AddressesFromPools: []corev1.TypedLocalObjectReference{testPool}},
+							{SubnetName: "subnet2", PrivateIPConfigs: 2, AddressesFromPools: []corev1.TypedLocalObjectReference{testPool}},
+						},
+					},
+				},
+			}
+
+			ready, err := reconcileMachineIPAddressClaims(context.Background(), c, machineScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ready).To(Equal(tc.expectReady))
+			g.Expect(machineScope.AzureMachine.Status.IPAddressClaims).To(Equal(tc.expectClaims))
+			g.Expect(conditions.IsTrue(machineScope.AzureMachine, infrav1.IPAddressesClaimedCondition)).To(Equal(tc.expectReady))
+
+			for _, name := range tc.expectCreated {
+				claim := &ipamv1.IPAddressClaim{}
+				g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, claim)).To(Succeed())
+				g.Expect(claim.Spec.PoolRef).To(Equal(testPool))
+				g.Expect(claim.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "my-cluster"))
+				g.Expect(claim.OwnerReferences).To(HaveLen(1))
+				g.Expect(claim.OwnerReferences[0].Kind).To(Equal("AzureMachine"))
+			}
+		})
+	}
+}
+
+func TestReconcileAPIServerLBIPAddressClaim(t *testing.T) {
+	tests := []struct {
+		name            string
+		lb              infrav1.LoadBalancerSpec
+		objects         []client.Object
+		expectReady     bool
+		expectPrivateIP string
+	}{
+		{
+			name: "no pool configured",
+			lb: infrav1.LoadBalancerSpec{
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal},
+				FrontendIPs:           []infrav1.FrontendIP{{Name: "frontend", FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"}}},
+			},
+			expectReady:     true,
+			expectPrivateIP: "10.0.0.100",
+		},
+		{
+			name: "waits for the claim to be fulfilled",
+			lb: infrav1.LoadBalancerSpec{
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal},
+				FrontendIPs:           []infrav1.FrontendIP{{Name: "frontend", AddressFromPool: &testPool}},
+			},
+			expectReady: false,
+		},
+		{
+			name: "sets the private IP from the claimed address",
+			lb: infrav1.LoadBalancerSpec{
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal},
+				FrontendIPs:           []infrav1.FrontendIP{{Name: "frontend", AddressFromPool: &testPool}},
+			},
+			objects:         fulfilledClaim("my-azure-cluster-frontend", "10.0.0.50"),
+			expectReady:     true,
+			expectPrivateIP: "10.0.0.50",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := newScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			clusterScope := &scope.ClusterScope{
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}},
+				AzureCluster: &infrav1.AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-azure-cluster", Namespace: "default"},
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{APIServerLB: tc.lb},
+					},
+				},
+			}
+
+			ready, err := reconcileAPIServerLBIPAddressClaim(context.Background(), c, clusterScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ready).To(Equal(tc.expectReady))
+			g.Expect(clusterScope.APIServerLB().FrontendIPs[0].PrivateIPAddress).To(Equal(tc.expectPrivateIP))
+		})
+	}
+}
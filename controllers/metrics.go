@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	reconcileOperation = "reconcile"
+	deleteOperation    = "delete"
+)
+
+var (
+	serviceReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "capz_service_reconcile_duration_seconds",
+		Help:    "Duration of the reconciles of Azure services, by service, operation (reconcile or delete) and result (success, in_progress or error).",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"service", "operation", "result"})
+
+	armErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capz_arm_errors_total",
+		Help: "Total number of Azure Resource Manager errors failing the reconciles of Azure services, by service, HTTP status code and ARM error code.",
+	}, []string{"service", "status_code", "error_code"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(serviceReconcileDuration, armErrorsTotal)
+}
+
+// ReconcileService reconciles an Azure service and records the duration and the result of the reconcile.
+func ReconcileService(ctx context.Context, service azure.ServiceReconciler) error {
+	start := time.Now()
+	err := service.Reconcile(ctx)
+	observeService(service.Name(), reconcileOperation, time.Since(start), err)
+	return err
+}
+
+// DeleteService deletes the resources of an Azure service and records the duration and the result of the delete.
+func DeleteService(ctx context.Context, service azure.ServiceReconciler) error {
+	start := time.Now()
+	err := service.Delete(ctx)
+	observeService(service.Name(), deleteOperation, time.Since(start), err)
+	return err
+}
+
+// observeService records the duration and the result of an operation on an Azure service. Long running operations
+// which are not done yet aren't errors, while Azure Resource Manager errors are also counted by status and error code.
+func observeService(serviceName, operation string, duration time.Duration, err error) {
+	result := "success"
+	switch {
+	case err == nil:
+	case azure.IsOperationNotDoneError(err):
+		result = "in_progress"
+	default:
+		result = "error"
+		if statusCode := azure.ErrorStatusCode(err); statusCode != 0 {
+			armErrorsTotal.WithLabelValues(serviceName, strconv.Itoa(statusCode), azure.ErrorCode(err)).Inc()
+		}
+	}
+	serviceReconcileDuration.WithLabelValues(serviceName, operation, result).Observe(duration.Seconds())
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ForWithPriority configures the builder to reconcile the objects of the kind of obj. When lowPriorityDelay is set, the
+// requests of low priority events, such as periodic resyncs, are only added to the queue after lowPriorityDelay so that
+// creating and deleting objects is not delayed by the reconciliation of all the objects of a large management cluster.
+func ForWithPriority(b *builder.Builder, obj client.Object, kind string, lowPriorityDelay time.Duration) *builder.Builder {
+	if lowPriorityDelay <= 0 {
+		return b.For(obj)
+	}
+	return b.
+		Named(strings.ToLower(kind)).
+		Watches(&source.Kind{Type: obj}, &priorityEnqueueRequestForObject{lowPriorityDelay: lowPriorityDelay})
+}
+
+// priorityEnqueueRequestForObject enqueues a request for the object of an event like handler.EnqueueRequestForObject,
+// but delays the requests of low priority events by lowPriorityDelay.
+type priorityEnqueueRequestForObject struct {
+	lowPriorityDelay time.Duration
+}
+
+var _ handler.EventHandler = &priorityEnqueueRequestForObject{}
+
+// Create enqueues a request for a created object. Objects which were already reconciled, i.e. the objects listed when
+// the controller starts, are low priority.
+func (e *priorityEnqueueRequestForObject) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	if evt.Object == nil {
+		return
+	}
+	e.enqueue(q, evt.Object, isNew(evt.Object) || isDeleting(evt.Object))
+}
+
+// Update enqueues a request for an updated object. Periodic resyncs, which don't change the resource version of the
+// object, are low priority unless the object is new or being deleted.
+func (e *priorityEnqueueRequestForObject) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if evt.ObjectNew == nil {
+		return
+	}
+	highPriority := isNew(evt.ObjectNew) || isDeleting(evt.ObjectNew) ||
+		evt.ObjectOld == nil || evt.ObjectOld.GetResourceVersion() != evt.ObjectNew.GetResourceVersion()
+	e.enqueue(q, evt.ObjectNew, highPriority)
+}
+
+// Delete enqueues a request for a deleted object.
+func (e *priorityEnqueueRequestForObject) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	if evt.Object == nil {
+		return
+	}
+	e.enqueue(q, evt.Object, true)
+}
+
+// Generic enqueues a request for the object of a generic event.
+func (e *priorityEnqueueRequestForObject) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	if evt.Object == nil {
+		return
+	}
+	e.enqueue(q, evt.Object, true)
+}
+
+// enqueue adds a request for obj to the queue, immediately if highPriority is set or after lowPriorityDelay otherwise.
+// A high priority request for an object is not held back by a low priority one waiting to be added.
+func (e *priorityEnqueueRequestForObject) enqueue(q workqueue.RateLimitingInterface, obj client.Object, highPriority bool) {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}}
+	if highPriority {
+		q.Add(req)
+		return
+	}
+	q.AddAfter(req, e.lowPriorityDelay)
+}
+
+// isNew returns true if the object was not reconciled yet, i.e. it has no finalizers.
+func isNew(obj client.Object) bool {
+	return len(obj.GetFinalizers()) == 0
+}
+
+// isDeleting returns true if the object is being deleted.
+func isDeleting(obj client.Object) bool {
+	return !obj.GetDeletionTimestamp().IsZero()
+}
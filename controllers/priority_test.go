@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPriorityEnqueueRequestForObject(t *testing.T) {
+	reconciled := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "reconciled",
+			Namespace:       "default",
+			Finalizers:      []string{infrav1.ClusterFinalizer},
+			ResourceVersion: "1",
+		},
+	}
+	created := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "created",
+			Namespace:       "default",
+			ResourceVersion: "1",
+		},
+	}
+	deleting := reconciled.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	changed := reconciled.DeepCopy()
+	changed.ResourceVersion = "2"
+
+	tests := []struct {
+		name         string
+		send         func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface)
+		highPriority bool
+	}{
+		{
+			name: "create of a new object is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: created}, q)
+			},
+			highPriority: true,
+		},
+		{
+			name: "create of a reconciled object is low priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: reconciled}, q)
+			},
+		},
+		{
+			name: "create of an object being deleted is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: deleting}, q)
+			},
+			highPriority: true,
+		},
+		{
+			name: "resync of a reconciled object is low priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: reconciled, ObjectNew: reconciled}, q)
+			},
+		},
+		{
+			name: "resync of a new object is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: created, ObjectNew: created}, q)
+			},
+			highPriority: true,
+		},
+		{
+			name: "update of a reconciled object is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: reconciled, ObjectNew: changed}, q)
+			},
+			highPriority: true,
+		},
+		{
+			name: "deletion of an object is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: reconciled, ObjectNew: deleting}, q)
+			},
+			highPriority: true,
+		},
+		{
+			name: "delete is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Delete(event.DeleteEvent{Object: reconciled}, q)
+			},
+			highPriority: true,
+		},
+		{
+			name: "generic event is high priority",
+			send: func(h *priorityEnqueueRequestForObject, q workqueue.RateLimitingInterface) {
+				h.Generic(event.GenericEvent{Object: reconciled}, q)
+			},
+			highPriority: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+			h := &priorityEnqueueRequestForObject{lowPriorityDelay: 100 * time.Millisecond}
+
+			tc.send(h, q)
+			if tc.highPriority {
+				g.Expect(q.Len()).To(Equal(1))
+				return
+			}
+			g.Expect(q.Len()).To(Equal(0))
+			g.Eventually(q.Len).Should(Equal(1))
+		})
+	}
+}
@@ -149,8 +149,11 @@ func (amp *AzureMachinePool) SetNetworkInterfacesDefaults() {
 		amp.Spec.Template.AcceleratedNetworking = nil
 	}
 
-	// Ensure that PrivateIPConfigs defaults to 1 if not specified.
+	// Ensure that PrivateIPConfigs matches the number of IPConfigs, or defaults to 1 if not specified.
 	for i := 0; i < len(amp.Spec.Template.NetworkInterfaces); i++ {
+		if len(amp.Spec.Template.NetworkInterfaces[i].IPConfigs) > 0 {
+			amp.Spec.Template.NetworkInterfaces[i].PrivateIPConfigs = len(amp.Spec.Template.NetworkInterfaces[i].IPConfigs)
+		}
 		if amp.Spec.Template.NetworkInterfaces[i].PrivateIPConfigs == 0 {
 			amp.Spec.Template.NetworkInterfaces[i].PrivateIPConfigs = 1
 		}
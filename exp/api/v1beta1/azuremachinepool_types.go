@@ -39,6 +39,14 @@ const (
 	NewestDeletePolicyType AzureMachinePoolDeletePolicyType = "Newest"
 	// RandomDeletePolicyType will delete machines in random order.
 	RandomDeletePolicyType AzureMachinePoolDeletePolicyType = "Random"
+
+	// DefaultScaleInPolicyType balances the scale set across zones and fault domains, then removes the newest
+	// instances first.
+	DefaultScaleInPolicyType AzureMachinePoolScaleInPolicyType = "Default"
+	// OldestVMScaleInPolicyType removes the oldest instances first.
+	OldestVMScaleInPolicyType AzureMachinePoolScaleInPolicyType = "OldestVM"
+	// NewestVMScaleInPolicyType removes the newest instances first.
+	NewestVMScaleInPolicyType AzureMachinePoolScaleInPolicyType = "NewestVM"
 )
 
 type (
@@ -97,12 +105,30 @@ type (
 		// +optional
 		VMExtensions []infrav1.VMExtension `json:"vmExtensions,omitempty"`
 
+		// GPUDriver specifies whether to install the NVIDIA GPU driver extension on the scale set.
+		// The VM size must have NVIDIA GPUs, e.g. one of the NC, ND or NV series.
+		// +optional
+		GPUDriver *infrav1.GPUDriver `json:"gpuDriver,omitempty"`
+
 		// NetworkInterfaces specifies a list of network interface configurations.
 		// If left unspecified, the VM will get a single network interface with a
 		// single IPConfig in the subnet specified in the cluster's node subnet field.
 		// The primary interface will be the first networkInterface specified (index 0) in the list.
 		// +optional
 		NetworkInterfaces []infrav1.NetworkInterface `json:"networkInterfaces,omitempty"`
+
+		// AllocatePublicIP gives every instance of the scale set a public IP on its primary network interface.
+		// +optional
+		AllocatePublicIP bool `json:"allocatePublicIP,omitempty"`
+
+		// PublicIPPrefixID is the resource ID of an existing public IP prefix from which the public IPs of the
+		// instances are allocated when AllocatePublicIP is true, so that node public IPs come from a known CIDR.
+		// +optional
+		PublicIPPrefixID *string `json:"publicIPPrefixID,omitempty"`
+
+		// PublicIP specifies the DNS label and IP tags of the public IPs of the instances when AllocatePublicIP is true.
+		// +optional
+		PublicIP *infrav1.NodePublicIP `json:"publicIP,omitempty"`
 	}
 
 	// AzureMachinePoolSpec defines the desired state of AzureMachinePool.
@@ -166,6 +192,32 @@ type (
 		// OrchestrationMode specifies the orchestration mode for the Virtual Machine Scale Set
 		// +kubebuilder:default=Uniform
 		OrchestrationMode infrav1.OrchestrationModeType `json:"orchestrationMode,omitempty"`
+
+		// PlatformFaultDomainCount is the number of fault domains the instances of a scale set in Flexible
+		// orchestration mode are spread across. It defaults to 1, which spreads the instances across as many
+		// fault domains as possible, or to the number of failure domains when the pool spans multiple zones.
+		// It can only be set in Flexible orchestration mode.
+		// +kubebuilder:validation:Minimum=1
+		// +kubebuilder:validation:Maximum=3
+		// +optional
+		PlatformFaultDomainCount *int32 `json:"platformFaultDomainCount,omitempty"`
+
+		// ProximityPlacementGroupName is the name of a proximity placement group in the cluster resource group to place
+		// the scale set in. It is typically one of the proximity placement groups of the AzureCluster.
+		// +optional
+		ProximityPlacementGroupName string `json:"proximityPlacementGroupName,omitempty"`
+
+		// CapacityReservationGroupID is the resource ID of a capacity reservation group to allocate the scale set
+		// instances from, so that they consume capacity reserved in that group.
+		// It can't be used with Spot VMs.
+		// +optional
+		CapacityReservationGroupID string `json:"capacityReservationGroupID,omitempty"`
+
+		// ScaleInPolicy is the rule Azure follows to select the instances to remove when the capacity of the scale set
+		// is reduced outside of CAPZ, e.g. by an autoscaler managing the replicas of the MachinePool. The instances
+		// CAPZ removes are selected by the deletePolicy of the deployment strategy instead.
+		// +optional
+		ScaleInPolicy AzureMachinePoolScaleInPolicyType `json:"scaleInPolicy,omitempty"`
 	}
 
 	// AzureMachinePoolDeploymentStrategyType is the type of deployment strategy employed to rollout a new version of
@@ -191,6 +243,11 @@ type (
 	// upgrade.
 	AzureMachinePoolDeletePolicyType string
 
+	// AzureMachinePoolScaleInPolicyType is the rule Azure follows to select the instances to remove when the capacity
+	// of the scale set is reduced.
+	// +kubebuilder:validation:Enum=Default;OldestVM;NewestVM
+	AzureMachinePoolScaleInPolicyType string
+
 	// MachineRollingUpdateDeployment is used to control the desired behavior of rolling update.
 	MachineRollingUpdateDeployment struct {
 		// The maximum number of machines that can be unavailable during the update.
@@ -250,7 +307,8 @@ type (
 		Instances []*AzureMachinePoolInstanceStatus `json:"instances,omitempty"`
 
 		// Image is the current image used in the AzureMachinePool. When the spec image is nil, this image is populated
-		// with the details of the defaulted Azure Marketplace "capi" offer.
+		// with the details of the defaulted Azure Marketplace "capi" offer. When the spec image is an Azure Compute Gallery
+		// image with a 'latest' or semver range version, this image contains the resolved image version.
 		// +optional
 		Image *infrav1.Image `json:"image,omitempty"`
 
@@ -308,6 +366,10 @@ type (
 		// next reconciliation loop.
 		// +optional
 		LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+
+		// OperationStatus reports the progress of the ongoing operation on the scale set, if any.
+		// +optional
+		OperationStatus *infrav1.OperationStatus `json:"operationStatus,omitempty"`
 	}
 
 	// AzureMachinePoolInstanceStatus provides status information for each instance in the VMSS.
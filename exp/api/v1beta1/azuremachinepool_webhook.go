@@ -104,11 +104,20 @@ func (amp *AzureMachinePool) Validate(old runtime.Object, client client.Client)
 		amp.ValidateSSHKey,
 		amp.ValidateUserAssignedIdentity,
 		amp.ValidateDiagnostics,
+		amp.ValidateSecurityProfile,
+		amp.ValidateDiffDiskSettings,
+		amp.ValidateDiskEncryptionSets,
+		amp.ValidateVMExtensions,
 		amp.ValidateOrchestrationMode(client),
 		amp.ValidateStrategy(),
 		amp.ValidateSystemAssignedIdentity(old),
 		amp.ValidateSystemAssignedIdentityRole,
 		amp.ValidateNetwork,
+		amp.ValidateProximityPlacementGroup(old),
+		amp.ValidateCapacityReservationGroup(old),
+		amp.ValidatePublicIP(old),
+		amp.ValidateSpotVMOptions(old),
+		amp.ValidateOrchestrationModeUpdate(old),
 	}
 
 	var errs []error
@@ -126,9 +135,159 @@ func (amp *AzureMachinePool) ValidateNetwork() error {
 	if (amp.Spec.Template.NetworkInterfaces != nil) && len(amp.Spec.Template.NetworkInterfaces) > 0 && amp.Spec.Template.SubnetName != "" {
 		return errors.New("cannot set both NetworkInterfaces and machine SubnetName")
 	}
+	for _, nic := range amp.Spec.Template.NetworkInterfaces {
+		if len(nic.AddressesFromPools) > 0 {
+			return errors.New("addressesFromPools is not supported for AzureMachinePools")
+		}
+		if nic.PrivateIPAddress != "" {
+			return errors.New("static private IP addresses are not supported for AzureMachinePools")
+		}
+		if len(nic.ApplicationSecurityGroups) > 0 {
+			return errors.New("application security groups are not supported for AzureMachinePools")
+		}
+		for _, ipConfig := range nic.IPConfigs {
+			if ipConfig.PrivateIPAddress != "" {
+				return errors.New("static private IP addresses in ipConfigs are not supported for AzureMachinePools")
+			}
+		}
+	}
 	return nil
 }
 
+// ValidateProximityPlacementGroup validates the proximity placement group name and that it is not changed.
+func (amp *AzureMachinePool) ValidateProximityPlacementGroup(old runtime.Object) func() error {
+	return func() error {
+		fldPath := field.NewPath("spec", "proximityPlacementGroupName")
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if oldMachinePool.Spec.ProximityPlacementGroupName != amp.Spec.ProximityPlacementGroupName {
+				return field.Forbidden(fldPath, "proximityPlacementGroupName is immutable")
+			}
+		}
+
+		if amp.Spec.ProximityPlacementGroupName != "" {
+			if err := infrav1.ValidateProximityPlacementGroupName(amp.Spec.ProximityPlacementGroupName, fldPath); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// ValidateCapacityReservationGroup validates the capacity reservation group of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidateCapacityReservationGroup(old runtime.Object) func() error {
+	return func() error {
+		fldPath := field.NewPath("spec", "capacityReservationGroupID")
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if oldMachinePool.Spec.CapacityReservationGroupID != amp.Spec.CapacityReservationGroupID {
+				return field.Forbidden(fldPath, "capacityReservationGroupID is immutable")
+			}
+		}
+
+		if errs := infrav1.ValidateCapacityReservationGroupID(amp.Spec.CapacityReservationGroupID, amp.Spec.Template.SpotVMOptions, fldPath); len(errs) > 0 {
+			return errs.ToAggregate()
+		}
+
+		return nil
+	}
+}
+
+// ValidatePublicIP validates the public IP configuration of an AzureMachinePool and that it is not changed.
+func (amp *AzureMachinePool) ValidatePublicIP(old runtime.Object) func() error {
+	return func() error {
+		fldPath := field.NewPath("spec", "template")
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if oldMachinePool.Spec.Template.AllocatePublicIP != amp.Spec.Template.AllocatePublicIP {
+				return field.Forbidden(fldPath.Child("allocatePublicIP"), "allocatePublicIP is immutable")
+			}
+			if !reflect.DeepEqual(oldMachinePool.Spec.Template.PublicIPPrefixID, amp.Spec.Template.PublicIPPrefixID) {
+				return field.Forbidden(fldPath.Child("publicIPPrefixID"), "publicIPPrefixID is immutable")
+			}
+			if !reflect.DeepEqual(oldMachinePool.Spec.Template.PublicIP, amp.Spec.Template.PublicIP) {
+				return field.Forbidden(fldPath.Child("publicIP"), "publicIP is immutable")
+			}
+		}
+
+		if errs := infrav1.ValidatePublicIPPrefixID(amp.Spec.Template.PublicIPPrefixID, amp.Spec.Template.AllocatePublicIP, fldPath.Child("publicIPPrefixID")); len(errs) > 0 {
+			return errs.ToAggregate()
+		}
+
+		if errs := infrav1.ValidateNodePublicIP(amp.Spec.Template.PublicIP, amp.Spec.Template.AllocatePublicIP, fldPath.Child("publicIP")); len(errs) > 0 {
+			return errs.ToAggregate()
+		}
+
+		return nil
+	}
+}
+
+// ValidateSpotVMOptions validates updates to the spot VM options of an AzureMachinePool.
+// Only the max price can be changed once the scale set exists.
+func (amp *AzureMachinePool) ValidateSpotVMOptions(old runtime.Object) func() error {
+	return func() error {
+		if old == nil {
+			return nil
+		}
+		oldMachinePool, ok := old.(*AzureMachinePool)
+		if !ok {
+			return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+				"AzureMachinePool", reflect.TypeOf(old))
+		}
+
+		fldPath := field.NewPath("spec", "template", "spotVMOptions")
+		oldOptions, newOptions := oldMachinePool.Spec.Template.SpotVMOptions, amp.Spec.Template.SpotVMOptions
+		if (oldOptions == nil) != (newOptions == nil) {
+			return field.Forbidden(fldPath, "spotVMOptions cannot be added or removed")
+		}
+		if oldOptions != nil && !reflect.DeepEqual(oldOptions.EvictionPolicy, newOptions.EvictionPolicy) {
+			return field.Forbidden(fldPath.Child("evictionPolicy"), "evictionPolicy is immutable")
+		}
+
+		return nil
+	}
+}
+
+// ValidateOrchestrationModeUpdate validates the orchestration mode and platform fault domain count of an AzureMachinePool.
+// Neither can be changed once the scale set exists.
+func (amp *AzureMachinePool) ValidateOrchestrationModeUpdate(old runtime.Object) func() error {
+	return func() error {
+		fldPath := field.NewPath("spec")
+		if amp.Spec.PlatformFaultDomainCount != nil && amp.Spec.OrchestrationMode != infrav1.FlexibleOrchestrationMode {
+			return field.Forbidden(fldPath.Child("platformFaultDomainCount"), "platformFaultDomainCount can only be set in Flexible orchestration mode")
+		}
+		if old == nil {
+			return nil
+		}
+		oldMachinePool, ok := old.(*AzureMachinePool)
+		if !ok {
+			return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+				"AzureMachinePool", reflect.TypeOf(old))
+		}
+		if oldMachinePool.Spec.OrchestrationMode != amp.Spec.OrchestrationMode {
+			return field.Forbidden(fldPath.Child("orchestrationMode"), "orchestrationMode is immutable")
+		}
+		if !reflect.DeepEqual(oldMachinePool.Spec.PlatformFaultDomainCount, amp.Spec.PlatformFaultDomainCount) {
+			return field.Forbidden(fldPath.Child("platformFaultDomainCount"), "platformFaultDomainCount is immutable")
+		}
+
+		return nil
+	}
+}
+
 // ValidateImage of an AzureMachinePool.
 func (amp *AzureMachinePool) ValidateImage() error {
 	if amp.Spec.Template.Image != nil {
@@ -208,7 +367,7 @@ func (amp *AzureMachinePool) ValidateSystemAssignedIdentity(old runtime.Object)
 				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
 					"AzureMachinePool", reflect.TypeOf(old))
 			}
-			if amp.Spec.SystemAssignedIdentityRole != nil {
+			if oldMachinePool.Spec.SystemAssignedIdentityRole != nil {
 				oldRole = oldMachinePool.Spec.SystemAssignedIdentityRole.Name
 			}
 		}
@@ -293,6 +452,54 @@ func (amp *AzureMachinePool) ValidateDiagnostics() error {
 	return nil
 }
 
+// ValidateSecurityProfile validates the SecurityProfile spec.
+func (amp *AzureMachinePool) ValidateSecurityProfile() error {
+	fldPath := field.NewPath("securityProfile")
+	errs := infrav1.ValidateSecurityProfile(amp.Spec.Template.SecurityProfile, fldPath)
+	errs = append(errs, infrav1.ValidateConfidentialCompute(amp.Spec.Template.OSDisk, amp.Spec.Template.SecurityProfile, fldPath)...)
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	return nil
+}
+
+// ValidateDiffDiskSettings validates the ephemeral OS disk settings.
+func (amp *AzureMachinePool) ValidateDiffDiskSettings() error {
+	if errs := infrav1.ValidateDiffDiskSettings(amp.Spec.Template.OSDisk.DiffDiskSettings, field.NewPath("osDisk", "diffDiskSettings")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	return nil
+}
+
+// ValidateVMExtensions validates the VM extensions of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidateVMExtensions() error {
+	if errs := infrav1.ValidateVMExtensions(amp.Spec.Template.VMExtensions, field.NewPath("template", "vmExtensions")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	return nil
+}
+
+// ValidateDiskEncryptionSets validates the disk encryption sets of the OS disk and data disks.
+func (amp *AzureMachinePool) ValidateDiskEncryptionSets() error {
+	var errs field.ErrorList
+	if amp.Spec.Template.OSDisk.ManagedDisk != nil {
+		errs = append(errs, infrav1.ValidateDiskEncryptionSet(amp.Spec.Template.OSDisk.ManagedDisk.DiskEncryptionSet, field.NewPath("osDisk", "managedDisk", "diskEncryptionSet"))...)
+	}
+	for i, disk := range amp.Spec.Template.DataDisks {
+		if disk.ManagedDisk != nil {
+			errs = append(errs, infrav1.ValidateDiskEncryptionSet(disk.ManagedDisk.DiskEncryptionSet, field.NewPath("dataDisks").Index(i).Child("managedDisk", "diskEncryptionSet"))...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	return nil
+}
+
 // ValidateOrchestrationMode validates requirements for the VMSS orchestration mode.
 func (amp *AzureMachinePool) ValidateOrchestrationMode(c client.Client) func() error {
 	return func() error {
@@ -30,7 +30,9 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/ssh"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -110,6 +112,119 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     createMachinePoolWithSharedImage("", "RG123", "NAME123", "GALLERY1", "1.0.0", pointer.Int(10)),
 			wantErr: true,
 		},
+		{
+			name:    "azuremachinepool with community gallery image",
+			amp:     createMachinePoolWithComputeGalleryImage("GALLERY1", "NAME123", "1.0.0", false),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with directly shared gallery image",
+			amp:     createMachinePoolWithComputeGalleryImage("GALLERY1", "NAME123", "1.0.0", true),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with community gallery image - semver range version",
+			amp:     createMachinePoolWithComputeGalleryImage("GALLERY1", "NAME123", "1.x", false),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with unsupported ephemeral os disk placement",
+			amp: &AzureMachinePool{
+				Spec: AzureMachinePoolSpec{
+					Template: AzureMachinePoolMachineTemplate{
+						SSHPublicKey: validSSHPublicKey,
+						OSDisk: infrav1.OSDisk{
+							DiffDiskSettings: &infrav1.DiffDiskSettings{
+								Option:    "Local",
+								Placement: (*infrav1.DiffDiskPlacement)(pointer.String("NvmeDisk")),
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with data disk encryption set",
+			amp: &AzureMachinePool{
+				Spec: AzureMachinePoolSpec{
+					Template: AzureMachinePoolMachineTemplate{
+						SSHPublicKey: validSSHPublicKey,
+						DataDisks: []infrav1.DataDisk{
+							{
+								NameSuffix: "my_disk",
+								DiskSizeGB: 64,
+								Lun:        pointer.Int32(0),
+								ManagedDisk: &infrav1.ManagedDiskParameters{
+									DiskEncryptionSet: &infrav1.DiskEncryptionSetParameters{
+										ID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/diskEncryptionSets/my-des",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with invalid data disk encryption set ID",
+			amp: &AzureMachinePool{
+				Spec: AzureMachinePoolSpec{
+					Template: AzureMachinePoolMachineTemplate{
+						SSHPublicKey: validSSHPublicKey,
+						DataDisks: []infrav1.DataDisk{
+							{
+								NameSuffix: "my_disk",
+								DiskSizeGB: 64,
+								Lun:        pointer.Int32(0),
+								ManagedDisk: &infrav1.ManagedDiskParameters{
+									DiskEncryptionSet: &infrav1.DiskEncryptionSetParameters{
+										ID: "my-des",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with capacity reservation group",
+			amp:     createMachinePoolWithCapacityReservationGroup("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1", nil),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with invalid capacity reservation group ID",
+			amp:     createMachinePoolWithCapacityReservationGroup("crg-1", nil),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with spot VMs and capacity reservation group",
+			amp:     createMachinePoolWithCapacityReservationGroup("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1", &infrav1.SpotVMOptions{}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with public IPs from a public IP prefix",
+			amp:     createMachinePoolWithPublicIP(true, pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix")),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with public IP DNS label and IP tags",
+			amp:     createMachinePoolWithPublicIPSettings(&infrav1.NodePublicIP{DNSLabelPrefix: "nodes", IPTags: []infrav1.IPTag{{Type: "RoutingPreference", Tag: "Internet"}}}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with invalid public IP tag",
+			amp:     createMachinePoolWithPublicIPSettings(&infrav1.NodePublicIP{IPTags: []infrav1.IPTag{{Type: "RoutingPreference"}}}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with public IP prefix without public IPs",
+			amp:     createMachinePoolWithPublicIP(false, pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix")),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with image by - with id",
 			amp:     createMachinePoolWithImageByID("ID123", pointer.Int(10)),
@@ -217,6 +332,60 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet"}}),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with static private IP address",
+			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet", PrivateIPAddress: "10.0.0.4"}}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with static private IP address in ipConfigs",
+			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet", IPConfigs: []infrav1.NetworkInterfaceIPConfig{{PrivateIPAddress: "10.0.0.4"}}}}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with application security groups",
+			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet", ApplicationSecurityGroups: []string{"backend"}}}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with trusted launch",
+			amp: createMachinePoolWithSecurityProfile(&infrav1.SecurityProfile{
+				SecurityType: infrav1.SecurityTypesTrustedLaunch,
+				UefiSettings: &infrav1.UefiSettings{SecureBootEnabled: pointer.Bool(true), VTpmEnabled: pointer.Bool(true)},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with confidential VM",
+			amp: func() *AzureMachinePool {
+				amp := createMachinePoolWithSecurityProfile(&infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{SecureBootEnabled: pointer.Bool(true), VTpmEnabled: pointer.Bool(true)},
+				})
+				amp.Spec.Template.OSDisk.ManagedDisk = &infrav1.ManagedDiskParameters{
+					SecurityProfile: &infrav1.VMDiskSecurityProfile{
+						SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+					},
+				}
+				return amp
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with confidential VM and no OS disk security profile",
+			amp: createMachinePoolWithSecurityProfile(&infrav1.SecurityProfile{
+				SecurityType: infrav1.SecurityTypesConfidentialVM,
+				UefiSettings: &infrav1.UefiSettings{VTpmEnabled: pointer.Bool(true)},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with secure boot but no trusted launch",
+			amp: createMachinePoolWithSecurityProfile(&infrav1.SecurityProfile{
+				UefiSettings: &infrav1.UefiSettings{SecureBootEnabled: pointer.Bool(true)},
+			}),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with Flexible orchestration mode",
 			amp:     createMachinePoolWithOrchestrationMode(compute.OrchestrationModeFlexible),
@@ -305,8 +474,12 @@ func TestAzureMachinePool_ValidateUpdate(t *testing.T) {
 	g := NewWithT(t)
 
 	var (
-		zero = intstr.FromInt(0)
-		one  = intstr.FromInt(1)
+		zero             = intstr.FromInt(0)
+		one              = intstr.FromInt(1)
+		maxPrice         = resource.MustParse("0.5")
+		newMaxPrice      = resource.MustParse("0.7")
+		deallocatePolicy = infrav1.SpotEvictionPolicyDeallocate
+		deletePolicy     = infrav1.SpotEvictionPolicyDelete
 	)
 
 	tests := []struct {
@@ -339,6 +512,12 @@ func TestAzureMachinePool_ValidateUpdate(t *testing.T) {
 			amp:     createMachinePoolWithSystemAssignedIdentity(string(uuid.NewUUID())),
 			wantErr: true,
 		},
+		{
+			name:    "azuremachinepool with user-assigned identity changed to system-assigned identity",
+			oldAMP:  createMachinePoolWithUserAssignedIdentity([]string{"azure:///subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity"}),
+			amp:     createMachinePoolWithSystemAssignedIdentity(string(uuid.NewUUID())),
+			wantErr: false,
+		},
 		{
 			name:   "azuremachinepool with invalid MaxSurge and MaxUnavailable rolling upgrade configuration",
 			oldAMP: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{}),
@@ -381,6 +560,66 @@ func TestAzureMachinePool_ValidateUpdate(t *testing.T) {
 			amp:     createMachinePoolWithNetworkConfig("subnet", []infrav1.NetworkInterface{{SubnetName: "testSubnet2"}}),
 			wantErr: true,
 		},
+		{
+			name:    "azuremachinepool with unchanged capacity reservation group",
+			oldAMP:  createMachinePoolWithCapacityReservationGroup("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1", nil),
+			amp:     createMachinePoolWithCapacityReservationGroup("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1", nil),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with changed capacity reservation group",
+			oldAMP:  createMachinePoolWithCapacityReservationGroup("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-1", nil),
+			amp:     createMachinePoolWithCapacityReservationGroup("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/crg-2", nil),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with changed public IP prefix",
+			oldAMP:  createMachinePoolWithPublicIP(true, pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/prefix-1")),
+			amp:     createMachinePoolWithPublicIP(true, pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/prefix-2")),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with changed public IP DNS label",
+			oldAMP:  createMachinePoolWithPublicIPSettings(&infrav1.NodePublicIP{DNSLabelPrefix: "nodes"}),
+			amp:     createMachinePoolWithPublicIPSettings(&infrav1.NodePublicIP{DNSLabelPrefix: "workers"}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with removed public IPs",
+			oldAMP:  createMachinePoolWithPublicIP(true, nil),
+			amp:     createMachinePoolWithPublicIP(false, nil),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with changed spot max price",
+			oldAMP:  createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{MaxPrice: &maxPrice}),
+			amp:     createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{MaxPrice: &newMaxPrice}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with removed spot max price",
+			oldAMP:  createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{MaxPrice: &maxPrice}),
+			amp:     createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with changed spot eviction policy",
+			oldAMP:  createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{EvictionPolicy: &deallocatePolicy}),
+			amp:     createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{EvictionPolicy: &deletePolicy}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with added spot vm options",
+			oldAMP:  createMachinePoolWithSpotVMOptions(nil),
+			amp:     createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with removed spot vm options",
+			oldAMP:  createMachinePoolWithSpotVMOptions(&infrav1.SpotVMOptions{}),
+			amp:     createMachinePoolWithSpotVMOptions(nil),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -530,6 +769,26 @@ func createMachinePoolWithSharedImage(subscriptionID, resourceGroup, name, galle
 	}
 }
 
+func createMachinePoolWithComputeGalleryImage(gallery, name, version string, directShared bool) *AzureMachinePool {
+	image := infrav1.Image{
+		ComputeGallery: &infrav1.AzureComputeGalleryImage{
+			Gallery:      gallery,
+			Name:         name,
+			Version:      version,
+			DirectShared: directShared,
+		},
+	}
+
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				Image:        &image,
+				SSHPublicKey: validSSHPublicKey,
+			},
+		},
+	}
+}
+
 func createMachinePoolWithNetworkConfig(subnetName string, interfaces []infrav1.NetworkInterface) *AzureMachinePool {
 	return &AzureMachinePool{
 		Spec: AzureMachinePoolSpec{
@@ -628,6 +887,57 @@ func createMachinePoolWithStrategy(strategy AzureMachinePoolDeploymentStrategy)
 	}
 }
 
+func createMachinePoolWithSecurityProfile(securityProfile *infrav1.SecurityProfile) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				SecurityProfile: securityProfile,
+			},
+		},
+	}
+}
+
+func createMachinePoolWithCapacityReservationGroup(capacityReservationGroupID string, spotVMOptions *infrav1.SpotVMOptions) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				SSHPublicKey:  validSSHPublicKey,
+				SpotVMOptions: spotVMOptions,
+			},
+			CapacityReservationGroupID: capacityReservationGroupID,
+		},
+	}
+}
+
+func createMachinePoolWithPublicIP(allocatePublicIP bool, publicIPPrefixID *string) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				SSHPublicKey:     validSSHPublicKey,
+				AllocatePublicIP: allocatePublicIP,
+				PublicIPPrefixID: publicIPPrefixID,
+			},
+		},
+	}
+}
+
+func createMachinePoolWithPublicIPSettings(publicIP *infrav1.NodePublicIP) *AzureMachinePool {
+	amp := createMachinePoolWithPublicIP(true, nil)
+	amp.Spec.Template.PublicIP = publicIP
+	return amp
+}
+
+func createMachinePoolWithSpotVMOptions(spotVMOptions *infrav1.SpotVMOptions) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				SSHPublicKey:  validSSHPublicKey,
+				SpotVMOptions: spotVMOptions,
+			},
+		},
+	}
+}
+
 func createMachinePoolWithOrchestrationMode(mode compute.OrchestrationMode) *AzureMachinePool {
 	return &AzureMachinePool{
 		Spec: AzureMachinePoolSpec{
@@ -636,6 +946,66 @@ func createMachinePoolWithOrchestrationMode(mode compute.OrchestrationMode) *Azu
 	}
 }
 
+func createMachinePoolWithPlatformFaultDomainCount(mode compute.OrchestrationMode, platformFaultDomainCount *int32) *AzureMachinePool {
+	amp := createMachinePoolWithOrchestrationMode(mode)
+	amp.Spec.PlatformFaultDomainCount = platformFaultDomainCount
+	return amp
+}
+
+func TestAzureMachinePool_ValidateOrchestrationModeUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldAMP  *AzureMachinePool
+		amp     *AzureMachinePool
+		wantErr bool
+	}{
+		{
+			name:    "platform fault domain count in Flexible orchestration mode",
+			amp:     createMachinePoolWithPlatformFaultDomainCount(compute.OrchestrationModeFlexible, pointer.Int32(2)),
+			wantErr: false,
+		},
+		{
+			name:    "platform fault domain count in Uniform orchestration mode",
+			amp:     createMachinePoolWithPlatformFaultDomainCount(compute.OrchestrationModeUniform, pointer.Int32(2)),
+			wantErr: true,
+		},
+		{
+			name:    "unchanged orchestration mode and platform fault domain count",
+			oldAMP:  createMachinePoolWithPlatformFaultDomainCount(compute.OrchestrationModeFlexible, pointer.Int32(2)),
+			amp:     createMachinePoolWithPlatformFaultDomainCount(compute.OrchestrationModeFlexible, pointer.Int32(2)),
+			wantErr: false,
+		},
+		{
+			name:    "changed orchestration mode",
+			oldAMP:  createMachinePoolWithOrchestrationMode(compute.OrchestrationModeUniform),
+			amp:     createMachinePoolWithOrchestrationMode(compute.OrchestrationModeFlexible),
+			wantErr: true,
+		},
+		{
+			name:    "changed platform fault domain count",
+			oldAMP:  createMachinePoolWithPlatformFaultDomainCount(compute.OrchestrationModeFlexible, nil),
+			amp:     createMachinePoolWithPlatformFaultDomainCount(compute.OrchestrationModeFlexible, pointer.Int32(3)),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var old runtime.Object
+			if tc.oldAMP != nil {
+				old = tc.oldAMP
+			}
+			err := tc.amp.ValidateOrchestrationModeUpdate(old)()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureMachinePool_ValidateCreateFailure(t *testing.T) {
 	g := NewWithT(t)
 
@@ -55,6 +55,10 @@ type (
 		// +optional
 		ProvisioningState *infrav1.ProvisioningState `json:"provisioningState"`
 
+		// PowerState is the power state of the Azure virtual machine instance, e.g. running, stopped or deallocated.
+		// +optional
+		PowerState string `json:"powerState,omitempty"`
+
 		// InstanceName is the name of the Machine Instance within the VMSS
 		// +optional
 		InstanceName string `json:"instanceName"`
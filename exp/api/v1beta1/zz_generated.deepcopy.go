@@ -237,7 +237,9 @@ func (in *AzureMachinePoolMachineStatus) DeepCopyInto(out *AzureMachinePoolMachi
 	if in.LongRunningOperationStates != nil {
 		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
 		*out = make(apiv1beta1.Futures, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -299,6 +301,11 @@ func (in *AzureMachinePoolMachineTemplate) DeepCopyInto(out *AzureMachinePoolMac
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GPUDriver != nil {
+		in, out := &in.GPUDriver, &out.GPUDriver
+		*out = new(apiv1beta1.GPUDriver)
+		**out = **in
+	}
 	if in.NetworkInterfaces != nil {
 		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
 		*out = make([]apiv1beta1.NetworkInterface, len(*in))
@@ -306,6 +313,16 @@ func (in *AzureMachinePoolMachineTemplate) DeepCopyInto(out *AzureMachinePoolMac
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PublicIPPrefixID != nil {
+		in, out := &in.PublicIPPrefixID, &out.PublicIPPrefixID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(apiv1beta1.NodePublicIP)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolMachineTemplate.
@@ -350,6 +367,11 @@ func (in *AzureMachinePoolSpec) DeepCopyInto(out *AzureMachinePoolSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.PlatformFaultDomainCount != nil {
+		in, out := &in.PlatformFaultDomainCount, &out.PlatformFaultDomainCount
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolSpec.
@@ -406,7 +428,14 @@ func (in *AzureMachinePoolStatus) DeepCopyInto(out *AzureMachinePoolStatus) {
 	if in.LongRunningOperationStates != nil {
 		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
 		*out = make(apiv1beta1.Futures, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OperationStatus != nil {
+		in, out := &in.OperationStatus, &out.OperationStatus
+		*out = new(apiv1beta1.OperationStatus)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -28,9 +28,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/throttle"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -58,6 +62,10 @@ type (
 		ReconcileTimeout              time.Duration
 		WatchFilterValue              string
 		createAzureMachinePoolService azureMachinePoolServiceCreator
+		// ServiceOptions configure how the services reconcile Azure resources.
+		ServiceOptions scope.ServiceOptions
+		// InstanceCache caches the instances of the scale sets. It is nil when caching is disabled.
+		InstanceCache *scalesets.InstanceCache
 	}
 
 	// annotationReaderWriter provides an interface to read and write annotations.
@@ -67,15 +75,17 @@ type (
 	}
 )
 
-type azureMachinePoolServiceCreator func(machinePoolScope *scope.MachinePoolScope) (*azureMachinePoolService, error)
+type azureMachinePoolServiceCreator func(machinePoolScope *scope.MachinePoolScope, instanceCache *scalesets.InstanceCache) (*azureMachinePoolService, error)
 
 // NewAzureMachinePoolReconciler returns a new AzureMachinePoolReconciler instance.
-func NewAzureMachinePoolReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureMachinePoolReconciler {
+func NewAzureMachinePoolReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serviceOptions scope.ServiceOptions, instanceCache *scalesets.InstanceCache) *AzureMachinePoolReconciler {
 	ampr := &AzureMachinePoolReconciler{
 		Client:           client,
 		Recorder:         recorder,
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		ServiceOptions:   serviceOptions,
+		InstanceCache:    instanceCache,
 	}
 
 	ampr.createAzureMachinePoolService = newAzureMachinePoolService
@@ -102,9 +112,9 @@ func (ampr *AzureMachinePoolReconciler) SetupWithManager(ctx context.Context, mg
 		return errors.Wrapf(err, "failed to create AzureCluster to AzureMachinePools mapper")
 	}
 
-	c, err := ctrl.NewControllerManagedBy(mgr).
-		WithOptions(options.Options).
-		For(&infrav1exp.AzureMachinePool{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options.Options)
+	c, err := infracontroller.ForWithPriority(b, &infrav1exp.AzureMachinePool{}, "AzureMachinePool", options.LowPriorityDelay).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, ampr.WatchFilterValue)).
 		// watch for changes in CAPI MachinePool resources
 		Watches(
@@ -209,10 +219,15 @@ func (ampr *AzureMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	logger = logger.WithValues("cluster", cluster.Name)
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, azMachinePool) {
-		logger.V(2).Info("AzureMachinePool or linked Cluster is marked as paused. Won't reconcile")
-		return ctrl.Result{}, nil
+	// Return early if the object or Cluster is paused. Long running operations which are still in flight are only
+	// polled until they complete, so that clusterctl move doesn't leave them behind.
+	paused := annotations.IsPaused(cluster, azMachinePool)
+	if paused {
+		if !futures.InFlight(azMachinePool) {
+			logger.V(2).Info("AzureMachinePool or linked Cluster is marked as paused. Won't reconcile")
+			return ctrl.Result{}, nil
+		}
+		logger.V(2).Info("AzureMachinePool or linked Cluster is marked as paused. Polling in-flight operations until they complete")
 	}
 
 	logger = logger.WithValues("AzureCluster", cluster.Spec.InfrastructureRef.Name)
@@ -228,9 +243,10 @@ func (ampr *AzureMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	// Create the cluster scope
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
-		Client:       ampr.Client,
-		Cluster:      cluster,
-		AzureCluster: azureCluster,
+		Client:         ampr.Client,
+		Cluster:        cluster,
+		AzureCluster:   azureCluster,
+		ServiceOptions: ampr.ServiceOptions,
 	})
 	if err != nil {
 		return reconcile.Result{}, err
@@ -254,6 +270,10 @@ func (ampr *AzureMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.
 		}
 	}()
 
+	if paused {
+		return infracontroller.PollFuturesWhilePaused(ctx, azMachinePool, clusterScope)
+	}
+
 	// Handle deleted machine pools
 	if !azMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
 		return ampr.reconcileDelete(ctx, machinePoolScope, clusterScope)
@@ -293,7 +313,16 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 		return reconcile.Result{}, nil
 	}
 
-	ams, err := ampr.createAzureMachinePoolService(machinePoolScope)
+	if requeueAfter, throttled := throttle.RequeueAfter(machinePoolScope.SubscriptionID(), ampr.ServiceOptions.ARMRateLimitRemainingThreshold); throttled {
+		log.Info("Throttling the reconciliation of Azure resources because the subscription is close to its Azure Resource Manager request limit", "requeueAfter", requeueAfter)
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	if err := machinePoolScope.InitMachinePoolCache(ctx); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to initialize machine pool cache")
+	}
+
+	ams, err := ampr.createAzureMachinePoolService(machinePoolScope, ampr.InstanceCache)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed creating a newAzureMachinePoolService")
 	}
@@ -304,6 +333,7 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
 				log.Error(err, "failed to reconcile AzureMachinePool", "name", machinePoolScope.Name())
+				machinePoolScope.Notify(notify.ProvisionFailed, err.Error())
 				return reconcile.Result{}, nil
 			}
 
@@ -346,7 +376,7 @@ func (ampr *AzureMachinePoolReconciler) reconcileDelete(ctx context.Context, mac
 	log.V(2).Info("handling deleted AzureMachinePool")
 
 	if infracontroller.ShouldDeleteIndividualResources(ctx, clusterScope) {
-		amps, err := ampr.createAzureMachinePoolService(machinePoolScope)
+		amps, err := ampr.createAzureMachinePoolService(machinePoolScope, ampr.InstanceCache)
 		if err != nil {
 			return reconcile.Result{}, errors.Wrap(err, "failed creating a new AzureMachinePoolService")
 		}
@@ -360,5 +390,6 @@ func (ampr *AzureMachinePoolReconciler) reconcileDelete(ctx context.Context, mac
 	// Delete succeeded, remove finalizer
 	log.V(4).Info("removing finalizer for AzureMachinePool")
 	controllerutil.RemoveFinalizer(machinePoolScope.AzureMachinePool, expv1.MachinePoolFinalizer)
+	machinePoolScope.Notify(notify.DeletionComplete, "machine pool infrastructure has been deleted")
 	return reconcile.Result{}, nil
 }
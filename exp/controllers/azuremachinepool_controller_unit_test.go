@@ -51,6 +51,7 @@ func Test_newAzureMachinePoolService(t *testing.T) {
 	clusterMock.EXPECT().BaseURI().AnyTimes()
 	clusterMock.EXPECT().Authorizer().AnyTimes()
 	clusterMock.EXPECT().Location().Return(cluster.Spec.Location)
+	clusterMock.EXPECT().CloudEnvironment().AnyTimes()
 	clusterMock.EXPECT().HashKey().Return("fakeCluster")
 
 	mps := &scope.MachinePoolScope{
@@ -63,7 +64,7 @@ func Test_newAzureMachinePoolService(t *testing.T) {
 		},
 	}
 
-	subject, err := newAzureMachinePoolService(mps)
+	subject, err := newAzureMachinePoolService(mps, nil)
 	g := NewWithT(t)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(subject).NotTo(BeNil())
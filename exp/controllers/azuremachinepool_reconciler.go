@@ -22,9 +22,11 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/previewfeatures"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
+	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -36,16 +38,21 @@ type azureMachinePoolService struct {
 }
 
 // newAzureMachinePoolService populates all the services based on input scope.
-func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope) (*azureMachinePoolService, error) {
+func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope, instanceCache *scalesets.InstanceCache) (*azureMachinePoolService, error) {
 	cache, err := resourceskus.GetCache(machinePoolScope, machinePoolScope.Location())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create a NewCache")
 	}
 
+	featureCache, err := previewfeatures.GetCache(machinePoolScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a preview features cache")
+	}
+
 	return &azureMachinePoolService{
 		scope: machinePoolScope,
 		services: []azure.ServiceReconciler{
-			scalesets.New(machinePoolScope, cache),
+			scalesets.New(machinePoolScope, cache, featureCache, instanceCache),
 			roleassignments.New(machinePoolScope),
 		},
 		skuCache: cache,
@@ -65,7 +72,7 @@ func (s *azureMachinePoolService) Reconcile(ctx context.Context) error {
 	}
 
 	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
+		if err := infracontroller.ReconcileService(ctx, service); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureMachinePool service %s", service.Name())
 		}
 	}
@@ -80,7 +87,7 @@ func (s *azureMachinePoolService) Delete(ctx context.Context) error {
 
 	// Delete services in reverse order of creation.
 	for i := len(s.services) - 1; i >= 0; i-- {
-		if err := s.services[i].Delete(ctx); err != nil {
+		if err := infracontroller.DeleteService(ctx, s.services[i]); err != nil {
 			return errors.Wrapf(err, "failed to delete AzureMachinePool service %s", s.services[i].Name())
 		}
 	}
@@ -55,7 +55,7 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 				gomock.InOrder(
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("foo"))
+					two.Name().Return("foo").AnyTimes())
 			},
 		},
 	}
@@ -73,6 +73,10 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			// The name of each service is also used for its metrics.
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachinePoolService{
 				scope: &scope.MachinePoolScope{
@@ -128,7 +132,7 @@ func TestAzureMachinePoolServiceDelete(t *testing.T) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("test-service-two"))
+					two.Name().Return("test-service-two").AnyTimes())
 			},
 		},
 	}
@@ -146,6 +150,10 @@ func TestAzureMachinePoolServiceDelete(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			// The name of each service is also used for its metrics.
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachinePoolService{
 				scope: &scope.MachinePoolScope{
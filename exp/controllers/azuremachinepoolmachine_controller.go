@@ -29,12 +29,14 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesetvms"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -49,7 +51,7 @@ import (
 )
 
 type (
-	azureMachinePoolMachineReconcilerFactory func(*scope.MachinePoolMachineScope) azure.Reconciler
+	azureMachinePoolMachineReconcilerFactory func(*scope.MachinePoolMachineScope, *scalesets.InstanceCache) azure.Reconciler
 
 	// AzureMachinePoolMachineController handles Kubernetes change events for AzureMachinePoolMachine resources.
 	AzureMachinePoolMachineController struct {
@@ -59,6 +61,10 @@ type (
 		ReconcileTimeout  time.Duration
 		WatchFilterValue  string
 		reconcilerFactory azureMachinePoolMachineReconcilerFactory
+		// ServiceOptions configure how the services reconcile Azure resources.
+		ServiceOptions scope.ServiceOptions
+		// InstanceCache caches the instances of the scale sets. It is nil when caching is disabled.
+		InstanceCache *scalesets.InstanceCache
 	}
 
 	azureMachinePoolMachineReconciler struct {
@@ -68,13 +74,15 @@ type (
 )
 
 // NewAzureMachinePoolMachineController creates a new AzureMachinePoolMachineController to handle updates to Azure Machine Pool Machines.
-func NewAzureMachinePoolMachineController(c client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureMachinePoolMachineController {
+func NewAzureMachinePoolMachineController(c client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serviceOptions scope.ServiceOptions, instanceCache *scalesets.InstanceCache) *AzureMachinePoolMachineController {
 	return &AzureMachinePoolMachineController{
 		Client:            c,
 		Recorder:          recorder,
 		ReconcileTimeout:  reconcileTimeout,
 		WatchFilterValue:  watchFilterValue,
 		reconcilerFactory: newAzureMachinePoolMachineReconciler,
+		ServiceOptions:    serviceOptions,
+		InstanceCache:     instanceCache,
 	}
 }
 
@@ -201,9 +209,10 @@ func (ampmr *AzureMachinePoolMachineController) Reconcile(ctx context.Context, r
 
 	// Create the cluster scope
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
-		Client:       ampmr.Client,
-		Cluster:      cluster,
-		AzureCluster: azureCluster,
+		Client:         ampmr.Client,
+		Cluster:        cluster,
+		AzureCluster:   azureCluster,
+		ServiceOptions: ampmr.ServiceOptions,
 	})
 	if err != nil {
 		return reconcile.Result{}, err
@@ -253,7 +262,7 @@ func (ampmr *AzureMachinePoolMachineController) reconcileNormal(ctx context.Cont
 		return reconcile.Result{}, nil
 	}
 
-	ampms := ampmr.reconcilerFactory(machineScope)
+	ampms := ampmr.reconcilerFactory(machineScope, ampmr.InstanceCache)
 	if err := ampms.Reconcile(ctx); err != nil {
 		// Handle transient and terminal errors
 		var reconcileError azure.ReconcileError
@@ -317,11 +326,21 @@ func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Cont
 	}
 
 	// deleting a single machine
-	// 1) drain the node (TODO: @devigned)
-	// 2) after drained, delete the infrastructure
-	// 3) remove finalizer
+	// 1) wait for pre-drain delete hooks to be removed
+	// 2) drain the node
+	// 3) after drained, delete the infrastructure
+	// 4) remove finalizer
+
+	// pre-drain.delete lifecycle hook
+	// Return early without error, will requeue if/when the hook owner removes the annotation.
+	if annotations.HasWithPrefix(clusterv1.PreDrainDeleteHookAnnotationPrefix, machineScope.AzureMachinePoolMachine.Annotations) {
+		log.V(2).Info("waiting for pre-drain delete hooks to be removed", "name", machineScope.Name())
+		conditions.MarkFalse(machineScope.AzureMachinePoolMachine, clusterv1.PreDrainDeleteHookSucceededCondition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, "")
+		return reconcile.Result{}, nil
+	}
+	conditions.MarkTrue(machineScope.AzureMachinePoolMachine, clusterv1.PreDrainDeleteHookSucceededCondition)
 
-	ampms := ampmr.reconcilerFactory(machineScope)
+	ampms := ampmr.reconcilerFactory(machineScope, ampmr.InstanceCache)
 	if err := ampms.Delete(ctx); err != nil {
 		// Handle transient and terminal errors
 		var reconcileError azure.ReconcileError
@@ -345,10 +364,10 @@ func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Cont
 	return reconcile.Result{}, nil
 }
 
-func newAzureMachinePoolMachineReconciler(scope *scope.MachinePoolMachineScope) azure.Reconciler {
+func newAzureMachinePoolMachineReconciler(scope *scope.MachinePoolMachineScope, instanceCache *scalesets.InstanceCache) azure.Reconciler {
 	return &azureMachinePoolMachineReconciler{
 		Scope:              scope,
-		scalesetVMsService: scalesetvms.NewService(scope),
+		scalesetVMsService: scalesetvms.NewService(scope, instanceCache),
 	}
 }
 
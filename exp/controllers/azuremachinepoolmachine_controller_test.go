@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	gomock2 "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -65,6 +66,7 @@ func TestAzureMachinePoolMachineReconciler_Reconcile(t *testing.T) {
 				ampm.DeletionTimestamp = &metav1.Time{
 					Time: time.Now(),
 				}
+				ampm.Finalizers = []string{infrav1exp.AzureMachinePoolMachineFinalizer}
 				reconciler.Delete(gomock2.AContext()).Return(nil)
 				cb.WithObjects(cluster, azCluster, mp, amp, ampm)
 			},
@@ -72,6 +74,24 @@ func TestAzureMachinePoolMachineReconciler_Reconcile(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 			},
 		},
+		{
+			Name: "should not drain and delete while a pre-drain delete hook is present",
+			Setup: func(cb *fake.ClientBuilder, reconciler *mock_azure.MockReconcilerMockRecorder) {
+				cluster, azCluster, mp, amp, ampm := getAReadyMachinePoolMachineCluster()
+				ampm.DeletionTimestamp = &metav1.Time{
+					Time: time.Now(),
+				}
+				ampm.Finalizers = []string{infrav1exp.AzureMachinePoolMachineFinalizer}
+				ampm.Annotations = map[string]string{
+					clusterv1.PreDrainDeleteHookAnnotationPrefix + "/my-hook": "my-controller",
+				}
+				cb.WithObjects(cluster, azCluster, mp, amp, ampm)
+			},
+			Verify: func(g *WithT, result ctrl.Result, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(ctrl.Result{}))
+			},
+		},
 	}
 
 	os.Setenv(auth.ClientID, "fooClient")
@@ -103,8 +123,8 @@ func TestAzureMachinePoolMachineReconciler_Reconcile(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			c.Setup(cb, reconciler.EXPECT())
-			controller := NewAzureMachinePoolMachineController(cb.Build(), nil, 30*time.Second, "foo")
-			controller.reconcilerFactory = func(_ *scope.MachinePoolMachineScope) azure.Reconciler {
+			controller := NewAzureMachinePoolMachineController(cb.Build(), nil, 30*time.Second, "foo", scope.ServiceOptions{}, nil)
+			controller.reconcilerFactory = func(_ *scope.MachinePoolMachineScope, _ *scalesets.InstanceCache) azure.Reconciler {
 				return reconciler
 			}
 			res, err := controller.Reconcile(context.TODO(), ctrl.Request{
@@ -47,6 +47,10 @@ const (
 	// owner: @upxinxin
 	// alpha: v1.8
 	EdgeZone featuregate.Feature = "EdgeZone"
+
+	// ResourceGraph is the feature gate for the Azure Resource Graph inventory of the resources owned by clusters.
+	// alpha: v1.10
+	ResourceGraph featuregate.Feature = "ResourceGraph"
 )
 
 func init() {
@@ -60,4 +64,5 @@ var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AKS:               {Default: true, PreRelease: featuregate.GA, LockToDefault: true}, // Remove in 1.12
 	AKSResourceHealth: {Default: false, PreRelease: featuregate.Alpha},
 	EdgeZone:          {Default: false, PreRelease: featuregate.Alpha},
+	ResourceGraph:     {Default: false, PreRelease: featuregate.Alpha},
 }
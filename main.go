@@ -37,17 +37,23 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	infrav1controllersexp "sigs.k8s.io/cluster-api-provider-azure/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/notify"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/throttle"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -60,6 +66,9 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// eventGridKeyEnvVar is the environment variable holding the access key of the notification Event Grid topic.
+const eventGridKeyEnvVar = "AZURE_EVENTGRID_TOPIC_KEY"
+
 func init() {
 	klog.InitFlags(nil)
 
@@ -69,6 +78,7 @@ func init() {
 	_ = clusterv1.AddToScheme(scheme)
 	_ = expv1.AddToScheme(scheme)
 	_ = kubeadmv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 
 	// Add aadpodidentity v1 to the scheme.
@@ -100,10 +110,22 @@ var (
 	azureMachinePoolMachineConcurrency int
 	debouncingTimer                    time.Duration
 	syncPeriod                         time.Duration
+	lowPriorityReconcileDelay          time.Duration
 	healthAddr                         string
 	webhookPort                        int
 	reconcileTimeout                   time.Duration
 	enableTracing                      bool
+	tracingEndpoint                    string
+	tracingSamplingRatio               float64
+	notificationWebhookURL             string
+	notificationEventGridEndpoint      string
+	driftPolicy                        string
+	defaultAzureTags                   map[string]string
+	azureServiceReconcileTimeout       time.Duration
+	azureServiceReconcileTimeouts      map[string]string
+	armRateLimitRemainingThreshold     int64
+	vmssInstanceCacheTTL               time.Duration
+	managementClusterEgressIPs         []string
 )
 
 // InitFlags initializes all command-line flags.
@@ -205,6 +227,12 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)",
 	)
 
+	fs.DurationVar(&lowPriorityReconcileDelay,
+		"low-priority-reconcile-delay",
+		0,
+		"How long the reconciliation of resources which are neither new nor being deleted is delayed after a periodic resync or a restart of the controller, so that creations and deletions are reconciled first (e.g. 30s). Disabled when 0",
+	)
+
 	fs.StringVar(&healthAddr,
 		"health-addr",
 		":9440",
@@ -230,6 +258,79 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable tracing to the opentelemetry-collector service in the same namespace.",
 	)
 
+	fs.StringVar(
+		&tracingEndpoint,
+		"tracing-endpoint",
+		ot.DefaultTracingEndpoint,
+		"OTLP gRPC endpoint traces are exported to when tracing is enabled.",
+	)
+
+	fs.Float64Var(
+		&tracingSamplingRatio,
+		"tracing-sampling-ratio",
+		1,
+		"Share of the traces exported when tracing is enabled, from 0 to 1.",
+	)
+
+	fs.StringVar(
+		&notificationWebhookURL,
+		"notification-webhook-url",
+		"",
+		"URL of a webhook that receives a JSON notification when clusters and machines become ready, fail to provision or are deleted.",
+	)
+
+	fs.StringVar(
+		&notificationEventGridEndpoint,
+		"notification-eventgrid-endpoint",
+		"",
+		"Endpoint of an Event Grid topic that receives lifecycle notifications. The topic access key is read from the "+eventGridKeyEnvVar+" environment variable.",
+	)
+
+	fs.StringVar(
+		&driftPolicy,
+		"drift-policy",
+		string(async.DriftPolicyCorrect),
+		"How Azure resources which no longer match their spec are handled. Correct updates them to match it again, Detect only reports them with events and conditions.",
+	)
+
+	fs.StringToStringVar(
+		&defaultAzureTags,
+		"default-azure-tags",
+		nil,
+		"Tags applied to all the Azure resources created by the controllers (e.g. costCenter=1234,owner=team-a). The additionalTags of clusters and machines take precedence over them.",
+	)
+
+	fs.DurationVar(&azureServiceReconcileTimeout,
+		"azure-service-reconcile-timeout",
+		reconciler.DefaultAzureServiceReconcileTimeout,
+		"The maximum duration the reconcile of an Azure service can run before its long running operations are resumed in a later reconcile loop (e.g. 12s)",
+	)
+
+	fs.StringToStringVar(
+		&azureServiceReconcileTimeouts,
+		"azure-service-reconcile-timeouts",
+		nil,
+		"The maximum durations the reconcile of specific Azure services can run, overriding --azure-service-reconcile-timeout (e.g. virtualnetworkgateways=5m,virtualmachine=30s).",
+	)
+
+	fs.Int64Var(&armRateLimitRemainingThreshold,
+		"arm-ratelimit-remaining-threshold",
+		throttle.DefaultRemainingThreshold,
+		"The number of remaining Azure Resource Manager requests of a subscription below which the reconciles of its Azure resources are delayed. Reconciles are also delayed while Azure throttles the requests of a subscription.",
+	)
+
+	fs.DurationVar(&vmssInstanceCacheTTL,
+		"vmss-instance-cache-ttl",
+		0,
+		"How long the instance lists of virtual machine scale sets are cached between AzureMachinePool reconciles (e.g. 1m). The cache of a scale set is invalidated when CAPZ modifies it. Disabled when 0",
+	)
+
+	fs.StringSliceVar(&managementClusterEgressIPs,
+		"management-cluster-egress-ips",
+		nil,
+		"IPs or CIDR ranges the management cluster reaches AKS API servers from, added to the authorized IP ranges of the AzureManagedControlPlanes setting apiServerAccessProfile.includeManagementClusterEgressIPs (e.g. 20.1.2.3,20.1.4.0/28).",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
 
@@ -291,11 +392,28 @@ func main() {
 	// Initialize event recorder.
 	record.InitFromRecorder(mgr.GetEventRecorderFor("azure-controller"))
 
+	// Initialize lifecycle notifications.
+	var notifiers notify.Notifiers
+	if notificationWebhookURL != "" {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(notificationWebhookURL))
+	}
+	if notificationEventGridEndpoint != "" {
+		notifiers = append(notifiers, notify.NewEventGridNotifier(notificationEventGridEndpoint, os.Getenv(eventGridKeyEnvVar)))
+	}
+	if len(notifiers) > 0 {
+		queueNotifier := notify.NewQueueNotifier(notifiers, notify.DefaultQueueSize)
+		if err := mgr.Add(queueNotifier); err != nil {
+			setupLog.Error(err, "unable to add the notification queue to the manager")
+			os.Exit(1)
+		}
+		notify.InitFromNotifier(queueNotifier)
+	}
+
 	// Setup the context that's going to be used in controllers and for the manager.
 	ctx := ctrl.SetupSignalHandler()
 
 	if enableTracing {
-		if err := ot.RegisterTracing(ctx, setupLog); err != nil {
+		if err := ot.RegisterTracing(ctx, setupLog, tracingEndpoint, tracingSamplingRatio); err != nil {
 			setupLog.Error(err, "unable to initialize tracing")
 			os.Exit(1)
 		}
@@ -319,6 +437,8 @@ func main() {
 }
 
 func registerControllers(ctx context.Context, mgr manager.Manager) {
+	serviceOptions := newServiceOptions()
+
 	machineCache, err := coalescing.NewRequestCache(debouncingTimer)
 	if err != nil {
 		setupLog.Error(err, "failed to build machineCache ReconcileCache")
@@ -327,7 +447,8 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		mgr.GetEventRecorderFor("azuremachine-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
-	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache}); err != nil {
+		serviceOptions,
+	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache, LowPriorityDelay: lowPriorityReconcileDelay}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureMachine")
 		os.Exit(1)
 	}
@@ -341,7 +462,8 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		mgr.GetEventRecorderFor("azurecluster-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
-	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: clusterCache}); err != nil {
+		serviceOptions,
+	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: clusterCache, LowPriorityDelay: lowPriorityReconcileDelay}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureCluster")
 		os.Exit(1)
 	}
@@ -376,6 +498,17 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&controllers.AzureClusterIdentityReconciler{
+		Client:           mgr.GetClient(),
+		APIReader:        mgr.GetAPIReader(),
+		Recorder:         mgr.GetEventRecorderFor("azureclusteridentity-reconciler"),
+		ReconcileTimeout: reconcileTimeout,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AzureClusterIdentity")
+		os.Exit(1)
+	}
+
 	// just use CAPI MachinePool feature flag rather than create a new one
 	setupLog.V(1).Info(fmt.Sprintf("%+v\n", feature.Gates))
 	if feature.Gates.Enabled(capifeature.MachinePool) {
@@ -384,12 +517,20 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			setupLog.Error(err, "failed to build mpCache ReconcileCache")
 		}
 
+		instanceCache, err := scalesets.NewInstanceCache(vmssInstanceCacheTTL)
+		if err != nil {
+			setupLog.Error(err, "unable to create the scale set instance cache")
+			os.Exit(1)
+		}
+
 		if err := infrav1controllersexp.NewAzureMachinePoolReconciler(
 			mgr.GetClient(),
 			mgr.GetEventRecorderFor("azuremachinepool-reconciler"),
 			reconcileTimeout,
 			watchFilterValue,
-		).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachinePoolConcurrency}, Cache: mpCache}); err != nil {
+			serviceOptions,
+			instanceCache,
+		).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachinePoolConcurrency}, Cache: mpCache, LowPriorityDelay: lowPriorityReconcileDelay}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureMachinePool")
 			os.Exit(1)
 		}
@@ -404,6 +545,8 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			mgr.GetEventRecorderFor("azuremachinepoolmachine-reconciler"),
 			reconcileTimeout,
 			watchFilterValue,
+			serviceOptions,
+			instanceCache,
 		).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachinePoolMachineConcurrency}, Cache: mpmCache}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureMachinePoolMachine")
 			os.Exit(1)
@@ -429,6 +572,7 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			mgr.GetEventRecorderFor("azuremanagedmachinepoolmachine-reconciler"),
 			reconcileTimeout,
 			watchFilterValue,
+			serviceOptions,
 		).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachinePoolConcurrency}, Cache: mmpmCache}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureManagedMachinePool")
 			os.Exit(1)
@@ -454,18 +598,56 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			setupLog.Error(err, "failed to build mcpCache ReconcileCache")
 		}
 
+		egressIPRanges, err := scope.ParseIPRanges(managementClusterEgressIPs)
+		if err != nil {
+			setupLog.Error(err, "invalid --management-cluster-egress-ips flag")
+			os.Exit(1)
+		}
+
 		if err := (&controllers.AzureManagedControlPlaneReconciler{
-			Client:           mgr.GetClient(),
-			Recorder:         mgr.GetEventRecorderFor("azuremanagedcontrolplane-reconciler"),
-			ReconcileTimeout: reconcileTimeout,
-			WatchFilterValue: watchFilterValue,
-		}).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: mcpCache}); err != nil {
+			Client:                          mgr.GetClient(),
+			Recorder:                        mgr.GetEventRecorderFor("azuremanagedcontrolplane-reconciler"),
+			ReconcileTimeout:                reconcileTimeout,
+			WatchFilterValue:                watchFilterValue,
+			ManagementClusterEgressIPRanges: egressIPRanges,
+			ServiceOptions:                  serviceOptions,
+		}).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: mcpCache, LowPriorityDelay: lowPriorityReconcileDelay}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureManagedControlPlane")
 			os.Exit(1)
 		}
 	}
 }
 
+// newServiceOptions returns the options the services reconcile Azure resources with, as set by the flags.
+func newServiceOptions() scope.ServiceOptions {
+	switch async.DriftPolicy(driftPolicy) {
+	case async.DriftPolicyCorrect, async.DriftPolicyDetect:
+	default:
+		setupLog.Error(fmt.Errorf("unsupported drift policy %q", driftPolicy), "invalid --drift-policy flag")
+		os.Exit(1)
+	}
+
+	serviceTimeouts := make(map[string]time.Duration, len(azureServiceReconcileTimeouts))
+	for serviceName, value := range azureServiceReconcileTimeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			setupLog.Error(fmt.Errorf("invalid timeout %q for service %q", value, serviceName), "invalid --azure-service-reconcile-timeouts flag")
+			os.Exit(1)
+		}
+		serviceTimeouts[serviceName] = timeout
+	}
+
+	return scope.ServiceOptions{
+		DefaultTags: defaultAzureTags,
+		Timeouts: reconciler.Timeouts{
+			AzureServiceReconcile:  azureServiceReconcileTimeout,
+			AzureServiceReconciles: serviceTimeouts,
+		},
+		DriftPolicy:                    async.DriftPolicy(driftPolicy),
+		ARMRateLimitRemainingThreshold: armRateLimitRemainingThreshold,
+	}
+}
+
 func registerWebhooks(mgr manager.Manager) {
 	if err := (&infrav1.AzureCluster{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AzureCluster")
@@ -482,7 +664,7 @@ func registerWebhooks(mgr manager.Manager) {
 		os.Exit(1)
 	}
 
-	if err := (&infrav1.AzureClusterIdentity{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := infrav1.SetupAzureClusterIdentityWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AzureClusterIdentity")
 		os.Exit(1)
 	}
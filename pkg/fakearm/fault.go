@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault describes a failure injected into the requests matching its method and resource type.
+type Fault struct {
+	// Method is the HTTP method of the requests the fault applies to. Empty matches all methods.
+	Method string
+	// ResourceType is the ARM resource type the fault applies to, e.g. Microsoft.Compute/virtualMachines.
+	// Empty matches all resource types.
+	ResourceType string
+	// Count is the number of matching requests the fault applies to. Zero applies it to all matching requests.
+	Count int
+	// StatusCode, if set, is returned for matching requests instead of processing them.
+	StatusCode int
+	// RetryAfter is returned in the Retry-After header with StatusCode.
+	RetryAfter time.Duration
+	// ErrorCode is the ARM error code returned with StatusCode or, if StatusCode is not set,
+	// the error code long running operations of matching requests fail with.
+	ErrorCode string
+	// Polls is the number of times long running operations of matching requests report being in progress
+	// before completing.
+	Polls int
+}
+
+// Throttle returns a fault throttling the next count requests of the resource type with a 429 status code.
+func Throttle(resourceType string, count int, retryAfter time.Duration) Fault {
+	return Fault{
+		ResourceType: resourceType,
+		Count:        count,
+		StatusCode:   http.StatusTooManyRequests,
+		RetryAfter:   retryAfter,
+		ErrorCode:    "TooManyRequests",
+	}
+}
+
+// AllocationFailure returns a fault failing the next count virtual machine creations or updates with an
+// AllocationFailed error, as when a region or zone is out of capacity.
+func AllocationFailure(count int) Fault {
+	return Fault{
+		Method:       http.MethodPut,
+		ResourceType: "Microsoft.Compute/virtualMachines",
+		Count:        count,
+		ErrorCode:    "AllocationFailed",
+	}
+}
+
+// SlowOperation returns a fault making long running operations on the resource type report being in progress
+// for the given number of polls before completing.
+func SlowOperation(resourceType string, polls int) Fault {
+	return Fault{
+		ResourceType: resourceType,
+		Polls:        polls,
+	}
+}
+
+func (f *Fault) matches(method, resourceType string) bool {
+	// Updates with PATCH are treated like updates with PUT.
+	if method == http.MethodPatch {
+		method = http.MethodPut
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, method) {
+		return false
+	}
+	return f.ResourceType == "" || strings.EqualFold(f.ResourceType, resourceType)
+}
+
+func (f *Fault) errorCode() string {
+	if f.ErrorCode != "" {
+		return f.ErrorCode
+	}
+	return http.StatusText(f.StatusCode)
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakearm provides an in-memory fake of the Azure Resource Manager API with failure injection,
+// which can be used to exercise CAPZ and its Azure clients without an Azure subscription.
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EnvironmentName is the name of the Azure environment served by the fake. CAPZ loads the endpoints of this
+	// environment from the file referenced by the AZURE_ENVIRONMENT_FILEPATH environment variable.
+	EnvironmentName = "AzureStackCloud"
+
+	operationsPath      = "/fakearm/operations/"
+	resourceGroupType   = "Microsoft.Resources/resourceGroups"
+	provisioningStateOK = "Succeeded"
+)
+
+// Server is a fake Azure Resource Manager. Resources are stored in memory, keyed by their case-insensitive ID.
+// Creates, updates and deletes of resources other than resource groups are long running operations
+// which can be polled with the Azure-AsyncOperation header, as with the real ARM API.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	resources  map[string]map[string]interface{}
+	operations map[string]*operation
+	faults     []*Fault
+}
+
+// operation is a long running operation in progress.
+type operation struct {
+	pollsLeft int
+	errorCode string
+	// done is called with the final status of the operation when it completes.
+	done func(status string)
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		resources:  map[string]map[string]interface{}{},
+		operations: map[string]*operation{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Environment returns an Azure environment whose Resource Manager and Active Directory endpoints point to the server.
+func (s *Server) Environment() azureautorest.Environment {
+	env := azureautorest.PublicCloud
+	env.Name = EnvironmentName
+	env.ResourceManagerEndpoint = s.URL + "/"
+	env.ActiveDirectoryEndpoint = s.URL + "/"
+	env.TokenAudience = s.URL + "/"
+	env.ResourceIdentifiers.Graph = s.URL + "/"
+	return env
+}
+
+// WriteEnvironmentFile writes the server's environment to path, for use as AZURE_ENVIRONMENT_FILEPATH together with
+// AZURE_ENVIRONMENT=AzureStackCloud.
+func (s *Server) WriteEnvironmentFile(path string) error {
+	data, err := json.MarshalIndent(s.Environment(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal environment")
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Inject adds a fault to the server. Faults are evaluated in the order they were injected.
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// Resource returns a copy of the resource with the given ID, or nil if it doesn't exist.
+func (s *Server) Resource(id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil
+	}
+	return deepCopy(resource)
+}
+
+// ResourceIDs returns the sorted IDs of all resources stored in the server.
+func (s *Server) ResourceIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.resources))
+	for _, resource := range s.resources {
+		ids = append(ids, resource["id"].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/oauth2/token"), strings.HasSuffix(r.URL.Path, "/oauth2/v2.0/token"):
+		s.token(w)
+		return
+	case strings.HasPrefix(r.URL.Path, operationsPath):
+		s.pollOperation(w, strings.TrimPrefix(r.URL.Path, operationsPath))
+		return
+	}
+
+	id := strings.TrimSuffix(r.URL.Path, "/")
+	resourceType, ok := parseResourceType(id)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "InvalidResourceId", fmt.Sprintf("%s is not a valid resource ID", id))
+		return
+	}
+
+	fault := s.matchFault(r.Method, resourceType)
+	if fault != nil && fault.StatusCode != 0 {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", int(fault.RetryAfter.Seconds())))
+		}
+		writeError(w, fault.StatusCode, fault.errorCode(), fmt.Sprintf("fault injected for %s %s", r.Method, id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, id)
+	case http.MethodHead:
+		if _, ok := s.resources[strings.ToLower(id)]; ok {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case http.MethodPut, http.MethodPatch:
+		s.put(w, r, id, resourceType, fault)
+	case http.MethodDelete:
+		s.delete(w, id, resourceType, fault)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported", r.Method))
+	}
+}
+
+func (s *Server) token(w http.ResponseWriter) {
+	expiresOn := time.Now().Add(time.Hour).Unix()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "fake-token",
+		"token_type":   "Bearer",
+		"expires_in":   "3600",
+		"expires_on":   fmt.Sprintf("%d", expiresOn),
+		"not_before":   fmt.Sprintf("%d", time.Now().Unix()),
+		"resource":     s.URL + "/",
+	})
+}
+
+func (s *Server) get(w http.ResponseWriter, id string) {
+	if resource, ok := s.resources[strings.ToLower(id)]; ok {
+		writeJSON(w, http.StatusOK, resource)
+		return
+	}
+
+	// A GET on a collection lists the resources directly under it.
+	prefix := strings.ToLower(id) + "/"
+	var children []interface{}
+	for key, resource := range s.resources {
+		if strings.HasPrefix(key, prefix) && !strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+			children = append(children, resource)
+		}
+	}
+	if children != nil {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].(map[string]interface{})["id"].(string) < children[j].(map[string]interface{})["id"].(string)
+		})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"value": children})
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("the resource %s was not found", id))
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request, id, resourceType string, fault *Fault) {
+	body := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	key := strings.ToLower(id)
+	existing, exists := s.resources[key]
+	if r.Method == http.MethodPatch {
+		if !exists {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("the resource %s was not found", id))
+			return
+		}
+		body = merge(deepCopy(existing), body)
+	}
+
+	segments := strings.Split(id, "/")
+	body["id"] = id
+	body["name"] = segments[len(segments)-1]
+	body["type"] = resourceType
+	properties, _ := body["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+		body["properties"] = properties
+	}
+
+	status := http.StatusCreated
+	if exists {
+		status = http.StatusOK
+	}
+
+	// Resource groups are created synchronously.
+	if resourceType == resourceGroupType {
+		properties["provisioningState"] = provisioningStateOK
+		s.resources[key] = body
+		writeJSON(w, status, body)
+		return
+	}
+
+	properties["provisioningState"] = "Creating"
+	if exists {
+		properties["provisioningState"] = "Updating"
+	}
+	s.resources[key] = body
+	s.startOperation(w, fault, func(status string) {
+		if resource, ok := s.resources[key]; ok {
+			resource["properties"].(map[string]interface{})["provisioningState"] = status
+		}
+	})
+	writeJSON(w, status, body)
+}
+
+func (s *Server) delete(w http.ResponseWriter, id, resourceType string, fault *Fault) {
+	key := strings.ToLower(id)
+	if _, ok := s.resources[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.startOperation(w, fault, func(status string) {
+		if status != provisioningStateOK {
+			return
+		}
+		for k := range s.resources {
+			if k == key || strings.HasPrefix(k, key+"/") {
+				delete(s.resources, k)
+			}
+		}
+	})
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// startOperation registers a long running operation and sets the headers used to poll it.
+func (s *Server) startOperation(w http.ResponseWriter, fault *Fault, done func(status string)) {
+	op := &operation{done: done}
+	if fault != nil {
+		op.pollsLeft = fault.Polls
+		op.errorCode = fault.ErrorCode
+	}
+	id := uuid.New().String()
+	s.operations[id] = op
+	w.Header().Set("Azure-AsyncOperation", s.URL+operationsPath+id)
+	w.Header().Set("Retry-After", "0")
+}
+
+func (s *Server) pollOperation(w http.ResponseWriter, id string) {
+	op, ok := s.operations[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("operation %s was not found", id))
+		return
+	}
+	if op.pollsLeft > 0 {
+		op.pollsLeft--
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "InProgress"})
+		return
+	}
+	status := provisioningStateOK
+	if op.errorCode != "" {
+		status = "Failed"
+	}
+	if op.done != nil {
+		op.done(status)
+		op.done = nil
+	}
+	if op.errorCode != "" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status": status,
+			"error":  map[string]interface{}{"code": op.errorCode, "message": "fault injected"},
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": status})
+}
+
+// matchFault returns the first fault matching the request and consumes one of its occurrences.
+func (s *Server) matchFault(method, resourceType string) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(method, resourceType) {
+			continue
+		}
+		// Faults on long running operations don't apply to reads.
+		if fault.StatusCode == 0 && (method == http.MethodGet || method == http.MethodHead) {
+			continue
+		}
+		if fault.Count > 0 {
+			fault.Count--
+			if fault.Count == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// parseResourceType returns the ARM resource type of a resource or collection ID such as
+// /subscriptions/<sub>/resourceGroups/<rg>/providers/Microsoft.Network/virtualNetworks/<vnet>/subnets/<subnet>.
+func parseResourceType(id string) (string, bool) {
+	segments := strings.Split(strings.TrimPrefix(id, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return "", false
+	}
+	if len(segments) <= 4 {
+		if len(segments) > 2 && !strings.EqualFold(segments[2], "resourceGroups") {
+			return "", false
+		}
+		return resourceGroupType, true
+	}
+	if !strings.EqualFold(segments[2], "resourceGroups") || !strings.EqualFold(segments[4], "providers") || len(segments) < 7 {
+		return "", false
+	}
+	parts := []string{segments[5]}
+	for i := 6; i < len(segments); i += 2 {
+		parts = append(parts, segments[i])
+	}
+	return strings.Join(parts, "/"), true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": message},
+	})
+}
+
+func deepCopy(in map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(in)
+	out := map[string]interface{}{}
+	_ = json.Unmarshal(data, &out)
+	return out
+}
+
+// merge applies a JSON merge patch to dst.
+func merge(dst, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		patchMap, isMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		switch {
+		case value == nil:
+			delete(dst, key)
+		case isMap && dstIsMap:
+			dst[key] = merge(dstMap, patchMap)
+		default:
+			dst[key] = value
+		}
+	}
+	return dst
+}
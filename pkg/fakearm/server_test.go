@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	fakeSubscriptionID = "123"
+	vnetID             = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	subnetID           = vnetID + "/subnets/my-subnet"
+)
+
+func newVnetClient(s *Server) network.VirtualNetworksClient {
+	client := network.NewVirtualNetworksClientWithBaseURI(s.URL, fakeSubscriptionID)
+	client.Authorizer = autorest.NullAuthorizer{}
+	client.PollingDelay = time.Millisecond
+	client.RetryAttempts = 1
+	return client
+}
+
+func createVnet(ctx context.Context, s *Server) error {
+	client := newVnetClient(s)
+	future, err := client.CreateOrUpdate(ctx, "my-rg", "my-vnet", network.VirtualNetwork{
+		Location: pointer.String("westus"),
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+			Subnets: &[]network.Subnet{{
+				Name: pointer.String("my-subnet"),
+			}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, client.Client)
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	ctx := context.TODO()
+
+	groups := resources.NewGroupsClientWithBaseURI(s.URL, fakeSubscriptionID)
+	groups.Authorizer = autorest.NullAuthorizer{}
+	_, err := groups.CreateOrUpdate(ctx, "my-rg", resources.Group{Location: pointer.String("westus")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(createVnet(ctx, s)).To(Succeed())
+
+	client := newVnetClient(s)
+	vnet, err := client.Get(ctx, "my-rg", "my-vnet", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*vnet.ID).To(Equal(vnetID))
+	g.Expect(vnet.ProvisioningState).To(Equal(network.ProvisioningStateSucceeded))
+	g.Expect((*vnet.AddressSpace.AddressPrefixes)[0]).To(Equal("10.0.0.0/8"))
+	g.Expect(s.ResourceIDs()).To(ConsistOf("/subscriptions/123/resourcegroups/my-rg", vnetID))
+
+	future, err := client.Delete(ctx, "my-rg", "my-vnet")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(future.WaitForCompletionRef(ctx, client.Client)).To(Succeed())
+	_, err = client.Get(ctx, "my-rg", "my-vnet", "")
+	g.Expect(isNotFound(err)).To(BeTrue())
+}
+
+func isNotFound(err error) bool {
+	derr, ok := err.(autorest.DetailedError)
+	return ok && derr.StatusCode == http.StatusNotFound
+}
+
+func TestFaults(t *testing.T) {
+	testcases := []struct {
+		name          string
+		fault         Fault
+		expectedError string
+		expectedState string
+	}{
+		{
+			name:          "slow operation completes",
+			fault:         SlowOperation("Microsoft.Network/virtualNetworks", 3),
+			expectedState: "Succeeded",
+		},
+		{
+			name:          "failed operation",
+			fault:         Fault{Method: http.MethodPut, ResourceType: "Microsoft.Network/virtualNetworks", ErrorCode: "InternalServerError"},
+			expectedError: "InternalServerError",
+			expectedState: "Failed",
+		},
+		{
+			name:          "fault for another resource type",
+			fault:         AllocationFailure(1),
+			expectedState: "Succeeded",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			s := NewServer()
+			defer s.Close()
+			s.Inject(tc.fault)
+
+			err := createVnet(context.TODO(), s)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(s.Resource(vnetID)["properties"]).To(HaveKeyWithValue("provisioningState", tc.expectedState))
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	s.Inject(Throttle("Microsoft.Network/virtualNetworks", 1, 5*time.Second))
+
+	// The SDK clients retry throttled requests, so send them directly to see the fault.
+	resp, err := http.Get(s.URL + vnetID)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	g.Expect(resp.Header.Get("Retry-After")).To(Equal("5"))
+
+	resp, err = http.Get(s.URL + vnetID)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+}
+
+func TestAllocationFailure(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	s.Inject(AllocationFailure(1))
+
+	client := compute.NewVirtualMachinesClientWithBaseURI(s.URL, fakeSubscriptionID)
+	client.Authorizer = autorest.NullAuthorizer{}
+	client.PollingDelay = time.Millisecond
+	vm := compute.VirtualMachine{Location: pointer.String("westus")}
+
+	future, err := client.CreateOrUpdate(context.TODO(), "my-rg", "my-vm", vm)
+	g.Expect(err).NotTo(HaveOccurred())
+	err = future.WaitForCompletionRef(context.TODO(), client.Client)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("AllocationFailed"))
+
+	// The fault only applies once, so retrying succeeds.
+	future, err = client.CreateOrUpdate(context.TODO(), "my-rg", "my-vm", vm)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(future.WaitForCompletionRef(context.TODO(), client.Client)).To(Succeed())
+}
+
+func TestWriteEnvironmentFile(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "env.json")
+	g.Expect(s.WriteEnvironmentFile(path)).To(Succeed())
+	env, err := azureautorest.EnvironmentFromFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(env.ResourceManagerEndpoint).To(Equal(s.URL + "/"))
+	g.Expect(env.ActiveDirectoryEndpoint).To(Equal(s.URL + "/"))
+}
+
+func TestParseResourceType(t *testing.T) {
+	testcases := []struct {
+		id           string
+		expectedType string
+		expectedOK   bool
+	}{
+		{id: "/subscriptions/123/resourceGroups/my-rg", expectedType: resourceGroupType, expectedOK: true},
+		{id: "/subscriptions/123/resourcegroups", expectedType: resourceGroupType, expectedOK: true},
+		{id: vnetID, expectedType: "Microsoft.Network/virtualNetworks", expectedOK: true},
+		{id: subnetID, expectedType: "Microsoft.Network/virtualNetworks/subnets", expectedOK: true},
+		{id: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines", expectedType: "Microsoft.Compute/virtualMachines", expectedOK: true},
+		{id: "/foo/bar", expectedOK: false},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.id, func(t *testing.T) {
+			g := NewWithT(t)
+			resourceType, ok := parseResourceType(tc.id)
+			g.Expect(ok).To(Equal(tc.expectedOK))
+			g.Expect(resourceType).To(Equal(tc.expectedType))
+		})
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify sends notifications to external systems when clusters and machines reach lifecycle milestones.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// EventType is the lifecycle milestone a notification is sent for.
+type EventType string
+
+const (
+	// InfrastructureReady is sent when the infrastructure of a cluster or machine becomes ready.
+	InfrastructureReady EventType = "InfrastructureReady"
+	// ProvisionFailed is sent when a machine fails to provision with a terminal error.
+	ProvisionFailed EventType = "ProvisionFailed"
+	// DeletionComplete is sent when the infrastructure of a cluster or machine has been deleted.
+	DeletionComplete EventType = "DeletionComplete"
+)
+
+// eventTypePrefix namespaces the event types sent to Event Grid.
+const eventTypePrefix = "io.k8s.sigs.cluster-api-provider-azure."
+
+// notifyTimeout is the maximum duration sending a notification can take, so that a slow endpoint doesn't hold up the
+// notifications queued after it.
+const notifyTimeout = 10 * time.Second
+
+// DefaultQueueSize is the default number of notifications waiting to be sent by a QueueNotifier.
+const DefaultQueueSize = 1000
+
+// Event is the payload of a notification.
+type Event struct {
+	ID          string    `json:"id"`
+	Type        EventType `json:"type"`
+	Time        time.Time `json:"time"`
+	Kind        string    `json:"kind"`
+	Namespace   string    `json:"namespace"`
+	Name        string    `json:"name"`
+	UID         string    `json:"uid"`
+	ClusterName string    `json:"clusterName,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// Subject returns the resource the event is about.
+func (e Event) Subject() string {
+	return fmt.Sprintf("/namespaces/%s/%s/%s", e.Namespace, e.Kind, e.Name)
+}
+
+// Notifier sends notifications to an external system.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+var (
+	initOnce        sync.Once
+	defaultNotifier Notifier = noopNotifier{}
+)
+
+// InitFromNotifier initializes the global default notifier. It can only be called once.
+// Subsequent calls are considered noops.
+func InitFromNotifier(notifier Notifier) {
+	initOnce.Do(func() {
+		defaultNotifier = notifier
+	})
+}
+
+// Send notifies the default notifier that obj of the given kind reached a lifecycle milestone.
+// Notifications are best effort: failures are logged and never fail reconciliation. The default notifier is expected to
+// return immediately, e.g. a QueueNotifier, since Send is called during reconciliation.
+func Send(ctx context.Context, kind string, obj metav1.Object, eventType EventType, message string) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "notify.Send")
+	defer done()
+
+	event := Event{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		Time:        time.Now().UTC(),
+		Kind:        kind,
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		UID:         string(obj.GetUID()),
+		ClusterName: obj.GetLabels()[clusterv1.ClusterNameLabel],
+		Message:     message,
+	}
+	if err := defaultNotifier.Notify(ctx, event); err != nil {
+		log.Error(err, "failed to send notification", "type", eventType, "subject", event.Subject())
+	}
+}
+
+// Pending holds the notifications of an object until its status is persisted. Sending them only once the object is
+// patched keeps a milestone from being notified twice when the patch fails and the next reconciliation reaches it again.
+type Pending struct {
+	events []pendingEvent
+}
+
+// pendingEvent is a notification held by Pending.
+type pendingEvent struct {
+	kind      string
+	obj       metav1.Object
+	eventType EventType
+	message   string
+}
+
+// Add holds a notification that obj of the given kind reached a lifecycle milestone.
+func (p *Pending) Add(kind string, obj metav1.Object, eventType EventType, message string) {
+	p.events = append(p.events, pendingEvent{kind: kind, obj: obj, eventType: eventType, message: message})
+}
+
+// Send sends the notifications held with Send, and clears them.
+func (p *Pending) Send(ctx context.Context) {
+	for _, e := range p.events {
+		Send(ctx, e.kind, e.obj, e.eventType, e.message)
+	}
+	p.events = nil
+}
+
+// noopNotifier is the default notifier when notifications are not configured.
+type noopNotifier struct{}
+
+// Notify does nothing.
+func (noopNotifier) Notify(context.Context, Event) error {
+	return nil
+}
+
+// QueueNotifier queues notifications and sends them in the background, so that reconciliation doesn't wait for the
+// notification endpoints. Notifications are dropped when the queue is full.
+type QueueNotifier struct {
+	notifier Notifier
+	queue    chan Event
+}
+
+// NewQueueNotifier creates a new QueueNotifier sending the notifications to notifier, holding at most size
+// notifications waiting to be sent. It only sends them once started with Start.
+func NewQueueNotifier(notifier Notifier, size int) *QueueNotifier {
+	return &QueueNotifier{
+		notifier: notifier,
+		queue:    make(chan Event, size),
+	}
+}
+
+// Notify queues the event, or returns an error if the queue is full.
+func (q *QueueNotifier) Notify(_ context.Context, event Event) error {
+	select {
+	case q.queue <- event:
+		return nil
+	default:
+		return errors.New("notification queue is full, dropping notification")
+	}
+}
+
+// Start sends the queued notifications until ctx is done. It implements manager.Runnable.
+func (q *QueueNotifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-q.queue:
+			q.send(ctx, event)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The queue is drained without waiting for the leader
+// election, so the notifications queued by a replica are sent even if it loses the leadership.
+func (q *QueueNotifier) NeedLeaderElection() bool {
+	return false
+}
+
+// send sends a queued notification, logging failures.
+func (q *QueueNotifier) send(ctx context.Context, event Event) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "notify.QueueNotifier.send")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	if err := q.notifier.Notify(ctx, event); err != nil {
+		log.Error(err, "failed to send notification", "type", event.Type, "subject", event.Subject())
+	}
+}
+
+// Notifiers sends a notification to each of its notifiers.
+type Notifiers []Notifier
+
+// Notify sends the event to all notifiers and returns the first error encountered.
+func (n Notifiers) Notify(ctx context.Context, event Event) error {
+	var firstErr error
+	for _, notifier := range n {
+		if err := notifier.Notify(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// WebhookNotifier posts events as JSON to a generic webhook.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Client: http.DefaultClient,
+	}
+}
+
+// Notify posts the event to the webhook.
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	return post(ctx, w.Client, w.URL, nil, event)
+}
+
+// EventGridNotifier publishes events to an Event Grid topic using the Event Grid event schema.
+type EventGridNotifier struct {
+	Endpoint string
+	Key      string
+	Client   *http.Client
+}
+
+// NewEventGridNotifier creates a new EventGridNotifier for the topic endpoint authenticated with an access key.
+func NewEventGridNotifier(endpoint, key string) *EventGridNotifier {
+	return &EventGridNotifier{
+		Endpoint: endpoint,
+		Key:      key,
+		Client:   http.DefaultClient,
+	}
+}
+
+// eventGridEvent is an event in the Event Grid event schema.
+type eventGridEvent struct {
+	ID          string    `json:"id"`
+	Subject     string    `json:"subject"`
+	EventType   string    `json:"eventType"`
+	EventTime   time.Time `json:"eventTime"`
+	Data        Event     `json:"data"`
+	DataVersion string    `json:"dataVersion"`
+}
+
+// Notify publishes the event to the Event Grid topic.
+func (e *EventGridNotifier) Notify(ctx context.Context, event Event) error {
+	events := []eventGridEvent{{
+		ID:          event.ID,
+		Subject:     event.Subject(),
+		EventType:   eventTypePrefix + string(event.Type),
+		EventTime:   event.Time,
+		Data:        event,
+		DataVersion: "1.0",
+	}}
+	return post(ctx, e.Client, e.Endpoint, map[string]string{"aeg-sas-key": e.Key}, events)
+}
+
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create notification request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send notification")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("notification endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
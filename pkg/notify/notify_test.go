@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var fakeEvent = Event{
+	ID:          "00000000-0000-0000-0000-000000000000",
+	Type:        InfrastructureReady,
+	Time:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	Kind:        "AzureCluster",
+	Namespace:   "default",
+	Name:        "my-cluster",
+	ClusterName: "my-cluster",
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	testcases := []struct {
+		name          string
+		status        int
+		expectedError string
+	}{
+		{
+			name:   "webhook accepts the event",
+			status: http.StatusOK,
+		},
+		{
+			name:          "webhook rejects the event",
+			status:        http.StatusInternalServerError,
+			expectedError: "notification endpoint returned status 500",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var received Event
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+				g.Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			err := NewWebhookNotifier(server.URL).Notify(context.TODO(), fakeEvent)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(received).To(Equal(fakeEvent))
+		})
+	}
+}
+
+func TestEventGridNotifier(t *testing.T) {
+	g := NewWithT(t)
+	var received []eventGridEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Header.Get("aeg-sas-key")).To(Equal("secret"))
+		g.Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+	}))
+	defer server.Close()
+
+	g.Expect(NewEventGridNotifier(server.URL, "secret").Notify(context.TODO(), fakeEvent)).To(Succeed())
+	g.Expect(received).To(HaveLen(1))
+	g.Expect(received[0].ID).To(Equal(fakeEvent.ID))
+	g.Expect(received[0].Subject).To(Equal("/namespaces/default/AzureCluster/my-cluster"))
+	g.Expect(received[0].EventType).To(Equal("io.k8s.sigs.cluster-api-provider-azure.InfrastructureReady"))
+	g.Expect(received[0].DataVersion).To(Equal("1.0"))
+	g.Expect(received[0].Data).To(Equal(fakeEvent))
+}
+
+// fakeNotifier records the events it is notified of.
+type fakeNotifier struct {
+	events chan Event
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, event Event) error {
+	f.events <- event
+	return nil
+}
+
+func TestQueueNotifier(t *testing.T) {
+	g := NewWithT(t)
+	fake := &fakeNotifier{events: make(chan Event, 2)}
+	q := NewQueueNotifier(fake, 1)
+
+	g.Expect(q.Notify(context.TODO(), fakeEvent)).To(Succeed())
+	g.Expect(q.Notify(context.TODO(), fakeEvent)).To(MatchError("notification queue is full, dropping notification"))
+	g.Expect(fake.events).To(BeEmpty())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = q.Start(ctx)
+	}()
+	g.Eventually(fake.events).Should(Receive(Equal(fakeEvent)))
+
+	g.Expect(q.Notify(context.TODO(), fakeEvent)).To(Succeed())
+	g.Eventually(fake.events).Should(Receive(Equal(fakeEvent)))
+}
+
+func TestPending(t *testing.T) {
+	g := NewWithT(t)
+	fake := &fakeNotifier{events: make(chan Event, 2)}
+	previous := defaultNotifier
+	defaultNotifier = fake
+	defer func() { defaultNotifier = previous }()
+
+	obj := &metav1.ObjectMeta{Namespace: "default", Name: "my-cluster"}
+	var pending Pending
+	pending.Add("AzureCluster", obj, InfrastructureReady, "cluster infrastructure is ready")
+	g.Expect(fake.events).To(BeEmpty())
+
+	pending.Send(context.TODO())
+	g.Expect(fake.events).To(Receive(SatisfyAll(
+		HaveField("Type", InfrastructureReady),
+		HaveField("Kind", "AzureCluster"),
+		HaveField("Name", "my-cluster"),
+	)))
+
+	// The notifications are only sent once.
+	pending.Send(context.TODO())
+	g.Expect(fake.events).To(BeEmpty())
+}
@@ -34,9 +34,17 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
 
-// RegisterTracing enables code tracing via OpenTelemetry.
-func RegisterTracing(ctx context.Context, log logr.Logger) error {
-	tp, err := otlpTracerProvider(ctx, "opentelemetry-collector:4317")
+// DefaultTracingEndpoint is the OTLP endpoint traces are exported to by default, the opentelemetry-collector service
+// in the same namespace.
+const DefaultTracingEndpoint = "opentelemetry-collector:4317"
+
+// RegisterTracing enables code tracing via OpenTelemetry, exporting the traces to the OTLP gRPC endpoint. The sampling
+// ratio is the share of the traces which are exported, from 0 to 1.
+func RegisterTracing(ctx context.Context, log logr.Logger, endpoint string, samplingRatio float64) error {
+	if samplingRatio < 0 || samplingRatio > 1 {
+		return errors.Errorf("invalid tracing sampling ratio %v, must be between 0 and 1", samplingRatio)
+	}
+	tp, err := otlpTracerProvider(ctx, endpoint, samplingRatio)
 	if err != nil {
 		return err
 	}
@@ -57,7 +65,7 @@ func RegisterTracing(ctx context.Context, log logr.Logger) error {
 }
 
 // otlpTracerProvider initializes an OTLP exporter and configures the corresponding tracer provider.
-func otlpTracerProvider(ctx context.Context, url string) (*sdktrace.TracerProvider, error) {
+func otlpTracerProvider(ctx context.Context, url string, samplingRatio float64) (*sdktrace.TracerProvider, error) {
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
 			semconv.ServiceNameKey.String("capz"),
@@ -78,9 +86,15 @@ func otlpTracerProvider(ctx context.Context, url string) (*sdktrace.TracerProvid
 		return nil, errors.Wrap(err, "failed to create otlp trace exporter")
 	}
 
+	sampler := sdktrace.AlwaysSample()
+	if samplingRatio < 1 {
+		// Follow the decision of the parent span so that traces are either exported entirely or not at all.
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))
+	}
+
 	bsp := sdktrace.NewBatchSpanProcessor(traceExporter)
 	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sampler),
 		sdktrace.WithResource(res),
 		sdktrace.WithSpanProcessor(bsp),
 	)
@@ -34,7 +34,8 @@ var (
 
 func init() {
 	defaultRecorder = new(record.FakeRecorder)
-	eng = cases.Title(language.English)
+	// NoLower keeps the case of the rest of CamelCase reasons, e.g. VMProvisioningFailed.
+	eng = cases.Title(language.English, cases.NoLower)
 }
 
 // InitFromRecorder initializes the global default recorder. It can only be called once.
@@ -64,3 +65,8 @@ func Warn(object runtime.Object, reason, message string) {
 func Warnf(object runtime.Object, reason, message string, args ...interface{}) {
 	defaultRecorder.Eventf(object, corev1.EventTypeWarning, eng.String(reason), message, args...)
 }
+
+// Emit constructs an event of the given type, either normal or warning, and puts it in the queue for sending.
+func Emit(object runtime.Object, eventType, reason, message string) {
+	defaultRecorder.Event(object, eventType, eng.String(reason), message)
+}
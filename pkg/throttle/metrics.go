@@ -0,0 +1,43 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttle
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	armRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capz_arm_requests_total",
+		Help: "Total number of Azure Resource Manager requests, by subscription, method and status code.",
+	}, []string{"subscription_id", "method", "code"})
+
+	armRemainingRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capz_arm_ratelimit_remaining_requests",
+		Help: "Remaining Azure Resource Manager requests of a subscription, by request type, as last reported by Azure.",
+	}, []string{"subscription_id", "request_type"})
+
+	throttledReconcilesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capz_arm_ratelimit_throttled_reconciles_total",
+		Help: "Total number of reconciles requeued because their subscription was close to exhausting its Azure Resource Manager requests.",
+	}, []string{"subscription_id"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(armRequestsTotal, armRemainingRequests, throttledReconcilesTotal)
+}
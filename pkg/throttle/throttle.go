@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package throttle tracks the remaining Azure Resource Manager requests of each subscription, as reported by the
+// x-ms-ratelimit-remaining-subscription-* response headers, and tells the controllers to hold off reconciling the
+// Azure resources of subscriptions which are about to exhaust them.
+package throttle
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+const (
+	// DefaultRemainingThreshold is the default number of remaining requests of a subscription below which its
+	// reconciles are throttled.
+	DefaultRemainingThreshold = 50
+	// throttleInterval is how long the reconciles of a subscription are throttled after its remaining requests went
+	// below the threshold.
+	throttleInterval = 1 * time.Minute
+)
+
+// requestTypeHeaders are the response headers with the remaining requests of a subscription, by request type.
+var requestTypeHeaders = map[string]string{
+	"reads":   "x-ms-ratelimit-remaining-subscription-reads",
+	"writes":  "x-ms-ratelimit-remaining-subscription-writes",
+	"deletes": "x-ms-ratelimit-remaining-subscription-deletes",
+}
+
+// remaining is the remaining requests of a subscription reported by its latest response.
+type remaining struct {
+	requests   int64
+	observedAt time.Time
+}
+
+// budgets tracks the request budgets of the subscriptions.
+type budgets struct {
+	mu  sync.Mutex
+	now func() time.Time
+	// remaining is the latest remaining requests of each subscription, by request type.
+	remaining map[string]map[string]remaining
+	// throttledUntil is the time until which Azure throttles the requests of each subscription.
+	throttledUntil map[string]time.Time
+}
+
+func newBudgets() *budgets {
+	return &budgets{
+		now:            time.Now,
+		remaining:      make(map[string]map[string]remaining),
+		throttledUntil: make(map[string]time.Time),
+	}
+}
+
+var defaultBudgets = newBudgets()
+
+// Observe records the remaining requests of the subscription of an Azure Resource Manager response.
+func Observe(resp *http.Response) {
+	defaultBudgets.observe(resp)
+}
+
+// RequeueAfter returns how long to wait before reconciling the Azure resources of a subscription, and whether its
+// reconciles are throttled. Reconciles are throttled while Azure throttles the requests of the subscription, and for
+// a while after the subscription reported fewer remaining requests than threshold. A threshold of zero only throttles
+// the reconciles of subscriptions whose requests are being throttled by Azure.
+func RequeueAfter(subscriptionID string, threshold int64) (time.Duration, bool) {
+	return defaultBudgets.requeueAfter(subscriptionID, threshold)
+}
+
+func (b *budgets) observe(resp *http.Response) {
+	if resp == nil || resp.Request == nil || resp.Request.URL == nil {
+		return
+	}
+	subscriptionID := subscriptionIDFromPath(resp.Request.URL.Path)
+	if subscriptionID == "" {
+		return
+	}
+	armRequestsTotal.WithLabelValues(subscriptionID, resp.Request.Method, strconv.Itoa(resp.StatusCode)).Inc()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for requestType, header := range requestTypeHeaders {
+		requests, err := strconv.ParseInt(resp.Header.Get(header), 10, 64)
+		if err != nil {
+			continue
+		}
+		armRemainingRequests.WithLabelValues(subscriptionID, requestType).Set(float64(requests))
+		if b.remaining[subscriptionID] == nil {
+			b.remaining[subscriptionID] = make(map[string]remaining)
+		}
+		b.remaining[subscriptionID][requestType] = remaining{requests: requests, observedAt: b.now()}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := reconciler.DefaultHTTP429RetryAfter
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		b.throttle(subscriptionID, retryAfter)
+	}
+}
+
+// throttle throttles the reconciles of a subscription for at least the given duration. b.mu must be held.
+func (b *budgets) throttle(subscriptionID string, duration time.Duration) {
+	until := b.now().Add(duration)
+	if until.After(b.throttledUntil[subscriptionID]) {
+		b.throttledUntil[subscriptionID] = until
+	}
+}
+
+func (b *budgets) requeueAfter(subscriptionID string, threshold int64) (time.Duration, bool) {
+	subscriptionID = strings.ToLower(subscriptionID)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	until := b.throttledUntil[subscriptionID]
+	if !until.After(now) {
+		delete(b.throttledUntil, subscriptionID)
+	}
+	for requestType, r := range b.remaining[subscriptionID] {
+		lowUntil := r.observedAt.Add(throttleInterval)
+		if !lowUntil.After(now) {
+			delete(b.remaining[subscriptionID], requestType)
+			continue
+		}
+		if r.requests < threshold && lowUntil.After(until) {
+			until = lowUntil
+		}
+	}
+
+	wait := until.Sub(now)
+	if wait <= 0 {
+		return 0, false
+	}
+	throttledReconcilesTotal.WithLabelValues(subscriptionID).Inc()
+	return wait, true
+}
+
+// subscriptionIDFromPath returns the lowercase subscription ID of an Azure Resource Manager request path, or an empty
+// string if the path is not scoped to a subscription.
+func subscriptionIDFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return ""
+	}
+	return strings.ToLower(segments[1])
+}
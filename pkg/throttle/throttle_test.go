@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package throttle
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+func response(path string, statusCode int, header map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{Path: path},
+		},
+	}
+	for key, value := range header {
+		resp.Header.Set(key, value)
+	}
+	return resp
+}
+
+func TestRequeueAfter(t *testing.T) {
+	const path = "/subscriptions/ABC-123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		threshold         int64
+		responses         []*http.Response
+		expectedWait      time.Duration
+		expectedThrottled bool
+	}{
+		{
+			name:      "subscription with enough remaining requests is not throttled",
+			threshold: DefaultRemainingThreshold,
+			responses: []*http.Response{response(path, http.StatusOK, map[string]string{"x-ms-ratelimit-remaining-subscription-reads": "11999"})},
+		},
+		{
+			name:              "subscription close to exhausting its writes is throttled",
+			threshold:         DefaultRemainingThreshold,
+			responses:         []*http.Response{response(path, http.StatusOK, map[string]string{"x-ms-ratelimit-remaining-subscription-writes": "10"})},
+			expectedWait:      throttleInterval,
+			expectedThrottled: true,
+		},
+		{
+			name:      "subscription close to exhausting its writes is not throttled with a zero threshold",
+			responses: []*http.Response{response(path, http.StatusOK, map[string]string{"x-ms-ratelimit-remaining-subscription-writes": "10"})},
+		},
+		{
+			name:      "a later response with enough remaining requests ends the throttling",
+			threshold: DefaultRemainingThreshold,
+			responses: []*http.Response{
+				response(path, http.StatusOK, map[string]string{"x-ms-ratelimit-remaining-subscription-writes": "10"}),
+				response(path, http.StatusOK, map[string]string{"x-ms-ratelimit-remaining-subscription-writes": "1199"}),
+			},
+		},
+		{
+			name:              "throttled response uses its Retry-After",
+			threshold:         DefaultRemainingThreshold,
+			responses:         []*http.Response{response(path, http.StatusTooManyRequests, map[string]string{"Retry-After": "300"})},
+			expectedWait:      5 * time.Minute,
+			expectedThrottled: true,
+		},
+		{
+			name:              "throttled response without Retry-After uses the default",
+			threshold:         DefaultRemainingThreshold,
+			responses:         []*http.Response{response(path, http.StatusTooManyRequests, nil)},
+			expectedWait:      reconciler.DefaultHTTP429RetryAfter,
+			expectedThrottled: true,
+		},
+		{
+			name:      "a later response with enough remaining requests doesn't shorten the throttling",
+			threshold: DefaultRemainingThreshold,
+			responses: []*http.Response{
+				response(path, http.StatusTooManyRequests, map[string]string{"Retry-After": "300"}),
+				response(path, http.StatusOK, map[string]string{"x-ms-ratelimit-remaining-subscription-reads": "11999"}),
+			},
+			expectedWait:      5 * time.Minute,
+			expectedThrottled: true,
+		},
+		{
+			name:      "response not scoped to a subscription is ignored",
+			threshold: DefaultRemainingThreshold,
+			responses: []*http.Response{response("/providers/Microsoft.Compute/operations", http.StatusTooManyRequests, nil)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			b := newBudgets()
+			b.now = func() time.Time { return now }
+
+			for _, resp := range tt.responses {
+				b.observe(resp)
+			}
+			wait, throttled := b.requeueAfter("abc-123", tt.threshold)
+			g.Expect(throttled).To(Equal(tt.expectedThrottled))
+			g.Expect(wait).To(Equal(tt.expectedWait))
+
+			// the throttling ends once the wait is over
+			b.now = func() time.Time { return now.Add(tt.expectedWait) }
+			_, throttled = b.requeueAfter("ABC-123", tt.threshold)
+			g.Expect(throttled).To(BeFalse())
+		})
+	}
+}
@@ -22,7 +22,7 @@ package e2e
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-03-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2022-07-01/containerservice"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -68,10 +68,10 @@ func AKSAzureClusterAutoscalerSettingsSpec(ctx context.Context, inputGetter func
 
 		// Conditional is based off of the actual AKS settings not the AzureManagedControlPlane
 		if aksInitialAutoScalerProfile == nil {
-			expectedAksExpander = containerservice.ExpanderLeastWaste
+			expectedAksExpander = containerservice.LeastWaste
 			newExpanderValue = infrav1.ExpanderLeastWaste
-		} else if aksInitialAutoScalerProfile.Expander == containerservice.ExpanderLeastWaste {
-			expectedAksExpander = containerservice.ExpanderMostPods
+		} else if aksInitialAutoScalerProfile.Expander == containerservice.LeastWaste {
+			expectedAksExpander = containerservice.MostPods
 			newExpanderValue = infrav1.ExpanderMostPods
 		}
 
@@ -18,13 +18,17 @@ package futures
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
 const fakeFutureType = "PUT"
 
+var fakeStartTime = metav1.NewTime(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC))
+
 func TestGet(t *testing.T) {
 	g := NewWithT(t)
 
@@ -75,5 +79,6 @@ func fakeFuture(name string, service string) infrav1.Future {
 		ResourceGroup: "test-rg",
 		Data:          "",
 		ServiceName:   service,
+		StartTime:     &fakeStartTime,
 	}
 }
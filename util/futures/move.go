@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package futures
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// InFlight returns true if the object has long running operations in progress.
+func InFlight(from Getter) bool {
+	return len(from.GetFutures()) > 0
+}
+
+// SetBlockMoveAnnotation adds the clusterctl block-move annotation to the object while it has long running
+// operations in progress and removes it once they complete, so the futures aren't lost by moving the object
+// to another management cluster.
+func SetBlockMoveAnnotation(to Getter) {
+	annotations := to.GetAnnotations()
+	if InFlight(to) {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[azure.BlockMoveAnnotation] = "true"
+		to.SetAnnotations(annotations)
+		return
+	}
+	if _, ok := annotations[azure.BlockMoveAnnotation]; ok {
+		delete(annotations, azure.BlockMoveAnnotation)
+		to.SetAnnotations(annotations)
+	}
+}
+
+// Checkpoint copies the long running operations in progress of the object to the futures checkpoint annotation and
+// removes the annotation once they complete, so the operations survive the loss of the status of the object, for
+// example when it is moved to another management cluster.
+func Checkpoint(to Getter) error {
+	annotations := to.GetAnnotations()
+	if !InFlight(to) {
+		if _, ok := annotations[azure.FuturesCheckpointAnnotation]; ok {
+			delete(annotations, azure.FuturesCheckpointAnnotation)
+			to.SetAnnotations(annotations)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(to.GetFutures())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal long running operation states")
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[azure.FuturesCheckpointAnnotation] = string(data)
+	to.SetAnnotations(annotations)
+	return nil
+}
+
+// Restore sets the long running operations of the object from its futures checkpoint annotation when its status has
+// none, e.g. after the object was moved to another management cluster. It returns true if operations were restored.
+// An invalid annotation is ignored, and replaced by the next Checkpoint.
+func Restore(to Setter) bool {
+	data, ok := to.GetAnnotations()[azure.FuturesCheckpointAnnotation]
+	if !ok || InFlight(to) {
+		return false
+	}
+
+	var checkpoint infrav1.Futures
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil || len(checkpoint) == 0 {
+		return false
+	}
+	to.SetFutures(checkpoint)
+	return true
+}
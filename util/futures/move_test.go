@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package futures
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+func TestSetBlockMoveAnnotation(t *testing.T) {
+	g := NewWithT(t)
+
+	azurecluster := &infrav1.AzureCluster{}
+	azurecluster.SetAnnotations(map[string]string{"foo": "bar"})
+
+	SetBlockMoveAnnotation(azurecluster)
+	g.Expect(InFlight(azurecluster)).To(BeFalse())
+	g.Expect(azurecluster.GetAnnotations()).NotTo(HaveKey(azure.BlockMoveAnnotation))
+
+	Set(azurecluster, &infrav1.Future{Name: "my-vnet", ServiceName: "virtualnetworks", Type: fakeFutureType})
+	SetBlockMoveAnnotation(azurecluster)
+	g.Expect(InFlight(azurecluster)).To(BeTrue())
+	g.Expect(azurecluster.GetAnnotations()).To(HaveKeyWithValue(azure.BlockMoveAnnotation, "true"))
+
+	Delete(azurecluster, "my-vnet", "virtualnetworks", fakeFutureType)
+	SetBlockMoveAnnotation(azurecluster)
+	g.Expect(InFlight(azurecluster)).To(BeFalse())
+	g.Expect(azurecluster.GetAnnotations()).To(Equal(map[string]string{"foo": "bar"}))
+}
+
+func TestCheckpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	azurecluster := &infrav1.AzureCluster{}
+	azurecluster.SetAnnotations(map[string]string{"foo": "bar"})
+
+	g.Expect(Checkpoint(azurecluster)).To(Succeed())
+	g.Expect(azurecluster.GetAnnotations()).NotTo(HaveKey(azure.FuturesCheckpointAnnotation))
+
+	Set(azurecluster, &infrav1.Future{Name: "my-vnet", ServiceName: "virtualnetworks", Type: fakeFutureType, Data: "ZmFrZSBiNjQgZnV0dXJlIGRhdGEK", StartTime: &fakeStartTime})
+	g.Expect(Checkpoint(azurecluster)).To(Succeed())
+	g.Expect(azurecluster.GetAnnotations()).To(HaveKey(azure.FuturesCheckpointAnnotation))
+	var checkpoint infrav1.Futures
+	g.Expect(json.Unmarshal([]byte(azurecluster.GetAnnotations()[azure.FuturesCheckpointAnnotation]), &checkpoint)).To(Succeed())
+	g.Expect(checkpoint).To(BeComparableTo(azurecluster.GetFutures()))
+
+	Delete(azurecluster, "my-vnet", "virtualnetworks", fakeFutureType)
+	g.Expect(Checkpoint(azurecluster)).To(Succeed())
+	g.Expect(azurecluster.GetAnnotations()).To(Equal(map[string]string{"foo": "bar"}))
+}
+
+func TestRestore(t *testing.T) {
+	future := &infrav1.Future{Name: "my-vnet", ServiceName: "virtualnetworks", Type: fakeFutureType, Data: "ZmFrZSBiNjQgZnV0dXJlIGRhdGEK", StartTime: &fakeStartTime}
+	checkpointed := &infrav1.AzureCluster{}
+	Set(checkpointed, future)
+	if err := Checkpoint(checkpointed); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint := checkpointed.GetAnnotations()[azure.FuturesCheckpointAnnotation]
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		futures     infrav1.Futures
+		expected    bool
+	}{
+		{
+			name:     "no annotation",
+			expected: false,
+		},
+		{
+			name:        "futures restored from the annotation",
+			annotations: map[string]string{azure.FuturesCheckpointAnnotation: checkpoint},
+			expected:    true,
+		},
+		{
+			name:        "futures in status are kept",
+			annotations: map[string]string{azure.FuturesCheckpointAnnotation: checkpoint},
+			futures:     infrav1.Futures{{Name: "my-subnet", ServiceName: "subnets", Type: fakeFutureType}},
+			expected:    false,
+		},
+		{
+			name:        "invalid annotation is ignored",
+			annotations: map[string]string{azure.FuturesCheckpointAnnotation: "not json"},
+			expected:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			azurecluster := &infrav1.AzureCluster{}
+			azurecluster.SetAnnotations(tt.annotations)
+			azurecluster.SetFutures(tt.futures)
+
+			g.Expect(Restore(azurecluster)).To(Equal(tt.expected))
+			if tt.expected {
+				g.Expect(azurecluster.GetFutures()).To(BeComparableTo(infrav1.Futures{*future}))
+			} else {
+				g.Expect(azurecluster.GetFutures()).To(Equal(tt.futures))
+			}
+		})
+	}
+}
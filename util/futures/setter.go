@@ -17,6 +17,7 @@ limitations under the License.
 package futures
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
@@ -30,11 +31,21 @@ type Setter interface {
 // Set sets the given future.
 //
 // NOTE: If a future already exists, we update it.
+// A future without a start time keeps the start time of the future of the same type it updates, or starts now.
 func Set(to Setter, future *infrav1.Future) {
 	if to == nil || future == nil {
 		return
 	}
 
+	if future.StartTime == nil {
+		startTime := metav1.Now()
+		if existing := Get(to, future.Name, future.ServiceName, future.Type); existing != nil && existing.StartTime != nil {
+			startTime = *existing.StartTime
+		}
+		future = future.DeepCopy()
+		future.StartTime = &startTime
+	}
+
 	// Check if the new future already exists, and update it if it does.
 	futures := to.GetFutures()
 	exists := false
@@ -18,6 +18,7 @@ package futures
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -73,6 +74,29 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestSetStartTime(t *testing.T) {
+	g := NewWithT(t)
+	a := fakeFuture("a", "test-service")
+	to := setterWithFutures(infrav1.Futures{a})
+
+	// an update of an ongoing operation keeps its start time
+	update := a
+	update.StartTime = nil
+	update.Data = "new"
+	Set(to, &update)
+	g.Expect(Get(to, "a", "test-service", fakeFutureType).StartTime).To(Equal(&fakeStartTime))
+	g.Expect(update.StartTime).To(BeNil())
+
+	// a new operation starts now
+	deleteFuture := a
+	deleteFuture.StartTime = nil
+	deleteFuture.Type = infrav1.DeleteFuture
+	Set(to, &deleteFuture)
+	startTime := Get(to, "a", "test-service", infrav1.DeleteFuture).StartTime
+	g.Expect(startTime).NotTo(BeNil())
+	g.Expect(startTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+}
+
 func TestDelete(t *testing.T) {
 	testService := "test-service"
 	a := fakeFuture("a", testService)
@@ -43,3 +43,37 @@ func DefaultedLoopTimeout(timeout time.Duration) time.Duration {
 
 	return timeout
 }
+
+// Timeouts are the timeouts of the reconciles of the Azure services. Timeouts which are zero or negative are ignored.
+type Timeouts struct {
+	// AzureServiceReconcile is the timeout of the reconcile of the Azure services without a timeout of their own.
+	// DefaultAzureServiceReconcileTimeout is used when it is not set.
+	AzureServiceReconcile time.Duration
+	// AzureServiceReconciles are the timeouts of the reconciles of specific Azure services, keyed by service name.
+	AzureServiceReconciles map[string]time.Duration
+}
+
+// AzureServiceReconcileTimeout returns the timeout for the reconcile of an Azure service.
+func (t Timeouts) AzureServiceReconcileTimeout(serviceName string) time.Duration {
+	if timeout := t.AzureServiceReconciles[serviceName]; timeout > 0 {
+		return timeout
+	}
+	if t.AzureServiceReconcile > 0 {
+		return t.AzureServiceReconcile
+	}
+	return DefaultAzureServiceReconcileTimeout
+}
+
+// TimeoutsGetter is a scope which sets the timeouts of the reconciles of its Azure services.
+type TimeoutsGetter interface {
+	AzureServiceReconcileTimeout(serviceName string) time.Duration
+}
+
+// AzureServiceReconcileTimeout returns the timeout for the reconcile of an Azure service of a scope. It is
+// DefaultAzureServiceReconcileTimeout unless the scope is a TimeoutsGetter.
+func AzureServiceReconcileTimeout(scope interface{}, serviceName string) time.Duration {
+	if getter, ok := scope.(TimeoutsGetter); ok {
+		return getter.AzureServiceReconcileTimeout(serviceName)
+	}
+	return DefaultAzureServiceReconcileTimeout
+}
@@ -56,3 +56,24 @@ func TestDefaultedTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureServiceReconcileTimeout(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(reconciler.Timeouts{}.AzureServiceReconcileTimeout("virtualnetworkgateways")).To(gomega.Equal(reconciler.DefaultAzureServiceReconcileTimeout))
+
+	timeouts := reconciler.Timeouts{
+		AzureServiceReconcile: 30 * time.Second,
+		AzureServiceReconciles: map[string]time.Duration{
+			"virtualnetworkgateways": 5 * time.Minute,
+			"subnets":                0,
+		},
+	}
+	g.Expect(timeouts.AzureServiceReconcileTimeout("virtualnetworkgateways")).To(gomega.Equal(5 * time.Minute))
+	g.Expect(timeouts.AzureServiceReconcileTimeout("subnets")).To(gomega.Equal(30 * time.Second))
+	g.Expect(timeouts.AzureServiceReconcileTimeout("virtualmachine")).To(gomega.Equal(30 * time.Second))
+
+	// scopes which don't set the timeouts of their services use the default timeout
+	g.Expect(reconciler.AzureServiceReconcileTimeout(struct{}{}, "virtualnetworkgateways")).To(gomega.Equal(reconciler.DefaultAzureServiceReconcileTimeout))
+	g.Expect(reconciler.AzureServiceReconcileTimeout(timeouts, "virtualnetworkgateways")).To(gomega.Equal(5 * time.Minute))
+}
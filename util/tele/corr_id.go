@@ -18,9 +18,12 @@ package tele
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // CorrIDKey is the type of the key used to store correlation
@@ -31,6 +34,10 @@ type CorrIDKey string
 // context.Contexts, HTTP headers, and other similar locations.
 const CorrIDKeyVal CorrIDKey = "x-ms-correlation-request-id"
 
+// RequestIDKeyVal is the HTTP header in which Azure returns the ID
+// it assigned to a request.
+const RequestIDKeyVal = "x-ms-request-id"
+
 // CorrID is a correlation ID that the cluster API provider
 // sends with all API requests to Azure. Do not create one
 // of these manually. Instead, use the CtxWithCorrelationID function
@@ -92,3 +99,28 @@ func corrIDLogger(ctx context.Context, lggr logr.Logger) logr.Logger {
 	}
 	return lggr
 }
+
+// RecordAzureResponse adds an event with the request and correlation
+// IDs returned by Azure to the span in the given ctx, so that the span
+// can be matched with the ARM operations it triggered. Azure returns
+// the correlation ID of the request, or generates one if the request
+// had none.
+func RecordAzureResponse(ctx context.Context, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	attrs := []attribute.KeyValue{
+		attribute.Int("http.status_code", resp.StatusCode),
+	}
+	if requestID := resp.Header.Get(RequestIDKeyVal); requestID != "" {
+		attrs = append(attrs, attribute.String(RequestIDKeyVal, requestID))
+	}
+	if corrID := resp.Header.Get(string(CorrIDKeyVal)); corrID != "" {
+		attrs = append(attrs, attribute.String(string(CorrIDKeyVal), corrID))
+	}
+	span.AddEvent("Azure response", trace.WithAttributes(attrs...))
+}